@@ -0,0 +1,78 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/dbverify"
+	"github.com/hyperledger/firefly/internal/namespace"
+	"github.com/spf13/cobra"
+)
+
+var dbCommand = &cobra.Command{
+	Use:   "db",
+	Short: "Work directly with the database of a namespace",
+}
+
+var dbVerifyCommand = &cobra.Command{
+	Use:   "verify <namespace>",
+	Short: "Check the database of a namespace for inconsistencies",
+	Long: `Walk the records of a namespace and report any that are inconsistent with each other, as a JSON
+report written to stdout. The command exits with an error if any inconsistencies are found.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return verifyDatabase(context.Background(), args[0])
+	},
+}
+
+func init() {
+	dbCommand.AddCommand(dbVerifyCommand)
+	rootCmd.AddCommand(dbCommand)
+}
+
+func getDatabaseVerifier(ctx context.Context, ns string) (dbverify.Verifier, error) {
+	if err := reloadConfig(); err != nil {
+		return nil, i18n.WrapError(ctx, err, i18n.MsgConfigFailed)
+	}
+	di, err := namespace.OpenDatabase(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	return dbverify.NewVerifier(ctx, ns, di)
+}
+
+func verifyDatabase(ctx context.Context, ns string) error {
+	verifier, err := getDatabaseVerifier(ctx, ns)
+	if err != nil {
+		return err
+	}
+	report, err := verifier.Verify(ctx)
+	if err != nil {
+		return err
+	}
+	b, _ := json.MarshalIndent(report, "", "  ")
+	fmt.Println(string(b))
+	if len(report.Issues) > 0 {
+		return i18n.NewError(ctx, coremsgs.MsgDatabaseVerifyIssues, len(report.Issues), ns)
+	}
+	return nil
+}
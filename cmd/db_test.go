@@ -0,0 +1,75 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/namespace"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseVerify(t *testing.T) {
+	cfgFile = writeNamespaceTestConfig(t)
+	defer func() { cfgFile = "" }()
+
+	rootCmd.SetArgs([]string{"db", "verify", "ns1"})
+	defer rootCmd.SetArgs([]string{})
+	err := rootCmd.Execute()
+	assert.NoError(t, err)
+}
+
+func TestDatabaseVerifyIssues(t *testing.T) {
+	cfgFile = writeNamespaceTestConfig(t)
+	defer func() { cfgFile = "" }()
+
+	ctx := context.Background()
+	err := reloadConfig()
+	assert.NoError(t, err)
+	di, err := namespace.OpenDatabase(ctx, "ns1")
+	assert.NoError(t, err)
+	err = di.InsertTransaction(ctx, &core.Transaction{ID: fftypes.NewUUID(), Namespace: "ns1", Type: core.TransactionTypeContractInvoke})
+	assert.NoError(t, err)
+
+	err = verifyDatabase(ctx, "ns1")
+	assert.Regexp(t, "FF10538", err)
+}
+
+func TestDatabaseVerifyBadConfig(t *testing.T) {
+	cfgFile = filepath.Join(t.TempDir(), "missing.yaml")
+	defer func() { cfgFile = "" }()
+	err := verifyDatabase(context.Background(), "ns1")
+	assert.Regexp(t, "FF00101", err)
+}
+
+func TestDatabaseVerifyUnknownNamespace(t *testing.T) {
+	cfgFile = writeNamespaceTestConfig(t)
+	defer func() { cfgFile = "" }()
+	err := verifyDatabase(context.Background(), "ns2")
+	assert.Regexp(t, "FF10536", err)
+}
+
+func TestDatabaseVerifyFail(t *testing.T) {
+	cfgFile = writeUnmigratedTestConfig(t)
+	defer func() { cfgFile = "" }()
+	err := verifyDatabase(context.Background(), "ns1")
+	assert.Regexp(t, "FF00176", err)
+}
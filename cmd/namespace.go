@@ -0,0 +1,135 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/namespace"
+	"github.com/hyperledger/firefly/internal/nsarchive"
+	"github.com/spf13/cobra"
+)
+
+var archiveFile string
+var verifyOnly bool
+
+var namespaceCommand = &cobra.Command{
+	Use:     "namespace",
+	Aliases: []string{"ns"},
+	Short:   "Export and import the contents of a namespace",
+	Long: `Export everything stored for a namespace to a portable archive, and import that archive
+into the database of another FireFly node. The namespace must be defined in the configuration
+of both nodes, and FireFly should not be running against either database while the command runs.`,
+}
+
+var namespaceExportCommand = &cobra.Command{
+	Use:   "export <namespace>",
+	Short: "Export the contents of a namespace to an archive file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return exportNamespace(context.Background(), args[0], archiveFile)
+	},
+}
+
+var namespaceImportCommand = &cobra.Command{
+	Use:   "import <namespace>",
+	Short: "Import the contents of a namespace from an archive file, into an empty namespace",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return importNamespace(context.Background(), args[0], archiveFile, verifyOnly)
+	},
+}
+
+func init() {
+	namespaceExportCommand.Flags().StringVarP(&archiveFile, "output", "o", "", "archive file to write")
+	_ = namespaceExportCommand.MarkFlagRequired("output")
+	namespaceImportCommand.Flags().StringVarP(&archiveFile, "input", "i", "", "archive file to read")
+	_ = namespaceImportCommand.MarkFlagRequired("input")
+	namespaceImportCommand.Flags().BoolVar(&verifyOnly, "verify", false, "check the archive without importing it")
+	namespaceCommand.AddCommand(namespaceExportCommand)
+	namespaceCommand.AddCommand(namespaceImportCommand)
+	rootCmd.AddCommand(namespaceCommand)
+}
+
+func getNamespaceArchiver(ctx context.Context, ns string) (nsarchive.Archiver, error) {
+	if err := reloadConfig(); err != nil {
+		return nil, i18n.WrapError(ctx, err, i18n.MsgConfigFailed)
+	}
+	di, err := namespace.OpenDatabase(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	return nsarchive.NewArchiver(ctx, ns, di)
+}
+
+func printArchiveSummary(summary *nsarchive.Summary) {
+	b, _ := json.MarshalIndent(summary, "", "  ")
+	fmt.Println(string(b))
+}
+
+func exportNamespace(ctx context.Context, ns, filename string) error {
+	archiver, err := getNamespaceArchiver(ctx, ns)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	summary, err := archiver.Export(ctx, f)
+	if err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	printArchiveSummary(summary)
+	return nil
+}
+
+func importNamespace(ctx context.Context, ns, filename string, verifyOnly bool) error {
+	archiver, err := getNamespaceArchiver(ctx, ns)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// Check the whole archive before writing anything
+	summary, err := archiver.Verify(ctx, f)
+	if err != nil {
+		return err
+	}
+	if !verifyOnly {
+		if _, err := f.Seek(0, 0); err != nil {
+			return err
+		}
+		if summary, err = archiver.Import(ctx, f); err != nil {
+			return err
+		}
+	}
+	printArchiveSummary(summary)
+	return nil
+}
@@ -0,0 +1,196 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/namespace"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeNamespaceTestConfig writes a config with a single namespace, backed by a new sqlite database
+func writeNamespaceTestConfig(t *testing.T) string {
+	_, thisFile, _, _ := runtime.Caller(0)
+	migrations := filepath.Join(filepath.Dir(thisFile), "..", "db", "migrations", "sqlite")
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "firefly.core.yaml")
+	err := os.WriteFile(configFile, []byte(fmt.Sprintf(`
+plugins:
+  database:
+  - name: database0
+    type: sqlite3
+    sqlite3:
+      url: %s
+      migrations:
+        auto: true
+        directory: %s
+namespaces:
+  predefined:
+  - name: ns1
+`, filepath.Join(dir, "firefly.sqlite"), migrations)), 0600)
+	assert.NoError(t, err)
+	return configFile
+}
+
+// writeUnmigratedTestConfig writes a config like writeNamespaceTestConfig, where the database has no tables
+func writeUnmigratedTestConfig(t *testing.T) string {
+	configFile := writeNamespaceTestConfig(t)
+	b, err := os.ReadFile(configFile)
+	assert.NoError(t, err)
+	err = os.WriteFile(configFile, []byte(strings.Replace(string(b), "auto: true", "auto: false", 1)), 0600)
+	assert.NoError(t, err)
+	return configFile
+}
+
+// exportTestNamespace exports ns1, after storing a transaction in it so the namespace is not empty
+func exportTestNamespace(t *testing.T) string {
+	ctx := context.Background()
+	err := reloadConfig()
+	assert.NoError(t, err)
+	di, err := namespace.OpenDatabase(ctx, "ns1")
+	assert.NoError(t, err)
+	err = di.InsertTransaction(ctx, &core.Transaction{ID: fftypes.NewUUID(), Namespace: "ns1", Type: core.TransactionTypeContractInvoke})
+	assert.NoError(t, err)
+	archive := filepath.Join(t.TempDir(), "ns1.ffns.gz")
+	err = exportNamespace(ctx, "ns1", archive)
+	assert.NoError(t, err)
+	return archive
+}
+
+func TestNamespaceExportImport(t *testing.T) {
+	cfgFile = writeNamespaceTestConfig(t)
+	defer func() { cfgFile = "" }()
+	archive := filepath.Join(t.TempDir(), "ns1.ffns.gz")
+
+	rootCmd.SetArgs([]string{"namespace", "export", "ns1", "-o", archive})
+	defer rootCmd.SetArgs([]string{})
+	err := rootCmd.Execute()
+	assert.NoError(t, err)
+
+	rootCmd.SetArgs([]string{"namespace", "import", "ns1", "-i", archive, "--verify"})
+	err = rootCmd.Execute()
+	assert.NoError(t, err)
+
+	// The namespace is empty, so the archive can be imported back into the same database
+	err = importNamespace(context.Background(), "ns1", archive, false)
+	assert.NoError(t, err)
+}
+
+func TestNamespaceExportBadConfig(t *testing.T) {
+	cfgFile = filepath.Join(t.TempDir(), "missing.yaml")
+	defer func() { cfgFile = "" }()
+	err := exportNamespace(context.Background(), "ns1", filepath.Join(t.TempDir(), "ns1.ffns.gz"))
+	assert.Regexp(t, "FF00101", err)
+}
+
+func TestNamespaceExportUnknownNamespace(t *testing.T) {
+	cfgFile = writeNamespaceTestConfig(t)
+	defer func() { cfgFile = "" }()
+	err := exportNamespace(context.Background(), "ns2", filepath.Join(t.TempDir(), "ns2.ffns.gz"))
+	assert.Regexp(t, "FF10536", err)
+}
+
+func TestNamespaceExportBadFile(t *testing.T) {
+	cfgFile = writeNamespaceTestConfig(t)
+	defer func() { cfgFile = "" }()
+	err := exportNamespace(context.Background(), "ns1", t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestNamespaceExportFail(t *testing.T) {
+	cfgFile = writeUnmigratedTestConfig(t)
+	defer func() { cfgFile = "" }()
+	err := exportNamespace(context.Background(), "ns1", filepath.Join(t.TempDir(), "ns1.ffns.gz"))
+	assert.Regexp(t, "FF00176", err)
+}
+
+func TestNamespaceExportSyncFail(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("relies on fsync of /dev/null failing on Linux")
+	}
+	cfgFile = writeNamespaceTestConfig(t)
+	defer func() { cfgFile = "" }()
+	err := exportNamespace(context.Background(), "ns1", os.DevNull)
+	assert.Error(t, err)
+}
+
+func TestNamespaceImportUnknownNamespace(t *testing.T) {
+	cfgFile = writeNamespaceTestConfig(t)
+	defer func() { cfgFile = "" }()
+	err := importNamespace(context.Background(), "ns2", filepath.Join(t.TempDir(), "ns2.ffns.gz"), false)
+	assert.Regexp(t, "FF10536", err)
+}
+
+func TestNamespaceImportMissingFile(t *testing.T) {
+	cfgFile = writeNamespaceTestConfig(t)
+	defer func() { cfgFile = "" }()
+	err := importNamespace(context.Background(), "ns1", filepath.Join(t.TempDir(), "ns1.ffns.gz"), false)
+	assert.Error(t, err)
+}
+
+func TestNamespaceImportInvalidArchive(t *testing.T) {
+	cfgFile = writeNamespaceTestConfig(t)
+	defer func() { cfgFile = "" }()
+	archive := filepath.Join(t.TempDir(), "ns1.ffns.gz")
+	err := os.WriteFile(archive, []byte("not an archive"), 0600)
+	assert.NoError(t, err)
+	err = importNamespace(context.Background(), "ns1", archive, false)
+	assert.Regexp(t, "FF10526", err)
+}
+
+func TestNamespaceImportNotEmpty(t *testing.T) {
+	cfgFile = writeNamespaceTestConfig(t)
+	defer func() { cfgFile = "" }()
+	archive := exportTestNamespace(t)
+
+	// The archive is valid, but the namespace it was exported from is not empty
+	err := importNamespace(context.Background(), "ns1", archive, true)
+	assert.NoError(t, err)
+	err = importNamespace(context.Background(), "ns1", archive, false)
+	assert.Regexp(t, "FF10533", err)
+}
+
+func TestNamespaceImportSeekFail(t *testing.T) {
+	cfgFile = writeNamespaceTestConfig(t)
+	defer func() { cfgFile = "" }()
+	archive, err := os.ReadFile(exportTestNamespace(t))
+	assert.NoError(t, err)
+
+	// A pipe can be read through once to verify the archive, but cannot be rewound to import it
+	pipe := filepath.Join(t.TempDir(), "ns1.pipe")
+	err = syscall.Mkfifo(pipe, 0600)
+	assert.NoError(t, err)
+	go func() {
+		f, err := os.OpenFile(pipe, os.O_WRONLY, 0)
+		assert.NoError(t, err)
+		_, err = f.Write(archive)
+		assert.NoError(t, err)
+		f.Close()
+	}()
+	err = importNamespace(context.Background(), "ns1", pipe, false)
+	assert.Regexp(t, "seek", err)
+}
@@ -0,0 +1,8 @@
+CREATE TABLE messages_data (
+  seq        BIGINT   NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  message_id CHAR(36) NOT NULL,
+  data_id    CHAR(36) NOT NULL,
+  data_hash  CHAR(64) NOT NULL,
+  data_idx   INT      NOT NULL
+);
+CREATE UNIQUE INDEX messages_data_idx ON messages_data(message_id, data_id);
\ No newline at end of file
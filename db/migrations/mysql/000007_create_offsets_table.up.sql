@@ -0,0 +1,10 @@
+CREATE TABLE offsets (
+  seq         BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  id          CHAR(36)        NOT NULL,
+  otype       VARCHAR(64)     NOT NULL,
+  namespace   VARCHAR(64)     NOT NULL,
+  name        VARCHAR(64)     NOT NULL,
+  current     BIGINT          NOT NULL
+);
+CREATE UNIQUE INDEX offsets_id ON offsets(id);
+CREATE UNIQUE INDEX offsets_unique ON offsets(otype, namespace, name);
\ No newline at end of file
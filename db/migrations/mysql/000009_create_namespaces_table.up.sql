@@ -0,0 +1,12 @@
+CREATE TABLE namespaces (
+  seq         BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  id          CHAR(36)        NOT NULL,
+  message_id  CHAR(36),
+  name        VARCHAR(64)     NOT NULL,
+  ntype       VARCHAR(64)     NOT NULL,
+  description VARCHAR(4096),
+  created     BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX namespaces_id ON operations(id);
+CREATE UNIQUE INDEX namespaces_name ON namespaces(name);
\ No newline at end of file
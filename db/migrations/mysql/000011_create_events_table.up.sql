@@ -0,0 +1,11 @@
+CREATE TABLE events (
+  seq            BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  id             CHAR(36)        NOT NULL,
+  etype          VARCHAR(64)     NOT NULL,
+  namespace      VARCHAR(64)     NOT NULL,
+  ref            CHAR(36),
+  created        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX events_id ON events(id);
+CREATE INDEX events_created ON events(created);
\ No newline at end of file
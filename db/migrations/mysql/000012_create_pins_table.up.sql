@@ -0,0 +1,12 @@
+CREATE TABLE pins (
+  seq            BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  masked         BOOLEAN         NOT NULL,
+  hash           CHAR(64)        NOT NULL,
+  batch_id       CHAR(36)        NOT NULL,
+  idx            BIGINT          NOT NULL,
+  dispatched     BOOLEAN         NOT NULL,
+  created        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX pins_pin ON pins(hash, batch_id, idx);
+CREATE INDEX pins_dispatched ON pins(dispatched);
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE orgs (
+  seq            BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  id             CHAR(36)        NOT NULL,
+  message_id     CHAR(36)        NOT NULL,
+  name           VARCHAR(64)     NOT NULL,
+  parent         VARCHAR(1024),
+  identity       VARCHAR(1024)   NOT NULL,
+  description    VARCHAR(4096)   NOT NULL,
+  profile        TEXT,
+  created        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX orgs_id ON orgs(id);
+CREATE UNIQUE INDEX orgs_identity ON orgs(identity(768));
+CREATE UNIQUE INDEX orgs_name ON orgs(name);
\ No newline at end of file
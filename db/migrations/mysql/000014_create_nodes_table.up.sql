@@ -0,0 +1,15 @@
+CREATE TABLE nodes (
+  seq            BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  id             CHAR(36)        NOT NULL,
+  message_id     CHAR(36)        NOT NULL,
+  owner          VARCHAR(1024)   NOT NULL,
+  name           VARCHAR(64)     NOT NULL,
+  description    VARCHAR(4096)   NOT NULL,
+  dx_peer        VARCHAR(256),
+  dx_endpoint    TEXT,
+  created        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX nodes_id ON nodes(id);
+CREATE UNIQUE INDEX nodes_owner ON nodes(owner(704), name);
+CREATE UNIQUE INDEX nodes_peer ON nodes(dx_peer);
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE groups (
+  seq            BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  message_id     CHAR(36),
+  name           VARCHAR(64)     NOT NULL,
+  namespace      VARCHAR(64)     NOT NULL,
+  hash           CHAR(64)        NOT NULL,
+  created        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX groups_hash ON "groups"(hash);
\ No newline at end of file
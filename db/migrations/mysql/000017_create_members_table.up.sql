@@ -0,0 +1,9 @@
+CREATE TABLE members (
+  seq            BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  group_hash     CHAR(64)        NOT NULL,
+  idx            INT             NOT NULL,
+  identity       VARCHAR(1024)   NOT NULL,
+  node_id        CHAR(36)        NOT NULL
+);
+
+CREATE INDEX members_group ON members(group_hash);
\ No newline at end of file
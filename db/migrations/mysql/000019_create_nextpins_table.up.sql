@@ -0,0 +1,9 @@
+CREATE TABLE nextpins (
+  seq            BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  context        CHAR(64)        NOT NULL,
+  identity       VARCHAR(1024)   NOT NULL,
+  hash           CHAR(64)        NOT NULL,
+  nonce          BIGINT          NOT NULL
+);
+
+CREATE INDEX nextpins_hash ON nextpins(hash);
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP INDEX messages_sortorder ON messages;
+ALTER TABLE messages DROP COLUMN pending;
+ALTER TABLE messages DROP COLUMN rejected;
+CREATE INDEX messages_created ON messages(created);
+CREATE INDEX messages_confirmed ON messages(confirmed);
@@ -0,0 +1,13 @@
+DROP INDEX messages_confirmed ON messages;
+DROP INDEX messages_created ON messages;
+
+ALTER TABLE messages ADD COLUMN pending SMALLINT;
+UPDATE messages SET pending = 1 WHERE confirmed = 0;
+UPDATE messages SET pending = 0 WHERE confirmed != 0;
+ALTER TABLE messages MODIFY COLUMN pending SMALLINT NOT NULL;
+
+ALTER TABLE messages ADD COLUMN rejected BOOLEAN;
+UPDATE messages SET rejected = FALSE;
+ALTER TABLE messages MODIFY COLUMN rejected BOOLEAN NOT NULL;
+
+CREATE INDEX messages_sortorder ON messages(pending, confirmed, created);
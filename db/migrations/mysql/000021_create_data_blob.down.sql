@@ -0,0 +1,4 @@
+DROP INDEX data_blobs ON data;
+ALTER TABLE data DROP COLUMN blob_hash;
+ALTER TABLE data DROP COLUMN blob_public;
+-- index data_blobs was dropped with its column
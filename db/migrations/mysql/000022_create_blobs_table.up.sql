@@ -0,0 +1,9 @@
+CREATE TABLE blobs (
+  seq            BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  hash           CHAR(64)        NOT NULL,
+  payload_ref    VARCHAR(1024)   NOT NULL,
+  created        BIGINT          NOT NULL,
+  peer           VARCHAR(256)    NOT NULL
+);
+
+CREATE INDEX blobs_hash ON blobs(hash);
\ No newline at end of file
@@ -0,0 +1,13 @@
+ALTER TABLE subscriptions DROP COLUMN updated;
+
+-- We change the primary key by which we access the data, so truncate the table
+-- Meaning offsets for subscriptions will be reset going down
+DELETE FROM offsets;
+
+DROP INDEX offsets_unique ON offsets;
+
+ALTER TABLE offsets ADD COLUMN id CHAR(36) NOT NULL;
+ALTER TABLE offsets ADD COLUMN namespace VARCHAR(64) NOT NULL;
+
+CREATE UNIQUE INDEX offsets_id ON offsets(id);
+CREATE UNIQUE INDEX offsets_unique ON offsets(otype, namespace, name);
@@ -0,0 +1,13 @@
+ALTER TABLE subscriptions ADD COLUMN updated BIGINT;
+
+-- We change the primary key by which we access the data, so truncate the table
+-- Meaning offsets for subscriptions will be reset going up
+DELETE FROM offsets;
+
+DROP INDEX offsets_id ON offsets;
+DROP INDEX offsets_unique ON offsets;
+
+ALTER TABLE offsets DROP COLUMN namespace;
+ALTER TABLE offsets DROP COLUMN id;
+
+CREATE UNIQUE INDEX offsets_unique ON offsets(otype, name);
\ No newline at end of file
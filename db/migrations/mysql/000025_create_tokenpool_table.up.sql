@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS tokenpool;
+CREATE TABLE tokenpool (
+  seq            BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  id             CHAR(36)        NOT NULL,
+  namespace      VARCHAR(64)     NOT NULL,
+  name           VARCHAR(64)     NOT NULL,
+  protocol_id    VARCHAR(1024)   NOT NULL,
+  type           VARCHAR(64)     NOT NULL,
+  tx_type        VARCHAR(64)     NOT NULL,
+  tx_id          CHAR(36)
+);
+
+CREATE UNIQUE INDEX tokenpool_id ON tokenpool(id);
+CREATE UNIQUE INDEX tokenpool_name ON tokenpool(namespace, name);
+CREATE UNIQUE INDEX tokenpool_protocolid ON tokenpool(protocol_id(768));
+CREATE INDEX tokenpool_fortx ON tokenpool(namespace, tx_id);
@@ -0,0 +1,2 @@
+ALTER TABLE operations RENAME COLUMN info TO output;
+ALTER TABLE operations ADD COLUMN input TEXT;
@@ -0,0 +1,6 @@
+DROP INDEX tokenpool_protocolid ON tokenpool;
+CREATE UNIQUE INDEX tokenpool_protocolid ON tokenpool(protocol_id(768));
+
+ALTER TABLE tokenpool DROP COLUMN connector;
+ALTER TABLE tokenpool DROP COLUMN symbol;
+ALTER TABLE tokenpool DROP COLUMN message_id;
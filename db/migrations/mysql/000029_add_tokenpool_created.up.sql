@@ -0,0 +1,2 @@
+DELETE FROM tokenpool;
+ALTER TABLE tokenpool ADD COLUMN created BIGINT NOT NULL;
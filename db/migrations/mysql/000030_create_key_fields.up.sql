@@ -0,0 +1,11 @@
+ALTER TABLE batches ADD COLUMN "key" VARCHAR(1024);
+UPDATE batches SET "key" = '';
+ALTER TABLE batches MODIFY COLUMN "key" VARCHAR(1024) NOT NULL;
+
+ALTER TABLE messages ADD COLUMN "key" VARCHAR(1024);
+UPDATE messages SET "key" = '';
+ALTER TABLE messages MODIFY COLUMN "key" VARCHAR(1024) NOT NULL;
+
+ALTER TABLE tokenpool ADD COLUMN "key" VARCHAR(1024);
+UPDATE tokenpool SET "key" = '';
+ALTER TABLE tokenpool MODIFY COLUMN "key" VARCHAR(1024) NOT NULL;
@@ -0,0 +1,20 @@
+CREATE TABLE tokentransfer (
+  seq              BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  local_id         CHAR(36)        NOT NULL,
+  type             VARCHAR(64)     NOT NULL,
+  pool_protocol_id VARCHAR(1024)   NOT NULL,
+  token_index      VARCHAR(1024),
+  "key"            VARCHAR(1024)   NOT NULL,
+  from_key         VARCHAR(1024),
+  to_key           VARCHAR(1024),
+  amount           VARCHAR(65),
+  protocol_id      VARCHAR(1024)   NOT NULL,
+  message_hash     CHAR(64),
+  tx_type          VARCHAR(64),
+  tx_id            CHAR(36),
+  created          BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX tokentransfer_id ON tokentransfer(local_id);
+CREATE INDEX tokentransfer_pool ON tokentransfer(pool_protocol_id(384), token_index(384));
+CREATE UNIQUE INDEX tokentransfer_protocolid ON tokentransfer(protocol_id(768));
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS tokenaccount;
+
+CREATE TABLE tokenaccount (
+  seq              BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  pool_protocol_id VARCHAR(1024)   NOT NULL,
+  token_index      VARCHAR(1024),
+  identity         VARCHAR(1024)   NOT NULL,
+  balance          VARCHAR(65)
+);
+
+CREATE UNIQUE INDEX tokenaccount_pool ON tokenaccount(identity(256), pool_protocol_id(256), token_index(256));
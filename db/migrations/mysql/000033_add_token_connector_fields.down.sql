@@ -0,0 +1,5 @@
+DROP INDEX tokentransfer_protocolid ON tokentransfer;
+CREATE UNIQUE INDEX tokentransfer_protocolid ON tokentransfer(protocol_id(768));
+
+ALTER TABLE tokenaccount DROP COLUMN connector;
+ALTER TABLE tokentransfer DROP COLUMN connector;
@@ -0,0 +1,18 @@
+ALTER TABLE tokenaccount ADD COLUMN connector VARCHAR(64);
+ALTER TABLE tokentransfer ADD COLUMN connector VARCHAR(64);
+
+UPDATE tokenaccount
+  JOIN (SELECT protocol_id, connector FROM tokenpool) AS pool
+  ON tokenaccount.pool_protocol_id = pool.protocol_id
+  SET tokenaccount.connector = pool.connector;
+
+UPDATE tokentransfer
+  JOIN (SELECT protocol_id, connector FROM tokenpool) AS pool
+  ON tokentransfer.pool_protocol_id = pool.protocol_id
+  SET tokentransfer.connector = pool.connector;
+
+ALTER TABLE tokenaccount MODIFY COLUMN connector VARCHAR(64) NOT NULL;
+ALTER TABLE tokentransfer MODIFY COLUMN connector VARCHAR(64) NOT NULL;
+
+DROP INDEX tokentransfer_protocolid ON tokentransfer;
+CREATE UNIQUE INDEX tokentransfer_protocolid ON tokentransfer(connector, protocol_id(704));
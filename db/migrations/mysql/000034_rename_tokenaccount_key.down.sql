@@ -0,0 +1 @@
+ALTER TABLE tokenaccount RENAME COLUMN "key" TO identity;
@@ -0,0 +1 @@
+ALTER TABLE tokenaccount DROP COLUMN updated;
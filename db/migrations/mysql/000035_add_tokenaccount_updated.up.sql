@@ -0,0 +1,3 @@
+ALTER TABLE tokenaccount ADD COLUMN updated BIGINT;
+UPDATE tokenaccount SET updated = 0;
+ALTER TABLE tokenaccount MODIFY COLUMN updated BIGINT NOT NULL;
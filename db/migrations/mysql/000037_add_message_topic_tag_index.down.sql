@@ -0,0 +1 @@
+DROP INDEX messages_topics_tag ON messages;
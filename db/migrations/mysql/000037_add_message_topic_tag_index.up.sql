@@ -0,0 +1 @@
+CREATE INDEX messages_topics_tag ON messages(namespace, topics(640), tag);
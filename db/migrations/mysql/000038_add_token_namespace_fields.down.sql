@@ -0,0 +1,2 @@
+ALTER TABLE tokenaccount DROP COLUMN namespace;
+ALTER TABLE tokentransfer DROP COLUMN namespace;
@@ -0,0 +1,12 @@
+ALTER TABLE tokenaccount ADD COLUMN namespace VARCHAR(64);
+ALTER TABLE tokentransfer ADD COLUMN namespace VARCHAR(64);
+
+UPDATE tokenaccount
+  JOIN (SELECT protocol_id, namespace FROM tokenpool) AS pool
+  ON tokenaccount.pool_protocol_id = pool.protocol_id
+  SET tokenaccount.namespace = pool.namespace;
+
+UPDATE tokentransfer
+  JOIN (SELECT protocol_id, namespace FROM tokenpool) AS pool
+  ON tokentransfer.pool_protocol_id = pool.protocol_id
+  SET tokentransfer.namespace = pool.namespace;
@@ -0,0 +1,4 @@
+ALTER TABLE messages ADD COLUMN pending SMALLINT;
+
+DROP INDEX messages_sortorder ON messages;
+CREATE INDEX messages_sortorder ON messages(pending, confirmed, created);
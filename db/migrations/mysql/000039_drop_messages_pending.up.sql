@@ -0,0 +1,4 @@
+DROP INDEX messages_sortorder ON messages;
+CREATE INDEX messages_sortorder ON messages(confirmed, created);
+
+ALTER TABLE messages DROP COLUMN pending;
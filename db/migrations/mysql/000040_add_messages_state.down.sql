@@ -0,0 +1,7 @@
+ALTER TABLE messages ADD COLUMN local BOOLEAN;
+ALTER TABLE messages ADD COLUMN rejected BOOLEAN;
+UPDATE messages SET rejected=true WHERE state="rejected";
+
+ALTER TABLE messages DROP COLUMN state;
+ALTER TABLE messages MODIFY COLUMN local BOOLEAN NOT NULL;
+ALTER TABLE messages MODIFY COLUMN rejected BOOLEAN NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE operations ADD COLUMN member VARCHAR(1024);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE operations DROP COLUMN member;
\ No newline at end of file
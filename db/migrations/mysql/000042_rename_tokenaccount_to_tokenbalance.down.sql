@@ -0,0 +1 @@
+ALTER TABLE tokenbalance RENAME TO tokenaccount;
@@ -0,0 +1 @@
+ALTER TABLE tokenaccount RENAME TO tokenbalance;
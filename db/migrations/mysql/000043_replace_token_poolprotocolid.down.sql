@@ -0,0 +1,24 @@
+DROP INDEX tokenbalance_pool ON tokenbalance;
+DROP INDEX tokentransfer_pool ON tokentransfer;
+
+ALTER TABLE tokenbalance ADD COLUMN pool_protocol_id VARCHAR(1024);
+ALTER TABLE tokentransfer ADD COLUMN pool_protocol_id VARCHAR(1024);
+
+UPDATE tokenbalance
+  JOIN (SELECT protocol_id, id FROM tokenpool) AS pool
+  ON tokenbalance.pool_id = pool.id
+  SET tokenbalance.pool_protocol_id = pool.protocol_id;
+
+UPDATE tokentransfer
+  JOIN (SELECT protocol_id, id FROM tokenpool) AS pool
+  ON tokentransfer.pool_id = pool.id
+  SET tokentransfer.pool_protocol_id = pool.protocol_id;
+
+ALTER TABLE tokenbalance DROP COLUMN pool_id;
+ALTER TABLE tokentransfer DROP COLUMN pool_id;
+
+ALTER TABLE tokenbalance MODIFY COLUMN pool_protocol_id VARCHAR(1024) NOT NULL;
+ALTER TABLE tokentransfer MODIFY COLUMN pool_protocol_id VARCHAR(1024) NOT NULL;
+
+CREATE UNIQUE INDEX tokenaccount_pool ON tokenbalance("key"(256), pool_protocol_id(256), token_index(256));
+CREATE INDEX tokentransfer_pool ON tokentransfer(pool_protocol_id(384), token_index(384));
@@ -0,0 +1,24 @@
+DROP INDEX tokenaccount_pool ON tokenbalance;
+DROP INDEX tokentransfer_pool ON tokentransfer;
+
+ALTER TABLE tokenbalance ADD COLUMN pool_id CHAR(36);
+ALTER TABLE tokentransfer ADD COLUMN pool_id CHAR(36);
+
+UPDATE tokenbalance
+  JOIN (SELECT protocol_id, id FROM tokenpool) AS pool
+  ON tokenbalance.pool_protocol_id = pool.protocol_id
+  SET tokenbalance.pool_id = pool.id;
+
+UPDATE tokentransfer
+  JOIN (SELECT protocol_id, id FROM tokenpool) AS pool
+  ON tokentransfer.pool_protocol_id = pool.protocol_id
+  SET tokentransfer.pool_id = pool.id;
+
+ALTER TABLE tokenbalance DROP COLUMN pool_protocol_id;
+ALTER TABLE tokentransfer DROP COLUMN pool_protocol_id;
+
+ALTER TABLE tokenbalance MODIFY COLUMN pool_id CHAR(36) NOT NULL;
+ALTER TABLE tokentransfer MODIFY COLUMN pool_id CHAR(36) NOT NULL;
+
+CREATE UNIQUE INDEX tokenbalance_pool ON tokenbalance("key"(366), pool_id, token_index(366));
+CREATE INDEX tokentransfer_pool ON tokentransfer(pool_id, token_index(732));
@@ -0,0 +1 @@
+ALTER TABLE tokenpool DROP COLUMN state;
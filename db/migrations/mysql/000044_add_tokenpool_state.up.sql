@@ -0,0 +1,3 @@
+ALTER TABLE tokenpool ADD COLUMN state VARCHAR(64);
+UPDATE tokenpool SET state='confirmed';
+ALTER TABLE tokenpool MODIFY COLUMN state VARCHAR(64) NOT NULL;
@@ -0,0 +1,6 @@
+ALTER TABLE tokentransfer ADD COLUMN message_id CHAR(36);
+
+UPDATE tokentransfer
+  JOIN (SELECT hash, id FROM messages) AS message
+  ON tokentransfer.message_hash = message.hash
+  SET tokentransfer.message_id = message.id;
@@ -0,0 +1 @@
+ALTER TABLE batches DROP COLUMN node_id;
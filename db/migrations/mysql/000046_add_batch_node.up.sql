@@ -0,0 +1 @@
+ALTER TABLE batches ADD COLUMN node_id CHAR(36);
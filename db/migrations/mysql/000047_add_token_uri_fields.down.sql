@@ -0,0 +1,7 @@
+DROP INDEX tokenbalance_pool ON tokenbalance;
+DROP INDEX tokenbalance_uri ON tokenbalance;
+
+ALTER TABLE tokenbalance DROP COLUMN uri;
+ALTER TABLE tokentransfer DROP COLUMN uri;
+
+CREATE UNIQUE INDEX tokenbalance_pool ON tokenbalance("key"(366), pool_id, token_index(366));
@@ -0,0 +1,7 @@
+DROP INDEX tokenbalance_pool ON tokenbalance;
+
+ALTER TABLE tokenbalance ADD COLUMN uri VARCHAR(1024);
+ALTER TABLE tokentransfer ADD COLUMN uri VARCHAR(1024);
+
+CREATE UNIQUE INDEX tokenbalance_pool ON tokenbalance(namespace, "key"(334), pool_id, token_index(334));
+CREATE UNIQUE INDEX tokenbalance_uri ON tokenbalance(namespace, "key"(334), pool_id, uri(334));
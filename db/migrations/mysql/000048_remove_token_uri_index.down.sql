@@ -0,0 +1 @@
+CREATE UNIQUE INDEX tokenbalance_uri ON tokenbalance(namespace, "key"(334), pool_id, uri(334));
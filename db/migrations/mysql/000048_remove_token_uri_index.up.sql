@@ -0,0 +1 @@
+DROP INDEX tokenbalance_uri ON tokenbalance;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ffi;
\ No newline at end of file
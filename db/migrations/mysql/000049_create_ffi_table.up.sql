@@ -0,0 +1,12 @@
+CREATE TABLE ffi (
+  seq               BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  id                CHAR(36)        NOT NULL,
+  namespace         VARCHAR(64)     NOT NULL,
+  name              VARCHAR(1024)   NOT NULL,
+  version           VARCHAR(64)     NOT NULL,
+  description       TEXT            NOT NULL,
+  message_id        CHAR(36)        NOT NULL
+);
+
+CREATE UNIQUE INDEX ffi_id ON ffi(id);
+CREATE UNIQUE INDEX ffi_name ON ffi(namespace, name(640), version);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ffimethods;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE ffimethods (
+  seq               BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  id                CHAR(36)        NOT NULL,
+  interface_id      CHAR(36)        NOT NULL,
+  namespace         VARCHAR(64)     NOT NULL,
+  name              VARCHAR(1024)   NOT NULL,
+  pathname          VARCHAR(1024)   NOT NULL,
+  description       TEXT            NOT NULL,
+  params            TEXT            NOT NULL,
+  returns           TEXT            NOT NULL
+);
+
+CREATE UNIQUE INDEX ffimethods_pathname ON ffimethods(interface_id, pathname(732));
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ffievents;
\ No newline at end of file
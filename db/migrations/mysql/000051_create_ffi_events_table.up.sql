@@ -0,0 +1,12 @@
+CREATE TABLE ffievents (
+  seq               BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  id                CHAR(36)        NOT NULL,
+  interface_id      CHAR(36)        NULL,
+  namespace         VARCHAR(64)     NOT NULL,
+  name              VARCHAR(1024)   NOT NULL,
+  pathname          VARCHAR(1024)   NOT NULL,
+  description       TEXT            NOT NULL,
+  params            TEXT            NOT NULL
+);
+
+CREATE UNIQUE INDEX ffievents_pathname ON ffievents(interface_id, pathname(732));
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS contractapis;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE contractapis (
+  seq               BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  id                CHAR(36)        NOT NULL,
+  interface_id      CHAR(36)        NOT NULL,
+  location          TEXT,
+  name              VARCHAR(64)     NOT NULL,
+  namespace         VARCHAR(64)     NOT NULL
+);
+
+CREATE UNIQUE INDEX contractapis_namespace_name ON contractapis(namespace, name);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS contractsubscriptions;
\ No newline at end of file
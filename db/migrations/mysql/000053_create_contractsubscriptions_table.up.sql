@@ -0,0 +1,14 @@
+CREATE TABLE contractsubscriptions (
+  seq              BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  id               CHAR(36)        NOT NULL,
+  interface_id     CHAR(36)        NULL,
+  event            TEXT            NOT NULL,
+  namespace        VARCHAR(64)     NOT NULL,
+  name             VARCHAR(64)     NULL,
+  protocol_id      VARCHAR(1024)   NOT NULL,
+  location         TEXT            NOT NULL,
+  created          BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX contractsubscriptions_protocolid ON contractsubscriptions(protocol_id(768));
+CREATE UNIQUE INDEX contractsubscriptions_name ON contractsubscriptions(namespace, name);
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE TABLE blockchainevents (
+  seq              BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  id               CHAR(36)        NOT NULL,
+  source           VARCHAR(256)    NOT NULL,
+  namespace        VARCHAR(64)     NOT NULL,
+  name             VARCHAR(256)    NOT NULL,
+  protocol_id      VARCHAR(256)    NOT NULL,
+  timestamp        BIGINT          NOT NULL,
+  subscription_id  CHAR(36),
+  output           TEXT,
+  info             TEXT,
+  tx_type          VARCHAR(64),
+  tx_id            CHAR(36)
+);
+
+CREATE UNIQUE INDEX blockchainevents_id ON blockchainevents(id);
+CREATE INDEX blockchainevents_tx ON blockchainevents(tx_id);
+CREATE INDEX blockchainevents_subscription_id ON blockchainevents(subscription_id);
@@ -0,0 +1,7 @@
+DROP INDEX data_blob_name ON data;
+DROP INDEX data_blob_size ON data;
+
+ALTER TABLE blobs DROP COLUMN size;
+ALTER TABLE data DROP COLUMN blob_name;
+ALTER TABLE data DROP COLUMN blob_size;
+ALTER TABLE data DROP COLUMN value_size;
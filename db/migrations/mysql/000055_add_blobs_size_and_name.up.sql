@@ -0,0 +1,15 @@
+ALTER TABLE blobs ADD COLUMN size BIGINT;
+
+ALTER TABLE data ADD COLUMN blob_name VARCHAR(1024);
+ALTER TABLE data ADD COLUMN blob_size BIGINT;
+ALTER TABLE data ADD COLUMN value_size BIGINT;
+
+UPDATE blobs SET size = 0;
+UPDATE data SET blob_size = 0, blob_name = '', value_size = 0;
+
+ALTER TABLE data MODIFY COLUMN blob_name VARCHAR(1024) NOT NULL;
+ALTER TABLE data MODIFY COLUMN blob_size BIGINT NOT NULL;
+ALTER TABLE data MODIFY COLUMN value_size BIGINT NOT NULL;
+
+CREATE INDEX data_blob_name ON data(blob_name(768));
+CREATE INDEX data_blob_size ON data(blob_size);
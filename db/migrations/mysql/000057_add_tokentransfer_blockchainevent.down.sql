@@ -0,0 +1 @@
+ALTER TABLE tokentransfer DROP COLUMN blockchain_event;
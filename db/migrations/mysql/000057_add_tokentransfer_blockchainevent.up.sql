@@ -0,0 +1 @@
+ALTER TABLE tokentransfer ADD COLUMN blockchain_event CHAR(36);
@@ -0,0 +1 @@
+DROP INDEX pins_batch ON pins;
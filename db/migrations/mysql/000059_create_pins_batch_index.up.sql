@@ -0,0 +1 @@
+CREATE INDEX pins_batch ON pins(batch_id);
\ No newline at end of file
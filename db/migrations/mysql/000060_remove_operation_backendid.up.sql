@@ -0,0 +1,2 @@
+DROP INDEX operations_backend ON operations;
+ALTER TABLE operations DROP COLUMN backend_id;
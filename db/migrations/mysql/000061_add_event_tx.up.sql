@@ -0,0 +1 @@
+ALTER TABLE events ADD COLUMN tx_id CHAR(36);
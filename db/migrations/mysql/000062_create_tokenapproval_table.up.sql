@@ -0,0 +1,19 @@
+CREATE TABLE tokenapproval (
+  seq              BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  local_id         CHAR(36)        NOT NULL,
+  pool_id          VARCHAR(1024)   NOT NULL,
+  "key"            VARCHAR(1024)   NOT NULL,
+  operator_key     VARCHAR(1024)   NOT NULL,
+  approved         BOOLEAN         NOT NULL,
+  protocol_id      VARCHAR(1024)   NOT NULL,
+  tx_type          VARCHAR(64),
+  connector        VARCHAR(64),
+  namespace        VARCHAR(64),
+  info             TEXT,
+  tx_id            CHAR(36),
+  blockchain_event CHAR(36),
+  created          BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX tokenapproval_id ON tokenapproval(local_id);
+CREATE UNIQUE INDEX tokenapproval_protocolid ON tokenapproval(pool_id(384), protocol_id(384));
@@ -0,0 +1 @@
+ALTER TABLE data MODIFY COLUMN value TEXT;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE pins ADD COLUMN signer TEXT;
+UPDATE pins SET signer = '';
+
+ALTER TABLE events ADD COLUMN cid CHAR(36);
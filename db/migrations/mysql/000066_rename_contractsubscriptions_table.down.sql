@@ -0,0 +1 @@
+ALTER TABLE contractlisteners RENAME TO contractsubscriptions;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE contractsubscriptions RENAME TO contractlisteners;
\ No newline at end of file
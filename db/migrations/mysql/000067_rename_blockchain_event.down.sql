@@ -0,0 +1 @@
+UPDATE events SET etype='bockchain_event' WHERE etype='bockchain_event_received';
\ No newline at end of file
@@ -0,0 +1 @@
+UPDATE events SET etype='bockchain_event_received' WHERE etype='bockchain_event';
\ No newline at end of file
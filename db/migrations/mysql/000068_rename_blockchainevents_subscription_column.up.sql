@@ -0,0 +1,3 @@
+DROP INDEX blockchainevents_subscription_id ON blockchainevents;
+ALTER TABLE blockchainevents RENAME COLUMN subscription_id TO listener_id;
+CREATE INDEX blockchainevents_listener_id ON blockchainevents(listener_id);
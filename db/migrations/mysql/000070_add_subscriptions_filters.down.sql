@@ -0,0 +1,5 @@
+ALTER TABLE subscriptions DROP COLUMN filters;
+ALTER TABLE subscriptions ADD COLUMN filter_events TEXT;
+ALTER TABLE subscriptions ADD COLUMN filter_topics TEXT;
+ALTER TABLE subscriptions ADD COLUMN filter_tag TEXT;
+ALTER TABLE subscriptions ADD COLUMN filter_group TEXT;
\ No newline at end of file
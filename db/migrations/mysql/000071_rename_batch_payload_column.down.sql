@@ -0,0 +1 @@
+ALTER TABLE batches RENAME COLUMN manifest TO payload;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE batches RENAME COLUMN payload TO manifest;
@@ -0,0 +1 @@
+ALTER TABLE contractlisteners DROP COLUMN options;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE contractlisteners ADD COLUMN options TEXT;
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP INDEX events_topic ON events;
+
+ALTER TABLE events DROP COLUMN topic;
+ALTER TABLE contractlisteners DROP COLUMN topic;
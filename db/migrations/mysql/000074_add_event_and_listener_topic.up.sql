@@ -0,0 +1,10 @@
+ALTER TABLE events ADD COLUMN topic VARCHAR(64);
+ALTER TABLE contractlisteners ADD COLUMN topic VARCHAR(64);
+
+UPDATE events SET topic = '';
+UPDATE contractlisteners SET topic = '';
+
+ALTER TABLE events MODIFY COLUMN topic VARCHAR(64) NOT NULL;
+ALTER TABLE contractlisteners MODIFY COLUMN topic VARCHAR(64) NOT NULL;
+
+CREATE INDEX events_topic ON events(topic);
@@ -0,0 +1 @@
+ALTER TABLE pins DROP COLUMN batch_hash;
@@ -0,0 +1 @@
+ALTER TABLE pins ADD COLUMN batch_hash VARCHAR(64);
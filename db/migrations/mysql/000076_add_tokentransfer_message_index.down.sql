@@ -0,0 +1 @@
+DROP INDEX tokentransfer_messageid ON tokentransfer;
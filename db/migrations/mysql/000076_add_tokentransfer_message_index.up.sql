@@ -0,0 +1 @@
+CREATE INDEX tokentransfer_messageid ON tokentransfer(message_id);
@@ -0,0 +1 @@
+DROP INDEX transactions_id ON transactions;
@@ -0,0 +1,4 @@
+-- We created an invalid index in versions v0.14.1 and earlier - replace it if it exists here
+-- We use an IF EXISTS here, as we no longer create the invalid index for new environments
+-- index transactions_id does not exist in MySQL schemas
+CREATE UNIQUE INDEX transactions_id ON transactions(id);
@@ -0,0 +1,8 @@
+DROP INDEX nonces_context ON nonces;
+DROP INDEX nonces_group ON nonces;
+
+ALTER TABLE nonces RENAME COLUMN context TO hash;
+ALTER TABLE nonces DROP COLUMN group_hash;
+ALTER TABLE nonces DROP COLUMN topic;
+
+CREATE INDEX nonces_hash ON nonces(hash);
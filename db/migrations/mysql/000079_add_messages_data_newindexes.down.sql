@@ -0,0 +1,4 @@
+DROP INDEX messages_data_message ON messages_data;
+DROP INDEX messages_data_data ON messages_data;
+
+CREATE UNIQUE INDEX messages_data_idx ON messages_data(message_id, data_id);
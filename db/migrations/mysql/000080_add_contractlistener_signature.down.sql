@@ -0,0 +1,2 @@
+DROP INDEX contractlisteners_signature ON contractlisteners;
+ALTER TABLE contractlisteners DROP COLUMN signature;
@@ -0,0 +1,9 @@
+DROP INDEX tokenapproval_subject ON tokenapproval;
+DROP INDEX tokentransfer_protocolid ON tokentransfer;
+DROP INDEX tokenpool_locator ON tokenpool;
+
+ALTER TABLE tokenapproval DROP COLUMN subject;
+ALTER TABLE tokenpool RENAME COLUMN locator TO protocol_id;
+
+CREATE UNIQUE INDEX tokenpool_protocolid ON tokenpool(connector, protocol_id(704));
+CREATE UNIQUE INDEX tokentransfer_protocolid ON tokentransfer(connector, protocol_id(704));
@@ -0,0 +1,16 @@
+DROP INDEX tokenapproval_protocolid ON tokenapproval;
+DROP INDEX tokentransfer_protocolid ON tokentransfer;
+DROP INDEX tokenpool_protocolid ON tokenpool;
+
+ALTER TABLE tokenapproval ADD COLUMN subject VARCHAR(1024);
+UPDATE tokenapproval SET subject = protocol_id;
+ALTER TABLE tokenapproval MODIFY COLUMN subject VARCHAR(1024) NOT NULL;
+ALTER TABLE tokenapproval ADD COLUMN active BOOLEAN;
+UPDATE tokenapproval SET active = true;
+
+ALTER TABLE tokenpool RENAME COLUMN protocol_id TO locator;
+
+CREATE UNIQUE INDEX tokenapproval_protocolid ON tokenapproval(pool_id(384), protocol_id(384));
+CREATE UNIQUE INDEX tokenapproval_subject ON tokenapproval(pool_id(384), subject(384));
+CREATE UNIQUE INDEX tokentransfer_protocolid ON tokentransfer(pool_id, protocol_id(732));
+CREATE UNIQUE INDEX tokenpool_locator ON tokenpool(connector, locator(704));
@@ -0,0 +1 @@
+DROP INDEX blockchainevents_protocolid ON blockchainevents;
@@ -0,0 +1,2 @@
+DELETE FROM blockchainevents WHERE seq NOT IN (SELECT seq FROM (SELECT MIN(seq) AS seq FROM blockchainevents GROUP BY namespace, listener_id, protocol_id) AS keep);
+CREATE UNIQUE INDEX blockchainevents_protocolid ON blockchainevents(namespace, listener_id, protocol_id);
@@ -0,0 +1 @@
+ALTER TABLE contractlisteners RENAME COLUMN backend_id TO protocol_id;
@@ -0,0 +1 @@
+ALTER TABLE contractlisteners RENAME COLUMN protocol_id TO backend_id;
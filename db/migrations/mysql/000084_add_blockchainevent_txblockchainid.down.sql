@@ -0,0 +1,2 @@
+DROP INDEX blockchainevents_txblockchainid ON blockchainevents;
+ALTER TABLE blockchainevents DROP COLUMN tx_blockchain_id;
@@ -0,0 +1 @@
+ALTER TABLE tokenpool ADD COLUMN decimals INTEGER DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE contractapis ADD COLUMN message_id CHAR(36) NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX tokenapproval_subject ON tokenapproval;
+CREATE INDEX tokenapproval_subject ON tokenapproval(pool_id(384), subject(384));
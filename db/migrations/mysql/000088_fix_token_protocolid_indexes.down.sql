@@ -0,0 +1,4 @@
+DROP INDEX tokentransfer_protocolid ON tokentransfer;
+DROP INDEX tokenapproval_protocolid ON tokenapproval;
+CREATE UNIQUE INDEX tokentransfer_protocolid ON tokentransfer(pool_id, protocol_id(732));
+CREATE UNIQUE INDEX tokenapproval_protocolid ON tokenapproval(pool_id(384), protocol_id(384));
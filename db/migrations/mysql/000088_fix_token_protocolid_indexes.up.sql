@@ -0,0 +1,12 @@
+DROP INDEX tokentransfer_protocolid ON tokentransfer;
+DROP INDEX tokenapproval_protocolid ON tokenapproval;
+
+-- De-duplicate existing approvals by adding the pool seq number to the protocol_id
+UPDATE tokenapproval
+  JOIN (SELECT seq, id FROM tokenpool) AS pool
+  ON pool.id = tokenapproval.pool_id
+  SET tokenapproval.protocol_id = CONCAT(tokenapproval.protocol_id, '/', pool.seq)
+  WHERE length(tokenapproval.protocol_id) = 26;
+
+CREATE UNIQUE INDEX tokentransfer_protocolid ON tokentransfer(connector, protocol_id(704));
+CREATE UNIQUE INDEX tokenapproval_protocolid ON tokenapproval(connector, protocol_id(704));
@@ -0,0 +1,10 @@
+DROP INDEX tokenapproval_protocolid ON tokenapproval;
+DROP INDEX tokenapproval_subject ON tokenapproval;
+
+ALTER TABLE tokenapproval RENAME COLUMN pool_id TO pool_id_old;
+ALTER TABLE tokenapproval ADD COLUMN pool_id VARCHAR(1024);
+UPDATE tokenapproval SET pool_id = CAST(pool_id_old AS CHAR(1024));
+ALTER TABLE tokenapproval DROP COLUMN pool_id_old;
+
+CREATE UNIQUE INDEX tokenapproval_protocolid ON tokenapproval(pool_id(384), protocol_id(384));
+CREATE INDEX tokenapproval_subject ON tokenapproval(pool_id(384), subject(384));
@@ -0,0 +1 @@
+ALTER TABLE namespaces ADD COLUMN firefly_contracts TEXT;
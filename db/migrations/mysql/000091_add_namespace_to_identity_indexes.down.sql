@@ -0,0 +1,5 @@
+DROP INDEX identities_did ON identities;
+CREATE UNIQUE INDEX identities_did ON identities(did);
+
+DROP INDEX verifiers_value ON verifiers;
+CREATE UNIQUE INDEX verifiers_value ON verifiers(vtype(255), value(255));
@@ -0,0 +1,4 @@
+DROP INDEX pins_pin ON pins;
+CREATE UNIQUE INDEX pins_pin ON pins(hash, batch_id, idx);
+
+ALTER TABLE pins DROP COLUMN namespace;
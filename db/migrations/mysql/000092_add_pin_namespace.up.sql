@@ -0,0 +1,6 @@
+ALTER TABLE pins ADD COLUMN namespace VARCHAR(64);
+UPDATE pins SET namespace = 'ff_system';
+ALTER TABLE pins MODIFY COLUMN namespace VARCHAR(64) NOT NULL;
+
+DROP INDEX pins_pin ON pins;
+CREATE UNIQUE INDEX pins_pin ON pins(namespace, hash, batch_id, idx);
@@ -0,0 +1,2 @@
+ALTER TABLE ffimethods DROP COLUMN details;
+ALTER TABLE ffievents DROP COLUMN details;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE ffimethods ADD COLUMN details TEXT;
+ALTER TABLE ffievents ADD COLUMN details TEXT;
\ No newline at end of file
@@ -0,0 +1,12 @@
+ALTER TABLE messages DROP COLUMN namespace_local;
+ALTER TABLE "groups" DROP COLUMN namespace_local;
+
+ALTER TABLE namespaces ADD COLUMN id CHAR(36);
+ALTER TABLE namespaces ADD COLUMN message_id CHAR(36);
+ALTER TABLE namespaces ADD COLUMN ntype VARCHAR(64);
+ALTER TABLE namespaces DROP COLUMN remote_name;
+
+DROP INDEX transactions_id ON transactions;
+CREATE UNIQUE INDEX transactions_id ON transactions(id);
+DROP INDEX operations_id ON operations;
+CREATE UNIQUE INDEX operations_id ON operations(id);
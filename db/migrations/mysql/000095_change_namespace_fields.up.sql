@@ -0,0 +1,18 @@
+ALTER TABLE messages ADD COLUMN namespace_local VARCHAR(64);
+UPDATE messages SET namespace_local = namespace;
+ALTER TABLE messages MODIFY COLUMN namespace_local VARCHAR(64) NOT NULL;
+
+ALTER TABLE "groups" ADD COLUMN namespace_local VARCHAR(64);
+UPDATE "groups" SET namespace_local = namespace;
+ALTER TABLE "groups" MODIFY COLUMN namespace_local VARCHAR(64) NOT NULL;
+
+DROP INDEX namespaces_id ON operations;
+ALTER TABLE namespaces DROP COLUMN id;
+ALTER TABLE namespaces DROP COLUMN message_id;
+ALTER TABLE namespaces DROP COLUMN ntype;
+ALTER TABLE namespaces ADD COLUMN remote_name VARCHAR(64);
+UPDATE namespaces SET remote_name = name;
+ALTER TABLE namespaces MODIFY COLUMN remote_name VARCHAR(64) NOT NULL;
+
+DROP INDEX transactions_id ON transactions;
+CREATE UNIQUE INDEX transactions_id ON transactions(namespace, id);
@@ -0,0 +1,48 @@
+DROP INDEX identities_id ON identities;
+CREATE UNIQUE INDEX identities_id ON identities(id);
+
+DROP INDEX verifiers_hash ON verifiers;
+CREATE UNIQUE INDEX verifiers_hash ON verifiers(hash);
+
+DROP INDEX verifiers_identity ON verifiers;
+CREATE UNIQUE INDEX verifiers_identity ON verifiers(identity);
+
+DROP INDEX tokenpool_locator ON tokenpool;
+CREATE UNIQUE INDEX tokenpool_locator ON tokenpool(connector, locator(704));
+
+DROP INDEX ffi_id ON ffi;
+CREATE UNIQUE INDEX ffi_id ON ffi(id);
+
+DROP INDEX datatypes_id ON datatypes;
+CREATE UNIQUE INDEX datatypes_id ON datatypes(id);
+
+DROP INDEX batches_id ON batches;
+CREATE UNIQUE INDEX batches_id ON batches(id);
+
+DROP INDEX groups_hash ON "groups";
+CREATE UNIQUE INDEX groups_hash ON "groups"(hash);
+
+DROP INDEX messages_id ON messages;
+CREATE UNIQUE INDEX messages_id ON messages(id);
+
+DROP INDEX data_id ON data;
+CREATE UNIQUE INDEX data_id ON data(id);
+
+ALTER TABLE messages_data RENAME TO messages_data_old;
+CREATE TABLE messages_data (
+  seq         BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  message_id  CHAR(36)        NOT NULL,
+  data_id     CHAR(36)        NOT NULL,
+  data_hash   CHAR(64)        NOT NULL,
+  data_idx    INT             NOT NULL
+);
+INSERT INTO messages_data(message_id, data_id, data_hash, data_idx)
+  SELECT message_id, data_id, data_hash, data_idx FROM messages_data_old;
+DROP TABLE messages_data_old;
+
+CREATE INDEX messages_data_message ON messages_data(message_id);
+CREATE INDEX messages_data_data ON messages_data(data_id);
+
+DROP INDEX nextpins_context ON nextpins;
+ALTER TABLE nextpins DROP COLUMN namespace;
+CREATE INDEX nextpins_hash ON nextpins(hash);
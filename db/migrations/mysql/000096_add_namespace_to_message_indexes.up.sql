@@ -0,0 +1,54 @@
+DROP INDEX identities_id ON identities;
+CREATE UNIQUE INDEX identities_id ON identities(namespace, id);
+
+DROP INDEX verifiers_hash ON verifiers;
+CREATE UNIQUE INDEX verifiers_hash ON verifiers(namespace, hash);
+
+DROP INDEX verifiers_identity ON verifiers;
+CREATE UNIQUE INDEX verifiers_identity ON verifiers(namespace, identity);
+
+DROP INDEX tokenpool_locator ON tokenpool;
+CREATE UNIQUE INDEX tokenpool_locator ON tokenpool(namespace, connector, locator(640));
+
+DROP INDEX ffi_id ON ffi;
+CREATE UNIQUE INDEX ffi_id ON ffi(namespace, id);
+
+DROP INDEX datatypes_id ON data;
+CREATE UNIQUE INDEX datatypes_id ON datatypes(namespace, id);
+
+DROP INDEX batches_id ON batches;
+CREATE UNIQUE INDEX batches_id ON batches(namespace, id);
+
+DROP INDEX groups_hash ON "groups";
+CREATE UNIQUE INDEX groups_hash ON "groups"(namespace_local, hash);
+
+DROP INDEX messages_id ON messages;
+CREATE UNIQUE INDEX messages_id ON messages(namespace_local, id);
+
+DROP INDEX data_id ON data;
+CREATE UNIQUE INDEX data_id ON data(namespace, id);
+
+ALTER TABLE messages_data RENAME TO messages_data_old;
+CREATE TABLE messages_data (
+  seq         BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  message_id  CHAR(36)        NOT NULL,
+  data_id     CHAR(36)        NOT NULL,
+  data_hash   CHAR(64)        NOT NULL,
+  data_idx    INT             NOT NULL,
+  namespace   VARCHAR(64)
+);
+INSERT INTO messages_data(message_id, data_id, data_hash, data_idx)
+  SELECT message_id, data_id, data_hash, data_idx FROM messages_data_old;
+DROP TABLE messages_data_old;
+UPDATE messages_data
+  JOIN (SELECT namespace, id FROM messages) AS msg
+  ON messages_data.message_id = msg.id
+  SET messages_data.namespace = msg.namespace;
+ALTER TABLE messages_data MODIFY COLUMN namespace VARCHAR(64) NOT NULL;
+
+CREATE INDEX messages_data_message ON messages_data(namespace, message_id);
+CREATE INDEX messages_data_data ON messages_data(namespace, data_id);
+
+ALTER TABLE nextpins ADD COLUMN namespace VARCHAR(64);
+DROP INDEX nextpins_hash ON nextpins;
+CREATE INDEX nextpins_context ON nextpins(namespace, context);
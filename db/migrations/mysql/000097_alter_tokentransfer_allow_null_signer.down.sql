@@ -0,0 +1 @@
+-- No action in down
\ No newline at end of file
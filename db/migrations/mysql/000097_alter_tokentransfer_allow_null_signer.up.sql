@@ -0,0 +1 @@
+ALTER TABLE tokentransfer MODIFY COLUMN "key" VARCHAR(1024);
@@ -0,0 +1,2 @@
+ALTER TABLE data ADD COLUMN public VARCHAR(1024);
+UPDATE data SET public = '';
@@ -0,0 +1,4 @@
+DROP INDEX blockchainevents_protocolid ON blockchainevents;
+DROP INDEX blockchainevents_listener_protocolid ON blockchainevents;
+ALTER TABLE blockchainevents DROP COLUMN listener_key;
+CREATE UNIQUE INDEX blockchainevents_protocolid ON blockchainevents(namespace, listener_id, protocol_id);
@@ -0,0 +1,7 @@
+DROP INDEX blockchainevents_protocolid ON blockchainevents;
+DELETE FROM blockchainevents WHERE listener_id IS NULL AND seq NOT IN (
+  SELECT seq FROM (SELECT MIN(seq) AS seq FROM blockchainevents WHERE listener_id IS NULL GROUP BY namespace, protocol_id) AS keep);
+-- MySQL has no partial indexes, so a generated column maps a NULL listener_id to an empty string
+ALTER TABLE blockchainevents ADD COLUMN listener_key CHAR(36) AS (COALESCE(listener_id, '')) VIRTUAL;
+CREATE UNIQUE INDEX blockchainevents_protocolid ON blockchainevents(namespace, listener_key, protocol_id);
+CREATE UNIQUE INDEX blockchainevents_listener_protocolid ON blockchainevents(namespace, listener_id, protocol_id);
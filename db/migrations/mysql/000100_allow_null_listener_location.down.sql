@@ -0,0 +1 @@
+-- No down migration for this one
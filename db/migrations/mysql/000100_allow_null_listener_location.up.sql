@@ -0,0 +1 @@
+ALTER TABLE contractlisteners MODIFY COLUMN location TEXT;
\ No newline at end of file
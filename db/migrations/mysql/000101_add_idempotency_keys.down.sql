@@ -0,0 +1,5 @@
+DROP INDEX transactions_idempotency_keys ON transactions;
+DROP INDEX messages_idempotency_keys ON messages;
+
+ALTER TABLE transactions DROP COLUMN idempotency_key;
+ALTER TABLE messages DROP COLUMN idempotency_key;
@@ -0,0 +1,3 @@
+DROP INDEX tokenapproval_messageid ON tokenapproval;
+ALTER TABLE tokenapproval DROP COLUMN message_id;
+ALTER TABLE tokenapproval DROP COLUMN message_hash;
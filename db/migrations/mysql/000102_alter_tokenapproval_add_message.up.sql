@@ -0,0 +1,3 @@
+ALTER TABLE tokenapproval ADD COLUMN message_id CHAR(36);
+ALTER TABLE tokenapproval ADD COLUMN message_hash CHAR(64);
+CREATE INDEX tokenapproval_messageid ON tokenapproval(message_id);
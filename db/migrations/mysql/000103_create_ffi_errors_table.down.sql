@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ffierrors;
\ No newline at end of file
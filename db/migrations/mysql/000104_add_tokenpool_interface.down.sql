@@ -0,0 +1,3 @@
+ALTER TABLE tokenpool DROP COLUMN interface;
+ALTER TABLE tokenpool DROP COLUMN interface_format;
+ALTER TABLE tokenpool DROP COLUMN methods;
@@ -0,0 +1,3 @@
+ALTER TABLE tokenpool ADD COLUMN interface CHAR(36);
+ALTER TABLE tokenpool ADD COLUMN interface_format VARCHAR(64) DEFAULT '';
+ALTER TABLE tokenpool ADD COLUMN methods TEXT;
@@ -0,0 +1,5 @@
+DROP INDEX blobs_namespace_data_id ON blobs;
+DROP INDEX blobs_payload_ref ON blobs;
+ALTER TABLE blobs DROP COLUMN namespace;
+ALTER TABLE blobs DROP COLUMN data_id;
+CREATE INDEX blob_hash ON blobs(hash);
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE messages DROP COLUMN tx_id;
+ALTER TABLE messages DROP COLUMN tx_parent_type;
+ALTER TABLE messages DROP COLUMN tx_parent_id;
@@ -0,0 +1,7 @@
+ALTER TABLE messages ADD COLUMN tx_id CHAR(36);
+UPDATE messages
+  JOIN batches ON messages.batch_id = batches.id
+  SET messages.tx_id = batches.tx_id
+  WHERE messages.tx_id IS NULL;
+ALTER TABLE messages ADD COLUMN tx_parent_type VARCHAR(64);
+ALTER TABLE messages ADD COLUMN tx_parent_id CHAR(36);
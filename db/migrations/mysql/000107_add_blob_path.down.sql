@@ -0,0 +1,2 @@
+DROP INDEX data_blob_path ON data;
+ALTER TABLE data DROP COLUMN blob_path;
\ No newline at end of file
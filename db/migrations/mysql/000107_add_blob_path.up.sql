@@ -0,0 +1,4 @@
+ALTER TABLE data ADD COLUMN blob_path VARCHAR(1024);
+CREATE INDEX data_blob_path ON data(blob_path(768));
+UPDATE data SET blob_path = '';
+ALTER TABLE data MODIFY COLUMN blob_path VARCHAR(1024) NOT NULL;
\ No newline at end of file
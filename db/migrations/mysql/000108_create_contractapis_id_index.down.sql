@@ -0,0 +1 @@
+DROP INDEX contractapis_id ON contractapis;
@@ -0,0 +1,3 @@
+DELETE FROM contractapis WHERE seq NOT IN (
+  SELECT seq FROM (SELECT MAX(seq) AS seq FROM contractapis GROUP BY namespace, id) AS keep);
+CREATE UNIQUE INDEX contractapis_id ON contractapis(namespace, id);
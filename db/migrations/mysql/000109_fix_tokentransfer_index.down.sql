@@ -0,0 +1 @@
+-- no down migration
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP INDEX tokentransfer_protocolid ON tokentransfer;
+DROP INDEX tokenapproval_protocolid ON tokenapproval;
+
+CREATE UNIQUE INDEX tokentransfer_protocolid ON tokentransfer(namespace, connector, protocol_id(640));
+CREATE UNIQUE INDEX tokenapproval_protocolid ON tokenapproval(namespace, connector, protocol_id(640));
@@ -0,0 +1,4 @@
+DROP INDEX tokenpool_networkname ON tokenpool;
+ALTER TABLE tokenpool DROP COLUMN published;
+ALTER TABLE tokenpool DROP COLUMN network_name;
+ALTER TABLE tokenpool DROP COLUMN plugin_data;
@@ -0,0 +1,6 @@
+DROP INDEX tokenpool_locator ON tokenpool;
+DROP INDEX tokentransfer_protocolid ON tokentransfer;
+DROP INDEX tokenapproval_protocolid ON tokenapproval;
+
+CREATE UNIQUE INDEX tokentransfer_protocolid ON tokentransfer(namespace, pool_id, protocol_id(668));
+CREATE UNIQUE INDEX tokenapproval_protocolid ON tokenapproval(namespace, pool_id, protocol_id(668));
@@ -0,0 +1,3 @@
+DROP INDEX ffi_networkname ON ffi;
+ALTER TABLE ffi DROP COLUMN published;
+ALTER TABLE ffi DROP COLUMN network_name;
@@ -0,0 +1,3 @@
+DROP INDEX contractapis_networkname ON contractapis;
+ALTER TABLE contractapis DROP COLUMN published;
+ALTER TABLE contractapis DROP COLUMN network_name;
@@ -0,0 +1 @@
+ALTER TABLE messages DROP COLUMN reject_reason;
@@ -0,0 +1 @@
+ALTER TABLE messages ADD COLUMN reject_reason TEXT DEFAULT ('');
@@ -0,0 +1 @@
+ALTER TABLE messages MODIFY COLUMN tx_parent_type VARCHAR(64);
@@ -0,0 +1,3 @@
+UPDATE messages SET tx_parent_type = ''
+  WHERE tx_parent_type IS NULL;
+ALTER TABLE messages MODIFY COLUMN tx_parent_type VARCHAR(64) NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE contractlisteners DROP COLUMN filters;
+-- no down for the VARCHAR change
\ No newline at end of file
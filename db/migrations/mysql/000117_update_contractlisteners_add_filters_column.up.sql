@@ -0,0 +1,5 @@
+ALTER TABLE contractlisteners ADD COLUMN filters TEXT;
+-- MySQL can only index a TEXT column by prefix, so the index is rebuilt around the change
+DROP INDEX contractlisteners_signature ON contractlisteners;
+ALTER TABLE contractlisteners MODIFY COLUMN signature TEXT;
+CREATE INDEX contractlisteners_signature ON contractlisteners(signature(255));
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE deadletters (
+  seq               BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  id                CHAR(36)        NOT NULL,
+  namespace         VARCHAR(64)     NOT NULL,
+  subscription_id   CHAR(36)        NOT NULL,
+  event_id          CHAR(36)        NOT NULL,
+  reason            TEXT            NOT NULL,
+  created           BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX deadletters_id ON deadletters(namespace, id);
+CREATE INDEX deadletters_subscription ON deadletters(namespace, subscription_id);
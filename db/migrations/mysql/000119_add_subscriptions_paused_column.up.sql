@@ -0,0 +1 @@
+ALTER TABLE subscriptions ADD COLUMN paused BOOLEAN DEFAULT false;
@@ -0,0 +1,30 @@
+CREATE TABLE search_index (
+  seq         BIGINT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  namespace   VARCHAR(64)     NOT NULL,
+  ref_type    VARCHAR(64)     NOT NULL,
+  ref_id      CHAR(36)        NOT NULL,
+  content     LONGTEXT        NOT NULL,
+  FULLTEXT INDEX search_index_content (content)
+);
+
+CREATE INDEX search_index_ref ON search_index(namespace, ref_type, ref_id);
+
+INSERT INTO search_index (namespace, ref_type, ref_id, content)
+  SELECT namespace_local, 'message', id, TRIM(CONCAT(tag, ' ', author, ' ', REPLACE(topics, ',', ' ')))
+  FROM messages;
+
+INSERT INTO search_index (namespace, ref_type, ref_id, content)
+  WITH RECURSIVE nodes (namespace, id, node) AS (
+    SELECT namespace, id, value FROM data WHERE value IS NOT NULL AND JSON_VALID(value)
+    UNION ALL
+    SELECT nodes.namespace, nodes.id, children.child
+    FROM nodes, JSON_TABLE(
+      IF(JSON_TYPE(nodes.node) = 'OBJECT', JSON_EXTRACT(nodes.node, '$.*'), nodes.node),
+      '$[*]' COLUMNS (child JSON PATH '$')
+    ) AS children
+    WHERE JSON_TYPE(nodes.node) IN ('OBJECT', 'ARRAY')
+  )
+  SELECT namespace, 'data', id, GROUP_CONCAT(JSON_UNQUOTE(node) SEPARATOR ' ')
+  FROM nodes
+  WHERE JSON_TYPE(node) IN ('STRING', 'INTEGER', 'UNSIGNED INTEGER', 'DOUBLE', 'DECIMAL')
+  GROUP BY namespace, id;
@@ -0,0 +1,14 @@
+BEGIN;
+CREATE TABLE deadletters (
+  seq               SERIAL          PRIMARY KEY,
+  id                UUID            NOT NULL,
+  namespace         VARCHAR(64)     NOT NULL,
+  subscription_id   UUID            NOT NULL,
+  event_id          UUID            NOT NULL,
+  reason            TEXT            NOT NULL,
+  created           BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX deadletters_id ON deadletters(namespace,id);
+CREATE INDEX deadletters_subscription ON deadletters(namespace,subscription_id);
+COMMIT;
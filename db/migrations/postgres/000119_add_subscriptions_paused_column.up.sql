@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE subscriptions ADD COLUMN paused BOOLEAN DEFAULT false;
+COMMIT;
@@ -0,0 +1,29 @@
+BEGIN;
+CREATE TABLE search_index (
+  seq         SERIAL          PRIMARY KEY,
+  namespace   VARCHAR(64)     NOT NULL,
+  ref_type    VARCHAR(64)     NOT NULL,
+  ref_id      UUID            NOT NULL,
+  content     TEXT            NOT NULL,
+  content_tsv TSVECTOR        GENERATED ALWAYS AS (to_tsvector('simple', content)) STORED
+);
+
+CREATE INDEX search_index_ref ON search_index(namespace,ref_type,ref_id);
+CREATE INDEX search_index_content ON search_index USING GIN (content_tsv);
+
+INSERT INTO search_index (namespace, ref_type, ref_id, content)
+  SELECT namespace_local, 'message', id, trim(tag || ' ' || author || ' ' || replace(topics, ',', ' '))
+  FROM messages;
+
+INSERT INTO search_index (namespace, ref_type, ref_id, content)
+  SELECT namespace, 'data', id, content FROM (
+    SELECT data.namespace, data.id, (
+      SELECT string_agg(word #>> '{}', ' ')
+      FROM jsonb_path_query(data.value::jsonb, 'strict $.**') AS word
+      WHERE jsonb_typeof(word) IN ('string', 'number')
+    ) AS content
+    FROM data
+    WHERE data.value IS NOT NULL
+  ) AS words
+  WHERE content IS NOT NULL;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE blobs DROP COLUMN exchange_ref;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE blobs ADD COLUMN exchange_ref VARCHAR(1024) DEFAULT '';
+COMMIT;
@@ -0,0 +1,23 @@
+CREATE VIRTUAL TABLE search_index USING fts4(
+  namespace,
+  ref_type,
+  ref_id,
+  content,
+  notindexed=namespace,
+  notindexed=ref_type,
+  notindexed=ref_id
+);
+
+INSERT INTO search_index (namespace, ref_type, ref_id, content)
+  SELECT namespace_local, 'message', id, trim(tag || ' ' || author || ' ' || replace(topics, ',', ' '))
+  FROM messages;
+
+INSERT INTO search_index (namespace, ref_type, ref_id, content)
+  SELECT namespace, 'data', id, content FROM (
+    SELECT namespace, id, (
+      SELECT group_concat(atom, ' ') FROM json_tree(data.value) WHERE type IN ('text', 'integer', 'real')
+    ) AS content
+    FROM data
+    WHERE value IS NOT NULL AND json_valid(value)
+  )
+  WHERE content IS NOT NULL;
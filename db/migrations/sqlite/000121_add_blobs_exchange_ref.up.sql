@@ -0,0 +1 @@
+ALTER TABLE blobs ADD COLUMN exchange_ref VARCHAR(1024) DEFAULT '';
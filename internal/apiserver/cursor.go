@@ -0,0 +1,63 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// The cursor is opaque to API clients, so that its contents can change in future
+func encodeCursor(pos *database.CursorPosition) string {
+	b, _ := json.Marshal(pos)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(ctx context.Context, cursor string) (*database.CursorPosition, error) {
+	var pos database.CursorPosition
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		err = json.Unmarshal(b, &pos)
+	}
+	if err != nil || pos.Sequence < 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInvalidCursor, cursor)
+	}
+	return &pos, nil
+}
+
+func cursorPagingHandler(r *ffapi.APIRequest, cr *coreRequest, handler func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error)) (output interface{}, err error) {
+	qc := &database.QueryCursor{}
+	if after := r.QP["after"]; after != "" {
+		if qc.After, err = decodeCursor(cr.ctx, after); err != nil {
+			return nil, err
+		}
+	}
+	cr.ctx = database.WithQueryCursor(cr.ctx, qc)
+	output, err = handler(r, cr)
+	// The database only sets the next position when the page is full, so there is no cursor on the last page
+	if err == nil && qc.Next != nil {
+		r.ResponseHeaders.Set(core.HTTPHeadersCursor, encodeCursor(qc.Next))
+	}
+	return output, err
+}
@@ -0,0 +1,89 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCursorPaging(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	after := encodeCursor(&database.CursorPosition{Sequence: 12, Descending: true})
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/operations?limit=2&after="+after, nil)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+
+	o.On("GetOperations", mock.MatchedBy(func(ctx context.Context) bool {
+		qc := database.GetQueryCursor(ctx)
+		return qc != nil && qc.After.Sequence == 12 && qc.After.Descending
+	}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			qc := database.GetQueryCursor(args[0].(context.Context))
+			qc.Next = &database.CursorPosition{Sequence: 10, Descending: true}
+		}).
+		Return([]*core.Operation{{}, {}}, nil, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	next, err := decodeCursor(context.Background(), res.Result().Header.Get(core.HTTPHeadersCursor))
+	assert.NoError(t, err)
+	assert.Equal(t, &database.CursorPosition{Sequence: 10, Descending: true}, next)
+}
+
+func TestCursorPagingNoNextPage(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/events", nil)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+
+	o.On("GetEvents", mock.MatchedBy(func(ctx context.Context) bool {
+		qc := database.GetQueryCursor(ctx)
+		return qc != nil && qc.After == nil
+	}), mock.Anything).
+		Return([]*core.Event{}, nil, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Empty(t, res.Result().Header.Get(core.HTTPHeadersCursor))
+}
+
+func TestCursorPagingInvalidCursor(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/transactions?after=!!!", nil)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 400, res.Result().StatusCode)
+	assert.Regexp(t, "FF10540", res.Body.String())
+}
+
+func TestDecodeCursorNegativeSequence(t *testing.T) {
+	_, err := decodeCursor(context.Background(), encodeCursor(&database.CursorPosition{Sequence: -1}))
+	assert.Regexp(t, "FF10540", err)
+}
@@ -0,0 +1,170 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+const ndjsonContentType = "application/x-ndjson"
+
+// exportError is written as the last line of an export that fails after the response has started,
+// as the HTTP status can no longer be changed. The cursor allows the export to be resumed.
+type exportError struct {
+	Error  string `json:"error"`
+	Cursor string `json:"cursor,omitempty"`
+}
+
+type exportPageFn[T any] func(ctx context.Context, filter ffapi.AndFilter) ([]T, *ffapi.FilterResult, error)
+
+type exporter[T any] struct {
+	ctx      context.Context
+	filter   ffapi.AndFilter
+	pageSize int
+	getPage  exportPageFn[T]
+	after    *database.CursorPosition
+}
+
+// exportHandler streams every record that matches the filter of the request as newline-delimited JSON,
+// reading them from the database a page at a time using a query cursor. The first page is read before
+// the response starts, so that an invalid query is reported with an error status.
+func exportHandler[T any](r *ffapi.APIRequest, cr *coreRequest, getPage exportPageFn[T]) (io.ReadCloser, error) {
+	e := &exporter[T]{
+		filter:   r.Filter,
+		pageSize: config.GetInt(coreconfig.APIExportPageSize),
+		getPage:  getPage,
+	}
+
+	// The sort, skip and limit of the request are replaced, as the export walks the whole result set in sequence order
+	fi, err := e.filter.Finalize()
+	if err != nil {
+		return nil, err
+	}
+	if len(fi.Sort) == 0 {
+		e.filter.Sort("sequence")
+	} else if len(fi.Sort) > 1 || (fi.Sort[0].Field != "sequence" && fi.Sort[0].Field != "seq") {
+		return nil, i18n.NewError(cr.ctx, coremsgs.MsgCursorRequiresSequenceSort)
+	}
+	e.filter.Skip(0).Limit(uint64(e.pageSize)).Count(false)
+	if after := r.QP["after"]; after != "" {
+		if e.after, err = decodeCursor(cr.ctx, after); err != nil {
+			return nil, err
+		}
+	}
+
+	// Once the response has started, the export continues for as long as the client reads it, rather than
+	// being bound by the request timeout
+	e.ctx = context.WithoutCancel(cr.ctx)
+	page, more, err := e.nextPage(cr.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.ResponseHeaders.Set("Content-Type", ndjsonContentType)
+	compress := strings.EqualFold(r.QP["gzip"], "true")
+	if compress {
+		r.ResponseHeaders.Set("Content-Encoding", "gzip")
+	}
+	reader, writer := io.Pipe()
+	go e.stream(writer, compress, page, more)
+	return reader, nil
+}
+
+func (e *exporter[T]) nextPage(ctx context.Context) (page []T, more bool, err error) {
+	qc := &database.QueryCursor{After: e.after}
+	page, _, err = e.getPage(database.WithQueryCursor(ctx, qc), e.filter)
+	if err != nil {
+		return nil, false, err
+	}
+	if qc.Next != nil {
+		e.after = qc.Next
+	}
+	return page, len(page) == e.pageSize && qc.Next != nil, nil
+}
+
+func (e *exporter[T]) stream(pw *io.PipeWriter, compress bool, page []T, more bool) {
+	var out io.Writer = pw
+	var gz *gzip.Writer
+	if compress {
+		gz = gzip.NewWriter(pw)
+		out = gz
+	}
+	bw := bufio.NewWriter(out)
+	enc := json.NewEncoder(bw)
+
+	count := 0
+	err := e.writePage(enc, page)
+	for err == nil && more {
+		count += len(page)
+		if err = bw.Flush(); err == nil {
+			page, more, err = e.nextPage(e.ctx)
+			if err != nil {
+				err = e.writeError(enc, err)
+			} else {
+				err = e.writePage(enc, page)
+			}
+		}
+	}
+	if err == nil {
+		count += len(page)
+		err = bw.Flush()
+	}
+	if err == nil && gz != nil {
+		err = gz.Close()
+	}
+	if err != nil {
+		// The client has gone away, or the failure has already been reported in the stream
+		log.L(e.ctx).Warnf("Export stopped after %d records: %s", count, err)
+		_ = pw.CloseWithError(err)
+		return
+	}
+	log.L(e.ctx).Infof("Exported %d records", count)
+	_ = pw.Close()
+}
+
+func (e *exporter[T]) writePage(enc *json.Encoder, page []T) error {
+	for _, record := range page {
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeError reports a failure to read the next page as the last line of the export. The stream is then
+// completed normally, so that the line is not lost in a truncated or corrupted response.
+func (e *exporter[T]) writeError(enc *json.Encoder, err error) error {
+	log.L(e.ctx).Errorf("Export failed: %s", err)
+	ee := &exportError{Error: err.Error()}
+	if e.after != nil {
+		ee.Cursor = encodeCursor(e.after)
+	}
+	return enc.Encode(ee)
+}
@@ -0,0 +1,223 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func exportCursorAfter(seq int64) interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		qc := database.GetQueryCursor(ctx)
+		if seq < 0 {
+			return qc != nil && qc.After == nil
+		}
+		return qc != nil && qc.After != nil && qc.After.Sequence == seq
+	})
+}
+
+func setExportNext(seq int64) func(args mock.Arguments) {
+	return func(args mock.Arguments) {
+		qc := database.GetQueryCursor(args[0].(context.Context))
+		qc.Next = &database.CursorPosition{Sequence: seq}
+	}
+}
+
+func readNDJSON(t *testing.T, r io.Reader) []fftypes.JSONObject {
+	var lines []fftypes.JSONObject
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var line fftypes.JSONObject
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestExportMessages(t *testing.T) {
+	o, r := newTestAPIServer()
+	config.Set(coreconfig.APIExportPageSize, 2)
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/messages/export?topics=topic1", nil)
+	res := httptest.NewRecorder()
+
+	msgs := []*core.Message{
+		{Header: core.MessageHeader{ID: fftypes.NewUUID()}},
+		{Header: core.MessageHeader{ID: fftypes.NewUUID()}},
+		{Header: core.MessageHeader{ID: fftypes.NewUUID()}},
+	}
+	o.On("GetMessages", exportCursorAfter(-1), mock.Anything).
+		Run(setExportNext(2)).
+		Return(msgs[0:2], nil, nil).Once()
+	o.On("GetMessages", exportCursorAfter(2), mock.Anything).
+		Run(setExportNext(3)).
+		Return(msgs[2:], nil, nil).Once()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Equal(t, "application/x-ndjson", res.Result().Header.Get("Content-Type"))
+	lines := readNDJSON(t, res.Body)
+	assert.Len(t, lines, 3)
+	for i, line := range lines {
+		assert.Equal(t, msgs[i].Header.ID.String(), line.GetObject("header").GetString("id"))
+	}
+
+	filter := o.Calls[1].Arguments[1].(ffapi.AndFilter)
+	fi, err := filter.Finalize()
+	assert.NoError(t, err)
+	assert.Equal(t, "sequence", fi.Sort[0].Field)
+	assert.Equal(t, uint64(2), fi.Limit)
+	o.AssertExpectations(t)
+}
+
+func TestExportMessagesWithDataGzip(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	after := encodeCursor(&database.CursorPosition{Sequence: 10})
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/messages/export?fetchdata&gzip&sort=sequence&after="+after, nil)
+	res := httptest.NewRecorder()
+
+	msg := &core.MessageInOut{
+		InlineData: core.InlineData{{Value: fftypes.JSONAnyPtr(`"some data"`)}},
+	}
+	o.On("GetMessagesWithData", exportCursorAfter(10), mock.Anything).
+		Return([]*core.MessageInOut{msg}, nil, nil).Once()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Equal(t, "gzip", res.Result().Header.Get("Content-Encoding"))
+	gz, err := gzip.NewReader(res.Body)
+	assert.NoError(t, err)
+	lines := readNDJSON(t, gz)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, "some data", lines[0].GetObjectArray("data")[0].GetString("value"))
+	o.AssertExpectations(t)
+}
+
+func TestExportEventsFailNextPage(t *testing.T) {
+	o, r := newTestAPIServer()
+	config.Set(coreconfig.APIExportPageSize, 1)
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/events/export?descending", nil)
+	res := httptest.NewRecorder()
+
+	o.On("GetEvents", exportCursorAfter(-1), mock.Anything).
+		Run(setExportNext(5)).
+		Return([]*core.Event{{ID: fftypes.NewUUID()}}, nil, nil).Once()
+	o.On("GetEvents", exportCursorAfter(5), mock.Anything).
+		Return(nil, nil, fmt.Errorf("pop")).Once()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	lines := readNDJSON(t, res.Body)
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "pop", lines[1].GetString("error"))
+	next, err := decodeCursor(context.Background(), lines[1].GetString("cursor"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), next.Sequence)
+	o.AssertExpectations(t)
+}
+
+func TestExportEventsWithReferences(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/events/export?fetchreferences", nil)
+	res := httptest.NewRecorder()
+
+	o.On("GetEventsWithReferences", exportCursorAfter(-1), mock.Anything).
+		Return([]*core.EnrichedEvent{{Event: core.Event{ID: fftypes.NewUUID()}}}, nil, nil).Once()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Len(t, readNDJSON(t, res.Body), 1)
+	o.AssertExpectations(t)
+}
+
+func TestExportEventsFailFirstPage(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/events/export", nil)
+	res := httptest.NewRecorder()
+
+	o.On("GetEvents", mock.Anything, mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 500, res.Result().StatusCode)
+	assert.Regexp(t, "pop", res.Body.String())
+}
+
+func TestExportBadSort(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/messages/export?sort=created", nil)
+	res := httptest.NewRecorder()
+
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 400, res.Result().StatusCode)
+	assert.Regexp(t, "FF10539", res.Body.String())
+}
+
+func TestExportBadFilterValue(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/messages/export?created=notadate", nil)
+	res := httptest.NewRecorder()
+
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 400, res.Result().StatusCode)
+}
+
+func TestExportBadCursor(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/messages/export?after=!!!", nil)
+	res := httptest.NewRecorder()
+
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 400, res.Result().StatusCode)
+	assert.Regexp(t, "FF10540", res.Body.String())
+}
+
+func TestExportClientGone(t *testing.T) {
+	e := &exporter[*core.Event]{
+		ctx:      context.Background(),
+		pageSize: 1,
+	}
+	pr, pw := io.Pipe()
+	pr.Close()
+	e.stream(pw, false, []*core.Event{{ID: fftypes.NewUUID(), Topic: strings.Repeat("a", 8192)}}, true)
+}
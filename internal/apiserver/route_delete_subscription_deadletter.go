@@ -0,0 +1,45 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+var deleteSubscriptionDeadLetter = &ffapi.Route{
+	Name:   "deleteSubscriptionDeadLetter",
+	Path:   "subscriptions/{subid}/deadletters/{dlid}",
+	Method: http.MethodDelete,
+	PathParams: []*ffapi.PathParam{
+		{Name: "subid", Description: coremsgs.APIParamsSubscriptionID},
+		{Name: "dlid", Description: coremsgs.APIParamsDeadLetterID},
+	},
+	QueryParams:     nil,
+	Description:     coremsgs.APIEndpointsDeleteSubscriptionDeadLetter,
+	JSONInputValue:  nil,
+	JSONOutputValue: nil,
+	JSONOutputCodes: []int{http.StatusNoContent}, // Sync operation, no output
+	Extensions: &coreExtensions{
+		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			err = cr.or.DeleteSubscriptionDeadLetter(cr.ctx, r.PP["subid"], r.PP["dlid"])
+			return nil, err
+		},
+	},
+}
@@ -26,17 +26,20 @@ import (
 )
 
 var getBlockchainEvents = &ffapi.Route{
-	Name:            "getBlockchainEvents",
-	Path:            "blockchainevents",
-	Method:          http.MethodGet,
-	PathParams:      nil,
-	QueryParams:     nil,
+	Name:       "getBlockchainEvents",
+	Path:       "blockchainevents",
+	Method:     http.MethodGet,
+	PathParams: nil,
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "after", Description: coremsgs.APICursorAfterParam},
+	},
 	FilterFactory:   database.BlockchainEventQueryFactory,
 	Description:     coremsgs.APIEndpointsListBlockchainEvents,
 	JSONInputValue:  nil,
 	JSONOutputValue: func() interface{} { return []*core.BlockchainEvent{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		CursorPaging: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			return r.FilterResult(cr.or.GetBlockchainEvents(cr.ctx, r.Filter))
 		},
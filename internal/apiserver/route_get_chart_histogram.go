@@ -45,6 +45,7 @@ var getChartHistogram = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return []*core.ChartHistogram{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		ReadReplica: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			startTime, err := fftypes.ParseTimeString(r.QP["startTime"])
 			if err != nil {
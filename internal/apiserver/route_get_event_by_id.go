@@ -40,6 +40,7 @@ var getEventByID = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return &core.Event{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		ReadReplica: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			if strings.EqualFold(r.QP["fetchreference"], "true") {
 				return cr.or.GetEventByIDWithReference(cr.ctx, r.PP["eid"])
@@ -34,6 +34,7 @@ var getEvents = &ffapi.Route{
 	QueryParams: []*ffapi.QueryParam{
 		{Name: "fetchreferences", Example: "true", Description: coremsgs.APIParamsFetchReferences, IsBool: true},
 		{Name: "fetchreference", Example: "true", Description: coremsgs.APIParamsFetchReference, IsBool: true},
+		{Name: "after", Description: coremsgs.APICursorAfterParam},
 	},
 	FilterFactory:   database.EventQueryFactory,
 	Description:     coremsgs.APIEndpointsGetEvents,
@@ -41,6 +42,8 @@ var getEvents = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return []*core.Event{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		ReadReplica:  true,
+		CursorPaging: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			if strings.EqualFold(r.QP["fetchreferences"], "true") || strings.EqualFold(r.QP["fetchreference"], "true") {
 				return r.FilterResult(cr.or.GetEventsWithReferences(cr.ctx, r.Filter))
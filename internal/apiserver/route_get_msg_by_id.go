@@ -40,6 +40,7 @@ var getMsgByID = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return &core.MessageInOut{} }, // can include full values
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		ReadReplica: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			if strings.EqualFold(r.QP["data"], "true") || strings.EqualFold(r.QP["fetchdata"], "true") {
 				return cr.or.GetMessageByIDWithData(cr.ctx, r.PP["msgid"])
@@ -38,6 +38,7 @@ var getMsgData = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return core.DataArray{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		ReadReplica: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			output, err = cr.or.GetMessageData(cr.ctx, r.PP["msgid"])
 			return output, err
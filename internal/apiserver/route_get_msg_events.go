@@ -39,6 +39,7 @@ var getMsgEvents = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return []*core.Event{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		ReadReplica: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			return r.FilterResult(cr.or.GetMessageEvents(cr.ctx, r.PP["msgid"], r.Filter))
 		},
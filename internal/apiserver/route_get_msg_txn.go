@@ -37,6 +37,7 @@ var getMsgTxn = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return &core.Transaction{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		ReadReplica: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			output, err = cr.or.GetMessageTransaction(cr.ctx, r.PP["msgid"])
 			return output, err
@@ -0,0 +1,53 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+var exportMsgs = &ffapi.Route{
+	Name:       "exportMsgs",
+	Path:       "messages/export",
+	Method:     http.MethodGet,
+	PathParams: nil,
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "fetchdata", IsBool: true, Description: coremsgs.APIFetchDataDesc},
+		{Name: "gzip", IsBool: true, Description: coremsgs.APIExportGzipParam},
+		{Name: "after", Description: coremsgs.APICursorAfterParam},
+	},
+	FilterFactory:   database.MessageQueryFactory,
+	Description:     coremsgs.APIEndpointsExportMsgs,
+	JSONInputValue:  nil,
+	JSONOutputValue: func() interface{} { return []byte{} },
+	JSONOutputCodes: []int{http.StatusOK},
+	Extensions: &coreExtensions{
+		ReadReplica:    true,
+		StreamResponse: true,
+		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			if strings.EqualFold(r.QP["fetchdata"], "true") {
+				return exportHandler(r, cr, cr.or.GetMessagesWithData)
+			}
+			return exportHandler(r, cr, cr.or.GetMessages)
+		},
+	},
+}
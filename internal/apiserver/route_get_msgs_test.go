@@ -23,6 +23,7 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -34,7 +35,7 @@ func TestGetMessages(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	res := httptest.NewRecorder()
 
-	o.On("GetMessages", mock.Anything, mock.Anything).
+	o.On("GetMessages", mock.MatchedBy(database.IsReadReplica), mock.Anything).
 		Return([]*core.Message{}, nil, nil)
 	r.ServeHTTP(res, req)
 
@@ -26,17 +26,20 @@ import (
 )
 
 var getOps = &ffapi.Route{
-	Name:            "getOps",
-	Path:            "operations",
-	Method:          http.MethodGet,
-	PathParams:      nil,
-	QueryParams:     nil,
+	Name:       "getOps",
+	Path:       "operations",
+	Method:     http.MethodGet,
+	PathParams: nil,
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "after", Description: coremsgs.APICursorAfterParam},
+	},
 	FilterFactory:   database.OperationQueryFactory,
 	Description:     coremsgs.APIEndpointsGetOps,
 	JSONInputValue:  nil,
 	JSONOutputValue: func() interface{} { return []*core.Operation{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		CursorPaging: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			return r.FilterResult(cr.or.GetOperations(cr.ctx, r.Filter))
 		},
@@ -18,6 +18,7 @@ package apiserver
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly/internal/coremsgs"
@@ -26,11 +27,13 @@ import (
 )
 
 var getSubscriptions = &ffapi.Route{
-	Name:            "getSubscriptions",
-	Path:            "subscriptions",
-	Method:          http.MethodGet,
-	PathParams:      nil,
-	QueryParams:     nil,
+	Name:       "getSubscriptions",
+	Path:       "subscriptions",
+	Method:     http.MethodGet,
+	PathParams: nil,
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "fetchstatus", Description: coremsgs.APIParamsFetchStatus, IsBool: true},
+	},
 	FilterFactory:   database.SubscriptionQueryFactory,
 	Description:     coremsgs.APIEndpointsGetSubscriptions,
 	JSONInputValue:  nil,
@@ -38,6 +41,9 @@ var getSubscriptions = &ffapi.Route{
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			if strings.EqualFold(r.QP["fetchstatus"], "true") {
+				return r.FilterResult(cr.or.GetSubscriptionsWithStatus(cr.ctx, r.Filter))
+			}
 			return r.FilterResult(cr.or.GetSubscriptions(cr.ctx, r.Filter))
 		},
 	},
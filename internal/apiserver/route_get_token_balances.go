@@ -37,6 +37,7 @@ var getTokenBalances = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return []*core.TokenBalance{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		ReadReplica: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			return r.FilterResult(cr.or.Assets().GetTokenBalances(cr.ctx, r.Filter))
 		},
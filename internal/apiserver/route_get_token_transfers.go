@@ -32,6 +32,7 @@ var getTokenTransfers = &ffapi.Route{
 	PathParams: nil,
 	QueryParams: []*ffapi.QueryParam{
 		{Name: "fromOrTo", Description: coremsgs.APIParamsTokenTransferFromOrTo},
+		{Name: "after", Description: coremsgs.APICursorAfterParam},
 	},
 	FilterFactory:   database.TokenTransferQueryFactory,
 	Description:     coremsgs.APIEndpointsGetTokenTransfers,
@@ -39,6 +40,7 @@ var getTokenTransfers = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return []*core.TokenTransfer{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		CursorPaging: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			filter := r.Filter
 			if fromOrTo, ok := r.QP["fromOrTo"]; ok {
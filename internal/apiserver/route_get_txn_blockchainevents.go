@@ -37,6 +37,7 @@ var getTxnBlockchainEvents = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return &[]*core.BlockchainEvent{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		ReadReplica: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			return r.FilterResult(cr.or.GetTransactionBlockchainEvents(cr.ctx, r.PP["txnid"]))
 		},
@@ -39,6 +39,7 @@ var getTxnByID = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return &core.Transaction{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		ReadReplica: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			output, err = cr.or.GetTransactionByID(cr.ctx, r.PP["txnid"])
 			return output, err
@@ -37,6 +37,7 @@ var getTxnOps = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return &[]*core.Operation{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		ReadReplica: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			return r.FilterResult(cr.or.GetTransactionOperations(cr.ctx, r.PP["txnid"]))
 		},
@@ -26,17 +26,21 @@ import (
 )
 
 var getTxns = &ffapi.Route{
-	Name:            "getTxns",
-	Path:            "transactions",
-	Method:          http.MethodGet,
-	PathParams:      nil,
-	QueryParams:     nil,
+	Name:       "getTxns",
+	Path:       "transactions",
+	Method:     http.MethodGet,
+	PathParams: nil,
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "after", Description: coremsgs.APICursorAfterParam},
+	},
 	FilterFactory:   database.TransactionQueryFactory,
 	Description:     coremsgs.APIEndpointsGetTxns,
 	JSONInputValue:  nil,
 	JSONOutputValue: func() interface{} { return []*core.Transaction{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		ReadReplica:  true,
+		CursorPaging: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			return r.FilterResult(cr.or.GetTransactions(cr.ctx, r.Filter))
 		},
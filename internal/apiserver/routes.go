@@ -37,6 +37,12 @@ type coreExtensions struct {
 	EnabledIf             func(or orchestrator.Orchestrator) bool
 	CoreJSONHandler       func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error)
 	CoreFormUploadHandler func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error)
+	// ReadReplica routes the read only database queries of the handler to the read replica, if one is configured
+	ReadReplica bool
+	// CursorPaging accepts a cursor in the "after" query param, and returns the cursor for the next page in a response header
+	CursorPaging bool
+	// StreamResponse removes the write timeout of the HTTP server, for a response that is streamed for as long as the client reads it
+	StreamResponse bool
 }
 
 const (
@@ -58,7 +64,10 @@ var routes = append(
 		deleteContractListener,
 		deleteData,
 		deleteSubscription,
+		deleteSubscriptionDeadLetter,
 		deleteTokenPool,
+		exportEvents,
+		exportMsgs,
 		getBatchByID,
 		getBatches,
 		getBlockchainEventByID,
@@ -106,10 +115,12 @@ var routes = append(
 		getOpByID,
 		getOps,
 		getPins,
+		getSearch,
 		getStatus,
 		getStatusMultiparty,
 		getStatusBatchManager,
 		getSubscriptionByID,
+		getSubscriptionDeadLetters,
 		getSubscriptions,
 		getSubscriptionEventsFiltered,
 		getTokenAccountPools,
@@ -158,6 +169,10 @@ var routes = append(
 		postNodesSelf,
 		postOpRetry,
 		postPinsRewind,
+		postSubscriptionDeadLetterReplay,
+		postSubscriptionPause,
+		postSubscriptionResume,
+		postSubscriptionRewind,
 		postTokenApproval,
 		postTokenBurn,
 		postTokenMint,
@@ -18,6 +18,7 @@ package apiserver
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -34,10 +35,13 @@ import (
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/events/eifactory"
+	"github.com/hyperledger/firefly/internal/events/sse"
 	"github.com/hyperledger/firefly/internal/events/websockets"
 	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/namespace"
 	"github.com/hyperledger/firefly/internal/orchestrator"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -246,6 +250,12 @@ func (as *apiServer) routeHandler(hf *ffapi.HandlerFactory, mgr namespace.Manage
 			ctx:        r.Req.Context(),
 			apiBaseURL: apiBaseURL,
 		}
+		if ce.ReadReplica {
+			cr.ctx = database.WithReadReplica(cr.ctx)
+		}
+		if ce.CursorPaging {
+			return cursorPagingHandler(r, cr, ce.CoreJSONHandler)
+		}
 		return ce.CoreJSONHandler(r, cr)
 	}
 	if ce.CoreFormUploadHandler != nil {
@@ -271,7 +281,16 @@ func (as *apiServer) routeHandler(hf *ffapi.HandlerFactory, mgr namespace.Manage
 			return ce.CoreFormUploadHandler(r, cr)
 		}
 	}
-	return hf.RouteHandler(route)
+	handler := hf.RouteHandler(route)
+	if ce.StreamResponse {
+		return func(res http.ResponseWriter, req *http.Request) {
+			if err := http.NewResponseController(res).SetWriteDeadline(time.Time{}); err != nil {
+				log.L(req.Context()).Warnf("Unable to remove write deadline for streamed response: %s", err)
+			}
+			handler(res, req)
+		}
+	}
+	return handler
 }
 
 func (as *apiServer) handlerFactory() *ffapi.HandlerFactory {
@@ -405,6 +424,13 @@ func (as *apiServer) createMuxRouter(ctx context.Context, mgr namespace.Manager)
 	// namespace scoped web sockets
 	r.HandleFunc("/api/v1/namespaces/{ns}/ws", hf.APIWrapper(getNamespacedWebSocketHandler(ws.(*websockets.WebSockets), mgr)))
 
+	// namespace scoped server-sent events, for clients that cannot upgrade to a websocket.
+	// The stream is not wrapped by the API handler, as it is held open beyond the request timeout.
+	sseEvents, _ := eifactory.GetPlugin(ctx, "sse")
+	sseEvents.(*sse.SSE).SetAuthorizer(mgr)
+	r.HandleFunc("/api/v1/namespaces/{ns}/sse", getNamespacedSSEHandler(sseEvents.(*sse.SSE))).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/namespaces/{ns}/sse/{connid}/ack", hf.APIWrapper(getNamespacedSSEAckHandler(sseEvents.(*sse.SSE)))).Methods(http.MethodPost)
+
 	uiPath := config.GetString(coreconfig.UIPath)
 	if uiPath != "" && config.GetBool(coreconfig.UIEnabled) {
 		r.PathPrefix(`/ui`).Handler(newStaticHandler(uiPath, "index.html", `/ui`))
@@ -431,6 +457,27 @@ func getNamespacedWebSocketHandler(ws websockets.WebSocketsNamespaced, mgr names
 
 }
 
+func getNamespacedSSEHandler(s sse.SSENamespaced) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		s.ServeHTTPNamespaced(mux.Vars(req)["ns"], res, req)
+	}
+}
+
+func getNamespacedSSEAckHandler(s sse.SSENamespaced) ffapi.HandlerFunction {
+	return func(res http.ResponseWriter, req *http.Request) (status int, err error) {
+		vars := mux.Vars(req)
+		var ack core.WSAck
+		if err := json.NewDecoder(req.Body).Decode(&ack); err != nil {
+			return 400, i18n.WrapError(req.Context(), err, coremsgs.MsgWSClientSentInvalidData)
+		}
+		if err := s.AckNamespaced(req.Context(), vars["ns"], vars["connid"], req.Header, &ack); err != nil {
+			return 400, err
+		}
+		res.WriteHeader(http.StatusNoContent)
+		return 204, nil
+	}
+}
+
 func (as *apiServer) notFoundHandler(res http.ResponseWriter, req *http.Request) (status int, err error) {
 	res.Header().Add("Content-Type", "application/json")
 	return 404, i18n.NewError(req.Context(), coremsgs.Msg404NotFound)
@@ -44,6 +44,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/namespacemocks"
 	"github.com/hyperledger/firefly/mocks/orchestratormocks"
 	"github.com/hyperledger/firefly/mocks/spieventsmocks"
+	"github.com/hyperledger/firefly/mocks/ssemocks"
 	"github.com/hyperledger/firefly/mocks/websocketsmocks"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/stretchr/testify/assert"
@@ -550,6 +551,63 @@ func TestGetNamespacedWebSocketHandlerUnknownNamespace(t *testing.T) {
 	assert.Equal(t, 404, status)
 }
 
+func TestGetNamespacedSSEHandler(t *testing.T) {
+	msse := ssemocks.NewSSENamespaced(t)
+	msse.On("ServeHTTPNamespaced", "ns1", mock.Anything, mock.Anything).Return()
+
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/sse?ephemeral", nil)
+	req = mux.SetURLVars(req, map[string]string{"ns": "ns1"})
+	res := httptest.NewRecorder()
+
+	handler := getNamespacedSSEHandler(msse)
+	handler(res, req)
+}
+
+func TestGetNamespacedSSEAckHandler(t *testing.T) {
+	msse := ssemocks.NewSSENamespaced(t)
+	ackID := fftypes.NewUUID()
+	msse.On("AckNamespaced", mock.Anything, "ns1", "conn1", mock.Anything, mock.MatchedBy(func(ack *core.WSAck) bool {
+		return ack.ID.Equals(ackID)
+	})).Return(nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/sse/conn1/ack", bytes.NewReader([]byte(fmt.Sprintf(`{"id":"%s"}`, ackID))))
+	req = mux.SetURLVars(req, map[string]string{"ns": "ns1", "connid": "conn1"})
+	res := httptest.NewRecorder()
+
+	handler := getNamespacedSSEAckHandler(msse)
+	status, err := handler(res, req)
+	assert.NoError(t, err)
+	assert.Equal(t, 204, status)
+	assert.Equal(t, 204, res.Result().StatusCode)
+}
+
+func TestGetNamespacedSSEAckHandlerBadBody(t *testing.T) {
+	msse := ssemocks.NewSSENamespaced(t)
+
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/sse/conn1/ack", bytes.NewReader([]byte(`!json`)))
+	req = mux.SetURLVars(req, map[string]string{"ns": "ns1", "connid": "conn1"})
+	res := httptest.NewRecorder()
+
+	handler := getNamespacedSSEAckHandler(msse)
+	status, err := handler(res, req)
+	assert.Regexp(t, "FF10176", err)
+	assert.Equal(t, 400, status)
+}
+
+func TestGetNamespacedSSEAckHandlerFail(t *testing.T) {
+	msse := ssemocks.NewSSENamespaced(t)
+	msse.On("AckNamespaced", mock.Anything, "ns1", "conn1", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/sse/conn1/ack", bytes.NewReader([]byte(`{}`)))
+	req = mux.SetURLVars(req, map[string]string{"ns": "ns1", "connid": "conn1"})
+	res := httptest.NewRecorder()
+
+	handler := getNamespacedSSEAckHandler(msse)
+	status, err := handler(res, req)
+	assert.Regexp(t, "pop", err)
+	assert.Equal(t, 400, status)
+}
+
 func TestContractAPIDefaultNS(t *testing.T) {
 	mgr, o, as := newTestServer()
 	r := as.createMuxRouter(context.Background(), mgr)
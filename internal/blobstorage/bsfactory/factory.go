@@ -0,0 +1,50 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bsfactory
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/blobstorage/filesystem"
+	"github.com/hyperledger/firefly/internal/blobstorage/s3"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/blobstorage"
+)
+
+var pluginsByName = map[string]func() blobstorage.Plugin{
+	(*filesystem.Filesystem)(nil).Name(): func() blobstorage.Plugin { return &filesystem.Filesystem{} },
+	(*s3.S3)(nil).Name():                 func() blobstorage.Plugin { return &s3.S3{} },
+}
+
+func InitConfig(config config.ArraySection) {
+	config.AddKnownKey(coreconfig.PluginConfigType)
+	config.AddKnownKey(coreconfig.PluginConfigName)
+	for name, plugin := range pluginsByName {
+		plugin().InitConfig(config.SubSection(name))
+	}
+}
+
+func GetPlugin(ctx context.Context, pluginType string) (blobstorage.Plugin, error) {
+	plugin, ok := pluginsByName[pluginType]
+	if !ok {
+		return nil, i18n.NewError(ctx, coremsgs.MsgUnknownBlobStoragePlugin, pluginType)
+	}
+	return plugin(), nil
+}
@@ -0,0 +1,144 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/blobstorage"
+)
+
+// Filesystem stores each blob as a file, in a directory per namespace under the configured path.
+// Payload references are relative to the configured path, so the directory can be moved.
+type Filesystem struct {
+	ctx          context.Context
+	capabilities *blobstorage.Capabilities
+	path         string
+}
+
+func (fs *Filesystem) Name() string {
+	return "filesystem"
+}
+
+func (fs *Filesystem) Init(ctx context.Context, config config.Section) (err error) {
+	fs.ctx = log.WithLogField(ctx, "blobstorage", "filesystem")
+
+	path := config.GetString(FilesystemConfPath)
+	if path == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, config.Resolve(FilesystemConfPath), "filesystem")
+	}
+	if fs.path, err = filepath.Abs(path); err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgBlobStorageFailed, path)
+	}
+	if err = os.MkdirAll(fs.path, 0700); err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgBlobStorageFailed, path)
+	}
+	fs.capabilities = &blobstorage.Capabilities{}
+	return nil
+}
+
+func (fs *Filesystem) Capabilities() *blobstorage.Capabilities {
+	return fs.capabilities
+}
+
+// filePath returns the path of the file for a payload reference, checking it is within the configured path
+func (fs *Filesystem) filePath(ctx context.Context, payloadRef string) (string, error) {
+	if !blobstorage.IsPayloadRef(fs, payloadRef) {
+		return "", i18n.NewError(ctx, coremsgs.MsgBlobStorageInvalidPayloadRef, payloadRef)
+	}
+	relPath := filepath.FromSlash(strings.TrimPrefix(payloadRef, fs.Name()+"://"))
+	fullPath := filepath.Join(fs.path, relPath)
+	if !filepath.IsLocal(relPath) || !strings.HasPrefix(fullPath, fs.path+string(filepath.Separator)) {
+		return "", i18n.NewError(ctx, coremsgs.MsgBlobStorageInvalidPayloadRef, payloadRef)
+	}
+	return fullPath, nil
+}
+
+func (fs *Filesystem) UploadBlob(ctx context.Context, ns string, id fftypes.UUID, content io.Reader) (payloadRef string, hash *fftypes.Bytes32, size int64, err error) {
+	payloadRef = fmt.Sprintf("%s://%s/%s", fs.Name(), ns, id.String())
+	fullPath, err := fs.filePath(ctx, payloadRef)
+	if err != nil {
+		return "", nil, -1, err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0700); err != nil {
+		return "", nil, -1, i18n.WrapError(ctx, err, coremsgs.MsgBlobStorageFailed, payloadRef)
+	}
+
+	// Write to a temporary file that is renamed once complete, so a partial blob is never visible
+	tmpFile, err := os.CreateTemp(filepath.Dir(fullPath), id.String()+".*.tmp")
+	if err != nil {
+		return "", nil, -1, i18n.WrapError(ctx, err, coremsgs.MsgBlobStorageFailed, payloadRef)
+	}
+	defer func() {
+		_ = tmpFile.Close()
+		if err != nil {
+			_ = os.Remove(tmpFile.Name())
+		}
+	}()
+
+	hashCalc := sha256.New()
+	size, err = io.Copy(io.MultiWriter(tmpFile, hashCalc), content)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	if err == nil {
+		err = tmpFile.Close()
+	}
+	if err == nil {
+		err = os.Rename(tmpFile.Name(), fullPath)
+	}
+	if err != nil {
+		return "", nil, -1, i18n.WrapError(ctx, err, coremsgs.MsgBlobStorageFailed, payloadRef)
+	}
+	log.L(ctx).Infof("Filesystem blob storage wrote %s size=%d", payloadRef, size)
+	return payloadRef, fftypes.HashResult(hashCalc), size, nil
+}
+
+func (fs *Filesystem) DownloadBlob(ctx context.Context, payloadRef string) (io.ReadCloser, error) {
+	fullPath, err := fs.filePath(ctx, payloadRef)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(fullPath)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgBlobStorageFailed, payloadRef)
+	}
+	return f, nil
+}
+
+func (fs *Filesystem) DeleteBlob(ctx context.Context, payloadRef string) error {
+	fullPath, err := fs.filePath(ctx, payloadRef)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+		return i18n.WrapError(ctx, err, coremsgs.MsgBlobStorageFailed, payloadRef)
+	}
+	log.L(ctx).Infof("Filesystem blob storage deleted %s", payloadRef)
+	return nil
+}
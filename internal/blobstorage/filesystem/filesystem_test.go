@@ -0,0 +1,174 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+var utConfig = config.RootSection("filesystem_unit_tests")
+
+func newTestFilesystem(t *testing.T) (*Filesystem, string) {
+	coreconfig.Reset()
+	fs := &Filesystem{}
+	fs.InitConfig(utConfig)
+	dir := filepath.Join(t.TempDir(), "blobs")
+	utConfig.Set(FilesystemConfPath, dir)
+	err := fs.Init(context.Background(), utConfig)
+	assert.NoError(t, err)
+	return fs, dir
+}
+
+func TestName(t *testing.T) {
+	fs := &Filesystem{}
+	assert.Equal(t, "filesystem", fs.Name())
+}
+
+func TestInitMissingPath(t *testing.T) {
+	coreconfig.Reset()
+	fs := &Filesystem{}
+	fs.InitConfig(utConfig)
+	err := fs.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10138", err)
+}
+
+func TestInitBadPath(t *testing.T) {
+	coreconfig.Reset()
+	fs := &Filesystem{}
+	fs.InitConfig(utConfig)
+	file := filepath.Join(t.TempDir(), "file")
+	err := os.WriteFile(file, []byte{}, 0600)
+	assert.NoError(t, err)
+	utConfig.Set(FilesystemConfPath, filepath.Join(file, "blobs"))
+	err = fs.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10544", err)
+}
+
+func TestInitWorkingDirRemoved(t *testing.T) {
+	coreconfig.Reset()
+	fs := &Filesystem{}
+	fs.InitConfig(utConfig)
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+	defer func() { _ = os.Chdir(cwd) }()
+	dir := filepath.Join(t.TempDir(), "removed")
+	assert.NoError(t, os.Mkdir(dir, 0700))
+	assert.NoError(t, os.Chdir(dir))
+	assert.NoError(t, os.Remove(dir))
+
+	// A relative path cannot be resolved once the working directory is gone
+	utConfig.Set(FilesystemConfPath, "blobs")
+	err = fs.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10544", err)
+}
+
+func TestUploadDownloadDelete(t *testing.T) {
+	fs, dir := newTestFilesystem(t)
+	ctx := context.Background()
+	assert.NotNil(t, fs.Capabilities())
+
+	id := fftypes.NewUUID()
+	payloadRef, hash, size, err := fs.UploadBlob(ctx, "ns1", *id, strings.NewReader("some data"))
+	assert.NoError(t, err)
+	assert.Equal(t, "filesystem://ns1/"+id.String(), payloadRef)
+	assert.Equal(t, fftypes.HashString("some data"), hash)
+	assert.Equal(t, int64(9), size)
+	files, _ := os.ReadDir(filepath.Join(dir, "ns1"))
+	assert.Len(t, files, 1)
+
+	reader, err := fs.DownloadBlob(ctx, payloadRef)
+	assert.NoError(t, err)
+	b, _ := io.ReadAll(reader)
+	reader.Close()
+	assert.Equal(t, "some data", string(b))
+
+	err = fs.DeleteBlob(ctx, payloadRef)
+	assert.NoError(t, err)
+	err = fs.DeleteBlob(ctx, payloadRef)
+	assert.NoError(t, err)
+
+	_, err = fs.DownloadBlob(ctx, payloadRef)
+	assert.Regexp(t, "FF10544", err)
+}
+
+func TestUploadReadFail(t *testing.T) {
+	fs, dir := newTestFilesystem(t)
+
+	reader, writer := io.Pipe()
+	writer.CloseWithError(io.ErrUnexpectedEOF)
+	_, _, _, err := fs.UploadBlob(context.Background(), "ns1", *fftypes.NewUUID(), reader)
+	assert.Regexp(t, "FF10544", err)
+	files, _ := os.ReadDir(filepath.Join(dir, "ns1"))
+	assert.Empty(t, files)
+}
+
+func TestUploadBadNamespaceDir(t *testing.T) {
+	fs, dir := newTestFilesystem(t)
+	err := os.WriteFile(filepath.Join(dir, "ns1"), []byte{}, 0600)
+	assert.NoError(t, err)
+
+	_, _, _, err = fs.UploadBlob(context.Background(), "ns1", *fftypes.NewUUID(), strings.NewReader("some data"))
+	assert.Regexp(t, "FF10544", err)
+}
+
+func TestUploadCreateTempFail(t *testing.T) {
+	fs, dir := newTestFilesystem(t)
+	// The namespace directory exists, but files cannot be created in it (even by root)
+	err := os.Symlink("/proc", filepath.Join(dir, "ns1"))
+	assert.NoError(t, err)
+
+	_, _, _, err = fs.UploadBlob(context.Background(), "ns1", *fftypes.NewUUID(), strings.NewReader("some data"))
+	assert.Regexp(t, "FF10544", err)
+}
+
+func TestUploadInvalidNamespace(t *testing.T) {
+	fs, _ := newTestFilesystem(t)
+
+	_, _, _, err := fs.UploadBlob(context.Background(), "..", *fftypes.NewUUID(), strings.NewReader("some data"))
+	assert.Regexp(t, "FF10543", err)
+}
+
+func TestInvalidPayloadRefs(t *testing.T) {
+	fs, _ := newTestFilesystem(t)
+	ctx := context.Background()
+
+	_, err := fs.DownloadBlob(ctx, "s3://bucket1/ns1/blob1")
+	assert.Regexp(t, "FF10543", err)
+	_, err = fs.DownloadBlob(ctx, "filesystem://../outside")
+	assert.Regexp(t, "FF10543", err)
+	err = fs.DeleteBlob(ctx, "filesystem:///etc/passwd")
+	assert.Regexp(t, "FF10543", err)
+}
+
+func TestDeleteFail(t *testing.T) {
+	fs, dir := newTestFilesystem(t)
+	err := os.MkdirAll(filepath.Join(dir, "ns1", "blob1", "child"), 0700)
+	assert.NoError(t, err)
+
+	err = fs.DeleteBlob(context.Background(), "filesystem://ns1/blob1")
+	assert.Regexp(t, "FF10544", err)
+}
@@ -0,0 +1,50 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+)
+
+const (
+	// S3ConfBucket is the bucket blobs are stored in
+	S3ConfBucket = "bucket"
+	// S3ConfRegion is the region of the bucket, used to sign requests
+	S3ConfRegion = "region"
+	// S3ConfPrefix is prepended to the key of every blob
+	S3ConfPrefix = "prefix"
+	// S3ConfAccessKeyID is the access key ID used to sign requests
+	S3ConfAccessKeyID = "accessKeyID"
+	// S3ConfSecretAccessKey is the secret access key used to sign requests
+	S3ConfSecretAccessKey = "secretAccessKey"
+	// S3ConfForcePathStyle addresses the bucket in the path of the URL, rather than as a sub-domain of the host
+	S3ConfForcePathStyle = "forcePathStyle"
+	// S3ConfTempDir is the directory blobs are written to while they are hashed, before they are uploaded
+	S3ConfTempDir = "tempDir"
+)
+
+func (s *S3) InitConfig(config config.Section) {
+	ffresty.InitConfig(config)
+	config.AddKnownKey(S3ConfBucket)
+	config.AddKnownKey(S3ConfRegion, "us-east-1")
+	config.AddKnownKey(S3ConfPrefix)
+	config.AddKnownKey(S3ConfAccessKeyID)
+	config.AddKnownKey(S3ConfSecretAccessKey)
+	config.AddKnownKey(S3ConfForcePathStyle, false)
+	config.AddKnownKey(S3ConfTempDir)
+}
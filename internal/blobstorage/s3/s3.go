@@ -0,0 +1,216 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/blobstorage"
+)
+
+// S3 stores blobs as objects in a bucket of an S3 compatible object store, using the REST API directly
+type S3 struct {
+	ctx             context.Context
+	capabilities    *blobstorage.Capabilities
+	client          *resty.Client
+	baseURL         *url.URL
+	bucket          string
+	prefix          string
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	forcePathStyle  bool
+	tempDir         string
+	now             func() time.Time
+}
+
+// uploadBodyKey is the context key of the body of an upload. The body is attached to the request
+// before each attempt to send it, rather than being set on the resty request, as resty would
+// otherwise read the whole of the body into memory and could not rewind it for a retry.
+type uploadBodyKey struct{}
+
+// uploadBody is the body of an upload, which is rewound before each attempt to send it
+type uploadBody struct {
+	file *os.File
+	size int64
+}
+
+func (b *uploadBody) Read(p []byte) (int, error) {
+	return b.file.Read(p)
+}
+
+// Close does nothing, as the file is closed once all attempts to upload it are complete
+func (b *uploadBody) Close() error {
+	return nil
+}
+
+func (s *S3) Name() string {
+	return "s3"
+}
+
+func (s *S3) Init(ctx context.Context, config config.Section) (err error) {
+	s.ctx = log.WithLogField(ctx, "blobstorage", "s3")
+
+	for _, key := range []string{ffresty.HTTPConfigURL, S3ConfBucket, S3ConfAccessKeyID, S3ConfSecretAccessKey} {
+		if config.GetString(key) == "" {
+			return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, config.Resolve(key), "s3")
+		}
+	}
+	if s.baseURL, err = url.Parse(config.GetString(ffresty.HTTPConfigURL)); err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgMissingPluginConfig, config.Resolve(ffresty.HTTPConfigURL), "s3")
+	}
+	s.bucket = config.GetString(S3ConfBucket)
+	s.prefix = config.GetString(S3ConfPrefix)
+	s.region = config.GetString(S3ConfRegion)
+	s.accessKeyID = config.GetString(S3ConfAccessKeyID)
+	s.secretAccessKey = config.GetString(S3ConfSecretAccessKey)
+	s.forcePathStyle = config.GetBool(S3ConfForcePathStyle)
+	s.tempDir = config.GetString(S3ConfTempDir)
+	s.now = time.Now
+
+	if s.client, err = ffresty.New(s.ctx, config); err != nil {
+		return err
+	}
+	// Requests are signed just before they are sent, so that each retry has a fresh signature
+	s.client.SetPreRequestHook(s.beforeSend)
+	s.capabilities = &blobstorage.Capabilities{}
+	return nil
+}
+
+func (s *S3) Capabilities() *blobstorage.Capabilities {
+	return s.capabilities
+}
+
+func (s *S3) beforeSend(_ *resty.Client, req *http.Request) error {
+	if body, ok := req.Context().Value(uploadBodyKey{}).(*uploadBody); ok {
+		if _, err := body.file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		req.ContentLength = body.size
+		req.Body = body
+		if body.size == 0 {
+			req.Body = http.NoBody
+		}
+	}
+	signV4(req, s.accessKeyID, s.secretAccessKey, s.region, s.now())
+	return nil
+}
+
+// objectURL returns the URL of an object, with the bucket in the path or as a sub-domain of the host
+func (s *S3) objectURL(bucket, key string) string {
+	u := *s.baseURL
+	basePath := strings.TrimSuffix(u.Path, "/")
+	if s.forcePathStyle {
+		u.Path = fmt.Sprintf("%s/%s/%s", basePath, bucket, key)
+	} else {
+		u.Host = bucket + "." + u.Host
+		u.Path = fmt.Sprintf("%s/%s", basePath, key)
+	}
+	return u.String()
+}
+
+func (s *S3) parsePayloadRef(ctx context.Context, payloadRef string) (bucket, key string, err error) {
+	if blobstorage.IsPayloadRef(s, payloadRef) {
+		bucket, key, _ = strings.Cut(strings.TrimPrefix(payloadRef, s.Name()+"://"), "/")
+	}
+	if bucket == "" || key == "" {
+		return "", "", i18n.NewError(ctx, coremsgs.MsgBlobStorageInvalidPayloadRef, payloadRef)
+	}
+	return bucket, key, nil
+}
+
+func (s *S3) UploadBlob(ctx context.Context, ns string, id fftypes.UUID, content io.Reader) (payloadRef string, hash *fftypes.Bytes32, size int64, err error) {
+	key := fmt.Sprintf("%s%s/%s", s.prefix, ns, id.String())
+	payloadRef = fmt.Sprintf("%s://%s/%s", s.Name(), s.bucket, key)
+
+	// The blob is written to a temporary file first, as the length and hash of the content must be
+	// known before it is uploaded
+	tmpFile, err := os.CreateTemp(s.tempDir, "ffblob-*")
+	if err != nil {
+		return "", nil, -1, i18n.WrapError(ctx, err, coremsgs.MsgBlobStorageFailed, payloadRef)
+	}
+	defer func() {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+	}()
+	hashCalc := sha256.New()
+	if size, err = io.Copy(io.MultiWriter(tmpFile, hashCalc), content); err != nil {
+		return "", nil, -1, i18n.WrapError(ctx, err, coremsgs.MsgBlobStorageFailed, payloadRef)
+	}
+	hash = fftypes.HashResult(hashCalc)
+
+	res, err := s.client.R().
+		SetContext(context.WithValue(ctx, uploadBodyKey{}, &uploadBody{file: tmpFile, size: size})).
+		SetHeader("Content-Type", "application/octet-stream").
+		SetHeader(headerContentSHA256, hash.String()).
+		Put(s.objectURL(s.bucket, key))
+	if err != nil || !res.IsSuccess() {
+		return "", nil, -1, ffresty.WrapRestErr(s.ctx, res, err, coremsgs.MsgBlobStorageRESTErr)
+	}
+	log.L(ctx).Infof("S3 blob storage uploaded %s size=%d", payloadRef, size)
+	return payloadRef, hash, size, nil
+}
+
+func (s *S3) DownloadBlob(ctx context.Context, payloadRef string) (io.ReadCloser, error) {
+	bucket, key, err := s.parsePayloadRef(ctx, payloadRef)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.client.R().
+		SetContext(ctx).
+		SetDoNotParseResponse(true).
+		Get(s.objectURL(bucket, key))
+	ffresty.OnAfterResponse(s.client, res) // required using SetDoNotParseResponse
+	if err != nil || !res.IsSuccess() {
+		if res != nil && res.RawBody() != nil {
+			_ = res.RawBody().Close()
+		}
+		return nil, ffresty.WrapRestErr(s.ctx, res, err, coremsgs.MsgBlobStorageRESTErr)
+	}
+	log.L(ctx).Infof("S3 blob storage downloading %s", payloadRef)
+	return res.RawBody(), nil
+}
+
+func (s *S3) DeleteBlob(ctx context.Context, payloadRef string) error {
+	bucket, key, err := s.parsePayloadRef(ctx, payloadRef)
+	if err != nil {
+		return err
+	}
+	res, err := s.client.R().
+		SetContext(ctx).
+		Delete(s.objectURL(bucket, key))
+	if err != nil || (!res.IsSuccess() && res.StatusCode() != http.StatusNotFound) {
+		return ffresty.WrapRestErr(s.ctx, res, err, coremsgs.MsgBlobStorageRESTErr)
+	}
+	log.L(ctx).Infof("S3 blob storage deleted %s", payloadRef)
+	return nil
+}
@@ -0,0 +1,262 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+var utConfig = config.RootSection("s3_unit_tests")
+
+// testS3Server is a minimal in-memory stand-in for an S3 compatible object store, that uses path style addressing
+type testS3Server struct {
+	mux     sync.Mutex
+	objects map[string][]byte
+	fail    int
+}
+
+func (ts *testS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ts.mux.Lock()
+	defer ts.mux.Unlock()
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=id1/") || r.Header.Get(headerDate) == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if ts.fail > 0 {
+		ts.fail--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		b, _ := io.ReadAll(r.Body)
+		hash := sha256.Sum256(b)
+		if r.ContentLength != int64(len(b)) || r.Header.Get(headerContentSHA256) != hex.EncodeToString(hash[:]) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		ts.objects[r.URL.Path] = b
+	case http.MethodGet:
+		b, ok := ts.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(b)
+	case http.MethodDelete:
+		delete(ts.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newTestS3(t *testing.T, retry bool) (*S3, *testS3Server, func()) {
+	ts := &testS3Server{objects: make(map[string][]byte)}
+	server := httptest.NewServer(ts)
+	coreconfig.Reset()
+	s := &S3{}
+	s.InitConfig(utConfig)
+	utConfig.Set(ffresty.HTTPConfigURL, server.URL)
+	utConfig.Set(ffresty.HTTPConfigRetryEnabled, retry)
+	utConfig.Set(ffresty.HTTPConfigRetryInitDelay, "1ms")
+	utConfig.Set(S3ConfBucket, "bucket1")
+	utConfig.Set(S3ConfPrefix, "firefly/")
+	utConfig.Set(S3ConfAccessKeyID, "id1")
+	utConfig.Set(S3ConfSecretAccessKey, "secret1")
+	utConfig.Set(S3ConfForcePathStyle, true)
+	utConfig.Set(S3ConfTempDir, t.TempDir())
+	err := s.Init(context.Background(), utConfig)
+	assert.NoError(t, err)
+	return s, ts, server.Close
+}
+
+func TestName(t *testing.T) {
+	s := &S3{}
+	assert.Equal(t, "s3", s.Name())
+}
+
+func TestInitMissingConfig(t *testing.T) {
+	coreconfig.Reset()
+	s := &S3{}
+	s.InitConfig(utConfig)
+	utConfig.Set(ffresty.HTTPConfigURL, "http://localhost:9000")
+	err := s.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10138.*bucket", err)
+}
+
+func TestInitBadURL(t *testing.T) {
+	coreconfig.Reset()
+	s := &S3{}
+	s.InitConfig(utConfig)
+	utConfig.Set(ffresty.HTTPConfigURL, ":::")
+	utConfig.Set(S3ConfBucket, "bucket1")
+	utConfig.Set(S3ConfAccessKeyID, "id1")
+	utConfig.Set(S3ConfSecretAccessKey, "secret1")
+	err := s.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10138.*url", err)
+}
+
+func TestInitBadTLSConfig(t *testing.T) {
+	coreconfig.Reset()
+	s := &S3{}
+	s.InitConfig(utConfig)
+	utConfig.Set(ffresty.HTTPConfigURL, "https://localhost:9000")
+	utConfig.Set(S3ConfBucket, "bucket1")
+	utConfig.Set(S3ConfAccessKeyID, "id1")
+	utConfig.Set(S3ConfSecretAccessKey, "secret1")
+	tlsConf := utConfig.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!!!badness")
+	err := s.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF00153", err)
+}
+
+func TestUploadDownloadDelete(t *testing.T) {
+	s, ts, done := newTestS3(t, false)
+	defer done()
+	ctx := context.Background()
+	assert.NotNil(t, s.Capabilities())
+
+	id := fftypes.NewUUID()
+	payloadRef, hash, size, err := s.UploadBlob(ctx, "ns1", *id, strings.NewReader("some data"))
+	assert.NoError(t, err)
+	assert.Equal(t, "s3://bucket1/firefly/ns1/"+id.String(), payloadRef)
+	assert.Equal(t, fftypes.HashString("some data"), hash)
+	assert.Equal(t, int64(9), size)
+	assert.Equal(t, []byte("some data"), ts.objects["/bucket1/firefly/ns1/"+id.String()])
+
+	reader, err := s.DownloadBlob(ctx, payloadRef)
+	assert.NoError(t, err)
+	b, _ := io.ReadAll(reader)
+	reader.Close()
+	assert.Equal(t, "some data", string(b))
+
+	err = s.DeleteBlob(ctx, payloadRef)
+	assert.NoError(t, err)
+	assert.Empty(t, ts.objects)
+
+	_, err = s.DownloadBlob(ctx, payloadRef)
+	assert.Regexp(t, "FF10542", err)
+}
+
+func TestUploadEmptyBlob(t *testing.T) {
+	s, ts, done := newTestS3(t, false)
+	defer done()
+
+	id := fftypes.NewUUID()
+	_, _, size, err := s.UploadBlob(context.Background(), "ns1", *id, strings.NewReader(""))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), size)
+	assert.Contains(t, ts.objects, "/bucket1/firefly/ns1/"+id.String())
+}
+
+func TestUploadRetry(t *testing.T) {
+	s, ts, done := newTestS3(t, true)
+	defer done()
+	ts.fail = 1
+
+	id := fftypes.NewUUID()
+	_, _, _, err := s.UploadBlob(context.Background(), "ns1", *id, strings.NewReader("some data"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("some data"), ts.objects["/bucket1/firefly/ns1/"+id.String()])
+}
+
+func TestUploadFail(t *testing.T) {
+	s, ts, done := newTestS3(t, false)
+	defer done()
+	ts.fail = 1
+
+	_, _, _, err := s.UploadBlob(context.Background(), "ns1", *fftypes.NewUUID(), strings.NewReader("some data"))
+	assert.Regexp(t, "FF10542", err)
+}
+
+func TestUploadBadTempDir(t *testing.T) {
+	s, _, done := newTestS3(t, false)
+	defer done()
+	s.tempDir = "/does/not/exist"
+
+	_, _, _, err := s.UploadBlob(context.Background(), "ns1", *fftypes.NewUUID(), strings.NewReader("some data"))
+	assert.Regexp(t, "FF10544", err)
+}
+
+func TestUploadReadFail(t *testing.T) {
+	s, _, done := newTestS3(t, false)
+	defer done()
+
+	reader, writer := io.Pipe()
+	writer.CloseWithError(io.ErrUnexpectedEOF)
+	_, _, _, err := s.UploadBlob(context.Background(), "ns1", *fftypes.NewUUID(), reader)
+	assert.Regexp(t, "FF10544", err)
+}
+
+func TestBeforeSendSeekFail(t *testing.T) {
+	s, _, done := newTestS3(t, false)
+	defer done()
+
+	tmpFile, err := os.CreateTemp(t.TempDir(), "upload")
+	assert.NoError(t, err)
+	tmpFile.Close()
+	ctx := context.WithValue(context.Background(), uploadBodyKey{}, &uploadBody{file: tmpFile, size: 9})
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPut, "http://localhost:9000/bucket1/firefly/ns1/blob1", nil)
+
+	err = s.beforeSend(s.client, req)
+	assert.ErrorIs(t, err, os.ErrClosed)
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
+
+func TestDeleteFail(t *testing.T) {
+	s, ts, done := newTestS3(t, false)
+	defer done()
+	ts.fail = 1
+
+	err := s.DeleteBlob(context.Background(), "s3://bucket1/firefly/ns1/blob1")
+	assert.Regexp(t, "FF10542", err)
+}
+
+func TestInvalidPayloadRefs(t *testing.T) {
+	s, _, done := newTestS3(t, false)
+	defer done()
+	ctx := context.Background()
+
+	_, err := s.DownloadBlob(ctx, "filesystem://ns1/blob1")
+	assert.Regexp(t, "FF10543", err)
+	err = s.DeleteBlob(ctx, "s3://bucket1")
+	assert.Regexp(t, "FF10543", err)
+}
+
+func TestObjectURLVirtualHostStyle(t *testing.T) {
+	s, _, done := newTestS3(t, false)
+	defer done()
+	s.forcePathStyle = false
+
+	assert.Equal(t, strings.Replace(s.baseURL.String(), "://", "://bucket1.", 1)+"/ns1/blob1", s.objectURL("bucket1", "ns1/blob1"))
+}
@@ -52,6 +52,13 @@ func TestGetPluginTezos(t *testing.T) {
 	assert.NotNil(t, plugin)
 }
 
+func TestGetPluginSolana(t *testing.T) {
+	ctx := context.Background()
+	plugin, err := GetPlugin(ctx, "solana")
+	assert.NoError(t, err)
+	assert.NotNil(t, plugin)
+}
+
 var root = config.RootSection("di")
 
 func TestInitConfig(t *testing.T) {
@@ -114,6 +114,7 @@ type BlockchainReceiptNotification struct {
 	Message          string                   `json:"errorMessage,omitempty"`
 	ProtocolID       string                   `json:"protocolId,omitempty"`
 	ContractLocation *fftypes.JSONAny         `json:"contractLocation,omitempty"`
+	PrivacyGroupID   string                   `json:"privacyGroupId,omitempty"`
 }
 
 type BlockchainRESTError struct {
@@ -383,14 +383,18 @@ func (e *Ethereum) processBatchPinEvent(ctx context.Context, events common.Event
 
 func (e *Ethereum) processContractEvent(ctx context.Context, events common.EventsToDispatch, msgJSON fftypes.JSONObject) error {
 	subID := msgJSON.GetString("subId")
-	subName, err := e.streams.getSubscriptionName(ctx, subID)
+	sub, err := e.streams.getCachedSubscription(ctx, subID)
 	if err != nil {
 		return err // this is a problem - we should be able to find the listener that dispatched this to us
 	}
 
-	namespace := common.GetNamespaceFromSubName(subName)
+	namespace := common.GetNamespaceFromSubName(sub.Name)
 	event := e.parseBlockchainEvent(ctx, msgJSON)
 	if event != nil {
+		// Private events are tagged with the privacy group of the listener, unless the connector already did
+		if sub.PrivacyGroupID != "" && event.Info.GetString(optionPrivacyGroupID) == "" {
+			event.Info[optionPrivacyGroupID] = sub.PrivacyGroupID
+		}
 		e.callbacks.PrepareBlockchainEvent(ctx, events, namespace, &blockchain.EventForListener{
 			Event:      event,
 			ListenerID: subID,
@@ -514,7 +518,7 @@ func (e *Ethereum) eventLoop(namespace string, wsconn wsclient.WSClient, closed
 				if !isBatch {
 					var receipt common.BlockchainReceiptNotification
 					_ = json.Unmarshal(msgBytes, &receipt)
-					err := common.HandleReceipt(ctx, namespace, e, &receipt, e.callbacks)
+					err := e.handleReceipt(ctx, namespace, &receipt)
 					if err != nil {
 						l.Errorf("Failed to process receipt: %+v", msgTyped)
 					}
@@ -627,6 +631,7 @@ func (e *Ethereum) invokeContractMethod(ctx context.Context, address, signingKey
 	if err != nil || !res.IsSuccess() {
 		return resErr.SubmissionRejected, common.WrapRESTError(ctx, &resErr, res, err, coremsgs.MsgEthConnectorRESTErr)
 	}
+	e.recordPrivacyGroup(requestID, options)
 	return false, nil
 }
 
@@ -754,6 +759,9 @@ func (e *Ethereum) DeployContract(ctx context.Context, nsOpID, signingKey string
 		"contract":   contract,
 	}
 
+	if err = validatePrivacyOptions(ctx, options); err != nil {
+		return true, err
+	}
 	body, err = e.applyOptions(ctx, body, options)
 	if err != nil {
 		return true, err
@@ -773,6 +781,7 @@ func (e *Ethereum) DeployContract(ctx context.Context, nsOpID, signingKey string
 		}
 		return resErr.SubmissionRejected, common.WrapRESTError(ctx, &resErr, res, err, coremsgs.MsgEthConnectorRESTErr)
 	}
+	e.recordPrivacyGroup(nsOpID, options)
 	return false, nil
 }
 
@@ -803,6 +812,9 @@ func (e *Ethereum) InvokeContract(ctx context.Context, nsOpID string, signingKey
 	if err != nil {
 		return true, err
 	}
+	if err = validatePrivacyOptions(ctx, options); err != nil {
+		return true, err
+	}
 	methodInfo, orderedInput, err := e.prepareRequest(ctx, parsedMethod, input)
 	if err != nil {
 		return true, err
@@ -828,6 +840,9 @@ func (e *Ethereum) QueryContract(ctx context.Context, signingKey string, locatio
 	if err != nil {
 		return nil, err
 	}
+	if err = validatePrivacyOptions(ctx, options); err != nil {
+		return nil, err
+	}
 	methodInfo, orderedInput, err := e.prepareRequest(ctx, parsedMethod, input)
 	if err != nil {
 		return nil, err
@@ -942,10 +957,17 @@ func (e *Ethereum) AddContractListener(ctx context.Context, listener *core.Contr
 
 	subName := fmt.Sprintf("ff-sub-%s-%s", listener.Namespace, listener.ID)
 	firstEvent := string(core.SubOptsFirstEventNewest)
+	privacyGroupID := ""
 	if listener.Options != nil {
 		firstEvent = listener.Options.FirstEvent
+		privacyGroupID = listener.Options.PrivacyGroupID
 	}
-	result, err := e.streams.createSubscription(ctx, e.streamID[namespace], subName, firstEvent, location, firstEventABI, filters, lastProtocolID)
+	if privacyGroupID != "" {
+		if err := validatePrivacyKey(ctx, optionPrivacyGroupID, privacyGroupID); err != nil {
+			return err
+		}
+	}
+	result, err := e.streams.createSubscription(ctx, e.streamID[namespace], subName, firstEvent, location, firstEventABI, filters, lastProtocolID, privacyGroupID)
 	if err != nil {
 		return err
 	}
@@ -1221,10 +1243,15 @@ func (e *Ethereum) GetTransactionStatus(ctx context.Context, operation *core.Ope
 				Headers: common.BlockchainReceiptHeaders{
 					ReceiptID: statusResponse.GetString("id"),
 					ReplyType: replyType},
-				TxHash:     statusResponse.GetString("transactionHash"),
-				Message:    statusResponse.GetString("errorMessage"),
-				ProtocolID: receiptInfo.GetString("protocolId")}
-			err := common.HandleReceipt(ctx, operation.Namespace, e, receipt, e.callbacks)
+				TxHash:         statusResponse.GetString("transactionHash"),
+				Message:        statusResponse.GetString("errorMessage"),
+				ProtocolID:     receiptInfo.GetString("protocolId"),
+				PrivacyGroupID: receiptInfo.GetString(optionPrivacyGroupID)}
+			if receipt.PrivacyGroupID == "" {
+				// The privacy group might not be cached, if the transaction was submitted before a restart
+				receipt.PrivacyGroupID = privacyGroupID(operation.Input.GetObject("options"))
+			}
+			err := e.handleReceipt(ctx, operation.Namespace, receipt)
 			if err != nil {
 				log.L(ctx).Warnf("Failed to handle receipt")
 			}
@@ -61,6 +61,7 @@ type subscription struct {
 	EthCompatAddress string     `json:"address,omitempty"`
 	EthCompatEvent   *abi.Entry `json:"event,omitempty"`
 	Filters          []*filter  `json:"filters"`
+	PrivacyGroupID   string     `json:"privacyGroupId,omitempty"`
 	subscriptionCheckpoint
 }
 
@@ -195,17 +196,17 @@ func (s *streamManager) getSubscription(ctx context.Context, subID string, okNot
 	return sub, nil
 }
 
-func (s *streamManager) getSubscriptionName(ctx context.Context, subID string) (string, error) {
-	if cachedValue := s.cache.GetString("sub:" + subID); cachedValue != "" {
+func (s *streamManager) getCachedSubscription(ctx context.Context, subID string) (*subscription, error) {
+	if cachedValue, ok := s.cache.Get("sub:" + subID).(*subscription); ok {
 		return cachedValue, nil
 	}
 
 	sub, err := s.getSubscription(ctx, subID, false)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	s.cache.SetString("sub:"+subID, sub.Name)
-	return sub.Name, nil
+	s.cache.Set("sub:"+subID, sub)
+	return sub, nil
 }
 
 func resolveFromBlock(ctx context.Context, firstEvent, lastProtocolID string) (string, error) {
@@ -249,7 +250,7 @@ func resolveFromBlock(ctx context.Context, firstEvent, lastProtocolID string) (s
 	return strconv.FormatUint(blockNumber, 10), nil
 }
 
-func (s *streamManager) createSubscription(ctx context.Context, stream, subName, firstEvent string, location *Location, abi *abi.Entry, filters []*filter, lastProtocolID string) (*subscription, error) {
+func (s *streamManager) createSubscription(ctx context.Context, stream, subName, firstEvent string, location *Location, abi *abi.Entry, filters []*filter, lastProtocolID, privacyGroupID string) (*subscription, error) {
 	fromBlock, err := resolveFromBlock(ctx, firstEvent, lastProtocolID)
 	if err != nil {
 		return nil, err
@@ -261,6 +262,7 @@ func (s *streamManager) createSubscription(ctx context.Context, stream, subName,
 		FromBlock:      fromBlock,
 		EthCompatEvent: abi, // only used for ethconnect
 		Filters:        filters,
+		PrivacyGroupID: privacyGroupID,
 	}
 
 	if location != nil {
@@ -339,7 +341,7 @@ func (s *streamManager) ensureFireFlySubscription(ctx context.Context, namespace
 			Address: location.Address,
 		},
 	}
-	if sub, err = s.createSubscription(ctx, stream, name, firstEvent, location, abi, filters, lastProtocolID); err != nil {
+	if sub, err = s.createSubscription(ctx, stream, name, firstEvent, location, abi, filters, lastProtocolID, ""); err != nil {
 		return nil, err
 	}
 	log.L(ctx).Infof("%s subscription: %s", abi.Name, sub.ID)
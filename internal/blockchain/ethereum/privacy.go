@@ -0,0 +1,137 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/hyperledger/firefly/internal/blockchain/common"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"golang.org/x/crypto/sha3"
+)
+
+// The options of a private transaction, as accepted by the connector for a Besu node with a Tessera
+// private transaction manager. The transaction is sent privately from the Tessera key in privateFrom,
+// either to the Tessera keys in privateFor, or to the members of an existing privacy group.
+const (
+	optionPrivateFrom    = "privateFrom"
+	optionPrivateFor     = "privateFor"
+	optionPrivacyGroupID = "privacyGroupId"
+)
+
+// Tessera public keys and Besu privacy group IDs are both 32 bytes encoded as base64
+const privacyKeyLength = 32
+
+func validatePrivacyKey(ctx context.Context, name string, value interface{}) error {
+	s, ok := value.(string)
+	if ok {
+		b, err := base64.StdEncoding.DecodeString(s)
+		ok = err == nil && len(b) == privacyKeyLength
+	}
+	if !ok {
+		return i18n.NewError(ctx, coremsgs.MsgInvalidPrivacyOptions, fmt.Sprintf("'%s' must be a base64 encoded %d byte key", name, privacyKeyLength))
+	}
+	return nil
+}
+
+// validatePrivacyOptions checks the options of a private transaction before they are passed to the connector,
+// so that a transaction is never sent publicly because of a mistake in its options
+func validatePrivacyOptions(ctx context.Context, options map[string]interface{}) error {
+	privateFrom, hasPrivateFrom := options[optionPrivateFrom]
+	privateFor, hasPrivateFor := options[optionPrivateFor]
+	privacyGroupID, hasPrivacyGroupID := options[optionPrivacyGroupID]
+	if !hasPrivateFrom && !hasPrivateFor && !hasPrivacyGroupID {
+		return nil
+	}
+
+	switch {
+	case hasPrivateFor && hasPrivacyGroupID:
+		return i18n.NewError(ctx, coremsgs.MsgInvalidPrivacyOptions, fmt.Sprintf("only one of '%s' and '%s' can be set", optionPrivateFor, optionPrivacyGroupID))
+	case !hasPrivateFor && !hasPrivacyGroupID:
+		return i18n.NewError(ctx, coremsgs.MsgInvalidPrivacyOptions, fmt.Sprintf("one of '%s' and '%s' must be set", optionPrivateFor, optionPrivacyGroupID))
+	}
+	if err := validatePrivacyKey(ctx, optionPrivateFrom, privateFrom); err != nil {
+		return err
+	}
+	if hasPrivacyGroupID {
+		return validatePrivacyKey(ctx, optionPrivacyGroupID, privacyGroupID)
+	}
+
+	recipients, ok := privateFor.([]interface{})
+	if !ok || len(recipients) == 0 {
+		return i18n.NewError(ctx, coremsgs.MsgInvalidPrivacyOptions, fmt.Sprintf("'%s' must be a non-empty list of keys", optionPrivateFor))
+	}
+	for _, recipient := range recipients {
+		if err := validatePrivacyKey(ctx, optionPrivateFor, recipient); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// privacyGroupID returns the ID of the privacy group of a private transaction, from its validated options,
+// or an empty string for a public transaction. A transaction sent with privateFor is sent to the legacy
+// privacy group of privateFrom and the privateFor keys, whose ID Besu derives from the keys. The ID is the
+// keccak256 hash of an RLP list of the distinct keys, sorted as unsigned bytes.
+func privacyGroupID(options map[string]interface{}) string {
+	if id, ok := options[optionPrivacyGroupID].(string); ok {
+		return id
+	}
+	privateFor, ok := options[optionPrivateFor].([]interface{})
+	if !ok {
+		return ""
+	}
+	keys := make([][]byte, 0, len(privateFor)+1)
+	for _, key := range append([]interface{}{options[optionPrivateFrom]}, privateFor...) {
+		s, _ := key.(string)
+		b, _ := base64.StdEncoding.DecodeString(s)
+		keys = append(keys, b)
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+	list := rlp.List{}
+	for i, key := range keys {
+		if i == 0 || !bytes.Equal(key, keys[i-1]) {
+			list = append(list, rlp.Data(key))
+		}
+	}
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(list.Encode())
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil))
+}
+
+// recordPrivacyGroup remembers the privacy group of a private transaction that has been submitted, so that it
+// can be included in the operation output when the receipt arrives
+func (e *Ethereum) recordPrivacyGroup(nsOpID string, options map[string]interface{}) {
+	if id := privacyGroupID(options); id != "" {
+		e.cache.SetString("privacygroup:"+nsOpID, id)
+	}
+}
+
+// handleReceipt passes a receipt to the callbacks, with the privacy group of a private transaction if the
+// connector did not include it in the receipt
+func (e *Ethereum) handleReceipt(ctx context.Context, namespace string, receipt *common.BlockchainReceiptNotification) error {
+	if receipt.PrivacyGroupID == "" && e.cache != nil {
+		receipt.PrivacyGroupID = e.cache.GetString("privacygroup:" + receipt.Headers.ReceiptID)
+	}
+	return common.HandleReceipt(ctx, namespace, e, receipt, e.callbacks)
+}
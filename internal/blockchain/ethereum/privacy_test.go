@@ -0,0 +1,456 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/blockchain/common"
+	"github.com/hyperledger/firefly/mocks/blockchainmocks"
+	"github.com/hyperledger/firefly/mocks/coremocks"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testTesseraKey1     = "A1aVtMxLCUHmBVHXoZzzBgPbW/wj5axDpW9X8l91SGo="
+	testTesseraKey2     = "Ko2bVqD+nNlNYL5EE7y3IdOnviftjiizpjRt+HTuFBs="
+	testPrivacyGroupID1 = "DyAOiF/ynpc+JXa2YAGB0bCitSlOMNm+ShmB/7M6C4w="
+)
+
+func TestPrivacyGroupID(t *testing.T) {
+	// The privacy group of privateFrom and privateFor is the same, whatever the order and repetition of the keys
+	assert.Equal(t, testPrivacyGroupID1, privacyGroupID(map[string]interface{}{
+		"privateFrom": testTesseraKey2,
+		"privateFor":  []interface{}{testTesseraKey1, testTesseraKey2},
+	}))
+	assert.Equal(t, testPrivacyGroupID1, privacyGroupID(map[string]interface{}{
+		"privateFrom":    testTesseraKey1,
+		"privacyGroupId": testPrivacyGroupID1,
+	}))
+	assert.Empty(t, privacyGroupID(map[string]interface{}{"customOption": "customValue"}))
+	assert.Empty(t, privacyGroupID(nil))
+}
+
+func TestValidatePrivacyOptions(t *testing.T) {
+	for name, options := range map[string]map[string]interface{}{
+		"public":        {"customOption": "customValue"},
+		"privateFor":    {"privateFrom": testTesseraKey1, "privateFor": []interface{}{testTesseraKey2}},
+		"privacy group": {"privateFrom": testTesseraKey1, "privacyGroupId": testPrivacyGroupID1},
+	} {
+		assert.NoError(t, validatePrivacyOptions(context.Background(), options), name)
+	}
+
+	for name, options := range map[string]map[string]interface{}{
+		"both recipients":   {"privateFrom": testTesseraKey1, "privateFor": []interface{}{testTesseraKey2}, "privacyGroupId": testPrivacyGroupID1},
+		"no recipients":     {"privateFrom": testTesseraKey1},
+		"no privateFrom":    {"privateFor": []interface{}{testTesseraKey2}},
+		"bad privateFrom":   {"privateFrom": "!!!", "privateFor": []interface{}{testTesseraKey2}},
+		"short privateFrom": {"privateFrom": "AAAA", "privateFor": []interface{}{testTesseraKey2}},
+		"privateFor string": {"privateFrom": testTesseraKey1, "privateFor": testTesseraKey2},
+		"empty privateFor":  {"privateFrom": testTesseraKey1, "privateFor": []interface{}{}},
+		"bad privateFor":    {"privateFrom": testTesseraKey1, "privateFor": []interface{}{12345}},
+		"bad privacy group": {"privateFrom": testTesseraKey1, "privacyGroupId": "0x12345"},
+	} {
+		assert.Regexp(t, "FF10545", validatePrivacyOptions(context.Background(), options), name)
+	}
+}
+
+func TestDeployContractPrivate(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+	signingKey := ethHexFormatB32(fftypes.NewRandB32())
+	options := map[string]interface{}{
+		"privateFrom": testTesseraKey1,
+		"privateFor":  []interface{}{testTesseraKey2},
+	}
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			assert.Equal(t, testTesseraKey1, body["privateFrom"])
+			assert.Equal(t, []interface{}{testTesseraKey2}, body["privateFor"])
+			return httpmock.NewJsonResponderOrPanic(200, "")(req)
+		})
+	_, err := e.DeployContract(context.Background(), "ns1:"+fftypes.NewUUID().String(), signingKey, fftypes.JSONAnyPtr("[]"), fftypes.JSONAnyPtr(`"0x123456"`), []interface{}{}, options)
+	assert.NoError(t, err)
+}
+
+func TestDeployContractPrivateInvalid(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	options := map[string]interface{}{
+		"privateFrom": testTesseraKey1,
+	}
+	submissionRejected, err := e.DeployContract(context.Background(), "", "0x123", fftypes.JSONAnyPtr("[]"), fftypes.JSONAnyPtr(`"0x123456"`), []interface{}{}, options)
+	assert.Regexp(t, "FF10545", err)
+	assert.True(t, submissionRejected)
+}
+
+func TestInvokeContractPrivate(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+	signingKey := ethHexFormatB32(fftypes.NewRandB32())
+	params := map[string]interface{}{
+		"x": float64(1),
+		"y": "1000000000000000000000000",
+	}
+	options := map[string]interface{}{
+		"privateFrom":    testTesseraKey1,
+		"privacyGroupId": testPrivacyGroupID1,
+	}
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			assert.Equal(t, testTesseraKey1, body["privateFrom"])
+			assert.Equal(t, testPrivacyGroupID1, body["privacyGroupId"])
+			return httpmock.NewJsonResponderOrPanic(200, "")(req)
+		})
+	parsedMethod, err := e.ParseInterface(context.Background(), testFFIMethod(), testFFIErrors())
+	assert.NoError(t, err)
+	_, err = e.InvokeContract(context.Background(), "", signingKey, fftypes.JSONAnyPtr(`{"address":"0x12345"}`), parsedMethod, params, options, nil)
+	assert.NoError(t, err)
+}
+
+func TestInvokeContractPrivateInvalid(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	options := map[string]interface{}{
+		"privateFrom":    testTesseraKey1,
+		"privateFor":     []interface{}{testTesseraKey2},
+		"privacyGroupId": testPrivacyGroupID1,
+	}
+	parsedMethod, err := e.ParseInterface(context.Background(), testFFIMethod(), testFFIErrors())
+	assert.NoError(t, err)
+	submissionRejected, err := e.InvokeContract(context.Background(), "", "0x123", fftypes.JSONAnyPtr(`{"address":"0x12345"}`), parsedMethod, map[string]interface{}{}, options, nil)
+	assert.Regexp(t, "FF10545", err)
+	assert.True(t, submissionRejected)
+}
+
+func TestQueryContractPrivateInvalid(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	options := map[string]interface{}{
+		"privacyGroupId": testPrivacyGroupID1,
+	}
+	parsedMethod, err := e.ParseInterface(context.Background(), testFFIMethod(), testFFIErrors())
+	assert.NoError(t, err)
+	_, err = e.QueryContract(context.Background(), "0x123", fftypes.JSONAnyPtr(`{"address":"0x12345"}`), parsedMethod, map[string]interface{}{}, options)
+	assert.Regexp(t, "FF10545", err)
+}
+
+func testPrivateListener(privacyGroupID string) *core.ContractListener {
+	return &core.ContractListener{
+		Filters: []*core.ListenerFilter{
+			{
+				Event: &core.FFISerializedEvent{
+					FFIEventDefinition: fftypes.FFIEventDefinition{
+						Name: "Changed",
+						Params: fftypes.FFIParams{
+							{
+								Name:   "value",
+								Schema: fftypes.JSONAnyPtr(`{"type": "string", "details": {"type": "string"}}`),
+							},
+						},
+					},
+				},
+				Location: fftypes.JSONAnyPtr(`{"address": "0x123"}`),
+			},
+		},
+		Options: &core.ContractListenerOptions{
+			FirstEvent:     string(core.SubOptsFirstEventOldest),
+			PrivacyGroupID: privacyGroupID,
+		},
+	}
+}
+
+func TestAddSubscriptionPrivate(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+	e.streamID["ns1"] = "es-1"
+	e.streams = &streamManager{
+		client: e.client,
+	}
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/subscriptions`,
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			assert.Equal(t, testPrivacyGroupID1, body["privacyGroupId"])
+			return httpmock.NewJsonResponderOrPanic(200, &subscription{ID: "sub1"})(req)
+		})
+
+	listener := testPrivateListener(testPrivacyGroupID1)
+	err := e.AddContractListener(context.Background(), listener, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "sub1", listener.BackendID)
+}
+
+func TestAddSubscriptionPrivateInvalid(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	err := e.AddContractListener(context.Background(), testPrivateListener("group1"), "")
+
+	assert.Regexp(t, "FF10545", err)
+}
+
+func TestHandleMessageContractEventPrivate(t *testing.T) {
+	data := fftypes.JSONAnyPtr(`
+[
+  {
+		"address": "0x1C197604587F046FD40684A8f21f4609FB811A7b",
+		"blockNumber": "38011",
+		"transactionIndex": "0x0",
+		"transactionHash": "0xc26df2bf1a733e9249372d61eb11bd8662d26c8129df76890b1beb2f6fa72628",
+		"data": {"value": "1"},
+		"subId": "sub2",
+		"signature": "Changed(uint256)",
+		"logIndex": "50",
+		"timestamp": "1640811383"
+  },
+  {
+		"address": "0x1C197604587F046FD40684A8f21f4609FB811A7b",
+		"blockNumber": "38012",
+		"transactionIndex": "0x0",
+		"transactionHash": "0x0c2d7a2ab5ea8e1fd1e2ea5e8b4dd3fb65a57a4e6b5ac0bc87c0ae0e10ff8d5d",
+		"data": {"value": "2"},
+		"subId": "sub2",
+		"signature": "Changed(uint256)",
+		"logIndex": "10",
+		"timestamp": "1640811384"
+  }
+]`)
+
+	em := &blockchainmocks.Callbacks{}
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions/sub2",
+		httpmock.NewJsonResponderOrPanic(200, subscription{
+			ID: "sub2", Stream: "es12345", Name: "ff-sub-ns1-1132312312312", PrivacyGroupID: testPrivacyGroupID1,
+		}))
+
+	e.SetHandler("ns1", em)
+	e.streams = newTestStreamManager(e.client)
+
+	em.On("BlockchainEventBatch", matchBatchWithEvent(
+		"000000038011/000000/000050",
+		"0xc26df2bf1a733e9249372d61eb11bd8662d26c8129df76890b1beb2f6fa72628",
+	)).Return(nil).Once()
+	em.On("BlockchainEventBatch", matchBatchWithEvent(
+		"000000038012/000000/000010",
+		"0x0c2d7a2ab5ea8e1fd1e2ea5e8b4dd3fb65a57a4e6b5ac0bc87c0ae0e10ff8d5d",
+	)).Return(nil).Once()
+
+	var events []interface{}
+	err := json.Unmarshal(data.Bytes(), &events)
+	assert.NoError(t, err)
+	err = e.handleMessageBatch(context.Background(), 0, events[0:1])
+	assert.NoError(t, err)
+	err = e.handleMessageBatch(context.Background(), 1, events[1:])
+	assert.NoError(t, err)
+
+	for _, call := range em.Calls {
+		ev := call.Arguments[0].([]*blockchain.EventToDispatch)[0]
+		assert.Equal(t, testPrivacyGroupID1, ev.ForListener.Event.Info.GetString("privacyGroupId"))
+	}
+	// The subscription is only fetched once, as it is cached with its privacy group
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+	em.AssertExpectations(t)
+}
+
+func testPrivateReceipt(nsOpID, privacyGroupID string) *common.BlockchainReceiptNotification {
+	return &common.BlockchainReceiptNotification{
+		Headers: common.BlockchainReceiptHeaders{
+			ReceiptID: nsOpID,
+			ReplyType: "TransactionSuccess",
+		},
+		TxHash:         "0x123",
+		PrivacyGroupID: privacyGroupID,
+	}
+}
+
+func expectPrivacyGroupUpdate(em *coremocks.OperationCallbacks, nsOpID, privacyGroupID string) {
+	em.On("OperationUpdate", mock.MatchedBy(func(update *core.OperationUpdateAsync) bool {
+		return update.NamespacedOpID == nsOpID &&
+			update.Status == core.OpStatusSucceeded &&
+			update.Output.GetString("privacyGroupId") == privacyGroupID
+	})).Return(nil)
+}
+
+func TestHandleReceiptPrivateDeploy(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+	em := &coremocks.OperationCallbacks{}
+	e.SetOperationHandler("ns1", em)
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`, httpmock.NewJsonResponderOrPanic(200, ""))
+
+	nsOpID := "ns1:" + fftypes.NewUUID().String()
+	options := map[string]interface{}{
+		"privateFrom": testTesseraKey1,
+		"privateFor":  []interface{}{testTesseraKey2},
+	}
+	_, err := e.DeployContract(context.Background(), nsOpID, "0x123", fftypes.JSONAnyPtr("[]"), fftypes.JSONAnyPtr(`"0x123456"`), []interface{}{}, options)
+	assert.NoError(t, err)
+
+	expectPrivacyGroupUpdate(em, nsOpID, testPrivacyGroupID1)
+	err = e.handleReceipt(context.Background(), "ns1", testPrivateReceipt(nsOpID, ""))
+	assert.NoError(t, err)
+
+	em.AssertExpectations(t)
+}
+
+func TestHandleReceiptPrivateInvoke(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+	em := &coremocks.OperationCallbacks{}
+	e.SetOperationHandler("ns1", em)
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`, httpmock.NewJsonResponderOrPanic(200, ""))
+
+	nsOpID := "ns1:" + fftypes.NewUUID().String()
+	options := map[string]interface{}{
+		"privateFrom":    testTesseraKey1,
+		"privacyGroupId": testPrivacyGroupID1,
+	}
+	parsedMethod, err := e.ParseInterface(context.Background(), testFFIMethod(), testFFIErrors())
+	assert.NoError(t, err)
+	_, err = e.InvokeContract(context.Background(), nsOpID, "0x123", fftypes.JSONAnyPtr(`{"address":"0x12345"}`), parsedMethod, map[string]interface{}{"x": 1, "y": 2}, options, nil)
+	assert.NoError(t, err)
+
+	expectPrivacyGroupUpdate(em, nsOpID, testPrivacyGroupID1)
+	err = e.handleReceipt(context.Background(), "ns1", testPrivateReceipt(nsOpID, ""))
+	assert.NoError(t, err)
+
+	em.AssertExpectations(t)
+}
+
+func TestHandleReceiptPrivacyGroupFromConnector(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	em := &coremocks.OperationCallbacks{}
+	e.SetOperationHandler("ns1", em)
+
+	nsOpID := "ns1:" + fftypes.NewUUID().String()
+	expectPrivacyGroupUpdate(em, nsOpID, testPrivacyGroupID1)
+	err := e.handleReceipt(context.Background(), "ns1", testPrivateReceipt(nsOpID, testPrivacyGroupID1))
+	assert.NoError(t, err)
+
+	em.AssertExpectations(t)
+}
+
+func TestHandleReceiptPublic(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	em := &coremocks.OperationCallbacks{}
+	e.SetOperationHandler("ns1", em)
+
+	nsOpID := "ns1:" + fftypes.NewUUID().String()
+	em.On("OperationUpdate", mock.MatchedBy(func(update *core.OperationUpdateAsync) bool {
+		_, hasPrivacyGroup := update.Output["privacyGroupId"]
+		return update.NamespacedOpID == nsOpID && !hasPrivacyGroup
+	})).Return(nil)
+	err := e.handleReceipt(context.Background(), "ns1", testPrivateReceipt(nsOpID, ""))
+	assert.NoError(t, err)
+
+	em.AssertExpectations(t)
+}
+
+func TestGetTransactionStatusPrivate(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+	em := &coremocks.OperationCallbacks{}
+	e.SetOperationHandler("ns1", em)
+
+	// The privacy group is worked out from the options of the operation, as it is not cached
+	op := &core.Operation{
+		Namespace: "ns1",
+		ID:        fftypes.NewUUID(),
+		Status:    core.OpStatusPending,
+		Input: fftypes.JSONObject{
+			"options": map[string]interface{}{
+				"privateFrom": testTesseraKey2,
+				"privateFor":  []interface{}{testTesseraKey1},
+			},
+		},
+	}
+	nsOpID := "ns1:" + op.ID.String()
+	httpmock.RegisterResponder("GET", "http://localhost:12345/transactions/"+nsOpID,
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"id":     nsOpID,
+			"status": "Succeeded",
+		}))
+
+	expectPrivacyGroupUpdate(em, nsOpID, testPrivacyGroupID1)
+	_, err := e.GetTransactionStatus(context.Background(), op)
+	assert.NoError(t, err)
+
+	em.AssertExpectations(t)
+}
+
+func TestGetTransactionStatusPrivacyGroupFromReceipt(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+	em := &coremocks.OperationCallbacks{}
+	e.SetOperationHandler("ns1", em)
+
+	op := &core.Operation{
+		Namespace: "ns1",
+		ID:        fftypes.NewUUID(),
+		Status:    core.OpStatusPending,
+	}
+	nsOpID := "ns1:" + op.ID.String()
+	httpmock.RegisterResponder("GET", "http://localhost:12345/transactions/"+nsOpID,
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"id":     nsOpID,
+			"status": "Succeeded",
+			"receipt": map[string]interface{}{
+				"privacyGroupId": testPrivacyGroupID1,
+			},
+		}))
+
+	expectPrivacyGroupUpdate(em, nsOpID, testPrivacyGroupID1)
+	_, err := e.GetTransactionStatus(context.Background(), op)
+	assert.NoError(t, err)
+
+	em.AssertExpectations(t)
+}
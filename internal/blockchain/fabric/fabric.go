@@ -771,7 +771,17 @@ func (f *Fabric) buildFabconnectRequestBody(ctx context.Context, channel, chainc
 }
 
 func (f *Fabric) DeployContract(ctx context.Context, nsOpID, signingKey string, definition, contract *fftypes.JSONAny, input []interface{}, options map[string]interface{}) (submissionRejected bool, err error) {
-	return true, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+	cd, pkg, err := f.parseChaincodeDeployment(ctx, definition, contract, input)
+	if err != nil {
+		return true, err
+	}
+	if f.metrics.IsMetricsEnabled() {
+		f.metrics.BlockchainContractDeployment()
+	}
+	// The lifecycle steps each wait for a transaction to be committed, so they run in the background
+	// for the life of the plugin, rather than the request
+	go f.deployChaincode(log.WithLogger(f.ctx, log.L(ctx)), nsOpID, signingKey, cd, pkg)
+	return false, nil
 }
 
 func (f *Fabric) ValidateInvokeRequest(ctx context.Context, parsedMethod interface{}, input map[string]interface{}, hasMessage bool) error {
@@ -1014,7 +1024,15 @@ func (f *Fabric) GetFFIParamValidator(ctx context.Context) (fftypes.FFIParamVali
 }
 
 func (f *Fabric) GenerateFFI(ctx context.Context, generationRequest *fftypes.FFIGenerationRequest) (*fftypes.FFI, error) {
-	return nil, i18n.NewError(ctx, coremsgs.MsgFFIGenerationUnsupported)
+	var input FFIGenerationInput
+	err := json.Unmarshal(generationRequest.Input.Bytes(), &input)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgFFIGenerationFailed, "unable to deserialize JSON as contract metadata")
+	}
+	if input.Metadata == nil || len(input.Metadata.Contracts) == 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgFFIGenerationFailed, "contract metadata is empty")
+	}
+	return convertMetadataToFFI(ctx, generationRequest, &input)
 }
 
 func (f *Fabric) GenerateEventSignature(ctx context.Context, event *fftypes.FFIEventDefinition) (string, error) {
@@ -2554,38 +2554,6 @@ func TestInvokeContractWithBatchOK(t *testing.T) {
 	assert.NoError(t, err)
 }
 
-func TestDeployContractOK(t *testing.T) {
-	e, cancel := newTestFabric()
-	defer cancel()
-	httpmock.ActivateNonDefault(e.client.GetClient())
-	defer httpmock.DeactivateAndReset()
-	signingKey := fftypes.NewRandB32().String()
-	input := []interface{}{
-		float64(1),
-		"1000000000000000000000000",
-	}
-	options := map[string]interface{}{
-		"contract": "not really a contract",
-	}
-	definitionBytes, err := json.Marshal([]interface{}{})
-	contractBytes, err := json.Marshal("0x123456")
-	assert.NoError(t, err)
-	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
-		func(req *http.Request) (*http.Response, error) {
-			var body map[string]interface{}
-			json.NewDecoder(req.Body).Decode(&body)
-			params := body["params"].([]interface{})
-			headers := body["headers"].(map[string]interface{})
-			assert.Equal(t, core.DeployContract, headers["type"])
-			assert.Equal(t, float64(1), params[0])
-			assert.Equal(t, "1000000000000000000000000", params[1])
-			assert.Equal(t, body["customOption"].(string), "customValue")
-			return httpmock.NewJsonResponderOrPanic(400, "pop")(req)
-		})
-	_, err = e.DeployContract(context.Background(), "", signingKey, fftypes.JSONAnyPtrBytes(definitionBytes), fftypes.JSONAnyPtrBytes(contractBytes), input, options)
-	assert.Regexp(t, "FF10429", err)
-}
-
 func TestInvokeContractBadSchema(t *testing.T) {
 	e, cancel := newTestFabric()
 	defer cancel()
@@ -2916,13 +2884,15 @@ func TestGetFFIParamValidator(t *testing.T) {
 
 func TestGenerateFFI(t *testing.T) {
 	e, _ := newTestFabric()
-	_, err := e.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+	ffi, err := e.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
 		Name:        "Simple",
 		Version:     "v0.0.1",
 		Description: "desc",
-		Input:       fftypes.JSONAnyPtr(`[]`),
+		Input:       fftypes.JSONAnyPtr(`{"metadata": {"contracts": {"Simple": {"transactions": [{"name": "Set"}]}}}}`),
 	})
-	assert.Regexp(t, "FF10347", err)
+	assert.NoError(t, err)
+	assert.Equal(t, "desc", ffi.Description)
+	assert.Equal(t, "Simple:Set", ffi.Methods[0].Name)
 }
 
 func TestGenerateEventSignature(t *testing.T) {
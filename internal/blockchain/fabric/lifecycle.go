@@ -0,0 +1,190 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fabric
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/blockchain/common"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+const (
+	lifecycleStepInstall = "install"
+	lifecycleStepApprove = "approve"
+	lifecycleStepCommit  = "commit"
+)
+
+// chaincodeDefinition is the definition of a chaincode, passed as the definition of a contract deployment
+type chaincodeDefinition struct {
+	Channel           string `json:"channel,omitempty"`
+	Name              string `json:"name"`
+	Version           string `json:"version"`
+	Sequence          int64  `json:"sequence"`
+	EndorsementPolicy string `json:"endorsementPolicy,omitempty"`
+	InitRequired      bool   `json:"initRequired,omitempty"`
+}
+
+type fabInstallChaincodeInput struct {
+	Headers *fabTxInputHeaders `json:"headers"`
+	Package string             `json:"package"`
+}
+
+type fabInstallChaincodeOutput struct {
+	PackageID string `json:"packageId"`
+}
+
+type fabChaincodeDefinitionInput struct {
+	Headers           *fabTxInputHeaders `json:"headers"`
+	Version           string             `json:"version"`
+	Sequence          int64              `json:"sequence"`
+	PackageID         string             `json:"packageId,omitempty"`
+	EndorsementPolicy string             `json:"endorsementPolicy,omitempty"`
+	InitRequired      bool               `json:"initRequired,omitempty"`
+}
+
+type fabChaincodeDefinitionOutput struct {
+	TransactionID string `json:"transactionID"`
+}
+
+// lifecycleStepResult is the result of one step of a chaincode deployment, recorded in the operation output
+type lifecycleStepResult struct {
+	Step          string        `json:"step"`
+	Status        core.OpStatus `json:"status"`
+	PackageID     string        `json:"packageId,omitempty"`
+	TransactionID string        `json:"transactionId,omitempty"`
+	Error         string        `json:"error,omitempty"`
+}
+
+func (f *Fabric) parseChaincodeDeployment(ctx context.Context, definition, contract *fftypes.JSONAny, input []interface{}) (*chaincodeDefinition, string, error) {
+	var cd chaincodeDefinition
+	if definition == nil {
+		return nil, "", i18n.NewError(ctx, coremsgs.MsgInvalidChaincodeDefinition, "'definition' not set")
+	}
+	if err := json.Unmarshal(definition.Bytes(), &cd); err != nil {
+		return nil, "", i18n.NewError(ctx, coremsgs.MsgInvalidChaincodeDefinition, err)
+	}
+	if cd.Channel == "" {
+		cd.Channel = f.defaultChannel
+	}
+	switch {
+	case cd.Channel == "":
+		return nil, "", i18n.NewError(ctx, coremsgs.MsgInvalidChaincodeDefinition, "'channel' not set")
+	case cd.Name == "":
+		return nil, "", i18n.NewError(ctx, coremsgs.MsgInvalidChaincodeDefinition, "'name' not set")
+	case cd.Version == "":
+		return nil, "", i18n.NewError(ctx, coremsgs.MsgInvalidChaincodeDefinition, "'version' not set")
+	case cd.Sequence < 1:
+		return nil, "", i18n.NewError(ctx, coremsgs.MsgInvalidChaincodeDefinition, "'sequence' must be 1 or more")
+	case len(input) > 0:
+		// The chaincode is not initialized as part of the deployment - an init function is invoked afterwards
+		return nil, "", i18n.NewError(ctx, coremsgs.MsgInvalidChaincodeDefinition, "'input' is not supported")
+	}
+
+	var pkg string
+	if contract == nil || json.Unmarshal(contract.Bytes(), &pkg) != nil || pkg == "" {
+		return nil, "", i18n.NewError(ctx, coremsgs.MsgInvalidChaincodePackage)
+	}
+	if _, err := base64.StdEncoding.DecodeString(pkg); err != nil {
+		return nil, "", i18n.NewError(ctx, coremsgs.MsgInvalidChaincodePackage)
+	}
+	return &cd, pkg, nil
+}
+
+// deployChaincode runs each step of the chaincode lifecycle in turn: installing the package on the peers of the
+// organization, approving the definition for the organization, and committing the definition to the channel.
+// The result of each step is recorded in the output of the operation, which succeeds once the definition is committed.
+func (f *Fabric) deployChaincode(ctx context.Context, nsOpID, signingKey string, cd *chaincodeDefinition, pkg string) {
+	var steps []lifecycleStepResult
+	update := func(status core.OpStatus, txID, errorMessage string, location *Location) {
+		// Each update gets its own copy of the steps, as updates are processed asynchronously
+		output := fftypes.JSONObject{"steps": append([]lifecycleStepResult{}, steps...)}
+		if location != nil {
+			output["contractLocation"] = location
+		}
+		f.callbacks.OperationUpdate(ctx, f, nsOpID, status, txID, errorMessage, output)
+	}
+	failed := func(step string, err error) {
+		log.L(ctx).Errorf("Chaincode %s step '%s' failed: %s", cd.Name, step, err)
+		steps = append(steps, lifecycleStepResult{Step: step, Status: core.OpStatusFailed, Error: err.Error()})
+		update(core.OpStatusFailed, "", err.Error(), nil)
+	}
+
+	var installed fabInstallChaincodeOutput
+	err := f.lifecycleRequest(ctx, "/chaincodes/install", &fabInstallChaincodeInput{
+		Headers: &fabTxInputHeaders{Type: "InstallChaincode", Signer: signingKey, Channel: cd.Channel},
+		Package: pkg,
+	}, &installed)
+	if err != nil {
+		failed(lifecycleStepInstall, err)
+		return
+	}
+	steps = append(steps, lifecycleStepResult{Step: lifecycleStepInstall, Status: core.OpStatusSucceeded, PackageID: installed.PackageID})
+	update(core.OpStatusPending, "", "", nil)
+
+	var approved fabChaincodeDefinitionOutput
+	err = f.lifecycleRequest(ctx, "/chaincodes/approve", f.chaincodeDefinitionInput("ApproveChaincode", signingKey, cd, installed.PackageID), &approved)
+	if err != nil {
+		failed(lifecycleStepApprove, err)
+		return
+	}
+	steps = append(steps, lifecycleStepResult{Step: lifecycleStepApprove, Status: core.OpStatusSucceeded, TransactionID: approved.TransactionID})
+	update(core.OpStatusPending, approved.TransactionID, "", nil)
+
+	var committed fabChaincodeDefinitionOutput
+	err = f.lifecycleRequest(ctx, "/chaincodes/commit", f.chaincodeDefinitionInput("CommitChaincode", signingKey, cd, ""), &committed)
+	if err != nil {
+		failed(lifecycleStepCommit, err)
+		return
+	}
+	steps = append(steps, lifecycleStepResult{Step: lifecycleStepCommit, Status: core.OpStatusSucceeded, TransactionID: committed.TransactionID})
+	log.L(ctx).Infof("Chaincode %s version %s sequence %d committed to channel %s", cd.Name, cd.Version, cd.Sequence, cd.Channel)
+	update(core.OpStatusSucceeded, committed.TransactionID, "", &Location{Channel: cd.Channel, Chaincode: cd.Name})
+}
+
+func (f *Fabric) chaincodeDefinitionInput(txType, signingKey string, cd *chaincodeDefinition, packageID string) *fabChaincodeDefinitionInput {
+	return &fabChaincodeDefinitionInput{
+		Headers:           &fabTxInputHeaders{Type: txType, Signer: signingKey, Channel: cd.Channel, Chaincode: cd.Name},
+		Version:           cd.Version,
+		Sequence:          cd.Sequence,
+		PackageID:         packageID,
+		EndorsementPolicy: cd.EndorsementPolicy,
+		InitRequired:      cd.InitRequired,
+	}
+}
+
+// lifecycleRequest sends a chaincode lifecycle request to FabConnect, waiting for any transaction to be committed
+func (f *Fabric) lifecycleRequest(ctx context.Context, path string, body, result interface{}) error {
+	var resErr common.BlockchainRESTError
+	res, err := f.client.R().
+		SetContext(ctx).
+		SetHeader("x-firefly-sync", "true").
+		SetBody(body).
+		SetResult(result).
+		SetError(&resErr).
+		Post(path)
+	if err != nil || !res.IsSuccess() {
+		return common.WrapRESTError(ctx, &resErr, res, err, coremsgs.MsgFabconnectRESTErr)
+	}
+	return nil
+}
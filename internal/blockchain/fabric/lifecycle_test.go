@@ -0,0 +1,285 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fabric
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/coremocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testChaincodePackage = "c29tZSBjaGFpbmNvZGUgcGFja2FnZQ==" // "some chaincode package"
+
+func newTestChaincodeDeployment(t *testing.T) (*Fabric, chan *core.OperationUpdateAsync, func()) {
+	e, cancel := newTestFabric()
+	mm := &metricsmocks.Manager{}
+	mm.On("IsMetricsEnabled").Return(true)
+	mm.On("BlockchainContractDeployment").Return()
+	e.metrics = mm
+	httpmock.ActivateNonDefault(e.client.GetClient())
+
+	updates := make(chan *core.OperationUpdateAsync, 3)
+	em := &coremocks.OperationCallbacks{}
+	em.On("OperationUpdate", mock.Anything).Run(func(args mock.Arguments) {
+		updates <- args[0].(*core.OperationUpdateAsync)
+	})
+	e.SetOperationHandler("ns1", em)
+	return e, updates, func() {
+		httpmock.DeactivateAndReset()
+		cancel()
+		mm.AssertExpectations(t)
+	}
+}
+
+func testChaincodeDefinition() *fftypes.JSONAny {
+	return fftypes.JSONAnyPtr(`{
+		"name": "asset_transfer",
+		"version": "1.0",
+		"sequence": 2,
+		"endorsementPolicy": "OR('Org1MSP.peer')"
+	}`)
+}
+
+func deployTestChaincode(t *testing.T, e *Fabric) string {
+	nsOpID := "ns1:" + fftypes.NewUUID().String()
+	rejected, err := e.DeployContract(context.Background(), nsOpID, signer, testChaincodeDefinition(), fftypes.JSONAnyPtr(`"`+testChaincodePackage+`"`), nil, nil)
+	assert.NoError(t, err)
+	assert.False(t, rejected)
+	return nsOpID
+}
+
+func lifecycleSteps(t *testing.T, update *core.OperationUpdateAsync) []map[string]interface{} {
+	var steps []map[string]interface{}
+	b, err := json.Marshal(update.Output["steps"])
+	assert.NoError(t, err)
+	err = json.Unmarshal(b, &steps)
+	assert.NoError(t, err)
+	return steps
+}
+
+func mockInstall(t *testing.T) {
+	httpmock.RegisterResponder("POST", "http://localhost:12345/chaincodes/install",
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			headers := body["headers"].(map[string]interface{})
+			assert.Equal(t, "true", req.Header.Get("x-firefly-sync"))
+			assert.Equal(t, "InstallChaincode", headers["type"])
+			assert.Equal(t, signer, headers["signer"])
+			assert.Equal(t, "firefly", headers["channel"])
+			assert.Equal(t, testChaincodePackage, body["package"])
+			return httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"packageId": "asset_transfer_1.0:abcd",
+			})(req)
+		})
+}
+
+func mockApprove(t *testing.T) {
+	httpmock.RegisterResponder("POST", "http://localhost:12345/chaincodes/approve",
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			headers := body["headers"].(map[string]interface{})
+			assert.Equal(t, "true", req.Header.Get("x-firefly-sync"))
+			assert.Equal(t, "ApproveChaincode", headers["type"])
+			assert.Equal(t, signer, headers["signer"])
+			assert.Equal(t, "firefly", headers["channel"])
+			assert.Equal(t, "asset_transfer", headers["chaincode"])
+			assert.Equal(t, "1.0", body["version"])
+			assert.Equal(t, float64(2), body["sequence"])
+			assert.Equal(t, "asset_transfer_1.0:abcd", body["packageId"])
+			assert.Equal(t, "OR('Org1MSP.peer')", body["endorsementPolicy"])
+			return httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"transactionID": "tx1",
+			})(req)
+		})
+}
+
+func TestDeployContractOK(t *testing.T) {
+	e, updates, done := newTestChaincodeDeployment(t)
+	defer done()
+
+	mockInstall(t)
+	mockApprove(t)
+	httpmock.RegisterResponder("POST", "http://localhost:12345/chaincodes/commit",
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			headers := body["headers"].(map[string]interface{})
+			assert.Equal(t, "true", req.Header.Get("x-firefly-sync"))
+			assert.Equal(t, "CommitChaincode", headers["type"])
+			assert.Equal(t, "asset_transfer", headers["chaincode"])
+			assert.Equal(t, float64(2), body["sequence"])
+			assert.Nil(t, body["packageId"])
+			return httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"transactionID": "tx2",
+			})(req)
+		})
+
+	nsOpID := deployTestChaincode(t, e)
+
+	update := <-updates
+	assert.Equal(t, nsOpID, update.NamespacedOpID)
+	assert.Equal(t, "fabric", update.Plugin)
+	assert.Equal(t, core.OpStatusPending, update.Status)
+	assert.Equal(t, []map[string]interface{}{
+		{"step": "install", "status": "Succeeded", "packageId": "asset_transfer_1.0:abcd"},
+	}, lifecycleSteps(t, update))
+
+	update = <-updates
+	assert.Equal(t, core.OpStatusPending, update.Status)
+	assert.Equal(t, "tx1", update.BlockchainTXID)
+	assert.Equal(t, []map[string]interface{}{
+		{"step": "install", "status": "Succeeded", "packageId": "asset_transfer_1.0:abcd"},
+		{"step": "approve", "status": "Succeeded", "transactionId": "tx1"},
+	}, lifecycleSteps(t, update))
+
+	update = <-updates
+	assert.Equal(t, core.OpStatusSucceeded, update.Status)
+	assert.Equal(t, "tx2", update.BlockchainTXID)
+	assert.Equal(t, []map[string]interface{}{
+		{"step": "install", "status": "Succeeded", "packageId": "asset_transfer_1.0:abcd"},
+		{"step": "approve", "status": "Succeeded", "transactionId": "tx1"},
+		{"step": "commit", "status": "Succeeded", "transactionId": "tx2"},
+	}, lifecycleSteps(t, update))
+	assert.Equal(t, &Location{Channel: "firefly", Chaincode: "asset_transfer"}, update.Output["contractLocation"])
+}
+
+func TestDeployContractInstallFail(t *testing.T) {
+	e, updates, done := newTestChaincodeDeployment(t)
+	defer done()
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/chaincodes/install",
+		httpmock.NewJsonResponderOrPanic(500, map[string]interface{}{"error": "pop"}))
+
+	deployTestChaincode(t, e)
+
+	update := <-updates
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.Regexp(t, "FF10284.*pop", update.ErrorMessage)
+	steps := lifecycleSteps(t, update)
+	assert.Len(t, steps, 1)
+	assert.Equal(t, "install", steps[0]["step"])
+	assert.Equal(t, "Failed", steps[0]["status"])
+	assert.Regexp(t, "FF10284.*pop", steps[0]["error"])
+}
+
+func TestDeployContractApproveFail(t *testing.T) {
+	e, updates, done := newTestChaincodeDeployment(t)
+	defer done()
+
+	mockInstall(t)
+	httpmock.RegisterResponder("POST", "http://localhost:12345/chaincodes/approve",
+		httpmock.NewJsonResponderOrPanic(500, map[string]interface{}{"error": "pop"}))
+
+	deployTestChaincode(t, e)
+
+	update := <-updates
+	assert.Equal(t, core.OpStatusPending, update.Status)
+
+	update = <-updates
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.Regexp(t, "FF10284.*pop", update.ErrorMessage)
+	steps := lifecycleSteps(t, update)
+	assert.Len(t, steps, 2)
+	assert.Equal(t, "Succeeded", steps[0]["status"])
+	assert.Equal(t, "approve", steps[1]["step"])
+	assert.Equal(t, "Failed", steps[1]["status"])
+}
+
+func TestDeployContractCommitFail(t *testing.T) {
+	e, updates, done := newTestChaincodeDeployment(t)
+	defer done()
+
+	mockInstall(t)
+	mockApprove(t)
+	httpmock.RegisterResponder("POST", "http://localhost:12345/chaincodes/commit",
+		httpmock.NewJsonResponderOrPanic(500, map[string]interface{}{"error": "pop"}))
+
+	deployTestChaincode(t, e)
+
+	<-updates
+	<-updates
+	update := <-updates
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.Regexp(t, "FF10284.*pop", update.ErrorMessage)
+	steps := lifecycleSteps(t, update)
+	assert.Len(t, steps, 3)
+	assert.Equal(t, "Succeeded", steps[1]["status"])
+	assert.Equal(t, "commit", steps[2]["step"])
+	assert.Equal(t, "Failed", steps[2]["status"])
+	assert.Nil(t, update.Output["contractLocation"])
+}
+
+func TestDeployContractBadDefinition(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+	pkg := fftypes.JSONAnyPtr(`"` + testChaincodePackage + `"`)
+
+	for def, errMatch := range map[string]string{
+		``:                               "FF10551.*definition",
+		`!json`:                          "FF10551",
+		`{"version":"1.0","sequence":1}`: "FF10551.*name",
+		`{"name":"cc","sequence":1}`:     "FF10551.*version",
+		`{"name":"cc","version":"1.0"}`:  "FF10551.*sequence",
+		`{"name":"cc","version":"1.0","sequence":1,"channel":""}`: "",
+	} {
+		var definition *fftypes.JSONAny
+		if def != "" {
+			definition = fftypes.JSONAnyPtr(def)
+		}
+		if errMatch == "" {
+			// A channel is only needed if there is no default channel
+			e.defaultChannel = ""
+			errMatch = "FF10551.*channel"
+		}
+		rejected, err := e.DeployContract(context.Background(), "ns1:"+fftypes.NewUUID().String(), signer, definition, pkg, nil, nil)
+		assert.True(t, rejected)
+		assert.Regexp(t, errMatch, err)
+		e.defaultChannel = "firefly"
+	}
+}
+
+func TestDeployContractInputNotSupported(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+
+	rejected, err := e.DeployContract(context.Background(), "ns1:"+fftypes.NewUUID().String(), signer, testChaincodeDefinition(),
+		fftypes.JSONAnyPtr(`"`+testChaincodePackage+`"`), []interface{}{"init"}, nil)
+	assert.True(t, rejected)
+	assert.Regexp(t, "FF10551.*input", err)
+}
+
+func TestDeployContractBadPackage(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+
+	for _, pkg := range []*fftypes.JSONAny{nil, fftypes.JSONAnyPtr(`{}`), fftypes.JSONAnyPtr(`""`), fftypes.JSONAnyPtr(`"!base64"`)} {
+		rejected, err := e.DeployContract(context.Background(), "ns1:"+fftypes.NewUUID().String(), signer, testChaincodeDefinition(), pkg, nil, nil)
+		assert.True(t, rejected)
+		assert.Regexp(t, "FF10552", err)
+	}
+}
@@ -0,0 +1,323 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fabric
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+// FFIGenerationInput is the input to GenerateFFI. The metadata is the output of the
+// "org.hyperledger.fabric:GetMetadata" transaction of a chaincode built with a contract-api
+type FFIGenerationInput struct {
+	Metadata *contractMetadata `json:"metadata,omitempty"`
+	Contract string            `json:"contract,omitempty"`
+}
+
+type contractMetadata struct {
+	Info       *metadataInfo                `json:"info,omitempty"`
+	Contracts  map[string]*metadataContract `json:"contracts"`
+	Components struct {
+		Schemas map[string]fftypes.JSONObject `json:"schemas"`
+	} `json:"components"`
+}
+
+type metadataInfo struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type metadataContract struct {
+	Name             string `json:"name"`
+	ContractInstance struct {
+		Default bool `json:"default"`
+	} `json:"contractInstance"`
+	Info         *metadataInfo          `json:"info,omitempty"`
+	Transactions []*metadataTransaction `json:"transactions"`
+	Events       []*metadataEvent       `json:"events,omitempty"`
+}
+
+type metadataTransaction struct {
+	Name       string               `json:"name"`
+	Tag        []string             `json:"tag,omitempty"`
+	Tags       []string             `json:"tags,omitempty"`
+	Parameters []*metadataParameter `json:"parameters,omitempty"`
+	Returns    json.RawMessage      `json:"returns,omitempty"`
+}
+
+type metadataParameter struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Schema      fftypes.JSONObject `json:"schema"`
+}
+
+type metadataEvent struct {
+	Name   string             `json:"name"`
+	Schema fftypes.JSONObject `json:"schema,omitempty"`
+}
+
+// The system contract that every contract-api chaincode includes, which provides GetMetadata itself
+const systemContractName = "org.hyperledger.fabric"
+
+const (
+	transactionTypeSubmit   = "submit"
+	transactionTypeEvaluate = "evaluate"
+)
+
+// Schemas are inlined into each param, so a limit on the depth guards against a reference to itself
+const maxSchemaRefDepth = 16
+
+type metadataConverter struct {
+	ctx      context.Context
+	metadata *contractMetadata
+}
+
+func convertMetadataToFFI(ctx context.Context, generationRequest *fftypes.FFIGenerationRequest, input *FFIGenerationInput) (*fftypes.FFI, error) {
+	c := &metadataConverter{ctx: ctx, metadata: input.Metadata}
+	contract, err := c.selectContract(input.Contract)
+	if err != nil {
+		return nil, err
+	}
+
+	ffi := &fftypes.FFI{
+		Namespace:   generationRequest.Namespace,
+		Name:        generationRequest.Name,
+		Version:     generationRequest.Version,
+		Description: generationRequest.Description,
+		Methods:     make([]*fftypes.FFIMethod, len(contract.Transactions)),
+		Events:      make([]*fftypes.FFIEvent, len(contract.Events)),
+	}
+	if ffi.Description == "" {
+		if info := contract.Info; info != nil && info.Description != "" {
+			ffi.Description = info.Description
+		} else if info := input.Metadata.Info; info != nil {
+			ffi.Description = info.Description
+		}
+	}
+
+	// Transactions of the default contract can be called by their name alone, otherwise they must be
+	// qualified with the name of the contract
+	prefix := ""
+	if !contract.ContractInstance.Default {
+		prefix = contract.Name + ":"
+	}
+	for i, tx := range contract.Transactions {
+		if ffi.Methods[i], err = c.convertTransaction(prefix, tx); err != nil {
+			return nil, err
+		}
+	}
+	for i, event := range contract.Events {
+		if ffi.Events[i], err = c.convertEvent(event); err != nil {
+			return nil, err
+		}
+	}
+	return ffi, nil
+}
+
+func (c *metadataConverter) failed(format string, args ...interface{}) error {
+	return i18n.NewError(c.ctx, coremsgs.MsgFFIGenerationFailed, fmt.Sprintf(format, args...))
+}
+
+// selectContract returns the named contract, or the default contract of the chaincode
+func (c *metadataConverter) selectContract(name string) (*metadataContract, error) {
+	if name != "" {
+		if contract, ok := c.metadata.Contracts[name]; ok {
+			return c.withName(name, contract), nil
+		}
+		return nil, c.failed("contract '%s' not found in metadata", name)
+	}
+
+	var candidates []string
+	for name, contract := range c.metadata.Contracts {
+		if contract.ContractInstance.Default {
+			return c.withName(name, contract), nil
+		}
+		if name != systemContractName {
+			candidates = append(candidates, name)
+		}
+	}
+	if len(candidates) == 1 {
+		return c.withName(candidates[0], c.metadata.Contracts[candidates[0]]), nil
+	}
+	sort.Strings(candidates)
+	return nil, c.failed("no default contract in metadata, so 'contract' must be one of %v", candidates)
+}
+
+func (c *metadataConverter) withName(name string, contract *metadataContract) *metadataContract {
+	if contract.Name == "" {
+		contract.Name = name
+	}
+	return contract
+}
+
+func isEvaluate(tx *metadataTransaction) bool {
+	for _, tags := range [][]string{tx.Tag, tx.Tags} {
+		for _, tag := range tags {
+			switch strings.ToLower(tag) {
+			case "evaluate", "evaluatetx":
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *metadataConverter) convertTransaction(prefix string, tx *metadataTransaction) (*fftypes.FFIMethod, error) {
+	method := &fftypes.FFIMethod{
+		Name:    prefix + tx.Name,
+		Params:  make(fftypes.FFIParams, len(tx.Parameters)),
+		Returns: fftypes.FFIParams{},
+		Details: fftypes.JSONObject{"transactionType": transactionTypeSubmit},
+	}
+	if isEvaluate(tx) {
+		method.Details["transactionType"] = transactionTypeEvaluate
+	}
+	for i, param := range tx.Parameters {
+		schema, err := c.resolveSchema(param.Schema, 0)
+		if err != nil {
+			return nil, err
+		}
+		if param.Description != "" {
+			schema["description"] = param.Description
+		}
+		method.Params[i] = c.ffiParam(param.Name, schema)
+	}
+
+	returns, err := c.returnsSchema(tx)
+	if err == nil && returns != nil {
+		returns, err = c.resolveSchema(returns, 0)
+		method.Returns = fftypes.FFIParams{c.ffiParam("", returns)}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return method, nil
+}
+
+// returnsSchema accepts the returns of a transaction as a schema, an object with a schema, or a list of them
+func (c *metadataConverter) returnsSchema(tx *metadataTransaction) (fftypes.JSONObject, error) {
+	if len(tx.Returns) == 0 || string(tx.Returns) == "null" {
+		return nil, nil
+	}
+	var returns fftypes.JSONObject
+	if err := json.Unmarshal(tx.Returns, &returns); err != nil {
+		var list []fftypes.JSONObject
+		if err := json.Unmarshal(tx.Returns, &list); err != nil || len(list) > 1 {
+			return nil, c.failed("invalid returns of transaction '%s'", tx.Name)
+		}
+		if len(list) == 0 {
+			return nil, nil
+		}
+		returns = list[0]
+	}
+	if schema := returns.GetObject("schema"); len(schema) > 0 {
+		return schema, nil
+	}
+	return returns, nil
+}
+
+func (c *metadataConverter) convertEvent(event *metadataEvent) (*fftypes.FFIEvent, error) {
+	ffiEvent := &fftypes.FFIEvent{
+		FFIEventDefinition: fftypes.FFIEventDefinition{
+			Name:   event.Name,
+			Params: fftypes.FFIParams{},
+		},
+	}
+	if event.Schema == nil {
+		return ffiEvent, nil
+	}
+
+	// The properties of a JSON payload are described as the params of the event
+	schema, err := c.resolveSchema(event.Schema, 0)
+	if err != nil {
+		return nil, err
+	}
+	properties := schema.GetObject("properties")
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ffiEvent.Params = append(ffiEvent.Params, c.ffiParam(name, properties.GetObject(name)))
+	}
+	return ffiEvent, nil
+}
+
+func (c *metadataConverter) ffiParam(name string, schema fftypes.JSONObject) *fftypes.FFIParam {
+	b, _ := json.Marshal(schema)
+	return &fftypes.FFIParam{
+		Name:   name,
+		Schema: fftypes.JSONAnyPtrBytes(b),
+	}
+}
+
+// resolveSchema returns a copy of the schema with every reference to the components of the metadata inlined,
+// as the schema of each FFI param must stand alone
+func (c *metadataConverter) resolveSchema(schema fftypes.JSONObject, depth int) (fftypes.JSONObject, error) {
+	if depth > maxSchemaRefDepth {
+		return nil, c.failed("schema references are nested too deeply")
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		component, ok := c.metadata.Components.Schemas[name]
+		if !ok || name == ref {
+			return nil, c.failed("unable to resolve schema reference '%s'", ref)
+		}
+		return c.resolveSchema(component, depth+1)
+	}
+
+	resolved := make(fftypes.JSONObject, len(schema))
+	for k, v := range schema {
+		// A component carries an id, which would otherwise change how the references within it are resolved
+		if k == "$id" {
+			continue
+		}
+		r, err := c.resolveValue(v, depth)
+		if err != nil {
+			return nil, err
+		}
+		resolved[k] = r
+	}
+	return resolved, nil
+}
+
+func (c *metadataConverter) resolveValue(v interface{}, depth int) (interface{}, error) {
+	switch vt := v.(type) {
+	case map[string]interface{}:
+		return c.resolveSchema(vt, depth)
+	case []interface{}:
+		resolved := make([]interface{}, len(vt))
+		for i, item := range vt {
+			r, err := c.resolveValue(item, depth)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = r
+		}
+		return resolved, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fabric
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+const assetTransferMetadata = `{
+	"info": {"title": "asset-transfer", "description": "Asset transfer chaincode", "version": "1.0.0"},
+	"contracts": {
+		"SmartContract": {
+			"contractInstance": {"name": "SmartContract", "default": true},
+			"info": {"title": "SmartContract", "version": "latest"},
+			"transactions": [
+				{
+					"name": "CreateAsset",
+					"tag": ["submit"],
+					"parameters": [
+						{"name": "id", "description": "The asset ID", "schema": {"type": "string"}},
+						{"name": "size", "schema": {"type": "integer", "format": "int64"}},
+						{"name": "asset", "schema": {"$ref": "#/components/schemas/Asset"}}
+					]
+				},
+				{
+					"name": "ReadAsset",
+					"tag": ["evaluate"],
+					"parameters": [{"name": "id", "schema": {"type": "string"}}],
+					"returns": {"$ref": "#/components/schemas/Asset"}
+				},
+				{
+					"name": "GetAllAssets",
+					"tags": ["EVALUATE"],
+					"returns": [{"name": "success", "schema": {"type": "array", "items": {"$ref": "#/components/schemas/Asset"}}}]
+				},
+				{
+					"name": "DeleteAll",
+					"returns": []
+				}
+			],
+			"events": [
+				{"name": "AssetCreated", "schema": {"$ref": "#/components/schemas/Asset"}},
+				{"name": "Cleared"}
+			]
+		},
+		"org.hyperledger.fabric": {
+			"contractInstance": {"name": "org.hyperledger.fabric"},
+			"transactions": [{"name": "GetMetadata", "tag": ["evaluate"]}]
+		}
+	},
+	"components": {
+		"schemas": {
+			"Asset": {
+				"$id": "Asset",
+				"type": "object",
+				"required": ["ID", "Owner"],
+				"properties": {
+					"ID": {"type": "string"},
+					"Owner": {"$ref": "#/components/schemas/Owner"},
+					"Tags": {"type": "array", "items": [{"type": "string"}]}
+				}
+			},
+			"Owner": {"$id": "Owner", "type": "string"}
+		}
+	}
+}`
+
+func generateTestFFI(t *testing.T, input string) (*fftypes.FFI, error) {
+	e, _ := newTestFabric()
+	return e.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+		Namespace: "ns1",
+		Name:      "asset_transfer",
+		Version:   "v1.0.0",
+		Input:     fftypes.JSONAnyPtr(input),
+	})
+}
+
+func TestConvertMetadataToFFI(t *testing.T) {
+	ffi, err := generateTestFFI(t, `{"metadata": `+assetTransferMetadata+`}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "asset_transfer", ffi.Name)
+	assert.Equal(t, "Asset transfer chaincode", ffi.Description)
+	assert.Len(t, ffi.Methods, 4)
+
+	create := ffi.Methods[0]
+	assert.Equal(t, "CreateAsset", create.Name)
+	assert.Equal(t, "submit", create.Details.GetString("transactionType"))
+	assert.Len(t, create.Params, 3)
+	assert.Equal(t, "id", create.Params[0].Name)
+	assert.JSONEq(t, `{"type":"string","description":"The asset ID"}`, create.Params[0].Schema.String())
+	assert.JSONEq(t, `{"type":"integer","format":"int64"}`, create.Params[1].Schema.String())
+	assert.JSONEq(t, `{
+		"type": "object",
+		"required": ["ID", "Owner"],
+		"properties": {
+			"ID": {"type": "string"},
+			"Owner": {"type": "string"},
+			"Tags": {"type": "array", "items": [{"type": "string"}]}
+		}
+	}`, create.Params[2].Schema.String())
+	assert.Empty(t, create.Returns)
+
+	read := ffi.Methods[1]
+	assert.Equal(t, "evaluate", read.Details.GetString("transactionType"))
+	assert.Len(t, read.Returns, 1)
+	assert.Equal(t, "object", read.Returns[0].Schema.JSONObject().GetString("type"))
+
+	getAll := ffi.Methods[2]
+	assert.Equal(t, "evaluate", getAll.Details.GetString("transactionType"))
+	assert.Equal(t, "array", getAll.Returns[0].Schema.JSONObject().GetString("type"))
+
+	assert.Empty(t, ffi.Methods[3].Returns)
+
+	assert.Len(t, ffi.Events, 2)
+	assert.Equal(t, "AssetCreated", ffi.Events[0].Name)
+	assert.Len(t, ffi.Events[0].Params, 3)
+	assert.Equal(t, "ID", ffi.Events[0].Params[0].Name)
+	assert.Equal(t, "Owner", ffi.Events[0].Params[1].Name)
+	assert.Empty(t, ffi.Events[1].Params)
+}
+
+func TestConvertMetadataToFFINamedContract(t *testing.T) {
+	ffi, err := generateTestFFI(t, `{
+		"metadata": {
+			"info": {"description": "top level"},
+			"contracts": {
+				"AssetContract": {"info": {"title": "assets"}, "transactions": [{"name": "CreateAsset"}]},
+				"OwnerContract": {"transactions": [{"name": "CreateOwner"}]}
+			}
+		},
+		"contract": "OwnerContract"
+	}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "top level", ffi.Description)
+	assert.Equal(t, "OwnerContract:CreateOwner", ffi.Methods[0].Name)
+}
+
+func TestConvertMetadataToFFISingleContract(t *testing.T) {
+	ffi, err := generateTestFFI(t, `{
+		"metadata": {
+			"contracts": {
+				"AssetContract": {"info": {"description": "assets"}, "transactions": [{"name": "CreateAsset"}]},
+				"org.hyperledger.fabric": {"transactions": [{"name": "GetMetadata"}]}
+			}
+		}
+	}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "assets", ffi.Description)
+	assert.Equal(t, "AssetContract:CreateAsset", ffi.Methods[0].Name)
+}
+
+func TestConvertMetadataToFFIErrors(t *testing.T) {
+	for name, input := range map[string]string{
+		"bad JSON":           `[]`,
+		"empty":              `{}`,
+		"no contracts":       `{"metadata": {"contracts": {}}}`,
+		"unknown contract":   `{"metadata": {"contracts": {"A": {}}}, "contract": "B"}`,
+		"no default":         `{"metadata": {"contracts": {"A": {}, "B": {}}}}`,
+		"bad param ref":      `{"metadata": {"contracts": {"A": {"transactions": [{"name": "tx", "parameters": [{"name": "p", "schema": {"$ref": "#/components/schemas/Missing"}}]}]}}}}`,
+		"external ref":       `{"metadata": {"contracts": {"A": {"transactions": [{"name": "tx", "parameters": [{"name": "p", "schema": {"$ref": "http://example.com/schema"}}]}]}}}}`,
+		"bad nested ref":     `{"metadata": {"contracts": {"A": {"transactions": [{"name": "tx", "parameters": [{"name": "p", "schema": {"anyOf": [{"$ref": "#/components/schemas/Missing"}]}}]}]}}}}`,
+		"bad returns ref":    `{"metadata": {"contracts": {"A": {"transactions": [{"name": "tx", "returns": {"$ref": "#/components/schemas/Missing"}}]}}}}`,
+		"bad returns":        `{"metadata": {"contracts": {"A": {"transactions": [{"name": "tx", "returns": "string"}]}}}}`,
+		"multiple returns":   `{"metadata": {"contracts": {"A": {"transactions": [{"name": "tx", "returns": [{}, {}]}]}}}}`,
+		"bad event ref":      `{"metadata": {"contracts": {"A": {"events": [{"name": "ev", "schema": {"$ref": "#/components/schemas/Missing"}}]}}}}`,
+		"recursive ref":      `{"metadata": {"contracts": {"A": {"transactions": [{"name": "tx", "parameters": [{"name": "p", "schema": {"$ref": "#/components/schemas/Node"}}]}]}}, "components": {"schemas": {"Node": {"type": "object", "properties": {"next": {"$ref": "#/components/schemas/Node"}}}}}}}`,
+		"recursive ref list": `{"metadata": {"contracts": {"A": {"transactions": [{"name": "tx", "parameters": [{"name": "p", "schema": {"$ref": "#/components/schemas/Node"}}]}]}}, "components": {"schemas": {"Node": {"type": "array", "items": [{"$ref": "#/components/schemas/Node"}]}}}}}`,
+	} {
+		_, err := generateTestFFI(t, input)
+		assert.Regexp(t, "FF10346", err, name)
+	}
+}
@@ -0,0 +1,455 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+// FFIGenerationInput is the input to GenerateFFI, which accepts the IDL of an Anchor program
+type FFIGenerationInput struct {
+	IDL *anchorIDL `json:"idl,omitempty"`
+}
+
+// anchorIDL is the part of an Anchor IDL needed to build an FFI. Both the legacy format, and the format
+// introduced in Anchor 0.30 (with discriminators, and the fields of events declared in the types) are accepted.
+type anchorIDL struct {
+	Instructions []*anchorInstruction `json:"instructions"`
+	Events       []*anchorEvent       `json:"events,omitempty"`
+	Errors       []*anchorError       `json:"errors,omitempty"`
+	Types        []*anchorTypeDef     `json:"types,omitempty"`
+}
+
+// anchorInstruction is an instruction of a program, which is passed as the method to the connector.
+// The connector serializes the args using the types, and builds the instruction from the accounts.
+type anchorInstruction struct {
+	Name          string           `json:"name"`
+	Docs          []string         `json:"docs,omitempty"`
+	Discriminator []int            `json:"discriminator,omitempty"`
+	Accounts      []interface{}    `json:"accounts,omitempty"`
+	Args          []*anchorField   `json:"args"`
+	Returns       interface{}      `json:"returns,omitempty"`
+	Types         []*anchorTypeDef `json:"types,omitempty"`
+}
+
+type anchorField struct {
+	Name string      `json:"name"`
+	Docs []string    `json:"docs,omitempty"`
+	Type interface{} `json:"type"`
+}
+
+// anchorEvent is an event emitted by a program, which is passed in the filters of a subscription to the connector
+type anchorEvent struct {
+	Name          string           `json:"name"`
+	Discriminator []int            `json:"discriminator,omitempty"`
+	Fields        []*anchorField   `json:"fields,omitempty"`
+	Types         []*anchorTypeDef `json:"types,omitempty"`
+}
+
+type anchorError struct {
+	Code int    `json:"code"`
+	Name string `json:"name"`
+	Msg  string `json:"msg,omitempty"`
+}
+
+type anchorTypeDef struct {
+	Name string          `json:"name"`
+	Docs []string        `json:"docs,omitempty"`
+	Type *anchorTypeKind `json:"type"`
+}
+
+// anchorTypeKind is a struct, enum or (in Anchor 0.30) type alias. The fields of a struct or enum variant
+// are either all named fields, or all types for a tuple.
+type anchorTypeKind struct {
+	Kind     string           `json:"kind"`
+	Fields   []interface{}    `json:"fields,omitempty"`
+	Variants []*anchorVariant `json:"variants,omitempty"`
+	Alias    interface{}      `json:"alias,omitempty"`
+}
+
+type anchorVariant struct {
+	Name   string        `json:"name"`
+	Fields []interface{} `json:"fields,omitempty"`
+}
+
+// anchorDetails are the details of an FFI method or event, which carry what the connector needs
+// beyond the types of the params
+type anchorDetails struct {
+	Discriminator []int            `json:"discriminator,omitempty"`
+	Accounts      []interface{}    `json:"accounts,omitempty"`
+	Types         []*anchorTypeDef `json:"types,omitempty"`
+}
+
+// JSON schema types
+const (
+	_jsonInteger = "integer"
+	_jsonNumber  = "number"
+	_jsonString  = "string"
+	_jsonBoolean = "boolean"
+	_jsonArray   = "array"
+	_jsonObject  = "object"
+)
+
+type anchorConverter struct {
+	ctx       context.Context
+	typeDefs  []*anchorTypeDef
+	types     map[string]*anchorTypeDef
+	used      map[string]bool
+	resolving map[string]bool
+}
+
+// convertAnchorToFFI builds an FFI method for each instruction, event and error of a program. The schema of
+// each param carries the Anchor type in its "details", so the methods can be invoked and the events listened
+// to as generated.
+func convertAnchorToFFI(ctx context.Context, generationRequest *fftypes.FFIGenerationRequest, idl *anchorIDL) (*fftypes.FFI, error) {
+	c := &anchorConverter{
+		ctx:      ctx,
+		typeDefs: idl.Types,
+		types:    make(map[string]*anchorTypeDef),
+	}
+	for _, typeDef := range idl.Types {
+		c.types[typeDef.Name] = typeDef
+	}
+
+	ffi := &fftypes.FFI{
+		Namespace:   generationRequest.Namespace,
+		Name:        generationRequest.Name,
+		Version:     generationRequest.Version,
+		Description: generationRequest.Description,
+		Methods:     make([]*fftypes.FFIMethod, len(idl.Instructions)),
+		Events:      make([]*fftypes.FFIEvent, len(idl.Events)),
+		Errors:      make([]*fftypes.FFIError, len(idl.Errors)),
+	}
+	for i, ix := range idl.Instructions {
+		method, err := c.convertInstruction(ix)
+		if err != nil {
+			return nil, err
+		}
+		ffi.Methods[i] = method
+	}
+	for i, ev := range idl.Events {
+		event, err := c.convertEvent(ev)
+		if err != nil {
+			return nil, err
+		}
+		ffi.Events[i] = event
+	}
+	for i, e := range idl.Errors {
+		ffi.Errors[i] = &fftypes.FFIError{
+			FFIErrorDefinition: fftypes.FFIErrorDefinition{
+				Name:        e.Name,
+				Description: e.Msg,
+				Params:      fftypes.FFIParams{},
+			},
+		}
+	}
+	return ffi, nil
+}
+
+func (c *anchorConverter) unsupported(name string, anchorType interface{}) error {
+	b, _ := json.Marshal(anchorType)
+	return i18n.NewError(c.ctx, coremsgs.MsgFFIGenerationFailed, fmt.Sprintf("unsupported type %s of '%s'", b, name))
+}
+
+func (c *anchorConverter) convertInstruction(ix *anchorInstruction) (*fftypes.FFIMethod, error) {
+	c.used = make(map[string]bool)
+	params, err := c.convertFields(ix.Args)
+	if err != nil {
+		return nil, err
+	}
+	returns := fftypes.FFIParams{}
+	if ix.Returns != nil {
+		schema, err := c.paramSchema("output", ix.Returns)
+		if err != nil {
+			return nil, err
+		}
+		returns = append(returns, &fftypes.FFIParam{Name: "output", Schema: schema})
+	}
+	return &fftypes.FFIMethod{
+		Name:        ix.Name,
+		Description: strings.Join(ix.Docs, " "),
+		Params:      params,
+		Returns:     returns,
+		Details: c.details(&anchorDetails{
+			Discriminator: ix.Discriminator,
+			Accounts:      ix.Accounts,
+		}),
+	}, nil
+}
+
+func (c *anchorConverter) convertEvent(ev *anchorEvent) (*fftypes.FFIEvent, error) {
+	fields := ev.Fields
+	if len(fields) == 0 {
+		// Since Anchor 0.30 the fields of an event are declared in a struct of the same name
+		if typeDef, ok := c.types[ev.Name]; ok && typeDef.Type != nil && typeDef.Type.Kind == "struct" {
+			var tuple []interface{}
+			if fields, tuple = splitFields(typeDef.Type.Fields); len(tuple) > 0 {
+				return nil, c.unsupported(ev.Name, typeDef.Type)
+			}
+		}
+	}
+	c.used = make(map[string]bool)
+	params, err := c.convertFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	return &fftypes.FFIEvent{
+		FFIEventDefinition: fftypes.FFIEventDefinition{
+			Name:    ev.Name,
+			Params:  params,
+			Details: c.details(&anchorDetails{Discriminator: ev.Discriminator}),
+		},
+	}, nil
+}
+
+// details adds the types used by the params of a method or event to its details
+func (c *anchorConverter) details(details *anchorDetails) fftypes.JSONObject {
+	for _, typeDef := range c.typeDefs {
+		if c.used[typeDef.Name] {
+			details.Types = append(details.Types, typeDef)
+		}
+	}
+	var result fftypes.JSONObject
+	b, _ := json.Marshal(details)
+	_ = json.Unmarshal(b, &result)
+	return result
+}
+
+func (c *anchorConverter) convertFields(fields []*anchorField) (fftypes.FFIParams, error) {
+	params := make(fftypes.FFIParams, len(fields))
+	for i, field := range fields {
+		schema, err := c.paramSchema(field.Name, field.Type)
+		if err != nil {
+			return nil, err
+		}
+		params[i] = &fftypes.FFIParam{
+			Name:   field.Name,
+			Schema: schema,
+		}
+	}
+	return params, nil
+}
+
+// paramSchema builds the schema of a param, with the Anchor type in the details
+func (c *anchorConverter) paramSchema(name string, anchorType interface{}) (*fftypes.JSONAny, error) {
+	schema, err := c.schema(name, anchorType)
+	if err != nil {
+		return nil, err
+	}
+	schema["details"] = map[string]interface{}{
+		"type": anchorType,
+	}
+	b, _ := json.Marshal(schema)
+	return fftypes.JSONAnyPtrBytes(b), nil
+}
+
+func (c *anchorConverter) schema(name string, anchorType interface{}) (map[string]interface{}, error) {
+	switch t := anchorType.(type) {
+	case string:
+		if schema := primitiveSchema(t); schema != nil {
+			return schema, nil
+		}
+	case map[string]interface{}:
+		if len(t) != 1 {
+			break
+		}
+		if v, ok := t["vec"]; ok {
+			return c.arraySchema(name, v, -1)
+		}
+		if v, ok := t["array"].([]interface{}); ok && len(v) == 2 {
+			if n, ok := v[1].(float64); ok {
+				return c.arraySchema(name, v[0], int(n))
+			}
+		}
+		if v, ok := t["option"]; ok {
+			return c.schema(name, v)
+		}
+		if v, ok := t["coption"]; ok {
+			return c.schema(name, v)
+		}
+		if typeName := definedTypeName(t); typeName != "" {
+			return c.definedSchema(name, typeName)
+		}
+	}
+	return nil, c.unsupported(name, anchorType)
+}
+
+func primitiveSchema(anchorType string) map[string]interface{} {
+	switch anchorType {
+	case "bool":
+		return map[string]interface{}{"type": _jsonBoolean}
+	case "u8", "i8", "u16", "i16", "u32", "i32":
+		return map[string]interface{}{"type": _jsonInteger}
+	case "u64", "i64", "u128", "i128", "u256", "i256":
+		return map[string]interface{}{
+			"oneOf": []interface{}{
+				map[string]interface{}{"type": _jsonString},
+				map[string]interface{}{"type": _jsonInteger},
+			},
+			"description": "An integer. You are recommended to use a JSON string. A JSON number can be used for values up to the safe maximum.",
+		}
+	case "f32", "f64":
+		return map[string]interface{}{"type": _jsonNumber}
+	case "string":
+		return map[string]interface{}{"type": _jsonString}
+	case "publicKey", "pubkey":
+		return map[string]interface{}{"type": _jsonString, "description": "A base58 encoded public key"}
+	case "bytes":
+		return map[string]interface{}{"type": _jsonString, "description": "A hex encoded set of bytes, with an optional '0x' prefix"}
+	}
+	return nil
+}
+
+func (c *anchorConverter) arraySchema(name string, itemType interface{}, length int) (map[string]interface{}, error) {
+	items, err := c.schema(name, itemType)
+	if err != nil {
+		return nil, err
+	}
+	schema := map[string]interface{}{
+		"type":  _jsonArray,
+		"items": items,
+	}
+	if length >= 0 {
+		schema["minItems"] = length
+		schema["maxItems"] = length
+	}
+	return schema, nil
+}
+
+func (c *anchorConverter) definedSchema(name, typeName string) (map[string]interface{}, error) {
+	typeDef, ok := c.types[typeName]
+	if !ok || typeDef.Type == nil || c.resolving[typeName] {
+		// Recursive types cannot be described by a schema without references
+		return nil, c.unsupported(name, map[string]interface{}{"defined": typeName})
+	}
+	if c.resolving == nil {
+		c.resolving = make(map[string]bool)
+	}
+	c.resolving[typeName] = true
+	defer delete(c.resolving, typeName)
+	c.used[typeName] = true
+
+	switch typeDef.Type.Kind {
+	case "struct":
+		return c.fieldsSchema(name, typeDef.Type.Fields)
+	case "enum":
+		return c.enumSchema(name, typeDef.Type.Variants)
+	case "type":
+		return c.schema(name, typeDef.Type.Alias)
+	}
+	return nil, c.unsupported(name, typeDef.Type)
+}
+
+// fieldsSchema builds an object for named fields, or an array for the fields of a tuple
+func (c *anchorConverter) fieldsSchema(name string, fields []interface{}) (map[string]interface{}, error) {
+	named, tuple := splitFields(fields)
+	if len(tuple) > 0 {
+		prefixItems := make([]interface{}, len(tuple))
+		for i, fieldType := range tuple {
+			schema, err := c.schema(name, fieldType)
+			if err != nil {
+				return nil, err
+			}
+			prefixItems[i] = schema
+		}
+		return map[string]interface{}{
+			"type":        _jsonArray,
+			"prefixItems": prefixItems,
+			"minItems":    len(tuple),
+			"maxItems":    len(tuple),
+		}, nil
+	}
+
+	properties := make(map[string]interface{}, len(named))
+	required := make([]string, len(named))
+	for i, field := range named {
+		schema, err := c.schema(name, field.Type)
+		if err != nil {
+			return nil, err
+		}
+		properties[field.Name] = schema
+		required[i] = field.Name
+	}
+	return map[string]interface{}{
+		"type":       _jsonObject,
+		"properties": properties,
+		"required":   required,
+	}, nil
+}
+
+// enumSchema builds a string for an enum where no variant has fields, or otherwise an object
+// with the name of the variant as its only property
+func (c *anchorConverter) enumSchema(name string, variants []*anchorVariant) (map[string]interface{}, error) {
+	names := make([]string, len(variants))
+	properties := make(map[string]interface{}, len(variants))
+	hasFields := false
+	for i, variant := range variants {
+		names[i] = variant.Name
+		schema, err := c.fieldsSchema(name, variant.Fields)
+		if err != nil {
+			return nil, err
+		}
+		properties[variant.Name] = schema
+		hasFields = hasFields || len(variant.Fields) > 0
+	}
+	if !hasFields {
+		return map[string]interface{}{
+			"type": _jsonString,
+			"enum": names,
+		}, nil
+	}
+	return map[string]interface{}{
+		"type":          _jsonObject,
+		"properties":    properties,
+		"minProperties": 1,
+		"maxProperties": 1,
+	}, nil
+}
+
+// splitFields returns the fields of a struct or enum variant, as either named fields or the types of a tuple
+func splitFields(fields []interface{}) (named []*anchorField, tuple []interface{}) {
+	for _, field := range fields {
+		if m, ok := field.(map[string]interface{}); ok {
+			if name, ok := m["name"].(string); ok {
+				named = append(named, &anchorField{Name: name, Type: m["type"]})
+				continue
+			}
+		}
+		tuple = append(tuple, field)
+	}
+	return named, tuple
+}
+
+// definedTypeName returns the name of a defined type, which is a string in the legacy format
+// and an object in Anchor 0.30
+func definedTypeName(anchorType map[string]interface{}) string {
+	switch t := anchorType["defined"].(type) {
+	case string:
+		return t
+	case map[string]interface{}:
+		name, _ := t["name"].(string)
+		return name
+	}
+	return ""
+}
@@ -0,0 +1,378 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+// A program in the legacy IDL format, where defined types are referred to by a string
+const counterIDL = `{
+	"version": "0.1.0",
+	"name": "counter",
+	"instructions": [
+		{
+			"name": "initialize",
+			"docs": ["Creates the counter,", "owned by the signer"],
+			"accounts": [
+				{"name": "counter", "isMut": true, "isSigner": false}
+			],
+			"args": [
+				{"name": "start", "type": "u64"},
+				{"name": "owner", "type": "publicKey"},
+				{"name": "config", "type": {"defined": "Config"}}
+			]
+		},
+		{
+			"name": "get",
+			"accounts": [],
+			"args": [],
+			"returns": {"option": "u64"}
+		}
+	],
+	"events": [
+		{
+			"name": "Incremented",
+			"fields": [
+				{"name": "value", "type": "u64", "index": false},
+				{"name": "by", "type": "publicKey", "index": false}
+			]
+		}
+	],
+	"errors": [
+		{"code": 6000, "name": "Overflow", "msg": "The counter overflowed"}
+	],
+	"types": [
+		{
+			"name": "Config",
+			"type": {
+				"kind": "struct",
+				"fields": [
+					{"name": "step", "type": "u8"},
+					{"name": "mode", "type": {"defined": "Mode"}},
+					{"name": "limits", "type": {"array": ["u32", 2]}},
+					{"name": "tags", "type": {"vec": "string"}}
+				]
+			}
+		},
+		{
+			"name": "Mode",
+			"type": {
+				"kind": "enum",
+				"variants": [{"name": "Up"}, {"name": "Down"}]
+			}
+		},
+		{
+			"name": "Unused",
+			"type": {"kind": "struct", "fields": []}
+		}
+	]
+}`
+
+// A program in the IDL format of Anchor 0.30, with discriminators, and the fields of events declared in the types
+const shapesIDL = `{
+	"address": "2HRbXDoT3fpNhiFo8VxM7yeay29jBuxmLbzuq47Xbo43",
+	"metadata": {"name": "shapes", "version": "0.1.0", "spec": "0.1.0"},
+	"instructions": [
+		{
+			"name": "set_shape",
+			"discriminator": [1, 2, 3, 4, 5, 6, 7, 8],
+			"accounts": [
+				{"name": "payer", "writable": true, "signer": true}
+			],
+			"args": [
+				{"name": "shape", "type": {"defined": {"name": "Shape"}}},
+				{"name": "origin", "type": {"defined": {"name": "Point"}}},
+				{"name": "amount", "type": {"defined": {"name": "Amount"}}},
+				{"name": "data", "type": "bytes"},
+				{"name": "delegate", "type": {"coption": "pubkey"}},
+				{"name": "enabled", "type": "bool"},
+				{"name": "small", "type": "i16"}
+			]
+		}
+	],
+	"events": [
+		{"name": "ShapeSet", "discriminator": [9, 9, 9, 9, 9, 9, 9, 9]}
+	],
+	"types": [
+		{
+			"name": "Shape",
+			"type": {
+				"kind": "enum",
+				"variants": [
+					{"name": "Circle", "fields": [{"name": "radius", "type": "f64"}]},
+					{"name": "Square", "fields": ["u16"]},
+					{"name": "Empty"}
+				]
+			}
+		},
+		{
+			"name": "Point",
+			"type": {"kind": "struct", "fields": ["i32", "i32"]}
+		},
+		{
+			"name": "Amount",
+			"type": {"kind": "type", "alias": "u128"}
+		},
+		{
+			"name": "ShapeSet",
+			"type": {
+				"kind": "struct",
+				"fields": [
+					{"name": "shape", "type": {"defined": {"name": "Shape"}}},
+					{"name": "by", "type": "pubkey"}
+				]
+			}
+		}
+	]
+}`
+
+func generateTestFFI(t *testing.T, idl string) *fftypes.FFI {
+	s, cancel := newTestSolana()
+	defer cancel()
+	ffi, err := s.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+		Namespace: "ns1",
+		Name:      "test",
+		Version:   "v1.0.0",
+		Input:     fftypes.JSONAnyPtr(`{"idl":` + idl + `}`),
+	})
+	assert.NoError(t, err)
+	return ffi
+}
+
+// assertParamsCompile checks the schema of every param is accepted by FireFly
+func assertParamsCompile(t *testing.T, params fftypes.FFIParams) {
+	for _, param := range params {
+		c := fftypes.NewFFISchemaCompiler()
+		err := c.AddResource(param.Name, strings.NewReader(param.Schema.String()))
+		assert.NoError(t, err)
+		_, err = c.Compile(param.Name)
+		assert.NoError(t, err, param.Name)
+	}
+}
+
+func TestConvertAnchorToFFILegacy(t *testing.T) {
+	ffi := generateTestFFI(t, counterIDL)
+
+	assert.Equal(t, "ns1", ffi.Namespace)
+	assert.Equal(t, "test", ffi.Name)
+	assert.Equal(t, "v1.0.0", ffi.Version)
+
+	assert.Len(t, ffi.Methods, 2)
+	initialize := ffi.Methods[0]
+	assert.Equal(t, "initialize", initialize.Name)
+	assert.Equal(t, "Creates the counter, owned by the signer", initialize.Description)
+	assert.Len(t, initialize.Params, 3)
+	assert.Equal(t, `{"description":"An integer. You are recommended to use a JSON string. A JSON number can be used for values up to the safe maximum.","details":{"type":"u64"},"oneOf":[{"type":"string"},{"type":"integer"}]}`, initialize.Params[0].Schema.String())
+	assert.Equal(t, `{"description":"A base58 encoded public key","details":{"type":"publicKey"},"type":"string"}`, initialize.Params[1].Schema.String())
+	assert.Equal(t, `{"details":{"type":{"defined":"Config"}},"properties":{"limits":{"items":{"type":"integer"},"maxItems":2,"minItems":2,"type":"array"},"mode":{"enum":["Up","Down"],"type":"string"},"step":{"type":"integer"},"tags":{"items":{"type":"string"},"type":"array"}},"required":["step","mode","limits","tags"],"type":"object"}`, initialize.Params[2].Schema.String())
+	assert.Empty(t, initialize.Returns)
+	assertParamsCompile(t, initialize.Params)
+
+	// Only the types used by the method are included in the details, in the order of the IDL
+	details, _ := json.Marshal(initialize.Details)
+	assert.Equal(t, `{"accounts":[{"isMut":true,"isSigner":false,"name":"counter"}],"types":[{"name":"Config","type":{"fields":[{"name":"step","type":"u8"},{"name":"mode","type":{"defined":"Mode"}},{"name":"limits","type":{"array":["u32",2]}},{"name":"tags","type":{"vec":"string"}}],"kind":"struct"}},{"name":"Mode","type":{"kind":"enum","variants":[{"name":"Up"},{"name":"Down"}]}}]}`, string(details))
+
+	get := ffi.Methods[1]
+	assert.Equal(t, "get", get.Name)
+	assert.Empty(t, get.Params)
+	assert.Len(t, get.Returns, 1)
+	assert.Equal(t, "output", get.Returns[0].Name)
+	assert.Equal(t, `{"description":"An integer. You are recommended to use a JSON string. A JSON number can be used for values up to the safe maximum.","details":{"type":{"option":"u64"}},"oneOf":[{"type":"string"},{"type":"integer"}]}`, get.Returns[0].Schema.String())
+	assert.Empty(t, get.Details)
+
+	assert.Len(t, ffi.Events, 1)
+	assert.Equal(t, "Incremented", ffi.Events[0].Name)
+	assert.Len(t, ffi.Events[0].Params, 2)
+	assert.Equal(t, "value", ffi.Events[0].Params[0].Name)
+	assert.Equal(t, "by", ffi.Events[0].Params[1].Name)
+
+	assert.Len(t, ffi.Errors, 1)
+	assert.Equal(t, "Overflow", ffi.Errors[0].Name)
+	assert.Equal(t, "The counter overflowed", ffi.Errors[0].Description)
+	assert.Empty(t, ffi.Errors[0].Params)
+}
+
+func TestConvertAnchorToFFI030(t *testing.T) {
+	ffi := generateTestFFI(t, shapesIDL)
+
+	assert.Len(t, ffi.Methods, 1)
+	setShape := ffi.Methods[0]
+	assert.Equal(t, "set_shape", setShape.Name)
+	assert.Len(t, setShape.Params, 7)
+	assert.Equal(t, `{"details":{"type":{"defined":{"name":"Shape"}}},"maxProperties":1,"minProperties":1,"properties":{"Circle":{"properties":{"radius":{"type":"number"}},"required":["radius"],"type":"object"},"Empty":{"properties":{},"required":[],"type":"object"},"Square":{"maxItems":1,"minItems":1,"prefixItems":[{"type":"integer"}],"type":"array"}},"type":"object"}`, setShape.Params[0].Schema.String())
+	assert.Equal(t, `{"details":{"type":{"defined":{"name":"Point"}}},"maxItems":2,"minItems":2,"prefixItems":[{"type":"integer"},{"type":"integer"}],"type":"array"}`, setShape.Params[1].Schema.String())
+	assert.Equal(t, `{"description":"An integer. You are recommended to use a JSON string. A JSON number can be used for values up to the safe maximum.","details":{"type":{"defined":{"name":"Amount"}}},"oneOf":[{"type":"string"},{"type":"integer"}]}`, setShape.Params[2].Schema.String())
+	assert.Equal(t, `{"description":"A hex encoded set of bytes, with an optional '0x' prefix","details":{"type":"bytes"},"type":"string"}`, setShape.Params[3].Schema.String())
+	assert.Equal(t, `{"description":"A base58 encoded public key","details":{"type":{"coption":"pubkey"}},"type":"string"}`, setShape.Params[4].Schema.String())
+	assert.Equal(t, `{"details":{"type":"bool"},"type":"boolean"}`, setShape.Params[5].Schema.String())
+	assert.Equal(t, `{"details":{"type":"i16"},"type":"integer"}`, setShape.Params[6].Schema.String())
+	assertParamsCompile(t, setShape.Params)
+
+	details, _ := json.Marshal(setShape.Details)
+	assert.Equal(t, `{"accounts":[{"name":"payer","signer":true,"writable":true}],"discriminator":[1,2,3,4,5,6,7,8],"types":[{"name":"Shape","type":{"kind":"enum","variants":[{"fields":[{"name":"radius","type":"f64"}],"name":"Circle"},{"fields":["u16"],"name":"Square"},{"name":"Empty"}]}},{"name":"Point","type":{"fields":["i32","i32"],"kind":"struct"}},{"name":"Amount","type":{"alias":"u128","kind":"type"}}]}`, string(details))
+
+	// The fields of the event come from the struct of the same name
+	assert.Len(t, ffi.Events, 1)
+	shapeSet := ffi.Events[0]
+	assert.Equal(t, "ShapeSet", shapeSet.Name)
+	assert.Len(t, shapeSet.Params, 2)
+	assert.Equal(t, "shape", shapeSet.Params[0].Name)
+	assert.Equal(t, "by", shapeSet.Params[1].Name)
+	details, _ = json.Marshal(shapeSet.Details)
+	assert.Equal(t, `{"discriminator":[9,9,9,9,9,9,9,9],"types":[{"name":"Shape","type":{"kind":"enum","variants":[{"fields":[{"name":"radius","type":"f64"}],"name":"Circle"},{"fields":["u16"],"name":"Square"},{"name":"Empty"}]}}]}`, string(details))
+	assert.Empty(t, ffi.Errors)
+}
+
+func TestConvertAnchorToFFIRoundTrip(t *testing.T) {
+	ffi := generateTestFFI(t, shapesIDL)
+
+	var idl anchorIDL
+	err := json.Unmarshal([]byte(shapesIDL), &idl)
+	assert.NoError(t, err)
+
+	// The instruction rebuilt from the method is the one in the IDL, with the types it uses
+	ix, err := ffiMethodToInstruction(context.Background(), ffi.Methods[0])
+	assert.NoError(t, err)
+	expected := idl.Instructions[0]
+	expected.Types = idl.Types[0:3]
+	assert.Equal(t, toJSON(expected), toJSON(ix))
+
+	event, err := ffiEventToAnchor(context.Background(), &ffi.Events[0].FFIEventDefinition)
+	assert.NoError(t, err)
+	assert.Equal(t, "ShapeSet(Shape,pubkey)", anchorEventSignature(event))
+	assert.Len(t, event.Types, 1)
+}
+
+func toJSON(v interface{}) string {
+	b, _ := json.Marshal(v)
+	var generic interface{}
+	_ = json.Unmarshal(b, &generic)
+	b, _ = json.Marshal(generic)
+	return string(b)
+}
+
+func TestConvertAnchorToFFIErrors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		idl   string
+		error string
+	}{
+		{
+			name:  "unknown primitive",
+			idl:   `{"instructions":[{"name":"a","args":[{"name":"x","type":"u512"}]}]}`,
+			error: `FF10346.*unsupported type "u512" of 'x'`,
+		},
+		{
+			name:  "unknown type",
+			idl:   `{"instructions":[{"name":"a","args":[{"name":"x","type":{"defined":"Missing"}}]}]}`,
+			error: `FF10346.*unsupported type {"defined":"Missing"} of 'x'`,
+		},
+		{
+			name:  "missing type",
+			idl:   `{"instructions":[{"name":"a","args":[{"name":"x"}]}]}`,
+			error: `FF10346.*unsupported type null of 'x'`,
+		},
+		{
+			name:  "unknown composite",
+			idl:   `{"instructions":[{"name":"a","args":[{"name":"x","type":{"hashMap":["u8","u8"]}}]}]}`,
+			error: `FF10346.*unsupported type {"hashMap":\["u8","u8"\]} of 'x'`,
+		},
+		{
+			name:  "too many keys",
+			idl:   `{"instructions":[{"name":"a","args":[{"name":"x","type":{"vec":"u8","option":"u8"}}]}]}`,
+			error: `FF10346.*unsupported type`,
+		},
+		{
+			name:  "array without a length",
+			idl:   `{"instructions":[{"name":"a","args":[{"name":"x","type":{"array":["u8",{"generic":"N"}]}}]}]}`,
+			error: `FF10346.*unsupported type`,
+		},
+		{
+			name:  "bad vec item",
+			idl:   `{"instructions":[{"name":"a","args":[{"name":"x","type":{"vec":"nope"}}]}]}`,
+			error: `FF10346.*unsupported type "nope" of 'x'`,
+		},
+		{
+			name:  "bad return",
+			idl:   `{"instructions":[{"name":"a","args":[],"returns":"nope"}]}`,
+			error: `FF10346.*unsupported type "nope" of 'output'`,
+		},
+		{
+			name:  "recursive type",
+			idl:   `{"instructions":[{"name":"a","args":[{"name":"x","type":{"defined":"Node"}}]}],"types":[{"name":"Node","type":{"kind":"struct","fields":[{"name":"next","type":{"option":{"defined":"Node"}}}]}}]}`,
+			error: `FF10346.*unsupported type {"defined":"Node"} of 'x'`,
+		},
+		{
+			name:  "unknown kind",
+			idl:   `{"instructions":[{"name":"a","args":[{"name":"x","type":{"defined":"T"}}]}],"types":[{"name":"T","type":{"kind":"union"}}]}`,
+			error: `FF10346.*unsupported type {"kind":"union"} of 'x'`,
+		},
+		{
+			name:  "bad tuple field",
+			idl:   `{"instructions":[{"name":"a","args":[{"name":"x","type":{"defined":"T"}}]}],"types":[{"name":"T","type":{"kind":"struct","fields":["nope"]}}]}`,
+			error: `FF10346.*unsupported type "nope" of 'x'`,
+		},
+		{
+			name:  "bad enum variant",
+			idl:   `{"instructions":[{"name":"a","args":[{"name":"x","type":{"defined":"E"}}]}],"types":[{"name":"E","type":{"kind":"enum","variants":[{"name":"V","fields":[{"name":"f","type":"nope"}]}]}}]}`,
+			error: `FF10346.*unsupported type "nope" of 'x'`,
+		},
+		{
+			name:  "bad alias",
+			idl:   `{"instructions":[{"name":"a","args":[{"name":"x","type":{"defined":{"name":"A"}}}]}],"types":[{"name":"A","type":{"kind":"type","alias":"nope"}}]}`,
+			error: `FF10346.*unsupported type "nope" of 'x'`,
+		},
+		{
+			name:  "bad defined name",
+			idl:   `{"instructions":[{"name":"a","args":[{"name":"x","type":{"defined":{"generics":[]}}}]}]}`,
+			error: `FF10346.*unsupported type`,
+		},
+		{
+			name:  "bad event field",
+			idl:   `{"instructions":[{"name":"a","args":[]}],"events":[{"name":"E","fields":[{"name":"f","type":"nope"}]}]}`,
+			error: `FF10346.*unsupported type "nope" of 'f'`,
+		},
+		{
+			name:  "tuple event",
+			idl:   `{"instructions":[{"name":"a","args":[]}],"events":[{"name":"E"}],"types":[{"name":"E","type":{"kind":"struct","fields":["u8"]}}]}`,
+			error: `FF10346.*unsupported type {"kind":"struct","fields":\["u8"\]} of 'E'`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, cancel := newTestSolana()
+			defer cancel()
+			_, err := s.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+				Input: fftypes.JSONAnyPtr(`{"idl":` + tc.idl + `}`),
+			})
+			assert.Regexp(t, tc.error, err)
+		})
+	}
+}
+
+func TestConvertAnchorEventWithoutFields(t *testing.T) {
+	ffi := generateTestFFI(t, `{"instructions":[{"name":"a","args":[]}],"events":[{"name":"Ping"}]}`)
+	assert.Len(t, ffi.Events, 1)
+	assert.Empty(t, ffi.Events[0].Params)
+}
@@ -0,0 +1,55 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/wsclient"
+)
+
+const (
+	defaultBatchSize    = 50
+	defaultBatchTimeout = 500
+	defaultPrefixShort  = "fly"
+	defaultPrefixLong   = "firefly"
+)
+
+const (
+	// SolanaconnectConfigKey is a sub-key in the config to contain all the Solana connector specific config
+	SolanaconnectConfigKey = "solanaconnect"
+	// SolanaconnectConfigTopic is the websocket listen topic that the node should register on, which is important if there are multiple
+	// nodes using a single Solana connector
+	SolanaconnectConfigTopic = "topic"
+	// SolanaconnectConfigBatchSize is the batch size to configure on event streams, when auto-defining them
+	SolanaconnectConfigBatchSize = "batchSize"
+	// SolanaconnectConfigBatchTimeout is the batch timeout to configure on event streams, when auto-defining them
+	SolanaconnectConfigBatchTimeout = "batchTimeout"
+	// SolanaconnectPrefixShort is used in the query string in requests to the Solana connector
+	SolanaconnectPrefixShort = "prefixShort"
+	// SolanaconnectPrefixLong is used in HTTP headers in requests to the Solana connector
+	SolanaconnectPrefixLong = "prefixLong"
+)
+
+func (s *Solana) InitConfig(config config.Section) {
+	s.solanaconnectConf = config.SubSection(SolanaconnectConfigKey)
+	wsclient.InitConfig(s.solanaconnectConf)
+	s.solanaconnectConf.AddKnownKey(SolanaconnectConfigTopic)
+	s.solanaconnectConf.AddKnownKey(SolanaconnectConfigBatchSize, defaultBatchSize)
+	s.solanaconnectConf.AddKnownKey(SolanaconnectConfigBatchTimeout, defaultBatchTimeout)
+	s.solanaconnectConf.AddKnownKey(SolanaconnectPrefixShort, defaultPrefixShort)
+	s.solanaconnectConf.AddKnownKey(SolanaconnectPrefixLong, defaultPrefixLong)
+}
@@ -0,0 +1,291 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/cache"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+type streamManager struct {
+	client       *resty.Client
+	cache        cache.CInterface
+	batchSize    uint
+	batchTimeout int64
+}
+
+type eventStream struct {
+	ID             string               `json:"id"`
+	Name           string               `json:"name"`
+	ErrorHandling  string               `json:"errorHandling"`
+	BatchSize      uint                 `json:"batchSize"`
+	BatchTimeoutMS int64                `json:"batchTimeoutMS"`
+	Type           string               `json:"type"`
+	WebSocket      eventStreamWebsocket `json:"websocket"`
+	Timestamps     bool                 `json:"timestamps"`
+}
+
+type subscription struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name,omitempty"`
+	Stream    string    `json:"stream"`
+	FromBlock string    `json:"fromBlock"`
+	Filters   []*filter `json:"filters"`
+	subscriptionCheckpoint
+}
+
+type filter struct {
+	Event   *anchorEvent `json:"event"`
+	Address string       `json:"address,omitempty"`
+}
+
+type subscriptionCheckpoint struct {
+	Checkpoint ListenerCheckpoint `json:"checkpoint,omitempty"`
+	Catchup    bool               `json:"catchup,omitempty"`
+}
+
+func newStreamManager(client *resty.Client, cache cache.CInterface, batchSize uint, batchTimeout int64) *streamManager {
+	return &streamManager{
+		client:       client,
+		cache:        cache,
+		batchSize:    batchSize,
+		batchTimeout: batchTimeout,
+	}
+}
+
+func (s *streamManager) getEventStreams(ctx context.Context) (streams []*eventStream, err error) {
+	res, err := s.client.R().
+		SetContext(ctx).
+		SetResult(&streams).
+		Get("/eventstreams")
+	if err != nil || !res.IsSuccess() {
+		return nil, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgSolanaconnectRESTErr)
+	}
+	return streams, nil
+}
+
+func buildEventStream(topic string, batchSize uint, batchTimeout int64) *eventStream {
+	return &eventStream{
+		Name:           topic,
+		ErrorHandling:  "block",
+		BatchSize:      batchSize,
+		BatchTimeoutMS: batchTimeout,
+		Type:           "websocket",
+		WebSocket:      eventStreamWebsocket{Topic: topic},
+		Timestamps:     true,
+	}
+}
+
+func (s *streamManager) createEventStream(ctx context.Context, topic string) (*eventStream, error) {
+	stream := buildEventStream(topic, s.batchSize, s.batchTimeout)
+	res, err := s.client.R().
+		SetContext(ctx).
+		SetBody(stream).
+		SetResult(stream).
+		Post("/eventstreams")
+	if err != nil || !res.IsSuccess() {
+		return nil, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgSolanaconnectRESTErr)
+	}
+	return stream, nil
+}
+
+func (s *streamManager) updateEventStream(ctx context.Context, topic string, batchSize uint, batchTimeout int64, eventStreamID string) (*eventStream, error) {
+	stream := buildEventStream(topic, batchSize, batchTimeout)
+	res, err := s.client.R().
+		SetContext(ctx).
+		SetBody(stream).
+		SetResult(stream).
+		Patch("/eventstreams/" + eventStreamID)
+	if err != nil || !res.IsSuccess() {
+		return nil, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgSolanaconnectRESTErr)
+	}
+	return stream, nil
+}
+
+func (s *streamManager) ensureEventStream(ctx context.Context, topic string) (*eventStream, error) {
+	existingStreams, err := s.getEventStreams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, stream := range existingStreams {
+		if stream.Name == topic {
+			return s.updateEventStream(ctx, topic, s.batchSize, s.batchTimeout, stream.ID)
+		}
+	}
+	return s.createEventStream(ctx, topic)
+}
+
+func (s *streamManager) getSubscriptions(ctx context.Context) (subs []*subscription, err error) {
+	res, err := s.client.R().
+		SetContext(ctx).
+		SetResult(&subs).
+		Get("/subscriptions")
+	if err != nil || !res.IsSuccess() {
+		return nil, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgSolanaconnectRESTErr)
+	}
+	return subs, nil
+}
+
+func (s *streamManager) getSubscription(ctx context.Context, subID string, okNotFound bool) (sub *subscription, err error) {
+	res, err := s.client.R().
+		SetContext(ctx).
+		SetResult(&sub).
+		Get(fmt.Sprintf("/subscriptions/%s", subID))
+	if err != nil || !res.IsSuccess() {
+		if okNotFound && res.StatusCode() == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgSolanaconnectRESTErr)
+	}
+	return sub, nil
+}
+
+func (s *streamManager) getCachedSubscription(ctx context.Context, subID string) (*subscription, error) {
+	if cachedValue, ok := s.cache.Get("sub:" + subID).(*subscription); ok {
+		return cachedValue, nil
+	}
+
+	sub, err := s.getSubscription(ctx, subID, false)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Set("sub:"+subID, sub)
+	return sub, nil
+}
+
+// resolveFromBlock works out the slot a subscription starts from, as the connector calls the slots of Solana blocks
+func resolveFromBlock(ctx context.Context, firstEvent, lastProtocolID string) (string, error) {
+	// Parse the lastProtocolID if supplied
+	var slotBeforeNewestEvent *uint64
+	if len(lastProtocolID) > 0 {
+		slotStr := strings.Split(lastProtocolID, "/")[0]
+		parsedUint, err := strconv.ParseUint(slotStr, 10, 64)
+		if err != nil {
+			return "", i18n.NewError(ctx, coremsgs.MsgInvalidLastEventProtocolID, lastProtocolID)
+		}
+		if parsedUint > 0 {
+			// We jump back one slot from the last event, to minimize re-delivery while ensuring
+			// we get all events since the last delivered (including subsequent events in the same slot)
+			parsedUint--
+			slotBeforeNewestEvent = &parsedUint
+		}
+	}
+
+	// If the user requested newest, then we use the last slot if we have one,
+	// or we pass the request for newest down to the connector
+	if firstEvent == "" || firstEvent == string(core.SubOptsFirstEventNewest) || firstEvent == "latest" {
+		if slotBeforeNewestEvent != nil {
+			return strconv.FormatUint(*slotBeforeNewestEvent, 10), nil
+		}
+		return "latest", nil
+	}
+
+	// Otherwise we expect to be able to parse the slot, with "oldest" being the same as "0"
+	if firstEvent == string(core.SubOptsFirstEventOldest) {
+		firstEvent = "0"
+	}
+	slot, err := strconv.ParseUint(firstEvent, 10, 64)
+	if err != nil {
+		return "", i18n.NewError(ctx, coremsgs.MsgInvalidFromBlockNumber, firstEvent)
+	}
+	// If the last event is already dispatched after this slot, recreate the listener from that slot
+	if slotBeforeNewestEvent != nil && *slotBeforeNewestEvent > slot {
+		slot = *slotBeforeNewestEvent
+	}
+	return strconv.FormatUint(slot, 10), nil
+}
+
+func (s *streamManager) createSubscription(ctx context.Context, stream, subName, firstEvent string, filters []*filter, lastProtocolID string) (*subscription, error) {
+	fromBlock, err := resolveFromBlock(ctx, firstEvent, lastProtocolID)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := subscription{
+		Name:      subName,
+		Stream:    stream,
+		FromBlock: fromBlock,
+		Filters:   filters,
+	}
+
+	res, err := s.client.R().
+		SetContext(ctx).
+		SetBody(&sub).
+		SetResult(&sub).
+		Post("/subscriptions")
+	if err != nil || !res.IsSuccess() {
+		return nil, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgSolanaconnectRESTErr)
+	}
+	return &sub, nil
+}
+
+func (s *streamManager) deleteSubscription(ctx context.Context, subID string, okNotFound bool) error {
+	res, err := s.client.R().
+		SetContext(ctx).
+		Delete("/subscriptions/" + subID)
+	if err != nil || !res.IsSuccess() {
+		if okNotFound && res.StatusCode() == http.StatusNotFound {
+			return nil
+		}
+		return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgSolanaconnectRESTErr)
+	}
+	return nil
+}
+
+func (s *streamManager) ensureFireFlySubscription(ctx context.Context, namespace, instancePath, firstEvent, stream, lastProtocolID string) (sub *subscription, err error) {
+	// Include a hash of the instance path in the subscription, so if we ever point at a different
+	// program, we re-subscribe from slot 0.
+	// We don't need full strength hashing, so just use the first 16 chars for readability.
+	instanceUniqueHash := hex.EncodeToString(sha256.New().Sum([]byte(instancePath)))[0:16]
+
+	existingSubs, err := s.getSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("%s_%s_%s", namespace, batchPinEvent.Name, instanceUniqueHash)
+	for _, s := range existingSubs {
+		if s.Stream == stream && s.Name == name {
+			return s, nil
+		}
+	}
+
+	filters := []*filter{
+		{
+			Event:   batchPinEvent,
+			Address: instancePath,
+		},
+	}
+	if sub, err = s.createSubscription(ctx, stream, name, firstEvent, filters, lastProtocolID); err != nil {
+		return nil, err
+	}
+	log.L(ctx).Infof("%s subscription: %s", batchPinEvent.Name, sub.ID)
+	return sub, nil
+}
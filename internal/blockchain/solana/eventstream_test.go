@@ -0,0 +1,104 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnsureEventStreamCreateError(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/eventstreams",
+		httpmock.NewJsonResponderOrPanic(200, []eventStream{}))
+	httpmock.RegisterResponder("POST", "http://localhost:12345/eventstreams",
+		httpmock.NewStringResponder(500, `pop`))
+
+	_, err := s.streams.ensureEventStream(context.Background(), "topic1/ns1")
+	assert.Regexp(t, "FF10554.*pop", err)
+}
+
+func TestEnsureEventStreamUpdateError(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/eventstreams",
+		httpmock.NewJsonResponderOrPanic(200, []eventStream{{ID: "es12345", Name: "topic1/ns1"}}))
+	httpmock.RegisterResponder("PATCH", "http://localhost:12345/eventstreams/es12345",
+		httpmock.NewStringResponder(500, `pop`))
+
+	_, err := s.streams.ensureEventStream(context.Background(), "topic1/ns1")
+	assert.Regexp(t, "FF10554.*pop", err)
+}
+
+func TestCreateSubscriptionBadBlock(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	_, err := s.streams.createSubscription(context.Background(), "", "", "wrongness", nil, "")
+	assert.Regexp(t, "FF10473", err)
+}
+
+func TestResolveFromBlockCombinations(t *testing.T) {
+
+	ctx := context.Background()
+
+	fromBlock, err := resolveFromBlock(ctx, "", "")
+	assert.Equal(t, "latest", fromBlock)
+	assert.NoError(t, err)
+
+	fromBlock, err = resolveFromBlock(ctx, "latest", "")
+	assert.Equal(t, "latest", fromBlock)
+	assert.NoError(t, err)
+
+	fromBlock, err = resolveFromBlock(ctx, "newest", "")
+	assert.Equal(t, "latest", fromBlock)
+	assert.NoError(t, err)
+
+	fromBlock, err = resolveFromBlock(ctx, "oldest", "")
+	assert.Equal(t, "0", fromBlock)
+	assert.NoError(t, err)
+
+	fromBlock, err = resolveFromBlock(ctx, "0", "000000000010/000000/000050")
+	assert.Equal(t, "9", fromBlock)
+	assert.NoError(t, err)
+
+	fromBlock, err = resolveFromBlock(ctx, "20", "000000000010/000000/000050")
+	assert.Equal(t, "20", fromBlock)
+	assert.NoError(t, err)
+
+	fromBlock, err = resolveFromBlock(ctx, "", "000000000010/000000/000050")
+	assert.Equal(t, "9", fromBlock)
+	assert.NoError(t, err)
+
+	fromBlock, err = resolveFromBlock(ctx, "", "000000000000/000000/000050")
+	assert.Equal(t, "latest", fromBlock)
+	assert.NoError(t, err)
+
+	_, err = resolveFromBlock(ctx, "", "wrong")
+	assert.Regexp(t, "FF10472", err)
+
+}
@@ -0,0 +1,161 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+// ffiMethodToInstruction rebuilds the Anchor instruction of a method, from the Anchor types in the
+// schemas of its params and the accounts, discriminator and types in its details
+func ffiMethodToInstruction(ctx context.Context, method *fftypes.FFIMethod) (*anchorInstruction, error) {
+	details, err := parseAnchorDetails(ctx, method.Details)
+	if err != nil {
+		return nil, err
+	}
+	args, err := anchorFields(ctx, method.Params)
+	if err != nil {
+		return nil, err
+	}
+	ix := &anchorInstruction{
+		Name:          method.Name,
+		Discriminator: details.Discriminator,
+		Accounts:      details.Accounts,
+		Args:          args,
+		Types:         details.Types,
+	}
+	if len(method.Returns) > 0 {
+		if ix.Returns, err = anchorParamType(ctx, method.Returns[0]); err != nil {
+			return nil, err
+		}
+	}
+	return ix, nil
+}
+
+// ffiEventToAnchor rebuilds the Anchor event of an event definition, in the same way as for a method
+func ffiEventToAnchor(ctx context.Context, event *fftypes.FFIEventDefinition) (*anchorEvent, error) {
+	details, err := parseAnchorDetails(ctx, event.Details)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := anchorFields(ctx, event.Params)
+	if err != nil {
+		return nil, err
+	}
+	return &anchorEvent{
+		Name:          event.Name,
+		Discriminator: details.Discriminator,
+		Fields:        fields,
+		Types:         details.Types,
+	}, nil
+}
+
+// anchorEventSignature is the name of an event, followed by the Anchor types of its fields
+func anchorEventSignature(event *anchorEvent) string {
+	types := make([]string, len(event.Fields))
+	for i, field := range event.Fields {
+		types[i], _ = anchorTypeName(field.Type)
+	}
+	return fmt.Sprintf("%s(%s)", event.Name, strings.Join(types, ","))
+}
+
+func parseAnchorDetails(ctx context.Context, details fftypes.JSONObject) (*anchorDetails, error) {
+	var result anchorDetails
+	b, _ := json.Marshal(details)
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, i18n.WrapError(ctx, err, i18n.MsgJSONObjectParseFailed, "details")
+	}
+	return &result, nil
+}
+
+func anchorFields(ctx context.Context, params fftypes.FFIParams) ([]*anchorField, error) {
+	fields := make([]*anchorField, len(params))
+	for i, param := range params {
+		anchorType, err := anchorParamType(ctx, param)
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = &anchorField{
+			Name: param.Name,
+			Type: anchorType,
+		}
+	}
+	return fields, nil
+}
+
+// anchorParamType returns the Anchor type of a param, from the details of its schema
+func anchorParamType(ctx context.Context, param *fftypes.FFIParam) (interface{}, error) {
+	var schema struct {
+		Details struct {
+			Type interface{} `json:"type"`
+		} `json:"details"`
+	}
+	if param.Schema != nil {
+		_ = json.Unmarshal(param.Schema.Bytes(), &schema)
+	}
+	anchorType := schema.Details.Type
+	if anchorType == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInvalidAnchorType, param.Name, "'details.type' not set")
+	}
+	if _, ok := anchorTypeName(anchorType); !ok {
+		b, _ := json.Marshal(anchorType)
+		return nil, i18n.NewError(ctx, coremsgs.MsgInvalidAnchorType, param.Name, string(b))
+	}
+	return anchorType, nil
+}
+
+// anchorTypeName returns the name of an Anchor type as it is written in Rust, such as "vec<[u8;32]>"
+func anchorTypeName(anchorType interface{}) (string, bool) {
+	switch t := anchorType.(type) {
+	case string:
+		return t, primitiveSchema(t) != nil
+	case map[string]interface{}:
+		if len(t) != 1 {
+			break
+		}
+		if v, ok := t["vec"]; ok {
+			name, ok := anchorTypeName(v)
+			return fmt.Sprintf("vec<%s>", name), ok
+		}
+		if v, ok := t["array"].([]interface{}); ok && len(v) == 2 {
+			switch v[1].(type) {
+			case float64, int:
+				name, ok := anchorTypeName(v[0])
+				return fmt.Sprintf("[%s;%v]", name, v[1]), ok
+			}
+		}
+		if v, ok := t["option"]; ok {
+			name, ok := anchorTypeName(v)
+			return fmt.Sprintf("option<%s>", name), ok
+		}
+		if v, ok := t["coption"]; ok {
+			name, ok := anchorTypeName(v)
+			return fmt.Sprintf("coption<%s>", name), ok
+		}
+		if name := definedTypeName(t); name != "" {
+			return name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,94 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFFIMethodToInstruction(t *testing.T) {
+	ix, err := ffiMethodToInstruction(context.Background(), testFFIMethod())
+	assert.NoError(t, err)
+	assert.Equal(t, "transfer", ix.Name)
+	assert.Equal(t, []int{163, 52, 200, 231, 140, 3, 69, 186}, ix.Discriminator)
+	assert.Len(t, ix.Accounts, 1)
+	assert.Equal(t, []*anchorField{
+		{Name: "to", Type: "publicKey"},
+		{Name: "amount", Type: "u64"},
+	}, ix.Args)
+	assert.Equal(t, "bool", ix.Returns)
+}
+
+func TestFFIMethodToInstructionBadDetails(t *testing.T) {
+	method := testFFIMethod()
+	method.Details = fftypes.JSONObject{"discriminator": "not an array"}
+	_, err := ffiMethodToInstruction(context.Background(), method)
+	assert.Regexp(t, "FF00127.*details", err)
+}
+
+func TestFFIMethodToInstructionBadReturn(t *testing.T) {
+	method := testFFIMethod()
+	method.Returns[0].Schema = fftypes.JSONAnyPtr(`{"type":"boolean","details":{"type":"boolean"}}`)
+	_, err := ffiMethodToInstruction(context.Background(), method)
+	assert.Regexp(t, `FF10555.*output.*"boolean"`, err)
+}
+
+func TestFFIEventToAnchorBadDetails(t *testing.T) {
+	event := testFFIEvent()
+	event.Details = fftypes.JSONObject{"types": "not an array"}
+	_, err := ffiEventToAnchor(context.Background(), event)
+	assert.Regexp(t, "FF00127.*details", err)
+}
+
+func TestAnchorTypeName(t *testing.T) {
+	testCases := []struct {
+		anchorType interface{}
+		name       string
+		valid      bool
+	}{
+		{"u64", "u64", true},
+		{"pubkey", "pubkey", true},
+		{"u512", "u512", false},
+		{map[string]interface{}{"vec": "u8"}, "vec<u8>", true},
+		{map[string]interface{}{"vec": "u512"}, "vec<u512>", false},
+		{map[string]interface{}{"array": []interface{}{"u8", float64(32)}}, "[u8;32]", true},
+		{map[string]interface{}{"array": []interface{}{"u8", 32}}, "[u8;32]", true},
+		{map[string]interface{}{"array": []interface{}{"u8", "N"}}, "", false},
+		{map[string]interface{}{"option": "string"}, "option<string>", true},
+		{map[string]interface{}{"coption": "pubkey"}, "coption<pubkey>", true},
+		{map[string]interface{}{"defined": "Config"}, "Config", true},
+		{map[string]interface{}{"defined": map[string]interface{}{"name": "Config"}}, "Config", true},
+		{map[string]interface{}{"defined": 42}, "", false},
+		{map[string]interface{}{"vec": "u8", "option": "u8"}, "", false},
+		{42, "", false},
+	}
+	for _, tc := range testCases {
+		name, valid := anchorTypeName(tc.anchorType)
+		assert.Equal(t, tc.valid, valid, tc.anchorType)
+		if tc.name != "" {
+			assert.Equal(t, tc.name, name, tc.anchorType)
+		}
+	}
+}
+
+func TestPinProgramEventSignature(t *testing.T) {
+	assert.Equal(t, "BatchPin(publicKey,i64,string,[u8;32],[u8;32],string,vec<[u8;32]>)", anchorEventSignature(batchPinEvent))
+}
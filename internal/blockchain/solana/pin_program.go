@@ -0,0 +1,66 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+// The instructions and events of the FireFly pin program, in the format of an Anchor IDL
+
+var bytes32Type = map[string]interface{}{"array": []interface{}{"u8", 32}}
+
+var authorAccount = map[string]interface{}{
+	"name":     "author",
+	"isMut":    false,
+	"isSigner": true,
+}
+
+var batchPinInstruction = &anchorInstruction{
+	Name:     "pinBatch",
+	Accounts: []interface{}{authorAccount},
+	Args: []*anchorField{
+		{Name: "uuids", Type: bytes32Type},
+		{Name: "batchHash", Type: bytes32Type},
+		{Name: "payloadRef", Type: "string"},
+		{Name: "contexts", Type: map[string]interface{}{"vec": bytes32Type}},
+	},
+}
+
+var networkActionInstruction = &anchorInstruction{
+	Name:     "networkAction",
+	Accounts: []interface{}{authorAccount},
+	Args: []*anchorField{
+		{Name: "action", Type: "string"},
+		{Name: "payload", Type: "string"},
+	},
+}
+
+var networkVersionInstruction = &anchorInstruction{
+	Name:    "networkVersion",
+	Args:    []*anchorField{},
+	Returns: "u8",
+}
+
+var batchPinEvent = &anchorEvent{
+	Name: "BatchPin",
+	Fields: []*anchorField{
+		{Name: "author", Type: "publicKey"},
+		{Name: "timestamp", Type: "i64"},
+		{Name: "action", Type: "string"},
+		{Name: "uuids", Type: bytes32Type},
+		{Name: "batchHash", Type: bytes32Type},
+		{Name: "payloadRef", Type: "string"},
+		{Name: "contexts", Type: map[string]interface{}{"vec": bytes32Type}},
+	},
+}
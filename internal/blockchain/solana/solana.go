@@ -0,0 +1,1052 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"blockwatch.cc/tzgo/base58"
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-common/pkg/wsclient"
+	"github.com/hyperledger/firefly/internal/blockchain/common"
+	"github.com/hyperledger/firefly/internal/cache"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/metrics"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	solanaTxStatusPending string = "Pending"
+)
+
+const (
+	ReceiptTransactionSuccess string = "TransactionSuccess"
+	ReceiptTransactionFailed  string = "TransactionFailed"
+)
+
+type Solana struct {
+	ctx               context.Context
+	cancelCtx         context.CancelFunc
+	pluginTopic       string
+	prefixShort       string
+	prefixLong        string
+	capabilities      *blockchain.Capabilities
+	callbacks         common.BlockchainCallbacks
+	client            *resty.Client
+	streams           *streamManager
+	streamID          map[string]string
+	wsconn            map[string]wsclient.WSClient
+	wsConfig          *wsclient.WSConfig
+	closed            map[string]chan struct{}
+	metrics           metrics.Manager
+	solanaconnectConf config.Section
+	subs              common.FireflySubscriptions
+	cache             cache.CInterface
+}
+
+type eventStreamWebsocket struct {
+	Topic string `json:"topic"`
+}
+
+type queryOutput struct {
+	Output interface{} `json:"output"`
+}
+
+type solanaWSCommandPayload struct {
+	Type        string `json:"type"`
+	Topic       string `json:"topic,omitempty"`
+	BatchNumber int64  `json:"batchNumber,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+// Location is the address of a program
+type Location struct {
+	Address string `json:"address"`
+}
+
+// ListenerCheckpoint is the position of a listener, where the block is the slot on Solana
+type ListenerCheckpoint struct {
+	Block            int64 `json:"block"`
+	TransactionIndex int64 `json:"transactionIndex"`
+	LogIndex         int64 `json:"logIndex"`
+}
+
+type ListenerStatus struct {
+	Checkpoint ListenerCheckpoint `json:"checkpoint"`
+	Catchup    bool               `json:"catchup"`
+}
+
+type SolanaconnectMessageHeaders struct {
+	Type string `json:"type,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+type parsedFFIMethod struct {
+	instruction *anchorInstruction
+}
+
+func (s *Solana) Name() string {
+	return "solana"
+}
+
+func (s *Solana) VerifierType() core.VerifierType {
+	return core.VerifierTypeSolanaAddress
+}
+
+func (s *Solana) Init(ctx context.Context, cancelCtx context.CancelFunc, conf config.Section, metrics metrics.Manager, cacheManager cache.Manager) (err error) {
+	s.InitConfig(conf)
+	solanaconnectConf := s.solanaconnectConf
+
+	s.ctx = log.WithLogField(ctx, "proto", "solana")
+	s.cancelCtx = cancelCtx
+	s.metrics = metrics
+	s.capabilities = &blockchain.Capabilities{}
+	s.callbacks = common.NewBlockchainCallbacks()
+	s.subs = common.NewFireflySubscriptions()
+
+	if solanaconnectConf.GetString(ffresty.HTTPConfigURL) == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, "url", solanaconnectConf)
+	}
+
+	s.wsConfig, err = wsclient.GenerateConfig(ctx, solanaconnectConf)
+	if err == nil {
+		s.client, err = ffresty.New(s.ctx, solanaconnectConf)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	s.pluginTopic = solanaconnectConf.GetString(SolanaconnectConfigTopic)
+	if s.pluginTopic == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, "topic", solanaconnectConf)
+	}
+	s.prefixShort = solanaconnectConf.GetString(SolanaconnectPrefixShort)
+	s.prefixLong = solanaconnectConf.GetString(SolanaconnectPrefixLong)
+
+	if s.wsConfig.WSKeyPath == "" {
+		s.wsConfig.WSKeyPath = "/ws"
+	}
+
+	cache, err := cacheManager.GetCache(
+		cache.NewCacheConfig(
+			ctx,
+			coreconfig.CacheBlockchainLimit,
+			coreconfig.CacheBlockchainTTL,
+			"",
+		),
+	)
+	if err != nil {
+		return err
+	}
+	s.cache = cache
+
+	s.streamID = make(map[string]string)
+	s.closed = make(map[string]chan struct{})
+	s.wsconn = make(map[string]wsclient.WSClient)
+	s.streams = newStreamManager(s.client, s.cache, s.solanaconnectConf.GetUint(SolanaconnectConfigBatchSize), s.solanaconnectConf.GetDuration(SolanaconnectConfigBatchTimeout).Milliseconds())
+
+	return nil
+}
+
+func (s *Solana) getTopic(namespace string) string {
+	return fmt.Sprintf("%s/%s", s.pluginTopic, namespace)
+}
+
+func (s *Solana) StartNamespace(ctx context.Context, namespace string) (err error) {
+	log.L(s.ctx).Debugf("Starting namespace: %s", namespace)
+	topic := s.getTopic(namespace)
+
+	s.wsconn[namespace], err = wsclient.New(ctx, s.wsConfig, nil, func(ctx context.Context, w wsclient.WSClient) error {
+		// Send a subscribe to our topic after each connect/reconnect
+		b, _ := json.Marshal(&solanaWSCommandPayload{
+			Type:  "listen",
+			Topic: topic,
+		})
+		err := w.Send(ctx, b)
+		if err == nil {
+			b, _ = json.Marshal(&solanaWSCommandPayload{
+				Type: "listenreplies",
+			})
+			err = w.Send(ctx, b)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	// Make sure that our event stream is in place
+	stream, err := s.streams.ensureEventStream(ctx, topic)
+	if err != nil {
+		return err
+	}
+	log.L(s.ctx).Infof("Event stream: %s (topic=%s)", stream.ID, topic)
+	s.streamID[namespace] = stream.ID
+
+	err = s.wsconn[namespace].Connect()
+	if err != nil {
+		return err
+	}
+
+	s.closed[namespace] = make(chan struct{})
+
+	go s.eventLoop(namespace, s.wsconn[namespace], s.closed[namespace])
+
+	return nil
+}
+
+func (s *Solana) StopNamespace(ctx context.Context, namespace string) (err error) {
+	wsconn, ok := s.wsconn[namespace]
+	if ok {
+		wsconn.Close()
+	}
+	delete(s.wsconn, namespace)
+	delete(s.streamID, namespace)
+	delete(s.closed, namespace)
+
+	return nil
+}
+
+func (s *Solana) SetHandler(namespace string, handler blockchain.Callbacks) {
+	s.callbacks.SetHandler(namespace, handler)
+}
+
+func (s *Solana) SetOperationHandler(namespace string, handler core.OperationCallbacks) {
+	s.callbacks.SetOperationalHandler(namespace, handler)
+}
+
+func (s *Solana) Capabilities() *blockchain.Capabilities {
+	return s.capabilities
+}
+
+func (s *Solana) AddFireflySubscription(ctx context.Context, namespace *core.Namespace, contract *blockchain.MultipartyContract, lastProtocolID string) (string, error) {
+	solanaLocation, err := s.parseContractLocation(ctx, contract.Location)
+	if err != nil {
+		return "", err
+	}
+
+	version, err := s.GetNetworkVersion(ctx, contract.Location)
+	if err != nil {
+		return "", err
+	}
+
+	streamID, ok := s.streamID[namespace.Name]
+	if !ok {
+		return "", i18n.NewError(ctx, coremsgs.MsgInternalServerError, "eventstream ID not found")
+	}
+	sub, err := s.streams.ensureFireFlySubscription(ctx, namespace.Name, solanaLocation.Address, contract.FirstEvent, streamID, lastProtocolID)
+	if err != nil {
+		return "", err
+	}
+
+	s.subs.AddSubscription(ctx, namespace, version, sub.ID, nil)
+	return sub.ID, nil
+}
+
+func (s *Solana) RemoveFireflySubscription(ctx context.Context, subID string) {
+	// As with the other connectors, the subscription is not deleted from the connector, as this may be
+	// called while processing events from the subscription
+	s.subs.RemoveSubscription(ctx, subID)
+}
+
+// bytes32 is passed to the connector as an array of numbers, which is how Anchor represents a [u8;32]
+func bytes32(b *fftypes.Bytes32) [32]byte {
+	if b == nil {
+		return [32]byte{}
+	}
+	return *b
+}
+
+// hexBytes returns the bytes in the data of an event as a hex string. An array of numbers (how Anchor
+// represents an array of u8) is converted, and a string is assumed to already be hex.
+func hexBytes(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		b := make([]byte, len(v))
+		for i, n := range v {
+			f, ok := n.(float64)
+			if !ok || f < 0 || f > 255 {
+				return ""
+			}
+			b[i] = byte(f)
+		}
+		return "0x" + hex.EncodeToString(b)
+	}
+	return ""
+}
+
+func (s *Solana) parseBlockchainEvent(ctx context.Context, msgJSON fftypes.JSONObject) *blockchain.Event {
+	sBlockNumber := msgJSON.GetString("blockNumber")
+	sTransactionHash := msgJSON.GetString("transactionHash")
+	blockNumber := msgJSON.GetInt64("blockNumber")
+	txIndex := msgJSON.GetInt64("transactionIndex")
+	logIndex := msgJSON.GetInt64("logIndex")
+	dataJSON := msgJSON.GetObject("data")
+	signature := msgJSON.GetString("signature")
+	// The name of the event follows the address of the program, when the signature includes it
+	name := strings.SplitN(signature, "(", 2)[0]
+	name = name[strings.LastIndex(name, ":")+1:]
+	timestampStr := msgJSON.GetString("timestamp")
+	timestamp, err := fftypes.ParseTimeString(timestampStr)
+	if err != nil {
+		log.L(ctx).Errorf("Blockchain event is not valid - missing timestamp: %+v", msgJSON)
+		return nil // move on
+	}
+
+	if sBlockNumber == "" || sTransactionHash == "" {
+		log.L(ctx).Errorf("Blockchain event is not valid - missing data: %+v", msgJSON)
+		return nil // move on
+	}
+
+	delete(msgJSON, "data")
+	return &blockchain.Event{
+		BlockchainTXID: sTransactionHash,
+		Source:         s.Name(),
+		Name:           name,
+		ProtocolID:     fmt.Sprintf("%.12d/%.6d/%.6d", blockNumber, txIndex, logIndex),
+		Output:         dataJSON,
+		Info:           msgJSON,
+		Timestamp:      timestamp,
+		Location:       s.buildEventLocationString(msgJSON),
+		Signature:      signature,
+	}
+}
+
+func (s *Solana) processBatchPinEvent(ctx context.Context, events common.EventsToDispatch, location *fftypes.JSONAny, subInfo *common.SubscriptionInfo, msgJSON fftypes.JSONObject) {
+	event := s.parseBlockchainEvent(ctx, msgJSON)
+	if event == nil {
+		return // move on
+	}
+
+	authorAddress := event.Output.GetString("author")
+	rawContexts, _ := event.Output["contexts"].([]interface{})
+	contexts := make([]string, len(rawContexts))
+	for i, rawContext := range rawContexts {
+		contexts[i] = hexBytes(rawContext)
+	}
+
+	params := &common.BatchPinParams{
+		UUIDs:      hexBytes(event.Output["uuids"]),
+		BatchHash:  hexBytes(event.Output["batchHash"]),
+		PayloadRef: event.Output.GetString("payloadRef"),
+		Contexts:   contexts,
+		NsOrAction: event.Output.GetString("action"),
+	}
+
+	// Validate the solana address - it must already be a valid address, as it is the key
+	// that signed the transaction
+	authorAddress, err := formatSolanaAddress(ctx, authorAddress)
+	if err != nil {
+		log.L(ctx).Errorf("BatchPin event is not valid - bad author address (%s): %+v", err, msgJSON)
+		return // move on
+	}
+	verifier := &core.VerifierRef{
+		Type:  core.VerifierTypeSolanaAddress,
+		Value: authorAddress,
+	}
+
+	s.callbacks.PrepareBatchPinOrNetworkAction(ctx, events, subInfo, location, event, verifier, params)
+}
+
+func (s *Solana) processContractEvent(ctx context.Context, events common.EventsToDispatch, msgJSON fftypes.JSONObject) error {
+	subID := msgJSON.GetString("subId")
+	sub, err := s.streams.getCachedSubscription(ctx, subID)
+	if err != nil {
+		return err // this is a problem - we should be able to find the listener that dispatched this to us
+	}
+
+	namespace := common.GetNamespaceFromSubName(sub.Name)
+	event := s.parseBlockchainEvent(ctx, msgJSON)
+	if event != nil {
+		s.callbacks.PrepareBlockchainEvent(ctx, events, namespace, &blockchain.EventForListener{
+			Event:      event,
+			ListenerID: subID,
+		})
+	}
+	return nil
+}
+
+func (s *Solana) buildEventLocationString(msgJSON fftypes.JSONObject) string {
+	return fmt.Sprintf("address=%s", msgJSON.GetString("address"))
+}
+
+func (s *Solana) handleMessageBatch(ctx context.Context, batchID int64, messages []interface{}) error {
+	// Build the set of events that need handling
+	events := make(common.EventsToDispatch)
+	count := len(messages)
+	for i, msgI := range messages {
+		msgMap, ok := msgI.(map[string]interface{})
+		if !ok {
+			log.L(ctx).Errorf("Message cannot be parsed as JSON: %+v", msgI)
+			return nil // Swallow this and move on
+		}
+		msgJSON := fftypes.JSONObject(msgMap)
+
+		signature := msgJSON.GetString("signature")
+		sub := msgJSON.GetString("subId")
+		logger := log.L(ctx)
+		logger.Infof("[Solana:%d:%d/%d]: '%s' on '%s'", batchID, i+1, count, signature, sub)
+		logger.Tracef("Message: %+v", msgJSON)
+
+		// Matches one of the active FireFly BatchPin subscriptions
+		if subInfo := s.subs.GetSubscription(sub); subInfo != nil {
+			location, err := s.encodeContractLocation(ctx, &Location{
+				Address: msgJSON.GetString("address"),
+			})
+			if err != nil {
+				return err
+			}
+
+			firstColon := strings.Index(signature, ":")
+			if firstColon >= 0 {
+				signature = signature[firstColon+1:]
+			}
+			switch strings.SplitN(signature, "(", 2)[0] {
+			case batchPinEvent.Name:
+				s.processBatchPinEvent(ctx, events, location, subInfo, msgJSON)
+			default:
+				log.L(ctx).Infof("Ignoring event with unknown signature: %s", signature)
+			}
+		} else {
+			// Subscription not recognized - assume it's from a custom contract listener
+			// (event manager will reject it if it's not)
+			if err := s.processContractEvent(ctx, events, msgJSON); err != nil {
+				return err
+			}
+		}
+	}
+	// Dispatch all the events from this patch that were successfully parsed and routed to namespaces
+	// (could be zero - that's ok)
+	return s.callbacks.DispatchBlockchainEvents(ctx, events)
+}
+
+func (s *Solana) eventLoop(namespace string, wsconn wsclient.WSClient, closed chan struct{}) {
+	topic := s.getTopic(namespace)
+	defer wsconn.Close()
+	defer close(closed)
+	l := log.L(s.ctx).WithField("role", "event-loop").WithField("namespace", namespace)
+	ctx := log.WithLogger(s.ctx, l)
+	log.L(ctx).Debugf("Starting event loop for namespace '%s'", namespace)
+	for {
+		select {
+		case <-ctx.Done():
+			l.Debugf("Event loop exiting (context cancelled)")
+			return
+		case msgBytes, ok := <-wsconn.Receive():
+			if !ok {
+				l.Debugf("Event loop exiting (receive channel closed). Terminating server!")
+				s.cancelCtx()
+				return
+			}
+
+			var msgParsed interface{}
+			err := json.Unmarshal(msgBytes, &msgParsed)
+			if err != nil {
+				l.Errorf("Message cannot be parsed as JSON: %s\n%s", err, string(msgBytes))
+				continue // Swallow this and move on
+			}
+			switch msgTyped := msgParsed.(type) {
+			case []interface{}:
+				err = s.handleMessageBatch(ctx, 0, msgTyped)
+				if err == nil {
+					ack, _ := json.Marshal(&solanaWSCommandPayload{
+						Type:  "ack",
+						Topic: topic,
+					})
+					err = wsconn.Send(ctx, ack)
+				}
+			case map[string]interface{}:
+				isBatch := false
+				if batchNumber, ok := msgTyped["batchNumber"].(float64); ok {
+					if events, ok := msgTyped["events"].([]interface{}); ok {
+						// Delivery with a batch number to use in the ack
+						isBatch = true
+						err = s.handleMessageBatch(ctx, (int64)(batchNumber), events)
+						// Errors processing messages are converted into nacks
+						ackOrNack := &solanaWSCommandPayload{
+							Topic:       topic,
+							BatchNumber: int64(batchNumber),
+						}
+						if err == nil {
+							ackOrNack.Type = "ack"
+						} else {
+							log.L(ctx).Errorf("Rejecting batch due error: %s", err)
+							ackOrNack.Type = "error"
+							ackOrNack.Message = err.Error()
+						}
+						b, _ := json.Marshal(&ackOrNack)
+						err = wsconn.Send(ctx, b)
+					}
+				}
+				if !isBatch {
+					var receipt common.BlockchainReceiptNotification
+					_ = json.Unmarshal(msgBytes, &receipt)
+					err := common.HandleReceipt(ctx, namespace, s, &receipt, s.callbacks)
+					if err != nil {
+						l.Errorf("Failed to process receipt: %+v", msgTyped)
+					}
+				}
+			default:
+				l.Errorf("Message unexpected: %+v", msgTyped)
+				continue
+			}
+
+			if err != nil {
+				l.Errorf("Event loop exiting (%s). Terminating server!", err)
+				s.cancelCtx()
+				return
+			}
+		}
+	}
+}
+
+// formatSolanaAddress checks an address is the base58 encoding of a 32 byte public key. The encoding
+// is unique, so the address is compared as it is supplied.
+func formatSolanaAddress(ctx context.Context, key string) (string, error) {
+	if b := base58.Decode(key, nil); len(b) == 32 && base58.Encode(b) == key {
+		return key, nil
+	}
+	return "", i18n.NewError(ctx, coremsgs.MsgInvalidSolanaAddress)
+}
+
+func (s *Solana) ResolveSigningKey(ctx context.Context, key string, intent blockchain.ResolveKeyIntent) (resolved string, err error) {
+	// Key may be unset for query intent only
+	if key == "" {
+		if intent == blockchain.ResolveKeyIntentQuery {
+			return "", nil
+		}
+		return "", i18n.NewError(ctx, coremsgs.MsgNodeMissingBlockchainKey)
+	}
+	return formatSolanaAddress(ctx, key)
+}
+
+func (s *Solana) buildSolanaconnectRequestBody(ctx context.Context, messageType, address, signingKey string, instruction *anchorInstruction, requestID string, input []interface{}, options map[string]interface{}) (map[string]interface{}, error) {
+	headers := SolanaconnectMessageHeaders{
+		Type: messageType,
+	}
+	if requestID != "" {
+		headers.ID = requestID
+	}
+	body := map[string]interface{}{
+		"headers": headers,
+		"to":      address,
+		"method":  instruction,
+		"params":  input,
+	}
+	if signingKey != "" {
+		body["from"] = signingKey
+	}
+	finalBody, err := s.applyOptions(ctx, body, options)
+	if err != nil {
+		return nil, err
+	}
+	if logrus.IsLevelEnabled(logrus.DebugLevel) {
+		jsonBody, _ := json.Marshal(finalBody)
+		log.L(ctx).Debugf("SolanaConnectorBody: %s", string(jsonBody))
+	}
+	return finalBody, nil
+}
+
+func (s *Solana) applyOptions(ctx context.Context, body, options map[string]interface{}) (map[string]interface{}, error) {
+	for k, v := range options {
+		// Set the new field if it's not already set. Do not allow overriding of existing fields
+		if _, ok := body[k]; !ok {
+			body[k] = v
+		} else {
+			return nil, i18n.NewError(ctx, coremsgs.MsgOverrideExistingFieldCustomOption, k)
+		}
+	}
+	return body, nil
+}
+
+func (s *Solana) invokeContractMethod(ctx context.Context, address, signingKey string, instruction *anchorInstruction, requestID string, input []interface{}, options map[string]interface{}) (submissionRejected bool, err error) {
+	if s.metrics.IsMetricsEnabled() {
+		s.metrics.BlockchainTransaction(address, instruction.Name)
+	}
+	messageType := "SendTransaction"
+	body, err := s.buildSolanaconnectRequestBody(ctx, messageType, address, signingKey, instruction, requestID, input, options)
+	if err != nil {
+		return true, err
+	}
+	var resErr common.BlockchainRESTError
+	res, err := s.client.R().
+		SetContext(ctx).
+		SetBody(body).
+		SetError(&resErr).
+		Post("/")
+	if err != nil || !res.IsSuccess() {
+		return resErr.SubmissionRejected, common.WrapRESTError(ctx, &resErr, res, err, coremsgs.MsgSolanaconnectRESTErr)
+	}
+	return false, nil
+}
+
+func (s *Solana) queryContractMethod(ctx context.Context, address, signingKey string, instruction *anchorInstruction, input []interface{}, options map[string]interface{}) (*resty.Response, error) {
+	if s.metrics.IsMetricsEnabled() {
+		s.metrics.BlockchainQuery(address, instruction.Name)
+	}
+	messageType := "Query"
+	body, err := s.buildSolanaconnectRequestBody(ctx, messageType, address, signingKey, instruction, "", input, options)
+	if err != nil {
+		return nil, err
+	}
+	var resErr common.BlockchainRESTError
+	res, err := s.client.R().
+		SetContext(ctx).
+		SetBody(body).
+		SetError(&resErr).
+		Post("/")
+	if err != nil || !res.IsSuccess() {
+		return res, common.WrapRESTError(ctx, &resErr, res, err, coremsgs.MsgSolanaconnectRESTErr)
+	}
+	return res, nil
+}
+
+// pinOptions passes the signing key as the author account of an instruction of the pin program
+func pinOptions(signingKey string) map[string]interface{} {
+	return map[string]interface{}{
+		"accounts": map[string]interface{}{
+			"author": signingKey,
+		},
+	}
+}
+
+func (s *Solana) SubmitBatchPin(ctx context.Context, nsOpID, networkNamespace, signingKey string, batch *blockchain.BatchPin, location *fftypes.JSONAny) error {
+	solanaLocation, err := s.parseContractLocation(ctx, location)
+	if err != nil {
+		return err
+	}
+
+	contexts := make([][32]byte, len(batch.Contexts))
+	for i, v := range batch.Contexts {
+		contexts[i] = bytes32(v)
+	}
+	var uuids fftypes.Bytes32
+	copy(uuids[0:16], (*batch.TransactionID)[:])
+	copy(uuids[16:32], (*batch.BatchID)[:])
+
+	// The namespace is not passed, as a subscription to the pin program is always for a single namespace
+	input := []interface{}{
+		bytes32(&uuids),
+		bytes32(batch.BatchHash),
+		batch.BatchPayloadRef,
+		contexts,
+	}
+	_, err = s.invokeContractMethod(ctx, solanaLocation.Address, signingKey, batchPinInstruction, nsOpID, input, pinOptions(signingKey))
+	return err
+}
+
+func (s *Solana) SubmitNetworkAction(ctx context.Context, nsOpID string, signingKey string, action core.NetworkActionType, location *fftypes.JSONAny) error {
+	solanaLocation, err := s.parseContractLocation(ctx, location)
+	if err != nil {
+		return err
+	}
+
+	input := []interface{}{
+		blockchain.FireFlyActionPrefix + action,
+		"",
+	}
+	_, err = s.invokeContractMethod(ctx, solanaLocation.Address, signingKey, networkActionInstruction, nsOpID, input, pinOptions(signingKey))
+	return err
+}
+
+// DeployContract deploys a compiled program, passed as the contract. The definition is the IDL of the program,
+// which the connector can publish with it. Programs are not initialized on deployment, so no input is accepted.
+func (s *Solana) DeployContract(ctx context.Context, nsOpID, signingKey string, definition, contract *fftypes.JSONAny, input []interface{}, options map[string]interface{}) (submissionRejected bool, err error) {
+	var program string
+	if contract == nil || json.Unmarshal(contract.Bytes(), &program) != nil || program == "" {
+		return true, i18n.NewError(ctx, coremsgs.MsgInvalidSolanaProgram)
+	}
+	if _, err := base64.StdEncoding.DecodeString(program); err != nil {
+		return true, i18n.NewError(ctx, coremsgs.MsgInvalidSolanaProgram)
+	}
+	if len(input) > 0 {
+		return true, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+	}
+
+	if s.metrics.IsMetricsEnabled() {
+		s.metrics.BlockchainContractDeployment()
+	}
+	headers := SolanaconnectMessageHeaders{
+		Type: core.DeployContract,
+		ID:   nsOpID,
+	}
+	body := map[string]interface{}{
+		"headers":    headers,
+		"from":       signingKey,
+		"definition": definition,
+		"contract":   program,
+	}
+	body, err = s.applyOptions(ctx, body, options)
+	if err != nil {
+		return true, err
+	}
+
+	var resErr common.BlockchainRESTError
+	res, err := s.client.R().
+		SetContext(ctx).
+		SetBody(body).
+		SetError(&resErr).
+		Post("/")
+	if err != nil || !res.IsSuccess() {
+		return resErr.SubmissionRejected, common.WrapRESTError(ctx, &resErr, res, err, coremsgs.MsgSolanaconnectRESTErr)
+	}
+	return false, nil
+}
+
+func (s *Solana) ValidateInvokeRequest(ctx context.Context, parsedMethod interface{}, input map[string]interface{}, hasMessage bool) error {
+	_, _, err := s.prepareRequest(ctx, parsedMethod, input)
+	if err == nil && hasMessage {
+		// There is no convention for passing a batch to a program as extra data
+		return i18n.NewError(ctx, coremsgs.MsgMethodDoesNotSupportPinning)
+	}
+	return err
+}
+
+func (s *Solana) InvokeContract(ctx context.Context, nsOpID string, signingKey string, location *fftypes.JSONAny, parsedMethod interface{}, input map[string]interface{}, options map[string]interface{}, batch *blockchain.BatchPin) (bool, error) {
+	solanaLocation, err := s.parseContractLocation(ctx, location)
+	if err != nil {
+		return true, err
+	}
+	methodInfo, orderedInput, err := s.prepareRequest(ctx, parsedMethod, input)
+	if err != nil {
+		return true, err
+	}
+	if batch != nil {
+		return true, i18n.NewError(ctx, coremsgs.MsgMethodDoesNotSupportPinning)
+	}
+	return s.invokeContractMethod(ctx, solanaLocation.Address, signingKey, methodInfo.instruction, nsOpID, orderedInput, options)
+}
+
+func (s *Solana) QueryContract(ctx context.Context, signingKey string, location *fftypes.JSONAny, parsedMethod interface{}, input map[string]interface{}, options map[string]interface{}) (interface{}, error) {
+	solanaLocation, err := s.parseContractLocation(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	methodInfo, orderedInput, err := s.prepareRequest(ctx, parsedMethod, input)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.queryContractMethod(ctx, solanaLocation.Address, signingKey, methodInfo.instruction, orderedInput, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var output interface{}
+	if err = json.Unmarshal(res.Body(), &output); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (s *Solana) CheckOverlappingLocations(ctx context.Context, left *fftypes.JSONAny, right *fftypes.JSONAny) (bool, error) {
+	if left == nil || right == nil {
+		// No location on either side so overlapping
+		return true, nil
+	}
+
+	parsedLeft, err := s.parseContractLocation(ctx, left)
+	if err != nil {
+		return false, err
+	}
+
+	parsedRight, err := s.parseContractLocation(ctx, right)
+	if err != nil {
+		return false, err
+	}
+
+	// Base58 addresses are case sensitive, so they are compared exactly
+	return parsedLeft.Address == parsedRight.Address, nil
+}
+
+func (s *Solana) NormalizeContractLocation(ctx context.Context, ntype blockchain.NormalizeType, location *fftypes.JSONAny) (result *fftypes.JSONAny, err error) {
+	parsed, err := s.parseContractLocation(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	return s.encodeContractLocation(ctx, parsed)
+}
+
+func (s *Solana) parseContractLocation(ctx context.Context, location *fftypes.JSONAny) (*Location, error) {
+	solanaLocation := Location{}
+	if err := json.Unmarshal(location.Bytes(), &solanaLocation); err != nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgContractLocationInvalid, err)
+	}
+	if solanaLocation.Address == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgContractLocationInvalid, "'address' not set")
+	}
+	return &solanaLocation, nil
+}
+
+func (s *Solana) encodeContractLocation(ctx context.Context, location *Location) (result *fftypes.JSONAny, err error) {
+	location.Address, err = formatSolanaAddress(ctx, location.Address)
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := json.Marshal(location)
+	if err == nil {
+		result = fftypes.JSONAnyPtrBytes(normalized)
+	}
+	return result, err
+}
+
+func (s *Solana) AddContractListener(ctx context.Context, listener *core.ContractListener, lastProtocolID string) (err error) {
+	if len(listener.Filters) == 0 {
+		return i18n.NewError(ctx, coremsgs.MsgFiltersEmpty, listener.Name)
+	}
+
+	filters := make([]*filter, len(listener.Filters))
+	for i, f := range listener.Filters {
+		event, err := ffiEventToAnchor(ctx, &f.Event.FFIEventDefinition)
+		if err != nil {
+			return err
+		}
+		filters[i] = &filter{
+			Event: event,
+		}
+		if f.Location != nil {
+			location, err := s.parseContractLocation(ctx, f.Location)
+			if err != nil {
+				return err
+			}
+			filters[i].Address = location.Address
+		}
+	}
+
+	subName := fmt.Sprintf("ff-sub-%s-%s", listener.Namespace, listener.ID)
+	firstEvent := string(core.SubOptsFirstEventNewest)
+	if listener.Options != nil {
+		firstEvent = listener.Options.FirstEvent
+	}
+	result, err := s.streams.createSubscription(ctx, s.streamID[listener.Namespace], subName, firstEvent, filters, lastProtocolID)
+	if err != nil {
+		return err
+	}
+	listener.BackendID = result.ID
+	return nil
+}
+
+func (s *Solana) DeleteContractListener(ctx context.Context, subscription *core.ContractListener, okNotFound bool) error {
+	return s.streams.deleteSubscription(ctx, subscription.BackendID, okNotFound)
+}
+
+func (s *Solana) GetContractListenerStatus(ctx context.Context, namespace, subID string, okNotFound bool) (found bool, detail interface{}, status core.ContractListenerStatus, err error) {
+	esID := s.streamID[namespace]
+	sub, err := s.streams.getSubscription(ctx, subID, okNotFound)
+	if err != nil || sub == nil || sub.Stream != esID {
+		return false, nil, core.ContractListenerStatusUnknown, err
+	}
+
+	checkpoint := &ListenerStatus{
+		Catchup:    sub.Catchup,
+		Checkpoint: sub.Checkpoint,
+	}
+
+	// reduce checkpoint data to a single enum
+	status = core.ContractListenerStatusSynced
+	if sub.Catchup {
+		status = core.ContractListenerStatusSyncing
+	}
+
+	return true, checkpoint, status, nil
+}
+
+func (s *Solana) GetFFIParamValidator(ctx context.Context) (fftypes.FFIParamValidator, error) {
+	// The Anchor type of each param is checked when the interface is parsed, so only "JSON Schema correctness" is needed
+	return nil, nil
+}
+
+func (s *Solana) GenerateEventSignature(ctx context.Context, event *fftypes.FFIEventDefinition) (string, error) {
+	anchorEvent, err := ffiEventToAnchor(ctx, event)
+	if err != nil {
+		return "", err
+	}
+	return anchorEventSignature(anchorEvent), nil
+}
+
+func (s *Solana) GenerateEventSignatureWithLocation(ctx context.Context, event *fftypes.FFIEventDefinition, location *fftypes.JSONAny) (string, error) {
+	eventSignature, err := s.GenerateEventSignature(ctx, event)
+	if err != nil {
+		return "", err
+	}
+
+	// No location set
+	if location == nil {
+		return fmt.Sprintf("*:%s", eventSignature), nil
+	}
+
+	parsed, err := s.parseContractLocation(ctx, location)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s:%s", parsed.Address, eventSignature), nil
+}
+
+func (s *Solana) GenerateErrorSignature(ctx context.Context, errorDef *fftypes.FFIErrorDefinition) string {
+	// Program errors are identified by their name, as they have no params
+	return errorDef.Name
+}
+
+// ParseInterface rebuilds the Anchor instruction of a method. Program errors are reported by the connector
+// with their code and message, so the errors of the interface are not needed to invoke it.
+func (s *Solana) ParseInterface(ctx context.Context, method *fftypes.FFIMethod, errors []*fftypes.FFIError) (interface{}, error) {
+	instruction, err := ffiMethodToInstruction(ctx, method)
+	if err != nil {
+		return nil, err
+	}
+	return &parsedFFIMethod{instruction: instruction}, nil
+}
+
+func (s *Solana) prepareRequest(ctx context.Context, parsedMethod interface{}, input map[string]interface{}) (*parsedFFIMethod, []interface{}, error) {
+	methodInfo, ok := parsedMethod.(*parsedFFIMethod)
+	if !ok || methodInfo.instruction == nil {
+		return nil, nil, i18n.NewError(ctx, coremsgs.MsgUnexpectedInterfaceType, parsedMethod)
+	}
+	args := methodInfo.instruction.Args
+	orderedInput := make([]interface{}, len(args))
+	for i, arg := range args {
+		orderedInput[i] = input[arg.Name]
+	}
+	return methodInfo, orderedInput, nil
+}
+
+func (s *Solana) GenerateFFI(ctx context.Context, generationRequest *fftypes.FFIGenerationRequest) (*fftypes.FFI, error) {
+	var input FFIGenerationInput
+	err := json.Unmarshal(generationRequest.Input.Bytes(), &input)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgFFIGenerationFailed, "unable to deserialize JSON as an Anchor IDL")
+	}
+	if input.IDL == nil || len(input.IDL.Instructions) == 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgFFIGenerationFailed, "IDL has no instructions")
+	}
+	return convertAnchorToFFI(ctx, generationRequest, input.IDL)
+}
+
+func (s *Solana) GetNetworkVersion(ctx context.Context, location *fftypes.JSONAny) (version int, err error) {
+	solanaLocation, err := s.parseContractLocation(ctx, location)
+	if err != nil {
+		return 0, err
+	}
+
+	cacheKey := "version:" + solanaLocation.Address
+	if cachedValue := s.cache.GetInt(cacheKey); cachedValue != 0 {
+		return cachedValue, nil
+	}
+
+	version, err = s.queryNetworkVersion(ctx, solanaLocation.Address)
+	if err == nil {
+		s.cache.SetInt(cacheKey, version)
+	}
+	return version, err
+}
+
+func (s *Solana) queryNetworkVersion(ctx context.Context, address string) (version int, err error) {
+	res, err := s.queryContractMethod(ctx, address, "", networkVersionInstruction, []interface{}{}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	output := &queryOutput{}
+	if err = json.Unmarshal(res.Body(), output); err != nil {
+		return 0, err
+	}
+
+	// The u8 returned by the program might be passed on as a number or a string
+	switch result := output.Output.(type) {
+	case float64:
+		version = int(result)
+	case string:
+		version, err = strconv.Atoi(result)
+	default:
+		err = i18n.NewError(ctx, coremsgs.MsgBadNetworkVersion, output.Output)
+	}
+	return version, err
+}
+
+func (s *Solana) GetAndConvertDeprecatedContractConfig(ctx context.Context) (location *fftypes.JSONAny, fromBlock string, err error) {
+	// There was never a config key for the pin program under "solanaconnect", so it must be set on the namespace
+	return nil, "", i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, "location", "namespaces.predefined[].multiparty.contract[]")
+}
+
+func (s *Solana) GetTransactionStatus(ctx context.Context, operation *core.Operation) (interface{}, error) {
+	txnID := (&core.PreparedOperation{ID: operation.ID, Namespace: operation.Namespace}).NamespacedIDString()
+
+	transactionRequestPath := fmt.Sprintf("/transactions/%s", txnID)
+	client := s.client
+	var resErr common.BlockchainRESTError
+	var statusResponse fftypes.JSONObject
+	res, err := client.R().
+		SetContext(ctx).
+		SetError(&resErr).
+		SetResult(&statusResponse).
+		Get(transactionRequestPath)
+	if err != nil || !res.IsSuccess() {
+		if res.StatusCode() == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, common.WrapRESTError(ctx, &resErr, res, err, coremsgs.MsgSolanaconnectRESTErr)
+	}
+
+	receiptInfo := statusResponse.GetObject("receipt")
+	txStatus := statusResponse.GetString("status")
+
+	if txStatus != "" {
+		var replyType string
+		if txStatus == "Succeeded" {
+			replyType = ReceiptTransactionSuccess
+		} else {
+			replyType = ReceiptTransactionFailed
+		}
+		// If the status has changed, mock up blockchain receipt as if we'd received it
+		// as a web socket notification
+		if (operation.Status == core.OpStatusPending || operation.Status == core.OpStatusInitialized) && txStatus != solanaTxStatusPending {
+			receipt := &common.BlockchainReceiptNotification{
+				Headers: common.BlockchainReceiptHeaders{
+					ReceiptID: statusResponse.GetString("id"),
+					ReplyType: replyType},
+				TxHash:     statusResponse.GetString("transactionHash"),
+				Message:    statusResponse.GetString("errorMessage"),
+				ProtocolID: receiptInfo.GetString("protocolId")}
+			err := common.HandleReceipt(ctx, operation.Namespace, s, receipt, s.callbacks)
+			if err != nil {
+				log.L(ctx).Warnf("Failed to handle receipt")
+			}
+		}
+	} else {
+		// Don't expect to get here so issue a warning
+		log.L(ctx).Warnf("Transaction status didn't include txStatus information")
+	}
+
+	return statusResponse, nil
+}
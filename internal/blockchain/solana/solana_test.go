@@ -0,0 +1,2144 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-common/pkg/wsclient"
+	"github.com/hyperledger/firefly/internal/blockchain/common"
+	"github.com/hyperledger/firefly/internal/cache"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/blockchainmocks"
+	"github.com/hyperledger/firefly/mocks/cachemocks"
+	"github.com/hyperledger/firefly/mocks/coremocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
+	"github.com/hyperledger/firefly/mocks/wsmocks"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/jarcoal/httpmock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var utConfig = config.RootSection("solana_unit_tests")
+var utSolanaconnectConf = utConfig.SubSection(SolanaconnectConfigKey)
+
+const (
+	testAuthor  = "7gzGJgZMTjFTJV7AcS9hS2sMTJKyjPU7HQbbCbohviQq"
+	testProgram = "2HRbXDoT3fpNhiFo8VxM7yeay29jBuxmLbzuq47Xbo43"
+	testOther   = "FciD4i2WPEYinnKaCzFZAPTUsRxTCpJM6FyQmezmkkoj"
+)
+
+func testLocation() *fftypes.JSONAny {
+	return fftypes.JSONAnyPtr(fftypes.JSONObject{
+		"address": testProgram,
+	}.String())
+}
+
+func testFFIMethod() *fftypes.FFIMethod {
+	return &fftypes.FFIMethod{
+		Name: "transfer",
+		Params: []*fftypes.FFIParam{
+			{
+				Name:   "to",
+				Schema: fftypes.JSONAnyPtr(`{"type":"string","details":{"type":"publicKey"}}`),
+			},
+			{
+				Name:   "amount",
+				Schema: fftypes.JSONAnyPtr(`{"oneOf":[{"type":"string"},{"type":"integer"}],"details":{"type":"u64"}}`),
+			},
+		},
+		Returns: []*fftypes.FFIParam{
+			{
+				Name:   "output",
+				Schema: fftypes.JSONAnyPtr(`{"type":"boolean","details":{"type":"bool"}}`),
+			},
+		},
+		Details: fftypes.JSONObject{
+			"discriminator": []interface{}{163, 52, 200, 231, 140, 3, 69, 186},
+			"accounts": []interface{}{
+				map[string]interface{}{"name": "from", "writable": true, "signer": true},
+			},
+		},
+	}
+}
+
+func testFFIEvent() *fftypes.FFIEventDefinition {
+	return &fftypes.FFIEventDefinition{
+		Name: "Transferred",
+		Params: fftypes.FFIParams{
+			{
+				Name:   "to",
+				Schema: fftypes.JSONAnyPtr(`{"type":"string","details":{"type":"publicKey"}}`),
+			},
+			{
+				Name:   "amounts",
+				Schema: fftypes.JSONAnyPtr(`{"type":"array","details":{"type":{"vec":"u64"}}}`),
+			},
+		},
+	}
+}
+
+func resetConf(s *Solana) {
+	coreconfig.Reset()
+	s.InitConfig(utConfig)
+}
+
+func newTestSolana() (*Solana, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	mm := &metricsmocks.Manager{}
+	mm.On("IsMetricsEnabled").Return(true)
+	mm.On("BlockchainTransaction", mock.Anything, mock.Anything).Return(nil)
+	mm.On("BlockchainContractDeployment", mock.Anything, mock.Anything).Return(nil)
+	mm.On("BlockchainQuery", mock.Anything, mock.Anything).Return(nil)
+	r := resty.New().SetBaseURL("http://localhost:12345")
+	s := &Solana{
+		ctx:         ctx,
+		cancelCtx:   cancel,
+		client:      r,
+		pluginTopic: "topic1",
+		prefixShort: defaultPrefixShort,
+		prefixLong:  defaultPrefixLong,
+		streamID:    make(map[string]string),
+		wsconn:      make(map[string]wsclient.WSClient),
+		closed:      make(map[string]chan struct{}),
+		wsConfig:    &wsclient.WSConfig{},
+		metrics:     mm,
+		cache:       cache.NewUmanagedCache(ctx, 100, 5*time.Minute),
+		callbacks:   common.NewBlockchainCallbacks(),
+		subs:        common.NewFireflySubscriptions(),
+		streams: &streamManager{
+			client: r,
+		},
+	}
+	return s, func() {
+		cancel()
+		if s.closed != nil {
+			// We've init'd, wait to close
+			for _, cls := range s.closed {
+				<-cls
+			}
+		}
+	}
+}
+
+func newTestStreamManager(client *resty.Client) *streamManager {
+	return newStreamManager(client, cache.NewUmanagedCache(context.Background(), 100, 5*time.Minute), defaultBatchSize, defaultBatchTimeout)
+}
+
+func mockNetworkVersion(version int) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		readBody, _ := req.GetBody()
+		var body map[string]interface{}
+		json.NewDecoder(readBody).Decode(&body)
+		headers := body["headers"].(map[string]interface{})
+		method := body["method"].(map[string]interface{})
+		if headers["type"] == "Query" && method["name"] == "networkVersion" {
+			return httpmock.NewJsonResponderOrPanic(200, queryOutput{
+				Output: version,
+			})(req)
+		}
+		return nil, nil
+	}
+}
+
+func TestInitMissingURL(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	resetConf(s)
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(s.ctx, 100, 5*time.Minute), nil)
+	err := s.Init(s.ctx, s.cancelCtx, utConfig, s.metrics, cmi)
+	assert.Regexp(t, "FF10138.*url", err)
+}
+
+func TestBadTLSConfig(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	resetConf(s)
+	utSolanaconnectConf.Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	tlsConf := utSolanaconnectConf.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!!!badness")
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(s.ctx, 100, 5*time.Minute), nil)
+	err := s.Init(s.ctx, s.cancelCtx, utConfig, s.metrics, cmi)
+	assert.Regexp(t, "FF00153", err)
+}
+
+func TestInitMissingTopic(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	resetConf(s)
+	utSolanaconnectConf.Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(s.ctx, 100, 5*time.Minute), nil)
+	err := s.Init(s.ctx, s.cancelCtx, utConfig, s.metrics, cmi)
+	assert.Regexp(t, "FF10138.*topic", err)
+}
+
+func TestInitCacheFail(t *testing.T) {
+	cacheInitError := errors.New("Initialization error.")
+	s, cancel := newTestSolana()
+	defer cancel()
+	resetConf(s)
+	utSolanaconnectConf.Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	utSolanaconnectConf.Set(SolanaconnectConfigTopic, "topic1")
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(nil, cacheInitError)
+	err := s.Init(s.ctx, s.cancelCtx, utConfig, s.metrics, cmi)
+	assert.Equal(t, cacheInitError, err)
+}
+
+func TestInitAndStart(t *testing.T) {
+
+	log.SetLevel("trace")
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	toServer, fromServer, wsURL, done := wsclient.NewTestWSServer(nil)
+	defer done()
+
+	mockedClient := &http.Client{}
+	httpmock.ActivateNonDefault(mockedClient)
+	defer httpmock.DeactivateAndReset()
+
+	u, _ := url.Parse(wsURL)
+	u.Scheme = "http"
+	httpURL := u.String()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/eventstreams", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, []eventStream{}))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/eventstreams", httpURL),
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			assert.Equal(t, "topic1/ns1", body["name"])
+			assert.Equal(t, float64(defaultBatchSize), body["batchSize"])
+			assert.Equal(t, float64(defaultBatchTimeout), body["batchTimeoutMS"])
+			return httpmock.NewJsonResponderOrPanic(200, eventStream{ID: "es12345"})(req)
+		})
+
+	resetConf(s)
+	utSolanaconnectConf.Set(ffresty.HTTPConfigURL, httpURL)
+	utSolanaconnectConf.Set(ffresty.HTTPCustomClient, mockedClient)
+	utSolanaconnectConf.Set(SolanaconnectConfigTopic, "topic1")
+
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(s.ctx, 100, 5*time.Minute), nil)
+	err := s.Init(s.ctx, s.cancelCtx, utConfig, s.metrics, cmi)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "solana", s.Name())
+	assert.Equal(t, core.VerifierTypeSolanaAddress, s.VerifierType())
+	assert.Equal(t, "/ws", s.wsConfig.WSKeyPath)
+
+	err = s.StartNamespace(s.ctx, "ns1")
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+	assert.Equal(t, "es12345", s.streamID["ns1"])
+	assert.NotNil(t, s.Capabilities())
+
+	startupMessage := <-toServer
+	assert.Equal(t, `{"type":"listen","topic":"topic1/ns1"}`, startupMessage)
+	startupMessage = <-toServer
+	assert.Equal(t, `{"type":"listenreplies"}`, startupMessage)
+	fromServer <- `[]` // empty batch, will be ignored, but acked
+	reply := <-toServer
+	assert.Equal(t, `{"type":"ack","topic":"topic1/ns1"}`, reply)
+	fromServer <- `{"batchNumber":12345,"events":[]}` // empty batch with a batch number, acked with the number
+	reply = <-toServer
+	assert.Equal(t, `{"type":"ack","topic":"topic1/ns1","batchNumber":12345}`, reply)
+	fromServer <- `{"batchNumber":12346,"events":["not a map"]}` // unparsable events are skipped
+	reply = <-toServer
+	assert.Equal(t, `{"type":"ack","topic":"topic1/ns1","batchNumber":12346}`, reply)
+
+	// Bad data will be ignored
+	fromServer <- `!json`
+	fromServer <- `{"not": "a reply"}`
+	fromServer <- `42`
+
+}
+
+func TestStartStopNamespace(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	toServer, _, wsURL, done := wsclient.NewTestWSServer(nil)
+	defer done()
+
+	mockedClient := &http.Client{}
+	httpmock.ActivateNonDefault(mockedClient)
+	defer httpmock.DeactivateAndReset()
+
+	u, _ := url.Parse(wsURL)
+	u.Scheme = "http"
+	httpURL := u.String()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/eventstreams", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, []eventStream{{ID: "es12345", Name: "topic1/ns1"}}))
+	httpmock.RegisterResponder("PATCH", fmt.Sprintf("%s/eventstreams/es12345", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, eventStream{ID: "es12345"}))
+
+	resetConf(s)
+	utSolanaconnectConf.Set(ffresty.HTTPConfigURL, httpURL)
+	utSolanaconnectConf.Set(ffresty.HTTPCustomClient, mockedClient)
+	utSolanaconnectConf.Set(SolanaconnectConfigTopic, "topic1")
+
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(s.ctx, 100, 5*time.Minute), nil)
+	err := s.Init(s.ctx, s.cancelCtx, utConfig, s.metrics, cmi)
+	assert.NoError(t, err)
+
+	err = s.StartNamespace(s.ctx, "ns1")
+	assert.NoError(t, err)
+	assert.Equal(t, "es12345", s.streamID["ns1"])
+
+	<-toServer
+
+	err = s.StopNamespace(s.ctx, "ns1")
+	assert.NoError(t, err)
+}
+
+func TestStartNamespaceWSCreateFail(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	resetConf(s)
+	utSolanaconnectConf.Set(ffresty.HTTPConfigURL, "!!!://")
+	utSolanaconnectConf.Set(SolanaconnectConfigTopic, "topic1")
+
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(s.ctx, 100, 5*time.Minute), nil)
+	err := s.Init(s.ctx, s.cancelCtx, utConfig, s.metrics, cmi)
+	assert.NoError(t, err)
+
+	err = s.StartNamespace(s.ctx, "ns1")
+	assert.Regexp(t, "FF00149", err)
+}
+
+func TestStartNamespaceStreamQueryFail(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	mockedClient := &http.Client{}
+	httpmock.ActivateNonDefault(mockedClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpURL := "http://solana.example.com:12345"
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/eventstreams", httpURL),
+		httpmock.NewStringResponder(500, `pop`))
+
+	resetConf(s)
+	utSolanaconnectConf.Set(ffresty.HTTPConfigURL, httpURL)
+	utSolanaconnectConf.Set(ffresty.HTTPCustomClient, mockedClient)
+	utSolanaconnectConf.Set(SolanaconnectConfigTopic, "topic1")
+
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(s.ctx, 100, 5*time.Minute), nil)
+	err := s.Init(s.ctx, s.cancelCtx, utConfig, s.metrics, cmi)
+	assert.NoError(t, err)
+
+	err = s.StartNamespace(s.ctx, "ns1")
+	assert.Regexp(t, "FF10554.*pop", err)
+}
+
+func TestStartNamespaceWSConnectFail(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	mockedClient := &http.Client{}
+	httpmock.ActivateNonDefault(mockedClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpURL := "http://solana.example.com:12345"
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/eventstreams", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, []eventStream{}))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/eventstreams", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, eventStream{ID: "es12345"}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ws", httpURL),
+		httpmock.NewJsonResponderOrPanic(500, "{}"))
+
+	resetConf(s)
+	utSolanaconnectConf.Set(ffresty.HTTPConfigURL, httpURL)
+	utSolanaconnectConf.Set(ffresty.HTTPCustomClient, mockedClient)
+	utSolanaconnectConf.Set(SolanaconnectConfigTopic, "topic1")
+
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(s.ctx, 100, 5*time.Minute), nil)
+	err := s.Init(s.ctx, s.cancelCtx, utConfig, s.metrics, cmi)
+	assert.NoError(t, err)
+
+	err = s.StartNamespace(s.ctx, "ns1")
+	assert.Regexp(t, "FF00148", err)
+}
+
+func TestAddAndRemoveFireflySubscription(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+	s.streamID["ns1"] = "es12345"
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/", mockNetworkVersion(2))
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions",
+		httpmock.NewJsonResponderOrPanic(200, []subscription{}))
+	httpmock.RegisterResponder("POST", "http://localhost:12345/subscriptions",
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			assert.Equal(t, "es12345", body["stream"])
+			assert.Regexp(t, "^ns1_BatchPin_", body["name"])
+			assert.Equal(t, "0", body["fromBlock"])
+			filters := body["filters"].([]interface{})
+			assert.Len(t, filters, 1)
+			filter := filters[0].(map[string]interface{})
+			assert.Equal(t, testProgram, filter["address"])
+			assert.Equal(t, "BatchPin", filter["event"].(map[string]interface{})["name"])
+			return httpmock.NewJsonResponderOrPanic(200, subscription{ID: "sub1"})(req)
+		})
+
+	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
+	contract := &blockchain.MultipartyContract{
+		Location:   testLocation(),
+		FirstEvent: "oldest",
+	}
+
+	subID, err := s.AddFireflySubscription(s.ctx, ns, contract, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "sub1", subID)
+	assert.NotNil(t, s.subs.GetSubscription("sub1"))
+	assert.Equal(t, 2, s.subs.GetSubscription("sub1").Version)
+
+	s.RemoveFireflySubscription(s.ctx, subID)
+	assert.Nil(t, s.subs.GetSubscription("sub1"))
+}
+
+func TestAddFireflySubscriptionExisting(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+	s.streamID["ns1"] = "es12345"
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/", mockNetworkVersion(2))
+	existing := []subscription{
+		{ID: "sub0", Stream: "es12345", Name: "ns1_BatchPin_" + hex.EncodeToString(sha256.New().Sum([]byte(testProgram)))[0:16]},
+	}
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions",
+		httpmock.NewJsonResponderOrPanic(200, existing))
+
+	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
+	contract := &blockchain.MultipartyContract{
+		Location: testLocation(),
+	}
+
+	subID, err := s.AddFireflySubscription(s.ctx, ns, contract, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "sub0", subID)
+}
+
+func TestAddFireflySubscriptionBadLocation(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
+	contract := &blockchain.MultipartyContract{
+		Location: fftypes.JSONAnyPtr(`{"bad": "bad"}`),
+	}
+
+	_, err := s.AddFireflySubscription(s.ctx, ns, contract, "")
+	assert.Regexp(t, "FF10310", err)
+}
+
+func TestAddFireflySubscriptionGetVersionError(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+	s.streamID["ns1"] = "es12345"
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/",
+		httpmock.NewJsonResponderOrPanic(500, fftypes.JSONObject{"error": "pop"}))
+
+	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
+	contract := &blockchain.MultipartyContract{
+		Location: testLocation(),
+	}
+
+	_, err := s.AddFireflySubscription(s.ctx, ns, contract, "")
+	assert.Regexp(t, "FF10554.*pop", err)
+}
+
+func TestAddFireflySubscriptionNoStream(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/", mockNetworkVersion(2))
+
+	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
+	contract := &blockchain.MultipartyContract{
+		Location: testLocation(),
+	}
+
+	_, err := s.AddFireflySubscription(s.ctx, ns, contract, "")
+	assert.Regexp(t, "FF10465.*eventstream ID not found", err)
+}
+
+func TestAddFireflySubscriptionQuerySubsFail(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+	s.streamID["ns1"] = "es12345"
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/", mockNetworkVersion(2))
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions",
+		httpmock.NewStringResponder(500, `pop`))
+
+	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
+	contract := &blockchain.MultipartyContract{
+		Location: testLocation(),
+	}
+
+	_, err := s.AddFireflySubscription(s.ctx, ns, contract, "")
+	assert.Regexp(t, "FF10554.*pop", err)
+}
+
+func TestAddFireflySubscriptionCreateError(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+	s.streamID["ns1"] = "es12345"
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/", mockNetworkVersion(2))
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions",
+		httpmock.NewJsonResponderOrPanic(200, []subscription{}))
+	httpmock.RegisterResponder("POST", "http://localhost:12345/subscriptions",
+		httpmock.NewStringResponder(500, `pop`))
+
+	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
+	contract := &blockchain.MultipartyContract{
+		Location: testLocation(),
+	}
+
+	_, err := s.AddFireflySubscription(s.ctx, ns, contract, "")
+	assert.Regexp(t, "FF10554.*pop", err)
+}
+
+func TestSubmitBatchPinOK(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	addr := testAuthor
+	batch := &blockchain.BatchPin{
+		TransactionID:   fftypes.MustParseUUID("9ffc50ff-6bfe-4502-adc7-93aea54cc059"),
+		BatchID:         fftypes.MustParseUUID("c5df767c-fe44-4e03-8eb5-1c5523097db5"),
+		BatchHash:       fftypes.NewRandB32(),
+		BatchPayloadRef: "Qmf412jQZiuVUtdgnB36FXFX7xg5V6KEbSJ4dpQuhkLyfD",
+		Contexts: []*fftypes.Bytes32{
+			fftypes.NewRandB32(),
+			fftypes.NewRandB32(),
+		},
+	}
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			assert.Equal(t, testProgram, body["to"])
+			assert.Equal(t, addr, body["from"])
+			headers := body["headers"].(map[string]interface{})
+			assert.Equal(t, "SendTransaction", headers["type"])
+			assert.Equal(t, "ns1:123", headers["id"])
+			method := body["method"].(map[string]interface{})
+			assert.Equal(t, "pinBatch", method["name"])
+			assert.Equal(t, map[string]interface{}{"author": addr}, body["accounts"])
+			params := body["params"].([]interface{})
+			assert.Len(t, params, 4)
+			assert.Equal(t, "0x9ffc50ff6bfe4502adc793aea54cc059c5df767cfe444e038eb51c5523097db5", hexBytes(params[0]))
+			assert.Equal(t, "0x"+batch.BatchHash.String(), hexBytes(params[1]))
+			assert.Equal(t, "Qmf412jQZiuVUtdgnB36FXFX7xg5V6KEbSJ4dpQuhkLyfD", params[2])
+			contexts := params[3].([]interface{})
+			assert.Len(t, contexts, 2)
+			assert.Equal(t, "0x"+batch.Contexts[0].String(), hexBytes(contexts[0]))
+			assert.Equal(t, "0x"+batch.Contexts[1].String(), hexBytes(contexts[1]))
+			return httpmock.NewJsonResponderOrPanic(200, "")(req)
+		})
+
+	err := s.SubmitBatchPin(context.Background(), "ns1:123", "ns1", addr, batch, testLocation())
+	assert.NoError(t, err)
+}
+
+func TestSubmitBatchPinBadLocation(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	batch := &blockchain.BatchPin{
+		TransactionID: fftypes.NewUUID(),
+		BatchID:       fftypes.NewUUID(),
+	}
+
+	err := s.SubmitBatchPin(context.Background(), "", "ns1", testAuthor, batch, fftypes.JSONAnyPtr(`{"address":""}`))
+	assert.Regexp(t, "FF10310", err)
+}
+
+func TestSubmitBatchPinFail(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	batch := &blockchain.BatchPin{
+		TransactionID: fftypes.NewUUID(),
+		BatchID:       fftypes.NewUUID(),
+		BatchHash:     fftypes.NewRandB32(),
+		Contexts:      []*fftypes.Bytes32{},
+	}
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		httpmock.NewJsonResponderOrPanic(500, fftypes.JSONObject{
+			"error":              "program failed",
+			"submissionRejected": true,
+		}))
+
+	err := s.SubmitBatchPin(context.Background(), "", "ns1", testAuthor, batch, testLocation())
+	assert.Regexp(t, "FF10554.*program failed", err)
+}
+
+func TestSubmitNetworkAction(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			method := body["method"].(map[string]interface{})
+			assert.Equal(t, "networkAction", method["name"])
+			assert.Equal(t, map[string]interface{}{"author": testAuthor}, body["accounts"])
+			assert.Equal(t, []interface{}{"firefly:terminate", ""}, body["params"])
+			return httpmock.NewJsonResponderOrPanic(200, "")(req)
+		})
+
+	err := s.SubmitNetworkAction(context.Background(), "ns1:"+fftypes.NewUUID().String(), testAuthor, core.NetworkActionTerminate, testLocation())
+	assert.NoError(t, err)
+}
+
+func TestSubmitNetworkActionBadLocation(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	err := s.SubmitNetworkAction(context.Background(), "", testAuthor, core.NetworkActionTerminate, fftypes.JSONAnyPtr(`!json`))
+	assert.Regexp(t, "FF10310", err)
+}
+
+func TestResolveSigningKey(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	key, err := s.ResolveSigningKey(context.Background(), testAuthor, blockchain.ResolveKeyIntentSign)
+	assert.NoError(t, err)
+	assert.Equal(t, testAuthor, key)
+
+	key, err = s.ResolveSigningKey(context.Background(), "", blockchain.ResolveKeyIntentQuery)
+	assert.NoError(t, err)
+	assert.Empty(t, key)
+
+	_, err = s.ResolveSigningKey(context.Background(), "", blockchain.ResolveKeyIntentSign)
+	assert.Regexp(t, "FF10354", err)
+
+	_, err = s.ResolveSigningKey(context.Background(), "0x91d2b4381a4cd5c7c0f27565a7d4b829844c8635", blockchain.ResolveKeyIntentSign)
+	assert.Regexp(t, "FF10553", err)
+}
+
+func TestFormatSolanaAddress(t *testing.T) {
+	ctx := context.Background()
+
+	for _, valid := range []string{testAuthor, testProgram, "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA", "11111111111111111111111111111111"} {
+		address, err := formatSolanaAddress(ctx, valid)
+		assert.NoError(t, err)
+		assert.Equal(t, valid, address)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"0OIl",           // not base58
+		"3yZe7d",         // too short
+		"1" + testAuthor, // a leading zero byte makes it too long
+	} {
+		_, err := formatSolanaAddress(ctx, invalid)
+		assert.Regexp(t, "FF10553", err)
+	}
+}
+
+func TestHexBytes(t *testing.T) {
+	assert.Equal(t, "0x01ff", hexBytes([]interface{}{float64(1), float64(255)}))
+	assert.Equal(t, "0xabcd", hexBytes("0xabcd"))
+	assert.Equal(t, "", hexBytes([]interface{}{float64(256)}))
+	assert.Equal(t, "", hexBytes([]interface{}{float64(-1)}))
+	assert.Equal(t, "", hexBytes([]interface{}{"1"}))
+	assert.Equal(t, "", hexBytes(nil))
+	assert.Equal(t, [32]byte{}, bytes32(nil))
+}
+
+func TestHandleMessageBatchPinOK(t *testing.T) {
+	data := fftypes.JSONAnyPtr(`
+[
+	{
+		"address": "` + testProgram + `",
+		"blockNumber": "38011",
+		"transactionIndex": "0",
+		"transactionHash": "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW",
+		"data": {
+			"author": "` + testAuthor + `",
+			"timestamp": "1620576488",
+			"action": "",
+			"uuids": [225,154,248,179,144,96,64,81,129,45,117,151,209,154,223,185,132,125,59,253,7,66,73,239,182,93,63,237,21,245,176,166],
+			"batchHash": [215,30,177,56,215,76,34,154,56,142,176,225,171,192,63,76,124,187,33,212,252,75,131,159,191,14,199,62,66,99,246,190],
+			"payloadRef": "Qmf412jQZiuVUtdgnB36FXFX7xg5V6KEbSJ4dpQuhkLyfD",
+			"contexts": [
+				[104,228,218,121,248,5,188,165,185,18,188,218,156,99,208,62,110,134,113,8,218,187,155,148,65,9,174,165,65,239,82,42]
+			]
+		},
+		"subId": "sb-b5b97a4e-a317-4053-6400-1474650efcb5",
+		"signature": "` + testProgram + `:BatchPin(publicKey,i64,string,[u8;32],[u8;32],string,vec<[u8;32]>)",
+		"logIndex": "50",
+		"timestamp": "1620576488"
+	},
+	{
+		"address": "` + testProgram + `",
+		"blockNumber": "38011",
+		"transactionIndex": "1",
+		"transactionHash": "4bWTqsEAqmCmLD6jVZ4kVpbHWCjm1L8sJgVdaQCRRvEBuWhXXDnR1DmrGUJ4BcCzDPH3FMyK1bT6gsbTpuCbqz8r",
+		"data": {
+			"author": "` + testAuthor + `",
+			"timestamp": "1620576489",
+			"action": "",
+			"uuids": "0x8a578549e56b49f9bd78d731f22b08d7a04c7cc37d444c2ba3b054e21326697e",
+			"batchHash": "0x20e6ef9b9c4df7fdb77a7de1e00347f4b02d996f2e56a7db361038be7b32a154",
+			"payloadRef": "",
+			"contexts": [
+				"0x8a63eb509713b0cf9250a8eee24ee2dfc4b37225e3ad5c29c95127699d382f85"
+			]
+		},
+		"subId": "sb-b5b97a4e-a317-4053-6400-1474650efcb5",
+		"signature": "BatchPin(publicKey,i64,string,[u8;32],[u8;32],string,vec<[u8;32]>)",
+		"logIndex": "51",
+		"timestamp": "1620576489"
+	},
+	{
+		"address": "` + testProgram + `",
+		"blockNumber": "38011",
+		"transactionIndex": "2",
+		"transactionHash": "4bWTqsEAqmCmLD6jVZ4kVpbHWCjm1L8sJgVdaQCRRvEBuWhXXDnR1DmrGUJ4BcCzDPH3FMyK1bT6gsbTpuCbqz8r",
+		"data": {
+			"author": "` + testAuthor + `"
+		},
+		"subId": "sb-b5b97a4e-a317-4053-6400-1474650efcb5",
+		"signature": "Random(publicKey)",
+		"logIndex": "52",
+		"timestamp": "1620576489"
+	}
+]`)
+
+	em := &blockchainmocks.Callbacks{}
+	s := &Solana{
+		callbacks: common.NewBlockchainCallbacks(),
+		subs:      common.NewFireflySubscriptions(),
+	}
+	s.SetHandler("ns1", em)
+	s.subs.AddSubscription(
+		context.Background(),
+		&core.Namespace{Name: "ns1", NetworkName: "ns1"},
+		2, "sb-b5b97a4e-a317-4053-6400-1474650efcb5", nil,
+	)
+
+	expectedSigningKeyRef := &core.VerifierRef{
+		Type:  core.VerifierTypeSolanaAddress,
+		Value: testAuthor,
+	}
+
+	em.On("BlockchainEventBatch", mock.MatchedBy(func(events []*blockchain.EventToDispatch) bool {
+		return len(events) == 2 &&
+			events[0].Type == blockchain.EventTypeBatchPinComplete &&
+			*events[0].BatchPinComplete.SigningKey == *expectedSigningKeyRef
+	})).Return(nil)
+
+	var events []interface{}
+	err := json.Unmarshal(data.Bytes(), &events)
+	assert.NoError(t, err)
+	err = s.handleMessageBatch(context.Background(), 0, events)
+	assert.NoError(t, err)
+
+	b := em.Calls[0].Arguments[0].([]*blockchain.EventToDispatch)[0].BatchPinComplete
+	assert.Equal(t, "ns1", b.Namespace)
+	assert.Equal(t, "e19af8b3-9060-4051-812d-7597d19adfb9", b.Batch.TransactionID.String())
+	assert.Equal(t, "847d3bfd-0742-49ef-b65d-3fed15f5b0a6", b.Batch.BatchID.String())
+	assert.Equal(t, "d71eb138d74c229a388eb0e1abc03f4c7cbb21d4fc4b839fbf0ec73e4263f6be", b.Batch.BatchHash.String())
+	assert.Equal(t, "Qmf412jQZiuVUtdgnB36FXFX7xg5V6KEbSJ4dpQuhkLyfD", b.Batch.BatchPayloadRef)
+	assert.Equal(t, expectedSigningKeyRef, b.SigningKey)
+	assert.Len(t, b.Batch.Contexts, 1)
+	assert.Equal(t, "68e4da79f805bca5b912bcda9c63d03e6e867108dabb9b944109aea541ef522a", b.Batch.Contexts[0].String())
+	assert.Equal(t, "BatchPin", b.Batch.Event.Name)
+	assert.Equal(t, "000000038011/000000/000050", b.Batch.Event.ProtocolID)
+	assert.Equal(t, "address="+testProgram, b.Batch.Event.Location)
+
+	b2 := em.Calls[0].Arguments[0].([]*blockchain.EventToDispatch)[1].BatchPinComplete.Batch
+	assert.Equal(t, "8a578549-e56b-49f9-bd78-d731f22b08d7", b2.TransactionID.String())
+	assert.Equal(t, "20e6ef9b9c4df7fdb77a7de1e00347f4b02d996f2e56a7db361038be7b32a154", b2.BatchHash.String())
+	assert.Len(t, b2.Contexts, 1)
+
+	em.AssertExpectations(t)
+}
+
+func TestHandleMessageBatchPinNetworkAction(t *testing.T) {
+	data := fftypes.JSONAnyPtr(`
+[
+	{
+		"address": "` + testProgram + `",
+		"blockNumber": "38011",
+		"transactionIndex": "0",
+		"transactionHash": "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW",
+		"data": {
+			"author": "` + testAuthor + `",
+			"timestamp": "1620576488",
+			"action": "firefly:terminate",
+			"uuids": [0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0],
+			"batchHash": [0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0],
+			"payloadRef": "",
+			"contexts": []
+		},
+		"subId": "sb-b5b97a4e-a317-4053-6400-1474650efcb5",
+		"signature": "BatchPin(publicKey,i64,string,[u8;32],[u8;32],string,vec<[u8;32]>)",
+		"logIndex": "50",
+		"timestamp": "1620576488"
+	}
+]`)
+
+	em := &blockchainmocks.Callbacks{}
+	s := &Solana{
+		callbacks: common.NewBlockchainCallbacks(),
+		subs:      common.NewFireflySubscriptions(),
+	}
+	s.SetHandler("ns1", em)
+	s.subs.AddSubscription(
+		context.Background(),
+		&core.Namespace{Name: "ns1", NetworkName: "ns1"},
+		2, "sb-b5b97a4e-a317-4053-6400-1474650efcb5", nil,
+	)
+
+	em.On("BlockchainEventBatch", mock.MatchedBy(func(events []*blockchain.EventToDispatch) bool {
+		return len(events) == 1 &&
+			events[0].Type == blockchain.EventTypeNetworkAction &&
+			events[0].NetworkAction.Action == "terminate" &&
+			events[0].NetworkAction.SigningKey.Value == testAuthor
+	})).Return(nil)
+
+	var events []interface{}
+	err := json.Unmarshal(data.Bytes(), &events)
+	assert.NoError(t, err)
+	err = s.handleMessageBatch(context.Background(), 0, events)
+	assert.NoError(t, err)
+
+	em.AssertExpectations(t)
+}
+
+func TestHandleMessageBatchPinBadAuthor(t *testing.T) {
+	data := fftypes.JSONAnyPtr(`
+[
+	{
+		"address": "` + testProgram + `",
+		"blockNumber": "38011",
+		"transactionIndex": "0",
+		"transactionHash": "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW",
+		"data": {
+			"author": "0x91d2b4381a4cd5c7c0f27565a7d4b829844c8635",
+			"uuids": "0xe19af8b390604051812d7597d19adfb9847d3bfd074249efb65d3fed15f5b0a6",
+			"batchHash": "0xd71eb138d74c229a388eb0e1abc03f4c7cbb21d4fc4b839fbf0ec73e4263f6be",
+			"contexts": []
+		},
+		"subId": "sb-b5b97a4e-a317-4053-6400-1474650efcb5",
+		"signature": "BatchPin(publicKey,i64,string,[u8;32],[u8;32],string,vec<[u8;32]>)",
+		"logIndex": "50",
+		"timestamp": "1620576488"
+	}
+]`)
+
+	em := &blockchainmocks.Callbacks{}
+	s := &Solana{
+		callbacks: common.NewBlockchainCallbacks(),
+		subs:      common.NewFireflySubscriptions(),
+	}
+	s.SetHandler("ns1", em)
+	s.subs.AddSubscription(
+		context.Background(),
+		&core.Namespace{Name: "ns1", NetworkName: "ns1"},
+		2, "sb-b5b97a4e-a317-4053-6400-1474650efcb5", nil,
+	)
+
+	var events []interface{}
+	err := json.Unmarshal(data.Bytes(), &events)
+	assert.NoError(t, err)
+	err = s.handleMessageBatch(context.Background(), 0, events)
+	assert.NoError(t, err)
+	assert.Len(t, em.Calls, 0)
+}
+
+func TestHandleMessageBatchMissingData(t *testing.T) {
+	data := fftypes.JSONAnyPtr(`
+[
+	{
+		"address": "` + testProgram + `",
+		"subId": "sb-b5b97a4e-a317-4053-6400-1474650efcb5",
+		"signature": "BatchPin(publicKey,i64,string,[u8;32],[u8;32],string,vec<[u8;32]>)",
+		"timestamp": "1620576488"
+	},
+	{
+		"address": "` + testProgram + `",
+		"blockNumber": "38011",
+		"transactionHash": "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW",
+		"subId": "sb-b5b97a4e-a317-4053-6400-1474650efcb5",
+		"signature": "BatchPin(publicKey,i64,string,[u8;32],[u8;32],string,vec<[u8;32]>)"
+	}
+]`)
+
+	em := &blockchainmocks.Callbacks{}
+	s := &Solana{
+		callbacks: common.NewBlockchainCallbacks(),
+		subs:      common.NewFireflySubscriptions(),
+	}
+	s.SetHandler("ns1", em)
+	s.subs.AddSubscription(
+		context.Background(),
+		&core.Namespace{Name: "ns1", NetworkName: "ns1"},
+		2, "sb-b5b97a4e-a317-4053-6400-1474650efcb5", nil,
+	)
+
+	var events []interface{}
+	err := json.Unmarshal(data.Bytes(), &events)
+	assert.NoError(t, err)
+	err = s.handleMessageBatch(context.Background(), 0, events)
+	assert.NoError(t, err)
+	assert.Len(t, em.Calls, 0)
+}
+
+func TestHandleMessageBatchPinBadProgramAddress(t *testing.T) {
+	data := fftypes.JSONAnyPtr(`
+[
+	{
+		"address": "0x1C197604587F046FD40684A8f21f4609FB811A7b",
+		"blockNumber": "38011",
+		"transactionHash": "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW",
+		"subId": "sb-b5b97a4e-a317-4053-6400-1474650efcb5",
+		"signature": "BatchPin(publicKey,i64,string,[u8;32],[u8;32],string,vec<[u8;32]>)",
+		"timestamp": "1620576488"
+	}
+]`)
+
+	s := &Solana{
+		callbacks: common.NewBlockchainCallbacks(),
+		subs:      common.NewFireflySubscriptions(),
+	}
+	s.subs.AddSubscription(
+		context.Background(),
+		&core.Namespace{Name: "ns1", NetworkName: "ns1"},
+		2, "sb-b5b97a4e-a317-4053-6400-1474650efcb5", nil,
+	)
+
+	var events []interface{}
+	err := json.Unmarshal(data.Bytes(), &events)
+	assert.NoError(t, err)
+	err = s.handleMessageBatch(context.Background(), 0, events)
+	assert.Regexp(t, "FF10553", err)
+}
+
+func TestHandleMessageBatchBadJSON(t *testing.T) {
+	s := &Solana{
+		callbacks: common.NewBlockchainCallbacks(),
+	}
+	err := s.handleMessageBatch(context.Background(), 0, []interface{}{10, 20})
+	assert.NoError(t, err)
+}
+
+func TestHandleMessageContractEventWithNamespace(t *testing.T) {
+	data := fftypes.JSONAnyPtr(`
+[
+	{
+		"address": "` + testProgram + `",
+		"blockNumber": "38011",
+		"transactionIndex": "0",
+		"transactionHash": "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW",
+		"data": {
+			"to": "` + testOther + `",
+			"amounts": ["1"]
+		},
+		"subId": "sub2",
+		"signature": "Transferred(publicKey,vec<u64>)",
+		"logIndex": "50",
+		"timestamp": "1640811383"
+	},
+	{
+		"address": "` + testProgram + `",
+		"blockNumber": "38011",
+		"transactionIndex": "0",
+		"transactionHash": "4bWTqsEAqmCmLD6jVZ4kVpbHWCjm1L8sJgVdaQCRRvEBuWhXXDnR1DmrGUJ4BcCzDPH3FMyK1bT6gsbTpuCbqz8r",
+		"data": {
+			"to": "` + testOther + `",
+			"amounts": ["2"]
+		},
+		"subId": "sub2",
+		"signature": "Transferred(publicKey,vec<u64>)",
+		"logIndex": "51",
+		"timestamp": "1640811384"
+	},
+	{
+		"address": "` + testProgram + `",
+		"subId": "sub2",
+		"signature": "Transferred(publicKey,vec<u64>)",
+		"timestamp": "!bad"
+	}
+]`)
+
+	em := &blockchainmocks.Callbacks{}
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions/sub2",
+		httpmock.NewJsonResponderOrPanic(200, subscription{
+			ID: "sub2", Stream: "es12345", Name: "ff-sub-ns1-1132312312312",
+		}))
+
+	s.SetHandler("ns1", em)
+	s.streams = newTestStreamManager(s.client)
+
+	em.On("BlockchainEventBatch", mock.MatchedBy(func(batch []*blockchain.EventToDispatch) bool {
+		return len(batch) == 2
+	})).Return(nil)
+
+	var events []interface{}
+	err := json.Unmarshal(data.Bytes(), &events)
+	assert.NoError(t, err)
+	err = s.handleMessageBatch(context.Background(), 0, events)
+	assert.NoError(t, err)
+
+	// The subscription is only fetched once
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+
+	ev := em.Calls[0].Arguments[0].([]*blockchain.EventToDispatch)[0]
+	assert.Equal(t, "sub2", ev.ForListener.ListenerID)
+	assert.Equal(t, "Transferred", ev.ForListener.Event.Name)
+	assert.Equal(t, "solana", ev.ForListener.Event.Source)
+
+	outputs := fftypes.JSONObject{
+		"to":      testOther,
+		"amounts": []interface{}{"1"},
+	}
+	assert.Equal(t, outputs, ev.ForListener.Event.Output)
+
+	info := fftypes.JSONObject{
+		"address":          testProgram,
+		"blockNumber":      "38011",
+		"logIndex":         "50",
+		"signature":        "Transferred(publicKey,vec<u64>)",
+		"subId":            "sub2",
+		"transactionHash":  "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW",
+		"transactionIndex": "0",
+		"timestamp":        "1640811383",
+	}
+	assert.Equal(t, info, ev.ForListener.Event.Info)
+
+	em.AssertExpectations(t)
+}
+
+func TestHandleMessageContractEventError(t *testing.T) {
+	data := fftypes.JSONAnyPtr(`
+[
+	{
+		"address": "` + testProgram + `",
+		"blockNumber": "38011",
+		"transactionIndex": "0",
+		"transactionHash": "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW",
+		"data": {},
+		"subId": "sub2",
+		"signature": "Transferred(publicKey,vec<u64>)",
+		"logIndex": "50",
+		"timestamp": "1640811383"
+	}
+]`)
+
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions/sub2",
+		httpmock.NewStringResponder(500, `pop`))
+	s.streams = newTestStreamManager(s.client)
+
+	var events []interface{}
+	err := json.Unmarshal(data.Bytes(), &events)
+	assert.NoError(t, err)
+	err = s.handleMessageBatch(context.Background(), 0, events)
+	assert.Regexp(t, "FF10554.*pop", err)
+}
+
+func TestEventLoopContextCancelled(t *testing.T) {
+	s, cancel := newTestSolana()
+	cancel()
+	r := make(<-chan []byte)
+	wsm := &wsmocks.WSClient{}
+	s.wsconn["ns1"] = wsm
+	wsm.On("Receive").Return(r)
+	wsm.On("Close").Return()
+	s.closed["ns1"] = make(chan struct{})
+	s.eventLoop("ns1", wsm, s.closed["ns1"]) // we're simply looking for it exiting
+	wsm.AssertExpectations(t)
+}
+
+func TestEventLoopReceiveClosed(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	r := make(chan []byte)
+	wsm := &wsmocks.WSClient{}
+	s.wsconn["ns1"] = wsm
+	close(r)
+	wsm.On("Receive").Return((<-chan []byte)(r))
+	wsm.On("Close").Return()
+	s.closed["ns1"] = make(chan struct{})
+	s.eventLoop("ns1", wsm, s.closed["ns1"]) // we're simply looking for it exiting
+	wsm.AssertExpectations(t)
+}
+
+func TestEventLoopSendClosed(t *testing.T) {
+	s, cancel := newTestSolana()
+	r := make(chan []byte, 1)
+	r <- []byte(`[]`)
+	wsm := &wsmocks.WSClient{}
+	s.wsconn["ns1"] = wsm
+	wsm.On("Receive").Return((<-chan []byte)(r))
+	wsm.On("Send", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		go cancel()
+	}).Return(fmt.Errorf("pop"))
+	wsm.On("Close").Return()
+	s.closed["ns1"] = make(chan struct{})
+	s.eventLoop("ns1", wsm, s.closed["ns1"]) // we're simply looking for it exiting
+	wsm.AssertExpectations(t)
+}
+
+func TestEventLoopBatchNack(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions/sub2",
+		httpmock.NewStringResponder(500, `pop`))
+	s.streams = newTestStreamManager(s.client)
+
+	r := make(chan []byte, 1)
+	r <- []byte(`{"batchNumber":12345,"events":[{"subId":"sub2"}]}`)
+	wsm := &wsmocks.WSClient{}
+	s.wsconn["ns1"] = wsm
+	wsm.On("Receive").Return((<-chan []byte)(r))
+	wsm.On("Send", mock.Anything, mock.MatchedBy(func(b []byte) bool {
+		var nack solanaWSCommandPayload
+		_ = json.Unmarshal(b, &nack)
+		return nack.Type == "error" && nack.BatchNumber == 12345 && nack.Topic == "topic1/ns1"
+	})).Run(func(args mock.Arguments) {
+		close(r)
+	}).Return(nil)
+	wsm.On("Close").Return()
+	s.closed["ns1"] = make(chan struct{})
+	s.eventLoop("ns1", wsm, s.closed["ns1"])
+	wsm.AssertExpectations(t)
+}
+
+func TestEventLoopReceipt(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	em := &coremocks.OperationCallbacks{}
+	s.SetOperationHandler("ns1", em)
+	operationID := fftypes.NewUUID()
+
+	em.On("OperationUpdate", mock.MatchedBy(func(update *core.OperationUpdateAsync) bool {
+		return update.NamespacedOpID == "ns1:"+operationID.String() &&
+			update.Status == core.OpStatusSucceeded &&
+			update.BlockchainTXID == "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW" &&
+			update.Plugin == "solana"
+	})).Return(nil)
+
+	r := make(chan []byte, 4)
+	r <- []byte(`!json`) // ignored
+	r <- []byte(`42`)    // ignored
+	r <- []byte(`{
+		"headers": {
+			"requestId": "ns1:` + operationID.String() + `",
+			"type": "TransactionSuccess"
+		},
+		"transactionHash": "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW"
+	}`)
+	r <- []byte(`{"headers": {"requestId": "ns1:` + operationID.String() + `"}}`) // missing type, logged
+	close(r)
+	wsm := &wsmocks.WSClient{}
+	s.wsconn["ns1"] = wsm
+	wsm.On("Receive").Return((<-chan []byte)(r))
+	wsm.On("Close").Return()
+	s.closed["ns1"] = make(chan struct{})
+	s.eventLoop("ns1", wsm, s.closed["ns1"])
+	wsm.AssertExpectations(t)
+	em.AssertExpectations(t)
+}
+
+func TestDeployContractOK(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	nsOpID := "ns1:" + fftypes.NewUUID().String()
+	definition := fftypes.JSONAnyPtr(`{"instructions":[]}`)
+	contract := fftypes.JSONAnyPtr(`"AQIDBA=="`)
+	options := map[string]interface{}{
+		"programId": testOther,
+	}
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			headers := body["headers"].(map[string]interface{})
+			assert.Equal(t, "DeployContract", headers["type"])
+			assert.Equal(t, nsOpID, headers["id"])
+			assert.Equal(t, testAuthor, body["from"])
+			assert.Equal(t, map[string]interface{}{"instructions": []interface{}{}}, body["definition"])
+			assert.Equal(t, "AQIDBA==", body["contract"])
+			assert.Equal(t, testOther, body["programId"])
+			return httpmock.NewJsonResponderOrPanic(200, "")(req)
+		})
+
+	rejected, err := s.DeployContract(context.Background(), nsOpID, testAuthor, definition, contract, nil, options)
+	assert.NoError(t, err)
+	assert.False(t, rejected)
+}
+
+func TestDeployContractBadProgram(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	for _, contract := range []*fftypes.JSONAny{nil, fftypes.JSONAnyPtr(`{}`), fftypes.JSONAnyPtr(`""`), fftypes.JSONAnyPtr(`"!base64"`)} {
+		rejected, err := s.DeployContract(context.Background(), "ns1:"+fftypes.NewUUID().String(), testAuthor, nil, contract, nil, nil)
+		assert.True(t, rejected)
+		assert.Regexp(t, "FF10556", err)
+	}
+}
+
+func TestDeployContractInputNotSupported(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	rejected, err := s.DeployContract(context.Background(), "ns1:"+fftypes.NewUUID().String(), testAuthor, nil, fftypes.JSONAnyPtr(`"AQIDBA=="`), []interface{}{"init"}, nil)
+	assert.True(t, rejected)
+	assert.Regexp(t, "FF10429", err)
+}
+
+func TestDeployContractInvalidOption(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	options := map[string]interface{}{
+		"contract": "not really",
+	}
+	rejected, err := s.DeployContract(context.Background(), "ns1:"+fftypes.NewUUID().String(), testAuthor, nil, fftypes.JSONAnyPtr(`"AQIDBA=="`), nil, options)
+	assert.True(t, rejected)
+	assert.Regexp(t, "FF10398", err)
+}
+
+func TestDeployContractError(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		httpmock.NewJsonResponderOrPanic(400, fftypes.JSONObject{
+			"error":              "insufficient funds",
+			"submissionRejected": true,
+		}))
+
+	rejected, err := s.DeployContract(context.Background(), "ns1:"+fftypes.NewUUID().String(), testAuthor, nil, fftypes.JSONAnyPtr(`"AQIDBA=="`), nil, nil)
+	assert.True(t, rejected)
+	assert.Regexp(t, "FF10554.*insufficient funds", err)
+}
+
+func TestInvokeContractOK(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	method := testFFIMethod()
+	params := map[string]interface{}{
+		"amount": "1000",
+		"to":     testOther,
+	}
+	options := map[string]interface{}{
+		"accounts": map[string]interface{}{"from": testAuthor},
+	}
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			assert.Equal(t, testAuthor, body["from"])
+			assert.Equal(t, testProgram, body["to"])
+			assert.Equal(t, "SendTransaction", body["headers"].(map[string]interface{})["type"])
+			assert.Equal(t, []interface{}{testOther, "1000"}, body["params"])
+			assert.Equal(t, options["accounts"], body["accounts"])
+			ix := body["method"].(map[string]interface{})
+			assert.Equal(t, "transfer", ix["name"])
+			assert.Equal(t, "bool", ix["returns"])
+			assert.Len(t, ix["accounts"], 1)
+			assert.Len(t, ix["discriminator"], 8)
+			assert.Equal(t, []interface{}{
+				map[string]interface{}{"name": "to", "type": "publicKey"},
+				map[string]interface{}{"name": "amount", "type": "u64"},
+			}, ix["args"])
+			return httpmock.NewJsonResponderOrPanic(200, "")(req)
+		})
+
+	parsedMethod, err := s.ParseInterface(context.Background(), method, nil)
+	assert.NoError(t, err)
+
+	rejected, err := s.InvokeContract(context.Background(), "ns1:"+fftypes.NewUUID().String(), testAuthor, testLocation(), parsedMethod, params, options, nil)
+	assert.NoError(t, err)
+	assert.False(t, rejected)
+}
+
+func TestInvokeContractWithBatchUnsupported(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	parsedMethod, err := s.ParseInterface(context.Background(), testFFIMethod(), nil)
+	assert.NoError(t, err)
+
+	rejected, err := s.InvokeContract(context.Background(), "", testAuthor, testLocation(), parsedMethod, map[string]interface{}{}, nil, &blockchain.BatchPin{})
+	assert.True(t, rejected)
+	assert.Regexp(t, "FF10443", err)
+}
+
+func TestInvokeContractInvalidOption(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	parsedMethod, err := s.ParseInterface(context.Background(), testFFIMethod(), nil)
+	assert.NoError(t, err)
+
+	options := map[string]interface{}{
+		"params": "shouldn't be allowed",
+	}
+	rejected, err := s.InvokeContract(context.Background(), "", testAuthor, testLocation(), parsedMethod, map[string]interface{}{}, options, nil)
+	assert.True(t, rejected)
+	assert.Regexp(t, "FF10398", err)
+}
+
+func TestInvokeContractAddressNotSet(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	rejected, err := s.InvokeContract(context.Background(), "", testAuthor, fftypes.JSONAnyPtr(`{}`), &parsedFFIMethod{}, nil, nil, nil)
+	assert.True(t, rejected)
+	assert.Regexp(t, "FF10310.*address", err)
+}
+
+func TestInvokeContractPrepareFail(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	rejected, err := s.InvokeContract(context.Background(), "", testAuthor, testLocation(), "wrong", nil, nil, nil)
+	assert.True(t, rejected)
+	assert.Regexp(t, "FF10457", err)
+}
+
+func TestInvokeContractConnectorError(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		httpmock.NewJsonResponderOrPanic(400, fftypes.JSONObject{
+			"error":              "account not found",
+			"submissionRejected": true,
+		}))
+
+	parsedMethod, err := s.ParseInterface(context.Background(), testFFIMethod(), nil)
+	assert.NoError(t, err)
+
+	rejected, err := s.InvokeContract(context.Background(), "", testAuthor, testLocation(), parsedMethod, map[string]interface{}{}, nil, nil)
+	assert.True(t, rejected)
+	assert.Regexp(t, "FF10554.*account not found", err)
+}
+
+func TestParseInterfaceFail(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	method := testFFIMethod()
+	method.Params[0].Schema = fftypes.JSONAnyPtr(`{"type":"string"}`)
+	_, err := s.ParseInterface(context.Background(), method, nil)
+	assert.Regexp(t, "FF10555.*to", err)
+}
+
+func TestValidateInvokeRequest(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	parsedMethod, err := s.ParseInterface(context.Background(), testFFIMethod(), nil)
+	assert.NoError(t, err)
+
+	err = s.ValidateInvokeRequest(context.Background(), parsedMethod, map[string]interface{}{}, false)
+	assert.NoError(t, err)
+
+	err = s.ValidateInvokeRequest(context.Background(), parsedMethod, map[string]interface{}{}, true)
+	assert.Regexp(t, "FF10443", err)
+
+	err = s.ValidateInvokeRequest(context.Background(), "wrong", map[string]interface{}{}, false)
+	assert.Regexp(t, "FF10457", err)
+}
+
+func TestQueryContractOK(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			headers := body["headers"].(map[string]interface{})
+			assert.Equal(t, "Query", headers["type"])
+			assert.Nil(t, headers["id"])
+			assert.Nil(t, body["from"])
+			assert.Equal(t, testProgram, body["to"])
+			assert.Equal(t, []interface{}{testOther, float64(5)}, body["params"])
+			return httpmock.NewJsonResponderOrPanic(200, queryOutput{Output: true})(req)
+		})
+
+	parsedMethod, err := s.ParseInterface(context.Background(), testFFIMethod(), nil)
+	assert.NoError(t, err)
+
+	result, err := s.QueryContract(context.Background(), "", testLocation(), parsedMethod, map[string]interface{}{"to": testOther, "amount": 5}, nil)
+	assert.NoError(t, err)
+	j, _ := json.Marshal(result)
+	assert.Equal(t, `{"output":true}`, string(j))
+}
+
+func TestQueryContractInvalidOption(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	parsedMethod, err := s.ParseInterface(context.Background(), testFFIMethod(), nil)
+	assert.NoError(t, err)
+
+	options := map[string]interface{}{
+		"to": "shouldn't be allowed",
+	}
+	_, err = s.QueryContract(context.Background(), testAuthor, testLocation(), parsedMethod, map[string]interface{}{}, options)
+	assert.Regexp(t, "FF10398", err)
+}
+
+func TestQueryContractAddressNotSet(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	_, err := s.QueryContract(context.Background(), "", fftypes.JSONAnyPtr(`{}`), &parsedFFIMethod{}, nil, nil)
+	assert.Regexp(t, "FF10310.*address", err)
+}
+
+func TestQueryContractErrorPrepare(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	_, err := s.QueryContract(context.Background(), "", testLocation(), &parsedFFIMethod{}, nil, nil)
+	assert.Regexp(t, "FF10457", err)
+}
+
+func TestQueryContractConnectorError(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		httpmock.NewJsonResponderOrPanic(400, fftypes.JSONObject{
+			"error": "simulation failed",
+		}))
+
+	parsedMethod, err := s.ParseInterface(context.Background(), testFFIMethod(), nil)
+	assert.NoError(t, err)
+
+	_, err = s.QueryContract(context.Background(), "", testLocation(), parsedMethod, map[string]interface{}{}, nil)
+	assert.Regexp(t, "FF10554.*simulation failed", err)
+}
+
+func TestQueryContractUnmarshalResponseError(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		httpmock.NewStringResponder(200, "[definitely not JSON}"))
+
+	parsedMethod, err := s.ParseInterface(context.Background(), testFFIMethod(), nil)
+	assert.NoError(t, err)
+
+	_, err = s.QueryContract(context.Background(), "", testLocation(), parsedMethod, map[string]interface{}{}, nil)
+	assert.Regexp(t, "invalid character", err)
+}
+
+func TestNormalizeContractLocation(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	result, err := s.NormalizeContractLocation(context.Background(), blockchain.NormalizeCall, fftypes.JSONAnyPtr(`{"address":"`+testProgram+`","other":"ignored"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"address":"`+testProgram+`"}`, result.String())
+
+	_, err = s.NormalizeContractLocation(context.Background(), blockchain.NormalizeCall, fftypes.JSONAnyPtr(`{"address":"0x71C7656EC7ab88b098defB751B7401B5f6d8976F"}`))
+	assert.Regexp(t, "FF10553", err)
+
+	_, err = s.NormalizeContractLocation(context.Background(), blockchain.NormalizeCall, fftypes.JSONAnyPtr(`{"address":""}`))
+	assert.Regexp(t, "FF10310.*address", err)
+
+	_, err = s.NormalizeContractLocation(context.Background(), blockchain.NormalizeCall, fftypes.JSONAnyPtr(`!json`))
+	assert.Regexp(t, "FF10310", err)
+}
+
+func TestCheckOverlappingLocations(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	result, err := s.CheckOverlappingLocations(context.Background(), nil, testLocation())
+	assert.NoError(t, err)
+	assert.True(t, result)
+
+	result, err = s.CheckOverlappingLocations(context.Background(), testLocation(), testLocation())
+	assert.NoError(t, err)
+	assert.True(t, result)
+
+	result, err = s.CheckOverlappingLocations(context.Background(), testLocation(), fftypes.JSONAnyPtr(`{"address":"`+testOther+`"}`))
+	assert.NoError(t, err)
+	assert.False(t, result)
+
+	_, err = s.CheckOverlappingLocations(context.Background(), fftypes.JSONAnyPtr(`{}`), testLocation())
+	assert.Regexp(t, "FF10310", err)
+
+	_, err = s.CheckOverlappingLocations(context.Background(), testLocation(), fftypes.JSONAnyPtr(`{}`))
+	assert.Regexp(t, "FF10310", err)
+}
+
+func TestAddContractListener(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+	s.streamID["ns1"] = "es-1"
+
+	listener := &core.ContractListener{
+		ID:        fftypes.MustParseUUID("1f0c1e5d-3b51-4b43-9d3b-6a6f4a6a3a7c"),
+		Namespace: "ns1",
+		Filters: []*core.ListenerFilter{
+			{
+				Event:    &core.FFISerializedEvent{FFIEventDefinition: *testFFIEvent()},
+				Location: testLocation(),
+			},
+			{
+				Event: &core.FFISerializedEvent{FFIEventDefinition: *testFFIEvent()},
+			},
+		},
+		Options: &core.ContractListenerOptions{
+			FirstEvent: string(core.SubOptsFirstEventOldest),
+		},
+	}
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/subscriptions`,
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			assert.Equal(t, "es-1", body["stream"])
+			assert.Equal(t, "ff-sub-ns1-1f0c1e5d-3b51-4b43-9d3b-6a6f4a6a3a7c", body["name"])
+			assert.Equal(t, "0", body["fromBlock"])
+			filters := body["filters"].([]interface{})
+			assert.Len(t, filters, 2)
+			assert.Equal(t, testProgram, filters[0].(map[string]interface{})["address"])
+			assert.Nil(t, filters[1].(map[string]interface{})["address"])
+			event := filters[0].(map[string]interface{})["event"].(map[string]interface{})
+			assert.Equal(t, "Transferred", event["name"])
+			assert.Equal(t, []interface{}{
+				map[string]interface{}{"name": "to", "type": "publicKey"},
+				map[string]interface{}{"name": "amounts", "type": map[string]interface{}{"vec": "u64"}},
+			}, event["fields"])
+			return httpmock.NewJsonResponderOrPanic(200, &subscription{ID: "sub1"})(req)
+		})
+
+	err := s.AddContractListener(context.Background(), listener, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "sub1", listener.BackendID)
+}
+
+func TestAddContractListenerNoOptions(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	listener := &core.ContractListener{
+		Namespace: "ns1",
+		Filters: []*core.ListenerFilter{
+			{Event: &core.FFISerializedEvent{FFIEventDefinition: *testFFIEvent()}},
+		},
+	}
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/subscriptions`,
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			assert.Equal(t, "latest", body["fromBlock"])
+			return httpmock.NewJsonResponderOrPanic(200, &subscription{ID: "sub1"})(req)
+		})
+
+	err := s.AddContractListener(context.Background(), listener, "")
+	assert.NoError(t, err)
+}
+
+func TestAddContractListenerNoFilters(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	err := s.AddContractListener(context.Background(), &core.ContractListener{Name: "listener1"}, "")
+	assert.Regexp(t, "FF10475", err)
+}
+
+func TestAddContractListenerBadEvent(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	event := testFFIEvent()
+	event.Params[1].Schema = fftypes.JSONAnyPtr(`{"type":"array","details":{"type":{"vec":"nope"}}}`)
+	listener := &core.ContractListener{
+		Filters: []*core.ListenerFilter{
+			{Event: &core.FFISerializedEvent{FFIEventDefinition: *event}},
+		},
+	}
+
+	err := s.AddContractListener(context.Background(), listener, "")
+	assert.Regexp(t, "FF10555.*amounts", err)
+}
+
+func TestAddContractListenerBadLocation(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	listener := &core.ContractListener{
+		Filters: []*core.ListenerFilter{
+			{
+				Event:    &core.FFISerializedEvent{FFIEventDefinition: *testFFIEvent()},
+				Location: fftypes.JSONAnyPtr(`{"bad":"location"}`),
+			},
+		},
+	}
+
+	err := s.AddContractListener(context.Background(), listener, "")
+	assert.Regexp(t, "FF10310", err)
+}
+
+func TestAddContractListenerFail(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	listener := &core.ContractListener{
+		Filters: []*core.ListenerFilter{
+			{Event: &core.FFISerializedEvent{FFIEventDefinition: *testFFIEvent()}},
+		},
+	}
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/subscriptions`,
+		httpmock.NewStringResponder(500, "pop"))
+
+	err := s.AddContractListener(context.Background(), listener, "")
+	assert.Regexp(t, "FF10554.*pop", err)
+}
+
+func TestDeleteContractListener(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("DELETE", `http://localhost:12345/subscriptions/sb-1`,
+		httpmock.NewStringResponder(204, ""))
+	httpmock.RegisterResponder("DELETE", `http://localhost:12345/subscriptions/sb-2`,
+		httpmock.NewStringResponder(404, ""))
+	httpmock.RegisterResponder("DELETE", `http://localhost:12345/subscriptions/sb-3`,
+		httpmock.NewStringResponder(500, "pop"))
+
+	err := s.DeleteContractListener(context.Background(), &core.ContractListener{BackendID: "sb-1"}, false)
+	assert.NoError(t, err)
+
+	err = s.DeleteContractListener(context.Background(), &core.ContractListener{BackendID: "sb-2"}, true)
+	assert.NoError(t, err)
+
+	err = s.DeleteContractListener(context.Background(), &core.ContractListener{BackendID: "sb-2"}, false)
+	assert.Regexp(t, "FF10554", err)
+
+	err = s.DeleteContractListener(context.Background(), &core.ContractListener{BackendID: "sb-3"}, true)
+	assert.Regexp(t, "FF10554.*pop", err)
+}
+
+func TestGetContractListenerStatus(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+	s.streamID["ns1"] = "es12345"
+
+	checkpoint := ListenerCheckpoint{Block: 38011, TransactionIndex: 1, LogIndex: 2}
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions/sub1",
+		httpmock.NewJsonResponderOrPanic(200, subscription{
+			ID: "sub1", Stream: "es12345", Name: "ff-sub-ns1-1132312312312",
+			subscriptionCheckpoint: subscriptionCheckpoint{Checkpoint: checkpoint},
+		}))
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions/sub2",
+		httpmock.NewJsonResponderOrPanic(200, subscription{
+			ID: "sub2", Stream: "es12345", Name: "ff-sub-ns1-1132312312312",
+			subscriptionCheckpoint: subscriptionCheckpoint{Checkpoint: checkpoint, Catchup: true},
+		}))
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions/sub3",
+		httpmock.NewJsonResponderOrPanic(200, subscription{
+			ID: "sub3", Stream: "es67890", Name: "ff-sub-ns2-1132312312312",
+		}))
+
+	found, detail, status, err := s.GetContractListenerStatus(context.Background(), "ns1", "sub1", true)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, core.ContractListenerStatusSynced, status)
+	assert.Equal(t, &ListenerStatus{Checkpoint: checkpoint}, detail)
+
+	found, detail, status, err = s.GetContractListenerStatus(context.Background(), "ns1", "sub2", true)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, core.ContractListenerStatusSyncing, status)
+	assert.Equal(t, &ListenerStatus{Checkpoint: checkpoint, Catchup: true}, detail)
+
+	found, _, status, err = s.GetContractListenerStatus(context.Background(), "ns1", "sub3", true)
+	assert.NoError(t, err)
+	assert.False(t, found)
+	assert.Equal(t, core.ContractListenerStatusUnknown, status)
+}
+
+func TestGetContractListenerStatusGetSubFail(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions/sub1",
+		httpmock.NewStringResponder(500, "pop"))
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions/sub2",
+		httpmock.NewStringResponder(404, ""))
+
+	found, _, status, err := s.GetContractListenerStatus(context.Background(), "ns1", "sub1", true)
+	assert.Regexp(t, "FF10554.*pop", err)
+	assert.False(t, found)
+	assert.Equal(t, core.ContractListenerStatusUnknown, status)
+
+	found, _, _, err = s.GetContractListenerStatus(context.Background(), "ns1", "sub2", true)
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestGetFFIParamValidator(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	v, err := s.GetFFIParamValidator(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestGenerateEventSignature(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	signature, err := s.GenerateEventSignature(context.Background(), testFFIEvent())
+	assert.NoError(t, err)
+	assert.Equal(t, "Transferred(publicKey,vec<u64>)", signature)
+
+	signature, err = s.GenerateEventSignature(context.Background(), &fftypes.FFIEventDefinition{Name: "Empty"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Empty()", signature)
+
+	event := testFFIEvent()
+	event.Params[0].Schema = nil
+	_, err = s.GenerateEventSignature(context.Background(), event)
+	assert.Regexp(t, "FF10555.*to", err)
+}
+
+func TestGenerateEventSignatureWithLocation(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	signature, err := s.GenerateEventSignatureWithLocation(context.Background(), testFFIEvent(), testLocation())
+	assert.NoError(t, err)
+	assert.Equal(t, testProgram+":Transferred(publicKey,vec<u64>)", signature)
+
+	signature, err = s.GenerateEventSignatureWithLocation(context.Background(), testFFIEvent(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "*:Transferred(publicKey,vec<u64>)", signature)
+
+	_, err = s.GenerateEventSignatureWithLocation(context.Background(), testFFIEvent(), fftypes.JSONAnyPtr(`{}`))
+	assert.Regexp(t, "FF10310", err)
+
+	event := testFFIEvent()
+	event.Params[0].Schema = nil
+	_, err = s.GenerateEventSignatureWithLocation(context.Background(), event, nil)
+	assert.Regexp(t, "FF10555", err)
+}
+
+func TestGenerateErrorSignature(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	signature := s.GenerateErrorSignature(context.Background(), &fftypes.FFIErrorDefinition{Name: "InsufficientFunds"})
+	assert.Equal(t, "InsufficientFunds", signature)
+}
+
+func TestGenerateFFI(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	ffi, err := s.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+		Name:        "Simple",
+		Version:     "v0.0.1",
+		Description: "desc",
+		Input:       fftypes.JSONAnyPtr(`{"idl":{"instructions":[{"name":"initialize","accounts":[],"args":[]}]}}`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Simple", ffi.Name)
+	assert.Len(t, ffi.Methods, 1)
+	assert.Equal(t, "initialize", ffi.Methods[0].Name)
+}
+
+func TestGenerateFFINoInstructions(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	for _, input := range []string{`{}`, `{"idl":{"instructions":[]}}`} {
+		_, err := s.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+			Input: fftypes.JSONAnyPtr(input),
+		})
+		assert.Regexp(t, "FF10346.*no instructions", err)
+	}
+}
+
+func TestGenerateFFIBadIDL(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	_, err := s.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+		Input: fftypes.JSONAnyPtr(`{"idl":false}`),
+	})
+	assert.Regexp(t, "FF10346.*Anchor IDL", err)
+}
+
+func TestGetNetworkVersion(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/", mockNetworkVersion(2))
+
+	version, err := s.GetNetworkVersion(context.Background(), testLocation())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, version)
+
+	// Cached on the second call
+	version, err = s.GetNetworkVersion(context.Background(), testLocation())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, version)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestGetNetworkVersionString(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/",
+		httpmock.NewJsonResponderOrPanic(200, queryOutput{Output: "2"}))
+
+	version, err := s.GetNetworkVersion(context.Background(), testLocation())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, version)
+}
+
+func TestGetNetworkVersionBadFormat(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/",
+		httpmock.NewJsonResponderOrPanic(200, queryOutput{Output: true}))
+
+	_, err := s.GetNetworkVersion(context.Background(), testLocation())
+	assert.Regexp(t, "FF10412", err)
+}
+
+func TestGetNetworkVersionUnmarshalFail(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/",
+		httpmock.NewStringResponder(200, "!json"))
+
+	_, err := s.GetNetworkVersion(context.Background(), testLocation())
+	assert.Regexp(t, "invalid character", err)
+}
+
+func TestGetNetworkVersionQueryFail(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/",
+		httpmock.NewJsonResponderOrPanic(500, fftypes.JSONObject{"error": "pop"}))
+
+	_, err := s.GetNetworkVersion(context.Background(), testLocation())
+	assert.Regexp(t, "FF10554.*pop", err)
+}
+
+func TestGetNetworkVersionBadLocation(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	_, err := s.GetNetworkVersion(context.Background(), fftypes.JSONAnyPtr(`{}`))
+	assert.Regexp(t, "FF10310", err)
+}
+
+func TestConvertDeprecatedContractConfig(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	_, _, err := s.GetAndConvertDeprecatedContractConfig(context.Background())
+	assert.Regexp(t, "FF10138.*location", err)
+}
+
+func TestGetTransactionStatusSuccess(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	em := &coremocks.OperationCallbacks{}
+	s.SetOperationHandler("ns1", em)
+	op := &core.Operation{
+		Namespace: "ns1",
+		ID:        fftypes.MustParseUUID("9ffc50ff-6bfe-4502-adc7-93aea54cc059"),
+		Status:    core.OpStatusPending,
+	}
+
+	httpmock.RegisterResponder("GET", `http://localhost:12345/transactions/ns1:9ffc50ff-6bfe-4502-adc7-93aea54cc059`,
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{
+			"id":              "ns1:9ffc50ff-6bfe-4502-adc7-93aea54cc059",
+			"status":          "Succeeded",
+			"transactionHash": "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW",
+			"receipt": fftypes.JSONObject{
+				"protocolId": "000000038011/000000",
+			},
+		}))
+
+	em.On("OperationUpdate", mock.MatchedBy(func(update *core.OperationUpdateAsync) bool {
+		return update.NamespacedOpID == "ns1:9ffc50ff-6bfe-4502-adc7-93aea54cc059" &&
+			update.Status == core.OpStatusSucceeded &&
+			update.BlockchainTXID == "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW"
+	})).Return(nil)
+
+	status, err := s.GetTransactionStatus(context.Background(), op)
+	assert.NoError(t, err)
+	assert.Equal(t, "Succeeded", status.(fftypes.JSONObject).GetString("status"))
+	em.AssertExpectations(t)
+}
+
+func TestGetTransactionStatusFailed(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	em := &coremocks.OperationCallbacks{}
+	s.SetOperationHandler("ns1", em)
+	op := &core.Operation{
+		Namespace: "ns1",
+		ID:        fftypes.MustParseUUID("9ffc50ff-6bfe-4502-adc7-93aea54cc059"),
+		Status:    core.OpStatusInitialized,
+	}
+
+	httpmock.RegisterResponder("GET", `http://localhost:12345/transactions/ns1:9ffc50ff-6bfe-4502-adc7-93aea54cc059`,
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{
+			"id":           "ns1:9ffc50ff-6bfe-4502-adc7-93aea54cc059",
+			"status":       "Failed",
+			"errorMessage": "custom program error: 0x1",
+		}))
+
+	em.On("OperationUpdate", mock.MatchedBy(func(update *core.OperationUpdateAsync) bool {
+		return update.Status == core.OpStatusFailed &&
+			update.ErrorMessage == "custom program error: 0x1"
+	})).Return(nil)
+
+	status, err := s.GetTransactionStatus(context.Background(), op)
+	assert.NoError(t, err)
+	assert.NotNil(t, status)
+	em.AssertExpectations(t)
+}
+
+func TestGetTransactionStatusUnchanged(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	op := &core.Operation{
+		Namespace: "ns1",
+		ID:        fftypes.MustParseUUID("9ffc50ff-6bfe-4502-adc7-93aea54cc059"),
+		Status:    core.OpStatusPending,
+	}
+
+	httpmock.RegisterResponder("GET", `http://localhost:12345/transactions/ns1:9ffc50ff-6bfe-4502-adc7-93aea54cc059`,
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{
+			"status": "Pending",
+		}))
+
+	status, err := s.GetTransactionStatus(context.Background(), op)
+	assert.NoError(t, err)
+	assert.NotNil(t, status)
+}
+
+func TestGetTransactionStatusNoStatus(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	op := &core.Operation{
+		Namespace: "ns1",
+		ID:        fftypes.MustParseUUID("9ffc50ff-6bfe-4502-adc7-93aea54cc059"),
+		Status:    core.OpStatusPending,
+	}
+
+	httpmock.RegisterResponder("GET", `http://localhost:12345/transactions/ns1:9ffc50ff-6bfe-4502-adc7-93aea54cc059`,
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{}))
+
+	status, err := s.GetTransactionStatus(context.Background(), op)
+	assert.NoError(t, err)
+	assert.NotNil(t, status)
+}
+
+func TestGetTransactionStatusNotFound(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	op := &core.Operation{
+		Namespace: "ns1",
+		ID:        fftypes.MustParseUUID("9ffc50ff-6bfe-4502-adc7-93aea54cc059"),
+	}
+
+	httpmock.RegisterResponder("GET", `http://localhost:12345/transactions/ns1:9ffc50ff-6bfe-4502-adc7-93aea54cc059`,
+		httpmock.NewStringResponder(404, ""))
+
+	status, err := s.GetTransactionStatus(context.Background(), op)
+	assert.NoError(t, err)
+	assert.Nil(t, status)
+}
+
+func TestGetTransactionStatusError(t *testing.T) {
+	s, cancel := newTestSolana()
+	defer cancel()
+	httpmock.ActivateNonDefault(s.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	op := &core.Operation{
+		Namespace: "ns1",
+		ID:        fftypes.MustParseUUID("9ffc50ff-6bfe-4502-adc7-93aea54cc059"),
+	}
+
+	httpmock.RegisterResponder("GET", `http://localhost:12345/transactions/ns1:9ffc50ff-6bfe-4502-adc7-93aea54cc059`,
+		httpmock.NewJsonResponderOrPanic(500, fftypes.JSONObject{"error": "pop"}))
+
+	_, err := s.GetTransactionStatus(context.Background(), op)
+	assert.Regexp(t, "FF10554.*pop", err)
+}
+
+func TestDebugLogRequestBody(t *testing.T) {
+	logrus.SetLevel(logrus.DebugLevel)
+	defer logrus.SetLevel(logrus.InfoLevel)
+	s, cancel := newTestSolana()
+	defer cancel()
+
+	body, err := s.buildSolanaconnectRequestBody(context.Background(), "Query", testProgram, "", networkVersionInstruction, "", []interface{}{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, networkVersionInstruction, body["method"])
+}
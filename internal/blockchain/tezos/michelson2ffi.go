@@ -0,0 +1,321 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tezos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"blockwatch.cc/tzgo/micheline"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+// FFIGenerationInput is the input to GenerateFFI, which accepts either the Micheline JSON of the
+// parameter type of a contract, or the full code of the contract as returned by the script RPC
+type FFIGenerationInput struct {
+	Parameter *micheline.Prim  `json:"parameter,omitempty"`
+	Code      []micheline.Prim `json:"code,omitempty"`
+}
+
+// JSON schema types
+const (
+	_jsonInteger = "integer"
+	_jsonString  = "string"
+	_jsonBoolean = "boolean"
+	_jsonObject  = "object"
+	_schema      = "schema"
+)
+
+type michelsonConverter struct {
+	ctx context.Context
+}
+
+// convertMichelsonToFFI builds an FFI method for each entrypoint of a parameter type. The schema of each
+// param uses the "details" that ffi2michelson.go consumes, so the methods can be invoked as generated.
+func convertMichelsonToFFI(ctx context.Context, generationRequest *fftypes.FFIGenerationRequest, parameter micheline.Prim) (*fftypes.FFI, error) {
+	c := &michelsonConverter{ctx: ctx}
+	if parameter.OpCode == micheline.K_PARAMETER && len(parameter.Args) == 1 {
+		parameter = parameter.Args[0]
+	}
+	entrypoints, err := micheline.NewType(parameter).Entrypoints(true)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgFFIGenerationFailed, "invalid parameter type")
+	}
+	if len(entrypoints) == 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgFFIGenerationFailed, "parameter type is empty")
+	}
+
+	ffi := &fftypes.FFI{
+		Namespace:   generationRequest.Namespace,
+		Name:        generationRequest.Name,
+		Version:     generationRequest.Version,
+		Description: generationRequest.Description,
+		Methods:     make([]*fftypes.FFIMethod, 0, len(entrypoints)),
+		Events:      []*fftypes.FFIEvent{},
+	}
+	for _, ep := range entrypoints {
+		params, err := c.entrypointParams(ep)
+		if err != nil {
+			return nil, err
+		}
+		ffi.Methods = append(ffi.Methods, &fftypes.FFIMethod{
+			Name:    ep.Name,
+			Params:  params,
+			Returns: fftypes.FFIParams{},
+		})
+	}
+	sort.Slice(ffi.Methods, func(i, j int) bool {
+		return ffi.Methods[i].Name < ffi.Methods[j].Name
+	})
+	return ffi, nil
+}
+
+func (c *michelsonConverter) unsupported(what string, prim micheline.Prim) error {
+	return i18n.NewError(c.ctx, coremsgs.MsgFFIGenerationFailed, fmt.Sprintf("%s '%s'", what, prim.OpCode))
+}
+
+// The values of multiple params are passed to the entrypoint as a sequence, which Michelson reads as a
+// right comb of pairs, so a pair is split into params for as long as it is not a named field itself
+func flattenComb(prim micheline.Prim) []micheline.Prim {
+	if prim.OpCode != micheline.T_PAIR || len(prim.Args) < 2 {
+		return []micheline.Prim{prim}
+	}
+	fields := append([]micheline.Prim{}, prim.Args[:len(prim.Args)-1]...)
+	last := prim.Args[len(prim.Args)-1]
+	if last.OpCode == micheline.T_PAIR && last.GetVarAnno() == "" {
+		return append(fields, flattenComb(last)...)
+	}
+	return append(fields, last)
+}
+
+func fieldName(prim micheline.Prim, i int) string {
+	if name := prim.GetVarAnno(); name != "" {
+		return name
+	}
+	if name := prim.GetTypeAnno(); name != "" {
+		return name
+	}
+	return fmt.Sprintf("arg%d", i)
+}
+
+func (c *michelsonConverter) entrypointParams(ep micheline.Entrypoint) (fftypes.FFIParams, error) {
+	root := *ep.Prim
+	params := fftypes.FFIParams{}
+	if root.OpCode == micheline.T_UNIT {
+		return params, nil
+	}
+
+	// The field annotation of the root is the name of the entrypoint, so a single param is named from
+	// its type annotation
+	fields := flattenComb(root)
+	if len(fields) == 1 {
+		root.Anno = nil
+		if typeName := ep.Prim.GetTypeAnno(); typeName != "" {
+			root.Anno = []string{":" + typeName}
+		}
+		fields = []micheline.Prim{root}
+	}
+	for i, field := range fields {
+		schema, err := c.paramSchema(field)
+		if err != nil {
+			return nil, err
+		}
+		b, _ := json.Marshal(schema)
+		params = append(params, &fftypes.FFIParam{
+			Name:   fieldName(field, i),
+			Schema: fftypes.JSONAnyPtrBytes(b),
+		})
+	}
+	return params, nil
+}
+
+// primitiveType returns the internal type used by ffi2michelson.go, and the JSON schema type of the value
+func primitiveType(prim micheline.Prim) (internalType, jsonType string, ok bool) {
+	switch prim.OpCode {
+	case micheline.T_NAT, micheline.T_MUTEZ:
+		return _internalNat, _jsonInteger, true
+	case micheline.T_INT:
+		return _internalInteger, _jsonInteger, true
+	case micheline.T_STRING:
+		return _internalString, _jsonString, true
+	case micheline.T_BYTES:
+		return _internalBytes, _jsonString, true
+	case micheline.T_BOOL:
+		return _internalBoolean, _jsonBoolean, true
+	case micheline.T_ADDRESS, micheline.T_CONTRACT:
+		return _internalAddress, _jsonString, true
+	}
+	return "", "", false
+}
+
+// primitiveDetails handles a primitive, or an option of a primitive, which is encoded using its internal type
+func primitiveDetails(prim micheline.Prim) (jsonType string, details fftypes.JSONObject, ok bool) {
+	kind := ""
+	if prim.OpCode == micheline.T_OPTION && len(prim.Args) == 1 {
+		kind = _internalOption
+		prim = prim.Args[0]
+	}
+	internalType, jsonType, ok := primitiveType(prim)
+	if !ok {
+		return "", nil, false
+	}
+	details = fftypes.JSONObject{
+		"type":         internalType,
+		"internalType": internalType,
+	}
+	if kind != "" {
+		details["kind"] = kind
+	}
+	return jsonType, details, true
+}
+
+func (c *michelsonConverter) paramSchema(prim micheline.Prim) (fftypes.JSONObject, error) {
+	if jsonType, details, ok := primitiveDetails(prim); ok {
+		return fftypes.JSONObject{"type": jsonType, "details": details}, nil
+	}
+
+	// Each item of a list param is encoded separately from the details
+	if (prim.OpCode == micheline.T_LIST || prim.OpCode == micheline.T_SET) && len(prim.Args) == 1 {
+		if _, details, ok := primitiveDetails(prim.Args[0]); ok {
+			return fftypes.JSONObject{"type": _jsonArray, "details": details}, nil
+		}
+		internalSchema, err := c.internalSchema(prim.Args[0], "")
+		if err != nil {
+			return nil, err
+		}
+		return fftypes.JSONObject{
+			"type":    _jsonArray,
+			"details": fftypes.JSONObject{"type": _schema, "internalSchema": internalSchema},
+		}, nil
+	}
+
+	internalSchema, err := c.internalSchema(prim, "")
+	if err != nil {
+		return nil, err
+	}
+	return fftypes.JSONObject{
+		"type":    _jsonObject,
+		"details": fftypes.JSONObject{"type": _schema, "internalSchema": internalSchema},
+	}, nil
+}
+
+func (c *michelsonConverter) internalSchema(prim micheline.Prim, name string) (fftypes.JSONObject, error) {
+	schema := fftypes.JSONObject{}
+	if name != "" {
+		schema["name"] = name
+	}
+	if internalType, _, ok := primitiveType(prim); ok {
+		schema["type"] = internalType
+		return schema, nil
+	}
+
+	switch {
+	case prim.OpCode == micheline.T_PAIR && len(prim.Args) >= 2:
+		fields := flattenComb(prim)
+		args := make([]interface{}, len(fields))
+		for i, field := range fields {
+			arg, err := c.internalSchema(field, fieldName(field, i))
+			if err != nil {
+				return nil, err
+			}
+			args[i] = arg
+		}
+		schema["type"] = _internalStruct
+		schema["args"] = args
+	case (prim.OpCode == micheline.T_LIST || prim.OpCode == micheline.T_SET) && len(prim.Args) == 1:
+		arg, err := c.internalSchema(prim.Args[0], "")
+		if err != nil {
+			return nil, err
+		}
+		schema["type"] = _internalList
+		schema["args"] = []interface{}{arg}
+	case (prim.OpCode == micheline.T_MAP || prim.OpCode == micheline.T_BIG_MAP) && len(prim.Args) == 2:
+		key, err := c.internalSchema(prim.Args[0], _key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := c.internalSchema(prim.Args[1], _value)
+		if err != nil {
+			return nil, err
+		}
+		schema["type"] = _internalMap
+		schema["args"] = []interface{}{key, value}
+	case prim.OpCode == micheline.T_OR && len(prim.Args) == 2:
+		return c.variantSchema(prim, schema)
+	default:
+		return nil, c.unsupported("unsupported Michelson type", prim)
+	}
+	return schema, nil
+}
+
+// variantLeaves returns the branches of an or type, in the layouts that wrapWithVariant in ffi2michelson.go
+// can encode: (or a b), (or a (or b c)) and (or (or a b) (or c d))
+func variantLeaves(prim micheline.Prim) ([]micheline.Prim, bool) {
+	left, right := prim.Args[0], prim.Args[1]
+	var leaves []micheline.Prim
+	switch {
+	case left.OpCode != micheline.T_OR && right.OpCode != micheline.T_OR:
+		leaves = []micheline.Prim{left, right}
+	case left.OpCode != micheline.T_OR && len(right.Args) == 2:
+		leaves = []micheline.Prim{left, right.Args[0], right.Args[1]}
+	case len(left.Args) == 2 && len(right.Args) == 2 && right.OpCode == micheline.T_OR:
+		leaves = []micheline.Prim{left.Args[0], left.Args[1], right.Args[0], right.Args[1]}
+	default:
+		return nil, false
+	}
+	for _, leaf := range leaves {
+		if leaf.OpCode == micheline.T_OR {
+			return nil, false
+		}
+	}
+	return leaves, true
+}
+
+// A variant is described by a single schema, so every branch must have the same type, and a name
+func (c *michelsonConverter) variantSchema(prim micheline.Prim, schema fftypes.JSONObject) (fftypes.JSONObject, error) {
+	leaves, ok := variantLeaves(prim)
+	if !ok {
+		return nil, c.unsupported("unsupported layout of variant", prim)
+	}
+	var arg fftypes.JSONObject
+	variants := make([]interface{}, len(leaves))
+	for i, leaf := range leaves {
+		variant := leaf.GetVarAnno()
+		if variant == "" {
+			return nil, c.unsupported("variant branch must be named", prim)
+		}
+		variants[i] = variant
+		leafSchema, err := c.internalSchema(leaf, "")
+		if err != nil {
+			return nil, err
+		}
+		if arg == nil {
+			arg = leafSchema
+		} else if !reflect.DeepEqual(arg, leafSchema) {
+			return nil, c.unsupported("variant branches must have the same type", prim)
+		}
+	}
+	schema["type"] = _internalVariant
+	schema["variants"] = variants
+	schema["args"] = []interface{}{arg}
+	return schema, nil
+}
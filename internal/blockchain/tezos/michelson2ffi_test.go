@@ -0,0 +1,356 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tezos
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"blockwatch.cc/tzgo/micheline"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+const fa2Parameter = `{
+	"prim": "or",
+	"args": [
+		{
+			"prim": "or",
+			"args": [
+				{"prim": "list", "args": [{"prim": "nat"}], "annots": ["%burn"]},
+				{
+					"prim": "pair",
+					"annots": ["%mint"],
+					"args": [
+						{"prim": "address", "annots": ["%owner"]},
+						{
+							"prim": "list",
+							"annots": ["%requests"],
+							"args": [{
+								"prim": "pair",
+								"args": [
+									{"prim": "bytes", "annots": ["%metadata"]},
+									{"prim": "nat", "annots": ["%token_id"]}
+								]
+							}]
+						}
+					]
+				}
+			]
+		},
+		{
+			"prim": "or",
+			"args": [
+				{"prim": "unit", "annots": ["%destroy"]},
+				{
+					"prim": "or",
+					"args": [
+						{"prim": "option", "args": [{"prim": "int"}], "annots": [":new_value", "%set"]},
+						{
+							"prim": "list",
+							"annots": ["%update_operators"],
+							"args": [{
+								"prim": "or",
+								"args": [
+									{
+										"prim": "pair",
+										"annots": ["%add_operator"],
+										"args": [
+											{"prim": "address", "annots": ["%owner"]},
+											{"prim": "pair", "args": [
+												{"prim": "address", "annots": ["%operator"]},
+												{"prim": "nat", "annots": ["%token_id"]}
+											]}
+										]
+									},
+									{
+										"prim": "pair",
+										"annots": ["%remove_operator"],
+										"args": [
+											{"prim": "address", "annots": ["%owner"]},
+											{"prim": "pair", "args": [
+												{"prim": "address", "annots": ["%operator"]},
+												{"prim": "nat", "annots": ["%token_id"]}
+											]}
+										]
+									}
+								]
+							}]
+						}
+					]
+				}
+			]
+		}
+	]
+}`
+
+func generateTestFFI(t *testing.T, parameter string) (*fftypes.FFI, error) {
+	var prim micheline.Prim
+	err := json.Unmarshal([]byte(parameter), &prim)
+	assert.NoError(t, err)
+	return convertMichelsonToFFI(context.Background(), &fftypes.FFIGenerationRequest{
+		Namespace: "ns1",
+		Name:      "fa2",
+		Version:   "v1.0.0",
+	}, prim)
+}
+
+func findTestMethod(ffi *fftypes.FFI, name string) *fftypes.FFIMethod {
+	for _, m := range ffi.Methods {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+func TestConvertMichelsonToFFI(t *testing.T) {
+	ffi, err := generateTestFFI(t, fa2Parameter)
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1", ffi.Namespace)
+	assert.Equal(t, "fa2", ffi.Name)
+
+	names := make([]string, len(ffi.Methods))
+	for i, m := range ffi.Methods {
+		names[i] = m.Name
+	}
+	assert.Equal(t, []string{"burn", "destroy", "mint", "set", "update_operators"}, names)
+
+	burn := findTestMethod(ffi, "burn")
+	assert.Len(t, burn.Params, 1)
+	assert.Equal(t, "arg0", burn.Params[0].Name)
+	assert.JSONEq(t, `{"type":"array","details":{"type":"nat","internalType":"nat"}}`, burn.Params[0].Schema.String())
+
+	assert.Empty(t, findTestMethod(ffi, "destroy").Params)
+
+	set := findTestMethod(ffi, "set")
+	assert.Equal(t, "new_value", set.Params[0].Name)
+	assert.JSONEq(t, `{"type":"integer","details":{"type":"integer","internalType":"integer","kind":"option"}}`, set.Params[0].Schema.String())
+
+	mint := findTestMethod(ffi, "mint")
+	assert.Len(t, mint.Params, 2)
+	assert.Equal(t, "owner", mint.Params[0].Name)
+	assert.JSONEq(t, `{"type":"string","details":{"type":"address","internalType":"address"}}`, mint.Params[0].Schema.String())
+	assert.Equal(t, "requests", mint.Params[1].Name)
+	assert.JSONEq(t, `{
+		"type": "array",
+		"details": {
+			"type": "schema",
+			"internalSchema": {
+				"type": "struct",
+				"args": [
+					{"name": "metadata", "type": "bytes"},
+					{"name": "token_id", "type": "nat"}
+				]
+			}
+		}
+	}`, mint.Params[1].Schema.String())
+
+	updateOperators := findTestMethod(ffi, "update_operators")
+	assert.JSONEq(t, `{
+		"type": "array",
+		"details": {
+			"type": "schema",
+			"internalSchema": {
+				"type": "variant",
+				"variants": ["add_operator", "remove_operator"],
+				"args": [{
+					"type": "struct",
+					"args": [
+						{"name": "owner", "type": "address"},
+						{"name": "operator", "type": "address"},
+						{"name": "token_id", "type": "nat"}
+					]
+				}]
+			}
+		}
+	}`, updateOperators.Params[0].Schema.String())
+}
+
+func TestConvertMichelsonToFFIInvoke(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+
+	ffi, err := generateTestFFI(t, fa2Parameter)
+	assert.NoError(t, err)
+
+	parsedMethod, err := tz.ParseInterface(context.Background(), findTestMethod(ffi, "mint"), nil)
+	assert.NoError(t, err)
+	methodName, params, err := tz.prepareRequest(context.Background(), parsedMethod, map[string]interface{}{
+		"owner": "tz1Y6GnVhC4EpcDDSmD3ibcC4WX6DJ4Q1QLN",
+		"requests": []interface{}{
+			map[string]interface{}{"metadata": "0x01", "token_id": float64(1)},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "mint", methodName)
+	assert.Equal(t, micheline.PrimSequence, params.Value.Type)
+	assert.Len(t, params.Value.Args, 2)
+
+	parsedMethod, err = tz.ParseInterface(context.Background(), findTestMethod(ffi, "update_operators"), nil)
+	assert.NoError(t, err)
+	_, params, err = tz.prepareRequest(context.Background(), parsedMethod, map[string]interface{}{
+		"arg0": []interface{}{
+			map[string]interface{}{
+				"remove_operator": map[string]interface{}{
+					"owner":    "tz1Y6GnVhC4EpcDDSmD3ibcC4WX6DJ4Q1QLN",
+					"operator": "tz1Y6GnVhC4EpcDDSmD3ibcC4WX6DJ4Q1QLN",
+					"token_id": float64(1),
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, micheline.D_RIGHT, params.Value.Args[0].OpCode)
+
+	parsedMethod, err = tz.ParseInterface(context.Background(), findTestMethod(ffi, "set"), nil)
+	assert.NoError(t, err)
+	_, params, err = tz.prepareRequest(context.Background(), parsedMethod, map[string]interface{}{
+		"new_value": float64(-5),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, micheline.D_SOME, params.Value.OpCode)
+}
+
+func TestConvertMichelsonToFFIDefaultEntrypoint(t *testing.T) {
+	ffi, err := generateTestFFI(t, `{
+		"prim": "parameter",
+		"args": [{
+			"prim": "pair",
+			"args": [
+				{"prim": "map", "args": [{"prim": "string"}, {"prim": "mutez"}]},
+				{"prim": "pair", "args": [
+					{"prim": "bool"},
+					{"prim": "set", "args": [{"prim": "pair", "args": [{"prim": "int"}, {"prim": "contract", "args": [{"prim": "unit"}]}]}]},
+					{"prim": "pair", "annots": ["%nested"], "args": [{"prim": "nat"}, {"prim": "nat"}]}
+				]}
+			]
+		}]
+	}`)
+	assert.NoError(t, err)
+	assert.Len(t, ffi.Methods, 1)
+	method := ffi.Methods[0]
+	assert.Equal(t, "default", method.Name)
+	assert.Len(t, method.Params, 4)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"details": {
+			"type": "schema",
+			"internalSchema": {
+				"type": "map",
+				"args": [
+					{"name": "key", "type": "string"},
+					{"name": "value", "type": "nat"}
+				]
+			}
+		}
+	}`, method.Params[0].Schema.String())
+	assert.Equal(t, "arg1", method.Params[1].Name)
+	assert.JSONEq(t, `{"type":"boolean","details":{"type":"boolean","internalType":"boolean"}}`, method.Params[1].Schema.String())
+	assert.JSONEq(t, `{
+		"type": "array",
+		"details": {
+			"type": "schema",
+			"internalSchema": {
+				"type": "struct",
+				"args": [
+					{"name": "arg0", "type": "integer"},
+					{"name": "arg1", "type": "address"}
+				]
+			}
+		}
+	}`, method.Params[2].Schema.String())
+	assert.Equal(t, "nested", method.Params[3].Name)
+}
+
+func TestConvertMichelsonToFFIVariants(t *testing.T) {
+	ffi, err := generateTestFFI(t, `{
+		"prim": "list",
+		"args": [{
+			"prim": "or",
+			"args": [
+				{"prim": "or", "args": [{"prim": "string", "annots": ["%a"]}, {"prim": "string", "annots": ["%b"]}]},
+				{"prim": "or", "args": [{"prim": "string", "annots": ["%c"]}, {"prim": "string", "annots": ["%d"]}]}
+			]
+		}]
+	}`)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "array",
+		"details": {
+			"type": "schema",
+			"internalSchema": {
+				"type": "variant",
+				"variants": ["a", "b", "c", "d"],
+				"args": [{"type": "string"}]
+			}
+		}
+	}`, ffi.Methods[0].Params[0].Schema.String())
+
+	ffi, err = generateTestFFI(t, `{
+		"prim": "pair",
+		"args": [
+			{"prim": "list", "args": [{"prim": "list", "args": [{"prim": "nat"}]}]},
+			{"prim": "or", "annots": ["%choice"], "args": [
+				{"prim": "nat", "annots": ["%a"]},
+				{"prim": "or", "args": [{"prim": "nat", "annots": ["%b"]}, {"prim": "nat", "annots": ["%c"]}]}
+			]}
+		]
+	}`)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "array",
+		"details": {
+			"type": "schema",
+			"internalSchema": {"type": "list", "args": [{"type": "nat"}]}
+		}
+	}`, ffi.Methods[0].Params[0].Schema.String())
+	assert.JSONEq(t, `{
+		"type": "object",
+		"details": {
+			"type": "schema",
+			"internalSchema": {"type": "variant", "variants": ["a", "b", "c"], "args": [{"type": "nat"}]}
+		}
+	}`, ffi.Methods[0].Params[1].Schema.String())
+}
+
+func TestConvertMichelsonToFFIErrors(t *testing.T) {
+	for name, parameter := range map[string]string{
+		"unsupported type":   `{"prim": "timestamp", "annots": ["%a"]}`,
+		"unsupported option": `{"prim": "option", "args": [{"prim": "pair", "args": [{"prim": "nat"}, {"prim": "nat"}]}]}`,
+		"unsupported list":   `{"prim": "list", "args": [{"prim": "key"}]}`,
+		"unsupported nested": `{"prim": "list", "args": [{"prim": "list", "args": [{"prim": "key"}]}]}`,
+		"unsupported key":    `{"prim": "map", "args": [{"prim": "key"}, {"prim": "nat"}]}`,
+		"unsupported value":  `{"prim": "map", "args": [{"prim": "nat"}, {"prim": "key"}]}`,
+		"unsupported field":  `{"prim": "pair", "args": [{"prim": "nat"}, {"prim": "pair", "annots": ["%p"], "args": [{"prim": "nat"}, {"prim": "key"}]}]}`,
+		"unnamed variant":    `{"prim": "list", "args": [{"prim": "or", "args": [{"prim": "nat"}, {"prim": "nat"}]}]}`,
+		"mixed variant":      `{"prim": "list", "args": [{"prim": "or", "args": [{"prim": "nat", "annots": ["%a"]}, {"prim": "int", "annots": ["%b"]}]}]}`,
+		"bad variant":        `{"prim": "list", "args": [{"prim": "or", "args": [{"prim": "key", "annots": ["%a"]}, {"prim": "key", "annots": ["%b"]}]}]}`,
+		"left variant":       `{"prim": "list", "args": [{"prim": "or", "args": [{"prim": "or", "args": [{"prim": "nat", "annots": ["%a"]}, {"prim": "nat", "annots": ["%b"]}]}, {"prim": "nat", "annots": ["%c"]}]}]}`,
+		"deep variant":       `{"prim": "list", "args": [{"prim": "or", "args": [{"prim": "nat", "annots": ["%a"]}, {"prim": "or", "args": [{"prim": "nat", "annots": ["%b"]}, {"prim": "or", "args": [{"prim": "nat", "annots": ["%c"]}, {"prim": "nat", "annots": ["%d"]}]}]}]}]}`,
+	} {
+		_, err := generateTestFFI(t, parameter)
+		assert.Regexp(t, "FF10346", err, name)
+	}
+
+	_, err := generateTestFFI(t, `{"prim": "or", "args": [{"prim": "nat"}]}`)
+	assert.Regexp(t, "FF10346.*invalid parameter type", err)
+
+	_, err = generateTestFFI(t, `{"prim": "parameter", "args": [{}]}`)
+	assert.Regexp(t, "FF10346", err)
+}
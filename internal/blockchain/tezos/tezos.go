@@ -538,7 +538,22 @@ func (t *Tezos) GenerateErrorSignature(ctx context.Context, event *fftypes.FFIEr
 }
 
 func (t *Tezos) GenerateFFI(ctx context.Context, generationRequest *fftypes.FFIGenerationRequest) (*fftypes.FFI, error) {
-	return nil, i18n.NewError(ctx, coremsgs.MsgFFIGenerationUnsupported)
+	var input FFIGenerationInput
+	err := json.Unmarshal(generationRequest.Input.Bytes(), &input)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgFFIGenerationFailed, "unable to deserialize JSON as Michelson")
+	}
+	if input.Parameter == nil {
+		for i := range input.Code {
+			if input.Code[i].OpCode == micheline.K_PARAMETER {
+				input.Parameter = &input.Code[i]
+			}
+		}
+	}
+	if input.Parameter == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgFFIGenerationFailed, "parameter type is empty")
+	}
+	return convertMichelsonToFFI(ctx, generationRequest, *input.Parameter)
 }
 
 func (t *Tezos) GetNetworkVersion(ctx context.Context, location *fftypes.JSONAny) (version int, err error) {
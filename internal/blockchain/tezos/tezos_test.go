@@ -1549,13 +1549,55 @@ func TestGenerateFFI(t *testing.T) {
 	tz, cancel := newTestTezos()
 	defer cancel()
 
-	_, err := tz.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+	ffi, err := tz.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
 		Name:        "Simple",
 		Version:     "v0.0.1",
 		Description: "desc",
-		Input:       fftypes.JSONAnyPtr(`[]`),
+		Input:       fftypes.JSONAnyPtr(`{"parameter": {"prim": "int", "annots": ["%set"]}}`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Simple", ffi.Name)
+	assert.Equal(t, "set", ffi.Methods[0].Name)
+}
+
+func TestGenerateFFIFromCode(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+
+	ffi, err := tz.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+		Name:    "Simple",
+		Version: "v0.0.1",
+		Input: fftypes.JSONAnyPtr(`{"code": [
+			{"prim": "parameter", "args": [{"prim": "or", "args": [{"prim": "int", "annots": ["%set"]}, {"prim": "unit", "annots": ["%get"]}]}]},
+			{"prim": "storage", "args": [{"prim": "int"}]}
+		]}`),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, ffi.Methods, 2)
+}
+
+func TestGenerateFFIEmpty(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+
+	_, err := tz.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+		Name:    "Simple",
+		Version: "v0.0.1",
+		Input:   fftypes.JSONAnyPtr(`{"code": []}`),
+	})
+	assert.Regexp(t, "FF10346.*parameter type is empty", err)
+}
+
+func TestGenerateFFIBadJSON(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+
+	_, err := tz.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+		Name:    "Simple",
+		Version: "v0.0.1",
+		Input:   fftypes.JSONAnyPtr(`[]`),
 	})
-	assert.Regexp(t, "FF10347", err)
+	assert.Regexp(t, "FF10346", err)
 }
 
 func TestConvertDeprecatedContractConfigNoChaincode(t *testing.T) {
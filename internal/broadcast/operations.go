@@ -171,11 +171,11 @@ func (bm *broadcastManager) uploadBatch(ctx context.Context, data uploadBatchDat
 	return getUploadBatchOutputs(payloadRef), core.OpPhaseComplete, nil
 }
 
-// uploadBlob streams a blob from the local data exchange, to public storage
+// uploadBlob streams a blob from the local data exchange or blob storage, to public storage
 func (bm *broadcastManager) uploadBlob(ctx context.Context, data uploadBlobData) (outputs fftypes.JSONObject, phase core.OpPhase, err error) {
 
-	// Stream from the local data exchange ...
-	reader, err := bm.exchange.DownloadBlob(ctx, data.Blob.PayloadRef)
+	// Stream from the local data exchange or blob storage ...
+	reader, err := bm.data.OpenBlob(ctx, data.Blob.PayloadRef)
 	if err != nil {
 		return nil, core.OpPhaseInitializing, i18n.WrapError(ctx, err, coremsgs.MsgDownloadBlobFailed, data.Blob.PayloadRef)
 	}
@@ -258,7 +258,8 @@ func TestPrepareAndRunUploadBlob(t *testing.T) {
 	mdi.On("GetDataByID", mock.Anything, "ns1", data.ID, false).Return(data, nil)
 	mdi.On("GetBlobs", mock.Anything, bm.namespace.Name, mock.Anything).Return([]*core.Blob{blob}, nil, nil)
 	mps.On("UploadData", context.Background(), mock.Anything).Return("123", nil)
-	mdx.On("DownloadBlob", context.Background(), mock.Anything).Return(reader, nil)
+	mdm := bm.data.(*datamocks.Manager)
+	mdm.On("OpenBlob", context.Background(), mock.Anything).Return(reader, nil)
 	mdi.On("UpdateData", context.Background(), "ns1", data.ID, mock.MatchedBy(func(update ffapi.Update) bool {
 		info, _ := update.Finalize()
 		assert.Equal(t, 1, len(info.SetOperations))
@@ -523,7 +524,8 @@ func TestRunOperationUploadBlobUpdateFail(t *testing.T) {
 	mdi := bm.database.(*databasemocks.Plugin)
 
 	reader := ioutil.NopCloser(strings.NewReader("some data"))
-	mdx.On("DownloadBlob", context.Background(), mock.Anything).Return(reader, nil)
+	mdm := bm.data.(*datamocks.Manager)
+	mdm.On("OpenBlob", context.Background(), mock.Anything).Return(reader, nil)
 	mps.On("UploadData", context.Background(), mock.Anything).Return("123", nil)
 	mdi.On("UpdateData", context.Background(), "ns1", data.ID, mock.Anything).Return(fmt.Errorf("pop"))
 
@@ -581,7 +583,8 @@ func TestRunOperationUploadBlobUploadFail(t *testing.T) {
 	mdx := bm.exchange.(*dataexchangemocks.Plugin)
 
 	reader := ioutil.NopCloser(strings.NewReader("some data"))
-	mdx.On("DownloadBlob", context.Background(), mock.Anything).Return(reader, nil)
+	mdm := bm.data.(*datamocks.Manager)
+	mdm.On("OpenBlob", context.Background(), mock.Anything).Return(reader, nil)
 	mps.On("UploadData", context.Background(), mock.Anything).Return("", fmt.Errorf("pop"))
 
 	_, phase, err := bm.RunOperation(context.Background(), opUploadBlob(op, data, blob))
@@ -633,7 +636,8 @@ func TestRunOperationUploadBlobDownloadFail(t *testing.T) {
 	mdx := bm.exchange.(*dataexchangemocks.Plugin)
 
 	reader := ioutil.NopCloser(strings.NewReader("some data"))
-	mdx.On("DownloadBlob", context.Background(), mock.Anything).Return(reader, fmt.Errorf("pop"))
+	mdm := bm.data.(*datamocks.Manager)
+	mdm.On("OpenBlob", context.Background(), mock.Anything).Return(reader, fmt.Errorf("pop"))
 
 	_, phase, err := bm.RunOperation(context.Background(), opUploadBlob(op, data, blob))
 
@@ -43,6 +43,12 @@ const (
 	NamespaceTLSConfigs = "tlsConfigs"
 	// NamespaceTLSConfigTLSSection is the section to provide the paths to CA , cert and key files
 	NamespaceTLSConfigTLSSection = "tls"
+	// NamespaceSigningSecrets is the list of named secrets used to sign webhook deliveries
+	NamespaceSigningSecrets = "signingSecrets"
+	// NamespaceSigningSecretName is the user-supplied name for the signing secret
+	NamespaceSigningSecretName = "name"
+	// NamespaceSigningSecretValue is the shared secret used as the HMAC key
+	NamespaceSigningSecretValue = "secret"
 	// NamespaceDefaultKey is the default signing key for blockchain transactions within this namespace
 	NamespaceDefaultKey = "defaultKey"
 	// NamespaceAssetKeyNormalization mechanism to normalize keys before using them. Valid options: "blockchain_plugin" - use blockchain plugin (default), "none" - do not attempt normalization
@@ -71,6 +77,20 @@ const (
 	NamespaceMultipartyContractLocation = "location"
 	// NamespaceMultipartyContractOptions is an object of additional blockchain-specific configuration
 	NamespaceMultipartyContractOptions = "options"
+	// NamespaceRetention contains the data retention policy for a namespace
+	NamespaceRetention = "retention"
+	// NamespaceRetentionMaxAge is the age after which records are archived and removed
+	NamespaceRetentionMaxAge = "maxAge"
+	// NamespaceRetentionMaxEvents is the maximum number of events to keep
+	NamespaceRetentionMaxEvents = "maxEvents"
+	// NamespaceRetentionInterval is how often the retention policy runs
+	NamespaceRetentionInterval = "interval"
+	// NamespaceRetentionBatchSize is the number of records archived and removed in each database transaction
+	NamespaceRetentionBatchSize = "batchSize"
+	// NamespaceRetentionCollections is the list of collections the retention policy applies to
+	NamespaceRetentionCollections = "collections"
+	// NamespaceRetentionArchivePath is the local directory archives are written to
+	NamespaceRetentionArchivePath = "archive.path"
 )
 
 // The following keys can be access from the root configuration.
@@ -80,6 +100,8 @@ var (
 	APIDefaultFilterLimit = ffc("api.defaultFilterLimit")
 	// APIMaxFilterLimit is the maximum limit that can be specified by an API call
 	APIMaxFilterLimit = ffc("api.maxFilterLimit")
+	// APIExportPageSize is the number of records read from the database at a time by an export
+	APIExportPageSize = ffc("api.exportPageSize")
 	// APIMaxFilterSkip is the maximum skip value that can be specified on the API
 	APIMaxFilterSkip = ffc("api.maxFilterLimit")
 	// APIRequestTimeout is the server side timeout for API calls (context timeout), to avoid the server continuing processing when the client gives up
@@ -151,6 +173,10 @@ var (
 	CacheEventListenerTopicLimit = ffc("cache.eventlistenertopic.limit")
 	CacheEventListenerTopicTTL   = ffc("cache.eventlistenertopic.ttl")
 
+	// ExpressionDocument cache config
+	CacheExpressionDocumentLimit = ffc("cache.expressiondocument.limit")
+	CacheExpressionDocumentTTL   = ffc("cache.expressiondocument.ttl")
+
 	// Group cache config
 	CacheGroupLimit = ffc("cache.group.limit")
 	CacheGroupTTL   = ffc("cache.group.ttl")
@@ -221,6 +247,8 @@ var (
 	PluginsBlockchainList = ffc("plugins.blockchain")
 	// PluginsSharedStorageList is the key containing a list of configured shared storage plugins
 	PluginsSharedStorageList = ffc("plugins.sharedstorage")
+	// PluginsBlobStorageList is the key containing a list of configured blob storage plugins
+	PluginsBlobStorageList = ffc("plugins.blobstorage")
 	// PluginsDatabaseList is the key containing a list of configured database plugins
 	PluginsDatabaseList = ffc("plugins.database")
 	// PluginsDataExchangeList is the key containing a list of configured database plugins
@@ -243,6 +271,8 @@ var (
 	EventAggregatorBatchTimeout = ffc("event.aggregator.batchTimeout")
 	// EventAggregatorPollTimeout the time to wait without a notification of new events, before trying a select on the table
 	EventAggregatorPollTimeout = ffc("event.aggregator.pollTimeout")
+	// EventAggregatorChangeFeedPollTimeout replaces the poll timeout when the database notifies of events inserted by other processes
+	EventAggregatorChangeFeedPollTimeout = ffc("event.aggregator.changeFeedPollTimeout")
 	// EventAggregatorRewindTimeout the minimum time to wait for rewinds to accumulate before resolving them
 	EventAggregatorRewindTimeout = ffc("event.aggregator.rewindTimeout")
 	// EventAggregatorRewindQueueLength the size of the queue into the rewind dispatcher
@@ -257,6 +287,8 @@ var (
 	EventAggregatorRetryMaxDelay = ffc("event.aggregator.retry.maxDelay")
 	// EventDispatcherPollTimeout the time to wait without a notification of new events, before trying a select on the table
 	EventDispatcherPollTimeout = ffc("event.dispatcher.pollTimeout")
+	// EventDispatcherChangeFeedPollTimeout replaces the poll timeout when the database notifies of events inserted by other processes
+	EventDispatcherChangeFeedPollTimeout = ffc("event.dispatcher.changeFeedPollTimeout")
 	// EventDispatcherBufferLength the number of events + attachments an individual dispatcher should hold in memory ready for delivery to the subscription
 	EventDispatcherBufferLength = ffc("event.dispatcher.bufferLength")
 	// EventDispatcherBatchTimeout a short time to wait for new events to arrive before re-polling for new events
@@ -366,6 +398,7 @@ func setDefaults() {
 	viper.SetDefault(string(APIRequestTimeout), "120s")
 	viper.SetDefault(string(APIRequestMaxTimeout), "10m")
 	viper.SetDefault(string(APIMaxFilterLimit), 250)
+	viper.SetDefault(string(APIExportPageSize), 1000)
 	viper.SetDefault(string(APIMaxFilterSkip), 1000) // protects database (skip+limit pagination is not for bulk operations)
 	viper.SetDefault(string(APIRequestTimeout), "120s")
 	viper.SetDefault(string(APIPassthroughHeaders), []string{})
@@ -414,6 +447,7 @@ func setDefaults() {
 	viper.SetDefault(string(EventAggregatorBatchSize), 200)
 	viper.SetDefault(string(EventAggregatorBatchTimeout), "0ms")
 	viper.SetDefault(string(EventAggregatorPollTimeout), "30s")
+	viper.SetDefault(string(EventAggregatorChangeFeedPollTimeout), "5m")
 	viper.SetDefault(string(EventAggregatorRewindTimeout), "50ms")
 	viper.SetDefault(string(EventAggregatorRewindQueueLength), 10)
 	viper.SetDefault(string(EventAggregatorRewindQueryLimit), 1000)
@@ -424,10 +458,13 @@ func setDefaults() {
 	viper.SetDefault(string(EventDispatcherBufferLength), 5)
 	viper.SetDefault(string(EventDispatcherBatchTimeout), "0ms")
 	viper.SetDefault(string(EventDispatcherPollTimeout), "30s")
+	viper.SetDefault(string(EventDispatcherChangeFeedPollTimeout), "5m")
 	viper.SetDefault(string(EventTransportsEnabled), []string{"websockets", "webhooks"})
 	viper.SetDefault(string(EventTransportsDefault), "websockets")
 	viper.SetDefault(string(CacheEventListenerTopicLimit), 100)
 	viper.SetDefault(string(CacheEventListenerTopicTTL), "5m")
+	viper.SetDefault(string(CacheExpressionDocumentLimit), 1000)
+	viper.SetDefault(string(CacheExpressionDocumentTTL), "1m")
 	viper.SetDefault(string(CacheGroupLimit), 50)
 	viper.SetDefault(string(CacheGroupTTL), "1h")
 	viper.SetDefault(string(SPIEnabled), false)
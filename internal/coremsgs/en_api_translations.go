@@ -28,6 +28,7 @@ var (
 	APIParamsContractListenerNameOrID       = ffm("api.params.contractListenerNameOrID", "The contract listener name or ID")
 	APIParamsContractListenerID             = ffm("api.params.contractListenerID", "The contract listener ID")
 	APIParamsSubscriptionID                 = ffm("api.params.subscriptionID", "The subscription ID")
+	APIParamsDeadLetterID                   = ffm("api.params.deadLetterID", "The dead letter ID")
 	APIParamsBatchID                        = ffm("api.params.batchId", "The batch ID")
 	APIParamsBlockchainEventID              = ffm("api.params.blockchainEventID", "The blockchain event ID")
 	APIParamsCollectionID                   = ffm("api.params.collectionID", "The collection ID")
@@ -80,6 +81,7 @@ var (
 	APIEndpointsDeleteContractInterface         = ffm("api.endpoints.deleteContractInterface", "Delete a contract interface")
 	APIEndpointsDeleteContractListener          = ffm("api.endpoints.deleteContractListener", "Deletes a contract listener referenced by its name or its ID")
 	APIEndpointsDeleteSubscription              = ffm("api.endpoints.deleteSubscription", "Deletes a subscription")
+	APIEndpointsDeleteSubscriptionDeadLetter    = ffm("api.endpoints.deleteSubscriptionDeadLetter", "Discards a dead letter of a subscription, without delivering the event")
 	APIEndpointsDeleteTokenPool                 = ffm("api.endpoints.deleteTokenPool", "Delete a token pool")
 	APIEndpointsGetBatchBbyID                   = ffm("api.endpoints.getBatchByID", "Gets a message batch")
 	APIEndpointsGetBatches                      = ffm("api.endpoints.getBatches", "Gets a list of message batches")
@@ -115,6 +117,8 @@ var (
 	APIEndpointsGetMsgEvents                    = ffm("api.endpoints.getMsgEvents", "Gets the list of events for a message")
 	APIEndpointsGetMsgTxn                       = ffm("api.endpoints.getMsgTxn", "Gets the transaction for a message")
 	APIEndpointsGetMsgs                         = ffm("api.endpoints.getMsgs", "Gets a list of messages")
+	APIEndpointsExportMsgs                      = ffm("api.endpoints.exportMsgs", "Streams every message that matches the filter as newline-delimited JSON")
+	APIEndpointsExportEvents                    = ffm("api.endpoints.exportEvents", "Streams every event that matches the filter as newline-delimited JSON")
 	APIEndpointsGetNamespace                    = ffm("api.endpoints.getNamespace", "Gets a namespace")
 	APIEndpointsGetNamespaces                   = ffm("api.endpoints.getNamespaces", "Gets a list of namespaces")
 	APIEndpointsGetNetworkIdentityByDID         = ffm("api.endpoints.getNetworkIdentityByDID", "Gets an identity by its DID (deprecated - use /identities/{did} instead of /network/identities/{did})")
@@ -127,6 +131,7 @@ var (
 	APIEndpointsGetNetworkOrgs                  = ffm("api.endpoints.APIEndpointsGetNetworkOrgs", "Gets a list of orgs in the network")
 	APIEndpointsGetOpByID                       = ffm("api.endpoints.getOpByID", "Gets an operation by ID")
 	APIEndpointsGetOps                          = ffm("api.endpoints.getOps", "Gets a a list of operations")
+	APIEndpointsGetSearch                       = ffm("api.endpoints.getSearch", "Searches the tags, topics and authors of messages, and the JSON values of data, returning references to the best matches first")
 	APIEndpointsGetStatusBatchManager           = ffm("api.endpoints.getStatusBatchManager", "Gets the status of the batch manager")
 	APIEndpointsGetPins                         = ffm("api.endpoints.getPins", "Queries the list of pins received from the blockchain")
 	APIEndpointsGetNextPins                     = ffm("api.endpoints.getNextPins", "Queries the list of next-pins that determine the next masked message sequence for each member of a privacy group, on each context/topic")
@@ -134,6 +139,7 @@ var (
 	APIEndpointsGetStatus                       = ffm("api.endpoints.getStatus", "Gets the status of this namespace")
 	APIEndpointsGetMultipartyStatus             = ffm("api.endpoints.getMultipartyStatus", "Gets the registration status of this organization and node on the configured multiparty network")
 	APIEndpointsGetSubscriptionByID             = ffm("api.endpoints.getSubscriptionByID", "Gets a subscription by its ID")
+	APIEndpointsGetSubscriptionDeadLetters      = ffm("api.endpoints.getSubscriptionDeadLetters", "Gets a list of the events that a subscription failed to deliver, after all retries")
 	APIEndpointsGetSubscriptionEventsFiltered   = ffm("api.endpoints.getSubscriptionEventsFiltered", "Gets a collection of events filtered by the subscription for further filtering")
 	APIEndpointsGetSubscriptions                = ffm("api.endpoints.getSubscriptions", "Gets a list of subscriptions")
 	APIEndpointsGetTokenAccountPools            = ffm("api.endpoints.getTokenAccountPools", "Gets a list of token pools that contain a given token account key")
@@ -183,6 +189,10 @@ var (
 	APIEndpointsPostNewSubscription             = ffm("api.endpoints.postNewSubscription", "Creates a new subscription for an application to receive events from FireFly")
 	APIEndpointsPostOpRetry                     = ffm("api.endpoints.postOpRetry", "Retries a failed operation")
 	APIEndpointsPostPinsRewind                  = ffm("api.endpoints.postPinsRewind", "Force a rewind of the event aggregator to a previous position, to re-evaluate (and possibly dispatch) that pin and others after it. Only accepts a sequence or batch ID for a currently undispatched pin")
+	APIEndpointsPostSubscriptionPause           = ffm("api.endpoints.postSubscriptionPause", "Pauses a durable subscription, so no further events are delivered until it is resumed")
+	APIEndpointsPostSubscriptionResume          = ffm("api.endpoints.postSubscriptionResume", "Resumes delivery of events on a paused subscription, from its current offset")
+	APIEndpointsPostSubscriptionRewind          = ffm("api.endpoints.postSubscriptionRewind", "Moves the offset of a durable subscription back to a sequence, a time, or the oldest event, so those events are redelivered")
+	APIEndpointsPostDeadLetterReplay            = ffm("api.endpoints.postSubscriptionDeadLetterReplay", "Redelivers a dead letter to its subscription, and removes it once the event is acknowledged")
 	APIEndpointsPostTokenApproval               = ffm("api.endpoints.postTokenApproval", "Creates a token approval")
 	APIEndpointsPostTokenBurn                   = ffm("api.endpoints.postTokenBurn", "Burns some tokens")
 	APIEndpointsPostTokenMint                   = ffm("api.endpoints.postTokenMint", "Mints some tokens")
@@ -209,6 +219,10 @@ var (
 	APIHistogramStartTimeParam = ffm("api.histogramStartTime", "Start time of the data to be fetched")
 	APIHistogramEndTimeParam   = ffm("api.histogramEndTime", "End time of the data to be fetched")
 	APIHistogramBucketsParam   = ffm("api.histogramBuckets", "Number of buckets between start time and end time")
+	APISearchTextParam         = ffm("api.searchText", "The words to search for")
+	APISearchLimitParam        = ffm("api.searchLimit", "The maximum number of results to return")
+	APIExportGzipParam         = ffm("api.exportGzip", "Compress the response with gzip")
+	APICursorAfterParam        = ffm("api.cursorAfter", "Continue from the end of a previous page of results, using the cursor returned in its x-ff-cursor response header. Requires the results to be sorted by sequence")
 
 	APISmartContractDetails      = ffm("api.smartContractDetails", "Additional smart contract details")
 	APISmartContractDetailsKey   = ffm("api.smartContractDetailsKey", "Key")
@@ -321,4 +321,78 @@ var (
 	MsgInvalidIdentityPatch                    = ffe("FF10480", "A profile must be provided when updating an identity", 400)
 	MsgNodeNotProvidedForCheck                 = ffe("FF10481", "Node not provided for check", 500)
 	MsgNodeMissingProfile                      = ffe("FF10482", "Node provided for check does not have a profile", 500)
+	MsgKafkaConnectFailed                      = ffe("FF10483", "Failed to connect to Kafka brokers %s")
+	MsgKafkaProduceFailed                      = ffe("FF10484", "Failed to produce record to Kafka topic '%s'")
+	MsgKafkaMissingTopic                       = ffe("FF10485", "Kafka subscription option 'topic' must be set", 400)
+	MsgKafkaInvalidKeyOption                   = ffe("FF10486", "Kafka subscription option 'key' must be one of 'topic', 'correlator' or 'none': %s", 400)
+	MsgAMQPConnectFailed                       = ffe("FF10487", "Failed to connect to AMQP broker")
+	MsgAMQPPublishFailed                       = ffe("FF10488", "Failed to publish to AMQP exchange '%s' with routing key '%s'")
+	MsgAMQPConfirmFailed                       = ffe("FF10489", "Failed waiting for AMQP broker to confirm event '%s'")
+	MsgAMQPNackReceived                        = ffe("FF10490", "AMQP broker rejected the message")
+	MsgAMQPMissingDestination                  = ffe("FF10491", "AMQP subscription must set either the 'exchange' or 'queue' option", 400)
+	MsgAMQPConflictingDestination              = ffe("FF10492", "AMQP subscription cannot set both the 'exchange' and 'queue' options", 400)
+	MsgAMQPRoutingKeyWithQueue                 = ffe("FF10493", "AMQP subscription option 'routingKey' cannot be used with 'queue'", 400)
+	MsgSSEStreamingNotSupported                = ffe("FF10494", "Server-sent events are not supported by the HTTP response writer", 500)
+	MsgSSEInvalidStart                         = ffe("FF10495", "A server-sent events request must set either a subscription name or ephemeral=true", 400)
+	MsgSSEConnectionNotActive                  = ffe("FF10496", "Server-sent events connection '%s' is not active", 404)
+	MsgSSEInvalidLastEventID                   = ffe("FF10497", "Invalid Last-Event-ID '%s' - must be an event sequence", 400)
+	MsgSSEClosed                               = ffe("FF10498", "Server-sent events connection closed")
+	MsgDuplicateSigningSecret                  = ffe("FF10499", "Found duplicate signing secret '%s'", 400)
+	MsgMissingSigningSecret                    = ffe("FF10500", "Signing secret '%s' must have a non-empty secret", 400)
+	MsgNotFoundSigningSecret                   = ffe("FF10501", "Provided signing secret name '%s' not found for namespace '%s'", 400)
+	MsgWebhookSignatureMissing                 = ffe("FF10502", "Webhook request is missing the '%s' header", 401)
+	MsgWebhookSignatureInvalid                 = ffe("FF10503", "Webhook request signature is invalid", 401)
+	MsgWebhookTimestampInvalid                 = ffe("FF10504", "Webhook request timestamp '%s' is invalid, or outside the tolerance of %s", 401)
+	MsgWebhookFailedStatus                     = ffe("FF10505", "Webhook returned HTTP status %d")
+	MsgInvalidDeadLetterPolicy                 = ffe("FF10506", "Invalid dead letter policy '%s'", 400)
+	MsgDeadLetterFastAckNotSupported           = ffe("FF10507", "The dead letter policy '%s' cannot be used with fastack, as events are acknowledged before delivery", 400)
+	MsgDeadLetterNotFound                      = ffe("FF10508", "Dead letter '%s' not found for subscription", 404)
+	MsgDeadLetterReplayFailed                  = ffe("FF10509", "Replay of dead letter '%s' failed: %s", 502)
+	MsgSubscriptionNotActive                   = ffe("FF10510", "Subscription '%s' is not active on this node", 409)
+	MsgExpressionCompileFailed                 = ffe("FF10511", "Unable to compile '%s' expression '%s'", 400)
+	MsgWebhookTemplateInvalid                  = ffe("FF10512", "Webhook subscription template '%s' is invalid: %s", 400)
+	MsgWebhookTemplateFailed                   = ffe("FF10513", "Webhook subscription template '%s' failed: %s")
+	MsgSubscriptionRewindInvalid               = ffe("FF10514", "Exactly one of 'sequence', 'timestamp' or 'oldest' must be specified to rewind a subscription", 400)
+	MsgMQTTConnectFailed                       = ffe("FF10515", "Failed to connect to MQTT broker '%s'")
+	MsgMQTTPublishFailed                       = ffe("FF10516", "Failed to publish to MQTT topic '%s'")
+	MsgMQTTNackReceived                        = ffe("FF10517", "MQTT broker rejected the message with reason code 0x%x")
+	MsgMQTTInvalidTopicTemplate                = ffe("FF10518", "MQTT subscription option 'topicTemplate' cannot contain the wildcards '+' or '#': %s", 400)
+	MsgMQTTReplyTopicNotConfigured             = ffe("FF10519", "MQTT subscription option 'reply' requires a replyTopic to be configured for the MQTT transport", 400)
+	MsgMQTTReplyTimeout                        = ffe("FF10520", "Timed out waiting for a reply to event '%s' on MQTT topic '%s'")
+	MsgRetentionUnknownCollection              = ffe("FF10521", "Unknown collection '%s' in the retention policy for namespace '%s'")
+	MsgRetentionArchiveFailed                  = ffe("FF10522", "Failed to write retention archive '%s'")
+	MsgSearchNotSupported                      = ffe("FF10523", "Full-text search is not supported by the database plugin", 400)
+	MsgSearchTextMissing                       = ffe("FF10524", "The text to search for must be provided in the 'q' query parameter", 400)
+	MsgInvalidSearchLimit                      = ffe("FF10525", "Invalid search limit '%s' - must be a number between 1 and %d", 400)
+	MsgNamespaceArchiveInvalid                 = ffe("FF10526", "Invalid namespace archive at line %d: %s")
+	MsgNamespaceArchiveVersion                 = ffe("FF10527", "Unsupported namespace archive version %d - expected version %d")
+	MsgNamespaceArchiveWrongNamespace          = ffe("FF10528", "Namespace archive contains namespace '%s' - cannot import into namespace '%s'")
+	MsgNamespaceArchiveUnknownCollection       = ffe("FF10529", "Unknown collection '%s' in namespace archive")
+	MsgNamespaceArchiveHashMismatch            = ffe("FF10530", "Hash of %s record at line %d of the namespace archive does not match its value")
+	MsgNamespaceArchiveChecksumMismatch        = ffe("FF10531", "Namespace archive checksum does not match its contents - the archive has been truncated or modified")
+	MsgNamespaceArchiveCountMismatch           = ffe("FF10532", "Namespace archive trailer expected %d %s records, but found %d")
+	MsgNamespaceArchiveTargetNotEmpty          = ffe("FF10533", "Cannot import into namespace '%s' as it already contains %s")
+	MsgNamespaceArchiveInvalidRecord           = ffe("FF10534", "Invalid %s record in namespace archive")
+	MsgNamespaceArchiveRecordHashInvalid       = ffe("FF10535", "Stored hash of %s record '%s' does not match its contents")
+	MsgNamespaceNotDefined                     = ffe("FF10536", "Namespace '%s' is not defined in the configuration")
+	MsgNamespaceNoDatabasePlugin               = ffe("FF10537", "Namespace '%s' does not have a database plugin")
+	MsgDatabaseVerifyIssues                    = ffe("FF10538", "Found %d inconsistencies in the database of namespace '%s'")
+	MsgCursorRequiresSequenceSort              = ffe("FF10539", "A cursor can only be used with results sorted by sequence alone, and without skip", 400)
+	MsgInvalidCursor                           = ffe("FF10540", "Invalid cursor '%s'", 400)
+	MsgUnknownBlobStoragePlugin                = ffe("FF10541", "Unknown blob storage plugin '%s'")
+	MsgBlobStorageRESTErr                      = ffe("FF10542", "Error from blob storage")
+	MsgBlobStorageInvalidPayloadRef            = ffe("FF10543", "Invalid blob storage payload reference '%s'")
+	MsgBlobStorageFailed                       = ffe("FF10544", "Blob storage failed for '%s'")
+	MsgInvalidPrivacyOptions                   = ffe("FF10545", "Invalid private transaction options: %s", 400)
+	MsgKafkaTopicNotAllowed                    = ffe("FF10546", "Kafka topic '%s' is not one of the configured topics", 400)
+	MsgWebhookTemplateBodyNotJSON              = ffe("FF10547", "Webhook subscription template 'body' did not produce valid JSON")
+	MsgWebhookTemplateBatchNotSupported        = ffe("FF10548", "Webhook subscription templates for headers and path cannot be used with batch delivery", 400)
+	MsgRetentionInvalidConfig                  = ffe("FF10549", "Invalid '%s' in the retention policy for namespace '%s': must be greater than zero")
+	MsgMQTTInvalidReplyTopic                   = ffe("FF10550", "MQTT replyTopic '%s' must contain {clientID}, so each node has its own reply topic, and cannot be a shared subscription or contain the wildcards '+' or '#'")
+	MsgInvalidChaincodeDefinition              = ffe("FF10551", "Invalid chaincode definition: %s", 400)
+	MsgInvalidChaincodePackage                 = ffe("FF10552", "The contract must be a chaincode package, encoded as a base64 string", 400)
+	MsgInvalidSolanaAddress                    = ffe("FF10553", "Supplied solana address is invalid", 400)
+	MsgSolanaconnectRESTErr                    = ffe("FF10554", "Error from solana connector: %s")
+	MsgInvalidAnchorType                       = ffe("FF10555", "Invalid Anchor type for '%s': %s", 400)
+	MsgInvalidSolanaProgram                    = ffe("FF10556", "The contract must be a compiled Solana program, encoded as a base64 string", 400)
 )
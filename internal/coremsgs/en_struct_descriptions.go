@@ -298,7 +298,7 @@ var (
 	FFIGenerationRequestName        = ffm("FFIGenerationRequest.name", "The name of the FFI to generate")
 	FFIGenerationRequestDescription = ffm("FFIGenerationRequest.description", "The description of the FFI to be generated. Defaults to the description extracted by the blockchain specific converter utility")
 	FFIGenerationRequestVersion     = ffm("FFIGenerationRequest.version", "The version of the FFI to generate")
-	FFIGenerationRequestInput       = ffm("FFIGenerationRequest.input", "A blockchain connector specific payload. For example in Ethereum this is a JSON structure containing an 'abi' array, and optionally a 'devdocs' array.")
+	FFIGenerationRequestInput       = ffm("FFIGenerationRequest.input", "A blockchain connector specific payload. For example in Ethereum this is a JSON structure containing an 'abi' array, and optionally a 'devdocs' array. In Fabric this is a JSON structure containing the contract 'metadata' of the chaincode. In Tezos this is a JSON structure containing the Micheline 'parameter' type, or the 'code' of the contract.")
 
 	// ContractListener field descriptions
 	ContractListenerID        = ffm("ContractListener.id", "The UUID of the smart contract listener")
@@ -317,7 +317,8 @@ var (
 	ContractListenerState     = ffm("ContractListener.state", "This field is provided for the event listener implementation of the blockchain provider to record state, such as checkpoint information")
 
 	// ContractListenerOptions field descriptions
-	ContractListenerOptionsFirstEvent = ffm("ContractListenerOptions.firstEvent", "A blockchain specific string, such as a block number, to start listening from. The special strings 'oldest' and 'newest' are supported by all blockchain connectors. Default is 'newest'")
+	ContractListenerOptionsFirstEvent     = ffm("ContractListenerOptions.firstEvent", "A blockchain specific string, such as a block number, to start listening from. The special strings 'oldest' and 'newest' are supported by all blockchain connectors. Default is 'newest'")
+	ContractListenerOptionsPrivacyGroupID = ffm("ContractListenerOptions.privacyGroupId", "The privacy group to listen for private events in, on Ethereum networks that support private transactions, such as Hyperledger Besu with Tessera")
 
 	ListenerFilterInterface = ffm("ListenerFilter.interface", "A reference to an existing FFI, containing pre-registered type information for the event, used in combination with eventPath")
 	ListenerFilterEvent     = ffm("ListenerFilter.event", "The definition of the event, either provided in-line when creating the listener, or extracted from the referenced FFI when supplied")
@@ -530,15 +531,22 @@ var (
 	SubscriptionFilter    = ffm("Subscription.filter", "Server-side filter to apply to events")
 	SubscriptionOptions   = ffm("Subscription.options", "Subscription options")
 	SubscriptionEphemeral = ffm("Subscription.ephemeral", "Ephemeral subscriptions only exist as long as the application is connected, and as such will miss events that occur while the application is disconnected, and cannot be created administratively. You can create one over over a connected WebSocket connection")
+	SubscriptionPaused    = ffm("Subscription.paused", "Paused subscriptions keep their offset, but no events are delivered until the subscription is resumed")
 	SubscriptionCreated   = ffm("Subscription.created", "Creation time of the subscription")
 	SubscriptionUpdated   = ffm("Subscription.updated", "Last time the subscription was updated")
 
+	// SubscriptionRewind field descriptions
+	SubscriptionRewindSequence  = ffm("SubscriptionRewind.sequence", "The sequence of the first event to redeliver. Exactly one of sequence, timestamp or oldest must be specified")
+	SubscriptionRewindTimestamp = ffm("SubscriptionRewind.timestamp", "Redeliver from the first event created at or after this time. Exactly one of sequence, timestamp or oldest must be specified")
+	SubscriptionRewindOldest    = ffm("SubscriptionRewind.oldest", "Redeliver from the oldest event in the namespace. Exactly one of sequence, timestamp or oldest must be specified")
+
 	// SubscriptionFilter field descriptions
 	SubscriptionFilterEvents           = ffm("SubscriptionFilter.events", "Regular expression to apply to the event type, to subscribe to a subset of event types")
 	SubscriptionFilterTopic            = ffm("SubscriptionFilter.topic", "Regular expression to apply to the topic of the event, to subscribe to a subset of topics. Note for messages sent with multiple topics, a separate event is emitted for each topic")
 	SubscriptionFilterMessage          = ffm("SubscriptionFilter.message", "Filters specific to message events. If an event is not a message event, these filters are ignored")
 	SubscriptionFilterTransaction      = ffm("SubscriptionFilter.transaction", "Filters specific to events with a transaction. If an event is not associated with a transaction, this filter is ignored")
 	SubscriptionFilterBlockchainEvent  = ffm("SubscriptionFilter.blockchainevent", "Filters specific to blockchain events. If an event is not a blockchain event, these filters are ignored")
+	SubscriptionFilterExpression       = ffm("SubscriptionFilter.expression", "A JSONPath expression that must evaluate to true against the enriched event for it to be delivered, such as $.message.header.tag == \"order\". When withData is set, the message data is available as $.data")
 	SubscriptionFilterDeprecatedTopics = ffm("SubscriptionFilter.topics", "Deprecated: Please use 'topic' instead")
 	SubscriptionFilterDeprecatedTag    = ffm("SubscriptionFilter.tag", "Deprecated: Please use 'message.tag' instead")
 	SubscriptionFilterDeprecatedGroup  = ffm("SubscriptionFilter.group", "Deprecated: Please use 'message.group' instead")
@@ -715,13 +723,19 @@ var (
 	WebhooksOptReplyTag                 = ffm("WebhookSubOptions.replytag", "Webhooks only: The tag to set on the reply message")
 	WebhooksOptReplyTx                  = ffm("WebhookSubOptions.replytx", "Webhooks only: The transaction type to set on the reply message")
 	WebhooksOptTLSConfigName            = ffm("WebhookSubOptions.tlsConfigName", "The name of an existing TLS configuration associated to the namespace to use")
+	WebhooksOptSigningSecretName        = ffm("WebhookSubOptions.signingSecretName", "Webhooks only: The name of a signing secret configured on the namespace, used to sign each request with an HMAC-SHA256 signature header")
 	WebhooksOptHTTPOptions              = ffm("WebhookSubOptions.httpOptions", "Webhooks only: a set of options for HTTP")
 	WebhooksOptHTTPRetry                = ffm("WebhookSubOptions.retry", "Webhooks only: a set of options for retrying the webhook call")
+	WebhooksOptDeadLetterPolicy         = ffm("WebhookSubOptions.deadLetterPolicy", "Webhooks only: What to do with an event when the webhook call fails, after any retries. Set to 'deadletter' to record the event as a dead letter on the subscription and move on. Default=none")
+	WebhooksOptTemplate                 = ffm("WebhookSubOptions.template", "Webhooks only: Go text/template templates to build the request body, headers and path from the event and its data, instead of sending the data as-is")
 	WebhooksOptInputQuery               = ffm("WebhookInputOptions.query", "A top-level property of the first data input, to use for query parameters")
 	WebhooksOptInputHeaders             = ffm("WebhookInputOptions.headers", "A top-level property of the first data input, to use for headers")
 	WebhooksOptInputBody                = ffm("WebhookInputOptions.body", "A top-level property of the first data input, to use for the request body. Default is the whole first body")
 	WebhooksOptInputPath                = ffm("WebhookInputOptions.path", "A top-level property of the first data input, to use for a path to append with escaping to the webhook path")
 	WebhooksOptInputReplyTx             = ffm("WebhookInputOptions.replytx", "A top-level property of the first data input, to use to dynamically set whether to pin the response (so the requester can choose)")
+	WebhooksOptTemplateBody             = ffm("WebhookTemplateOptions.body", "A template for the request body. In batch mode, the output for each event must be valid JSON")
+	WebhooksOptTemplateHeaders          = ffm("WebhookTemplateOptions.headers", "A map of header names to templates for the header values. Not applied in batch mode")
+	WebhooksOptTemplatePath             = ffm("WebhookTemplateOptions.path", "A template for a path to append with escaping to the webhook path. Not applied in batch mode")
 	WebhooksOptRetryEnabled             = ffm("WebhookRetryOptions.enabled", "Enables retry on HTTP calls, defaults to false")
 	WebhooksOptRetryCount               = ffm("WebhookRetryOptions.count", "Number of times to retry the webhook call in case of failure")
 	WebhooksOptRetryInitialDelay        = ffm("WebhookRetryOptions.initialDelay", "Initial delay between retries when we retry the webhook call")
@@ -734,6 +748,19 @@ var (
 	WebhookOptHTTPRequestTimeout        = ffm("WebhookHTTPOptions.requestTimeout", "The max duration to hold a TLS handshake alive")
 	WebhookOptHTTPProxyURL              = ffm("WebhookHTTPOptions.proxyURL", "HTTP proxy URL to use for outbound requests to the webhook")
 
+	// DeadLetter field descriptions
+	DeadLetterID           = ffm("DeadLetter.id", "The UUID of the dead letter")
+	DeadLetterNamespace    = ffm("DeadLetter.namespace", "The namespace of the dead letter")
+	DeadLetterSubscription = ffm("DeadLetter.subscription", "The UUID of the subscription that failed to deliver the event")
+	DeadLetterEvent        = ffm("DeadLetter.event", "The UUID of the event that could not be delivered")
+	DeadLetterReason       = ffm("DeadLetter.reason", "The error from the last delivery attempt")
+	DeadLetterCreated      = ffm("DeadLetter.created", "The time the event was recorded as a dead letter")
+
+	// SearchResult field descriptions
+	SearchResultType = ffm("SearchResult.type", "The type of the matching resource - a message matched on its tag, topics or author, or a data item matched on its JSON value")
+	SearchResultID   = ffm("SearchResult.id", "The UUID of the matching message or data item")
+	SearchResultRank = ffm("SearchResult.rank", "The relevance of the match, where a higher rank is a better match. Only comparable between results of the same search")
+
 	// PublishInput field descriptions
 	PublishInputIdempotencyKey = ffm("PublishInput.idempotencyKey", "An optional identifier to allow idempotent submission of requests. Stored on the transaction uniquely within a namespace")
 
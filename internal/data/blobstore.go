@@ -21,6 +21,7 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"io"
+	"sync"
 
 	"github.com/docker/go-units"
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
@@ -28,6 +29,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/blobstorage"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/hyperledger/firefly/pkg/dataexchange"
@@ -37,9 +39,29 @@ type blobStore struct {
 	dm       *dataManager
 	database database.Plugin
 	exchange dataexchange.Plugin // optional
+	storage  blobstorage.Plugin  // optional - blobs are stored in the data exchange if not configured
+	copyLock sync.Mutex          // serializes copies from blob storage into the data exchange
 }
 
-func (bs *blobStore) uploadVerifyBlob(ctx context.Context, id *fftypes.UUID, reader io.Reader) (hash *fftypes.Bytes32, written int64, payloadRef string, err error) {
+type uploadBlobFn func(ctx context.Context, id fftypes.UUID, content io.Reader) (payloadRef string, hash *fftypes.Bytes32, size int64, err error)
+
+// uploader returns the function used to store newly uploaded blobs
+func (bs *blobStore) uploader() uploadBlobFn {
+	if bs.storage != nil {
+		return func(ctx context.Context, id fftypes.UUID, content io.Reader) (string, *fftypes.Bytes32, int64, error) {
+			return bs.storage.UploadBlob(ctx, bs.dm.namespace.Name, id, content)
+		}
+	}
+	return bs.exchangeUploader()
+}
+
+func (bs *blobStore) exchangeUploader() uploadBlobFn {
+	return func(ctx context.Context, id fftypes.UUID, content io.Reader) (string, *fftypes.Bytes32, int64, error) {
+		return bs.exchange.UploadBlob(ctx, bs.dm.namespace.NetworkName, id, content)
+	}
+}
+
+func (bs *blobStore) uploadVerifyBlob(ctx context.Context, id *fftypes.UUID, reader io.Reader, upload uploadBlobFn) (hash *fftypes.Bytes32, written int64, payloadRef string, err error) {
 	hashCalc := sha256.New()
 	dxReader, dx := io.Pipe()
 	storeAndHash := io.MultiWriter(hashCalc, dx)
@@ -53,7 +75,7 @@ func (bs *blobStore) uploadVerifyBlob(ctx context.Context, id *fftypes.UUID, rea
 		copyDone <- err
 	}()
 
-	payloadRef, uploadHash, uploadSize, dxErr := bs.exchange.UploadBlob(ctx, bs.dm.namespace.NetworkName, *id, dxReader)
+	payloadRef, uploadHash, uploadSize, dxErr := upload(ctx, *id, dxReader)
 	dxReader.Close()
 	copyErr := <-copyDone
 	if dxErr != nil {
@@ -79,7 +101,7 @@ func (bs *blobStore) uploadVerifyBlob(ctx context.Context, id *fftypes.UUID, rea
 
 func (bs *blobStore) UploadBlob(ctx context.Context, inData *core.DataRefOrValue, mpart *ffapi.Multipart, autoMeta bool) (*core.Data, error) {
 
-	if bs.exchange == nil {
+	if bs.exchange == nil && bs.storage == nil {
 		return nil, i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
 	}
 
@@ -92,7 +114,7 @@ func (bs *blobStore) UploadBlob(ctx context.Context, inData *core.DataRefOrValue
 		Value:     inData.Value,
 	}
 
-	hash, blobSize, payloadRef, err := bs.uploadVerifyBlob(ctx, data.ID, mpart.Data)
+	hash, blobSize, payloadRef, err := bs.uploadVerifyBlob(ctx, data.ID, mpart.Data, bs.uploader())
 	if err != nil {
 		return nil, err
 	}
@@ -144,7 +166,7 @@ func (bs *blobStore) UploadBlob(ctx context.Context, inData *core.DataRefOrValue
 
 func (bs *blobStore) DownloadBlob(ctx context.Context, dataID string) (*core.Blob, io.ReadCloser, error) {
 
-	if bs.exchange == nil {
+	if bs.exchange == nil && bs.storage == nil {
 		return nil, nil, i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
 	}
 
@@ -173,12 +195,81 @@ func (bs *blobStore) DownloadBlob(ctx context.Context, dataID string) (*core.Blo
 	}
 	blob := blobs[0]
 
-	reader, err := bs.exchange.DownloadBlob(ctx, blob.PayloadRef)
+	reader, err := bs.OpenBlob(ctx, blob.PayloadRef)
 	return blob, reader, err
 }
 
-func (bs *blobStore) DeleteBlob(ctx context.Context, blob *core.Blob) error {
+// isStorageRef returns true if the payload reference is held by the blob storage plugin, rather than by the
+// data exchange. Blobs uploaded before a blob storage plugin was configured, and blobs received from other
+// members of the network, are always held by the data exchange.
+func (bs *blobStore) isStorageRef(payloadRef string) bool {
+	return bs.storage != nil && blobstorage.IsPayloadRef(bs.storage, payloadRef)
+}
+
+// OpenBlob streams the content of a blob, from wherever it is stored
+func (bs *blobStore) OpenBlob(ctx context.Context, payloadRef string) (io.ReadCloser, error) {
+	if bs.isStorageRef(payloadRef) {
+		return bs.storage.DownloadBlob(ctx, payloadRef)
+	}
+	if bs.exchange == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
+	}
+	return bs.exchange.DownloadBlob(ctx, payloadRef)
+}
+
+// ExchangeBlobRef returns a reference to the blob that can be passed to the data exchange, to transfer
+// the blob to another member. A blob held in blob storage is copied into the data exchange the first
+// time it is sent, and the reference to that copy is stored on the blob for all later sends and retries.
+func (bs *blobStore) ExchangeBlobRef(ctx context.Context, blob *core.Blob) (string, error) {
+	if !bs.isStorageRef(blob.PayloadRef) {
+		return blob.PayloadRef, nil
+	}
+	if blob.ExchangeRef != "" {
+		return blob.ExchangeRef, nil
+	}
 	if bs.exchange == nil {
+		return "", i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
+	}
+
+	// Sends to each member of a group run in parallel, so check again under the lock
+	// whether another send has already made the copy
+	bs.copyLock.Lock()
+	defer bs.copyLock.Unlock()
+	fb := database.BlobQueryFactory.NewFilter(ctx)
+	blobs, _, err := bs.database.GetBlobs(ctx, bs.dm.namespace.Name, fb.Eq("payloadref", blob.PayloadRef))
+	if err != nil {
+		return "", err
+	}
+	if len(blobs) > 0 && blobs[0].ExchangeRef != "" {
+		blob.ExchangeRef = blobs[0].ExchangeRef
+		return blob.ExchangeRef, nil
+	}
+
+	reader, err := bs.storage.DownloadBlob(ctx, blob.PayloadRef)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	hash, _, payloadRef, err := bs.uploadVerifyBlob(ctx, blob.DataID, reader, bs.exchangeUploader())
+	if err != nil {
+		return "", err
+	}
+	if !hash.Equals(blob.Hash) {
+		return "", i18n.NewError(ctx, coremsgs.MsgDXBadHash, hash, blob.Hash)
+	}
+	log.L(ctx).Debugf("Copied blob '%s' from blob storage to data exchange: '%s'", blob.PayloadRef, payloadRef)
+
+	err = bs.database.UpdateBlob(ctx, blob.Sequence, database.BlobQueryFactory.NewUpdate(ctx).Set("exchangeref", payloadRef))
+	if err != nil {
+		return "", err
+	}
+	blob.ExchangeRef = payloadRef
+	return payloadRef, nil
+}
+
+func (bs *blobStore) DeleteBlob(ctx context.Context, blob *core.Blob) error {
+	storageRef := bs.isStorageRef(blob.PayloadRef)
+	if bs.exchange == nil && !storageRef {
 		return i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
 	}
 
@@ -192,8 +283,15 @@ func (bs *blobStore) DeleteBlob(ctx context.Context, blob *core.Blob) error {
 		return err
 	}
 	if len(blobs) <= 1 {
-
-		err := bs.exchange.DeleteBlob(ctx, blob.PayloadRef)
+		if storageRef {
+			err = bs.storage.DeleteBlob(ctx, blob.PayloadRef)
+			if err == nil && blob.ExchangeRef != "" && bs.exchange != nil {
+				// Also remove the copy made in the data exchange to send the blob to other members
+				err = bs.exchange.DeleteBlob(ctx, blob.ExchangeRef)
+			}
+		} else {
+			err = bs.exchange.DeleteBlob(ctx, blob.PayloadRef)
+		}
 		if err != nil {
 			return err
 		}
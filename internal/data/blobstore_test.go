@@ -29,6 +29,7 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/blobstoragemocks"
 	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
 	"github.com/hyperledger/firefly/pkg/core"
@@ -563,3 +564,420 @@ func TestDeleteBlobBackwardCompatibilityFail(t *testing.T) {
 	assert.Regexp(t, "pop", err)
 	mdb.AssertExpectations(t)
 }
+
+func newTestBlobStorage(dm *dataManager) *blobstoragemocks.Plugin {
+	mbs := &blobstoragemocks.Plugin{}
+	mbs.On("Name").Return("filesystem").Maybe()
+	dm.storage = mbs
+	return mbs
+}
+
+func TestUploadBlobStorageOk(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	dm.exchange = nil
+	mbs := newTestBlobStorage(dm)
+
+	b := []byte("some data")
+
+	mdi := dm.database.(*databasemocks.Plugin)
+	rag := mdi.On("RunAsGroup", mock.Anything, mock.Anything)
+	rag.RunFn = func(a mock.Arguments) {
+		rag.ReturnArguments = mock.Arguments{
+			a[1].(func(context.Context) error)(a[0].(context.Context)),
+		}
+	}
+	mdi.On("UpsertData", mock.Anything, mock.Anything, database.UpsertOptimizationNew).Return(nil)
+	mdi.On("InsertBlob", mock.Anything, mock.MatchedBy(func(blob *core.Blob) bool {
+		return blob.PayloadRef == "filesystem://ns1/"+blob.DataID.String()
+	})).Return(nil)
+
+	bsUpload := mbs.On("UploadBlob", ctx, "ns1", mock.Anything, mock.Anything)
+	bsUpload.RunFn = func(a mock.Arguments) {
+		readBytes, err := ioutil.ReadAll(a[3].(io.Reader))
+		assert.Nil(t, err)
+		uuid := a[2].(fftypes.UUID)
+		var hash fftypes.Bytes32 = sha256.Sum256(readBytes)
+		bsUpload.ReturnArguments = mock.Arguments{fmt.Sprintf("filesystem://ns1/%s", uuid.String()), &hash, int64(len(readBytes)), err}
+	}
+
+	assert.True(t, dm.BlobsEnabled())
+	data, err := dm.UploadBlob(ctx, &core.DataRefOrValue{}, &ffapi.Multipart{Data: bytes.NewReader(b)}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, [32]byte(sha256.Sum256(b)), [32]byte(*data.Blob.Hash))
+
+	mdi.AssertExpectations(t)
+	mbs.AssertExpectations(t)
+}
+
+func TestDownloadBlobStorageOk(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mbs := newTestBlobStorage(dm)
+
+	blobHash := fftypes.NewRandB32()
+	dataID := fftypes.NewUUID()
+
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetDataByID", ctx, "ns1", dataID, false).Return(&core.Data{
+		ID:        dataID,
+		Namespace: "ns1",
+		Blob: &core.BlobRef{
+			Hash: blobHash,
+		},
+	}, nil)
+	mdi.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{{
+		Hash:       blobHash,
+		PayloadRef: "filesystem://ns1/blob1",
+	}}, nil, nil)
+
+	mbs.On("DownloadBlob", ctx, "filesystem://ns1/blob1").Return(io.NopCloser(bytes.NewReader([]byte("some blob"))), nil)
+
+	blob, reader, err := dm.DownloadBlob(ctx, dataID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, blobHash.String(), blob.Hash.String())
+	b, err := ioutil.ReadAll(reader)
+	reader.Close()
+	assert.Equal(t, "some blob", string(b))
+
+	mdi.AssertExpectations(t)
+	mbs.AssertExpectations(t)
+}
+
+func TestOpenBlobDataExchange(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mbs := newTestBlobStorage(dm)
+
+	mdx := dm.exchange.(*dataexchangemocks.Plugin)
+	mdx.On("DownloadBlob", ctx, "ns1/blob1").Return(io.NopCloser(bytes.NewReader([]byte("some blob"))), nil)
+
+	_, err := dm.OpenBlob(ctx, "ns1/blob1")
+	assert.NoError(t, err)
+
+	mdx.AssertExpectations(t)
+	mbs.AssertExpectations(t)
+}
+
+func TestOpenBlobDisabled(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	dm.exchange = nil
+	newTestBlobStorage(dm)
+
+	_, err := dm.OpenBlob(ctx, "ns1/blob1")
+	assert.Regexp(t, "FF10414", err)
+}
+
+func TestExchangeBlobRefDataExchange(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	newTestBlobStorage(dm)
+
+	payloadRef, err := dm.ExchangeBlobRef(ctx, &core.Blob{PayloadRef: "ns1/blob1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1/blob1", payloadRef)
+}
+
+func TestExchangeBlobRefStored(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mbs := newTestBlobStorage(dm)
+
+	payloadRef, err := dm.ExchangeBlobRef(ctx, &core.Blob{PayloadRef: "filesystem://ns1/blob1", ExchangeRef: "ns1/blob1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1/blob1", payloadRef)
+
+	mbs.AssertExpectations(t)
+}
+
+func TestExchangeBlobRefCopy(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mbs := newTestBlobStorage(dm)
+
+	b := []byte("some blob")
+	var hash fftypes.Bytes32 = sha256.Sum256(b)
+	blob := &core.Blob{
+		Sequence:   12345,
+		Hash:       &hash,
+		PayloadRef: "filesystem://ns1/blob1",
+		DataID:     fftypes.NewUUID(),
+	}
+
+	mdb := dm.database.(*databasemocks.Plugin)
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{{PayloadRef: "filesystem://ns1/blob1"}}, &ffapi.FilterResult{}, nil).Once()
+	mdb.On("UpdateBlob", ctx, int64(12345), mock.MatchedBy(func(u ffapi.Update) bool {
+		info, _ := u.Finalize()
+		val, _ := info.SetOperations[0].Value.Value()
+		return info.SetOperations[0].Field == "exchangeref" && val == "ns1/blob1"
+	})).Return(nil).Once()
+	mbs.On("DownloadBlob", ctx, "filesystem://ns1/blob1").Return(io.NopCloser(bytes.NewReader(b)), nil).Once()
+	mdx := dm.exchange.(*dataexchangemocks.Plugin)
+	dxUpload := mdx.On("UploadBlob", ctx, "ns1", *blob.DataID, mock.Anything).Once()
+	dxUpload.RunFn = func(a mock.Arguments) {
+		readBytes, err := ioutil.ReadAll(a[3].(io.Reader))
+		assert.Nil(t, err)
+		assert.Equal(t, b, readBytes)
+		dxUpload.ReturnArguments = mock.Arguments{"ns1/blob1", &hash, int64(len(b)), err}
+	}
+
+	payloadRef, err := dm.ExchangeBlobRef(ctx, blob)
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1/blob1", payloadRef)
+	assert.Equal(t, "ns1/blob1", blob.ExchangeRef)
+
+	// A retry of the send uses the copy already in the data exchange
+	payloadRef, err = dm.ExchangeBlobRef(ctx, blob)
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1/blob1", payloadRef)
+
+	mdb.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+	mbs.AssertExpectations(t)
+}
+
+func TestExchangeBlobRefCopiedByOtherSend(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mbs := newTestBlobStorage(dm)
+
+	blob := &core.Blob{
+		Sequence:   12345,
+		Hash:       fftypes.NewRandB32(),
+		PayloadRef: "filesystem://ns1/blob1",
+		DataID:     fftypes.NewUUID(),
+	}
+
+	mdb := dm.database.(*databasemocks.Plugin)
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{{PayloadRef: "filesystem://ns1/blob1", ExchangeRef: "ns1/blob1"}}, &ffapi.FilterResult{}, nil)
+
+	payloadRef, err := dm.ExchangeBlobRef(ctx, blob)
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1/blob1", payloadRef)
+	assert.Equal(t, "ns1/blob1", blob.ExchangeRef)
+
+	mdb.AssertExpectations(t)
+	mbs.AssertExpectations(t)
+}
+
+func TestExchangeBlobRefGetBlobsFail(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	newTestBlobStorage(dm)
+
+	mdb := dm.database.(*databasemocks.Plugin)
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := dm.ExchangeBlobRef(ctx, &core.Blob{PayloadRef: "filesystem://ns1/blob1"})
+	assert.Regexp(t, "pop", err)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestExchangeBlobRefUpdateFail(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mbs := newTestBlobStorage(dm)
+
+	b := []byte("some blob")
+	var hash fftypes.Bytes32 = sha256.Sum256(b)
+	blob := &core.Blob{
+		Sequence:   12345,
+		Hash:       &hash,
+		PayloadRef: "filesystem://ns1/blob1",
+		DataID:     fftypes.NewUUID(),
+	}
+
+	mdb := dm.database.(*databasemocks.Plugin)
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{}, &ffapi.FilterResult{}, nil)
+	mdb.On("UpdateBlob", ctx, int64(12345), mock.Anything).Return(fmt.Errorf("pop"))
+	mbs.On("DownloadBlob", ctx, "filesystem://ns1/blob1").Return(io.NopCloser(bytes.NewReader(b)), nil)
+	mdx := dm.exchange.(*dataexchangemocks.Plugin)
+	dxUpload := mdx.On("UploadBlob", ctx, "ns1", *blob.DataID, mock.Anything)
+	dxUpload.RunFn = func(a mock.Arguments) {
+		_, err := ioutil.ReadAll(a[3].(io.Reader))
+		dxUpload.ReturnArguments = mock.Arguments{"ns1/blob1", &hash, int64(len(b)), err}
+	}
+
+	_, err := dm.ExchangeBlobRef(ctx, blob)
+	assert.Regexp(t, "pop", err)
+	assert.Empty(t, blob.ExchangeRef)
+
+	mdb.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+	mbs.AssertExpectations(t)
+}
+
+func TestExchangeBlobRefHashMismatch(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mbs := newTestBlobStorage(dm)
+
+	b := []byte("some blob")
+	var hash fftypes.Bytes32 = sha256.Sum256(b)
+	blob := &core.Blob{
+		Hash:       fftypes.NewRandB32(),
+		PayloadRef: "filesystem://ns1/blob1",
+		DataID:     fftypes.NewUUID(),
+	}
+
+	mdb := dm.database.(*databasemocks.Plugin)
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{}, &ffapi.FilterResult{}, nil)
+	mbs.On("DownloadBlob", ctx, "filesystem://ns1/blob1").Return(io.NopCloser(bytes.NewReader(b)), nil)
+	mdx := dm.exchange.(*dataexchangemocks.Plugin)
+	dxUpload := mdx.On("UploadBlob", ctx, "ns1", *blob.DataID, mock.Anything)
+	dxUpload.RunFn = func(a mock.Arguments) {
+		_, err := ioutil.ReadAll(a[3].(io.Reader))
+		dxUpload.ReturnArguments = mock.Arguments{"ns1/blob1", &hash, int64(len(b)), err}
+	}
+
+	_, err := dm.ExchangeBlobRef(ctx, blob)
+	assert.Regexp(t, "FF10238", err)
+
+	mdx.AssertExpectations(t)
+	mbs.AssertExpectations(t)
+}
+
+func TestExchangeBlobRefUploadFail(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mbs := newTestBlobStorage(dm)
+
+	blob := &core.Blob{
+		Hash:       fftypes.NewRandB32(),
+		PayloadRef: "filesystem://ns1/blob1",
+		DataID:     fftypes.NewUUID(),
+	}
+
+	mdb := dm.database.(*databasemocks.Plugin)
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{}, &ffapi.FilterResult{}, nil)
+	mbs.On("DownloadBlob", ctx, "filesystem://ns1/blob1").Return(io.NopCloser(bytes.NewReader([]byte("some blob"))), nil)
+	mdx := dm.exchange.(*dataexchangemocks.Plugin)
+	mdx.On("UploadBlob", ctx, "ns1", *blob.DataID, mock.Anything).Return("", nil, int64(-1), fmt.Errorf("pop"))
+
+	_, err := dm.ExchangeBlobRef(ctx, blob)
+	assert.Regexp(t, "pop", err)
+
+	mdx.AssertExpectations(t)
+	mbs.AssertExpectations(t)
+}
+
+func TestExchangeBlobRefDownloadFail(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mbs := newTestBlobStorage(dm)
+
+	mdb := dm.database.(*databasemocks.Plugin)
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{}, &ffapi.FilterResult{}, nil)
+	mbs.On("DownloadBlob", ctx, "filesystem://ns1/blob1").Return(nil, fmt.Errorf("pop"))
+
+	_, err := dm.ExchangeBlobRef(ctx, &core.Blob{PayloadRef: "filesystem://ns1/blob1"})
+	assert.Regexp(t, "pop", err)
+
+	mbs.AssertExpectations(t)
+}
+
+func TestExchangeBlobRefNoDataExchange(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	dm.exchange = nil
+	newTestBlobStorage(dm)
+
+	_, err := dm.ExchangeBlobRef(ctx, &core.Blob{PayloadRef: "filesystem://ns1/blob1"})
+	assert.Regexp(t, "FF10414", err)
+}
+
+func TestDeleteBlobStorage(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	dm.exchange = nil
+	mbs := newTestBlobStorage(dm)
+	mdb := dm.database.(*databasemocks.Plugin)
+
+	blob := &core.Blob{
+		Sequence:   1,
+		Namespace:  "ns1",
+		Hash:       fftypes.NewRandB32(),
+		PayloadRef: "filesystem://ns1/blob1",
+		DataID:     fftypes.NewUUID(),
+	}
+
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{}, &ffapi.FilterResult{}, nil)
+	mbs.On("DeleteBlob", ctx, "filesystem://ns1/blob1").Return(nil)
+	mdb.On("DeleteBlob", ctx, int64(1)).Return(nil)
+
+	err := dm.DeleteBlob(ctx, blob)
+	assert.NoError(t, err)
+	mdb.AssertExpectations(t)
+	mbs.AssertExpectations(t)
+}
+
+func TestDeleteBlobStorageWithExchangeCopy(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mbs := newTestBlobStorage(dm)
+	mdb := dm.database.(*databasemocks.Plugin)
+	mdx := dm.exchange.(*dataexchangemocks.Plugin)
+
+	blob := &core.Blob{
+		Sequence:    1,
+		Namespace:   "ns1",
+		Hash:        fftypes.NewRandB32(),
+		PayloadRef:  "filesystem://ns1/blob1",
+		ExchangeRef: "ns1/blob1",
+		DataID:      fftypes.NewUUID(),
+	}
+
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{}, &ffapi.FilterResult{}, nil)
+	mbs.On("DeleteBlob", ctx, "filesystem://ns1/blob1").Return(nil)
+	mdx.On("DeleteBlob", ctx, "ns1/blob1").Return(nil)
+	mdb.On("DeleteBlob", ctx, int64(1)).Return(nil)
+
+	err := dm.DeleteBlob(ctx, blob)
+	assert.NoError(t, err)
+	mdb.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+	mbs.AssertExpectations(t)
+}
+
+func TestDeleteBlobStorageExchangeCopyFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mbs := newTestBlobStorage(dm)
+	mdb := dm.database.(*databasemocks.Plugin)
+	mdx := dm.exchange.(*dataexchangemocks.Plugin)
+
+	blob := &core.Blob{
+		Sequence:    1,
+		Namespace:   "ns1",
+		Hash:        fftypes.NewRandB32(),
+		PayloadRef:  "filesystem://ns1/blob1",
+		ExchangeRef: "ns1/blob1",
+		DataID:      fftypes.NewUUID(),
+	}
+
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{}, &ffapi.FilterResult{}, nil)
+	mbs.On("DeleteBlob", ctx, "filesystem://ns1/blob1").Return(nil)
+	mdx.On("DeleteBlob", ctx, "ns1/blob1").Return(fmt.Errorf("pop"))
+
+	err := dm.DeleteBlob(ctx, blob)
+	assert.Regexp(t, "pop", err)
+	mdb.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+	mbs.AssertExpectations(t)
+}
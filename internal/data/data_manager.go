@@ -29,6 +29,7 @@ import (
 	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/blobstorage"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/hyperledger/firefly/pkg/dataexchange"
@@ -50,6 +51,8 @@ type Manager interface {
 	UploadJSON(ctx context.Context, inData *core.DataRefOrValue) (*core.Data, error)
 	UploadBlob(ctx context.Context, inData *core.DataRefOrValue, blob *ffapi.Multipart, autoMeta bool) (*core.Data, error)
 	DownloadBlob(ctx context.Context, dataID string) (*core.Blob, io.ReadCloser, error)
+	OpenBlob(ctx context.Context, payloadRef string) (io.ReadCloser, error)
+	ExchangeBlobRef(ctx context.Context, blob *core.Blob) (string, error)
 	DeleteData(ctx context.Context, dataID string) error
 	HydrateBatch(ctx context.Context, persistedBatch *core.BatchPersisted) (*core.Batch, error)
 	Start()
@@ -93,7 +96,7 @@ const (
 	CRORequireBatchID
 )
 
-func NewDataManager(ctx context.Context, ns *core.Namespace, di database.Plugin, dx dataexchange.Plugin, cacheManager cache.Manager) (Manager, error) {
+func NewDataManager(ctx context.Context, ns *core.Namespace, di database.Plugin, dx dataexchange.Plugin, bs blobstorage.Plugin, cacheManager cache.Manager) (Manager, error) {
 	if di == nil {
 		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "DataManager")
 	}
@@ -105,6 +108,7 @@ func NewDataManager(ctx context.Context, ns *core.Namespace, di database.Plugin,
 		dm:       dm,
 		database: di,
 		exchange: dx,
+		storage:  bs,
 	}
 
 	validatorCache, err := cacheManager.GetCache(
@@ -145,7 +149,7 @@ func (dm *dataManager) Start() {
 }
 
 func (dm *dataManager) BlobsEnabled() bool {
-	return dm.blobStore.exchange != nil
+	return dm.blobStore.exchange != nil || dm.blobStore.storage != nil
 }
 
 func (dm *dataManager) CheckDatatype(ctx context.Context, datatype *core.Datatype) error {
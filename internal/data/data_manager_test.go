@@ -58,7 +58,7 @@ func TestCacheInitFail(t *testing.T) {
 		ns.Name,
 	)).Return(nil, cacheInitError).Once()
 	defer vErrcmi.AssertExpectations(t)
-	_, err := NewDataManager(ctx, ns, mdi, mdx, vErrcmi)
+	_, err := NewDataManager(ctx, ns, mdi, mdx, nil, vErrcmi)
 	assert.Equal(t, cacheInitError, err)
 
 	mErrcmi := &cachemocks.Manager{}
@@ -75,7 +75,7 @@ func TestCacheInitFail(t *testing.T) {
 		ns.Name,
 	)).Return(nil, cacheInitError).Once()
 	defer mErrcmi.AssertExpectations(t)
-	_, err = NewDataManager(ctx, ns, mdi, mdx, mErrcmi)
+	_, err = NewDataManager(ctx, ns, mdi, mdx, nil, mErrcmi)
 	assert.Equal(t, cacheInitError, err)
 }
 
@@ -92,7 +92,7 @@ func newTestDataManager(t *testing.T) (*dataManager, context.Context, func()) {
 
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 10000, 5*time.Minute), nil)
-	dm, err := NewDataManager(ctx, ns, mdi, mdx, cmi)
+	dm, err := NewDataManager(ctx, ns, mdi, mdx, nil, cmi)
 	cmi.AssertCalled(t, "GetCache", cache.NewCacheConfig(
 		ctx,
 		coreconfig.CacheMessageSize,
@@ -276,7 +276,7 @@ func TestWriteNewMessageE2E(t *testing.T) {
 }
 
 func TestInitBadDeps(t *testing.T) {
-	_, err := NewDataManager(context.Background(), &core.Namespace{}, nil, nil, nil)
+	_, err := NewDataManager(context.Background(), &core.Namespace{}, nil, nil, nil, nil)
 	assert.Regexp(t, "FF10128", err)
 }
 
@@ -38,6 +38,13 @@ func TestGetPluginPostgres(t *testing.T) {
 	assert.NotNil(t, plugin)
 }
 
+func TestGetPluginMySQL(t *testing.T) {
+	ctx := context.Background()
+	plugin, err := GetPlugin(ctx, "mysql")
+	assert.NoError(t, err)
+	assert.NotNil(t, plugin)
+}
+
 func TestGetPluginSQLite(t *testing.T) {
 	ctx := context.Background()
 	plugin, err := GetPlugin(ctx, "sqlite3")
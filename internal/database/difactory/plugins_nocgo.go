@@ -20,10 +20,12 @@
 package difactory
 
 import (
+	"github.com/hyperledger/firefly/internal/database/mysql"
 	"github.com/hyperledger/firefly/internal/database/postgres"
 	"github.com/hyperledger/firefly/pkg/database"
 )
 
 var pluginsByName = map[string]func() database.Plugin{
+	(*mysql.MySQL)(nil).Name():       func() database.Plugin { return &mysql.MySQL{} },
 	(*postgres.Postgres)(nil).Name(): func() database.Plugin { return &postgres.Postgres{} },
 }
@@ -0,0 +1,124 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	migratedb "github.com/golang-migrate/migrate/v4/database"
+	migratemysql "github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/dbsql"
+	"github.com/hyperledger/firefly/internal/database/sqlcommon"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+type MySQL struct {
+	sqlcommon.SQLCommon
+}
+
+func (my *MySQL) Init(ctx context.Context, config config.Section) error {
+	capabilities := &database.Capabilities{}
+	if config.GetInt(dbsql.SQLConfMaxConnections) > 1 {
+		capabilities.Concurrency = true
+	}
+	if err := my.SQLCommon.Init(ctx, my, config, capabilities); err != nil {
+		return err
+	}
+	my.EnableSearch(my)
+	my.EnableLockQuery(my)
+	my.EnableInsertIgnore()
+	return nil
+}
+
+func (my *MySQL) SetHandler(namespace string, handler database.Callbacks) {
+	my.SQLCommon.SetHandler(namespace, handler)
+}
+
+func (my *MySQL) Name() string {
+	return "mysql"
+}
+
+func (my *MySQL) SequenceColumn() string {
+	return "seq"
+}
+
+func (my *MySQL) MigrationsDir() string {
+	return my.Name()
+}
+
+func (my *MySQL) Features() dbsql.SQLFeatures {
+	features := dbsql.DefaultSQLProviderFeatures()
+	features.PlaceholderFormat = sq.Question
+	features.UseILIKE = false // Not supported
+	return features
+}
+
+// LockQuery locks the row of the namespace the lock is named after, which is upserted when the namespace
+// is initialized, as MySQL has no transaction scoped advisory locks
+func (my *MySQL) LockQuery(lockName string) sq.SelectBuilder {
+	return sq.Select("seq").From("namespaces").Where(sq.Eq{"name": lockName}).Suffix("FOR UPDATE")
+}
+
+func (my *MySQL) ApplyInsertQueryCustomizations(insert sq.InsertBuilder, requestConflictEmptyResult bool) (sq.InsertBuilder, bool) {
+	if requestConflictEmptyResult {
+		// Caller wants an empty result on insert conflict, rather than an error. MySQL has no RETURNING clause,
+		// so the conflict is ignored, and detected from the insert not reporting a sequence.
+		return insert.Options("IGNORE"), false
+	}
+	return insert, false
+}
+
+// SearchMatch uses the FULLTEXT index of the content column in natural language mode, where the
+// relevance is zero for rows that do not match
+func (my *MySQL) SearchMatch(text string) (match sq.Sqlizer, rank sq.Sqlizer) {
+	relevance := sq.Expr("MATCH(content) AGAINST(? IN NATURAL LANGUAGE MODE)", text)
+	return relevance, relevance
+}
+
+// The migrations are applied as multi-statement scripts, and the shared SQL double quotes
+// column and table names that are reserved words in MySQL, so both are enabled on the DSN
+func connectionDSN(url string) (string, error) {
+	cfg, err := mysqldriver.ParseDSN(url)
+	if err != nil {
+		return "", err
+	}
+	cfg.MultiStatements = true
+	if cfg.Params == nil {
+		cfg.Params = map[string]string{}
+	}
+	if _, ok := cfg.Params["sql_mode"]; !ok {
+		cfg.Params["sql_mode"] = "CONCAT(@@sql_mode,',ANSI_QUOTES')"
+	}
+	return cfg.FormatDSN(), nil
+}
+
+func (my *MySQL) Open(url string) (*sql.DB, error) {
+	dsn, err := connectionDSN(url)
+	if err != nil {
+		return nil, err
+	}
+	return sql.Open(my.Name(), dsn)
+}
+
+func (my *MySQL) GetMigrationDriver(db *sql.DB) (migratedb.Driver, error) {
+	return migratemysql.WithInstance(db, &migratemysql.Config{})
+}
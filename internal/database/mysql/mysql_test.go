@@ -0,0 +1,97 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/internal/database/sqlcommon"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLProvider(t *testing.T) {
+	config.RootConfigReset()
+	my := &MySQL{}
+	my.SetHandler("ns", &databasemocks.Callbacks{})
+	config := config.RootSection("unittest")
+	my.InitConfig(config)
+	config.Set(sqlcommon.SQLConfDatasourceURL, "user:pass@tcp(127.0.0.1:1)/firefly")
+	err := my.Init(context.Background(), config)
+	assert.NoError(t, err)
+	assert.True(t, my.Capabilities().Concurrency)
+	assert.True(t, my.Capabilities().Search)
+	_, err = my.GetMigrationDriver(my.DB())
+	assert.Error(t, err)
+
+	assert.Equal(t, "mysql", my.Name())
+	assert.Equal(t, "mysql", my.MigrationsDir())
+	assert.Equal(t, "seq", my.SequenceColumn())
+	assert.Equal(t, sq.Question, my.Features().PlaceholderFormat)
+	assert.Nil(t, my.Features().AcquireLock)
+
+	sql, args, err := my.LockQuery("n's").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT seq FROM namespaces WHERE name = ? FOR UPDATE", sql)
+	assert.Equal(t, []interface{}{"n's"}, args)
+
+	insert := sq.Insert("test").Columns("col1").Values("val1")
+	insert, query := my.ApplyInsertQueryCustomizations(insert, false)
+	sql, _, err = insert.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO test (col1) VALUES (?)", sql)
+	assert.False(t, query)
+
+	insert = sq.Insert("test").Columns("col1").Values("val1")
+	insert, query = my.ApplyInsertQueryCustomizations(insert, true)
+	sql, _, err = insert.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT IGNORE INTO test (col1) VALUES (?)", sql)
+	assert.False(t, query)
+}
+
+func TestMySQLSearchMatch(t *testing.T) {
+	my := &MySQL{}
+	match, rank := my.SearchMatch("PO-1234")
+	sql, args, err := sq.Select("ref_id").Column(rank).From("search_index").Where(match).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT ref_id, MATCH(content) AGAINST(? IN NATURAL LANGUAGE MODE) FROM search_index WHERE MATCH(content) AGAINST(? IN NATURAL LANGUAGE MODE)", sql)
+	assert.Equal(t, []interface{}{"PO-1234", "PO-1234"}, args)
+}
+
+func TestMySQLInitBadURL(t *testing.T) {
+	config.RootConfigReset()
+	my := &MySQL{}
+	config := config.RootSection("unittest")
+	my.InitConfig(config)
+	config.Set(sqlcommon.SQLConfDatasourceURL, "!bad connection")
+	err := my.Init(context.Background(), config)
+	assert.Regexp(t, "invalid DSN", err)
+}
+
+func TestConnectionDSN(t *testing.T) {
+	dsn, err := connectionDSN("user:pass@tcp(127.0.0.1:1)/firefly")
+	assert.NoError(t, err)
+	assert.Equal(t, "user:pass@tcp(127.0.0.1:1)/firefly?multiStatements=true&sql_mode=CONCAT%28%40%40sql_mode%2C%27%2CANSI_QUOTES%27%29", dsn)
+
+	dsn, err = connectionDSN("user:pass@tcp(127.0.0.1:1)/firefly?sql_mode=ANSI")
+	assert.NoError(t, err)
+	assert.Equal(t, "user:pass@tcp(127.0.0.1:1)/firefly?multiStatements=true&sql_mode=ANSI", dsn)
+}
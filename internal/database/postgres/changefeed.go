@@ -0,0 +1,89 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/dbsql"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/database/sqlcommon"
+	"github.com/lib/pq"
+)
+
+const (
+	changeFeedChannel              = "firefly_changes"
+	changeFeedMinReconnectInterval = 1 * time.Second
+	changeFeedMaxReconnectInterval = 1 * time.Minute
+)
+
+// NotifyTx publishes the notification with pg_notify, which PostgreSQL only delivers to listeners
+// once the transaction commits
+func (psql *Postgres) NotifyTx(ctx context.Context, tx *dbsql.TXWrapper, n *sqlcommon.ChangeNotification) error {
+	payload, _ := json.Marshal(n)
+	_, err := psql.ExecTx(ctx, changeFeedChannel, tx, `SELECT pg_notify($1, $2)`, []interface{}{changeFeedChannel, string(payload)})
+	return err
+}
+
+func (psql *Postgres) startChangeFeed(ctx context.Context, url string) {
+	listener := pq.NewListener(url, changeFeedMinReconnectInterval, changeFeedMaxReconnectInterval, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.L(ctx).Warnf("Change feed listener event %d: %s", event, err)
+		}
+	})
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+	psql.changeFeedDone = make(chan struct{})
+	go psql.changeFeedListen(ctx, listener)
+}
+
+type changeFeedListener interface {
+	Listen(channel string) error
+	NotificationChannel() <-chan *pq.Notification
+}
+
+func (psql *Postgres) changeFeedListen(ctx context.Context, listener changeFeedListener) {
+	defer close(psql.changeFeedDone)
+	// Listen blocks until the first connection is established
+	if err := listener.Listen(changeFeedChannel); err != nil {
+		log.L(ctx).Errorf("Change feed failed to listen on '%s': %s", changeFeedChannel, err)
+		return
+	}
+	log.L(ctx).Infof("Change feed listening on '%s'", changeFeedChannel)
+	psql.changeFeedLoop(ctx, listener.NotificationChannel())
+}
+
+// changeFeedLoop runs until the listener is closed. A nil notification is delivered after a reconnect,
+// where notifications might have been missed - the timed polls cover that gap.
+func (psql *Postgres) changeFeedLoop(ctx context.Context, notifications <-chan *pq.Notification) {
+	for notification := range notifications {
+		if notification == nil {
+			log.L(ctx).Infof("Change feed reconnected")
+			continue
+		}
+		var n sqlcommon.ChangeNotification
+		if err := json.Unmarshal([]byte(notification.Extra), &n); err != nil {
+			log.L(ctx).Errorf("Invalid change feed notification '%s': %s", notification.Extra, err)
+			continue
+		}
+		psql.DispatchChangeNotification(ctx, &n)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/database/sqlcommon"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockDBPostgres struct {
+	*Postgres
+	db *sql.DB
+}
+
+func (mp *mockDBPostgres) Open(url string) (*sql.DB, error) {
+	return mp.db, nil
+}
+
+func newMockChangeFeedPostgres(t *testing.T) (*Postgres, sqlmock.Sqlmock) {
+	db, mdb, err := sqlmock.New()
+	assert.NoError(t, err)
+	config.RootConfigReset()
+	psql := &Postgres{}
+	conf := config.RootSection("unittest")
+	psql.InitConfig(conf)
+	conf.Set(sqlcommon.SQLConfDatasourceURL, "test")
+	err = psql.SQLCommon.Init(context.Background(), &mockDBPostgres{Postgres: psql, db: db}, conf, &database.Capabilities{})
+	assert.NoError(t, err)
+	psql.EnableChangeFeed(psql)
+	return psql, mdb
+}
+
+func TestPostgresProviderChangeFeed(t *testing.T) {
+	config.RootConfigReset()
+	psql := &Postgres{}
+	conf := config.RootSection("unittest")
+	psql.InitConfig(conf)
+	conf.Set(sqlcommon.SQLConfDatasourceURL, "!bad connection")
+	conf.Set(PostgresConfChangeFeedEnabled, true)
+	ctx, cancel := context.WithCancel(context.Background())
+	err := psql.Init(ctx, conf)
+	assert.NoError(t, err)
+	assert.True(t, psql.Capabilities().ChangeFeed)
+	time.Sleep(10 * time.Millisecond) // allow the listener to report the failed connection attempt
+	cancel()
+	psql.WaitStop()
+}
+
+func TestPostgresProviderWaitStopNoChangeFeed(t *testing.T) {
+	psql := &Postgres{}
+	psql.WaitStop()
+}
+
+func TestPostgresProviderInitFail(t *testing.T) {
+	config.RootConfigReset()
+	psql := &Postgres{}
+	conf := config.RootSection("unittest")
+	psql.InitConfig(conf)
+	conf.Set(sqlcommon.SQLConfDatasourceURL, "!bad connection")
+	conf.Set(sqlcommon.SQLConfMigrationsAuto, true)
+	conf.Set(PostgresConfChangeFeedEnabled, true)
+	err := psql.Init(context.Background(), conf)
+	assert.Error(t, err)
+	assert.False(t, psql.Capabilities().ChangeFeed)
+}
+
+type testListener struct {
+	notifications chan *pq.Notification
+}
+
+func (tl *testListener) Listen(channel string) error {
+	return nil
+}
+
+func (tl *testListener) NotificationChannel() <-chan *pq.Notification {
+	return tl.notifications
+}
+
+func TestChangeFeedListen(t *testing.T) {
+	psql, _ := newMockChangeFeedPostgres(t)
+	tl := &testListener{notifications: make(chan *pq.Notification)}
+	close(tl.notifications)
+	psql.changeFeedDone = make(chan struct{})
+	psql.changeFeedListen(context.Background(), tl)
+	<-psql.changeFeedDone
+}
+
+func TestNotifyTx(t *testing.T) {
+	psql, mdb := newMockChangeFeedPostgres(t)
+	mdb.ExpectBegin()
+	mdb.ExpectExec("SELECT pg_notify").
+		WithArgs(changeFeedChannel, `{"origin":"origin1","collection":"events","namespace":"ns1","sequence":12}`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	ctx, tx, _, err := psql.BeginOrUseTx(context.Background())
+	assert.NoError(t, err)
+	err = psql.NotifyTx(ctx, tx, &sqlcommon.ChangeNotification{
+		Origin:     "origin1",
+		Collection: database.CollectionEvents,
+		Namespace:  "ns1",
+		Sequence:   12,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mdb.ExpectationsWereMet())
+}
+
+func TestNotifyTxFail(t *testing.T) {
+	psql, mdb := newMockChangeFeedPostgres(t)
+	mdb.ExpectBegin()
+	mdb.ExpectExec("SELECT pg_notify").WillReturnError(fmt.Errorf("pop"))
+	ctx, tx, _, err := psql.BeginOrUseTx(context.Background())
+	assert.NoError(t, err)
+	err = psql.NotifyTx(ctx, tx, &sqlcommon.ChangeNotification{})
+	assert.Regexp(t, "FF00245", err)
+	assert.NoError(t, mdb.ExpectationsWereMet())
+}
+
+func TestChangeFeedLoop(t *testing.T) {
+	psql, _ := newMockChangeFeedPostgres(t)
+	mcb := &databasemocks.Callbacks{}
+	psql.SetHandler("ns1", mcb)
+	mcb.On("OrderedUUIDCollectionNSEvent", database.CollectionMessages, core.ChangeEventTypeCreated, "ns1", (*fftypes.UUID)(nil), int64(5)).Return()
+
+	notifications := make(chan *pq.Notification, 3)
+	notifications <- nil
+	notifications <- &pq.Notification{Extra: "!json"}
+	notifications <- &pq.Notification{Extra: `{"origin":"other","collection":"messages","namespace":"ns1","sequence":5}`}
+	close(notifications)
+	psql.changeFeedLoop(context.Background(), notifications)
+
+	mcb.AssertExpectations(t)
+}
@@ -25,7 +25,14 @@ const (
 	defaultConnectionLimitPostgreSQL = 50
 )
 
+const (
+	// PostgresConfChangeFeedEnabled uses LISTEN/NOTIFY to wake pollers in other processes sharing the database
+	PostgresConfChangeFeedEnabled = "changeFeed.enabled"
+)
+
 func (psql *Postgres) InitConfig(config config.Section) {
 	psql.SQLCommon.InitConfig(psql, config)
 	config.SetDefault(sqlcommon.SQLConfMaxConnections, defaultConnectionLimitPostgreSQL)
+	config.SubSection(sqlcommon.SQLConfReadReplica).SetDefault(sqlcommon.SQLConfMaxConnections, defaultConnectionLimitPostgreSQL)
+	config.AddKnownKey(PostgresConfChangeFeedEnabled, false)
 }
@@ -37,6 +37,7 @@ import (
 
 type Postgres struct {
 	sqlcommon.SQLCommon
+	changeFeedDone chan struct{}
 }
 
 func (psql *Postgres) Init(ctx context.Context, config config.Section) error {
@@ -44,7 +45,22 @@ func (psql *Postgres) Init(ctx context.Context, config config.Section) error {
 	if config.GetInt(dbsql.SQLConfMaxConnections) > 1 {
 		capabilities.Concurrency = true
 	}
-	return psql.SQLCommon.Init(ctx, psql, config, capabilities)
+	if err := psql.SQLCommon.Init(ctx, psql, config, capabilities); err != nil {
+		return err
+	}
+	psql.EnableSearch(psql)
+	if config.GetBool(PostgresConfChangeFeedEnabled) {
+		psql.EnableChangeFeed(psql)
+		psql.startChangeFeed(ctx, config.GetString(sqlcommon.SQLConfDatasourceURL))
+	}
+	return nil
+}
+
+// WaitStop waits for the change feed listener to exit, after the context passed to Init is cancelled
+func (psql *Postgres) WaitStop() {
+	if psql.changeFeedDone != nil {
+		<-psql.changeFeedDone
+	}
 }
 
 func (psql *Postgres) SetHandler(namespace string, handler database.Callbacks) {
@@ -92,6 +108,13 @@ func (psql *Postgres) ApplyInsertQueryCustomizations(insert sq.InsertBuilder, re
 	return insert.Suffix(suffix), true
 }
 
+// SearchMatch uses the 'simple' text search configuration, without stemming or stop words, so identifiers
+// such as order numbers match exactly as well as words
+func (psql *Postgres) SearchMatch(text string) (match sq.Sqlizer, rank sq.Sqlizer) {
+	query := sq.Expr("websearch_to_tsquery('simple', ?)", text)
+	return sq.Expr("content_tsv @@ ?", query), sq.Expr("ts_rank(content_tsv, ?)", query)
+}
+
 func (psql *Postgres) Open(url string) (*sql.DB, error) {
 	return sql.Open(psql.Name(), url)
 }
@@ -35,6 +35,7 @@ func TestPostgresProvider(t *testing.T) {
 	config.Set(sqlcommon.SQLConfDatasourceURL, "!bad connection")
 	err := psql.Init(context.Background(), config)
 	assert.NoError(t, err)
+	assert.True(t, psql.Capabilities().Search)
 	_, err = psql.GetMigrationDriver(psql.DB())
 	assert.Error(t, err)
 
@@ -51,3 +52,12 @@ func TestPostgresProvider(t *testing.T) {
 	assert.Equal(t, "INSERT INTO test (col1) VALUES (?)  ON CONFLICT DO NOTHING RETURNING seq", sql)
 	assert.True(t, query)
 }
+
+func TestPostgresSearchMatch(t *testing.T) {
+	psql := &Postgres{}
+	match, rank := psql.SearchMatch("PO-1234")
+	sql, args, err := sq.Select("ref_id").Column(rank).From("search_index").Where(match).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT ref_id, ts_rank(content_tsv, websearch_to_tsquery('simple', ?)) FROM search_index WHERE content_tsv @@ websearch_to_tsquery('simple', ?)", sql)
+	assert.Equal(t, []interface{}{"PO-1234", "PO-1234"}, args)
+}
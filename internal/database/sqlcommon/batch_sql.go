@@ -36,7 +36,7 @@ var (
 		"btype",
 		"namespace",
 		"author",
-		"key",
+		`"key"`,
 		"group_hash",
 		"created",
 		"hash",
@@ -52,6 +52,7 @@ var (
 		"tx.id":   "tx_id",
 		"group":   "group_hash",
 		"node":    "node_id",
+		"key":     `"key"`,
 	}
 )
 
@@ -37,9 +37,11 @@ var (
 		"peer",
 		"size",
 		"data_id",
+		"exchange_ref",
 	}
 	blobFilterFieldMap = map[string]string{
-		"payloadref": "payload_ref",
+		"payloadref":  "payload_ref",
+		"exchangeref": "exchange_ref",
 	}
 )
 
@@ -69,6 +71,7 @@ func (s *SQLCommon) setBlobInsertValues(query sq.InsertBuilder, blob *core.Blob)
 		blob.Peer,
 		blob.Size,
 		blob.DataID,
+		blob.ExchangeRef,
 	)
 }
 
@@ -125,6 +128,7 @@ func (s *SQLCommon) blobResult(ctx context.Context, row *sql.Rows) (*core.Blob,
 		&blob.Peer,
 		&blob.Size,
 		&blob.DataID,
+		&blob.ExchangeRef,
 		&blob.Sequence,
 	)
 	if err != nil {
@@ -166,6 +170,28 @@ func (s *SQLCommon) GetBlobs(ctx context.Context, namespace string, filter ffapi
 
 }
 
+func (s *SQLCommon) UpdateBlob(ctx context.Context, sequence int64, update ffapi.Update) (err error) {
+
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	query, err := s.BuildUpdate(sq.Update(blobsTable), update, blobFilterFieldMap)
+	if err != nil {
+		return err
+	}
+	query = query.Where(sq.Eq{s.SequenceColumn(): sequence})
+
+	_, err = s.UpdateTx(ctx, blobsTable, tx, query, nil /* no change events for blobs */)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
 func (s *SQLCommon) DeleteBlob(ctx context.Context, sequence int64) (err error) {
 
 	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
@@ -77,6 +77,14 @@ func TestBlobsE2EWithDB(t *testing.T) {
 	assert.Equal(t, string(blobJson), string(blobReadJson))
 	assert.Equal(t, blob.Sequence, blobRes[0].Sequence)
 
+	// Record the copy of the blob in the data exchange
+	err = s.UpdateBlob(ctx, blob.Sequence, database.BlobQueryFactory.NewUpdate(ctx).Set("exchangeref", "ns1/blob1"))
+	assert.NoError(t, err)
+	blobs, _, err = s.GetBlobs(ctx, namespace, fb.Eq("exchangeref", "ns1/blob1"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(blobs))
+	assert.Equal(t, "ns1/blob1", blobs[0].ExchangeRef)
+
 	// Test delete
 	err = s.DeleteBlob(ctx, blob.Sequence)
 	assert.NoError(t, err)
@@ -234,3 +242,29 @@ func TestBlobDeleteFail(t *testing.T) {
 	err := s.DeleteBlob(context.Background(), 12345)
 	assert.Regexp(t, "FF00179", err)
 }
+
+func TestBlobUpdateBeginFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	u := database.BlobQueryFactory.NewUpdate(context.Background()).Set("exchangeref", "anything")
+	err := s.UpdateBlob(context.Background(), 12345, u)
+	assert.Regexp(t, "FF00175", err)
+}
+
+func TestBlobUpdateBuildQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	u := database.BlobQueryFactory.NewUpdate(context.Background()).Set("exchangeref", map[bool]bool{true: false})
+	err := s.UpdateBlob(context.Background(), 12345, u)
+	assert.Regexp(t, "FF00143.*exchangeref", err)
+}
+
+func TestBlobUpdateFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	u := database.BlobQueryFactory.NewUpdate(context.Background()).Set("exchangeref", "anything")
+	err := s.UpdateBlob(context.Background(), 12345, u)
+	assert.Regexp(t, "FF00178", err)
+}
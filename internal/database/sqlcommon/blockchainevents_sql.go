@@ -146,9 +146,9 @@ func (s *SQLCommon) InsertOrGetBlockchainEvent(ctx context.Context, event *core.
 	return nil, opErr
 }
 
-func (s *SQLCommon) blockchainEventResult(ctx context.Context, row *sql.Rows) (*core.BlockchainEvent, error) {
+func (s *SQLCommon) blockchainEventResult(ctx context.Context, row *sql.Rows, extra ...interface{}) (*core.BlockchainEvent, error) {
 	var event core.BlockchainEvent
-	err := row.Scan(
+	err := row.Scan(append([]interface{}{
 		&event.ID,
 		&event.Source,
 		&event.Namespace,
@@ -161,7 +161,7 @@ func (s *SQLCommon) blockchainEventResult(ctx context.Context, row *sql.Rows) (*
 		&event.TX.Type,
 		&event.TX.ID,
 		&event.TX.BlockchainID,
-	)
+	}, extra...)...)
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, blockchaineventsTable)
 	}
@@ -206,12 +206,17 @@ func (s *SQLCommon) GetBlockchainEventByProtocolID(ctx context.Context, ns strin
 
 func (s *SQLCommon) GetBlockchainEvents(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.BlockchainEvent, *ffapi.FilterResult, error) {
 
+	cursor := claimQueryCursor(ctx)
+	cols := cursor.withSequence(blockchainEventColumns, s.SequenceColumn())
 	query, fop, fi, err := s.FilterSelect(ctx, "",
-		sq.Select(blockchainEventColumns...).From(blockchaineventsTable),
+		sq.Select(cols...).From(blockchaineventsTable),
 		filter, blockchainEventFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"namespace": namespace})
 	if err != nil {
 		return nil, nil, err
 	}
+	if query, err = cursor.apply(ctx, query, fi, s.SequenceColumn()); err != nil {
+		return nil, nil, err
+	}
 
 	rows, tx, err := s.Query(ctx, blockchaineventsTable, query)
 	if err != nil {
@@ -221,12 +226,18 @@ func (s *SQLCommon) GetBlockchainEvents(ctx context.Context, namespace string, f
 
 	events := []*core.BlockchainEvent{}
 	for rows.Next() {
-		event, err := s.blockchainEventResult(ctx, rows)
+		var seq int64
+		event, err := s.blockchainEventResult(ctx, rows, cursor.scanSequence(&seq)...)
 		if err != nil {
 			return nil, nil, err
 		}
+		cursor.next(seq)
 		events = append(events, event)
 	}
 
 	return events, s.QueryRes(ctx, blockchaineventsTable, tx, fop, nil, fi), err
 }
+
+func (s *SQLCommon) PurgeBlockchainEvents(ctx context.Context, namespace string, filter ffapi.Filter) (int64, error) {
+	return s.purge(ctx, blockchaineventsTable, filter, blockchainEventFilterFieldMap, sq.Eq{"namespace": namespace})
+}
@@ -257,3 +257,42 @@ func TestGetBlockchainEventsScanFail(t *testing.T) {
 	assert.Regexp(t, "FF10121", err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestPurgeBlockchainEventsE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	oldEvent := &core.BlockchainEvent{
+		ID:         fftypes.NewUUID(),
+		Namespace:  "ns1",
+		Name:       "Changed",
+		ProtocolID: "000001",
+		Timestamp:  fftypes.UnixTime(1000000000),
+	}
+	newEvent := &core.BlockchainEvent{
+		ID:         fftypes.NewUUID(),
+		Namespace:  "ns1",
+		Name:       "Changed",
+		ProtocolID: "000002",
+		Timestamp:  fftypes.Now(),
+	}
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionBlockchainEvents, core.ChangeEventTypeCreated, "ns1", oldEvent.ID).Return()
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionBlockchainEvents, core.ChangeEventTypeCreated, "ns1", newEvent.ID).Return()
+	for _, ev := range []*core.BlockchainEvent{oldEvent, newEvent} {
+		_, err := s.InsertOrGetBlockchainEvent(ctx, ev)
+		assert.NoError(t, err)
+	}
+
+	fb := database.BlockchainEventQueryFactory.NewFilter(ctx)
+	count, err := s.PurgeBlockchainEvents(ctx, "ns1", fb.Lt("timestamp", fftypes.UnixTime(1500000000)))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	evs, _, err := s.GetBlockchainEvents(ctx, "ns1", fb.And())
+	assert.NoError(t, err)
+	assert.Len(t, evs, 1)
+	assert.Equal(t, newEvent.ID, evs[0].ID)
+
+	s.callbacks.AssertExpectations(t)
+}
@@ -0,0 +1,91 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/dbsql"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// ChangeFeedProvider is implemented by database providers that can propagate inserts to other
+// processes sharing the same database, such as PostgreSQL via LISTEN/NOTIFY
+type ChangeFeedProvider interface {
+	// NotifyTx publishes the notification as part of the transaction, so it is only delivered on commit
+	NotifyTx(ctx context.Context, tx *dbsql.TXWrapper, n *ChangeNotification) error
+}
+
+// ChangeNotification is the payload published to the change feed for an insert
+type ChangeNotification struct {
+	Origin     string                           `json:"origin"`
+	Collection database.OrderedUUIDCollectionNS `json:"collection"`
+	Namespace  string                           `json:"namespace"`
+	Sequence   int64                            `json:"sequence"`
+}
+
+type changeFeed struct {
+	provider ChangeFeedProvider
+	origin   string
+}
+
+// EnableChangeFeed reports the ChangeFeed capability, and publishes a notification to the provider
+// for the latest sequence of each message and event insert
+func (s *SQLCommon) EnableChangeFeed(provider ChangeFeedProvider) {
+	s.changeFeed = &changeFeed{
+		provider: provider,
+		origin:   fftypes.NewUUID().String(),
+	}
+	s.capabilities.ChangeFeed = true
+}
+
+func (s *SQLCommon) notifyChangeFeedTx(ctx context.Context, tx *dbsql.TXWrapper, collection database.OrderedUUIDCollectionNS, latest map[string]int64) error {
+	if s.changeFeed == nil {
+		return nil
+	}
+	for namespace, sequence := range latest {
+		err := s.changeFeed.provider.NotifyTx(ctx, tx, &ChangeNotification{
+			Origin:     s.changeFeed.origin,
+			Collection: collection,
+			Namespace:  namespace,
+			Sequence:   sequence,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DispatchChangeNotification passes a notification received from the change feed to the handler of
+// the namespace. Notifications published by this process are ignored, as the in-process event has
+// already been emitted on commit.
+func (s *SQLCommon) DispatchChangeNotification(ctx context.Context, n *ChangeNotification) {
+	if s.changeFeed == nil || n.Origin == s.changeFeed.origin {
+		return
+	}
+	s.callbacks.writeLock.Lock()
+	cb, ok := s.callbacks.handlers[n.Namespace]
+	s.callbacks.writeLock.Unlock()
+	if ok {
+		log.L(ctx).Debugf("Change feed notification for %s in namespace '%s' (sequence=%d)", n.Collection, n.Namespace, n.Sequence)
+		cb.OrderedUUIDCollectionNSEvent(n.Collection, core.ChangeEventTypeCreated, n.Namespace, nil, n.Sequence)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/dbsql"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+type testChangeFeed struct {
+	notifications []*ChangeNotification
+	err           error
+}
+
+func (tcf *testChangeFeed) NotifyTx(ctx context.Context, tx *dbsql.TXWrapper, n *ChangeNotification) error {
+	tcf.notifications = append(tcf.notifications, n)
+	return tcf.err
+}
+
+func TestChangeFeedEventsMultiRow(t *testing.T) {
+	s := newMockProvider()
+	s.multiRowInsert = true
+	s.fakePSQLInsert = true
+	s, mock := s.init()
+	tcf := &testChangeFeed{}
+	s.EnableChangeFeed(tcf)
+	assert.True(t, s.Capabilities().ChangeFeed)
+
+	ev1 := &core.Event{ID: fftypes.NewUUID(), Namespace: "ns1"}
+	ev2 := &core.Event{ID: fftypes.NewUUID(), Namespace: "ns1"}
+	s.callbacks.On("OrderedUUIDCollectionNSEvent", database.CollectionEvents, core.ChangeEventTypeCreated, "ns1", ev1.ID, int64(1001))
+	s.callbacks.On("OrderedUUIDCollectionNSEvent", database.CollectionEvents, core.ChangeEventTypeCreated, "ns1", ev2.ID, int64(1002))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("<acquire lock ns1>").WillReturnResult(driver.ResultNoRows)
+	mock.ExpectQuery("INSERT.*").WillReturnRows(sqlmock.NewRows([]string{s.SequenceColumn()}).
+		AddRow(int64(1001)).
+		AddRow(int64(1002)),
+	)
+	mock.ExpectCommit()
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(context.Background())
+	tx.SetPreCommitAccumulator(&eventsPCA{
+		s:      &s.SQLCommon,
+		events: []*core.Event{ev1, ev2},
+	})
+	assert.NoError(t, err)
+	err = s.CommitTx(ctx, tx, autoCommit)
+	assert.NoError(t, err)
+
+	assert.Len(t, tcf.notifications, 1)
+	assert.Equal(t, s.changeFeed.origin, tcf.notifications[0].Origin)
+	assert.Equal(t, database.CollectionEvents, tcf.notifications[0].Collection)
+	assert.Equal(t, "ns1", tcf.notifications[0].Namespace)
+	assert.Equal(t, int64(1002), tcf.notifications[0].Sequence)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	s.callbacks.AssertExpectations(t)
+}
+
+func TestChangeFeedEventsMultiRowNotifyFail(t *testing.T) {
+	s := newMockProvider()
+	s.multiRowInsert = true
+	s.fakePSQLInsert = true
+	s, mock := s.init()
+	s.EnableChangeFeed(&testChangeFeed{err: fmt.Errorf("pop")})
+
+	mock.ExpectBegin()
+	mock.ExpectExec("<acquire lock ns1>").WillReturnResult(driver.ResultNoRows)
+	mock.ExpectQuery("INSERT.*").WillReturnRows(sqlmock.NewRows([]string{s.SequenceColumn()}).AddRow(int64(1001)))
+	mock.ExpectRollback()
+	err := s.InsertEvent(context.Background(), &core.Event{ID: fftypes.NewUUID(), Namespace: "ns1"})
+	assert.Regexp(t, "pop", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChangeFeedEventsSingleRow(t *testing.T) {
+	s, mock := newMockProvider().init()
+	tcf := &testChangeFeed{}
+	s.EnableChangeFeed(tcf)
+
+	eventID := fftypes.NewUUID()
+	s.callbacks.On("OrderedUUIDCollectionNSEvent", database.CollectionEvents, core.ChangeEventTypeCreated, "ns1", eventID, int64(1001))
+	mock.ExpectBegin()
+	mock.ExpectExec("<acquire lock ns1>").WillReturnResult(driver.ResultNoRows)
+	mock.ExpectExec("INSERT .*").WillReturnResult(sqlmock.NewResult(1001, 1))
+	mock.ExpectCommit()
+	err := s.InsertEvent(context.Background(), &core.Event{ID: eventID, Namespace: "ns1"})
+	assert.NoError(t, err)
+
+	assert.Len(t, tcf.notifications, 1)
+	assert.Equal(t, int64(1001), tcf.notifications[0].Sequence)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	s.callbacks.AssertExpectations(t)
+}
+
+func TestChangeFeedMessagesMultiRow(t *testing.T) {
+	s := newMockProvider()
+	s.multiRowInsert = true
+	s.fakePSQLInsert = true
+	s, mock := s.init()
+	tcf := &testChangeFeed{}
+	s.EnableChangeFeed(tcf)
+
+	msg1 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID(), Namespace: "ns1"}, LocalNamespace: "ns1"}
+	msg2 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID(), Namespace: "ns1"}, LocalNamespace: "ns1"}
+	s.callbacks.On("OrderedUUIDCollectionNSEvent", database.CollectionMessages, core.ChangeEventTypeCreated, "ns1", msg1.Header.ID, int64(1001))
+	s.callbacks.On("OrderedUUIDCollectionNSEvent", database.CollectionMessages, core.ChangeEventTypeCreated, "ns1", msg2.Header.ID, int64(1002))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT.*messages").WillReturnRows(sqlmock.NewRows([]string{s.SequenceColumn()}).
+		AddRow(int64(1001)).
+		AddRow(int64(1002)),
+	)
+	mock.ExpectCommit()
+	err := s.InsertMessages(context.Background(), []*core.Message{msg1, msg2})
+	assert.NoError(t, err)
+
+	assert.Len(t, tcf.notifications, 1)
+	assert.Equal(t, database.CollectionMessages, tcf.notifications[0].Collection)
+	assert.Equal(t, int64(1002), tcf.notifications[0].Sequence)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	s.callbacks.AssertExpectations(t)
+}
+
+func TestChangeFeedMessagesMultiRowNotifyFail(t *testing.T) {
+	s := newMockProvider()
+	s.multiRowInsert = true
+	s.fakePSQLInsert = true
+	s, mock := s.init()
+	s.EnableChangeFeed(&testChangeFeed{err: fmt.Errorf("pop")})
+
+	msg1 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID(), Namespace: "ns1"}, LocalNamespace: "ns1"}
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT.*messages").WillReturnRows(sqlmock.NewRows([]string{s.SequenceColumn()}).AddRow(int64(1001)))
+	mock.ExpectRollback()
+	err := s.InsertMessages(context.Background(), []*core.Message{msg1})
+	assert.Regexp(t, "pop", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChangeFeedMessageSingleRowNotifyFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	tcf := &testChangeFeed{err: fmt.Errorf("pop")}
+	s.EnableChangeFeed(tcf)
+
+	msg1 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID(), Namespace: "ns1"}, LocalNamespace: "ns1"}
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT.*messages").WillReturnResult(sqlmock.NewResult(1001, 1))
+	mock.ExpectRollback()
+	err := s.InsertMessages(context.Background(), []*core.Message{msg1})
+	assert.Regexp(t, "pop", err)
+
+	assert.Len(t, tcf.notifications, 1)
+	assert.Equal(t, int64(1001), tcf.notifications[0].Sequence)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDispatchChangeNotification(t *testing.T) {
+	s, _ := newMockProvider().init()
+	mcb := &databasemocks.Callbacks{}
+	s.SetHandler("ns1", mcb)
+
+	// Ignored until the change feed is enabled
+	s.DispatchChangeNotification(context.Background(), &ChangeNotification{Origin: "other", Collection: database.CollectionEvents, Namespace: "ns1", Sequence: 12})
+
+	s.EnableChangeFeed(&testChangeFeed{})
+	mcb.On("OrderedUUIDCollectionNSEvent", database.CollectionEvents, core.ChangeEventTypeCreated, "ns1", (*fftypes.UUID)(nil), int64(12)).Return().Once()
+
+	// Our own notifications are ignored, as are those for namespaces without a handler
+	s.DispatchChangeNotification(context.Background(), &ChangeNotification{Origin: s.changeFeed.origin, Collection: database.CollectionEvents, Namespace: "ns1", Sequence: 11})
+	s.DispatchChangeNotification(context.Background(), &ChangeNotification{Origin: "other", Collection: database.CollectionEvents, Namespace: "ns2", Sequence: 11})
+	s.DispatchChangeNotification(context.Background(), &ChangeNotification{Origin: "other", Collection: database.CollectionEvents, Namespace: "ns1", Sequence: 12})
+
+	mcb.AssertExpectations(t)
+	s.callbacks.AssertExpectations(t)
+}
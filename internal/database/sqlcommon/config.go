@@ -38,6 +38,8 @@ const (
 	SQLConfMaxIdleConns = "maxIdleConns"
 	// SQLConfMaxConnLifetime maximum connections to the database
 	SQLConfMaxConnLifetime = "maxConnLifetime"
+	// SQLConfReadReplica is the sub-section for an optional read replica, used for read only API queries
+	SQLConfReadReplica = "readReplica"
 )
 
 const (
@@ -52,4 +54,11 @@ func (s *SQLCommon) InitConfig(provider dbsql.Provider, config config.Section) {
 	config.AddKnownKey(SQLConfMaxConnIdleTime, "1m")
 	config.AddKnownKey(SQLConfMaxIdleConns) // defaults to the max connections
 	config.AddKnownKey(SQLConfMaxConnLifetime)
+
+	replicaConf := config.SubSection(SQLConfReadReplica)
+	replicaConf.AddKnownKey(SQLConfDatasourceURL)
+	replicaConf.AddKnownKey(SQLConfMaxConnections) // some providers set a default
+	replicaConf.AddKnownKey(SQLConfMaxConnIdleTime, "1m")
+	replicaConf.AddKnownKey(SQLConfMaxIdleConns)
+	replicaConf.AddKnownKey(SQLConfMaxConnLifetime)
 }
@@ -0,0 +1,110 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// queryCursor applies the query cursor of an API request to a single query, and records where
+// the page of results returned by that query ended.
+//
+// The cursor only restricts the records returned, and not the total count, which is the count of
+// every record that matches the filter.
+type queryCursor struct {
+	qc         *database.QueryCursor
+	effective  bool
+	descending bool
+	limit      uint64
+	count      uint64
+}
+
+// claimQueryCursor returns the query cursor in the context, if there is one that has not been
+// used by an earlier query
+func claimQueryCursor(ctx context.Context) *queryCursor {
+	qc := database.GetQueryCursor(ctx)
+	if qc == nil || qc.Used {
+		return nil
+	}
+	qc.Used = true
+	return &queryCursor{qc: qc}
+}
+
+// withSequence adds the sequence column to the columns of a query that does not otherwise select it
+func (c *queryCursor) withSequence(cols []string, seqCol string) []string {
+	if c == nil {
+		return cols
+	}
+	return append(append([]string{}, cols...), seqCol)
+}
+
+// scanSequence returns the destination to scan the sequence column into, if it was added by withSequence
+func (c *queryCursor) scanSequence(seq *int64) []interface{} {
+	if c == nil {
+		return nil
+	}
+	return []interface{}{seq}
+}
+
+// apply restricts the query to the records after the cursor. It must be called with the finalized filter info
+// returned by FilterSelect, as the cursor can only be used when the records are sorted by sequence alone.
+func (c *queryCursor) apply(ctx context.Context, query sq.SelectBuilder, fi *ffapi.FilterInfo, seqCol string) (sq.SelectBuilder, error) {
+	if c == nil {
+		return query, nil
+	}
+	c.effective = len(fi.Sort) == 1 && (fi.Sort[0].Field == "sequence" || fi.Sort[0].Field == "seq")
+	c.limit = fi.Limit
+	after := c.qc.After
+	if after == nil {
+		if c.effective {
+			c.descending = fi.Sort[0].Descending
+		}
+		return query, nil
+	}
+	if !c.effective || fi.Skip > 0 {
+		return query, i18n.NewError(ctx, coremsgs.MsgCursorRequiresSequenceSort)
+	}
+	c.descending = fi.Sort[0].Descending
+	if after.Descending != c.descending {
+		return query, i18n.NewError(ctx, coremsgs.MsgInvalidCursor, "sort order has changed")
+	}
+	if c.descending {
+		return query.Where(sq.Lt{seqCol: after.Sequence}), nil
+	}
+	return query.Where(sq.Gt{seqCol: after.Sequence}), nil
+}
+
+// next counts the records returned by the query, and records the sequence of the last one as the cursor for
+// the next page once the page is full. A page with fewer records than the limit is the last, so has no cursor.
+func (c *queryCursor) next(seq int64) {
+	if c == nil || !c.effective {
+		return
+	}
+	c.count++
+	if c.count == c.limit {
+		c.qc.Next = &database.CursorPosition{
+			Sequence:   seq,
+			Descending: c.descending,
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCursorPagingOperations(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionOperations, core.ChangeEventTypeCreated, "ns1", mock.Anything).Return()
+	ids := make([]*fftypes.UUID, 5)
+	for i := range ids {
+		ids[i] = fftypes.NewUUID()
+		err := s.InsertOperation(ctx, &core.Operation{
+			ID:          ids[i],
+			Namespace:   "ns1",
+			Transaction: fftypes.NewUUID(),
+			Type:        core.OpTypeBlockchainPinBatch,
+			Status:      core.OpStatusPending,
+			Plugin:      "ethereum",
+			Created:     fftypes.Now(),
+		})
+		assert.NoError(t, err)
+	}
+
+	// Page through the operations, newest first, until a page that is not full
+	var read []*fftypes.UUID
+	var after *database.CursorPosition
+	pages := 0
+	for ; pages < 4; pages++ {
+		qc := &database.QueryCursor{After: after}
+		ops, res, err := s.GetOperations(database.WithQueryCursor(ctx, qc), "ns1", database.OperationQueryFactory.NewFilterLimit(ctx, 2).Count(true).And())
+		assert.NoError(t, err)
+		// The total is every operation that matches the filter, whatever the position of the cursor
+		assert.Equal(t, int64(5), *res.TotalCount)
+		for _, op := range ops {
+			read = append(read, op.ID)
+		}
+		if qc.Next == nil {
+			assert.Len(t, ops, 1)
+			break
+		}
+		assert.True(t, qc.Next.Descending)
+		after = qc.Next
+	}
+	assert.Equal(t, 2, pages)
+	assert.Equal(t, []*fftypes.UUID{ids[4], ids[3], ids[2], ids[1], ids[0]}, read)
+}
+
+func TestCursorPagingOperationsBadSort(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	qc := &database.QueryCursor{After: &database.CursorPosition{Sequence: 10, Descending: true}}
+	_, _, err := s.GetOperations(database.WithQueryCursor(ctx, qc), "ns1", database.OperationQueryFactory.NewFilter(ctx).And().Sort("created"))
+	assert.Regexp(t, "FF10539", err)
+}
+
+func TestCursorPagingEventsWithSkip(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	qc := &database.QueryCursor{After: &database.CursorPosition{Sequence: 10, Descending: true}}
+	_, _, err := s.GetEvents(database.WithQueryCursor(ctx, qc), "ns1", database.EventQueryFactory.NewFilter(ctx).And().Skip(1))
+	assert.Regexp(t, "FF10539", err)
+}
+
+func TestCursorPagingFullLastPage(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	s.callbacks.On("OrderedUUIDCollectionNSEvent", database.CollectionEvents, core.ChangeEventTypeCreated, "ns1", mock.Anything, mock.Anything).Return()
+	for i := 0; i < 2; i++ {
+		err := s.InsertEvent(ctx, &core.Event{ID: fftypes.NewUUID(), Namespace: "ns1", Type: core.EventTypeMessageConfirmed, Created: fftypes.Now()})
+		assert.NoError(t, err)
+	}
+
+	// A full page cannot know it is the last, so it has a cursor, and the next page is empty
+	qc := &database.QueryCursor{}
+	events, _, err := s.GetEvents(database.WithQueryCursor(ctx, qc), "ns1", database.EventQueryFactory.NewFilterLimit(ctx, 2).And())
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.NotNil(t, qc.Next)
+
+	qc = &database.QueryCursor{After: qc.Next}
+	events, _, err = s.GetEvents(database.WithQueryCursor(ctx, qc), "ns1", database.EventQueryFactory.NewFilterLimit(ctx, 2).And())
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Nil(t, qc.Next)
+}
+
+func TestCursorPagingEventsAscending(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	s.callbacks.On("OrderedUUIDCollectionNSEvent", database.CollectionEvents, core.ChangeEventTypeCreated, "ns1", mock.Anything, mock.Anything).Return()
+	for i := 0; i < 3; i++ {
+		err := s.InsertEvent(ctx, &core.Event{
+			ID:        fftypes.NewUUID(),
+			Namespace: "ns1",
+			Type:      core.EventTypeMessageConfirmed,
+			Created:   fftypes.Now(),
+		})
+		assert.NoError(t, err)
+	}
+
+	filter := func() ffapi.Filter {
+		return database.EventQueryFactory.NewFilterLimit(ctx, 2).And().Sort("sequence").Ascending()
+	}
+	qc := &database.QueryCursor{}
+	events, _, err := s.GetEvents(database.WithQueryCursor(ctx, qc), "ns1", filter())
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, &database.CursorPosition{Sequence: events[1].Sequence}, qc.Next)
+
+	qc = &database.QueryCursor{After: qc.Next}
+	events2, _, err := s.GetEvents(database.WithQueryCursor(ctx, qc), "ns1", filter())
+	assert.NoError(t, err)
+	assert.Len(t, events2, 1)
+	assert.Greater(t, events2[0].Sequence, events[1].Sequence)
+	assert.Nil(t, qc.Next)
+
+	// Only the first query made with the context uses the cursor
+	events3, _, err := s.GetEvents(database.WithQueryCursor(ctx, qc), "ns1", filter())
+	assert.NoError(t, err)
+	assert.Len(t, events3, 2)
+}
+
+func TestCursorPagingMessagesDefaultSort(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Messages are not sorted by sequence by default, so no cursor is returned
+	qc := &database.QueryCursor{}
+	_, _, err := s.GetMessages(database.WithQueryCursor(ctx, qc), "ns1", database.MessageQueryFactory.NewFilter(ctx).And())
+	assert.NoError(t, err)
+	assert.Nil(t, qc.Next)
+
+	qc = &database.QueryCursor{After: &database.CursorPosition{Sequence: 10, Descending: true}}
+	_, _, err = s.GetMessages(database.WithQueryCursor(ctx, qc), "ns1", database.MessageQueryFactory.NewFilter(ctx).And())
+	assert.Regexp(t, "FF10539", err)
+
+	qc = &database.QueryCursor{After: &database.CursorPosition{Sequence: 10, Descending: true}}
+	_, _, err = s.GetMessages(database.WithQueryCursor(ctx, qc), "ns1", database.MessageQueryFactory.NewFilter(ctx).And().Sort("-sequence"))
+	assert.NoError(t, err)
+}
+
+func TestCursorPagingWithSkip(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	qc := &database.QueryCursor{After: &database.CursorPosition{Sequence: 10, Descending: true}}
+	_, _, err := s.GetTransactions(database.WithQueryCursor(ctx, qc), "ns1", database.TransactionQueryFactory.NewFilter(ctx).And().Skip(1))
+	assert.Regexp(t, "FF10539", err)
+}
+
+func TestCursorPagingSortChanged(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	qc := &database.QueryCursor{After: &database.CursorPosition{Sequence: 10}}
+	_, _, err := s.GetBlockchainEvents(database.WithQueryCursor(ctx, qc), "ns1", database.BlockchainEventQueryFactory.NewFilter(ctx).And())
+	assert.Regexp(t, "FF10540", err)
+
+	qc = &database.QueryCursor{After: &database.CursorPosition{Sequence: 10}}
+	_, _, err = s.GetTokenTransfers(database.WithQueryCursor(ctx, qc), "ns1", database.TokenTransferQueryFactory.NewFilter(ctx).And())
+	assert.Regexp(t, "FF10540", err)
+}
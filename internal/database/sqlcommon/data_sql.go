@@ -129,11 +129,15 @@ func (s *SQLCommon) setDataInsertValues(query sq.InsertBuilder, data *core.Data)
 }
 
 func (s *SQLCommon) attemptDataInsert(ctx context.Context, tx *dbsql.TXWrapper, data *core.Data, requestConflictEmptyResult bool) (int64, error) {
-	return s.InsertTxExt(ctx, dataTable, tx,
+	sequence, err := s.InsertTxExt(ctx, dataTable, tx,
 		s.setDataInsertValues(sq.Insert(dataTable).Columns(dataColumnsWithValue...), data),
 		func() {
 			s.callbacks.UUIDCollectionNSEvent(database.CollectionData, core.ChangeEventTypeCreated, data.Namespace, data.ID)
 		}, requestConflictEmptyResult)
+	if err != nil {
+		return -1, err
+	}
+	return sequence, s.indexSearchTx(ctx, tx, dataSearchEntry(data))
 }
 
 func (s *SQLCommon) UpsertData(ctx context.Context, data *core.Data, optimization database.UpsertOptimization) (err error) {
@@ -216,6 +220,13 @@ func (s *SQLCommon) InsertDataArray(ctx context.Context, dataArray core.DataArra
 		if err != nil {
 			return err
 		}
+		searchEntries := make([]*searchEntry, len(dataArray))
+		for i, data := range dataArray {
+			searchEntries[i] = dataSearchEntry(data)
+		}
+		if err = s.indexSearchTx(ctx, tx, searchEntries...); err != nil {
+			return err
+		}
 	} else {
 		// Fall back to individual inserts grouped in a TX
 		for _, data := range dataArray {
@@ -434,6 +445,38 @@ func (s *SQLCommon) DeleteData(ctx context.Context, namespace string, id *fftype
 	if err != nil {
 		return err
 	}
+	if err = s.deleteSearchIndexTx(ctx, tx, namespace, core.SearchResultTypeData, id); err != nil {
+		return err
+	}
 
 	return s.CommitTx(ctx, tx, autoCommit)
 }
+
+func (s *SQLCommon) PurgeData(ctx context.Context, namespace string, filter ffapi.Filter) (int64, error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return -1, err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	// Data that is still referenced by a message is always retained
+	preconditions := []sq.Sqlizer{
+		sq.Eq{"namespace": namespace},
+		sq.Expr("NOT EXISTS (SELECT 1 FROM " + messagesDataJoinTable + " WHERE " + messagesDataJoinTable + ".data_id = " + dataTable + ".id)"),
+	}
+	if s.searchProvider != nil {
+		_, where, _, err := s.FilterSelect(ctx, "", sq.Select(), filter, dataFilterFieldMap, nil, preconditions...)
+		if err != nil {
+			return -1, err
+		}
+		if err = s.purgeSearchIndexTx(ctx, tx, namespace, core.SearchResultTypeData, sq.Select("id").From(dataTable).Where(where)); err != nil {
+			return -1, err
+		}
+	}
+
+	count, err := s.purgeTx(ctx, dataTable, tx, filter, dataFilterFieldMap, preconditions...)
+	if err != nil {
+		return -1, err
+	}
+	return count, s.CommitTx(ctx, tx, autoCommit)
+}
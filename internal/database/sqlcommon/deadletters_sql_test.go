@@ -0,0 +1,220 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeadLettersE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	deadLetter := &core.DeadLetter{
+		ID:           fftypes.NewUUID(),
+		Namespace:    "ns1",
+		Subscription: fftypes.NewUUID(),
+		Event:        fftypes.NewUUID(),
+		Reason:       "pop",
+	}
+
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionDeadLetters, core.ChangeEventTypeCreated, "ns1", deadLetter.ID).Return()
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionDeadLetters, core.ChangeEventTypeDeleted, "ns1", deadLetter.ID).Return()
+
+	err := s.InsertDeadLetter(ctx, deadLetter)
+	assert.NoError(t, err)
+	assert.NotNil(t, deadLetter.Created)
+	deadLetterJson, _ := json.Marshal(&deadLetter)
+
+	// Query back by ID
+	deadLetterRead, err := s.GetDeadLetterByID(ctx, "ns1", deadLetter.ID)
+	assert.NoError(t, err)
+	deadLetterReadJson, _ := json.Marshal(&deadLetterRead)
+	assert.Equal(t, string(deadLetterJson), string(deadLetterReadJson))
+
+	// Query back by subscription
+	fb := database.DeadLetterQueryFactory.NewFilter(ctx)
+	filter := fb.And(
+		fb.Eq("subscription", deadLetter.Subscription),
+		fb.Eq("event", deadLetter.Event),
+	)
+	deadLetters, res, err := s.GetDeadLetters(ctx, "ns1", filter.Count(true))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(deadLetters))
+	assert.Equal(t, int64(1), *res.TotalCount)
+	deadLetterReadJson, _ = json.Marshal(deadLetters[0])
+	assert.Equal(t, string(deadLetterJson), string(deadLetterReadJson))
+
+	// Other namespaces do not see it
+	deadLetterRead, err = s.GetDeadLetterByID(ctx, "ns2", deadLetter.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, deadLetterRead)
+
+	// Delete, and check it is gone
+	err = s.DeleteDeadLetterByID(ctx, "ns1", deadLetter.ID)
+	assert.NoError(t, err)
+	deadLetters, _, err = s.GetDeadLetters(ctx, "ns1", filter)
+	assert.NoError(t, err)
+	assert.Empty(t, deadLetters)
+}
+
+func TestDeleteDeadLettersE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	subID := fftypes.NewUUID()
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionDeadLetters, core.ChangeEventTypeCreated, "ns1", mock.Anything).Return()
+	for _, sub := range []*fftypes.UUID{subID, subID, fftypes.NewUUID()} {
+		err := s.InsertDeadLetter(ctx, &core.DeadLetter{
+			ID:           fftypes.NewUUID(),
+			Namespace:    "ns1",
+			Subscription: sub,
+			Event:        fftypes.NewUUID(),
+			Reason:       "pop",
+		})
+		assert.NoError(t, err)
+	}
+
+	err := s.DeleteDeadLetters(ctx, "ns1", subID)
+	assert.NoError(t, err)
+	fb := database.DeadLetterQueryFactory.NewFilter(ctx)
+	deadLetters, _, err := s.GetDeadLetters(ctx, "ns1", fb.And())
+	assert.NoError(t, err)
+	assert.Len(t, deadLetters, 1)
+	assert.NotEqual(t, *subID, *deadLetters[0].Subscription)
+
+	// Deleting when there are none is not an error
+	err = s.DeleteDeadLetters(ctx, "ns1", subID)
+	assert.NoError(t, err)
+}
+
+func TestInsertDeadLetterFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertDeadLetter(context.Background(), &core.DeadLetter{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertDeadLetterFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertDeadLetter(context.Background(), &core.DeadLetter{})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertDeadLetterFailCommit(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertDeadLetter(context.Background(), &core.DeadLetter{})
+	assert.Regexp(t, "FF00180", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDeadLetterByIDSelectFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetDeadLetterByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDeadLetterByIDScanFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, err := s.GetDeadLetterByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDeadLettersQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	f := database.DeadLetterQueryFactory.NewFilter(context.Background()).Eq("reason", "")
+	_, _, err := s.GetDeadLetters(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDeadLettersBuildQueryFail(t *testing.T) {
+	s, _ := newMockProvider().init()
+	f := database.DeadLetterQueryFactory.NewFilter(context.Background()).Eq("reason", map[bool]bool{true: false})
+	_, _, err := s.GetDeadLetters(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00143.*reason", err)
+}
+
+func TestGetDeadLettersScanFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	f := database.DeadLetterQueryFactory.NewFilter(context.Background()).Eq("reason", "")
+	_, _, err := s.GetDeadLetters(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteDeadLetterFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.DeleteDeadLetterByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteDeadLetterFailDelete(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.DeleteDeadLetterByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00179", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteDeadLettersFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.DeleteDeadLetters(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteDeadLettersFailDelete(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.DeleteDeadLetters(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00179", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
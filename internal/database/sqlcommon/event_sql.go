@@ -118,6 +118,7 @@ func (p *eventsPCA) PreCommit(ctx context.Context, tx *dbsql.TXWrapper) (err err
 		}
 	}
 
+	latest := make(map[string]int64)
 	if p.s.Features().MultiRowInsert {
 		query := sq.Insert(eventsTable).Columns(eventColumns...)
 		for _, event := range p.events {
@@ -133,6 +134,9 @@ func (p *eventsPCA) PreCommit(ctx context.Context, tx *dbsql.TXWrapper) (err err
 		if err != nil {
 			return err
 		}
+		for i, event := range p.events {
+			latest[event.Namespace] = max(latest[event.Namespace], sequences[i])
+		}
 	} else {
 		// Fall back to individual inserts grouped in a TX
 		for _, event := range p.events {
@@ -143,10 +147,11 @@ func (p *eventsPCA) PreCommit(ctx context.Context, tx *dbsql.TXWrapper) (err err
 			if err != nil {
 				return err
 			}
+			latest[event.Namespace] = max(latest[event.Namespace], event.Sequence)
 		}
 	}
 
-	return nil
+	return p.s.notifyChangeFeedTx(ctx, tx, database.CollectionEvents, latest)
 }
 
 func (s *SQLCommon) eventResult(ctx context.Context, row *sql.Rows) (*core.Event, error) {
@@ -196,13 +201,16 @@ func (s *SQLCommon) GetEventByID(ctx context.Context, namespace string, id *ffty
 	return event, nil
 }
 
-func (s *SQLCommon) getEventsGeneric(ctx context.Context, namespace string, sql sq.SelectBuilder, filter ffapi.Filter) (message []*core.Event, res *ffapi.FilterResult, err error) {
+func (s *SQLCommon) getEventsGeneric(ctx context.Context, namespace string, sql sq.SelectBuilder, filter ffapi.Filter, cursor *queryCursor) (message []*core.Event, res *ffapi.FilterResult, err error) {
 	query, fop, fi, err := s.FilterSelect(
 		ctx, "", sql,
 		filter, eventFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"namespace": namespace})
 	if err != nil {
 		return nil, nil, err
 	}
+	if query, err = cursor.apply(ctx, query, fi, s.SequenceColumn()); err != nil {
+		return nil, nil, err
+	}
 
 	rows, tx, err := s.Query(ctx, eventsTable, query)
 	if err != nil {
@@ -216,6 +224,7 @@ func (s *SQLCommon) getEventsGeneric(ctx context.Context, namespace string, sql
 		if err != nil {
 			return nil, nil, err
 		}
+		cursor.next(event.Sequence)
 		events = append(events, event)
 	}
 
@@ -229,7 +238,7 @@ func (s *SQLCommon) GetEvents(ctx context.Context, namespace string, filter ffap
 
 	query := sq.Select(cols...).From(eventsTable)
 
-	return s.getEventsGeneric(ctx, namespace, query, filter)
+	return s.getEventsGeneric(ctx, namespace, query, filter, claimQueryCursor(ctx))
 }
 
 func (s *SQLCommon) GetEventsInSequenceRange(ctx context.Context, namespace string, filter ffapi.Filter, startSequence int, endSequence int) (message []*core.Event, res *ffapi.FilterResult, err error) {
@@ -244,5 +253,9 @@ func (s *SQLCommon) GetEventsInSequenceRange(ctx context.Context, namespace stri
 		"seq": endSequence,
 	})
 
-	return s.getEventsGeneric(ctx, namespace, query, filter)
+	return s.getEventsGeneric(ctx, namespace, query, filter, nil)
+}
+
+func (s *SQLCommon) PurgeEvents(ctx context.Context, namespace string, filter ffapi.Filter) (int64, error) {
+	return s.purge(ctx, eventsTable, filter, eventFilterFieldMap, sq.Eq{"namespace": namespace})
 }
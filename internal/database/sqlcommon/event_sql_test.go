@@ -328,3 +328,30 @@ func TestGetEventsInSequenceRangeShouldCallGetEventsWhenNoSequencedProvidedAndTh
 	assert.NotNil(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestPurgeEventsE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	s.callbacks.On("OrderedUUIDCollectionNSEvent", database.CollectionEvents, core.ChangeEventTypeCreated, "ns1", mock.Anything, mock.Anything).Return()
+
+	ev1 := &core.Event{ID: fftypes.NewUUID(), Namespace: "ns1", Type: core.EventTypeMessageConfirmed, Created: fftypes.Now()}
+	ev2 := &core.Event{ID: fftypes.NewUUID(), Namespace: "ns1", Type: core.EventTypeMessageConfirmed, Created: fftypes.Now()}
+	ev3 := &core.Event{ID: fftypes.NewUUID(), Namespace: "ns2", Type: core.EventTypeMessageConfirmed, Created: fftypes.Now()}
+	s.callbacks.On("OrderedUUIDCollectionNSEvent", database.CollectionEvents, core.ChangeEventTypeCreated, "ns2", ev3.ID, mock.Anything).Return()
+	for _, ev := range []*core.Event{ev1, ev2, ev3} {
+		err := s.InsertEvent(ctx, ev)
+		assert.NoError(t, err)
+	}
+
+	count, err := s.PurgeEvents(ctx, "ns1", database.EventQueryFactory.NewFilter(ctx).Lte("sequence", ev2.Sequence))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	evs, _, err := s.GetEvents(ctx, "ns2", database.EventQueryFactory.NewFilter(ctx).And())
+	assert.NoError(t, err)
+	assert.Len(t, evs, 1)
+
+	s.callbacks.AssertExpectations(t)
+}
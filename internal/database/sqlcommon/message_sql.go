@@ -38,7 +38,7 @@ var (
 		"cid",
 		"mtype",
 		"author",
-		"key",
+		`"key"`,
 		"created",
 		"namespace",
 		"namespace_local",
@@ -68,6 +68,7 @@ var (
 		"group":          "group_hash",
 		"idempotencykey": "idempotency_key",
 		"rejectreason":   "reject_reason",
+		"key":            `"key"`,
 	}
 )
 
@@ -87,7 +88,7 @@ func (s *SQLCommon) attemptMessageUpdate(ctx context.Context, tx *dbsql.TXWrappe
 			Set("cid", message.Header.CID).
 			Set("mtype", string(message.Header.Type)).
 			Set("author", message.Header.Author).
-			Set("key", message.Header.Key).
+			Set(`"key"`, message.Header.Key).
 			Set("created", message.Header.Created).
 			Set("topics", message.Header.Topics).
 			Set("tag", message.Header.Tag).
@@ -156,7 +157,13 @@ func (s *SQLCommon) attemptMessageInsert(ctx context.Context, tx *dbsql.TXWrappe
 		func() {
 			s.callbacks.OrderedUUIDCollectionNSEvent(database.CollectionMessages, core.ChangeEventTypeCreated, message.LocalNamespace, message.Header.ID, message.Sequence)
 		}, requestConflictEmptyResult)
-	return err
+	if err != nil {
+		return err
+	}
+	if err = s.indexSearchTx(ctx, tx, messageSearchEntry(message)); err != nil {
+		return err
+	}
+	return s.notifyChangeFeedTx(ctx, tx, database.CollectionMessages, map[string]int64{message.LocalNamespace: message.Sequence})
 }
 
 func (s *SQLCommon) UpsertMessage(ctx context.Context, message *core.Message, optimization database.UpsertOptimization, hooks ...database.PostCompletionHook) (err error) {
@@ -269,6 +276,20 @@ func (s *SQLCommon) InsertMessages(ctx context.Context, messages []*core.Message
 		if err != nil {
 			return err
 		}
+		latest := make(map[string]int64)
+		for i, message := range messages {
+			latest[message.LocalNamespace] = max(latest[message.LocalNamespace], sequences[i])
+		}
+		if err = s.notifyChangeFeedTx(ctx, tx, database.CollectionMessages, latest); err != nil {
+			return err
+		}
+		searchEntries := make([]*searchEntry, len(messages))
+		for i, message := range messages {
+			searchEntries[i] = messageSearchEntry(message)
+		}
+		if err = s.indexSearchTx(ctx, tx, searchEntries...); err != nil {
+			return err
+		}
 
 		// Use a single multi-row insert for the data refs
 		if dataRefCount > 0 {
@@ -318,6 +339,11 @@ func (s *SQLCommon) ReplaceMessage(ctx context.Context, message *core.Message) (
 		return err
 	}
 
+	// The message is indexed again when it is inserted
+	if err = s.deleteSearchIndexTx(ctx, tx, message.LocalNamespace, core.SearchResultTypeMessage, message.Header.ID); err != nil {
+		return err
+	}
+
 	if err = s.attemptMessageInsert(ctx, tx, message, false); err != nil {
 		return err
 	}
@@ -505,7 +531,7 @@ func (s *SQLCommon) GetMessageByID(ctx context.Context, namespace string, id *ff
 	return msg, nil
 }
 
-func (s *SQLCommon) getMessagesQuery(ctx context.Context, namespace string, query sq.SelectBuilder, fop sq.Sqlizer, fi *ffapi.FilterInfo, allowCount bool) (message []*core.Message, fr *ffapi.FilterResult, err error) {
+func (s *SQLCommon) getMessagesQuery(ctx context.Context, namespace string, query sq.SelectBuilder, fop sq.Sqlizer, fi *ffapi.FilterInfo, allowCount bool, cursor *queryCursor) (message []*core.Message, fr *ffapi.FilterResult, err error) {
 	if fi.Count && !allowCount {
 		return nil, nil, i18n.NewError(ctx, coremsgs.MsgFilterCountNotSupported)
 	}
@@ -522,6 +548,7 @@ func (s *SQLCommon) getMessagesQuery(ctx context.Context, namespace string, quer
 		if err != nil {
 			return nil, nil, err
 		}
+		cursor.next(msg.Sequence)
 		msgs = append(msgs, msg)
 	}
 
@@ -606,7 +633,11 @@ func (s *SQLCommon) GetMessages(ctx context.Context, namespace string, filter ff
 	if err != nil {
 		return nil, nil, err
 	}
-	return s.getMessagesQuery(ctx, namespace, query, fop, fi, true)
+	cursor := claimQueryCursor(ctx)
+	if query, err = cursor.apply(ctx, query, fi, s.SequenceColumn()); err != nil {
+		return nil, nil, err
+	}
+	return s.getMessagesQuery(ctx, namespace, query, fop, fi, true, cursor)
 }
 
 func (s *SQLCommon) GetMessagesForData(ctx context.Context, namespace string, dataID *fftypes.UUID, filter ffapi.Filter) (message []*core.Message, fr *ffapi.FilterResult, err error) {
@@ -624,7 +655,7 @@ func (s *SQLCommon) GetMessagesForData(ctx context.Context, namespace string, da
 	}
 
 	query = query.LeftJoin("messages AS m ON m.id = md.message_id")
-	return s.getMessagesQuery(ctx, namespace, query, fop, fi, false)
+	return s.getMessagesQuery(ctx, namespace, query, fop, fi, false, nil)
 }
 
 func (s *SQLCommon) UpdateMessage(ctx context.Context, namespace string, msgid *fftypes.UUID, update ffapi.Update) (err error) {
@@ -656,3 +687,32 @@ func (s *SQLCommon) UpdateMessages(ctx context.Context, namespace string, filter
 
 	return s.CommitTx(ctx, tx, autoCommit)
 }
+
+func (s *SQLCommon) PurgeMessages(ctx context.Context, namespace string, filter ffapi.Filter) (int64, error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return -1, err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	// The data references are removed first, using the messages that match the filter as a sub-query
+	_, where, _, err := s.FilterSelect(ctx, "", sq.Select(), filter, msgFilterFieldMap, nil, sq.Eq{"namespace_local": namespace})
+	if err != nil {
+		return -1, err
+	}
+	if _, err = s.purgeTx(ctx, messagesDataJoinTable, tx, database.MessageQueryFactory.NewFilter(ctx).And(), nil,
+		sq.Eq{"namespace": namespace},
+		sq.Expr("message_id IN (?)", sq.Select("id").From(messagesTable).Where(where)),
+	); err != nil {
+		return -1, err
+	}
+	if err = s.purgeSearchIndexTx(ctx, tx, namespace, core.SearchResultTypeMessage, sq.Select("id").From(messagesTable).Where(where)); err != nil {
+		return -1, err
+	}
+
+	count, err := s.purgeTx(ctx, messagesTable, tx, filter, msgFilterFieldMap, sq.Eq{"namespace_local": namespace})
+	if err != nil {
+		return -1, err
+	}
+	return count, s.CommitTx(ctx, tx, autoCommit)
+}
@@ -709,3 +709,109 @@ func TestGetBatchIDsForMessagesScanFail(t *testing.T) {
 	assert.Regexp(t, "FF10121", err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestPurgeMessagesE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionData, core.ChangeEventTypeCreated, "ns1", mock.Anything, mock.Anything).Return()
+	s.callbacks.On("OrderedUUIDCollectionNSEvent", database.CollectionMessages, core.ChangeEventTypeCreated, "ns1", mock.Anything, mock.Anything).Return()
+
+	data := &core.Data{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Hash:      fftypes.NewRandB32(),
+		Created:   fftypes.Now(),
+	}
+	err := s.UpsertData(ctx, data, database.UpsertOptimizationNew)
+	assert.NoError(t, err)
+
+	msgConfirmed := &core.Message{
+		Header: core.MessageHeader{
+			ID:        fftypes.NewUUID(),
+			Namespace: "ns1",
+			Created:   fftypes.Now(),
+			DataHash:  fftypes.NewRandB32(),
+		},
+		Hash:           fftypes.NewRandB32(),
+		LocalNamespace: "ns1",
+		State:          core.MessageStateConfirmed,
+		Confirmed:      fftypes.Now(),
+		Data:           core.DataRefs{{ID: data.ID, Hash: data.Hash}},
+	}
+	msgPending := &core.Message{
+		Header: core.MessageHeader{
+			ID:        fftypes.NewUUID(),
+			Namespace: "ns1",
+			Created:   fftypes.Now(),
+			DataHash:  fftypes.NewRandB32(),
+		},
+		Hash:           fftypes.NewRandB32(),
+		LocalNamespace: "ns1",
+		State:          core.MessageStatePending,
+	}
+	err = s.InsertMessages(ctx, []*core.Message{msgConfirmed, msgPending})
+	assert.NoError(t, err)
+
+	// The data is referenced by a message, so is retained
+	count, err := s.PurgeData(ctx, "ns1", database.DataQueryFactory.NewFilter(ctx).And())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	fb := database.MessageQueryFactory.NewFilter(ctx)
+	count, err = s.PurgeMessages(ctx, "ns1", fb.Eq("state", core.MessageStateConfirmed))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	msgs, _, err := s.GetMessages(ctx, "ns1", fb.And())
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 1)
+	assert.Equal(t, msgPending.Header.ID, msgs[0].Header.ID)
+
+	// Once the reference has gone, the data can be purged
+	count, err = s.PurgeData(ctx, "ns1", database.DataQueryFactory.NewFilter(ctx).And())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	s.callbacks.AssertExpectations(t)
+}
+
+func TestPurgeMessagesFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	_, err := s.PurgeMessages(context.Background(), "ns1", database.MessageQueryFactory.NewFilter(context.Background()).And())
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeMessagesBuildQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	f := database.MessageQueryFactory.NewFilter(context.Background()).Eq("id", map[bool]bool{true: false})
+	_, err := s.PurgeMessages(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00143.*id", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeMessagesFailDeleteDataRefs(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.PurgeMessages(context.Background(), "ns1", database.MessageQueryFactory.NewFilter(context.Background()).And())
+	assert.Regexp(t, "FF00245", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeMessagesFailDeleteMessages(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.PurgeMessages(context.Background(), "ns1", database.MessageQueryFactory.NewFilter(context.Background()).And())
+	assert.Regexp(t, "FF00245", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -141,9 +141,9 @@ func (s *SQLCommon) InsertOperations(ctx context.Context, operations []*core.Ope
 
 }
 
-func (s *SQLCommon) opResult(ctx context.Context, row *sql.Rows) (*core.Operation, error) {
+func (s *SQLCommon) opResult(ctx context.Context, row *sql.Rows, extra ...interface{}) (*core.Operation, error) {
 	var op core.Operation
-	err := row.Scan(
+	err := row.Scan(append([]interface{}{
 		&op.ID,
 		&op.Namespace,
 		&op.Transaction,
@@ -156,7 +156,7 @@ func (s *SQLCommon) opResult(ctx context.Context, row *sql.Rows) (*core.Operatio
 		&op.Input,
 		&op.Output,
 		&op.Retry,
-	)
+	}, extra...)...)
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, operationsTable)
 	}
@@ -190,10 +190,15 @@ func (s *SQLCommon) GetOperationByID(ctx context.Context, namespace string, id *
 
 func (s *SQLCommon) GetOperations(ctx context.Context, namespace string, filter ffapi.Filter) (operation []*core.Operation, fr *ffapi.FilterResult, err error) {
 
-	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(opColumns...).From(operationsTable), filter, opFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	cursor := claimQueryCursor(ctx)
+	cols := cursor.withSequence(opColumns, s.SequenceColumn())
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(cols...).From(operationsTable), filter, opFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"namespace": namespace})
 	if err != nil {
 		return nil, nil, err
 	}
+	if query, err = cursor.apply(ctx, query, fi, s.SequenceColumn()); err != nil {
+		return nil, nil, err
+	}
 
 	rows, tx, err := s.Query(ctx, operationsTable, query)
 	if err != nil {
@@ -203,10 +208,12 @@ func (s *SQLCommon) GetOperations(ctx context.Context, namespace string, filter
 
 	ops := []*core.Operation{}
 	for rows.Next() {
-		op, err := s.opResult(ctx, rows)
+		var seq int64
+		op, err := s.opResult(ctx, rows, cursor.scanSequence(&seq)...)
 		if err != nil {
 			return nil, nil, err
 		}
+		cursor.next(seq)
 		ops = append(ops, op)
 	}
 
@@ -247,3 +254,7 @@ func (s *SQLCommon) UpdateOperation(ctx context.Context, ns string, id *fftypes.
 	}
 	return ra > 0, s.CommitTx(ctx, tx, autoCommit)
 }
+
+func (s *SQLCommon) PurgeOperations(ctx context.Context, namespace string, filter ffapi.Filter) (int64, error) {
+	return s.purge(ctx, operationsTable, filter, opFilterFieldMap, sq.Eq{"namespace": namespace})
+}
@@ -306,3 +306,29 @@ func TestInsertOperationsSingleRowFail(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 	s.callbacks.AssertExpectations(t)
 }
+
+func TestPurgeOperationsE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	op1 := &core.Operation{ID: fftypes.NewUUID(), Namespace: "ns1", Type: core.OpTypeBlockchainPinBatch, Transaction: fftypes.NewUUID(), Status: core.OpStatusSucceeded, Created: fftypes.Now()}
+	op2 := &core.Operation{ID: fftypes.NewUUID(), Namespace: "ns1", Type: core.OpTypeBlockchainPinBatch, Transaction: fftypes.NewUUID(), Status: core.OpStatusPending, Created: fftypes.Now()}
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionOperations, core.ChangeEventTypeCreated, "ns1", op1.ID).Return()
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionOperations, core.ChangeEventTypeCreated, "ns1", op2.ID).Return()
+	for _, op := range []*core.Operation{op1, op2} {
+		err := s.InsertOperation(ctx, op)
+		assert.NoError(t, err)
+	}
+
+	count, err := s.PurgeOperations(ctx, "ns1", database.OperationQueryFactory.NewFilter(ctx).Eq("status", core.OpStatusSucceeded))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	ops, _, err := s.GetOperations(ctx, "ns1", database.OperationQueryFactory.NewFilter(ctx).And())
+	assert.NoError(t, err)
+	assert.Len(t, ops, 1)
+	assert.Equal(t, op2.ID, ops[0].ID)
+
+	s.callbacks.AssertExpectations(t)
+}
@@ -0,0 +1,71 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/dbsql"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// readReplicaConfig never enables migrations, as they are applied to the primary and replicated from there
+type readReplicaConfig struct {
+	config.Section
+}
+
+func (rc *readReplicaConfig) GetBool(key string) bool {
+	if key == SQLConfMigrationsAuto {
+		return false
+	}
+	return rc.Section.GetBool(key)
+}
+
+func (s *SQLCommon) initReadReplica(ctx context.Context, provider dbsql.Provider, config config.Section) error {
+	if config.GetString(SQLConfDatasourceURL) == "" {
+		return nil
+	}
+	s.readReplica = &dbsql.Database{}
+	if err := s.readReplica.Init(ctx, provider, &readReplicaConfig{Section: config}); err != nil {
+		return err
+	}
+	log.L(ctx).Infof("Read only API queries will use the %s read replica", provider.Name())
+	return nil
+}
+
+// reader returns the read replica for read only API queries, or the primary for everything else.
+// A transaction on the context means read-after-write consistency is needed, so always uses the primary.
+// There is no read-your-writes guarantee across API calls, as the replica can lag behind the primary.
+func (s *SQLCommon) reader(ctx context.Context) *dbsql.Database {
+	if s.readReplica != nil && database.IsReadReplica(ctx) && dbsql.GetTXFromContext(ctx) == nil {
+		return s.readReplica
+	}
+	return &s.Database
+}
+
+func (s *SQLCommon) Query(ctx context.Context, table string, q sq.SelectBuilder) (*sql.Rows, *dbsql.TXWrapper, error) {
+	return s.reader(ctx).Query(ctx, table, q)
+}
+
+func (s *SQLCommon) QueryRes(ctx context.Context, table string, tx *dbsql.TXWrapper, fop sq.Sqlizer, qm dbsql.QueryModifier, fi *ffapi.FilterInfo) *ffapi.FilterResult {
+	return s.reader(ctx).QueryRes(ctx, table, tx, fop, qm, fi)
+}
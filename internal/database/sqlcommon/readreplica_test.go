@@ -0,0 +1,122 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+type readReplicaTestProvider struct {
+	*mockProvider
+	replicaDB        *sql.DB
+	replicaOpenError error
+}
+
+func (rp *readReplicaTestProvider) Open(url string) (*sql.DB, error) {
+	if url == "replica" {
+		return rp.replicaDB, rp.replicaOpenError
+	}
+	return rp.mockProvider.Open(url)
+}
+
+func newReadReplicaTestProvider(t *testing.T) (*readReplicaTestProvider, sqlmock.Sqlmock) {
+	mp := newMockProvider()
+	mp.config.SubSection(SQLConfReadReplica).Set(SQLConfDatasourceURL, "replica")
+	rp := &readReplicaTestProvider{mockProvider: mp}
+	var rdb sqlmock.Sqlmock
+	rp.replicaDB, rdb, _ = sqlmock.New()
+	return rp, rdb
+}
+
+func TestReadReplicaQueries(t *testing.T) {
+	rp, rdb := newReadReplicaTestProvider(t)
+	err := rp.Init(context.Background(), rp, rp.config, rp.capabilities)
+	assert.NoError(t, err)
+	assert.NotNil(t, rp.readReplica)
+	q := sq.Select("id").From("messages")
+
+	// Read only API queries, including the count, go to the replica
+	ctx := database.WithReadReplica(context.Background())
+	rdb.ExpectQuery("SELECT id FROM messages").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	rdb.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	rows, tx, err := rp.Query(ctx, "messages", q)
+	assert.NoError(t, err)
+	rows.Close()
+	res := rp.QueryRes(ctx, "messages", tx, sq.Eq{}, nil, &ffapi.FilterInfo{Count: true})
+	assert.Equal(t, int64(0), *res.TotalCount)
+	assert.NoError(t, rdb.ExpectationsWereMet())
+
+	// Everything else uses the primary
+	rp.mdb.ExpectQuery("SELECT id FROM messages").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	rows, _, err = rp.Query(context.Background(), "messages", q)
+	assert.NoError(t, err)
+	rows.Close()
+
+	// As do queries within a transaction, even if the context is marked
+	rp.mdb.ExpectBegin()
+	rp.mdb.ExpectQuery("SELECT id FROM messages").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	txCtx, _, _, err := rp.BeginOrUseTx(ctx)
+	assert.NoError(t, err)
+	rows, _, err = rp.Query(txCtx, "messages", q)
+	assert.NoError(t, err)
+	rows.Close()
+	assert.NoError(t, rp.mdb.ExpectationsWereMet())
+}
+
+func TestReadReplicaNotConfigured(t *testing.T) {
+	mp, mdb := newMockProvider().init()
+	assert.Nil(t, mp.readReplica)
+	mdb.ExpectQuery("SELECT id FROM messages").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	rows, _, err := mp.Query(database.WithReadReplica(context.Background()), "messages", sq.Select("id").From("messages"))
+	assert.NoError(t, err)
+	rows.Close()
+	assert.NoError(t, mdb.ExpectationsWereMet())
+}
+
+func TestReadReplicaInitFail(t *testing.T) {
+	rp, _ := newReadReplicaTestProvider(t)
+	rp.replicaOpenError = fmt.Errorf("pop")
+	err := rp.Init(context.Background(), rp, rp.config, rp.capabilities)
+	assert.Regexp(t, "FF00173.*pop", err)
+}
+
+func TestReadReplicaPrimaryInitFail(t *testing.T) {
+	rp, _ := newReadReplicaTestProvider(t)
+	rp.openError = fmt.Errorf("pop")
+	err := rp.Init(context.Background(), rp, rp.config, rp.capabilities)
+	assert.Regexp(t, "FF00173.*pop", err)
+	assert.Nil(t, rp.readReplica)
+}
+
+func TestReadReplicaConfigNoMigrations(t *testing.T) {
+	conf := config.RootSection("unittest.replica")
+	conf.AddKnownKey(SQLConfMigrationsAuto, true)
+	conf.AddKnownKey("other", true)
+	rc := &readReplicaConfig{Section: conf}
+	assert.False(t, rc.GetBool(SQLConfMigrationsAuto))
+	assert.True(t, rc.GetBool("other"))
+}
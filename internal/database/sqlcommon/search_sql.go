@@ -0,0 +1,202 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/dbsql"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// SearchProvider is implemented by database providers that maintain a full-text index over the content
+// column of the search_index table, such as a PostgreSQL tsvector column or a SQLite FTS4 table
+type SearchProvider interface {
+	// SearchMatch returns the condition that selects the rows of the index matching the text, and the
+	// expression that ranks them, where a higher rank is a better match
+	SearchMatch(text string) (match sq.Sqlizer, rank sq.Sqlizer)
+}
+
+var (
+	searchIndexColumns = []string{
+		"namespace",
+		"ref_type",
+		"ref_id",
+		"content",
+	}
+)
+
+const searchIndexTable = "search_index"
+
+type searchEntry struct {
+	namespace string
+	refType   core.SearchResultType
+	refID     *fftypes.UUID
+	content   string
+}
+
+// EnableSearch reports the Search capability, and indexes the headers of messages and the values of data
+// as they are inserted
+func (s *SQLCommon) EnableSearch(provider SearchProvider) {
+	s.searchProvider = provider
+	s.capabilities.Search = true
+}
+
+func messageSearchEntry(message *core.Message) *searchEntry {
+	words := append([]string{message.Header.Tag, message.Header.Author}, message.Header.Topics...)
+	return &searchEntry{
+		namespace: message.LocalNamespace,
+		refType:   core.SearchResultTypeMessage,
+		refID:     message.Header.ID,
+		content:   strings.TrimSpace(strings.Join(words, " ")),
+	}
+}
+
+// dataSearchEntry indexes the string and number values of the JSON, without the keys. Numbers keep the
+// text they were submitted with, so identifiers such as order numbers are not reformatted as floats.
+func dataSearchEntry(data *core.Data) *searchEntry {
+	var words []string
+	if data.Value != nil {
+		var value interface{}
+		decoder := json.NewDecoder(bytes.NewReader(data.Value.Bytes()))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err == nil {
+			words = appendSearchWords(words, value)
+		}
+	}
+	return &searchEntry{
+		namespace: data.Namespace,
+		refType:   core.SearchResultTypeData,
+		refID:     data.ID,
+		content:   strings.Join(words, " "),
+	}
+}
+
+func appendSearchWords(words []string, value interface{}) []string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			words = appendSearchWords(words, v[key])
+		}
+	case []interface{}:
+		for _, child := range v {
+			words = appendSearchWords(words, child)
+		}
+	case string:
+		words = append(words, v)
+	case json.Number:
+		words = append(words, v.String())
+	}
+	return words
+}
+
+func (s *SQLCommon) indexSearchTx(ctx context.Context, tx *dbsql.TXWrapper, entries ...*searchEntry) error {
+	if s.searchProvider == nil {
+		return nil
+	}
+	query := sq.Insert(searchIndexTable).Columns(searchIndexColumns...)
+	count := 0
+	for _, entry := range entries {
+		if entry.content == "" {
+			continue
+		}
+		if !s.Features().MultiRowInsert {
+			if _, err := s.InsertTx(ctx, searchIndexTable, tx,
+				sq.Insert(searchIndexTable).Columns(searchIndexColumns...).Values(entry.namespace, entry.refType, entry.refID, entry.content),
+				nil, // no change event
+			); err != nil {
+				return err
+			}
+			continue
+		}
+		query = query.Values(entry.namespace, entry.refType, entry.refID, entry.content)
+		count++
+	}
+	if count == 0 {
+		return nil
+	}
+	return s.InsertTxRows(ctx, searchIndexTable, tx, query, nil, make([]int64, count), false)
+}
+
+func (s *SQLCommon) deleteSearchIndexTx(ctx context.Context, tx *dbsql.TXWrapper, namespace string, refType core.SearchResultType, refID *fftypes.UUID) error {
+	if s.searchProvider == nil {
+		return nil
+	}
+	err := s.DeleteTx(ctx, searchIndexTable, tx,
+		sq.Delete(searchIndexTable).Where(sq.Eq{"namespace": namespace, "ref_type": refType, "ref_id": refID}),
+		nil, // no change event
+	)
+	if err != nil && err != fftypes.DeleteRecordNotFound {
+		return err
+	}
+	return nil
+}
+
+// purgeSearchIndexTx removes the entries for the resources selected by the sub-query, before they are purged
+func (s *SQLCommon) purgeSearchIndexTx(ctx context.Context, tx *dbsql.TXWrapper, namespace string, refType core.SearchResultType, refIDs sq.SelectBuilder) error {
+	if s.searchProvider == nil {
+		return nil
+	}
+	_, err := s.purgeTx(ctx, searchIndexTable, tx, database.MessageQueryFactory.NewFilter(ctx).And(), nil,
+		sq.Eq{"namespace": namespace, "ref_type": refType},
+		sq.Expr("ref_id IN (?)", refIDs),
+	)
+	return err
+}
+
+func (s *SQLCommon) Search(ctx context.Context, namespace string, text string, limit int) ([]*core.SearchResult, error) {
+	if s.searchProvider == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgSearchNotSupported)
+	}
+	match, rank := s.searchProvider.SearchMatch(text)
+	rows, _, err := s.Query(ctx, searchIndexTable,
+		sq.Select("ref_type", "ref_id").
+			Column(sq.Alias(rank, "rank")).
+			From(searchIndexTable).
+			Where(sq.And{sq.Eq{"namespace": namespace}, match}).
+			OrderBy("rank DESC").
+			Limit(uint64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []*core.SearchResult{}
+	for rows.Next() {
+		var result core.SearchResult
+		if err := rows.Scan(&result.Type, &result.ID, &result.Rank); err != nil {
+			return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, searchIndexTable)
+		}
+		results = append(results, &result)
+	}
+	return results, nil
+}
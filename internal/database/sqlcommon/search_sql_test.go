@@ -0,0 +1,344 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+type testSearchProvider struct{}
+
+func (tsp *testSearchProvider) SearchMatch(text string) (match sq.Sqlizer, rank sq.Sqlizer) {
+	return sq.Expr("content MATCHES ?", text), sq.Expr("score")
+}
+
+func newSearchMockProvider(multiRow bool) (*mockProvider, sqlmock.Sqlmock) {
+	s := newMockProvider()
+	s.multiRowInsert = multiRow
+	s.fakePSQLInsert = multiRow
+	s, mock := s.init()
+	s.EnableSearch(&testSearchProvider{})
+	return s, mock
+}
+
+func TestSearchEntries(t *testing.T) {
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:        fftypes.NewUUID(),
+			SignerRef: core.SignerRef{Author: "did:firefly:org/org1"},
+			Tag:       "purchase_order",
+			Topics:    fftypes.FFStringArray{"orders", "supplier1"},
+		},
+		LocalNamespace: "ns1",
+	}
+	entry := messageSearchEntry(msg)
+	assert.Equal(t, "ns1", entry.namespace)
+	assert.Equal(t, core.SearchResultTypeMessage, entry.refType)
+	assert.Equal(t, msg.Header.ID, entry.refID)
+	assert.Equal(t, "purchase_order did:firefly:org/org1 orders supplier1", entry.content)
+
+	data := &core.Data{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Value:     fftypes.JSONAnyPtr(`{"po": "PO-1234", "lines": [{"qty": 12345678, "sku": "abc"}], "paid": true}`),
+	}
+	entry = dataSearchEntry(data)
+	assert.Equal(t, core.SearchResultTypeData, entry.refType)
+	assert.Equal(t, data.ID, entry.refID)
+	assert.Equal(t, "12345678 abc PO-1234", entry.content)
+
+	entry = dataSearchEntry(&core.Data{Value: fftypes.JSONAnyPtr(`!json`)})
+	assert.Empty(t, entry.content)
+	entry = dataSearchEntry(&core.Data{})
+	assert.Empty(t, entry.content)
+}
+
+func TestSearchNotSupported(t *testing.T) {
+	s, _ := newMockProvider().init()
+	assert.False(t, s.Capabilities().Search)
+	_, err := s.Search(context.Background(), "ns1", "PO-1234", 10)
+	assert.Regexp(t, "FF10523", err)
+}
+
+func TestSearch(t *testing.T) {
+	s, mock := newSearchMockProvider(false)
+	assert.True(t, s.Capabilities().Search)
+	msgID := fftypes.NewUUID()
+	dataID := fftypes.NewUUID()
+	mock.ExpectQuery(`SELECT ref_type, ref_id, \(score\) AS rank FROM search_index WHERE \(namespace = \$1 AND content MATCHES \$2\) ORDER BY rank DESC LIMIT 10`).
+		WithArgs("ns1", "PO-1234").
+		WillReturnRows(sqlmock.NewRows([]string{"ref_type", "ref_id", "rank"}).
+			AddRow("data", dataID.String(), 0.9).
+			AddRow("message", msgID.String(), 0.5),
+		)
+	results, err := s.Search(context.Background(), "ns1", "PO-1234", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []*core.SearchResult{
+		{Type: core.SearchResultTypeData, ID: dataID, Rank: 0.9},
+		{Type: core.SearchResultTypeMessage, ID: msgID, Rank: 0.5},
+	}, results)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchQueryFail(t *testing.T) {
+	s, mock := newSearchMockProvider(false)
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.Search(context.Background(), "ns1", "PO-1234", 10)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchReadFail(t *testing.T) {
+	s, mock := newSearchMockProvider(false)
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"ref_type"}).AddRow("message"))
+	_, err := s.Search(context.Background(), "ns1", "PO-1234", 10)
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchIndexMessagesMultiRow(t *testing.T) {
+	s, mock := newSearchMockProvider(true)
+	msg1 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID(), Tag: "tag1"}, LocalNamespace: "ns1"}
+	msg2 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID(), Tag: "tag2"}, LocalNamespace: "ns1"}
+	s.callbacks.On("OrderedUUIDCollectionNSEvent", database.CollectionMessages, core.ChangeEventTypeCreated, "ns1", msg1.Header.ID, int64(1001))
+	s.callbacks.On("OrderedUUIDCollectionNSEvent", database.CollectionMessages, core.ChangeEventTypeCreated, "ns1", msg2.Header.ID, int64(1002))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT.*messages").WillReturnRows(sqlmock.NewRows([]string{s.SequenceColumn()}).
+		AddRow(int64(1001)).
+		AddRow(int64(1002)),
+	)
+	mock.ExpectQuery("INSERT.*search_index").
+		WithArgs("ns1", core.SearchResultTypeMessage, msg1.Header.ID, "tag1", "ns1", core.SearchResultTypeMessage, msg2.Header.ID, "tag2").
+		WillReturnRows(sqlmock.NewRows([]string{s.SequenceColumn()}).
+			AddRow(int64(1)).
+			AddRow(int64(2)),
+		)
+	mock.ExpectCommit()
+	err := s.InsertMessages(context.Background(), []*core.Message{msg1, msg2})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	s.callbacks.AssertExpectations(t)
+}
+
+func TestSearchIndexMessagesMultiRowFail(t *testing.T) {
+	s, mock := newSearchMockProvider(true)
+	msg1 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID(), Tag: "tag1"}, LocalNamespace: "ns1"}
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT.*messages").WillReturnRows(sqlmock.NewRows([]string{s.SequenceColumn()}).AddRow(int64(1001)))
+	mock.ExpectQuery("INSERT.*search_index").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertMessages(context.Background(), []*core.Message{msg1})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchIndexMessageSingleRowFail(t *testing.T) {
+	s, mock := newSearchMockProvider(false)
+	msg1 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID(), Tag: "tag1"}, LocalNamespace: "ns1"}
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT.*messages").WillReturnResult(sqlmock.NewResult(1001, 1))
+	mock.ExpectExec("INSERT.*search_index").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertMessages(context.Background(), []*core.Message{msg1})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchIndexDataMultiRow(t *testing.T) {
+	s, mock := newSearchMockProvider(true)
+	data1 := &core.Data{ID: fftypes.NewUUID(), Namespace: "ns1", Value: fftypes.JSONAnyPtr(`"PO-1234"`)}
+	data2 := &core.Data{ID: fftypes.NewUUID(), Namespace: "ns1"}
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionData, core.ChangeEventTypeCreated, "ns1", data1.ID)
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionData, core.ChangeEventTypeCreated, "ns1", data2.ID)
+
+	// Only the data with a value is indexed
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT.*data").WillReturnRows(sqlmock.NewRows([]string{s.SequenceColumn()}).
+		AddRow(int64(1001)).
+		AddRow(int64(1002)),
+	)
+	mock.ExpectQuery("INSERT.*search_index").
+		WithArgs("ns1", core.SearchResultTypeData, data1.ID, "PO-1234").
+		WillReturnRows(sqlmock.NewRows([]string{s.SequenceColumn()}).AddRow(int64(1)))
+	mock.ExpectCommit()
+	err := s.InsertDataArray(context.Background(), core.DataArray{data1, data2})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	s.callbacks.AssertExpectations(t)
+}
+
+func TestSearchIndexDataMultiRowFail(t *testing.T) {
+	s, mock := newSearchMockProvider(true)
+	data1 := &core.Data{ID: fftypes.NewUUID(), Namespace: "ns1", Value: fftypes.JSONAnyPtr(`"PO-1234"`)}
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT.*data").WillReturnRows(sqlmock.NewRows([]string{s.SequenceColumn()}).AddRow(int64(1001)))
+	mock.ExpectQuery("INSERT.*search_index").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertDataArray(context.Background(), core.DataArray{data1})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchIndexDataUpsert(t *testing.T) {
+	s, mock := newSearchMockProvider(false)
+	data1 := &core.Data{ID: fftypes.NewUUID(), Namespace: "ns1", Value: fftypes.JSONAnyPtr(`{"po": "PO-1234"}`)}
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionData, core.ChangeEventTypeCreated, "ns1", data1.ID)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT.*data").WillReturnResult(sqlmock.NewResult(1001, 1))
+	mock.ExpectExec("INSERT.*search_index").
+		WithArgs("ns1", core.SearchResultTypeData, data1.ID, "PO-1234").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	err := s.UpsertData(context.Background(), data1, database.UpsertOptimizationNew)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	s.callbacks.AssertExpectations(t)
+}
+
+func TestSearchIndexReplaceMessage(t *testing.T) {
+	s, mock := newSearchMockProvider(false)
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID(), Tag: "tag1"}, LocalNamespace: "ns1"}
+	s.callbacks.On("OrderedUUIDCollectionNSEvent", database.CollectionMessages, core.ChangeEventTypeCreated, "ns1", msg.Header.ID, int64(1001))
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM messages").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("DELETE FROM search_index").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT.*messages").WillReturnResult(sqlmock.NewResult(1001, 1))
+	mock.ExpectExec("INSERT.*search_index").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	err := s.ReplaceMessage(context.Background(), msg)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	s.callbacks.AssertExpectations(t)
+}
+
+func TestSearchIndexReplaceMessageDeleteFail(t *testing.T) {
+	s, mock := newSearchMockProvider(false)
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}, LocalNamespace: "ns1"}
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM messages").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("DELETE FROM search_index").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.ReplaceMessage(context.Background(), msg)
+	assert.Regexp(t, "FF00179", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchIndexDeleteData(t *testing.T) {
+	s, mock := newSearchMockProvider(false)
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM data").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("DELETE FROM search_index").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	err := s.DeleteData(context.Background(), "ns1", fftypes.NewUUID())
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchIndexDeleteDataFail(t *testing.T) {
+	s, mock := newSearchMockProvider(false)
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM data").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("DELETE FROM search_index").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.DeleteData(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00179", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchIndexPurgeMessages(t *testing.T) {
+	s, mock := newSearchMockProvider(false)
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM messages_data").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`DELETE FROM search_index WHERE .*ref_id IN \(SELECT id FROM messages`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM messages").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	count, err := s.PurgeMessages(context.Background(), "ns1", database.MessageQueryFactory.NewFilter(context.Background()).And())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchIndexPurgeMessagesFail(t *testing.T) {
+	s, mock := newSearchMockProvider(false)
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM messages_data").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("DELETE FROM search_index").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.PurgeMessages(context.Background(), "ns1", database.MessageQueryFactory.NewFilter(context.Background()).And())
+	assert.Regexp(t, "FF00245", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchIndexPurgeData(t *testing.T) {
+	s, mock := newSearchMockProvider(false)
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM search_index WHERE .*ref_id IN \(SELECT id FROM data`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM data").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	count, err := s.PurgeData(context.Background(), "ns1", database.DataQueryFactory.NewFilter(context.Background()).And())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchIndexPurgeDataFail(t *testing.T) {
+	s, mock := newSearchMockProvider(false)
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM search_index").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.PurgeData(context.Background(), "ns1", database.DataQueryFactory.NewFilter(context.Background()).And())
+	assert.Regexp(t, "FF00245", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchIndexPurgeDataBuildQueryFail(t *testing.T) {
+	s, mock := newSearchMockProvider(false)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	f := database.DataQueryFactory.NewFilter(context.Background()).Eq("id", map[bool]bool{true: false})
+	_, err := s.PurgeData(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00143.*id", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeDataFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	_, err := s.PurgeData(context.Background(), "ns1", database.DataQueryFactory.NewFilter(context.Background()).And())
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeDataFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM data").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.PurgeData(context.Background(), "ns1", database.DataQueryFactory.NewFilter(context.Background()).And())
+	assert.Regexp(t, "FF00245", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -20,9 +20,13 @@ import (
 	"context"
 	"sync"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/dbsql"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 
@@ -32,8 +36,20 @@ import (
 
 type SQLCommon struct {
 	dbsql.Database
-	capabilities *database.Capabilities
-	callbacks    callbacks
+	capabilities   *database.Capabilities
+	callbacks      callbacks
+	changeFeed     *changeFeed
+	readReplica    *dbsql.Database
+	searchProvider SearchProvider
+	lockProvider   LockProvider
+	insertIgnore   bool
+}
+
+// LockProvider is implemented by database providers that lock with a query taking the name of the lock
+// as a bound parameter, rather than the SQL string of the AcquireLock feature
+type LockProvider interface {
+	// LockQuery returns the query that holds the named lock until the end of the transaction
+	LockQuery(lockName string) sq.SelectBuilder
 }
 
 type callbacks struct {
@@ -79,7 +95,10 @@ func (cb *callbacks) HashCollectionNSEvent(resType database.HashCollectionNS, ev
 
 func (s *SQLCommon) Init(ctx context.Context, provider dbsql.Provider, config config.Section, capabilities *database.Capabilities) (err error) {
 	s.capabilities = capabilities
-	return s.Database.Init(ctx, provider, config)
+	if err = s.Database.Init(ctx, provider, config); err != nil {
+		return err
+	}
+	return s.initReadReplica(ctx, provider, config.SubSection(SQLConfReadReplica))
 }
 
 func (s *SQLCommon) SetHandler(namespace string, handler database.Callbacks) {
@@ -96,3 +115,83 @@ func (s *SQLCommon) SetHandler(namespace string, handler database.Callbacks) {
 }
 
 func (s *SQLCommon) Capabilities() *database.Capabilities { return s.capabilities }
+
+// EnableLockQuery uses the query of the provider in place of the AcquireLock feature to take locks
+func (s *SQLCommon) EnableLockQuery(provider LockProvider) {
+	s.lockProvider = provider
+}
+
+// EnableInsertIgnore is for providers without a RETURNING clause, which ignore a conflicting insert when
+// the caller requests an empty result on conflict. The conflict is then detected from the insert reporting
+// no sequence, and returned to the caller as the same error as an empty result.
+func (s *SQLCommon) EnableInsertIgnore() {
+	s.insertIgnore = true
+}
+
+func (s *SQLCommon) InsertTxExt(ctx context.Context, table string, tx *dbsql.TXWrapper, q sq.InsertBuilder, postCommit func(), requestConflictEmptyResult bool) (int64, error) {
+	if !s.insertIgnore || !requestConflictEmptyResult {
+		return s.Database.InsertTxExt(ctx, table, tx, q, postCommit, requestConflictEmptyResult)
+	}
+	inserted := false
+	sequence, err := s.Database.InsertTxExt(ctx, table, tx, q, func() {
+		if inserted && postCommit != nil {
+			postCommit()
+		}
+	}, requestConflictEmptyResult)
+	if err != nil {
+		return -1, err
+	}
+	if sequence <= 0 {
+		log.L(ctx).Infof("SQL insert into %s ignored on conflict", table)
+		return -1, i18n.WrapError(ctx, i18n.NewError(ctx, i18n.MsgDBNoSequence, 1), i18n.MsgDBInsertFailed)
+	}
+	inserted = true
+	return sequence, nil
+}
+
+func (s *SQLCommon) AcquireLockTx(ctx context.Context, lockName string, tx *dbsql.TXWrapper) error {
+	if s.lockProvider == nil {
+		return s.Database.AcquireLockTx(ctx, lockName, tx)
+	}
+	sqlQuery, args, err := s.lockProvider.LockQuery(lockName).PlaceholderFormat(s.Features().PlaceholderFormat).ToSql()
+	if err != nil {
+		return i18n.WrapError(ctx, err, i18n.MsgDBQueryBuildFailed)
+	}
+	if _, err := s.ExecTx(ctx, "lock", tx, sqlQuery, args); err != nil {
+		return i18n.WrapError(ctx, err, i18n.MsgDBLockFailed)
+	}
+	return nil
+}
+
+// purgeTx bulk deletes every row of the table that matches the filter and the preconditions, returning the
+// number of rows deleted. No change events are emitted, as purged rows are historical records.
+func (s *SQLCommon) purgeTx(ctx context.Context, table string, tx *dbsql.TXWrapper, filter ffapi.Filter, filterFieldMap map[string]string, preconditions ...sq.Sqlizer) (int64, error) {
+	_, where, _, err := s.FilterSelect(ctx, "", sq.Select(), filter, filterFieldMap, nil, preconditions...)
+	if err != nil {
+		return -1, err
+	}
+	sqlQuery, args, err := sq.Delete(table).Where(where).PlaceholderFormat(s.Features().PlaceholderFormat).ToSql()
+	if err != nil {
+		return -1, i18n.WrapError(ctx, err, i18n.MsgDBQueryBuildFailed)
+	}
+	res, err := s.ExecTx(ctx, table, tx, sqlQuery, args)
+	if err != nil {
+		return -1, err
+	}
+	return res.RowsAffected()
+}
+
+// purge runs purgeTx in its own transaction, or the one already on the context
+func (s *SQLCommon) purge(ctx context.Context, table string, filter ffapi.Filter, filterFieldMap map[string]string, preconditions ...sq.Sqlizer) (int64, error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return -1, err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	count, err := s.purgeTx(ctx, table, tx, filter, filterFieldMap, preconditions...)
+	if err != nil {
+		return -1, err
+	}
+	return count, s.CommitTx(ctx, tx, autoCommit)
+}
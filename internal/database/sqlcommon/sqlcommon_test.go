@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
@@ -114,3 +115,135 @@ func TestNamespaceCallbacks(t *testing.T) {
 	s.SetHandler("ns1", nil)
 	assert.Empty(t, s.callbacks.handlers)
 }
+
+func TestPurgeFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	_, err := s.PurgeEvents(context.Background(), "ns1", database.EventQueryFactory.NewFilter(context.Background()).And())
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeBuildQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	f := database.EventQueryFactory.NewFilter(context.Background()).Eq("id", map[bool]bool{true: false})
+	_, err := s.PurgeEvents(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00143.*id", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeFailDelete(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.PurgeEvents(context.Background(), "ns1", database.EventQueryFactory.NewFilter(context.Background()).And())
+	assert.Regexp(t, "FF00245", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeFailRowsAffected(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnResult(sqlmock.NewErrorResult(fmt.Errorf("pop")))
+	mock.ExpectRollback()
+	_, err := s.PurgeEvents(context.Background(), "ns1", database.EventQueryFactory.NewFilter(context.Background()).And())
+	assert.Regexp(t, "pop", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeBuildDeleteFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	_, err := s.purge(context.Background(), eventsTable, database.EventQueryFactory.NewFilter(context.Background()).And(), eventFilterFieldMap,
+		sq.Lt{"sequence": []int{1}})
+	assert.Regexp(t, "FF00174", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+type testLockProvider struct{}
+
+func (tlp *testLockProvider) LockQuery(lockName string) sq.SelectBuilder {
+	return sq.Select("seq").From("locks").Where(sq.Eq{"name": lockName}).Suffix("FOR UPDATE")
+}
+
+func TestAcquireLockQuery(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.EnableLockQuery(&testLockProvider{})
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT seq FROM locks WHERE name = \\$1 FOR UPDATE").WithArgs("n's").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	ctx, tx, ac, err := s.BeginOrUseTx(context.Background())
+	assert.NoError(t, err)
+	err = s.AcquireLockTx(ctx, "n's", tx)
+	assert.NoError(t, err)
+	err = s.CommitTx(ctx, tx, ac)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAcquireLockQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.EnableLockQuery(&testLockProvider{})
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	ctx, tx, _, err := s.BeginOrUseTx(context.Background())
+	assert.NoError(t, err)
+	err = s.AcquireLockTx(ctx, "ns1", tx)
+	assert.Regexp(t, "FF00187.*pop", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAcquireLockQueryBuildFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.EnableLockQuery(&testBadLockProvider{})
+	mock.ExpectBegin()
+	ctx, tx, _, err := s.BeginOrUseTx(context.Background())
+	assert.NoError(t, err)
+	err = s.AcquireLockTx(ctx, "ns1", tx)
+	assert.Regexp(t, "FF00174", err)
+}
+
+type testBadLockProvider struct{}
+
+func (tlp *testBadLockProvider) LockQuery(lockName string) sq.SelectBuilder {
+	return sq.Select()
+}
+
+func TestInsertIgnore(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.EnableInsertIgnore()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT .*").WillReturnResult(sqlmock.NewResult(12345, 1))
+	mock.ExpectExec("INSERT .*").WillReturnResult(sqlmock.NewResult(12346, 1))
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectCommit()
+	ctx, tx, ac, err := s.BeginOrUseTx(context.Background())
+	assert.NoError(t, err)
+
+	conflicted := false
+	_, err = s.InsertTxExt(ctx, "table1", tx, sq.Insert("table1").Columns("col1").Values("val1"), func() { conflicted = true }, true)
+	assert.Regexp(t, "FF00177.*FF00185", err)
+
+	inserted := false
+	sequence, err := s.InsertTxExt(ctx, "table1", tx, sq.Insert("table1").Columns("col1").Values("val2"), func() { inserted = true }, true)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12345), sequence)
+
+	sequence, err = s.InsertTxExt(ctx, "table1", tx, sq.Insert("table1").Columns("col1").Values("val3"), nil, true)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12346), sequence)
+
+	_, err = s.InsertTxExt(ctx, "table1", tx, sq.Insert("table1").Columns("col1").Values("val4"), nil, true)
+	assert.Regexp(t, "FF00177.*pop", err)
+
+	err = s.CommitTx(ctx, tx, ac)
+	assert.NoError(t, err)
+	assert.False(t, conflicted)
+	assert.True(t, inserted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
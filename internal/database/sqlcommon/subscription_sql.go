@@ -39,6 +39,7 @@ var (
 		"transport",
 		"filters",
 		"options",
+		"paused",
 		"created",
 		"updated",
 	}
@@ -120,6 +121,7 @@ func (s *SQLCommon) UpsertSubscription(ctx context.Context, subscription *core.S
 					subscription.Transport,
 					subscription.Filter,
 					subscription.Options,
+					subscription.Paused,
 					subscription.Created,
 					subscription.Updated,
 				),
@@ -144,6 +146,7 @@ func (s *SQLCommon) subscriptionResult(ctx context.Context, row *sql.Rows) (*cor
 		&subscription.Transport,
 		&subscription.Filter,
 		&subscription.Options,
+		&subscription.Paused,
 		&subscription.Created,
 		&subscription.Updated,
 	)
@@ -139,6 +139,16 @@ func TestSubscriptionsE2EWithDB(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(subscriptions))
 
+	// Pause, and check the flag is kept on a subsequent upsert
+	up = database.SubscriptionQueryFactory.NewUpdate(ctx).Set("paused", true)
+	err = s.UpdateSubscription(ctx, subscriptionUpdated.Namespace, subscriptionUpdated.Name, up)
+	assert.NoError(t, err)
+	err = s.UpsertSubscription(ctx, subscriptionUpdated, true)
+	assert.NoError(t, err)
+	subscriptionRead, err = s.GetSubscriptionByID(ctx, "ns1", subscriptionUpdated.ID)
+	assert.NoError(t, err)
+	assert.True(t, subscriptionRead.Paused)
+
 	// Test delete, and refind no return
 	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionSubscriptions, core.ChangeEventTypeDeleted, "ns1", subscription.ID).Return()
 	err = s.DeleteSubscriptionByID(ctx, "ns1", subscriptionUpdated.ID)
@@ -264,7 +274,7 @@ func TestSubscriptionUpdateBuildQueryFail(t *testing.T) {
 	s, mock := newMockProvider().init()
 	mock.ExpectBegin()
 	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows(subscriptionColumns).AddRow(
-		fftypes.NewUUID(), "ns1", "sub1", "websockets", `{}`, `{}`, fftypes.Now(), fftypes.Now()),
+		fftypes.NewUUID(), "ns1", "sub1", "websockets", `{}`, `{}`, false, fftypes.Now(), fftypes.Now()),
 	)
 	u := database.SubscriptionQueryFactory.NewUpdate(context.Background()).Set("name", map[bool]bool{true: false})
 	err := s.UpdateSubscription(context.Background(), "ns1", "name1", u)
@@ -295,7 +305,7 @@ func TestSubscriptionUpdateFail(t *testing.T) {
 	s, mock := newMockProvider().init()
 	mock.ExpectBegin()
 	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows(subscriptionColumns).AddRow(
-		fftypes.NewUUID(), "ns1", "sub1", "websockets", `{}`, `{}`, fftypes.Now(), fftypes.Now()),
+		fftypes.NewUUID(), "ns1", "sub1", "websockets", `{}`, `{}`, false, fftypes.Now(), fftypes.Now()),
 	)
 	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
 	mock.ExpectRollback()
@@ -315,7 +325,7 @@ func TestSubscriptionDeleteFail(t *testing.T) {
 	s, mock := newMockProvider().init()
 	mock.ExpectBegin()
 	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows(subscriptionColumns).AddRow(
-		fftypes.NewUUID(), "ns1", "sub1", "websockets", `{}`, `{}`, fftypes.Now(), fftypes.Now()),
+		fftypes.NewUUID(), "ns1", "sub1", "websockets", `{}`, `{}`, false, fftypes.Now(), fftypes.Now()),
 	)
 	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
 	err := s.DeleteSubscriptionByID(context.Background(), "ns1", fftypes.NewUUID())
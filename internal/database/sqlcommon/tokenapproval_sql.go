@@ -36,7 +36,7 @@ var (
 		"protocol_id",
 		"subject",
 		"active",
-		"key",
+		`"key"`,
 		"operator_key",
 		"pool_id",
 		"connector",
@@ -55,7 +55,7 @@ var (
 		"protocolid":      "protocol_id",
 		"pool":            "pool_id",
 		"approved":        "approved",
-		"key":             "key",
+		"key":             `"key"`,
 		"operator":        "operator_key",
 		"tx.type":         "tx_type",
 		"tx.id":           "tx_id",
@@ -95,7 +95,7 @@ func (s *SQLCommon) UpsertTokenApproval(ctx context.Context, approval *core.Toke
 				Set("local_id", approval.LocalID).
 				Set("subject", approval.Subject).
 				Set("active", approval.Active).
-				Set("key", approval.Key).
+				Set(`"key"`, approval.Key).
 				Set("operator_key", approval.Operator).
 				Set("pool_id", approval.Pool).
 				Set("connector", approval.Connector).
@@ -40,7 +40,7 @@ var (
 		"uri",
 		"connector",
 		"namespace",
-		"key",
+		`"key"`,
 		"from_key",
 		"to_key",
 		"amount",
@@ -65,6 +65,7 @@ var (
 		"tx.type":         "tx_type",
 		"tx.id":           "tx_id",
 		"blockchainevent": "blockchain_event",
+		"key":             `"key"`,
 	}
 )
 
@@ -126,9 +127,9 @@ func (s *SQLCommon) InsertOrGetTokenTransfer(ctx context.Context, transfer *core
 	return nil, opErr
 }
 
-func (s *SQLCommon) tokenTransferResult(ctx context.Context, row *sql.Rows) (*core.TokenTransfer, error) {
+func (s *SQLCommon) tokenTransferResult(ctx context.Context, row *sql.Rows, extra ...interface{}) (*core.TokenTransfer, error) {
 	transfer := core.TokenTransfer{}
-	err := row.Scan(
+	err := row.Scan(append([]interface{}{
 		&transfer.Type,
 		&transfer.LocalID,
 		&transfer.Pool,
@@ -147,7 +148,7 @@ func (s *SQLCommon) tokenTransferResult(ctx context.Context, row *sql.Rows) (*co
 		&transfer.TX.ID,
 		&transfer.BlockchainEvent,
 		&transfer.Created,
-	)
+	}, extra...)...)
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, tokentransferTable)
 	}
@@ -191,11 +192,16 @@ func (s *SQLCommon) GetTokenTransferByProtocolID(ctx context.Context, namespace
 }
 
 func (s *SQLCommon) GetTokenTransfers(ctx context.Context, namespace string, filter ffapi.Filter) (message []*core.TokenTransfer, fr *ffapi.FilterResult, err error) {
-	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(tokenTransferColumns...).From(tokentransferTable),
+	cursor := claimQueryCursor(ctx)
+	cols := cursor.withSequence(tokenTransferColumns, s.SequenceColumn())
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(cols...).From(tokentransferTable),
 		filter, tokenTransferFilterFieldMap, []interface{}{"seq"}, sq.Eq{"namespace": namespace})
 	if err != nil {
 		return nil, nil, err
 	}
+	if query, err = cursor.apply(ctx, query, fi, s.SequenceColumn()); err != nil {
+		return nil, nil, err
+	}
 
 	rows, tx, err := s.Query(ctx, tokentransferTable, query)
 	if err != nil {
@@ -205,10 +211,12 @@ func (s *SQLCommon) GetTokenTransfers(ctx context.Context, namespace string, fil
 
 	transfers := []*core.TokenTransfer{}
 	for rows.Next() {
-		d, err := s.tokenTransferResult(ctx, rows)
+		var seq int64
+		d, err := s.tokenTransferResult(ctx, rows, cursor.scanSequence(&seq)...)
 		if err != nil {
 			return nil, nil, err
 		}
+		cursor.next(seq)
 		transfers = append(transfers, d)
 	}
 
@@ -232,3 +240,7 @@ func (s *SQLCommon) DeleteTokenTransfers(ctx context.Context, namespace string,
 
 	return s.CommitTx(ctx, tx, autoCommit)
 }
+
+func (s *SQLCommon) PurgeTokenTransfers(ctx context.Context, namespace string, filter ffapi.Filter) (int64, error) {
+	return s.purge(ctx, tokentransferTable, filter, tokenTransferFilterFieldMap, sq.Eq{"namespace": namespace})
+}
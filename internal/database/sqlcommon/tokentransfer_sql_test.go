@@ -220,3 +220,25 @@ func TestDeleteTokenTransfersFailDelete(t *testing.T) {
 	assert.Regexp(t, "FF00179", err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestPurgeTokenTransfersE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	transfer := newTestTransfer()
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionTokenTransfers, core.ChangeEventTypeCreated, transfer.Namespace, transfer.LocalID, mock.Anything).Return()
+	_, err := s.InsertOrGetTokenTransfer(ctx, transfer)
+	assert.NoError(t, err)
+
+	fb := database.TokenTransferQueryFactory.NewFilter(ctx)
+	count, err := s.PurgeTokenTransfers(ctx, "ns1", fb.Eq("localid", transfer.LocalID))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	transfers, _, err := s.GetTokenTransfers(ctx, "ns1", fb.And())
+	assert.NoError(t, err)
+	assert.Empty(t, transfers)
+
+	s.callbacks.AssertExpectations(t)
+}
@@ -163,16 +163,16 @@ func (s *SQLCommon) InsertTransactions(ctx context.Context, txns []*core.Transac
 
 }
 
-func (s *SQLCommon) transactionResult(ctx context.Context, row *sql.Rows) (*core.Transaction, error) {
+func (s *SQLCommon) transactionResult(ctx context.Context, row *sql.Rows, extra ...interface{}) (*core.Transaction, error) {
 	var transaction core.Transaction
-	err := row.Scan(
+	err := row.Scan(append([]interface{}{
 		&transaction.ID,
 		&transaction.Type,
 		&transaction.Namespace,
 		&transaction.Created,
 		&transaction.IdempotencyKey,
 		&transaction.BlockchainIDs,
-	)
+	}, extra...)...)
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, transactionsTable)
 	}
@@ -206,10 +206,15 @@ func (s *SQLCommon) GetTransactionByID(ctx context.Context, namespace string, id
 
 func (s *SQLCommon) GetTransactions(ctx context.Context, namespace string, filter ffapi.Filter) (message []*core.Transaction, fr *ffapi.FilterResult, err error) {
 
-	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(transactionColumns...).From(transactionsTable), filter, transactionFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	cursor := claimQueryCursor(ctx)
+	cols := cursor.withSequence(transactionColumns, s.SequenceColumn())
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(cols...).From(transactionsTable), filter, transactionFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"namespace": namespace})
 	if err != nil {
 		return nil, nil, err
 	}
+	if query, err = cursor.apply(ctx, query, fi, s.SequenceColumn()); err != nil {
+		return nil, nil, err
+	}
 
 	rows, tx, err := s.Query(ctx, transactionsTable, query)
 	if err != nil {
@@ -219,10 +224,12 @@ func (s *SQLCommon) GetTransactions(ctx context.Context, namespace string, filte
 
 	transactions := []*core.Transaction{}
 	for rows.Next() {
-		transaction, err := s.transactionResult(ctx, rows)
+		var seq int64
+		transaction, err := s.transactionResult(ctx, rows, cursor.scanSequence(&seq)...)
 		if err != nil {
 			return nil, nil, err
 		}
+		cursor.next(seq)
 		transactions = append(transactions, transaction)
 	}
 
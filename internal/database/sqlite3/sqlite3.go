@@ -21,6 +21,8 @@ package sqlite3
 
 import (
 	"context"
+	"encoding/binary"
+	"strings"
 
 	"database/sql"
 
@@ -47,7 +49,32 @@ func connHook(conn *sqlite3.SQLiteConn) error {
 		PRAGMA case_sensitive_like=ON;
 		PRAGMA busy_timeout=1000;
 	`, nil)
-	return err
+	if err != nil {
+		return err
+	}
+	return conn.RegisterFunc("search_rank", searchRank, true)
+}
+
+// searchRank scores an FTS4 match from its matchinfo(search_index, 'pcx') blob, which is a list of native
+// byte order unsigned integers: the phrase count, the column count, then for each phrase and column the
+// hits in this row, the hits in all rows, and the rows with a hit. Each phrase scores the share of all its
+// hits that are in this row, so rarer words weigh more.
+func searchRank(matchinfo []byte) float64 {
+	values := make([]uint32, len(matchinfo)/4)
+	for i := range values {
+		values[i] = binary.NativeEndian.Uint32(matchinfo[i*4:])
+	}
+	if len(values) < 2 {
+		return 0
+	}
+	rank := 0.0
+	hits := values[2:]
+	for i := 0; i < int(values[0]*values[1]) && (i*3)+1 < len(hits); i++ {
+		if hits[(i*3)+1] > 0 {
+			rank += float64(hits[i*3]) / float64(hits[(i*3)+1])
+		}
+	}
+	return rank
 }
 
 func (sqlite *SQLite3) Init(ctx context.Context, config config.Section) error {
@@ -59,7 +86,11 @@ func (sqlite *SQLite3) Init(ctx context.Context, config config.Section) error {
 			})
 		ffSQLiteRegistered = true
 	}
-	return sqlite.SQLCommon.Init(ctx, sqlite, config, capabilities)
+	if err := sqlite.SQLCommon.Init(ctx, sqlite, config, capabilities); err != nil {
+		return err
+	}
+	sqlite.EnableSearch(sqlite)
+	return nil
 }
 
 func (sqlite *SQLite3) SetHandler(namespace string, handler database.Callbacks) {
@@ -89,6 +120,16 @@ func (sqlite *SQLite3) ApplyInsertQueryCustomizations(insert sq.InsertBuilder, r
 	return insert, false
 }
 
+// SearchMatch quotes each word of the text as an FTS4 phrase, so punctuation in identifiers is matched rather
+// than parsed as query syntax. FTS4 phrases cannot contain quotes, so those are removed.
+func (sqlite *SQLite3) SearchMatch(text string) (match sq.Sqlizer, rank sq.Sqlizer) {
+	words := strings.Fields(strings.ReplaceAll(text, `"`, " "))
+	for i, word := range words {
+		words[i] = `"` + word + `"`
+	}
+	return sq.Expr("search_index MATCH ?", strings.Join(words, " ")), sq.Expr("search_rank(matchinfo(search_index, 'pcx'))")
+}
+
 func (sqlite *SQLite3) Open(url string) (*sql.DB, error) {
 	return sql.Open("sqlite3_ff", url)
 }
@@ -21,12 +21,18 @@ package sqlite3
 
 import (
 	"context"
+	"encoding/binary"
 	"testing"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/dbsql"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/internal/database/sqlcommon"
 	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -60,4 +66,134 @@ func TestSQLite3GoProvider(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "INSERT INTO test (col1) VALUES (?)", sql)
 	assert.False(t, query)
+	assert.True(t, sqlite.Capabilities().Search)
+}
+
+func TestSQLite3InitFail(t *testing.T) {
+	sqlite := &SQLite3{}
+	config := config.RootSection("unittest.fail")
+	sqlite.InitConfig(config)
+	err := sqlite.Init(context.Background(), config)
+	assert.Regexp(t, "FF00183", err)
+	assert.False(t, sqlite.Capabilities().Search)
+}
+
+func TestSQLite3ConnHookFail(t *testing.T) {
+	driverConn, err := (&sqlite3.SQLiteDriver{}).Open("file::memory:")
+	assert.NoError(t, err)
+	conn := driverConn.(*sqlite3.SQLiteConn)
+	err = conn.Close()
+	assert.NoError(t, err)
+
+	err = connHook(conn)
+	assert.Error(t, err)
+}
+
+func TestSQLite3SearchMatch(t *testing.T) {
+	sqlite := &SQLite3{}
+	match, rank := sqlite.SearchMatch(` PO-1234  say"hi" `)
+	sql, args, err := sq.Select("ref_id").Column(rank).From("search_index").Where(match).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT ref_id, search_rank(matchinfo(search_index, 'pcx')) FROM search_index WHERE search_index MATCH ?", sql)
+	assert.Equal(t, []interface{}{`"PO-1234" "say" "hi"`}, args)
+}
+
+func TestSQLite3SearchRank(t *testing.T) {
+	matchinfo := func(values ...uint32) []byte {
+		b := make([]byte, len(values)*4)
+		for i, v := range values {
+			binary.NativeEndian.PutUint32(b[i*4:], v)
+		}
+		return b
+	}
+	// Two phrases over two columns, where the second column has no hits in any row
+	assert.Equal(t, 0.75, searchRank(matchinfo(2, 2, 1, 2, 2, 0, 0, 0, 1, 4, 3, 0, 0, 0)))
+	assert.Equal(t, 0.0, searchRank(matchinfo(1, 1)))
+	assert.Equal(t, 0.0, searchRank(nil))
+}
+
+func TestSQLite3SearchE2E(t *testing.T) {
+	ctx := context.Background()
+	sqlite := &SQLite3{}
+	config := config.RootSection("unittest.search")
+	sqlite.InitConfig(config)
+	config.Set(sqlcommon.SQLConfDatasourceURL, "file::memory:")
+	config.Set(sqlcommon.SQLConfMigrationsAuto, true)
+	config.Set(sqlcommon.SQLConfMigrationsDirectory, "../../../db/migrations/sqlite")
+	config.Set(dbsql.SQLConfMaxConnections, 1)
+	err := sqlite.Init(ctx, config)
+	assert.NoError(t, err)
+	defer sqlite.Close()
+	assert.True(t, sqlite.Capabilities().Search)
+
+	data1 := &core.Data{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Hash:      fftypes.NewRandB32(),
+		Created:   fftypes.Now(),
+		Value:     fftypes.JSONAnyPtr(`{"order": {"number": "PO-98765", "quantity": 12}}`),
+	}
+	data2 := &core.Data{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Hash:      fftypes.NewRandB32(),
+		Created:   fftypes.Now(),
+		Value:     fftypes.JSONAnyPtr(`{"orders": ["PO-98765", "PO-98765", "PO-12345"]}`),
+	}
+	err = sqlite.UpsertData(ctx, data1, database.UpsertOptimizationNew)
+	assert.NoError(t, err)
+	err = sqlite.UpsertData(ctx, data2, database.UpsertOptimizationNew)
+	assert.NoError(t, err)
+
+	results, err := sqlite.Search(ctx, "ns1", "PO-98765", 10)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, core.SearchResultTypeData, results[0].Type)
+	assert.Equal(t, data2.ID, results[0].ID)
+	assert.Equal(t, data1.ID, results[1].ID)
+	assert.Greater(t, results[0].Rank, results[1].Rank)
+
+	results, err = sqlite.Search(ctx, "ns2", "PO-98765", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+
+	err = sqlite.DeleteData(ctx, "ns1", data2.ID)
+	assert.NoError(t, err)
+	results, err = sqlite.Search(ctx, "ns1", "PO-98765", 10)
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	// Purging the data for retention removes it from the index
+	count, err := sqlite.PurgeData(ctx, "ns1", database.DataQueryFactory.NewFilter(ctx).And())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	results, err = sqlite.Search(ctx, "ns1", "PO-98765", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:        fftypes.NewUUID(),
+			Namespace: "ns1",
+			Tag:       "purchase_order",
+			Created:   fftypes.Now(),
+			DataHash:  fftypes.NewRandB32(),
+		},
+		Hash:           fftypes.NewRandB32(),
+		LocalNamespace: "ns1",
+		State:          core.MessageStateConfirmed,
+	}
+	err = sqlite.InsertMessages(ctx, []*core.Message{msg})
+	assert.NoError(t, err)
+	results, err = sqlite.Search(ctx, "ns1", "purchase_order", 10)
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	// Purging the messages for retention removes them from the index
+	count, err = sqlite.PurgeMessages(ctx, "ns1", database.MessageQueryFactory.NewFilter(ctx).And())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	results, err = sqlite.Search(ctx, "ns1", "purchase_order", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
 }
@@ -0,0 +1,308 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbverify
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+const (
+	CheckMessageData           = "message_data"
+	CheckPinBatch              = "pin_batch"
+	CheckBatchManifest         = "batch_manifest"
+	CheckNextPin               = "nextpin"
+	CheckTransactionOperations = "transaction_operations"
+	CheckTokenBalance          = "token_balance"
+)
+
+func (v *verifier) allChecks() []*check {
+	return []*check{
+		{name: CheckMessageData, run: v.checkMessageData},
+		{name: CheckPinBatch, run: v.checkPinBatches},
+		{name: CheckBatchManifest, run: v.checkBatchManifests},
+		{name: CheckNextPin, run: v.checkNextPins},
+		{name: CheckTransactionOperations, run: v.checkTransactionOperations},
+		{name: CheckTokenBalance, run: v.checkTokenBalances},
+	}
+}
+
+// checkMessageData checks that the data hash in each message header matches its data
+// references, and that every referenced data record exists with a matching hash
+func (v *verifier) checkMessageData(ctx context.Context, r *Report) error {
+	return v.forEachPage(ctx, database.MessageQueryFactory, func(filter ffapi.AndFilter) (int, error) {
+		msgs, _, err := v.database.GetMessages(ctx, v.namespace, filter)
+		if err != nil {
+			return 0, err
+		}
+		for _, msg := range msgs {
+			r.Checked["messages"]++
+			if msg.Hash != nil && !msg.Hash.Equals(msg.Header.Hash()) {
+				r.addIssue(CheckMessageData, "messages", msg.Header.ID, "message hash %s does not match the message header", msg.Hash)
+			}
+			if msg.Header.DataHash != nil && !msg.Header.DataHash.Equals(msg.Data.Hash()) {
+				r.addIssue(CheckMessageData, "messages", msg.Header.ID, "data hash %s does not match the data references of the message", msg.Header.DataHash)
+			}
+			for _, ref := range msg.Data {
+				if err := v.checkDataRef(ctx, r, CheckMessageData, "messages", msg.Header.ID, ref, true); err != nil {
+					return 0, err
+				}
+			}
+		}
+		return len(msgs), nil
+	})
+}
+
+// checkDataRef checks that a data record exists with the hash of the reference, and
+// optionally that the hash of the record matches its value
+func (v *verifier) checkDataRef(ctx context.Context, r *Report, checkName, collection string, id *fftypes.UUID, ref *core.DataRef, checkValue bool) error {
+	d, err := v.database.GetDataByID(ctx, v.namespace, ref.ID, checkValue)
+	if err != nil {
+		return err
+	}
+	switch {
+	case d == nil:
+		r.addIssue(checkName, collection, id, "data %s does not exist", ref.ID)
+	case !d.Hash.Equals(ref.Hash):
+		r.addIssue(checkName, collection, id, "data %s has hash %s, but is referenced with hash %s", ref.ID, d.Hash, ref.Hash)
+	case checkValue && d.Hash != nil:
+		if hash, err := d.CalcHash(ctx); err != nil || !hash.Equals(d.Hash) {
+			r.addIssue(checkName, collection, id, "hash of data %s does not match its value", ref.ID)
+		}
+	}
+	return nil
+}
+
+// checkPinBatches checks that the batch of every dispatched pin exists. Pins that have not been
+// dispatched can legitimately arrive before their batch, so are not checked.
+func (v *verifier) checkPinBatches(ctx context.Context, r *Report) error {
+	found := make(map[fftypes.UUID]bool)
+	return v.forEachPage(ctx, database.PinQueryFactory, func(filter ffapi.AndFilter) (int, error) {
+		pins, _, err := v.database.GetPins(ctx, v.namespace, filter)
+		if err != nil {
+			return 0, err
+		}
+		for _, pin := range pins {
+			r.Checked["pins"]++
+			if !pin.Dispatched || pin.Batch == nil {
+				continue
+			}
+			exists, checked := found[*pin.Batch]
+			if !checked {
+				batch, err := v.database.GetBatchByID(ctx, v.namespace, pin.Batch)
+				if err != nil {
+					return 0, err
+				}
+				exists = batch != nil
+				found[*pin.Batch] = exists
+			}
+			if !exists {
+				r.addIssue(CheckPinBatch, "pins", pin.Sequence, "pin %s was dispatched, but batch %s does not exist", pin.Hash, pin.Batch)
+			}
+		}
+		return len(pins), nil
+	})
+}
+
+// checkBatchManifests checks that every message and data record in the manifest of each batch
+// exists with the hash in the manifest, and that each message is assigned to the batch
+func (v *verifier) checkBatchManifests(ctx context.Context, r *Report) error {
+	return v.forEachPage(ctx, database.BatchQueryFactory, func(filter ffapi.AndFilter) (int, error) {
+		batches, _, err := v.database.GetBatches(ctx, v.namespace, filter)
+		if err != nil {
+			return 0, err
+		}
+		for _, batch := range batches {
+			r.Checked["batches"]++
+			var manifest core.BatchManifest
+			if batch.Manifest == nil || batch.Manifest.Unmarshal(ctx, &manifest) != nil {
+				r.addIssue(CheckBatchManifest, "batches", batch.ID, "manifest is missing or invalid")
+				continue
+			}
+			if !manifest.ID.Equals(batch.ID) {
+				r.addIssue(CheckBatchManifest, "batches", batch.ID, "manifest is for batch %s", manifest.ID)
+			}
+			for _, entry := range manifest.Messages {
+				msg, err := v.database.GetMessageByID(ctx, v.namespace, entry.ID)
+				if err != nil {
+					return 0, err
+				}
+				switch {
+				case msg == nil:
+					r.addIssue(CheckBatchManifest, "batches", batch.ID, "message %s does not exist", entry.ID)
+				case !msg.Hash.Equals(entry.Hash):
+					r.addIssue(CheckBatchManifest, "batches", batch.ID, "message %s has hash %s, but the manifest has hash %s", entry.ID, msg.Hash, entry.Hash)
+				case !msg.BatchID.Equals(batch.ID):
+					r.addIssue(CheckBatchManifest, "batches", batch.ID, "message %s is assigned to batch %s", entry.ID, msg.BatchID)
+				}
+			}
+			for _, ref := range manifest.Data {
+				if err := v.checkDataRef(ctx, r, CheckBatchManifest, "batches", batch.ID, ref, false); err != nil {
+					return 0, err
+				}
+			}
+		}
+		return len(batches), nil
+	})
+}
+
+// checkNextPins checks that there is only one next pin for each member of each context, and that
+// no next pin has already been dispatched, which would mean it was not moved on to the next nonce
+func (v *verifier) checkNextPins(ctx context.Context, r *Report) error {
+	type member struct {
+		context  fftypes.Bytes32
+		identity string
+	}
+	members := make(map[member]bool)
+	return v.forEachPage(ctx, database.NextPinQueryFactory, func(filter ffapi.AndFilter) (int, error) {
+		nextpins, _, err := v.database.GetNextPins(ctx, v.namespace, filter)
+		if err != nil {
+			return 0, err
+		}
+		for _, np := range nextpins {
+			r.Checked["nextpins"]++
+			if np.Context == nil || np.Hash == nil {
+				r.addIssue(CheckNextPin, "nextpins", np.Sequence, "next pin is missing its context or hash")
+				continue
+			}
+			m := member{context: *np.Context, identity: np.Identity}
+			if members[m] {
+				r.addIssue(CheckNextPin, "nextpins", np.Sequence, "context %s has more than one next pin for '%s'", np.Context, np.Identity)
+			}
+			members[m] = true
+
+			pins, _, err := v.database.GetPins(ctx, v.namespace, database.PinQueryFactory.NewFilter(ctx).And(
+				database.PinQueryFactory.NewFilter(ctx).Eq("hash", np.Hash),
+				database.PinQueryFactory.NewFilter(ctx).Eq("dispatched", true),
+			).Limit(1))
+			if err != nil {
+				return 0, err
+			}
+			if len(pins) > 0 {
+				r.addIssue(CheckNextPin, "nextpins", np.Sequence, "next pin %s with nonce %d for '%s' on context %s has already been dispatched", np.Hash, np.Nonce, np.Identity, np.Context)
+			}
+		}
+		return len(nextpins), nil
+	})
+}
+
+// checkTransactionOperations checks that every transaction has at least one operation. Transactions
+// that were submitted by other members of the network have no local operations, but do have the
+// blockchain IDs they were confirmed with, so only transactions with neither are reported.
+func (v *verifier) checkTransactionOperations(ctx context.Context, r *Report) error {
+	return v.forEachPage(ctx, database.TransactionQueryFactory, func(filter ffapi.AndFilter) (int, error) {
+		txns, _, err := v.database.GetTransactions(ctx, v.namespace, filter)
+		if err != nil {
+			return 0, err
+		}
+		for _, tx := range txns {
+			r.Checked["transactions"]++
+			if len(tx.BlockchainIDs) > 0 {
+				continue
+			}
+			ops, _, err := v.database.GetOperations(ctx, v.namespace, database.OperationQueryFactory.NewFilter(ctx).Eq("tx", tx.ID).Limit(1))
+			if err != nil {
+				return 0, err
+			}
+			if len(ops) == 0 {
+				r.addIssue(CheckTransactionOperations, "transactions", tx.ID, "%s transaction has no operations, and has not been confirmed on the blockchain", tx.Type)
+			}
+		}
+		return len(txns), nil
+	})
+}
+
+// checkTokenBalances checks that each token balance is the sum of the amounts transferred to the
+// account, less the amounts transferred from it, and that no account with a non-zero sum of
+// transfers is missing a balance
+func (v *verifier) checkTokenBalances(ctx context.Context, r *Report) error {
+	sums := make(map[string]*big.Int)
+	add := func(pool *fftypes.UUID, tokenIndex, key string, amount *big.Int, negate bool) {
+		id := core.TokenBalanceIdentifier(pool, tokenIndex, key)
+		sum, ok := sums[id]
+		if !ok {
+			sum = new(big.Int)
+			sums[id] = sum
+		}
+		if negate {
+			sum.Sub(sum, amount)
+		} else {
+			sum.Add(sum, amount)
+		}
+	}
+	err := v.forEachPage(ctx, database.TokenTransferQueryFactory, func(filter ffapi.AndFilter) (int, error) {
+		transfers, _, err := v.database.GetTokenTransfers(ctx, v.namespace, filter)
+		if err != nil {
+			return 0, err
+		}
+		for _, t := range transfers {
+			r.Checked["tokentransfers"]++
+			if t.From != "" {
+				add(t.Pool, t.TokenIndex, t.From, t.Amount.Int(), true)
+			}
+			if t.To != "" {
+				add(t.Pool, t.TokenIndex, t.To, t.Amount.Int(), false)
+			}
+		}
+		return len(transfers), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = v.forEachPage(ctx, database.TokenBalanceQueryFactory, func(filter ffapi.AndFilter) (int, error) {
+		balances, _, err := v.database.GetTokenBalances(ctx, v.namespace, filter)
+		if err != nil {
+			return 0, err
+		}
+		for _, b := range balances {
+			r.Checked["tokenbalances"]++
+			id := core.TokenBalanceIdentifier(b.Pool, b.TokenIndex, b.Key)
+			sum, ok := sums[id]
+			if !ok {
+				sum = new(big.Int)
+			}
+			delete(sums, id)
+			if b.Balance.Int().Cmp(sum) != 0 {
+				r.addIssue(CheckTokenBalance, "tokenbalances", id, "balance is %s, but the transfers sum to %s", b.Balance.Int(), sum)
+			}
+		}
+		return len(balances), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Anything left over has transfers but no balance, which is only consistent if the transfers cancel out
+	ids := make([]string, 0, len(sums))
+	for id := range sums {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if sums[id].Sign() != 0 {
+			r.addIssue(CheckTokenBalance, "tokenbalances", id, "there is no balance, but the transfers sum to %s", sums[id])
+		}
+	}
+	return nil
+}
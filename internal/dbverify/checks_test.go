@@ -0,0 +1,235 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbverify
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestReport() *Report {
+	return &Report{Namespace: "ns1", Checked: make(map[string]int64)}
+}
+
+func TestVerifyFail(t *testing.T) {
+	v, mdi := newTestVerifier(t)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	_, err := v.Verify(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestChecksListFail(t *testing.T) {
+	v, mdi := newTestVerifier(t)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetNextPins", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetTransactions", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetTokenTransfers", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	for _, c := range v.checks {
+		err := c.run(context.Background(), newTestReport())
+		assert.EqualError(t, err, "pop", c.name)
+	}
+}
+
+func TestCheckMessageDataHashes(t *testing.T) {
+	v, mdi := newTestVerifier(t)
+	ctx := context.Background()
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
+	data.Hash = fftypes.NewRandB32()
+	msg := &core.Message{
+		Header: core.MessageHeader{ID: fftypes.NewUUID(), DataHash: fftypes.NewRandB32()},
+		Hash:   fftypes.NewRandB32(),
+		Data:   core.DataRefs{{ID: data.ID, Hash: data.Hash}},
+	}
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	mdi.On("GetDataByID", mock.Anything, "ns1", data.ID, true).Return(data, nil)
+
+	r := newTestReport()
+	err := v.checkMessageData(ctx, r)
+	assert.NoError(t, err)
+	assert.Len(t, r.Issues, 3)
+	assert.Regexp(t, "does not match the message header", r.Issues[0].Detail)
+	assert.Regexp(t, "does not match the data references", r.Issues[1].Detail)
+	assert.Regexp(t, "does not match its value", r.Issues[2].Detail)
+}
+
+func TestCheckMessageDataRefMismatch(t *testing.T) {
+	v, mdi := newTestVerifier(t)
+	dataID := fftypes.NewUUID()
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}, Data: core.DataRefs{{ID: dataID, Hash: fftypes.NewRandB32()}}}
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	mdi.On("GetDataByID", mock.Anything, "ns1", dataID, true).Return(&core.Data{ID: dataID, Hash: fftypes.NewRandB32()}, nil)
+
+	r := newTestReport()
+	err := v.checkMessageData(context.Background(), r)
+	assert.NoError(t, err)
+	assert.Len(t, r.Issues, 1)
+	assert.Regexp(t, "but is referenced with hash", r.Issues[0].Detail)
+}
+
+func TestCheckMessageDataGetDataFail(t *testing.T) {
+	v, mdi := newTestVerifier(t)
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}, Data: core.DataRefs{{ID: fftypes.NewUUID()}}}
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	mdi.On("GetDataByID", mock.Anything, "ns1", mock.Anything, true).Return(nil, fmt.Errorf("pop"))
+	err := v.checkMessageData(context.Background(), newTestReport())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCheckPinBatches(t *testing.T) {
+	v, mdi := newTestVerifier(t)
+	batchID := fftypes.NewUUID()
+	pins := []*core.Pin{
+		{Sequence: 1, Batch: batchID, Dispatched: true},
+		{Sequence: 2, Batch: batchID, Dispatched: true},
+		{Sequence: 3, Batch: fftypes.NewUUID()},
+	}
+	mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return(pins, nil, nil)
+	mdi.On("GetBatchByID", mock.Anything, "ns1", batchID).Return(nil, nil).Once()
+
+	r := newTestReport()
+	err := v.checkPinBatches(context.Background(), r)
+	assert.NoError(t, err)
+	assert.Len(t, r.Issues, 2)
+	assert.Equal(t, int64(3), r.Checked["pins"])
+	mdi.AssertExpectations(t)
+}
+
+func TestCheckPinBatchesGetBatchFail(t *testing.T) {
+	v, mdi := newTestVerifier(t)
+	mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{{Batch: fftypes.NewUUID(), Dispatched: true}}, nil, nil)
+	mdi.On("GetBatchByID", mock.Anything, "ns1", mock.Anything).Return(nil, fmt.Errorf("pop"))
+	err := v.checkPinBatches(context.Background(), newTestReport())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCheckBatchManifests(t *testing.T) {
+	v, mdi := newTestVerifier(t)
+	batchID := fftypes.NewUUID()
+	msg1 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}, Hash: fftypes.NewRandB32(), BatchID: batchID}
+	msg2 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}, Hash: fftypes.NewRandB32(), BatchID: fftypes.NewUUID()}
+	data := &core.Data{ID: fftypes.NewUUID(), Hash: fftypes.NewRandB32()}
+	manifest := &core.BatchManifest{
+		ID: fftypes.NewUUID(),
+		Messages: []*core.MessageManifestEntry{
+			{MessageRef: core.MessageRef{ID: msg1.Header.ID, Hash: fftypes.NewRandB32()}},
+			{MessageRef: core.MessageRef{ID: msg2.Header.ID, Hash: msg2.Hash}},
+		},
+		Data: core.DataRefs{{ID: data.ID, Hash: data.Hash}},
+	}
+	batches := []*core.BatchPersisted{
+		{BatchHeader: core.BatchHeader{ID: batchID}, Manifest: fftypes.JSONAnyPtr(manifest.String())},
+		{BatchHeader: core.BatchHeader{ID: fftypes.NewUUID()}, Manifest: fftypes.JSONAnyPtr(`!json`)},
+		{BatchHeader: core.BatchHeader{ID: fftypes.NewUUID()}},
+	}
+	mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return(batches, nil, nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", msg1.Header.ID).Return(msg1, nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", msg2.Header.ID).Return(msg2, nil)
+	mdi.On("GetDataByID", mock.Anything, "ns1", data.ID, false).Return(data, nil)
+
+	r := newTestReport()
+	err := v.checkBatchManifests(context.Background(), r)
+	assert.NoError(t, err)
+	assert.Len(t, r.Issues, 5)
+	assert.Regexp(t, "manifest is for batch", r.Issues[0].Detail)
+	assert.Regexp(t, "but the manifest has hash", r.Issues[1].Detail)
+	assert.Regexp(t, "is assigned to batch", r.Issues[2].Detail)
+	assert.Regexp(t, "missing or invalid", r.Issues[3].Detail)
+	assert.Regexp(t, "missing or invalid", r.Issues[4].Detail)
+}
+
+func TestCheckBatchManifestsGetMessageFail(t *testing.T) {
+	v, mdi := newTestVerifier(t)
+	batchID := fftypes.NewUUID()
+	manifest := &core.BatchManifest{ID: batchID, Messages: []*core.MessageManifestEntry{{MessageRef: core.MessageRef{ID: fftypes.NewUUID()}}}}
+	mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return([]*core.BatchPersisted{
+		{BatchHeader: core.BatchHeader{ID: batchID}, Manifest: fftypes.JSONAnyPtr(manifest.String())},
+	}, nil, nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", mock.Anything).Return(nil, fmt.Errorf("pop"))
+	err := v.checkBatchManifests(context.Background(), newTestReport())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCheckBatchManifestsGetDataFail(t *testing.T) {
+	v, mdi := newTestVerifier(t)
+	batchID := fftypes.NewUUID()
+	manifest := &core.BatchManifest{ID: batchID, Data: core.DataRefs{{ID: fftypes.NewUUID()}}}
+	mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return([]*core.BatchPersisted{
+		{BatchHeader: core.BatchHeader{ID: batchID}, Manifest: fftypes.JSONAnyPtr(manifest.String())},
+	}, nil, nil)
+	mdi.On("GetDataByID", mock.Anything, "ns1", mock.Anything, false).Return(nil, fmt.Errorf("pop"))
+	err := v.checkBatchManifests(context.Background(), newTestReport())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCheckNextPinsMissingFields(t *testing.T) {
+	v, mdi := newTestVerifier(t)
+	mdi.On("GetNextPins", mock.Anything, "ns1", mock.Anything).Return([]*core.NextPin{{Sequence: 1}}, nil, nil)
+	r := newTestReport()
+	err := v.checkNextPins(context.Background(), r)
+	assert.NoError(t, err)
+	assert.Len(t, r.Issues, 1)
+	assert.Regexp(t, "missing its context or hash", r.Issues[0].Detail)
+}
+
+func TestCheckNextPinsGetPinsFail(t *testing.T) {
+	v, mdi := newTestVerifier(t)
+	mdi.On("GetNextPins", mock.Anything, "ns1", mock.Anything).Return([]*core.NextPin{{Context: fftypes.NewRandB32(), Hash: fftypes.NewRandB32()}}, nil, nil)
+	mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	err := v.checkNextPins(context.Background(), newTestReport())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCheckTransactionOperationsFail(t *testing.T) {
+	v, mdi := newTestVerifier(t)
+	mdi.On("GetTransactions", mock.Anything, "ns1", mock.Anything).Return([]*core.Transaction{{ID: fftypes.NewUUID()}}, nil, nil)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	err := v.checkTransactionOperations(context.Background(), newTestReport())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCheckTokenBalancesFail(t *testing.T) {
+	v, mdi := newTestVerifier(t)
+	mdi.On("GetTokenTransfers", mock.Anything, "ns1", mock.Anything).Return([]*core.TokenTransfer{}, nil, nil)
+	mdi.On("GetTokenBalances", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	err := v.checkTokenBalances(context.Background(), newTestReport())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCheckTokenBalancesWithoutTransfers(t *testing.T) {
+	v, mdi := newTestVerifier(t)
+	pool := fftypes.NewUUID()
+	mdi.On("GetTokenTransfers", mock.Anything, "ns1", mock.Anything).Return([]*core.TokenTransfer{}, nil, nil)
+	mdi.On("GetTokenBalances", mock.Anything, "ns1", mock.Anything).Return([]*core.TokenBalance{
+		{Pool: pool, TokenIndex: "1", Key: "0x1", Balance: *fftypes.NewFFBigInt(0)},
+		{Pool: pool, TokenIndex: "1", Key: "0x2", Balance: *fftypes.NewFFBigInt(5)},
+	}, nil, nil)
+	r := newTestReport()
+	err := v.checkTokenBalances(context.Background(), r)
+	assert.NoError(t, err)
+	assert.Len(t, r.Issues, 1)
+	assert.Regexp(t, "balance is 5, but the transfers sum to 0", r.Issues[0].Detail)
+	assert.Equal(t, int64(2), r.Checked["tokenbalances"])
+}
@@ -0,0 +1,126 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbverify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+const defaultPageSize = 500
+
+// Issue is a single inconsistency found in the database
+type Issue struct {
+	Check      string `json:"check"`
+	Collection string `json:"collection"`
+	ID         string `json:"id"`
+	Detail     string `json:"detail"`
+}
+
+// Report is the result of verifying a namespace
+type Report struct {
+	Namespace string           `json:"namespace"`
+	Started   *fftypes.FFTime  `json:"started"`
+	Completed *fftypes.FFTime  `json:"completed,omitempty"`
+	Checked   map[string]int64 `json:"checked"`
+	Issues    []*Issue         `json:"issues"`
+}
+
+func (r *Report) addIssue(check, collection string, id interface{}, detail string, args ...interface{}) {
+	r.Issues = append(r.Issues, &Issue{
+		Check:      check,
+		Collection: collection,
+		ID:         fmt.Sprintf("%v", id),
+		Detail:     fmt.Sprintf(detail, args...),
+	})
+}
+
+// Verifier walks the records of one namespace, and reports any that are inconsistent with
+// each other. It only reads from the database, so it is safe to run against a database in use,
+// although records written while it runs might be reported as inconsistent.
+type Verifier interface {
+	// Verify runs every check, returning an error only if the database could not be read
+	Verify(ctx context.Context) (*Report, error)
+}
+
+type verifier struct {
+	namespace string
+	database  database.Plugin
+	pageSize  int
+	checks    []*check
+}
+
+// check is one of the consistency checks, which records the issues it finds on the report
+type check struct {
+	name string
+	run  func(ctx context.Context, r *Report) error
+}
+
+func NewVerifier(ctx context.Context, ns string, di database.Plugin) (Verifier, error) {
+	if di == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "Verifier")
+	}
+	v := &verifier{
+		namespace: ns,
+		database:  di,
+		pageSize:  defaultPageSize,
+	}
+	v.checks = v.allChecks()
+	return v, nil
+}
+
+func (v *verifier) Verify(ctx context.Context) (*Report, error) {
+	r := &Report{
+		Namespace: v.namespace,
+		Started:   fftypes.Now(),
+		Checked:   make(map[string]int64),
+		Issues:    []*Issue{},
+	}
+	for _, c := range v.checks {
+		log.L(ctx).Infof("Running check '%s' on namespace '%s'", c.name, v.namespace)
+		issues := len(r.Issues)
+		if err := c.run(ctx, r); err != nil {
+			return nil, err
+		}
+		log.L(ctx).Infof("Check '%s' found %d issues", c.name, len(r.Issues)-issues)
+	}
+	r.Completed = fftypes.Now()
+	return r, nil
+}
+
+// forEachPage calls the supplied function with a filter for each page of a collection, in
+// database sequence order, until it returns fewer records than a full page
+func (v *verifier) forEachPage(ctx context.Context, factory ffapi.QueryFactory, fn func(filter ffapi.AndFilter) (int, error)) error {
+	for skip := 0; ; skip += v.pageSize {
+		filter := factory.NewFilter(ctx).And()
+		filter.Sort("sequence").Skip(uint64(skip)).Limit(uint64(v.pageSize))
+		count, err := fn(filter)
+		if err != nil {
+			return err
+		}
+		if count < v.pageSize {
+			return nil
+		}
+	}
+}
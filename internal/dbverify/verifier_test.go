@@ -0,0 +1,266 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbverify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/dbsql"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/database/sqlcommon"
+	"github.com/hyperledger/firefly/internal/database/sqlite3"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSQLite(t *testing.T) (*sqlite3.SQLite3, func()) {
+	coreconfig.Reset()
+	db := &sqlite3.SQLite3{}
+	conf := config.RootSection("unittest.dbverify")
+	db.InitConfig(conf)
+	conf.Set(sqlcommon.SQLConfDatasourceURL, "file::memory:")
+	conf.Set(sqlcommon.SQLConfMigrationsAuto, true)
+	conf.Set(sqlcommon.SQLConfMigrationsDirectory, "../../db/migrations/sqlite")
+	conf.Set(dbsql.SQLConfMaxConnections, 1)
+	err := db.Init(context.Background(), conf)
+	assert.NoError(t, err)
+	return db, db.Close
+}
+
+func newTestVerifier(t *testing.T) (*verifier, *databasemocks.Plugin) {
+	mdi := &databasemocks.Plugin{}
+	v, err := NewVerifier(context.Background(), "ns1", mdi)
+	assert.NoError(t, err)
+	return v.(*verifier), mdi
+}
+
+// seedBatch writes a message with one data record, in a batch with a matching manifest
+// and a dispatched pin, along with the transaction and its operation
+func seedBatch(t *testing.T, ctx context.Context, db database.Plugin) (*core.Message, *core.Data, *core.BatchPersisted) {
+	txID := fftypes.NewUUID()
+	err := db.InsertTransaction(ctx, &core.Transaction{ID: txID, Namespace: "ns1", Type: core.TransactionTypeBatchPin})
+	assert.NoError(t, err)
+	err = db.InsertOperation(ctx, &core.Operation{ID: fftypes.NewUUID(), Namespace: "ns1", Transaction: txID, Type: core.OpTypeBlockchainPinBatch, Plugin: "ethereum", Created: fftypes.Now()})
+	assert.NoError(t, err)
+
+	data := &core.Data{ID: fftypes.NewUUID(), Namespace: "ns1", Value: fftypes.JSONAnyPtr(`{"some":"data"}`)}
+	err = data.Seal(ctx, nil)
+	assert.NoError(t, err)
+	err = db.UpsertData(ctx, data, database.UpsertOptimizationNew)
+	assert.NoError(t, err)
+
+	msg := &core.Message{
+		Header:         core.MessageHeader{Namespace: "ns1", Type: core.MessageTypeBroadcast, TxType: core.TransactionTypeBatchPin, Topics: fftypes.FFStringArray{"topic1"}},
+		LocalNamespace: "ns1",
+		Data:           core.DataRefs{{ID: data.ID, Hash: data.Hash}},
+		State:          core.MessageStateConfirmed,
+	}
+	err = msg.Seal(ctx)
+	assert.NoError(t, err)
+	batch := &core.BatchPersisted{
+		BatchHeader: core.BatchHeader{ID: fftypes.NewUUID(), Type: core.BatchTypeBroadcast, Namespace: "ns1", Created: fftypes.Now()},
+		TX:          core.TransactionRef{ID: txID, Type: core.TransactionTypeBatchPin},
+	}
+	manifest := batch.GenManifest([]*core.Message{msg}, core.DataArray{data}).String()
+	batch.Manifest = fftypes.JSONAnyPtr(manifest)
+	batch.Hash = fftypes.HashString(manifest)
+	_, err = db.InsertOrGetBatch(ctx, batch)
+	assert.NoError(t, err)
+	msg.BatchID = batch.ID
+	msg.TransactionID = txID
+	err = db.UpsertMessage(ctx, msg, database.UpsertOptimizationNew)
+	assert.NoError(t, err)
+
+	err = db.InsertPins(ctx, []*core.Pin{{Namespace: "ns1", Hash: fftypes.NewRandB32(), Batch: batch.ID, BatchHash: batch.Hash, Signer: "0x12345", Dispatched: true, Created: fftypes.Now()}})
+	assert.NoError(t, err)
+	return msg, data, batch
+}
+
+// seedTransfer records a transfer of an amount in the base units of the pool, which is a mint when
+// there is no from key, and a burn when there is no to key
+func seedTransfer(t *testing.T, ctx context.Context, db database.Plugin, pool *fftypes.UUID, from, to string, amount string) {
+	transferType := core.TokenTransferTypeTransfer
+	if from == "" {
+		transferType = core.TokenTransferTypeMint
+	} else if to == "" {
+		transferType = core.TokenTransferTypeBurn
+	}
+	transfer := &core.TokenTransfer{
+		Type:       transferType,
+		LocalID:    fftypes.NewUUID(),
+		Pool:       pool,
+		TokenIndex: "1",
+		Connector:  "erc1155",
+		Namespace:  "ns1",
+		From:       from,
+		To:         to,
+		ProtocolID: fftypes.NewUUID().String(),
+		Created:    fftypes.Now(),
+	}
+	_, ok := transfer.Amount.Int().SetString(amount, 10)
+	assert.True(t, ok)
+	_, err := db.InsertOrGetTokenTransfer(ctx, transfer)
+	assert.NoError(t, err)
+	err = db.UpdateTokenBalances(ctx, transfer)
+	assert.NoError(t, err)
+}
+
+func TestNewVerifierMissingDeps(t *testing.T) {
+	_, err := NewVerifier(context.Background(), "ns1", nil)
+	assert.Regexp(t, "FF10128", err)
+}
+
+func TestVerifyConsistent(t *testing.T) {
+	ctx := context.Background()
+	db, done := newTestSQLite(t)
+	defer done()
+
+	seedBatch(t, ctx, db)
+	seedBatch(t, ctx, db)
+	err := db.InsertNextPin(ctx, &core.NextPin{Namespace: "ns1", Context: fftypes.NewRandB32(), Identity: "did:firefly:org/org1", Hash: fftypes.NewRandB32(), Nonce: 1})
+	assert.NoError(t, err)
+	err = db.InsertTransaction(ctx, &core.Transaction{ID: fftypes.NewUUID(), Namespace: "ns1", Type: core.TransactionTypeBatchPin, BlockchainIDs: fftypes.FFStringArray{"0x123"}})
+	assert.NoError(t, err)
+	pool := fftypes.NewUUID()
+	seedTransfer(t, ctx, db, pool, "", "0x1", "10")
+	seedTransfer(t, ctx, db, pool, "0x1", "0x2", "3")
+	seedTransfer(t, ctx, db, pool, "0x2", "", "3")
+
+	v, err := NewVerifier(ctx, "ns1", db)
+	assert.NoError(t, err)
+	v.(*verifier).pageSize = 1
+	report, err := v.Verify(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, report.Issues)
+	assert.Equal(t, "ns1", report.Namespace)
+	assert.NotNil(t, report.Completed)
+	assert.Equal(t, map[string]int64{
+		"messages":       2,
+		"pins":           2,
+		"batches":        2,
+		"nextpins":       1,
+		"transactions":   3,
+		"tokentransfers": 3,
+		"tokenbalances":  2,
+	}, report.Checked)
+}
+
+func TestVerifyTokenBalancesReconcileToZero(t *testing.T) {
+	ctx := context.Background()
+	db, done := newTestSQLite(t)
+	defer done()
+
+	// Amounts are always in the base units of the pool, so with 18 decimals 2.5 tokens is 2500000000000000000,
+	// and the sums must not overflow a 64 bit integer
+	decimals := 18
+	pool := &core.TokenPool{ID: fftypes.NewUUID(), Namespace: "ns1", Name: "coin", Type: core.TokenTypeFungible, Decimals: decimals, Connector: "erc20_erc721", Created: fftypes.Now()}
+	err := db.UpsertTokenPool(ctx, pool, database.UpsertOptimizationNew)
+	assert.NoError(t, err)
+	seedTransfer(t, ctx, db, pool.ID, "", "0x1", "2500000000000000000")
+	seedTransfer(t, ctx, db, pool.ID, "", "0x1", "10000000000000000000")
+	seedTransfer(t, ctx, db, pool.ID, "0x1", "0x2", "1250000000000000000")
+	seedTransfer(t, ctx, db, pool.ID, "0x1", "", "11250000000000000000")
+	seedTransfer(t, ctx, db, pool.ID, "0x2", "", "1250000000000000000")
+
+	balances, _, err := db.GetTokenBalances(ctx, "ns1", database.TokenBalanceQueryFactory.NewFilter(ctx).And())
+	assert.NoError(t, err)
+	assert.Len(t, balances, 2)
+	for _, b := range balances {
+		assert.Equal(t, int64(0), b.Balance.Int().Int64())
+	}
+
+	v, err := NewVerifier(ctx, "ns1", db)
+	assert.NoError(t, err)
+	report, err := v.Verify(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, report.Issues)
+	assert.Equal(t, int64(5), report.Checked["tokentransfers"])
+	assert.Equal(t, int64(2), report.Checked["tokenbalances"])
+}
+
+func TestVerifyInconsistent(t *testing.T) {
+	ctx := context.Background()
+	db, done := newTestSQLite(t)
+	defer done()
+
+	// A message that refers to data that does not exist, and whose batch manifest refers to
+	// a message that does not exist
+	msg, _, _ := seedBatch(t, ctx, db)
+	msg.Header.ID = fftypes.NewUUID()
+	msg.Data = core.DataRefs{{ID: fftypes.NewUUID(), Hash: fftypes.NewRandB32()}}
+	err := msg.Seal(ctx)
+	assert.NoError(t, err)
+	batch := &core.BatchPersisted{
+		BatchHeader: core.BatchHeader{ID: fftypes.NewUUID(), Type: core.BatchTypeBroadcast, Namespace: "ns1", Created: fftypes.Now()},
+		Manifest:    fftypes.JSONAnyPtr((&core.BatchManifest{Messages: []*core.MessageManifestEntry{{MessageRef: core.MessageRef{ID: fftypes.NewUUID()}}}}).String()),
+		Hash:        fftypes.NewRandB32(),
+	}
+	_, err = db.InsertOrGetBatch(ctx, batch)
+	assert.NoError(t, err)
+	err = db.UpsertMessage(ctx, msg, database.UpsertOptimizationNew)
+	assert.NoError(t, err)
+
+	// A dispatched pin for a batch that does not exist, and a next pin that matches it
+	missingBatch := fftypes.NewUUID()
+	pinHash := fftypes.NewRandB32()
+	err = db.InsertPins(ctx, []*core.Pin{{Namespace: "ns1", Hash: pinHash, Batch: missingBatch, BatchHash: fftypes.NewRandB32(), Signer: "0x12345", Dispatched: true, Created: fftypes.Now()}})
+	assert.NoError(t, err)
+	pinContext := fftypes.NewRandB32()
+	err = db.InsertNextPin(ctx, &core.NextPin{Namespace: "ns1", Context: pinContext, Identity: "did:firefly:org/org1", Hash: pinHash, Nonce: 1})
+	assert.NoError(t, err)
+	err = db.InsertNextPin(ctx, &core.NextPin{Namespace: "ns1", Context: pinContext, Identity: "did:firefly:org/org1", Hash: fftypes.NewRandB32(), Nonce: 2})
+	assert.NoError(t, err)
+
+	// A transaction with no operations
+	txID := fftypes.NewUUID()
+	err = db.InsertTransaction(ctx, &core.Transaction{ID: txID, Namespace: "ns1", Type: core.TransactionTypeContractInvoke})
+	assert.NoError(t, err)
+
+	// A balance without transfers, and transfers without a balance
+	pool := fftypes.NewUUID()
+	seedTransfer(t, ctx, db, pool, "", "0x1", "10")
+	err = db.UpdateTokenBalances(ctx, &core.TokenTransfer{Pool: pool, TokenIndex: "1", Namespace: "ns1", To: "0x1", Amount: *fftypes.NewFFBigInt(5)})
+	assert.NoError(t, err)
+	transfer := &core.TokenTransfer{Type: core.TokenTransferTypeMint, LocalID: fftypes.NewUUID(), Pool: pool, TokenIndex: "1", Connector: "erc1155", Namespace: "ns1", To: "0x2", ProtocolID: "000001", Created: fftypes.Now(), Amount: *fftypes.NewFFBigInt(7)}
+	_, err = db.InsertOrGetTokenTransfer(ctx, transfer)
+	assert.NoError(t, err)
+
+	v, err := NewVerifier(ctx, "ns1", db)
+	assert.NoError(t, err)
+	report, err := v.Verify(ctx)
+	assert.NoError(t, err)
+
+	issues := make(map[string][]string)
+	for _, issue := range report.Issues {
+		issues[issue.Check] = append(issues[issue.Check], issue.Detail)
+	}
+	assert.Len(t, issues[CheckMessageData], 1)
+	assert.Regexp(t, "does not exist", issues[CheckMessageData][0])
+	assert.Len(t, issues[CheckPinBatch], 1)
+	assert.Regexp(t, missingBatch.String(), issues[CheckPinBatch][0])
+	assert.Len(t, issues[CheckBatchManifest], 2)
+	assert.Len(t, issues[CheckNextPin], 2)
+	assert.Len(t, issues[CheckTransactionOperations], 1)
+	assert.Len(t, issues[CheckTokenBalance], 2)
+	assert.Regexp(t, "balance is 15, but the transfers sum to 10", issues[CheckTokenBalance][0])
+	assert.Regexp(t, "no balance, but the transfers sum to 7", issues[CheckTokenBalance][1])
+}
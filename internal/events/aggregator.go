@@ -126,6 +126,7 @@ func newAggregator(ctx context.Context, ns string, di database.Plugin, bi blockc
 		eventBatchSize:             batchSize,
 		eventBatchTimeout:          config.GetDuration(coreconfig.EventAggregatorBatchTimeout),
 		eventPollTimeout:           config.GetDuration(coreconfig.EventAggregatorPollTimeout),
+		changeFeedPollTimeout:      config.GetDuration(coreconfig.EventAggregatorChangeFeedPollTimeout),
 		startupOffsetRetryAttempts: config.GetInt(coreconfig.OrchestratorStartupAttempts),
 		retry: retry.Retry{
 			InitialDelay: config.GetDuration(coreconfig.EventAggregatorRetryInitDelay),
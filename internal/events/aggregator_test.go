@@ -76,6 +76,7 @@ func newTestAggregatorCommon(metrics bool) *testAggregator {
 	ctx, ctxCancel := context.WithCancel(context.Background())
 	logrus.SetLevel(logrus.DebugLevel)
 	mdi := &databasemocks.Plugin{}
+	mdi.On("Capabilities").Return(&database.Capabilities{}).Maybe()
 	mdm := &datamocks.Manager{}
 	mpm := &privatemessagingmocks.Manager{}
 	mdh := &definitionsmocks.Handler{}
@@ -187,6 +188,7 @@ func TestNewAggregator(t *testing.T) {
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	mbi := &blockchainmocks.Plugin{}
 	mbi.On("VerifierType").Return(core.VerifierTypeEthAddress)
+	mdi.On("Capabilities").Return(&database.Capabilities{}).Maybe()
 	ns := "ns1"
 	_, err := newAggregator(ctx, ns, mdi, mbi, mpm, mdh, mim, mdm, newEventNotifier(ctx, "ut"), mmi, cmi)
 	assert.NoError(t, err)
@@ -212,6 +214,7 @@ func TestCacheInitFail(t *testing.T) {
 	cmi.On("GetCache", mock.Anything).Return(nil, cacheInitError)
 	mbi := &blockchainmocks.Plugin{}
 	mbi.On("VerifierType").Return(core.VerifierTypeEthAddress)
+	mdi.On("Capabilities").Return(&database.Capabilities{}).Maybe()
 	ns := "ns1"
 	_, err := newAggregator(ctx, ns, mdi, mbi, mpm, mdh, mim, mdm, newEventNotifier(ctx, "ut"), mmi, cmi)
 	assert.Equal(t, cacheInitError, err)
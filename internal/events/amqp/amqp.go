@@ -0,0 +1,301 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package amqp
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/events"
+	amqp091 "github.com/rabbitmq/amqp091-go"
+)
+
+// AMQP publishes events to a broker using AMQP 0-9-1, which is the protocol of RabbitMQ and brokers
+// compatible with it. Brokers that only support AMQP 1.0 cannot be used with this plugin.
+type AMQP struct {
+	ctx            context.Context
+	capabilities   *events.Capabilities
+	callbacks      callbacks
+	publisher      publisher
+	connID         string
+	confirmTimeout time.Duration
+	nackRetry      retry.Retry
+	nackLock       sync.Mutex
+	nacks          map[string]map[fftypes.UUID]int // consecutive rejections by the broker, per subscription in each namespace
+}
+
+type callbacks struct {
+	writeLock sync.Mutex
+	handlers  map[string]events.Callbacks
+}
+
+// amqpMessage is the JSON body of each message published to the broker
+type amqpMessage struct {
+	*core.EventDelivery
+	Data core.DataArray `json:"data,omitempty"`
+}
+
+// pendingDelivery is a message that has been published, and is waiting for the broker to confirm it
+type pendingDelivery struct {
+	event        *core.EventDelivery
+	confirmation confirmation
+}
+
+func (a *AMQP) Name() string { return "amqp" }
+
+func (a *AMQP) Init(ctx context.Context, config config.Section) (err error) {
+	url := config.GetString(AMQPConfURL)
+	if url == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, AMQPConfURL, "events.amqp")
+	}
+
+	tlsConfig, err := fftls.ConstructTLSConfig(ctx, config.SubSection(AMQPConfTLS), fftls.ClientType)
+	if err != nil {
+		return err
+	}
+
+	publisher, err := dialPublisher(ctx, url, tlsConfig)
+	if err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgAMQPConnectFailed)
+	}
+
+	connID := fftypes.ShortID()
+	retryConfig := config.SubSection(AMQPConfRetry)
+	*a = AMQP{
+		ctx: log.WithLogField(ctx, "amqp", connID),
+		capabilities: &events.Capabilities{
+			BatchDelivery: true,
+		},
+		callbacks: callbacks{
+			handlers: make(map[string]events.Callbacks),
+		},
+		publisher:      publisher,
+		connID:         connID,
+		confirmTimeout: config.GetDuration(AMQPConfConfirmTimeout),
+		nackRetry: retry.Retry{
+			InitialDelay: retryConfig.GetDuration(AMQPConfRetryInitialDelay),
+			MaximumDelay: retryConfig.GetDuration(AMQPConfRetryMaxDelay),
+			Factor:       retryConfig.GetFloat64(AMQPConfRetryFactor),
+		},
+		nacks: make(map[string]map[fftypes.UUID]int),
+	}
+	return nil
+}
+
+func (a *AMQP) getHandler(namespace string) (events.Callbacks, bool) {
+	a.callbacks.writeLock.Lock()
+	defer a.callbacks.writeLock.Unlock()
+	cb, ok := a.callbacks.handlers[namespace]
+	return cb, ok
+}
+
+func (a *AMQP) SetHandler(namespace string, handler events.Callbacks) error {
+	a.callbacks.writeLock.Lock()
+	defer a.callbacks.writeLock.Unlock()
+	if handler == nil {
+		delete(a.callbacks.handlers, namespace)
+		return nil
+	}
+	a.callbacks.handlers[namespace] = handler
+	// We have a single logical connection, that matches all subscriptions
+	return handler.RegisterConnection(a.connID, func(sr core.SubscriptionRef) bool { return true })
+}
+
+func (a *AMQP) Capabilities() *events.Capabilities {
+	return a.capabilities
+}
+
+// ValidateOptions checks the subscription binds to exactly one of an exchange (with an optional routing key),
+// or a queue (published via the default exchange)
+func (a *AMQP) ValidateOptions(ctx context.Context, options *core.SubscriptionOptions) error {
+	exchange := options.TransportOptions().GetString("exchange")
+	queue := options.TransportOptions().GetString("queue")
+	switch {
+	case exchange == "" && queue == "":
+		return i18n.NewError(ctx, coremsgs.MsgAMQPMissingDestination)
+	case exchange != "" && queue != "":
+		return i18n.NewError(ctx, coremsgs.MsgAMQPConflictingDestination)
+	case queue != "" && options.TransportOptions().GetString("routingKey") != "":
+		return i18n.NewError(ctx, coremsgs.MsgAMQPRoutingKeyWithQueue)
+	}
+	return nil
+}
+
+func (a *AMQP) buildMessage(ctx context.Context, sub *core.Subscription, event *core.EventDelivery, data core.DataArray) (*amqp091.Publishing, error) {
+	body, err := json.Marshal(&amqpMessage{
+		EventDelivery: event,
+		Data:          data,
+	})
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgSerializationFailed)
+	}
+
+	msg := &amqp091.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp091.Persistent,
+		MessageId:    event.ID.String(),
+		Type:         string(event.Type),
+		AppId:        "firefly",
+		Headers: amqp091.Table{
+			"ff-namespace":    event.Namespace,
+			"ff-event-id":     event.ID.String(),
+			"ff-event-type":   string(event.Type),
+			"ff-subscription": sub.Name,
+		},
+		Body: body,
+	}
+	if event.Correlator != nil {
+		msg.CorrelationId = event.Correlator.String()
+	}
+	if event.Created != nil {
+		msg.Timestamp = time.Time(*event.Created)
+	}
+	return msg, nil
+}
+
+func (a *AMQP) publish(ctx context.Context, sub *core.Subscription, event *core.EventDelivery, data core.DataArray) (*pendingDelivery, error) {
+	msg, err := a.buildMessage(ctx, sub, event, data)
+	if err != nil {
+		return nil, err
+	}
+
+	exchange := sub.Options.TransportOptions().GetString("exchange")
+	key := sub.Options.TransportOptions().GetString("routingKey")
+	if queue := sub.Options.TransportOptions().GetString("queue"); queue != "" {
+		// Publishing to a queue is done via the default exchange, with the queue name as the routing key
+		if err := a.publisher.DeclareQueue(ctx, queue); err != nil {
+			return nil, i18n.WrapError(ctx, err, coremsgs.MsgAMQPPublishFailed, "", queue)
+		}
+		exchange, key = "", queue
+	} else if key == "" {
+		key = event.Topic
+	}
+
+	log.L(a.ctx).Debugf("AMQP-> exchange='%s' key='%s' event %s on subscription %s", exchange, key, event.ID, sub.ID)
+	confirmation, err := a.publisher.Publish(ctx, exchange, key, *msg)
+	if err != nil {
+		log.L(a.ctx).Errorf("AMQP<- exchange='%s' key='%s' event %s on subscription %s failed: %s", exchange, key, event.ID, sub.ID, err)
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgAMQPPublishFailed, exchange, key)
+	}
+	return &pendingDelivery{
+		event:        event,
+		confirmation: confirmation,
+	}, nil
+}
+
+// nackDelay returns how long to wait before reporting a rejection by the broker. The dispatcher redelivers
+// a rejected event straight away, so without a delay a message the broker always rejects would be
+// published in a tight loop.
+func (a *AMQP) nackDelay(sub *core.Subscription, acked bool) time.Duration {
+	a.nackLock.Lock()
+	defer a.nackLock.Unlock()
+	nsNacks := a.nacks[sub.Namespace]
+	if acked {
+		delete(nsNacks, *sub.ID)
+		return 0
+	}
+	if nsNacks == nil {
+		nsNacks = make(map[fftypes.UUID]int)
+		a.nacks[sub.Namespace] = nsNacks
+	}
+	count := nsNacks[*sub.ID]
+	nsNacks[*sub.ID] = count + 1
+	delay := float64(a.nackRetry.InitialDelay) * math.Pow(a.nackRetry.Factor, float64(count))
+	if delay > float64(a.nackRetry.MaximumDelay) {
+		return a.nackRetry.MaximumDelay
+	}
+	return time.Duration(delay)
+}
+
+// waitConfirm blocks until the broker confirms (or rejects) the message. We only send a
+// DeliveryResponse once the broker has responded, so the offset only moves forwards once
+// the broker has taken responsibility for the message.
+func (a *AMQP) waitConfirm(ctx context.Context, connID string, sub *core.Subscription, pending *pendingDelivery) error {
+	waitCtx, cancel := context.WithTimeout(ctx, a.confirmTimeout)
+	defer cancel()
+	acked, err := pending.confirmation.WaitContext(waitCtx)
+	if err != nil {
+		log.L(a.ctx).Errorf("AMQP<- event %s on subscription %s not confirmed: %s", pending.event.ID, sub.ID, err)
+		return i18n.WrapError(ctx, err, coremsgs.MsgAMQPConfirmFailed, pending.event.ID)
+	}
+	log.L(a.ctx).Debugf("AMQP<- event %s on subscription %s acked=%t", pending.event.ID, sub.ID, acked)
+	if delay := a.nackDelay(sub, acked); delay > 0 {
+		log.L(a.ctx).Warnf("AMQP<- event %s on subscription %s rejected by the broker, redelivering in %s", pending.event.ID, sub.ID, delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+	}
+
+	response := &core.EventDeliveryResponse{
+		ID:           pending.event.ID,
+		Rejected:     !acked,
+		Subscription: pending.event.Subscription,
+	}
+	if !acked {
+		response.Info = i18n.NewError(ctx, coremsgs.MsgAMQPNackReceived).Error()
+	}
+	if cb, ok := a.getHandler(sub.Namespace); ok {
+		cb.DeliveryResponse(connID, response)
+	}
+	return nil
+}
+
+func (a *AMQP) DeliveryRequest(ctx context.Context, connID string, sub *core.Subscription, event *core.EventDelivery, data core.DataArray) error {
+	pending, err := a.publish(ctx, sub, event, data)
+	if err != nil {
+		return err
+	}
+	return a.waitConfirm(ctx, connID, sub, pending)
+}
+
+func (a *AMQP) BatchDeliveryRequest(ctx context.Context, connID string, sub *core.Subscription, events []*core.CombinedEventDataDelivery) error {
+	// Publish the whole batch before waiting, so the broker can confirm them together
+	pending := make([]*pendingDelivery, len(events))
+	for i, combinedEvent := range events {
+		p, err := a.publish(ctx, sub, combinedEvent.Event, combinedEvent.Data)
+		if err != nil {
+			return err
+		}
+		pending[i] = p
+	}
+	for _, p := range pending {
+		if err := a.waitConfirm(ctx, connID, sub, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NamespaceRestarted forgets the rejections for the subscriptions of the namespace, as its
+// dispatchers start afresh without any delay before their first delivery
+func (a *AMQP) NamespaceRestarted(ns string, startTime time.Time) {
+	a.nackLock.Lock()
+	defer a.nackLock.Unlock()
+	delete(a.nacks, ns)
+}
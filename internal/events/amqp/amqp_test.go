@@ -0,0 +1,466 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package amqp
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/eventsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/events"
+	amqp091 "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testConfirmation struct {
+	acked bool
+	err   error
+}
+
+func (c *testConfirmation) WaitContext(ctx context.Context) (bool, error) {
+	if c == nil {
+		<-ctx.Done()
+		return false, ctx.Err()
+	}
+	return c.acked, c.err
+}
+
+type testPublished struct {
+	exchange string
+	key      string
+	msg      amqp091.Publishing
+}
+
+type testPublisher struct {
+	published    []*testPublished
+	queues       []string
+	declareErr   error
+	publishErr   error
+	confirmation *testConfirmation
+}
+
+func (p *testPublisher) DeclareQueue(ctx context.Context, name string) error {
+	p.queues = append(p.queues, name)
+	return p.declareErr
+}
+
+func (p *testPublisher) Publish(ctx context.Context, exchange, key string, msg amqp091.Publishing) (confirmation, error) {
+	if p.publishErr != nil {
+		return nil, p.publishErr
+	}
+	p.published = append(p.published, &testPublished{exchange: exchange, key: key, msg: msg})
+	return p.confirmation, nil
+}
+
+func newTestAMQP(t *testing.T) (a *AMQP, p *testPublisher, cbs *eventsmocks.Callbacks) {
+	coreconfig.Reset()
+
+	p = &testPublisher{confirmation: &testConfirmation{acked: true}}
+	origDial := dialPublisher
+	dialPublisher = func(ctx context.Context, url string, tlsConfig *tls.Config) (publisher, error) {
+		assert.Equal(t, "amqp://localhost:5672", url)
+		return p, nil
+	}
+	t.Cleanup(func() { dialPublisher = origDial })
+
+	cbs = &eventsmocks.Callbacks{}
+	rc := cbs.On("RegisterConnection", mock.Anything, mock.Anything).Return(nil)
+	rc.RunFn = func(a mock.Arguments) {
+		assert.True(t, a[1].(events.SubscriptionMatcher)(core.SubscriptionRef{}))
+	}
+	a = &AMQP{}
+	conf := config.RootSection("ut.amqp")
+	a.InitConfig(conf)
+	conf.Set(AMQPConfURL, "amqp://localhost:5672")
+	err := a.Init(context.Background(), conf)
+	assert.NoError(t, err)
+	err = a.SetHandler("ns1", cbs)
+	assert.NoError(t, err)
+	assert.Equal(t, "amqp", a.Name())
+	assert.True(t, a.Capabilities().BatchDelivery)
+	return a, p, cbs
+}
+
+func newTestEvent(sub *core.Subscription) *core.EventDelivery {
+	return &core.EventDelivery{
+		EnrichedEvent: core.EnrichedEvent{
+			Event: core.Event{
+				ID:         fftypes.NewUUID(),
+				Namespace:  "ns1",
+				Type:       core.EventTypeMessageConfirmed,
+				Topic:      "fftopic1",
+				Correlator: fftypes.NewUUID(),
+				Created:    fftypes.Now(),
+			},
+		},
+		Subscription: sub.SubscriptionRef,
+	}
+}
+
+func newTestSubscription(options fftypes.JSONObject) *core.Subscription {
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID:        fftypes.NewUUID(),
+			Namespace: "ns1",
+			Name:      "sub1",
+		},
+		Transport: "amqp",
+	}
+	for k, v := range options {
+		sub.Options.TransportOptions()[k] = v
+	}
+	return sub
+}
+
+func TestInitMissingURL(t *testing.T) {
+	coreconfig.Reset()
+
+	a := &AMQP{}
+	conf := config.RootSection("ut.amqp")
+	a.InitConfig(conf)
+	err := a.Init(context.Background(), conf)
+	assert.Regexp(t, "FF10138.*url", err)
+}
+
+func TestInitBadTLS(t *testing.T) {
+	coreconfig.Reset()
+
+	a := &AMQP{}
+	conf := config.RootSection("ut.amqp")
+	a.InitConfig(conf)
+	conf.Set(AMQPConfURL, "amqps://localhost:5671")
+	tlsConfig := conf.SubSection(AMQPConfTLS)
+	tlsConfig.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConfig.Set(fftls.HTTPConfTLSCAFile, "BADCA")
+	err := a.Init(context.Background(), conf)
+	assert.Regexp(t, "FF00153", err)
+}
+
+func TestInitConnectFail(t *testing.T) {
+	coreconfig.Reset()
+
+	a := &AMQP{}
+	conf := config.RootSection("ut.amqp")
+	a.InitConfig(conf)
+	conf.Set(AMQPConfURL, "amqp://localhost:1")
+	err := a.Init(context.Background(), conf)
+	assert.Regexp(t, "FF10487", err)
+}
+
+func TestInitBadURL(t *testing.T) {
+	coreconfig.Reset()
+
+	a := &AMQP{}
+	conf := config.RootSection("ut.amqp")
+	a.InitConfig(conf)
+	conf.Set(AMQPConfURL, "http://not-amqp")
+	err := a.Init(context.Background(), conf)
+	assert.Regexp(t, "FF10487", err)
+}
+
+func TestSetHandlerRemove(t *testing.T) {
+	a, _, _ := newTestAMQP(t)
+
+	err := a.SetHandler("ns1", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, a.callbacks.handlers)
+}
+
+func TestValidateOptions(t *testing.T) {
+	a, _, _ := newTestAMQP(t)
+
+	err := a.ValidateOptions(context.Background(), &newTestSubscription(fftypes.JSONObject{"exchange": "ex1"}).Options)
+	assert.NoError(t, err)
+	err = a.ValidateOptions(context.Background(), &newTestSubscription(fftypes.JSONObject{"exchange": "ex1", "routingKey": "rk1"}).Options)
+	assert.NoError(t, err)
+	err = a.ValidateOptions(context.Background(), &newTestSubscription(fftypes.JSONObject{"queue": "q1"}).Options)
+	assert.NoError(t, err)
+
+	err = a.ValidateOptions(context.Background(), &newTestSubscription(fftypes.JSONObject{}).Options)
+	assert.Regexp(t, "FF10491", err)
+	err = a.ValidateOptions(context.Background(), &newTestSubscription(fftypes.JSONObject{"exchange": "ex1", "queue": "q1"}).Options)
+	assert.Regexp(t, "FF10492", err)
+	err = a.ValidateOptions(context.Background(), &newTestSubscription(fftypes.JSONObject{"queue": "q1", "routingKey": "rk1"}).Options)
+	assert.Regexp(t, "FF10493", err)
+}
+
+func TestDeliveryRequestExchangeConfirmed(t *testing.T) {
+	a, p, cbs := newTestAMQP(t)
+
+	sub := newTestSubscription(fftypes.JSONObject{"exchange": "ex1"})
+	event := newTestEvent(sub)
+	cbs.On("DeliveryResponse", a.connID, mock.MatchedBy(func(resp *core.EventDeliveryResponse) bool {
+		return resp.ID.Equals(event.ID) && !resp.Rejected
+	})).Return()
+
+	err := a.DeliveryRequest(context.Background(), a.connID, sub, event, core.DataArray{
+		{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`{"some":"data"}`)},
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, p.published, 1)
+	assert.Equal(t, "ex1", p.published[0].exchange)
+	assert.Equal(t, "fftopic1", p.published[0].key)
+	msg := p.published[0].msg
+	assert.Equal(t, "application/json", msg.ContentType)
+	assert.Equal(t, amqp091.Persistent, msg.DeliveryMode)
+	assert.Equal(t, event.ID.String(), msg.MessageId)
+	assert.Equal(t, event.Correlator.String(), msg.CorrelationId)
+	assert.Equal(t, "ns1", msg.Headers["ff-namespace"])
+	assert.Equal(t, "sub1", msg.Headers["ff-subscription"])
+	var body amqpMessage
+	err = json.Unmarshal(msg.Body, &body)
+	assert.NoError(t, err)
+	assert.Equal(t, event.ID, body.ID)
+	assert.Len(t, body.Data, 1)
+	assert.Empty(t, p.queues)
+
+	cbs.AssertExpectations(t)
+}
+
+func TestDeliveryRequestExchangeRoutingKey(t *testing.T) {
+	a, p, cbs := newTestAMQP(t)
+
+	sub := newTestSubscription(fftypes.JSONObject{"exchange": "ex1", "routingKey": "rk1"})
+	event := newTestEvent(sub)
+	cbs.On("DeliveryResponse", a.connID, mock.Anything).Return()
+
+	err := a.DeliveryRequest(context.Background(), a.connID, sub, event, nil)
+	assert.NoError(t, err)
+
+	assert.Len(t, p.published, 1)
+	assert.Equal(t, "ex1", p.published[0].exchange)
+	assert.Equal(t, "rk1", p.published[0].key)
+
+	cbs.AssertExpectations(t)
+}
+
+func TestDeliveryRequestQueue(t *testing.T) {
+	a, p, cbs := newTestAMQP(t)
+
+	sub := newTestSubscription(fftypes.JSONObject{"queue": "q1"})
+	event := newTestEvent(sub)
+	event.Correlator = nil
+	event.Created = nil
+	cbs.On("DeliveryResponse", a.connID, mock.Anything).Return()
+
+	err := a.DeliveryRequest(context.Background(), a.connID, sub, event, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"q1"}, p.queues)
+	assert.Len(t, p.published, 1)
+	assert.Equal(t, "", p.published[0].exchange)
+	assert.Equal(t, "q1", p.published[0].key)
+	assert.Empty(t, p.published[0].msg.CorrelationId)
+
+	cbs.AssertExpectations(t)
+}
+
+func TestDeliveryRequestQueueDeclareFail(t *testing.T) {
+	a, p, _ := newTestAMQP(t)
+	p.declareErr = fmt.Errorf("pop")
+
+	sub := newTestSubscription(fftypes.JSONObject{"queue": "q1"})
+	err := a.DeliveryRequest(context.Background(), a.connID, sub, newTestEvent(sub), nil)
+	assert.Regexp(t, "FF10488.*pop", err)
+}
+
+func TestDeliveryRequestPublishFail(t *testing.T) {
+	a, p, _ := newTestAMQP(t)
+	p.publishErr = fmt.Errorf("pop")
+
+	sub := newTestSubscription(fftypes.JSONObject{"exchange": "ex1"})
+	err := a.DeliveryRequest(context.Background(), a.connID, sub, newTestEvent(sub), nil)
+	assert.Regexp(t, "FF10488.*pop", err)
+}
+
+func TestDeliveryRequestBadData(t *testing.T) {
+	a, _, _ := newTestAMQP(t)
+
+	sub := newTestSubscription(fftypes.JSONObject{"exchange": "ex1"})
+	err := a.DeliveryRequest(context.Background(), a.connID, sub, newTestEvent(sub), core.DataArray{
+		{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`!json`)},
+	})
+	assert.Regexp(t, "FF10137", err)
+}
+
+func TestDeliveryRequestNacked(t *testing.T) {
+	a, p, cbs := newTestAMQP(t)
+	p.confirmation = &testConfirmation{acked: false}
+	a.nackRetry.InitialDelay = time.Millisecond
+
+	sub := newTestSubscription(fftypes.JSONObject{"exchange": "ex1"})
+	event := newTestEvent(sub)
+	cbs.On("DeliveryResponse", a.connID, mock.MatchedBy(func(resp *core.EventDeliveryResponse) bool {
+		return resp.ID.Equals(event.ID) && resp.Rejected && resp.Info != ""
+	})).Return()
+
+	err := a.DeliveryRequest(context.Background(), a.connID, sub, event, nil)
+	assert.NoError(t, err)
+
+	cbs.AssertExpectations(t)
+}
+
+func TestDeliveryRequestNackedClosing(t *testing.T) {
+	a, p, cbs := newTestAMQP(t)
+	p.confirmation = &testConfirmation{acked: false}
+	a.nackRetry.InitialDelay = time.Hour
+
+	sub := newTestSubscription(fftypes.JSONObject{"exchange": "ex1"})
+	cbs.On("DeliveryResponse", a.connID, mock.Anything).Return()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := a.DeliveryRequest(ctx, a.connID, sub, newTestEvent(sub), nil)
+	assert.NoError(t, err)
+
+	cbs.AssertExpectations(t)
+}
+
+func TestNackDelay(t *testing.T) {
+	a, _, _ := newTestAMQP(t)
+	a.nackRetry.InitialDelay = 10 * time.Millisecond
+	a.nackRetry.MaximumDelay = 30 * time.Millisecond
+
+	sub1, sub2 := newTestSubscription(nil), newTestSubscription(nil)
+	assert.Equal(t, 10*time.Millisecond, a.nackDelay(sub1, false))
+	assert.Equal(t, 20*time.Millisecond, a.nackDelay(sub1, false))
+	assert.Equal(t, 10*time.Millisecond, a.nackDelay(sub2, false))
+	assert.Equal(t, 30*time.Millisecond, a.nackDelay(sub1, false))
+	assert.Equal(t, time.Duration(0), a.nackDelay(sub1, true))
+	assert.Equal(t, 10*time.Millisecond, a.nackDelay(sub1, false))
+}
+
+func TestDeliveryRequestConfirmFail(t *testing.T) {
+	a, p, _ := newTestAMQP(t)
+	p.confirmation = &testConfirmation{err: fmt.Errorf("pop")}
+
+	sub := newTestSubscription(fftypes.JSONObject{"exchange": "ex1"})
+	err := a.DeliveryRequest(context.Background(), a.connID, sub, newTestEvent(sub), nil)
+	assert.Regexp(t, "FF10489.*pop", err)
+}
+
+func TestDeliveryRequestConfirmTimeout(t *testing.T) {
+	a, p, _ := newTestAMQP(t)
+	p.confirmation = nil
+	a.confirmTimeout = 0
+
+	sub := newTestSubscription(fftypes.JSONObject{"exchange": "ex1"})
+	err := a.DeliveryRequest(context.Background(), a.connID, sub, newTestEvent(sub), nil)
+	assert.Regexp(t, "FF10489", err)
+}
+
+func TestDeliveryRequestNoHandler(t *testing.T) {
+	a, _, _ := newTestAMQP(t)
+
+	sub := newTestSubscription(fftypes.JSONObject{"exchange": "ex1"})
+	sub.Namespace = "ns2"
+	err := a.DeliveryRequest(context.Background(), a.connID, sub, newTestEvent(sub), nil)
+	assert.NoError(t, err)
+}
+
+func TestBatchDeliveryRequestOk(t *testing.T) {
+	a, p, cbs := newTestAMQP(t)
+
+	sub := newTestSubscription(fftypes.JSONObject{"exchange": "ex1"})
+	event1 := newTestEvent(sub)
+	event2 := newTestEvent(sub)
+	cbs.On("DeliveryResponse", a.connID, mock.MatchedBy(func(resp *core.EventDeliveryResponse) bool {
+		return resp.ID.Equals(event1.ID) && !resp.Rejected
+	})).Return()
+	cbs.On("DeliveryResponse", a.connID, mock.MatchedBy(func(resp *core.EventDeliveryResponse) bool {
+		return resp.ID.Equals(event2.ID) && !resp.Rejected
+	})).Return()
+
+	err := a.BatchDeliveryRequest(context.Background(), a.connID, sub, []*core.CombinedEventDataDelivery{
+		{Event: event1},
+		{Event: event2},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, p.published, 2)
+
+	cbs.AssertExpectations(t)
+}
+
+func TestBatchDeliveryRequestPublishFail(t *testing.T) {
+	a, p, _ := newTestAMQP(t)
+	p.publishErr = fmt.Errorf("pop")
+
+	sub := newTestSubscription(fftypes.JSONObject{"exchange": "ex1"})
+	err := a.BatchDeliveryRequest(context.Background(), a.connID, sub, []*core.CombinedEventDataDelivery{
+		{Event: newTestEvent(sub)},
+	})
+	assert.Regexp(t, "FF10488.*pop", err)
+}
+
+func TestBatchDeliveryRequestConfirmFail(t *testing.T) {
+	a, p, _ := newTestAMQP(t)
+	p.confirmation = &testConfirmation{err: fmt.Errorf("pop")}
+
+	sub := newTestSubscription(fftypes.JSONObject{"exchange": "ex1"})
+	err := a.BatchDeliveryRequest(context.Background(), a.connID, sub, []*core.CombinedEventDataDelivery{
+		{Event: newTestEvent(sub)},
+	})
+	assert.Regexp(t, "FF10489.*pop", err)
+}
+
+func TestDeliveryRequestConcurrentSetHandler(t *testing.T) {
+	a, _, _ := newTestAMQP(t)
+
+	sub := newTestSubscription(fftypes.JSONObject{"exchange": "ex1"})
+	sub.Namespace = "ns3"
+	cbs := &eventsmocks.Callbacks{}
+	cbs.On("RegisterConnection", mock.Anything, mock.Anything).Return(nil)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = a.SetHandler("ns2", cbs)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		err := a.DeliveryRequest(context.Background(), a.connID, sub, newTestEvent(sub), nil)
+		assert.NoError(t, err)
+	}
+	<-done
+}
+
+func TestNamespaceRestarted(t *testing.T) {
+	a, _, _ := newTestAMQP(t)
+	a.nackRetry.InitialDelay = 10 * time.Millisecond
+	a.nackRetry.MaximumDelay = 30 * time.Millisecond
+
+	sub1 := newTestSubscription(nil)
+	sub2 := newTestSubscription(nil)
+	sub2.Namespace = "ns2"
+	assert.Equal(t, 10*time.Millisecond, a.nackDelay(sub1, false))
+	assert.Equal(t, 10*time.Millisecond, a.nackDelay(sub2, false))
+
+	a.NamespaceRestarted("ns1", time.Now())
+	assert.Equal(t, 10*time.Millisecond, a.nackDelay(sub1, false))
+	assert.Equal(t, 20*time.Millisecond, a.nackDelay(sub2, false))
+}
@@ -0,0 +1,56 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package amqp
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+)
+
+const (
+	defaultConfirmTimeout    = "30s"
+	defaultRetryInitialDelay = "250ms"
+	defaultRetryMaxDelay     = "30s"
+	defaultRetryFactor       = 2.0
+)
+
+const (
+	// AMQPConfURL is the AMQP URL of the broker to connect to
+	AMQPConfURL = "url"
+	// AMQPConfConfirmTimeout is the maximum time to wait for the broker to confirm a publish
+	AMQPConfConfirmTimeout = "confirmTimeout"
+	// AMQPConfTLS is the sub-section for TLS configuration
+	AMQPConfTLS = "tls"
+	// AMQPConfRetry is the sub-section for the backoff before an event rejected by the broker is redelivered
+	AMQPConfRetry = "retry"
+	// AMQPConfRetryInitialDelay is the delay before an event is redelivered after the first rejection
+	AMQPConfRetryInitialDelay = "initialDelay"
+	// AMQPConfRetryMaxDelay is the maximum delay before an event is redelivered
+	AMQPConfRetryMaxDelay = "maxDelay"
+	// AMQPConfRetryFactor is the factor the delay increases by on each consecutive rejection
+	AMQPConfRetryFactor = "factor"
+)
+
+func (a *AMQP) InitConfig(config config.Section) {
+	config.AddKnownKey(AMQPConfURL)
+	config.AddKnownKey(AMQPConfConfirmTimeout, defaultConfirmTimeout)
+	fftls.InitTLSConfig(config.SubSection(AMQPConfTLS))
+	retryConfig := config.SubSection(AMQPConfRetry)
+	retryConfig.AddKnownKey(AMQPConfRetryInitialDelay, defaultRetryInitialDelay)
+	retryConfig.AddKnownKey(AMQPConfRetryMaxDelay, defaultRetryMaxDelay)
+	retryConfig.AddKnownKey(AMQPConfRetryFactor, defaultRetryFactor)
+}
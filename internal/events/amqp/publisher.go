@@ -0,0 +1,152 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package amqp
+
+import (
+	"context"
+	"crypto/tls"
+	"sync"
+
+	"github.com/hyperledger/firefly-common/pkg/log"
+	amqp091 "github.com/rabbitmq/amqp091-go"
+)
+
+// confirmation is a pending publisher confirm from the broker
+type confirmation interface {
+	WaitContext(ctx context.Context) (bool, error)
+}
+
+// publisher is the subset of an AMQP channel in confirm mode that the plugin uses
+type publisher interface {
+	DeclareQueue(ctx context.Context, name string) error
+	Publish(ctx context.Context, exchange, key string, msg amqp091.Publishing) (confirmation, error)
+}
+
+// amqpConnection is the subset of an amqp091.Connection that the publisher uses
+type amqpConnection interface {
+	IsClosed() bool
+	Channel() (amqpChannel, error)
+}
+
+// amqpChannel is the subset of an amqp091.Channel that the publisher uses
+type amqpChannel interface {
+	IsClosed() bool
+	Confirm(noWait bool) error
+	Close() error
+	QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp091.Table) (amqp091.Queue, error)
+	PublishWithDeferredConfirmWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp091.Publishing) (*amqp091.DeferredConfirmation, error)
+}
+
+// amqpConnectionAdapter returns the channels of an amqp091.Connection as an amqpChannel
+type amqpConnectionAdapter struct {
+	*amqp091.Connection
+}
+
+func (c *amqpConnectionAdapter) Channel() (amqpChannel, error) {
+	channel, err := c.Connection.Channel()
+	if err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+// dialConnection is replaced in unit tests
+var dialConnection = func(url string, tlsConfig *tls.Config) (amqpConnection, error) {
+	conn, err := amqp091.DialTLS(url, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &amqpConnectionAdapter{Connection: conn}, nil
+}
+
+// dialPublisher is replaced in unit tests
+var dialPublisher = func(ctx context.Context, url string, tlsConfig *tls.Config) (publisher, error) {
+	p := &amqpPublisher{
+		url:       url,
+		tlsConfig: tlsConfig,
+	}
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if err := p.ensureChannelLocked(ctx); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+type amqpPublisher struct {
+	url       string
+	tlsConfig *tls.Config
+	mux       sync.Mutex
+	conn      amqpConnection
+	channel   amqpChannel
+	queues    map[string]bool
+}
+
+// ensureChannelLocked re-establishes the connection and channel if the broker has closed either of them.
+// Any confirmations outstanding on a closed channel are resolved as nacks by the library.
+func (p *amqpPublisher) ensureChannelLocked(ctx context.Context) (err error) {
+	if p.channel != nil && !p.channel.IsClosed() {
+		return nil
+	}
+	if p.conn == nil || p.conn.IsClosed() {
+		log.L(ctx).Infof("Connecting to AMQP broker")
+		if p.conn, err = dialConnection(p.url, p.tlsConfig); err != nil {
+			return err
+		}
+	}
+	channel, err := p.conn.Channel()
+	if err != nil {
+		return err
+	}
+	if err := channel.Confirm(false); err != nil {
+		_ = channel.Close()
+		return err
+	}
+	p.channel = channel
+	// Queue declarations are re-checked on each new channel
+	p.queues = make(map[string]bool)
+	return nil
+}
+
+func (p *amqpPublisher) DeclareQueue(ctx context.Context, name string) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if err := p.ensureChannelLocked(ctx); err != nil {
+		return err
+	}
+	if p.queues[name] {
+		return nil
+	}
+	if _, err := p.channel.QueueDeclare(name, true /* durable */, false, false, false, nil); err != nil {
+		return err
+	}
+	p.queues[name] = true
+	return nil
+}
+
+func (p *amqpPublisher) Publish(ctx context.Context, exchange, key string, msg amqp091.Publishing) (confirmation, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if err := p.ensureChannelLocked(ctx); err != nil {
+		return nil, err
+	}
+	confirmation, err := p.channel.PublishWithDeferredConfirmWithContext(ctx, exchange, key, false, false, msg)
+	if err != nil {
+		return nil, err
+	}
+	return confirmation, nil
+}
@@ -0,0 +1,372 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package amqp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	amqp091 "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+type testChannel struct {
+	closed     bool
+	confirmErr error
+	declareErr error
+	publishErr error
+	declared   []string
+}
+
+func (c *testChannel) IsClosed() bool { return c.closed }
+
+func (c *testChannel) Confirm(noWait bool) error { return c.confirmErr }
+
+func (c *testChannel) Close() error {
+	c.closed = true
+	return nil
+}
+
+func (c *testChannel) QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp091.Table) (amqp091.Queue, error) {
+	c.declared = append(c.declared, name)
+	return amqp091.Queue{Name: name}, c.declareErr
+}
+
+func (c *testChannel) PublishWithDeferredConfirmWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp091.Publishing) (*amqp091.DeferredConfirmation, error) {
+	if c.publishErr != nil {
+		return nil, c.publishErr
+	}
+	// The broker never confirms this message
+	return &amqp091.DeferredConfirmation{}, nil
+}
+
+type testConnection struct {
+	closed     bool
+	channelErr error
+	channels   []*testChannel
+}
+
+func (c *testConnection) IsClosed() bool { return c.closed }
+
+func (c *testConnection) Channel() (amqpChannel, error) {
+	if c.channelErr != nil {
+		return nil, c.channelErr
+	}
+	channel := c.channels[0]
+	c.channels = c.channels[1:]
+	return channel, nil
+}
+
+func newTestPublisher(t *testing.T, conns ...*testConnection) *amqpPublisher {
+	origDial := dialConnection
+	dialConnection = func(url string, tlsConfig *tls.Config) (amqpConnection, error) {
+		if len(conns) == 0 {
+			return nil, fmt.Errorf("pop")
+		}
+		conn := conns[0]
+		conns = conns[1:]
+		return conn, nil
+	}
+	t.Cleanup(func() { dialConnection = origDial })
+
+	p, err := dialPublisher(context.Background(), "amqp://localhost:5672", nil)
+	assert.NoError(t, err)
+	return p.(*amqpPublisher)
+}
+
+func TestPublisherDialFail(t *testing.T) {
+	newTestPublisher(t, &testConnection{channels: []*testChannel{{}}})
+
+	dialConnection = func(url string, tlsConfig *tls.Config) (amqpConnection, error) {
+		return nil, fmt.Errorf("pop")
+	}
+	_, err := dialPublisher(context.Background(), "amqp://localhost:5672", nil)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestPublisherChannelFail(t *testing.T) {
+	conn := &testConnection{channels: []*testChannel{{}}}
+	p := newTestPublisher(t, conn)
+
+	p.channel.(*testChannel).closed = true
+	conn.channelErr = fmt.Errorf("pop")
+	err := p.DeclareQueue(context.Background(), "q1")
+	assert.Regexp(t, "pop", err)
+	_, err = p.Publish(context.Background(), "ex1", "rk1", amqp091.Publishing{})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestPublisherConfirmFail(t *testing.T) {
+	ch1 := &testChannel{}
+	ch2 := &testChannel{confirmErr: fmt.Errorf("pop")}
+	p := newTestPublisher(t, &testConnection{channels: []*testChannel{ch1, ch2}})
+
+	ch1.closed = true
+	err := p.DeclareQueue(context.Background(), "q1")
+	assert.Regexp(t, "pop", err)
+	assert.True(t, ch2.closed)
+}
+
+func TestPublisherReconnectAfterChannelClose(t *testing.T) {
+	ch1, ch2 := &testChannel{}, &testChannel{}
+	p := newTestPublisher(t, &testConnection{channels: []*testChannel{ch1, ch2}})
+
+	err := p.DeclareQueue(context.Background(), "q1")
+	assert.NoError(t, err)
+	err = p.DeclareQueue(context.Background(), "q1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"q1"}, ch1.declared)
+
+	// The declaration is repeated on the new channel, as the broker might have lost the queue
+	ch1.closed = true
+	err = p.DeclareQueue(context.Background(), "q1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"q1"}, ch2.declared)
+}
+
+func TestPublisherReconnectAfterConnectionClose(t *testing.T) {
+	conn1 := &testConnection{channels: []*testChannel{{}}}
+	ch2 := &testChannel{}
+	p := newTestPublisher(t, conn1, &testConnection{channels: []*testChannel{ch2}})
+
+	conn1.closed = true
+	p.channel.(*testChannel).closed = true
+	_, err := p.Publish(context.Background(), "ex1", "rk1", amqp091.Publishing{})
+	assert.NoError(t, err)
+	assert.Equal(t, ch2, p.channel)
+}
+
+func TestPublisherDeclareQueueFail(t *testing.T) {
+	ch := &testChannel{declareErr: fmt.Errorf("pop")}
+	p := newTestPublisher(t, &testConnection{channels: []*testChannel{ch}})
+
+	err := p.DeclareQueue(context.Background(), "q1")
+	assert.Regexp(t, "pop", err)
+	err = p.DeclareQueue(context.Background(), "q1")
+	assert.Regexp(t, "pop", err)
+	assert.Equal(t, []string{"q1", "q1"}, ch.declared)
+}
+
+func TestPublisherPublishFail(t *testing.T) {
+	p := newTestPublisher(t, &testConnection{channels: []*testChannel{{publishErr: fmt.Errorf("pop")}}})
+
+	_, err := p.Publish(context.Background(), "ex1", "rk1", amqp091.Publishing{})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestPublisherConfirmTimeout(t *testing.T) {
+	p := newTestPublisher(t, &testConnection{channels: []*testChannel{{}}})
+
+	confirmation, err := p.Publish(context.Background(), "ex1", "rk1", amqp091.Publishing{})
+	assert.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, err = confirmation.WaitContext(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+// testBroker speaks just enough AMQP 0-9-1 to test the publisher against the real client library
+type testBroker struct {
+	t                 *testing.T
+	listener          net.Listener
+	mux               sync.Mutex
+	confirm           string // "ack", "nack", or "" to never confirm
+	closeOnDeclare    string // queue that the broker refuses to declare, closing the channel
+	dropOnChannelOpen bool
+	channelsOpened    int
+}
+
+func newTestBroker(t *testing.T) *testBroker {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	b := &testBroker{t: t, listener: l, confirm: "ack"}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go b.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { _ = l.Close() })
+	return b
+}
+
+func (b *testBroker) url() string {
+	return fmt.Sprintf("amqp://%s", b.listener.Addr())
+}
+
+func (b *testBroker) writeMethod(w io.Writer, channel, class, method uint16, args ...interface{}) {
+	var payload bytes.Buffer
+	_ = binary.Write(&payload, binary.BigEndian, class)
+	_ = binary.Write(&payload, binary.BigEndian, method)
+	for _, arg := range args {
+		if s, ok := arg.(string); ok {
+			// All the strings we send are short strings
+			payload.WriteByte(byte(len(s)))
+			payload.WriteString(s)
+		} else {
+			_ = binary.Write(&payload, binary.BigEndian, arg)
+		}
+	}
+	var frame bytes.Buffer
+	frame.WriteByte(1 /* method */)
+	_ = binary.Write(&frame, binary.BigEndian, channel)
+	_ = binary.Write(&frame, binary.BigEndian, uint32(payload.Len()))
+	frame.Write(payload.Bytes())
+	frame.WriteByte(0xCE)
+	_, _ = w.Write(frame.Bytes())
+}
+
+func (b *testBroker) serve(conn net.Conn) {
+	defer conn.Close()
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return
+	}
+	mechanisms, locales := "PLAIN", "en_US"
+	b.writeMethod(conn, 0, 10, 10, uint8(0), uint8(9), uint32(0),
+		uint32(len(mechanisms)), []byte(mechanisms), uint32(len(locales)), []byte(locales))
+
+	var deliveryTag uint64
+	for {
+		frameHeader := make([]byte, 7)
+		if _, err := io.ReadFull(conn, frameHeader); err != nil {
+			return
+		}
+		channel := binary.BigEndian.Uint16(frameHeader[1:3])
+		payload := make([]byte, binary.BigEndian.Uint32(frameHeader[3:7])+1)
+		if _, err := io.ReadFull(conn, payload); err != nil {
+			return
+		}
+		b.mux.Lock()
+		confirm, closeOnDeclare, dropOnChannelOpen := b.confirm, b.closeOnDeclare, b.dropOnChannelOpen
+		b.mux.Unlock()
+
+		if frameHeader[0] == 3 /* body */ {
+			deliveryTag++
+			switch confirm {
+			case "ack":
+				b.writeMethod(conn, channel, 60, 80, deliveryTag, uint8(0))
+			case "nack":
+				b.writeMethod(conn, channel, 60, 120, deliveryTag, uint8(0))
+			}
+			continue
+		}
+		if frameHeader[0] != 1 /* method */ {
+			continue
+		}
+		switch [2]uint16{binary.BigEndian.Uint16(payload[0:2]), binary.BigEndian.Uint16(payload[2:4])} {
+		case [2]uint16{10, 11}: // connection.start-ok
+			b.writeMethod(conn, 0, 10, 30, uint16(0), uint32(131072), uint16(0))
+		case [2]uint16{10, 40}: // connection.open
+			b.writeMethod(conn, 0, 10, 41, "")
+		case [2]uint16{10, 50}: // connection.close
+			b.writeMethod(conn, 0, 10, 51)
+			return
+		case [2]uint16{20, 10}: // channel.open
+			if dropOnChannelOpen {
+				return
+			}
+			b.mux.Lock()
+			b.channelsOpened++
+			b.mux.Unlock()
+			b.writeMethod(conn, channel, 20, 11, uint32(0))
+		case [2]uint16{85, 10}: // confirm.select
+			deliveryTag = 0
+			b.writeMethod(conn, channel, 85, 11)
+		case [2]uint16{50, 10}: // queue.declare
+			name := string(payload[7 : 7+payload[6]])
+			if name == closeOnDeclare {
+				b.writeMethod(conn, channel, 20, 40, uint16(406), "PRECONDITION_FAILED", uint16(50), uint16(10))
+			} else {
+				b.writeMethod(conn, channel, 50, 11, name, uint32(0), uint32(0))
+			}
+		}
+	}
+}
+
+func (b *testBroker) set(fn func()) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	fn()
+}
+
+func TestPublisherBroker(t *testing.T) {
+	b := newTestBroker(t)
+	b.closeOnDeclare = "bad"
+
+	p, err := dialPublisher(context.Background(), b.url(), nil)
+	assert.NoError(t, err)
+
+	err = p.DeclareQueue(context.Background(), "q1")
+	assert.NoError(t, err)
+
+	confirmation, err := p.Publish(context.Background(), "", "q1", amqp091.Publishing{Body: []byte("hello")})
+	assert.NoError(t, err)
+	acked, err := confirmation.WaitContext(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, acked)
+
+	b.set(func() { b.confirm = "nack" })
+	confirmation, err = p.Publish(context.Background(), "", "q1", amqp091.Publishing{Body: []byte("hello")})
+	assert.NoError(t, err)
+	acked, err = confirmation.WaitContext(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, acked)
+
+	b.set(func() { b.confirm = "" })
+	confirmation, err = p.Publish(context.Background(), "", "q1", amqp091.Publishing{Body: []byte("hello")})
+	assert.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = confirmation.WaitContext(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// The broker closes the channel when it refuses a declaration, and the publisher opens a new one
+	err = p.DeclareQueue(context.Background(), "bad")
+	assert.Regexp(t, "PRECONDITION_FAILED", err)
+	b.set(func() { b.confirm = "ack" })
+	err = p.DeclareQueue(context.Background(), "q1")
+	assert.NoError(t, err)
+	confirmation, err = p.Publish(context.Background(), "", "q1", amqp091.Publishing{Body: []byte("hello")})
+	assert.NoError(t, err)
+	acked, err = confirmation.WaitContext(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, acked)
+
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	assert.Equal(t, 2, b.channelsOpened)
+}
+
+func TestPublisherBrokerChannelOpenFail(t *testing.T) {
+	b := newTestBroker(t)
+	b.dropOnChannelOpen = true
+
+	_, err := dialPublisher(context.Background(), b.url(), nil)
+	assert.Error(t, err)
+}
@@ -254,7 +254,13 @@ func TestBatchPinCompleteOkPrivate(t *testing.T) {
 
 	// Call through to persistBatch - the hash of our batch will be invalid,
 	// which is swallowed without error as we cannot retry (it is logged of course)
-	fn := em.mdi.Calls[1].Arguments[1].(func(ctx context.Context) error)
+	var fn func(ctx context.Context) error
+	for _, call := range em.mdi.Calls {
+		if call.Method == "RunAsGroup" {
+			fn = call.Arguments[1].(func(ctx context.Context) error)
+			break
+		}
+	}
 	err = fn(context.Background())
 	assert.NoError(t, err)
 
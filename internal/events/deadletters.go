@@ -0,0 +1,116 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+func (ed *eventDispatcher) recordDeadLetter(event *core.Event, response *core.EventDeliveryResponse) error {
+	deadLetter := &core.DeadLetter{
+		ID:           fftypes.NewUUID(),
+		Namespace:    ed.namespace,
+		Subscription: ed.subscription.definition.ID,
+		Event:        event.ID,
+		Reason:       response.Info,
+	}
+	log.L(ed.ctx).Infof("Recording dead letter %s for event %.10d/%s: %s", deadLetter.ID, event.Sequence, event.ID, deadLetter.Reason)
+	return ed.database.InsertDeadLetter(ed.ctx, deadLetter)
+}
+
+// replayDeadLetter redelivers a dead lettered event to the transport of its subscription, outside of
+// the ordered stream of the dispatchers, and deletes the dead letter once the transport acknowledges it.
+// Dead letters are only recorded for webhooks, which return the connection ID they were given with the
+// response, so each replay uses its own connection ID to tell its response apart from the response to
+// any delivery of the same event by a dispatcher. This also means the replay does not need a dispatcher
+// of the subscription, and works on any node. Note that replies are not sent for replayed events.
+func (sm *subscriptionManager) replayDeadLetter(ctx context.Context, deadLetter *core.DeadLetter) error {
+	sm.mux.Lock()
+	loaded, ok := sm.durableSubs[*deadLetter.Subscription]
+	sm.mux.Unlock()
+	if !ok {
+		return i18n.NewError(ctx, coremsgs.MsgSubscriptionNotActive, deadLetter.Subscription)
+	}
+	sub := loaded.definition
+	transport, err := sm.getTransport(ctx, sub.Transport)
+	if err != nil {
+		return err
+	}
+
+	event, err := sm.database.GetEventByID(ctx, sm.namespace.Name, deadLetter.Event)
+	if err != nil {
+		return err
+	}
+	if event == nil {
+		return i18n.NewError(ctx, coremsgs.Msg404NoResult)
+	}
+	enrichedEvent, err := sm.enricher.enrichEvent(ctx, event)
+	if err != nil {
+		return err
+	}
+	var data core.DataArray
+	withData := sub.Options.WithData != nil && *sub.Options.WithData
+	if withData && enrichedEvent.Message != nil {
+		if data, _, err = sm.data.GetMessageDataCached(ctx, enrichedEvent.Message); err != nil {
+			return err
+		}
+	}
+
+	connID := fftypes.NewUUID().String()
+	responses := make(chan *core.EventDeliveryResponse, 1)
+	sm.mux.Lock()
+	sm.replays[connID] = responses
+	sm.mux.Unlock()
+	defer func() {
+		sm.mux.Lock()
+		delete(sm.replays, connID)
+		sm.mux.Unlock()
+	}()
+
+	log.L(ctx).Infof("Replaying dead letter %s for event %s on subscription %s conn=%s", deadLetter.ID, event.ID, sub.ID, connID)
+	delivery := &core.EventDelivery{
+		EnrichedEvent: *enrichedEvent,
+		Subscription:  sub.SubscriptionRef,
+	}
+	if sub.Options.Batch != nil && *sub.Options.Batch {
+		err = transport.BatchDeliveryRequest(ctx, connID, sub, []*core.CombinedEventDataDelivery{
+			{Event: delivery, Data: data},
+		})
+	} else {
+		err = transport.DeliveryRequest(ctx, connID, sub, delivery, data)
+	}
+	if err != nil {
+		return i18n.NewError(ctx, coremsgs.MsgDeadLetterReplayFailed, deadLetter.ID, err)
+	}
+
+	select {
+	case response := <-responses:
+		if response.Rejected || response.DeadLetter {
+			return i18n.NewError(ctx, coremsgs.MsgDeadLetterReplayFailed, deadLetter.ID, response.Info)
+		}
+	case <-ctx.Done():
+		return i18n.NewError(ctx, coremsgs.MsgDeadLetterReplayFailed, deadLetter.ID, ctx.Err())
+	}
+
+	return sm.database.DeleteDeadLetterByID(ctx, sm.namespace.Name, deadLetter.ID)
+}
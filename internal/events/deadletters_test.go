@@ -0,0 +1,340 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/datamocks"
+	"github.com/hyperledger/firefly/mocks/eventsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestDeadLetterReplay(t *testing.T, withData bool) (*subscriptionManager, *eventsmocks.Plugin, *core.DeadLetter, func()) {
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	sub := &subscription{
+		definition: &core.Subscription{
+			SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Namespace: "ns1", Name: "sub1"},
+			Transport:       "ut",
+		},
+	}
+	sub.definition.Options.WithData = &withData
+	sm.durableSubs[*sub.definition.ID] = sub
+	deadLetter := &core.DeadLetter{
+		ID:           fftypes.NewUUID(),
+		Namespace:    "ns1",
+		Subscription: sub.definition.ID,
+		Event:        fftypes.NewUUID(),
+		Reason:       "pop",
+	}
+	return sm, mei, deadLetter, cancel
+}
+
+func TestDispatcherRecordsDeadLetter(t *testing.T) {
+	sub := &subscription{
+		definition: &core.Subscription{
+			SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Namespace: "ns1"},
+		},
+	}
+	ed, cancel := newTestEventDispatcher(sub)
+	defer cancel()
+
+	mdi := ed.database.(*databasemocks.Plugin)
+	event := &core.Event{ID: fftypes.NewUUID(), Sequence: 10}
+	mdi.On("InsertDeadLetter", mock.Anything, mock.MatchedBy(func(deadLetter *core.DeadLetter) bool {
+		return *deadLetter.Subscription == *sub.definition.ID &&
+			*deadLetter.Event == *event.ID &&
+			deadLetter.Namespace == "ns1" &&
+			deadLetter.Reason == "pop"
+	})).Return(nil)
+
+	ed.inflight[*event.ID] = event
+	go ed.deliveryResponse(&core.EventDeliveryResponse{ID: event.ID, DeadLetter: true, Info: "pop"})
+	an := <-ed.acksNacks
+	assert.False(t, an.isNack)
+	assert.Equal(t, int64(10), an.offset)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestDispatcherRecordDeadLetterFailNacks(t *testing.T) {
+	sub := &subscription{
+		definition: &core.Subscription{
+			SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Namespace: "ns1"},
+		},
+	}
+	ed, cancel := newTestEventDispatcher(sub)
+	defer cancel()
+
+	mdi := ed.database.(*databasemocks.Plugin)
+	mdi.On("InsertDeadLetter", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	event := &core.Event{ID: fftypes.NewUUID(), Sequence: 10}
+	ed.inflight[*event.ID] = event
+	go ed.deliveryResponse(&core.EventDeliveryResponse{ID: event.ID, DeadLetter: true, Info: "pop"})
+	an := <-ed.acksNacks
+	assert.True(t, an.isNack)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestReplayDeadLetterOk(t *testing.T) {
+	sm, mei, deadLetter, cancel := newTestDeadLetterReplay(t, true)
+	defer cancel()
+
+	mdi := sm.database.(*databasemocks.Plugin)
+	mdm := sm.data.(*datamocks.Manager)
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}}
+	data := core.DataArray{{ID: fftypes.NewUUID()}}
+	mdi.On("GetEventByID", mock.Anything, "ns1", deadLetter.Event).Return(&core.Event{
+		ID:        deadLetter.Event,
+		Type:      core.EventTypeMessageConfirmed,
+		Reference: msg.Header.ID,
+	}, nil)
+	mdm.On("GetMessageWithDataCached", mock.Anything, msg.Header.ID).Return(msg, data, true, nil)
+	mdm.On("GetMessageDataCached", mock.Anything, msg).Return(data, true, nil)
+	mei.On("DeliveryRequest", mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(event *core.EventDelivery) bool {
+		return *event.ID == *deadLetter.Event && *event.Subscription.ID == *deadLetter.Subscription
+	}), data).Return(nil).Run(func(args mock.Arguments) {
+		sm.deliveryResponse(mei, args[1].(string), &core.EventDeliveryResponse{
+			ID:           deadLetter.Event,
+			Subscription: core.SubscriptionRef{ID: deadLetter.Subscription},
+		})
+	})
+	mdi.On("DeleteDeadLetterByID", mock.Anything, "ns1", deadLetter.ID).Return(nil)
+
+	err := sm.replayDeadLetter(context.Background(), deadLetter)
+	assert.NoError(t, err)
+	assert.Empty(t, sm.replays)
+
+	mdi.AssertExpectations(t)
+	mdm.AssertExpectations(t)
+	mei.AssertNumberOfCalls(t, "DeliveryRequest", 1)
+}
+
+func TestReplayDeadLetterFailedAgain(t *testing.T) {
+	sm, mei, deadLetter, cancel := newTestDeadLetterReplay(t, false)
+	defer cancel()
+
+	mdi := sm.database.(*databasemocks.Plugin)
+	mdi.On("GetEventByID", mock.Anything, "ns1", deadLetter.Event).Return(&core.Event{ID: deadLetter.Event}, nil)
+	mei.On("DeliveryRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything, core.DataArray(nil)).Return(nil).Run(func(args mock.Arguments) {
+		sm.deliveryResponse(mei, args[1].(string), &core.EventDeliveryResponse{
+			ID:           deadLetter.Event,
+			Subscription: core.SubscriptionRef{ID: deadLetter.Subscription},
+			DeadLetter:   true,
+			Info:         "still down",
+		})
+	})
+
+	err := sm.replayDeadLetter(context.Background(), deadLetter)
+	assert.Regexp(t, "FF10509.*still down", err)
+
+	mdi.AssertExpectations(t)
+	mei.AssertNumberOfCalls(t, "DeliveryRequest", 1)
+}
+
+func TestReplayDeadLetterBatch(t *testing.T) {
+	sm, mei, deadLetter, cancel := newTestDeadLetterReplay(t, false)
+	defer cancel()
+
+	batch := true
+	sm.durableSubs[*deadLetter.Subscription].definition.Options.Batch = &batch
+	mdi := sm.database.(*databasemocks.Plugin)
+	mdi.On("GetEventByID", mock.Anything, "ns1", deadLetter.Event).Return(&core.Event{ID: deadLetter.Event}, nil)
+	mei.On("BatchDeliveryRequest", mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(events []*core.CombinedEventDataDelivery) bool {
+		return len(events) == 1 && *events[0].Event.ID == *deadLetter.Event
+	})).Return(nil).Run(func(args mock.Arguments) {
+		sm.deliveryResponse(mei, args[1].(string), &core.EventDeliveryResponse{
+			ID:           deadLetter.Event,
+			Subscription: core.SubscriptionRef{ID: deadLetter.Subscription},
+		})
+	})
+	mdi.On("DeleteDeadLetterByID", mock.Anything, "ns1", deadLetter.ID).Return(nil)
+
+	err := sm.replayDeadLetter(context.Background(), deadLetter)
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+	mei.AssertNumberOfCalls(t, "BatchDeliveryRequest", 1)
+}
+
+func TestReplayDeadLetterDeliveryRequestFail(t *testing.T) {
+	sm, mei, deadLetter, cancel := newTestDeadLetterReplay(t, false)
+	defer cancel()
+
+	mdi := sm.database.(*databasemocks.Plugin)
+	mdi.On("GetEventByID", mock.Anything, "ns1", deadLetter.Event).Return(&core.Event{ID: deadLetter.Event}, nil)
+	mei.On("DeliveryRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := sm.replayDeadLetter(context.Background(), deadLetter)
+	assert.Regexp(t, "FF10509.*pop", err)
+}
+
+func TestReplayDeadLetterContextDone(t *testing.T) {
+	sm, mei, deadLetter, cancel := newTestDeadLetterReplay(t, false)
+	defer cancel()
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	mdi := sm.database.(*databasemocks.Plugin)
+	mdi.On("GetEventByID", mock.Anything, "ns1", deadLetter.Event).Return(&core.Event{ID: deadLetter.Event}, nil)
+	mei.On("DeliveryRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		cancelCtx()
+	})
+
+	err := sm.replayDeadLetter(ctx, deadLetter)
+	assert.Regexp(t, "FF10509", err)
+}
+
+func TestReplayDeadLetterGetDataFail(t *testing.T) {
+	sm, _, deadLetter, cancel := newTestDeadLetterReplay(t, true)
+	defer cancel()
+
+	mdi := sm.database.(*databasemocks.Plugin)
+	mdm := sm.data.(*datamocks.Manager)
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}}
+	mdi.On("GetEventByID", mock.Anything, "ns1", deadLetter.Event).Return(&core.Event{
+		ID:        deadLetter.Event,
+		Type:      core.EventTypeMessageConfirmed,
+		Reference: msg.Header.ID,
+	}, nil)
+	mdm.On("GetMessageWithDataCached", mock.Anything, msg.Header.ID).Return(msg, nil, true, nil)
+	mdm.On("GetMessageDataCached", mock.Anything, msg).Return(nil, false, fmt.Errorf("pop"))
+
+	err := sm.replayDeadLetter(context.Background(), deadLetter)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReplayDeadLetterEnrichFail(t *testing.T) {
+	sm, _, deadLetter, cancel := newTestDeadLetterReplay(t, false)
+	defer cancel()
+
+	mdi := sm.database.(*databasemocks.Plugin)
+	mdm := sm.data.(*datamocks.Manager)
+	msgID := fftypes.NewUUID()
+	mdi.On("GetEventByID", mock.Anything, "ns1", deadLetter.Event).Return(&core.Event{
+		ID:        deadLetter.Event,
+		Type:      core.EventTypeMessageConfirmed,
+		Reference: msgID,
+	}, nil)
+	mdm.On("GetMessageWithDataCached", mock.Anything, msgID).Return(nil, nil, false, fmt.Errorf("pop"))
+
+	err := sm.replayDeadLetter(context.Background(), deadLetter)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReplayDeadLetterEventNotFound(t *testing.T) {
+	sm, _, deadLetter, cancel := newTestDeadLetterReplay(t, false)
+	defer cancel()
+
+	mdi := sm.database.(*databasemocks.Plugin)
+	mdi.On("GetEventByID", mock.Anything, "ns1", deadLetter.Event).Return(nil, nil)
+
+	err := sm.replayDeadLetter(context.Background(), deadLetter)
+	assert.Regexp(t, "FF10143", err)
+}
+
+func TestReplayDeadLetterGetEventFail(t *testing.T) {
+	sm, _, deadLetter, cancel := newTestDeadLetterReplay(t, false)
+	defer cancel()
+
+	mdi := sm.database.(*databasemocks.Plugin)
+	mdi.On("GetEventByID", mock.Anything, "ns1", deadLetter.Event).Return(nil, fmt.Errorf("pop"))
+
+	err := sm.replayDeadLetter(context.Background(), deadLetter)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReplayDeadLetterSubscriptionNotActive(t *testing.T) {
+	sm, _, deadLetter, cancel := newTestDeadLetterReplay(t, false)
+	defer cancel()
+
+	deadLetter.Subscription = fftypes.NewUUID()
+
+	err := sm.replayDeadLetter(context.Background(), deadLetter)
+	assert.Regexp(t, "FF10510", err)
+}
+
+func TestReplayDeadLetterUnknownTransport(t *testing.T) {
+	sm, _, deadLetter, cancel := newTestDeadLetterReplay(t, false)
+	defer cancel()
+
+	sm.durableSubs[*deadLetter.Subscription].definition.Transport = "wrong"
+
+	err := sm.replayDeadLetter(context.Background(), deadLetter)
+	assert.Regexp(t, "FF10172", err)
+}
+
+func TestReplayDeadLetterSameEventInFlightOnDispatcher(t *testing.T) {
+	sm, mei, deadLetter, cancel := newTestDeadLetterReplay(t, false)
+	defer cancel()
+
+	// The dispatcher has the same event in flight, for example after a rewind
+	ed, cancelEd := newTestEventDispatcher(sm.durableSubs[*deadLetter.Subscription])
+	defer cancelEd()
+	ed.connID = "conn1"
+	ed.transport = mei
+	ed.acksNacks = make(chan ackNack, 1)
+	ed.inflight[*deadLetter.Event] = &core.Event{ID: deadLetter.Event}
+	sm.connections["conn1"] = &connection{
+		id:          "conn1",
+		transport:   "ut",
+		ei:          mei,
+		dispatchers: map[fftypes.UUID]*eventDispatcher{*deadLetter.Subscription: ed},
+	}
+
+	mdi := sm.database.(*databasemocks.Plugin)
+	mdi.On("GetEventByID", mock.Anything, "ns1", deadLetter.Event).Return(&core.Event{ID: deadLetter.Event}, nil)
+	mei.On("DeliveryRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		assert.NotEqual(t, "conn1", args[1])
+		// The response from the dispatcher goes to the dispatcher, and the response from the replay to the replay
+		sm.deliveryResponse(mei, "conn1", &core.EventDeliveryResponse{
+			ID:           deadLetter.Event,
+			Subscription: core.SubscriptionRef{ID: deadLetter.Subscription},
+		})
+		sm.deliveryResponse(mei, args[1].(string), &core.EventDeliveryResponse{
+			ID:           deadLetter.Event,
+			Subscription: core.SubscriptionRef{ID: deadLetter.Subscription},
+			DeadLetter:   true,
+			Info:         "still down",
+		})
+	})
+
+	err := sm.replayDeadLetter(context.Background(), deadLetter)
+	assert.Regexp(t, "FF10509.*still down", err)
+	an := <-ed.acksNacks
+	assert.False(t, an.isNack)
+}
+
+func TestReplayDeadLetterDuplicateResponseIgnored(t *testing.T) {
+	sm, mei, _, cancel := newTestDeadLetterReplay(t, false)
+	defer cancel()
+
+	responses := make(chan *core.EventDeliveryResponse, 1)
+	sm.replays["replay1"] = responses
+	response := &core.EventDeliveryResponse{ID: fftypes.NewUUID()}
+	sm.deliveryResponse(mei, "replay1", response)
+	sm.deliveryResponse(mei, "replay1", response)
+	assert.Len(t, responses, 1)
+}
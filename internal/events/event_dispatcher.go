@@ -32,6 +32,7 @@ import (
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/data"
+	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/privatemessaging"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/pkg/core"
@@ -50,6 +51,15 @@ type ackNack struct {
 	offset int64
 }
 
+// deliveryStats are the counts of deliveries made by a dispatcher since it started, protected by the dispatcher mutex
+type deliveryStats struct {
+	delivered       int64
+	acked           int64
+	rejected        int64
+	lastDelivery    *fftypes.FFTime
+	highestSequence int64
+}
+
 type eventDispatcher struct {
 	acksNacks     chan ackNack
 	cancelCtx     func()
@@ -72,9 +82,11 @@ type eventDispatcher struct {
 	batch         bool
 	subscription  *subscription
 	txHelper      txcommon.Helper
+	metrics       metrics.Manager
+	stats         deliveryStats
 }
 
-func newEventDispatcher(ctx context.Context, enricher *eventEnricher, ei events.Plugin, di database.Plugin, dm data.Manager, bm broadcast.Manager, pm privatemessaging.Manager, connID string, sub *subscription, en *eventNotifier, txHelper txcommon.Helper) *eventDispatcher {
+func newEventDispatcher(ctx context.Context, enricher *eventEnricher, ei events.Plugin, di database.Plugin, dm data.Manager, bm broadcast.Manager, pm privatemessaging.Manager, connID string, sub *subscription, en *eventNotifier, txHelper txcommon.Helper, mm metrics.Manager) *eventDispatcher {
 	ctx, cancelCtx := context.WithCancel(ctx)
 	readAhead := uint64(0)
 	if sub.definition.Options.ReadAhead != nil {
@@ -109,6 +121,7 @@ func newEventDispatcher(ctx context.Context, enricher *eventEnricher, ei events.
 		acksNacks:     make(chan ackNack),
 		closed:        make(chan struct{}),
 		txHelper:      txHelper,
+		metrics:       mm,
 		batch:         batch,
 	}
 
@@ -116,6 +129,7 @@ func newEventDispatcher(ctx context.Context, enricher *eventEnricher, ei events.
 		eventBatchSize:             config.GetUint64(coreconfig.EventDispatcherBufferLength),
 		eventBatchTimeout:          config.GetDuration(coreconfig.EventDispatcherBatchTimeout),
 		eventPollTimeout:           config.GetDuration(coreconfig.EventDispatcherPollTimeout),
+		changeFeedPollTimeout:      config.GetDuration(coreconfig.EventDispatcherChangeFeedPollTimeout),
 		startupOffsetRetryAttempts: 0, // We need to keep trying to start indefinitely
 		retry: retry.Retry{
 			InitialDelay: config.GetDuration(coreconfig.EventDispatcherRetryInitDelay),
@@ -216,6 +230,33 @@ func (ed *eventDispatcher) filterEvents(candidates []*core.EventDelivery) []*cor
 	return matchingEvents
 }
 
+// filterEventsByExpression applies the expression filter of the subscription (if any). It runs after the
+// cheaper regular expression filters, so we only load the data for messages that could be delivered
+func (ed *eventDispatcher) filterEventsByExpression(candidates []*core.EventDelivery) ([]*core.EventDelivery, error) {
+	if !ed.subscription.HasExpression() {
+		return candidates, nil
+	}
+	withData := ed.subscription.definition.Options.WithData != nil && *ed.subscription.definition.Options.WithData
+	matchingEvents := make([]*core.EventDelivery, 0, len(candidates))
+	for _, event := range candidates {
+		var data core.DataArray
+		if withData && event.Message != nil {
+			var err error
+			if data, _, err = ed.data.GetMessageDataCached(ed.ctx, event.Message); err != nil {
+				return nil, err
+			}
+		}
+		doc, err := ed.enricher.expressionDocument(&event.EnrichedEvent, data)
+		if err != nil {
+			return nil, err
+		}
+		if ed.subscription.MatchesExpression(ed.ctx, event.ID, doc) {
+			matchingEvents = append(matchingEvents, event)
+		}
+	}
+	return matchingEvents, nil
+}
+
 func (ed *eventDispatcher) bufferedDelivery(events []core.LocallySequenced) (bool, error) {
 	// At this point, the page of messages we've been given are loaded from the DB into memory,
 	// but we can only make them in-flight and push them to the client up to the maximum
@@ -225,6 +266,11 @@ func (ed *eventDispatcher) bufferedDelivery(events []core.LocallySequenced) (boo
 		return false, nil
 	}
 	highestOffset := events[len(events)-1].LocalSequence()
+	ed.mux.Lock()
+	if highestOffset > ed.stats.highestSequence {
+		ed.stats.highestSequence = highestOffset
+	}
+	ed.mux.Unlock()
 	var lastAck int64
 	var nacks int
 
@@ -235,6 +281,9 @@ func (ed *eventDispatcher) bufferedDelivery(events []core.LocallySequenced) (boo
 	}
 
 	matching := ed.filterEvents(candidates)
+	if matching, err = ed.filterEventsByExpression(matching); err != nil {
+		return false, err
+	}
 	matchCount := len(matching)
 	dispatched := 0
 
@@ -256,6 +305,7 @@ func (ed *eventDispatcher) bufferedDelivery(events []core.LocallySequenced) (boo
 
 		l.Debugf("Dispatcher event state: readahead=%d candidates=%d matched=%d inflight=%d queued=%d dispatched=%d dispatchable=%d lastAck=%d nacks=%d highest=%d",
 			ed.readAhead, len(candidates), matchCount, inflightCount, len(matching), dispatched, len(dispatchable), lastAck, nacks, highestOffset)
+		ed.publishStatus()
 
 		for _, event := range dispatchable {
 			ed.mux.Lock()
@@ -297,6 +347,7 @@ func (ed *eventDispatcher) bufferedDelivery(events []core.LocallySequenced) (boo
 	if nacks == 0 && lastAck != highestOffset {
 		ed.eventPoller.commitOffset(highestOffset)
 	}
+	ed.publishStatus()
 	return true, nil // poll again straight away for more messages
 }
 
@@ -360,6 +411,8 @@ func (ed *eventDispatcher) deliverEvents() {
 				if !ed.batch {
 					// .. only attempt to deliver if we've not triggered into an error scenario for one of the events already
 					if err == nil {
+						// Recorded before the request, as some transports respond before DeliveryRequest returns
+						ed.recordDelivered(1)
 						err = ed.transport.DeliveryRequest(ed.ctx, ed.connID, ed.subscription.definition, e.Event, e.Data)
 					}
 					// ... if we've triggered into an error scenario, we need to nack immediately for this and all the rest of the events
@@ -373,6 +426,7 @@ func (ed *eventDispatcher) deliverEvents() {
 			if ed.batch {
 				// Only attempt to deliver if we're in a non error case (enrich might have failed above)
 				if err == nil {
+					ed.recordDelivered(len(events))
 					err = ed.transport.BatchDeliveryRequest(ed.ctx, ed.connID, ed.subscription.definition, eventsWithData)
 				}
 				// If we're in an error case we have to nack everything immediately
@@ -408,6 +462,16 @@ func (ed *eventDispatcher) deliveryResponse(response *core.EventDeliveryResponse
 		return
 	}
 
+	// A dead letter must be recorded before the offset can move past the event, otherwise it is redelivered
+	if response.DeadLetter {
+		if err := ed.recordDeadLetter(event, response); err != nil {
+			l.Errorf("Failed to record dead letter for event %s: %s", event.ID, err)
+			an.isNack = true
+		}
+	}
+
+	ed.recordResponse(an.isNack)
+
 	// We might have a message to send, do that before we dispatch the ack
 	// Note a failure to send the reply does not invalidate the ack
 	if response.Reply != nil {
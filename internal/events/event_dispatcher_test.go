@@ -33,6 +33,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/mocks/datamocks"
 	"github.com/hyperledger/firefly/mocks/eventsmocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
 	"github.com/hyperledger/firefly/mocks/operationmocks"
 	"github.com/hyperledger/firefly/mocks/privatemessagingmocks"
 	"github.com/hyperledger/firefly/mocks/syncasyncmocks"
@@ -45,6 +46,7 @@ import (
 
 func newTestEventDispatcher(sub *subscription) (*eventDispatcher, func()) {
 	mdi := &databasemocks.Plugin{}
+	mdi.On("Capabilities").Return(&database.Capabilities{}).Maybe()
 	mei := &eventsmocks.Plugin{}
 	mei.On("Capabilities").Return(&events.Capabilities{}).Maybe()
 	mei.On("Name").Return("ut").Maybe()
@@ -56,9 +58,11 @@ func newTestEventDispatcher(sub *subscription) (*eventDispatcher, func()) {
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
-	enricher := newEventEnricher("ns1", mdi, mdm, mom, txHelper)
+	enricher := newEventEnricher("ns1", mdi, mdm, mom, txHelper, cache.NewUmanagedCache(ctx, 100, 5*time.Minute))
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(false).Maybe()
 	ctx, cancel := context.WithCancel(context.Background())
-	return newEventDispatcher(ctx, enricher, mei, mdi, mdm, mbm, mpm, fftypes.NewUUID().String(), sub, newEventNotifier(ctx, "ut"), txHelper), func() {
+	return newEventDispatcher(ctx, enricher, mei, mdi, mdm, mbm, mpm, fftypes.NewUUID().String(), sub, newEventNotifier(ctx, "ut"), txHelper, mmi), func() {
 		cancel()
 		coreconfig.Reset()
 	}
@@ -1303,3 +1307,137 @@ func TestEventDeliveryBatch(t *testing.T) {
 	mbm.AssertExpectations(t)
 	mms.AssertExpectations(t)
 }
+
+func TestFilterEventsByExpression(t *testing.T) {
+	withData := true
+	sub := &subscription{
+		definition: &core.Subscription{
+			Options: core.SubscriptionOptions{
+				SubscriptionCoreOptions: core.SubscriptionCoreOptions{
+					WithData: &withData,
+				},
+			},
+		},
+	}
+	ed, cancel := newTestEventDispatcher(sub)
+	defer cancel()
+
+	msg1 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID(), Tag: "order"}}
+	msg2 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID(), Tag: "order"}}
+	events := []*core.EventDelivery{
+		{EnrichedEvent: core.EnrichedEvent{Event: core.Event{ID: fftypes.NewUUID()}, Message: msg1}},
+		{EnrichedEvent: core.EnrichedEvent{Event: core.Event{ID: fftypes.NewUUID()}, Message: msg2}},
+		{EnrichedEvent: core.EnrichedEvent{Event: core.Event{ID: fftypes.NewUUID()}}},
+	}
+
+	// No expression passes everything through
+	matched, err := ed.filterEventsByExpression(events)
+	assert.NoError(t, err)
+	assert.Len(t, matched, 3)
+
+	mdm := ed.data.(*datamocks.Manager)
+	mdm.On("GetMessageDataCached", ed.ctx, msg1).Return(core.DataArray{
+		{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`{"amount": 150}`)},
+	}, true, nil)
+	mdm.On("GetMessageDataCached", ed.ctx, msg2).Return(core.DataArray{
+		{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`{"amount": 50}`)},
+	}, true, nil)
+
+	ed.subscription.expressionFilter, err = expressionLanguage.NewEvaluable(`$.message.header.tag == "order" && $.data[0].value.amount > 100`)
+	assert.NoError(t, err)
+	matched, err = ed.filterEventsByExpression(events)
+	assert.NoError(t, err)
+	assert.Len(t, matched, 1)
+	assert.Equal(t, events[0].ID, matched[0].ID)
+
+	// A second subscription evaluates the same cached documents
+	ed.subscription.expressionFilter, err = expressionLanguage.NewEvaluable(`$.data[0].value.amount < 100`)
+	assert.NoError(t, err)
+	matched, err = ed.filterEventsByExpression(events)
+	assert.NoError(t, err)
+	assert.Len(t, matched, 1)
+	assert.Equal(t, events[1].ID, matched[0].ID)
+
+	mdm.AssertExpectations(t)
+}
+
+func TestFilterEventsByExpressionDocumentFail(t *testing.T) {
+	withData := true
+	sub := &subscription{
+		definition: &core.Subscription{
+			Options: core.SubscriptionOptions{
+				SubscriptionCoreOptions: core.SubscriptionCoreOptions{
+					WithData: &withData,
+				},
+			},
+		},
+	}
+	sub.expressionFilter, _ = expressionLanguage.NewEvaluable(`$.data[0].value.amount > 100`)
+	ed, cancel := newTestEventDispatcher(sub)
+	defer cancel()
+
+	mdm := ed.data.(*datamocks.Manager)
+	mdm.On("GetMessageDataCached", ed.ctx, mock.Anything).Return(core.DataArray{
+		{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`!json`)},
+	}, true, nil)
+
+	_, err := ed.filterEventsByExpression([]*core.EventDelivery{
+		{EnrichedEvent: core.EnrichedEvent{Event: core.Event{ID: fftypes.NewUUID()}, Message: &core.Message{}}},
+	})
+	assert.Error(t, err)
+
+	mdm.AssertExpectations(t)
+}
+
+func TestFilterEventsByExpressionDataFail(t *testing.T) {
+	withData := true
+	sub := &subscription{
+		definition: &core.Subscription{
+			Options: core.SubscriptionOptions{
+				SubscriptionCoreOptions: core.SubscriptionCoreOptions{
+					WithData: &withData,
+				},
+			},
+		},
+	}
+	sub.expressionFilter, _ = expressionLanguage.NewEvaluable(`$.data[0].value.amount > 100`)
+	ed, cancel := newTestEventDispatcher(sub)
+	defer cancel()
+
+	mdm := ed.data.(*datamocks.Manager)
+	mdm.On("GetMessageDataCached", ed.ctx, mock.Anything).Return(nil, false, fmt.Errorf("pop"))
+
+	_, err := ed.filterEventsByExpression([]*core.EventDelivery{
+		{EnrichedEvent: core.EnrichedEvent{Event: core.Event{ID: fftypes.NewUUID()}, Message: &core.Message{}}},
+	})
+	assert.EqualError(t, err, "pop")
+
+	mdm.AssertExpectations(t)
+}
+
+func TestBufferedDeliveryExpressionDataFail(t *testing.T) {
+	withData := true
+	sub := &subscription{
+		definition: &core.Subscription{
+			Options: core.SubscriptionOptions{
+				SubscriptionCoreOptions: core.SubscriptionCoreOptions{
+					WithData: &withData,
+				},
+			},
+		},
+	}
+	sub.expressionFilter, _ = expressionLanguage.NewEvaluable(`$.data[0].value.amount > 100`)
+	ed, cancel := newTestEventDispatcher(sub)
+	defer cancel()
+
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}}
+	mdm := ed.data.(*datamocks.Manager)
+	mdm.On("GetMessageWithDataCached", mock.Anything, msg.Header.ID).Return(msg, nil, true, nil)
+	mdm.On("GetMessageDataCached", ed.ctx, msg).Return(nil, false, fmt.Errorf("pop"))
+
+	repoll, err := ed.bufferedDelivery([]core.LocallySequenced{&core.Event{ID: fftypes.NewUUID(), Type: core.EventTypeMessageConfirmed, Reference: msg.Header.ID}})
+	assert.False(t, repoll)
+	assert.EqualError(t, err, "pop")
+
+	mdm.AssertExpectations(t)
+}
@@ -18,7 +18,10 @@ package events
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
+	"github.com/hyperledger/firefly-common/pkg/cache"
 	"github.com/hyperledger/firefly/internal/data"
 	"github.com/hyperledger/firefly/internal/operations"
 	"github.com/hyperledger/firefly/internal/txcommon"
@@ -27,20 +30,22 @@ import (
 )
 
 type eventEnricher struct {
-	namespace  string
-	data       data.Manager
-	database   database.Plugin
-	operations operations.Manager
-	txHelper   txcommon.Helper
+	namespace      string
+	data           data.Manager
+	database       database.Plugin
+	operations     operations.Manager
+	txHelper       txcommon.Helper
+	expressionDocs cache.CInterface
 }
 
-func newEventEnricher(ns string, di database.Plugin, dm data.Manager, om operations.Manager, txHelper txcommon.Helper) *eventEnricher {
+func newEventEnricher(ns string, di database.Plugin, dm data.Manager, om operations.Manager, txHelper txcommon.Helper, expressionDocs cache.CInterface) *eventEnricher {
 	return &eventEnricher{
-		namespace:  ns,
-		data:       dm,
-		database:   di,
-		operations: om,
-		txHelper:   txHelper,
+		namespace:      ns,
+		data:           dm,
+		database:       di,
+		operations:     om,
+		txHelper:       txHelper,
+		expressionDocs: expressionDocs,
 	}
 }
 
@@ -137,3 +142,27 @@ func (em *eventEnricher) enrichEvent(ctx context.Context, event *core.Event) (*c
 	}
 	return e, nil
 }
+
+// expressionDocument returns the generic JSON form of the enriched event, with any message data available
+// as "data", that expression filters are evaluated against. Every subscription with an expression filter
+// evaluates it against the same events, so the document is cached rather than built for each of them.
+func (em *eventEnricher) expressionDocument(event *core.EnrichedEvent, data core.DataArray) (interface{}, error) {
+	key := fmt.Sprintf("%s:%t", event.ID, len(data) > 0)
+	if cached := em.expressionDocs.Get(key); cached != nil {
+		return cached, nil
+	}
+
+	var doc interface{}
+	b, err := json.Marshal(&expressionInput{
+		EnrichedEvent: event,
+		Data:          data,
+	})
+	if err == nil {
+		err = json.Unmarshal(b, &doc)
+	}
+	if err != nil {
+		return nil, err
+	}
+	em.expressionDocs.Set(key, doc)
+	return doc, nil
+}
@@ -42,7 +42,7 @@ func newTestEventEnricher() *eventEnricher {
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
-	return newEventEnricher("ns1", mdi, mdm, mom, txHelper)
+	return newEventEnricher("ns1", mdi, mdm, mom, txHelper, cache.NewUmanagedCache(ctx, 100, 5*time.Minute))
 }
 
 func TestEnrichMessageConfirmed(t *testing.T) {
@@ -63,6 +63,9 @@ type EventManager interface {
 	EnrichEvent(ctx context.Context, event *core.Event) (*core.EnrichedEvent, error)
 	EnrichEvents(ctx context.Context, events []*core.Event) ([]*core.EnrichedEvent, error)
 	FilterHistoricalEventsOnSubscription(ctx context.Context, events []*core.EnrichedEvent, sub *core.Subscription) ([]*core.EnrichedEvent, error)
+	ReplayDeadLetter(ctx context.Context, deadLetter *core.DeadLetter) error
+	RewindDurableSubscription(ctx context.Context, subDef *core.Subscription, offset int64) error
+	GetSubscriptionDeliveryStatus(id *fftypes.UUID) *core.SubscriptionStatus
 	QueueBatchRewind(batchID *fftypes.UUID)
 	ResolveTransportAndCapabilities(ctx context.Context, transportName string) (string, *events.Capabilities, error)
 	Start() error
@@ -135,6 +138,18 @@ func NewEventManager(ctx context.Context, ns *core.Namespace, di database.Plugin
 		return nil, err
 	}
 
+	expressionDocCache, err := cacheManager.GetCache(
+		cache.NewCacheConfig(
+			ctx,
+			coreconfig.CacheExpressionDocumentLimit,
+			coreconfig.CacheExpressionDocumentTTL,
+			ns.Name,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	em := &eventManager{
 		ctx:            log.WithLogField(ctx, "role", "event-manager"),
 		namespace:      ns,
@@ -171,9 +186,9 @@ func NewEventManager(ctx context.Context, ns *core.Namespace, di database.Plugin
 		em.blobReceiver = newBlobReceiver(ctx, em.aggregator)
 	}
 
-	em.enricher = newEventEnricher(ns.Name, di, dm, om, txHelper)
+	em.enricher = newEventEnricher(ns.Name, di, dm, om, txHelper, expressionDocCache)
 
-	if em.subManager, err = newSubscriptionManager(ctx, ns, em.enricher, di, dm, newEventNotifier, bm, pm, txHelper, transports); err != nil {
+	if em.subManager, err = newSubscriptionManager(ctx, ns, em.enricher, di, dm, newEventNotifier, bm, pm, txHelper, mm, transports); err != nil {
 		return nil, err
 	}
 
@@ -254,6 +269,7 @@ func (em *eventManager) CreateUpdateDurableSubscription(ctx context.Context, sub
 		subDef.ID = existing.ID
 		subDef.Updated = fftypes.Now()
 		subDef.Options.FirstEvent = existing.Options.FirstEvent // we do not reset the sub position
+		subDef.Paused = existing.Paused                         // only changed by the pause and resume APIs
 		existing.Updated = subDef.Updated
 		def1, _ := json.Marshal(existing)
 		def2, _ := json.Marshal(subDef)
@@ -262,6 +278,8 @@ func (em *eventManager) CreateUpdateDurableSubscription(ctx context.Context, sub
 			return nil
 		}
 	} else {
+		// A subscription can only be paused once it exists
+		subDef.Paused = false
 		// We lock in the starting sequence at creation time, rather than when the first dispatcher
 		// starts, as that's a more obvious behavior for users
 		sequence, err := calcFirstOffset(ctx, em.namespace.Name, em.database, subDef.Options.FirstEvent)
@@ -278,7 +296,24 @@ func (em *eventManager) CreateUpdateDurableSubscription(ctx context.Context, sub
 
 func (em *eventManager) DeleteDurableSubscription(ctx context.Context, subDef *core.Subscription) (err error) {
 	// The event in the database for the deletion of the susbscription, will asynchronously update the submanager
-	return em.database.DeleteSubscriptionByID(ctx, em.namespace.Name, subDef.ID)
+	return em.database.RunAsGroup(ctx, func(ctx context.Context) error {
+		if err := em.database.DeleteSubscriptionByID(ctx, em.namespace.Name, subDef.ID); err != nil {
+			return err
+		}
+		return em.database.DeleteDeadLetters(ctx, em.namespace.Name, subDef.ID)
+	})
+}
+
+func (em *eventManager) ReplayDeadLetter(ctx context.Context, deadLetter *core.DeadLetter) error {
+	return em.subManager.replayDeadLetter(ctx, deadLetter)
+}
+
+func (em *eventManager) RewindDurableSubscription(ctx context.Context, subDef *core.Subscription, offset int64) error {
+	return em.subManager.rewindDurableSubscription(ctx, subDef, offset)
+}
+
+func (em *eventManager) GetSubscriptionDeliveryStatus(id *fftypes.UUID) *core.SubscriptionStatus {
+	return em.subManager.getDeliveryStatus(id)
 }
 
 func (em *eventManager) AddSystemEventListener(ns string, el system.EventListener) error {
@@ -318,11 +353,29 @@ func (em *eventManager) FilterHistoricalEventsOnSubscription(ctx context.Context
 		return nil, err
 	}
 
+	// Expressions can reference the message data, so it is loaded for the events that pass the other filters
+	withData := sub.Options.WithData != nil && *sub.Options.WithData
 	matchingEvents := []*core.EnrichedEvent{}
 	for _, event := range events {
-		if subscriptionDef.MatchesEvent(event) {
-			matchingEvents = append(matchingEvents, event)
+		if !subscriptionDef.MatchesEvent(event) {
+			continue
+		}
+		if subscriptionDef.HasExpression() {
+			var data core.DataArray
+			if withData && event.Message != nil {
+				if data, _, err = em.data.GetMessageDataCached(ctx, event.Message); err != nil {
+					return nil, err
+				}
+			}
+			doc, err := em.enricher.expressionDocument(event, data)
+			if err != nil {
+				return nil, err
+			}
+			if !subscriptionDef.MatchesExpression(ctx, event.ID, doc) {
+				continue
+			}
 		}
+		matchingEvents = append(matchingEvents, event)
 	}
 
 	return matchingEvents, nil
@@ -240,6 +240,12 @@ func TestAggregatorCacheInitFail(t *testing.T) {
 		coreconfig.CacheEventListenerTopicTTL,
 		ns.Name,
 	)).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
+	cmi.On("GetCache", cache.NewCacheConfig(
+		ctx,
+		coreconfig.CacheExpressionDocumentLimit,
+		coreconfig.CacheExpressionDocumentTTL,
+		ns.Name,
+	)).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	cmi.On("GetCache", cache.NewCacheConfig(
 		ctx,
 		coreconfig.CacheTransactionSize,
@@ -316,6 +322,61 @@ func TestEventCacheInitFail(t *testing.T) {
 	assert.Equal(t, cacheInitError, err)
 }
 
+func TestExpressionDocumentCacheInitFail(t *testing.T) {
+	cacheInitError := errors.New("Initialization error.")
+	config.Set(coreconfig.EventTransportsEnabled, []string{"wrongun"})
+	defer coreconfig.Reset()
+	mdi := &databasemocks.Plugin{}
+	mbi := &blockchainmocks.Plugin{}
+	mim := &identitymanagermocks.Manager{}
+	mdm := &datamocks.Manager{}
+	msh := &definitionsmocks.Handler{}
+	mds := &definitionsmocks.Sender{}
+	mbm := &broadcastmocks.Manager{}
+	mpm := &privatemessagingmocks.Manager{}
+	mam := &assetmocks.Manager{}
+	msd := &shareddownloadmocks.Manager{}
+	mm := &metricsmocks.Manager{}
+	mom := &operationmocks.Manager{}
+	mev := &eventsmocks.Plugin{}
+	events := map[string]events.Plugin{"websockets": mev}
+	mmp := &multipartymocks.Manager{}
+	ctx := context.Background()
+	cmi := &cachemocks.Manager{}
+	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
+	cmi.On("GetCache", cache.NewCacheConfig(
+		ctx,
+		coreconfig.CacheEventListenerTopicLimit,
+		coreconfig.CacheEventListenerTopicTTL,
+		ns.Name,
+	)).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
+	cmi.On("GetCache", cache.NewCacheConfig(
+		ctx,
+		coreconfig.CacheExpressionDocumentLimit,
+		coreconfig.CacheExpressionDocumentTTL,
+		ns.Name,
+	)).Return(nil, cacheInitError)
+	cmi.On("GetCache", cache.NewCacheConfig(
+		ctx,
+		coreconfig.CacheTransactionSize,
+		coreconfig.CacheTransactionTTL,
+		ns.Name,
+	)).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
+	cmi.On("GetCache", cache.NewCacheConfig(
+		ctx,
+		coreconfig.CacheBlockchainEventLimit,
+		coreconfig.CacheBlockchainEventTTL,
+		ns.Name,
+	)).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
+	txHelper, _ := txcommon.NewTransactionHelper(ctx, ns.Name, mdi, mdm, cmi)
+	mdi.On("Capabilities").Return(&database.Capabilities{Concurrency: false})
+	mbi.On("VerifierType").Return(core.VerifierTypeEthAddress)
+	mev.On("SetHandler", "ns1", mock.Anything).Return(nil).Maybe()
+	mev.On("ValidateOptions", mock.Anything).Return(nil).Maybe()
+	_, err := NewEventManager(context.Background(), ns, mdi, mbi, mim, msh, mdm, mds, mbm, mpm, mam, msd, mm, mom, txHelper, events, mmp, cmi)
+	assert.Equal(t, cacheInitError, err)
+}
+
 func TestStartStopEventListenerFail(t *testing.T) {
 	config.Set(coreconfig.EventTransportsEnabled, []string{"wrongun"})
 	defer coreconfig.Reset()
@@ -511,6 +572,27 @@ func TestCreateDurableSubscriptionOk(t *testing.T) {
 	assert.Equal(t, "12345", string(*sub.Options.FirstEvent))
 }
 
+func TestCreateDurableSubscriptionIgnoresPaused(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	sub := &core.Subscription{
+		Transport: "websockets",
+		SubscriptionRef: core.SubscriptionRef{
+			ID:        fftypes.NewUUID(),
+			Namespace: "ns1",
+			Name:      "sub1",
+		},
+		Paused: true,
+	}
+	em.mdi.On("GetSubscriptionByName", mock.Anything, "ns1", "sub1").Return(nil, nil)
+	em.mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil)
+	em.mdi.On("UpsertSubscription", mock.Anything, mock.MatchedBy(func(s *core.Subscription) bool {
+		return !s.Paused
+	}), false).Return(nil)
+	err := em.CreateUpdateDurableSubscription(em.ctx, sub, true)
+	assert.NoError(t, err)
+}
+
 func TestUpdateDurableSubscriptionOk(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
@@ -543,6 +625,30 @@ func TestUpdateDurableSubscriptionOk(t *testing.T) {
 	assert.Equal(t, "12345", string(*sub.Options.FirstEvent))
 }
 
+func TestUpdateDurableSubscriptionKeepsPaused(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	sub := &core.Subscription{
+		Transport: "websockets",
+		SubscriptionRef: core.SubscriptionRef{
+			ID:        fftypes.NewUUID(),
+			Namespace: "ns1",
+			Name:      "sub1",
+		},
+	}
+	em.mdi.On("GetSubscriptionByName", mock.Anything, "ns1", "sub1").Return(&core.Subscription{
+		Transport: "websockets",
+		SubscriptionRef: core.SubscriptionRef{
+			ID: fftypes.NewUUID(),
+		},
+		Paused: true,
+	}, nil)
+	em.mdi.On("UpsertSubscription", mock.Anything, mock.Anything, true).Return(nil)
+	err := em.CreateUpdateDurableSubscription(em.ctx, sub, false)
+	assert.NoError(t, err)
+	assert.True(t, sub.Paused)
+}
+
 func TestUpdateDurableSubscriptionNoOp(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
@@ -574,11 +680,24 @@ func TestCreateDeleteDurableSubscriptionOk(t *testing.T) {
 	defer em.cleanup(t)
 	subId := fftypes.NewUUID()
 	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: subId, Namespace: "ns1"}}
+	mockRunAsGroupPassthrough(em.mdi)
 	em.mdi.On("DeleteSubscriptionByID", mock.Anything, "ns1", subId).Return(nil)
+	em.mdi.On("DeleteDeadLetters", mock.Anything, "ns1", subId).Return(nil)
 	err := em.DeleteDurableSubscription(em.ctx, sub)
 	assert.NoError(t, err)
 }
 
+func TestCreateDeleteDurableSubscriptionFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	subId := fftypes.NewUUID()
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: subId, Namespace: "ns1"}}
+	mockRunAsGroupPassthrough(em.mdi)
+	em.mdi.On("DeleteSubscriptionByID", mock.Anything, "ns1", subId).Return(fmt.Errorf("pop"))
+	err := em.DeleteDurableSubscription(em.ctx, sub)
+	assert.EqualError(t, err, "pop")
+}
+
 func TestAddInternalListener(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
@@ -599,6 +718,31 @@ func TestAddInternalListener(t *testing.T) {
 	cbs.AssertExpectations(t)
 }
 
+func TestEventManagerReplayDeadLetter(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	err := em.ReplayDeadLetter(em.ctx, &core.DeadLetter{
+		ID:           fftypes.NewUUID(),
+		Subscription: fftypes.NewUUID(),
+	})
+	assert.Regexp(t, "FF10510", err)
+}
+
+func TestEventManagerRewindDurableSubscription(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Namespace: "ns1", Name: "sub1"}}
+	em.mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, sub.ID.String()).Return(nil, nil)
+	em.mdi.On("UpsertOffset", mock.Anything, mock.MatchedBy(func(o *core.Offset) bool {
+		return o.Current == 10
+	}), false).Return(nil)
+	em.mdi.On("UpdateSubscription", mock.Anything, "ns1", "sub1", mock.Anything).Return(nil)
+	err := em.RewindDurableSubscription(em.ctx, sub, 10)
+	assert.NoError(t, err)
+}
+
 func TestGetPlugins(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
@@ -612,6 +756,13 @@ func TestGetPlugins(t *testing.T) {
 	assert.ElementsMatch(t, em.GetPlugins(), expectedPlugins)
 }
 
+func TestGetSubscriptionDeliveryStatusNotDispatching(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	assert.Nil(t, em.GetSubscriptionDeliveryStatus(fftypes.NewUUID()))
+}
+
 func TestResolveTransportAndCapabilities(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
@@ -651,6 +802,101 @@ func TestResolveTransportAndCapabilitiesDefault(t *testing.T) {
 	em.mev.AssertExpectations(t)
 }
 
+func TestEventFilterOnSubscriptionMatchesExpression(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	events := []*core.EnrichedEvent{
+		{Event: core.Event{ID: fftypes.NewUUID(), Topic: "topic1"}},
+		{Event: core.Event{ID: fftypes.NewUUID(), Topic: "topic2"}},
+		{Event: core.Event{ID: fftypes.NewUUID(), Topic: "other"}},
+	}
+
+	subscription := &core.Subscription{
+		Filter: core.SubscriptionFilter{
+			Topic:      "^topic",
+			Expression: `$.topic == "topic2" || $.topic == "other"`,
+		},
+	}
+
+	filteredEvents, err := em.FilterHistoricalEventsOnSubscription(context.Background(), events, subscription)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(filteredEvents))
+	assert.Equal(t, "topic2", filteredEvents[0].Topic)
+}
+
+func TestEventFilterOnSubscriptionMatchesExpressionData(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	msg1 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}}
+	msg2 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}}
+	events := []*core.EnrichedEvent{
+		{Event: core.Event{ID: fftypes.NewUUID()}, Message: msg1},
+		{Event: core.Event{ID: fftypes.NewUUID()}, Message: msg2},
+		{Event: core.Event{ID: fftypes.NewUUID()}},
+	}
+	em.mdm.On("GetMessageDataCached", mock.Anything, msg1).Return(core.DataArray{{Value: fftypes.JSONAnyPtr(`{"amount": 50}`)}}, true, nil)
+	em.mdm.On("GetMessageDataCached", mock.Anything, msg2).Return(core.DataArray{{Value: fftypes.JSONAnyPtr(`{"amount": 150}`)}}, true, nil)
+
+	withData := true
+	subscription := &core.Subscription{
+		Filter: core.SubscriptionFilter{
+			Expression: `$.data[0].value.amount > 100`,
+		},
+	}
+	subscription.Options.WithData = &withData
+
+	filteredEvents, err := em.FilterHistoricalEventsOnSubscription(context.Background(), events, subscription)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(filteredEvents))
+	assert.Equal(t, msg2, filteredEvents[0].Message)
+}
+
+func TestEventFilterOnSubscriptionExpressionDataFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}}
+	events := []*core.EnrichedEvent{
+		{Event: core.Event{ID: fftypes.NewUUID()}, Message: msg},
+	}
+	em.mdm.On("GetMessageDataCached", mock.Anything, msg).Return(nil, false, fmt.Errorf("pop"))
+
+	withData := true
+	subscription := &core.Subscription{
+		Filter: core.SubscriptionFilter{
+			Expression: `$.data[0].value.amount > 100`,
+		},
+	}
+	subscription.Options.WithData = &withData
+
+	_, err := em.FilterHistoricalEventsOnSubscription(context.Background(), events, subscription)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestEventFilterOnSubscriptionExpressionDocumentFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}}
+	events := []*core.EnrichedEvent{
+		{Event: core.Event{ID: fftypes.NewUUID()}, Message: msg},
+	}
+	em.mdm.On("GetMessageDataCached", mock.Anything, msg).Return(core.DataArray{{Value: fftypes.JSONAnyPtr(`!json`)}}, true, nil)
+
+	withData := true
+	subscription := &core.Subscription{
+		Filter: core.SubscriptionFilter{
+			Expression: `$.data[0].value.amount > 100`,
+		},
+	}
+	subscription.Options.WithData = &withData
+
+	_, err := em.FilterHistoricalEventsOnSubscription(context.Background(), events, subscription)
+	assert.Error(t, err)
+}
+
 func TestEventFilterOnSubscriptionMatchesEventType(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
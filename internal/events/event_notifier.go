@@ -63,6 +63,13 @@ func (en *eventNotifier) waitNext(lastSequence int64) error {
 	return nil
 }
 
+// getLatestSequence returns the sequence of the latest notification, or -1 if there has not been one
+func (en *eventNotifier) getLatestSequence() int64 {
+	en.cond.L.Lock()
+	defer en.cond.L.Unlock()
+	return en.latestSequence
+}
+
 func (en *eventNotifier) close() {
 	en.cond.L.Lock()
 	en.closed = true
@@ -49,6 +49,7 @@ type eventPollerConf struct {
 	eventBatchSize             uint64
 	eventBatchTimeout          time.Duration
 	eventPollTimeout           time.Duration
+	changeFeedPollTimeout      time.Duration
 	firstEvent                 *core.SubOptsFirstEvent
 	queryFactory               ffapi.QueryFactory
 	addCriteria                func(ffapi.AndFilter) ffapi.AndFilter
@@ -75,6 +76,11 @@ func newEventPoller(ctx context.Context, di database.Plugin, en *eventNotifier,
 	if ep.conf.maybeRewind == nil {
 		ep.conf.maybeRewind = func() (bool, int64) { return false, -1 }
 	}
+	// When the database has a change feed, inserts by other processes tap us as well as our own,
+	// so the timed poll is only a fallback for notifications missed while the feed reconnects
+	if ep.conf.changeFeedPollTimeout > ep.conf.eventPollTimeout && di.Capabilities().ChangeFeed {
+		ep.conf.eventPollTimeout = ep.conf.changeFeedPollTimeout
+	}
 	return ep
 }
 
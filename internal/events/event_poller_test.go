@@ -80,6 +80,23 @@ func TestStartStopEventPoller(t *testing.T) {
 	<-ep.closed
 }
 
+func TestEventPollerChangeFeedPollTimeout(t *testing.T) {
+	ctx := context.Background()
+	for _, changeFeed := range []bool{false, true} {
+		mdi := &databasemocks.Plugin{}
+		mdi.On("Capabilities").Return(&database.Capabilities{ChangeFeed: changeFeed})
+		ep := newEventPoller(ctx, mdi, newEventNotifier(ctx, "ut"), &eventPollerConf{
+			eventPollTimeout:      30 * time.Second,
+			changeFeedPollTimeout: 5 * time.Minute,
+		})
+		if changeFeed {
+			assert.Equal(t, 5*time.Minute, ep.conf.eventPollTimeout)
+		} else {
+			assert.Equal(t, 30*time.Second, ep.conf.eventPollTimeout)
+		}
+	}
+}
+
 func TestRestoreOffsetNewestOK(t *testing.T) {
 	mdi := &databasemocks.Plugin{}
 	ep, cancel := newTestEventPoller(mdi, nil, nil)
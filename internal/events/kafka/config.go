@@ -0,0 +1,44 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+)
+
+const (
+	defaultClientID = "firefly"
+)
+
+const (
+	// KafkaConfBrokers is the list of Kafka brokers to connect to
+	KafkaConfBrokers = "brokers"
+	// KafkaConfClientID is the client ID reported to the Kafka brokers
+	KafkaConfClientID = "clientID"
+	// KafkaConfTopics is the list of Kafka topics that subscriptions can produce to
+	KafkaConfTopics = "topics"
+	// KafkaConfTLS is the sub-section for TLS configuration
+	KafkaConfTLS = "tls"
+)
+
+func (k *Kafka) InitConfig(config config.Section) {
+	config.AddKnownKey(KafkaConfBrokers)
+	config.AddKnownKey(KafkaConfClientID, defaultClientID)
+	config.AddKnownKey(KafkaConfTopics)
+	fftls.InitTLSConfig(config.SubSection(KafkaConfTLS))
+}
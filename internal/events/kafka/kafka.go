@@ -0,0 +1,281 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/events"
+)
+
+const (
+	// KeyTopic keys each record by the FireFly topic of the event, so ordering is preserved per topic
+	KeyTopic = "topic"
+	// KeyCorrelator keys each record by the correlator of the event
+	KeyCorrelator = "correlator"
+	// KeyNone leaves the record key empty, so the producer partitions freely
+	KeyNone = "none"
+)
+
+type Kafka struct {
+	ctx          context.Context
+	capabilities *events.Capabilities
+	callbacks    callbacks
+	brokers      []string
+	saramaConfig *sarama.Config
+	producerMux  sync.Mutex
+	producer     sarama.SyncProducer // connected on first use, so an unreachable broker does not stop the node starting
+	connID       string
+	topics       map[string]bool // the topics subscriptions can produce to, or any topic when empty
+}
+
+type callbacks struct {
+	writeLock sync.Mutex
+	handlers  map[string]events.Callbacks
+}
+
+// kafkaRecord is the JSON value of each record produced to Kafka
+type kafkaRecord struct {
+	*core.EventDelivery
+	Data core.DataArray `json:"data,omitempty"`
+}
+
+func (k *Kafka) Name() string { return "kafka" }
+
+func (k *Kafka) Init(ctx context.Context, config config.Section) (err error) {
+	brokers := config.GetStringSlice(KafkaConfBrokers)
+	if len(brokers) == 0 {
+		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, KafkaConfBrokers, "events.kafka")
+	}
+
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.ClientID = config.GetString(KafkaConfClientID)
+	// We only acknowledge an event back to FireFly once every in-sync replica has the record,
+	// and the sync producer requires successes to be returned
+	saramaConfig.Producer.RequiredAcks = sarama.WaitForAll
+	saramaConfig.Producer.Return.Successes = true
+	saramaConfig.Producer.Return.Errors = true
+
+	tlsConfig, err := fftls.ConstructTLSConfig(ctx, config.SubSection(KafkaConfTLS), fftls.ClientType)
+	if err != nil {
+		return err
+	}
+	if tlsConfig != nil {
+		saramaConfig.Net.TLS.Enable = true
+		saramaConfig.Net.TLS.Config = tlsConfig
+	}
+
+	connID := fftypes.ShortID()
+	topics := make(map[string]bool)
+	for _, topic := range config.GetStringSlice(KafkaConfTopics) {
+		topics[topic] = true
+	}
+
+	*k = Kafka{
+		ctx: log.WithLogField(ctx, "kafka", connID),
+		capabilities: &events.Capabilities{
+			BatchDelivery: true,
+		},
+		callbacks: callbacks{
+			handlers: make(map[string]events.Callbacks),
+		},
+		brokers:      brokers,
+		saramaConfig: saramaConfig,
+		connID:       connID,
+		topics:       topics,
+	}
+	return nil
+}
+
+// getProducer connects to the brokers the first time an event is delivered. A failure is returned
+// as a delivery failure, so the event is redelivered and the connection attempted again.
+func (k *Kafka) getProducer(ctx context.Context) (sarama.SyncProducer, error) {
+	k.producerMux.Lock()
+	defer k.producerMux.Unlock()
+	if k.producer == nil {
+		producer, err := sarama.NewSyncProducer(k.brokers, k.saramaConfig)
+		if err != nil {
+			log.L(k.ctx).Errorf("Kafka connection to %v failed: %s", k.brokers, err)
+			return nil, i18n.WrapError(ctx, err, coremsgs.MsgKafkaConnectFailed, k.brokers)
+		}
+		k.producer = producer
+	}
+	return k.producer, nil
+}
+
+func (k *Kafka) getHandler(namespace string) (events.Callbacks, bool) {
+	k.callbacks.writeLock.Lock()
+	defer k.callbacks.writeLock.Unlock()
+	cb, ok := k.callbacks.handlers[namespace]
+	return cb, ok
+}
+
+func (k *Kafka) SetHandler(namespace string, handler events.Callbacks) error {
+	k.callbacks.writeLock.Lock()
+	defer k.callbacks.writeLock.Unlock()
+	if handler == nil {
+		delete(k.callbacks.handlers, namespace)
+		return nil
+	}
+	k.callbacks.handlers[namespace] = handler
+	// We have a single logical connection, that matches all subscriptions
+	return handler.RegisterConnection(k.connID, func(sr core.SubscriptionRef) bool { return true })
+}
+
+func (k *Kafka) Capabilities() *events.Capabilities {
+	return k.capabilities
+}
+
+// ValidateOptions checks the subscription names the Kafka topic to produce to, and how each record is keyed
+func (k *Kafka) ValidateOptions(ctx context.Context, options *core.SubscriptionOptions) error {
+	if _, err := k.getTopic(ctx, options); err != nil {
+		return err
+	}
+	switch options.TransportOptions().GetString("key") {
+	case "", KeyTopic, KeyCorrelator, KeyNone:
+		return nil
+	default:
+		return i18n.NewError(ctx, coremsgs.MsgKafkaInvalidKeyOption, options.TransportOptions().GetString("key"))
+	}
+}
+
+func (k *Kafka) buildMessage(ctx context.Context, topic string, sub *core.Subscription, event *core.EventDelivery, data core.DataArray) (*sarama.ProducerMessage, error) {
+	value, err := json.Marshal(&kafkaRecord{
+		EventDelivery: event,
+		Data:          data,
+	})
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgSerializationFailed)
+	}
+
+	msg := &sarama.ProducerMessage{
+		Topic: topic,
+		Value: sarama.ByteEncoder(value),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte("ff-namespace"), Value: []byte(event.Namespace)},
+			{Key: []byte("ff-event-id"), Value: []byte(event.ID.String())},
+			{Key: []byte("ff-event-type"), Value: []byte(event.Type)},
+			{Key: []byte("ff-subscription"), Value: []byte(sub.Name)},
+		},
+	}
+
+	switch sub.Options.TransportOptions().GetString("key") {
+	case KeyNone:
+	case KeyCorrelator:
+		if event.Correlator != nil {
+			msg.Key = sarama.StringEncoder(event.Correlator.String())
+		}
+	default:
+		if event.Topic != "" {
+			msg.Key = sarama.StringEncoder(event.Topic)
+		}
+	}
+	return msg, nil
+}
+
+func (k *Kafka) getTopic(ctx context.Context, options *core.SubscriptionOptions) (string, error) {
+	topic := options.TransportOptions().GetString("topic")
+	if topic == "" {
+		return "", i18n.NewError(ctx, coremsgs.MsgKafkaMissingTopic)
+	}
+	// The configured topics are checked on each delivery as well, in case they changed since the subscription was created
+	if len(k.topics) > 0 && !k.topics[topic] {
+		return "", i18n.NewError(ctx, coremsgs.MsgKafkaTopicNotAllowed, topic)
+	}
+	return topic, nil
+}
+
+func (k *Kafka) ack(connID string, sub *core.Subscription, event *core.EventDelivery) {
+	if cb, ok := k.getHandler(sub.Namespace); ok {
+		cb.DeliveryResponse(connID, &core.EventDeliveryResponse{
+			ID:           event.ID,
+			Rejected:     false,
+			Subscription: event.Subscription,
+		})
+	}
+}
+
+func (k *Kafka) DeliveryRequest(ctx context.Context, connID string, sub *core.Subscription, event *core.EventDelivery, data core.DataArray) error {
+	topic, err := k.getTopic(ctx, &sub.Options)
+	if err != nil {
+		return err
+	}
+	msg, err := k.buildMessage(ctx, topic, sub, event, data)
+	if err != nil {
+		return err
+	}
+	producer, err := k.getProducer(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.L(k.ctx).Debugf("Kafka-> %s event %s on subscription %s", topic, event.ID, sub.ID)
+	partition, offset, err := producer.SendMessage(msg)
+	if err != nil {
+		log.L(k.ctx).Errorf("Kafka<- %s event %s on subscription %s failed: %s", topic, event.ID, sub.ID, err)
+		return i18n.WrapError(ctx, err, coremsgs.MsgKafkaProduceFailed, topic)
+	}
+	log.L(k.ctx).Debugf("Kafka<- %s event %s on subscription %s stored partition=%d offset=%d", topic, event.ID, sub.ID, partition, offset)
+
+	// The broker has the record, so the subscription offset can now move forwards
+	k.ack(connID, sub, event)
+	return nil
+}
+
+func (k *Kafka) BatchDeliveryRequest(ctx context.Context, connID string, sub *core.Subscription, events []*core.CombinedEventDataDelivery) error {
+	topic, err := k.getTopic(ctx, &sub.Options)
+	if err != nil {
+		return err
+	}
+	msgs := make([]*sarama.ProducerMessage, len(events))
+	for i, combinedEvent := range events {
+		if msgs[i], err = k.buildMessage(ctx, topic, sub, combinedEvent.Event, combinedEvent.Data); err != nil {
+			return err
+		}
+	}
+
+	producer, err := k.getProducer(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.L(k.ctx).Debugf("Kafka-> %s batch of %d events on subscription %s", topic, len(msgs), sub.ID)
+	if err := producer.SendMessages(msgs); err != nil {
+		log.L(k.ctx).Errorf("Kafka<- %s batch of %d events on subscription %s failed: %s", topic, len(msgs), sub.ID, err)
+		return i18n.WrapError(ctx, err, coremsgs.MsgKafkaProduceFailed, topic)
+	}
+
+	for _, combinedEvent := range events {
+		k.ack(connID, sub, combinedEvent.Event)
+	}
+	return nil
+}
+
+func (k *Kafka) NamespaceRestarted(ns string, startTime time.Time) {
+	// no-op
+}
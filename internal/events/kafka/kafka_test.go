@@ -0,0 +1,446 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/eventsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestBroker(t *testing.T) *sarama.MockBroker {
+	broker := sarama.NewMockBroker(t, 1)
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader("topic1", 0, broker.BrokerID()),
+		"ProduceRequest": sarama.NewMockProduceResponse(t),
+	})
+	return broker
+}
+
+func newTestKafka(t *testing.T, broker *sarama.MockBroker) (k *Kafka, cancel func()) {
+	coreconfig.Reset()
+
+	cbs := &eventsmocks.Callbacks{}
+	rc := cbs.On("RegisterConnection", mock.Anything, mock.Anything).Return(nil)
+	rc.RunFn = func(a mock.Arguments) {
+		assert.True(t, a[1].(events.SubscriptionMatcher)(core.SubscriptionRef{Name: "anything"}))
+	}
+	k = &Kafka{}
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	conf := config.RootSection("ut.kafka")
+	k.InitConfig(conf)
+	conf.Set(KafkaConfBrokers, []string{broker.Addr()})
+	conf.Set(KafkaConfTopics, []string{"topic1"})
+	err := k.Init(ctx, conf)
+	assert.NoError(t, err)
+	err = k.SetHandler("ns1", cbs)
+	assert.NoError(t, err)
+	assert.Equal(t, "kafka", k.Name())
+	assert.True(t, k.Capabilities().BatchDelivery)
+	return k, func() {
+		cancelCtx()
+		if k.producer != nil {
+			_ = k.producer.Close()
+		}
+	}
+}
+
+func mockProducer(t *testing.T, k *Kafka) *mocks.SyncProducer {
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.Producer.Return.Successes = true
+	producer := mocks.NewSyncProducer(t, saramaConfig)
+	k.producer = producer
+	return producer
+}
+
+func newTestEvent(sub *core.Subscription) *core.EventDelivery {
+	return &core.EventDelivery{
+		EnrichedEvent: core.EnrichedEvent{
+			Event: core.Event{
+				ID:         fftypes.NewUUID(),
+				Namespace:  "ns1",
+				Type:       core.EventTypeMessageConfirmed,
+				Topic:      "fftopic1",
+				Correlator: fftypes.NewUUID(),
+			},
+		},
+		Subscription: sub.SubscriptionRef,
+	}
+}
+
+func newTestSubscription() *core.Subscription {
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID:        fftypes.NewUUID(),
+			Namespace: "ns1",
+			Name:      "sub1",
+		},
+		Transport: "kafka",
+	}
+	sub.Options.TransportOptions()["topic"] = "topic1"
+	return sub
+}
+
+func TestInitMissingBrokers(t *testing.T) {
+	coreconfig.Reset()
+
+	k := &Kafka{}
+	conf := config.RootSection("ut.kafka")
+	k.InitConfig(conf)
+	err := k.Init(context.Background(), conf)
+	assert.Regexp(t, "FF10138.*brokers", err)
+}
+
+func TestInitBadTLS(t *testing.T) {
+	coreconfig.Reset()
+
+	k := &Kafka{}
+	conf := config.RootSection("ut.kafka")
+	k.InitConfig(conf)
+	conf.Set(KafkaConfBrokers, []string{"localhost:9092"})
+	tlsConfig := conf.SubSection(KafkaConfTLS)
+	tlsConfig.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConfig.Set(fftls.HTTPConfTLSCAFile, "BADCA")
+	err := k.Init(context.Background(), conf)
+	assert.Regexp(t, "FF00153", err)
+}
+
+func TestInitBrokerUnreachable(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	k, cancel := newTestKafka(t, broker)
+	defer cancel()
+	broker.Close()
+
+	// The node starts without the broker, and each delivery fails until it is reachable
+	sub := newTestSubscription()
+	err := k.DeliveryRequest(k.ctx, k.connID, sub, newTestEvent(sub), nil)
+	assert.Regexp(t, "FF10483", err)
+	err = k.BatchDeliveryRequest(k.ctx, k.connID, sub, []*core.CombinedEventDataDelivery{
+		{Event: newTestEvent(sub)},
+	})
+	assert.Regexp(t, "FF10483", err)
+	assert.Nil(t, k.producer)
+}
+
+func TestInitTLSBrokerUnreachable(t *testing.T) {
+	coreconfig.Reset()
+
+	broker := sarama.NewMockBroker(t, 1)
+	addr := broker.Addr()
+	broker.Close()
+
+	k := &Kafka{}
+	conf := config.RootSection("ut.kafka")
+	k.InitConfig(conf)
+	conf.Set(KafkaConfBrokers, []string{addr})
+	conf.SubSection(KafkaConfTLS).Set(fftls.HTTPConfTLSEnabled, true)
+	err := k.Init(context.Background(), conf)
+	assert.NoError(t, err)
+	_, err = k.getProducer(context.Background())
+	assert.Regexp(t, "FF10483", err)
+}
+
+func TestSetHandlerConcurrentWithAck(t *testing.T) {
+	broker := newTestBroker(t)
+	defer broker.Close()
+	k, cancel := newTestKafka(t, broker)
+	defer cancel()
+
+	sub := newTestSubscription()
+	sub.Namespace = "ns3"
+	cbs := &eventsmocks.Callbacks{}
+	cbs.On("RegisterConnection", mock.Anything, mock.Anything).Return(nil)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = k.SetHandler("ns2", cbs)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		k.ack(k.connID, sub, newTestEvent(sub))
+	}
+	<-done
+}
+
+func TestSetHandlerRemove(t *testing.T) {
+	broker := newTestBroker(t)
+	defer broker.Close()
+	k, cancel := newTestKafka(t, broker)
+	defer cancel()
+
+	err := k.SetHandler("ns1", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, k.callbacks.handlers)
+}
+
+func TestSetHandlerRegisterFail(t *testing.T) {
+	broker := newTestBroker(t)
+	defer broker.Close()
+	k, cancel := newTestKafka(t, broker)
+	defer cancel()
+
+	cbs := &eventsmocks.Callbacks{}
+	cbs.On("RegisterConnection", k.connID, mock.Anything).Return(fmt.Errorf("pop"))
+	err := k.SetHandler("ns2", cbs)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestValidateOptions(t *testing.T) {
+	broker := newTestBroker(t)
+	defer broker.Close()
+	k, cancel := newTestKafka(t, broker)
+	defer cancel()
+
+	opts := &core.SubscriptionOptions{}
+	assert.Regexp(t, "FF10485", k.ValidateOptions(k.ctx, opts))
+	opts.TransportOptions()["topic"] = "other"
+	assert.Regexp(t, "FF10546", k.ValidateOptions(k.ctx, opts))
+	opts.TransportOptions()["topic"] = "topic1"
+	assert.NoError(t, k.ValidateOptions(k.ctx, opts))
+	k.topics = map[string]bool{}
+	opts.TransportOptions()["topic"] = "other"
+	assert.NoError(t, k.ValidateOptions(k.ctx, opts))
+	opts.TransportOptions()["key"] = KeyCorrelator
+	assert.NoError(t, k.ValidateOptions(k.ctx, opts))
+	opts.TransportOptions()["key"] = "wrong"
+	assert.Regexp(t, "FF10486", k.ValidateOptions(k.ctx, opts))
+}
+
+func TestDeliveryRequestEndToEnd(t *testing.T) {
+	broker := newTestBroker(t)
+	defer broker.Close()
+	k, cancel := newTestKafka(t, broker)
+	defer cancel()
+
+	sub := newTestSubscription()
+	event := newTestEvent(sub)
+
+	mcb := k.callbacks.handlers["ns1"].(*eventsmocks.Callbacks)
+	mcb.On("DeliveryResponse", k.connID, mock.MatchedBy(func(response *core.EventDeliveryResponse) bool {
+		return response.ID.Equals(event.ID) && !response.Rejected
+	})).Return(nil)
+
+	err := k.DeliveryRequest(k.ctx, k.connID, sub, event, nil)
+	assert.NoError(t, err)
+
+	mcb.AssertExpectations(t)
+}
+
+func TestDeliveryRequestKeyedByTopicWithData(t *testing.T) {
+	broker := newTestBroker(t)
+	defer broker.Close()
+	k, cancel := newTestKafka(t, broker)
+	defer cancel()
+
+	producer := mockProducer(t, k)
+	sub := newTestSubscription()
+	event := newTestEvent(sub)
+	data := core.DataArray{{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`{"some":"data"}`)}}
+
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		assert.Equal(t, "topic1", msg.Topic)
+		key, _ := msg.Key.Encode()
+		assert.Equal(t, "fftopic1", string(key))
+		value, _ := msg.Value.Encode()
+		var record fftypes.JSONObject
+		err := json.Unmarshal(value, &record)
+		assert.NoError(t, err)
+		assert.Equal(t, event.ID.String(), record.GetString("id"))
+		assert.Equal(t, "data", record.GetObjectArray("data")[0].GetObject("value").GetString("some"))
+		assert.Equal(t, "ff-namespace", string(msg.Headers[0].Key))
+		assert.Equal(t, "ns1", string(msg.Headers[0].Value))
+		return nil
+	})
+
+	mcb := k.callbacks.handlers["ns1"].(*eventsmocks.Callbacks)
+	mcb.On("DeliveryResponse", k.connID, mock.Anything).Return(nil)
+
+	err := k.DeliveryRequest(k.ctx, k.connID, sub, event, data)
+	assert.NoError(t, err)
+
+	mcb.AssertExpectations(t)
+}
+
+func TestDeliveryRequestKeyedByCorrelator(t *testing.T) {
+	broker := newTestBroker(t)
+	defer broker.Close()
+	k, cancel := newTestKafka(t, broker)
+	defer cancel()
+
+	producer := mockProducer(t, k)
+	sub := newTestSubscription()
+	sub.Options.TransportOptions()["key"] = KeyCorrelator
+	event := newTestEvent(sub)
+
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		key, _ := msg.Key.Encode()
+		assert.Equal(t, event.Correlator.String(), string(key))
+		return nil
+	})
+
+	mcb := k.callbacks.handlers["ns1"].(*eventsmocks.Callbacks)
+	mcb.On("DeliveryResponse", k.connID, mock.Anything).Return(nil)
+
+	err := k.DeliveryRequest(k.ctx, k.connID, sub, event, nil)
+	assert.NoError(t, err)
+
+	mcb.AssertExpectations(t)
+}
+
+func TestDeliveryRequestNoKey(t *testing.T) {
+	broker := newTestBroker(t)
+	defer broker.Close()
+	k, cancel := newTestKafka(t, broker)
+	defer cancel()
+
+	producer := mockProducer(t, k)
+	sub := newTestSubscription()
+	sub.Options.TransportOptions()["key"] = KeyNone
+	event := newTestEvent(sub)
+
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		assert.Nil(t, msg.Key)
+		return nil
+	})
+
+	mcb := k.callbacks.handlers["ns1"].(*eventsmocks.Callbacks)
+	mcb.On("DeliveryResponse", k.connID, mock.Anything).Return(nil)
+
+	err := k.DeliveryRequest(k.ctx, k.connID, sub, event, nil)
+	assert.NoError(t, err)
+
+	mcb.AssertExpectations(t)
+}
+
+func TestDeliveryRequestProduceFail(t *testing.T) {
+	broker := newTestBroker(t)
+	defer broker.Close()
+	k, cancel := newTestKafka(t, broker)
+	defer cancel()
+
+	producer := mockProducer(t, k)
+	producer.ExpectSendMessageAndFail(fmt.Errorf("pop"))
+
+	sub := newTestSubscription()
+	err := k.DeliveryRequest(k.ctx, k.connID, sub, newTestEvent(sub), nil)
+	assert.Regexp(t, "FF10484.*pop", err)
+}
+
+func TestDeliveryRequestTopicNotAllowed(t *testing.T) {
+	broker := newTestBroker(t)
+	defer broker.Close()
+	k, cancel := newTestKafka(t, broker)
+	defer cancel()
+
+	sub := newTestSubscription()
+	sub.Options.TransportOptions()["topic"] = "other"
+	err := k.DeliveryRequest(k.ctx, k.connID, sub, newTestEvent(sub), nil)
+	assert.Regexp(t, "FF10546", err)
+
+	err = k.BatchDeliveryRequest(k.ctx, k.connID, sub, []*core.CombinedEventDataDelivery{})
+	assert.Regexp(t, "FF10546", err)
+}
+
+func TestDeliveryRequestBadData(t *testing.T) {
+	broker := newTestBroker(t)
+	defer broker.Close()
+	k, cancel := newTestKafka(t, broker)
+	defer cancel()
+
+	sub := newTestSubscription()
+	data := core.DataArray{{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`!json`)}}
+	err := k.DeliveryRequest(k.ctx, k.connID, sub, newTestEvent(sub), data)
+	assert.Regexp(t, "FF10137", err)
+
+	err = k.BatchDeliveryRequest(k.ctx, k.connID, sub, []*core.CombinedEventDataDelivery{
+		{Event: newTestEvent(sub), Data: data},
+	})
+	assert.Regexp(t, "FF10137", err)
+}
+
+func TestBatchDeliveryRequestOk(t *testing.T) {
+	broker := newTestBroker(t)
+	defer broker.Close()
+	k, cancel := newTestKafka(t, broker)
+	defer cancel()
+
+	producer := mockProducer(t, k)
+	producer.ExpectSendMessageAndSucceed()
+	producer.ExpectSendMessageAndSucceed()
+
+	sub := newTestSubscription()
+	event1 := newTestEvent(sub)
+	event2 := newTestEvent(sub)
+
+	mcb := k.callbacks.handlers["ns1"].(*eventsmocks.Callbacks)
+	mcb.On("DeliveryResponse", k.connID, mock.MatchedBy(func(response *core.EventDeliveryResponse) bool {
+		return response.ID.Equals(event1.ID)
+	})).Return(nil)
+	mcb.On("DeliveryResponse", k.connID, mock.MatchedBy(func(response *core.EventDeliveryResponse) bool {
+		return response.ID.Equals(event2.ID)
+	})).Return(nil)
+
+	err := k.BatchDeliveryRequest(k.ctx, k.connID, sub, []*core.CombinedEventDataDelivery{
+		{Event: event1},
+		{Event: event2},
+	})
+	assert.NoError(t, err)
+
+	mcb.AssertExpectations(t)
+}
+
+func TestBatchDeliveryRequestProduceFail(t *testing.T) {
+	broker := newTestBroker(t)
+	defer broker.Close()
+	k, cancel := newTestKafka(t, broker)
+	defer cancel()
+
+	producer := mockProducer(t, k)
+	producer.ExpectSendMessageAndFail(fmt.Errorf("pop"))
+
+	sub := newTestSubscription()
+	err := k.BatchDeliveryRequest(k.ctx, k.connID, sub, []*core.CombinedEventDataDelivery{
+		{Event: newTestEvent(sub)},
+	})
+	assert.Regexp(t, "FF10484", err)
+}
+
+func TestNamespaceRestarted(t *testing.T) {
+	broker := newTestBroker(t)
+	defer broker.Close()
+	k, cancel := newTestKafka(t, broker)
+	defer cancel()
+
+	k.NamespaceRestarted("ns1", time.Now())
+}
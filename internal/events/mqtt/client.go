@@ -0,0 +1,89 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"net/url"
+	"time"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/hyperledger/firefly-common/pkg/log"
+)
+
+// client is the subset of the paho connection manager that the plugin uses
+type client interface {
+	Publish(ctx context.Context, p *paho.Publish) (*paho.PublishResponse, error)
+}
+
+type clientConfig struct {
+	url            *url.URL
+	clientID       string
+	username       string
+	password       string
+	keepAlive      time.Duration
+	connectTimeout time.Duration
+	tlsConfig      *tls.Config
+	replyTopic     string
+}
+
+// dialClient is replaced in unit tests
+var dialClient = func(ctx context.Context, conf *clientConfig, onReply func(*paho.Publish)) (client, error) {
+	cm, err := autopaho.NewConnection(ctx, autopaho.ClientConfig{
+		ServerUrls:                    []*url.URL{conf.url},
+		TlsCfg:                        conf.tlsConfig,
+		KeepAlive:                     uint16(conf.keepAlive.Seconds()),
+		CleanStartOnInitialConnection: true,
+		ConnectUsername:               conf.username,
+		ConnectPassword:               []byte(conf.password),
+		OnConnectionUp: func(cm *autopaho.ConnectionManager, _ *paho.Connack) {
+			// We start with a clean session, so the reply subscription is made again on every reconnect
+			if conf.replyTopic == "" {
+				return
+			}
+			if _, err := cm.Subscribe(ctx, &paho.Subscribe{
+				Subscriptions: []paho.SubscribeOptions{{Topic: conf.replyTopic, QoS: 1}},
+			}); err != nil {
+				log.L(ctx).Errorf("Failed to subscribe to MQTT reply topic '%s': %s", conf.replyTopic, err)
+			}
+		},
+		OnConnectError: func(err error) {
+			log.L(ctx).Warnf("MQTT connection to '%s' failed: %s", conf.url.Redacted(), err)
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: conf.clientID,
+			OnPublishReceived: []func(paho.PublishReceived) (bool, error){
+				func(pr paho.PublishReceived) (bool, error) {
+					onReply(pr.Packet)
+					return true, nil
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	connectCtx, cancel := context.WithTimeout(ctx, conf.connectTimeout)
+	defer cancel()
+	if err := cm.AwaitConnection(connectCtx); err != nil {
+		_ = cm.Disconnect(ctx)
+		return nil, err
+	}
+	return cm, nil
+}
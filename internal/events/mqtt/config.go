@@ -0,0 +1,70 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+)
+
+const (
+	defaultClientID       = "firefly"
+	defaultKeepAlive      = "30s"
+	defaultConnectTimeout = "30s"
+	defaultPublishTimeout = "30s"
+	defaultTopicTemplate  = "firefly/{namespace}/{type}/{topic}"
+	defaultReplyTimeout   = "2m"
+)
+
+const (
+	// MQTTConfURL is the URL of the MQTT broker to connect to
+	MQTTConfURL = "url"
+	// MQTTConfClientID is the prefix of the client ID used to connect to the broker
+	MQTTConfClientID = "clientID"
+	// MQTTConfUsername is the username to connect to the broker with
+	MQTTConfUsername = "username"
+	// MQTTConfPassword is the password to connect to the broker with
+	MQTTConfPassword = "password"
+	// MQTTConfKeepAlive is the MQTT keep alive interval
+	MQTTConfKeepAlive = "keepAlive"
+	// MQTTConfConnectTimeout is the maximum time to wait for the initial connection
+	MQTTConfConnectTimeout = "connectTimeout"
+	// MQTTConfPublishTimeout is the maximum time to wait for a PUBACK from the broker
+	MQTTConfPublishTimeout = "publishTimeout"
+	// MQTTConfTopicTemplate is the default topic template for each event
+	MQTTConfTopicTemplate = "topicTemplate"
+	// MQTTConfReplyTopic is the topic subscribed to for replies from consumers
+	MQTTConfReplyTopic = "replyTopic"
+	// MQTTConfReplyTimeout is the maximum time to wait for a reply to an event
+	MQTTConfReplyTimeout = "replyTimeout"
+	// MQTTConfTLS is the sub-section for TLS configuration
+	MQTTConfTLS = "tls"
+)
+
+func (m *MQTT) InitConfig(config config.Section) {
+	config.AddKnownKey(MQTTConfURL)
+	config.AddKnownKey(MQTTConfClientID, defaultClientID)
+	config.AddKnownKey(MQTTConfUsername)
+	config.AddKnownKey(MQTTConfPassword)
+	config.AddKnownKey(MQTTConfKeepAlive, defaultKeepAlive)
+	config.AddKnownKey(MQTTConfConnectTimeout, defaultConnectTimeout)
+	config.AddKnownKey(MQTTConfPublishTimeout, defaultPublishTimeout)
+	config.AddKnownKey(MQTTConfTopicTemplate, defaultTopicTemplate)
+	config.AddKnownKey(MQTTConfReplyTopic)
+	config.AddKnownKey(MQTTConfReplyTimeout, defaultReplyTimeout)
+	fftls.InitTLSConfig(config.SubSection(MQTTConfTLS))
+}
@@ -0,0 +1,361 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/events"
+)
+
+// pubackErrorThreshold is the lowest MQTT v5 reason code that indicates the broker did not accept a publish
+const pubackErrorThreshold = 0x80
+
+// replyTopicClientID is replaced in the reply topic with the client ID of this node, which is unique to each node
+const replyTopicClientID = "{clientID}"
+
+type MQTT struct {
+	ctx            context.Context
+	capabilities   *events.Capabilities
+	callbacks      callbacks
+	client         client
+	connID         string
+	topicTemplate  string
+	replyTopic     string
+	publishTimeout time.Duration
+	replyTimeout   time.Duration
+	replyMux       sync.Mutex
+	awaitingReply  map[string]*pendingReply // keyed by event ID, which is sent as the correlation data
+}
+
+type callbacks struct {
+	writeLock sync.Mutex
+	handlers  map[string]events.Callbacks
+}
+
+// mqttMessage is the JSON payload of each message published to the broker
+type mqttMessage struct {
+	*core.EventDelivery
+	Data core.DataArray `json:"data,omitempty"`
+}
+
+// pendingReply is an event that has been published, and is waiting for the consumer to reply on the reply topic
+type pendingReply struct {
+	connID    string
+	namespace string
+	event     *core.EventDelivery
+	timer     *time.Timer
+}
+
+func (m *MQTT) Name() string { return "mqtt" }
+
+func (m *MQTT) Init(ctx context.Context, config config.Section) (err error) {
+	urlString := config.GetString(MQTTConfURL)
+	if urlString == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, MQTTConfURL, "events.mqtt")
+	}
+	u, err := url.Parse(urlString)
+	if err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgMQTTConnectFailed, urlString)
+	}
+	topicTemplate := config.GetString(MQTTConfTopicTemplate)
+	if strings.ContainsAny(topicTemplate, "+#") {
+		return i18n.NewError(ctx, coremsgs.MsgMQTTInvalidTopicTemplate, topicTemplate)
+	}
+
+	// Replies are only matched to the events published by the node that receives them, so every node
+	// must subscribe to its own reply topic, and never share one with the other nodes
+	replyTopic := config.GetString(MQTTConfReplyTopic)
+	if replyTopic != "" && (!strings.Contains(replyTopic, replyTopicClientID) ||
+		strings.HasPrefix(replyTopic, "$share/") || strings.ContainsAny(replyTopic, "+#")) {
+		return i18n.NewError(ctx, coremsgs.MsgMQTTInvalidReplyTopic, replyTopic)
+	}
+
+	tlsConfig, err := fftls.ConstructTLSConfig(ctx, config.SubSection(MQTTConfTLS), fftls.ClientType)
+	if err != nil {
+		return err
+	}
+
+	connID := fftypes.ShortID()
+	// Each node needs its own client ID, otherwise the broker disconnects the other nodes
+	clientID := config.GetString(MQTTConfClientID) + "-" + connID
+	*m = MQTT{
+		ctx: log.WithLogField(ctx, "mqtt", connID),
+		capabilities: &events.Capabilities{
+			BatchDelivery: false,
+		},
+		callbacks: callbacks{
+			handlers: make(map[string]events.Callbacks),
+		},
+		connID:         connID,
+		topicTemplate:  topicTemplate,
+		replyTopic:     strings.ReplaceAll(replyTopic, replyTopicClientID, clientID),
+		publishTimeout: config.GetDuration(MQTTConfPublishTimeout),
+		replyTimeout:   config.GetDuration(MQTTConfReplyTimeout),
+		awaitingReply:  make(map[string]*pendingReply),
+	}
+
+	m.client, err = dialClient(m.ctx, &clientConfig{
+		url:            u,
+		clientID:       clientID,
+		username:       config.GetString(MQTTConfUsername),
+		password:       config.GetString(MQTTConfPassword),
+		keepAlive:      config.GetDuration(MQTTConfKeepAlive),
+		connectTimeout: config.GetDuration(MQTTConfConnectTimeout),
+		tlsConfig:      tlsConfig,
+		replyTopic:     m.replyTopic,
+	}, m.handleReply)
+	if err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgMQTTConnectFailed, u.Redacted())
+	}
+	return nil
+}
+
+func (m *MQTT) getHandler(namespace string) (events.Callbacks, bool) {
+	m.callbacks.writeLock.Lock()
+	defer m.callbacks.writeLock.Unlock()
+	cb, ok := m.callbacks.handlers[namespace]
+	return cb, ok
+}
+
+func (m *MQTT) SetHandler(namespace string, handler events.Callbacks) error {
+	m.callbacks.writeLock.Lock()
+	defer m.callbacks.writeLock.Unlock()
+	if handler == nil {
+		delete(m.callbacks.handlers, namespace)
+		return nil
+	}
+	m.callbacks.handlers[namespace] = handler
+	// We have a single logical connection, that matches all subscriptions
+	return handler.RegisterConnection(m.connID, func(sr core.SubscriptionRef) bool { return true })
+}
+
+func (m *MQTT) Capabilities() *events.Capabilities {
+	return m.capabilities
+}
+
+func (m *MQTT) ValidateOptions(ctx context.Context, options *core.SubscriptionOptions) error {
+	if topicTemplate := options.TransportOptions().GetString("topicTemplate"); strings.ContainsAny(topicTemplate, "+#") {
+		return i18n.NewError(ctx, coremsgs.MsgMQTTInvalidTopicTemplate, topicTemplate)
+	}
+	if options.TransportOptions().GetBool("reply") && m.replyTopic == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMQTTReplyTopicNotConfigured)
+	}
+	return nil
+}
+
+// topicLevel stops values from the event being interpreted as wildcards by consumers
+func topicLevel(s string) string {
+	return strings.NewReplacer("+", "_", "#", "_").Replace(s)
+}
+
+func (m *MQTT) buildTopic(sub *core.Subscription, event *core.EventDelivery) string {
+	topicTemplate := sub.Options.TransportOptions().GetString("topicTemplate")
+	if topicTemplate == "" {
+		topicTemplate = m.topicTemplate
+	}
+	return strings.NewReplacer(
+		"{namespace}", topicLevel(event.Namespace),
+		"{type}", topicLevel(string(event.Type)),
+		"{topic}", topicLevel(event.Topic),
+	).Replace(topicTemplate)
+}
+
+func (m *MQTT) buildMessage(ctx context.Context, sub *core.Subscription, event *core.EventDelivery, data core.DataArray) (*paho.Publish, error) {
+	payload, err := json.Marshal(&mqttMessage{
+		EventDelivery: event,
+		Data:          data,
+	})
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgSerializationFailed)
+	}
+	msg := &paho.Publish{
+		QoS:     1,
+		Topic:   m.buildTopic(sub, event),
+		Payload: payload,
+		Properties: &paho.PublishProperties{
+			ContentType: "application/json",
+			User: paho.UserProperties{
+				{Key: "ff-namespace", Value: event.Namespace},
+				{Key: "ff-event-id", Value: event.ID.String()},
+				{Key: "ff-event-type", Value: string(event.Type)},
+				{Key: "ff-subscription", Value: sub.Name},
+			},
+		},
+	}
+	if sub.Options.TransportOptions().GetBool("reply") {
+		// MQTT v5 request/response - the consumer publishes its reply to the response topic, with the same correlation data
+		msg.Properties.ResponseTopic = m.replyTopic
+		msg.Properties.CorrelationData = []byte(event.ID.String())
+	}
+	return msg, nil
+}
+
+func (m *MQTT) respond(connID, namespace string, response *core.EventDeliveryResponse) {
+	if cb, ok := m.getHandler(namespace); ok {
+		cb.DeliveryResponse(connID, response)
+	}
+}
+
+func (m *MQTT) addPendingReply(connID string, sub *core.Subscription, event *core.EventDelivery) {
+	m.replyMux.Lock()
+	defer m.replyMux.Unlock()
+	eventID := event.ID.String()
+	m.awaitingReply[eventID] = &pendingReply{
+		connID:    connID,
+		namespace: sub.Namespace,
+		event:     event,
+		timer: time.AfterFunc(m.replyTimeout, func() {
+			if pending := m.takePendingReply(eventID); pending != nil {
+				log.L(m.ctx).Warnf("MQTT<- no reply to event %s on subscription %s", eventID, sub.ID)
+				m.respond(pending.connID, pending.namespace, &core.EventDeliveryResponse{
+					ID:           pending.event.ID,
+					Rejected:     true,
+					Info:         i18n.NewError(m.ctx, coremsgs.MsgMQTTReplyTimeout, eventID, m.replyTopic).Error(),
+					Subscription: pending.event.Subscription,
+				})
+			}
+		}),
+	}
+}
+
+func (m *MQTT) takePendingReply(eventID string) *pendingReply {
+	m.replyMux.Lock()
+	defer m.replyMux.Unlock()
+	pending, ok := m.awaitingReply[eventID]
+	if !ok {
+		return nil
+	}
+	delete(m.awaitingReply, eventID)
+	pending.timer.Stop()
+	return pending
+}
+
+// handleReply passes a reply from a consumer to the dispatcher as the response to the event it correlates to,
+// where the optional reply message is sent in the same way as a reply on any other transport
+func (m *MQTT) handleReply(p *paho.Publish) {
+	if p.Properties == nil || len(p.Properties.CorrelationData) == 0 {
+		log.L(m.ctx).Warnf("MQTT<- message on '%s' ignored, as it has no correlation data", p.Topic)
+		return
+	}
+	eventID := string(p.Properties.CorrelationData)
+	pending := m.takePendingReply(eventID)
+	if pending == nil {
+		// When the reply topic is shared by more than one node, every node receives every reply, and only
+		// the node that published the event is awaiting it
+		log.L(m.ctx).Warnf("MQTT<- reply ignored, as event %s is not awaiting a reply", eventID)
+		return
+	}
+
+	var response core.EventDeliveryResponse
+	if err := json.Unmarshal(p.Payload, &response); err != nil {
+		log.L(m.ctx).Errorf("MQTT<- invalid reply to event %s: %s", eventID, err)
+		response = core.EventDeliveryResponse{
+			Rejected: true,
+			Info:     err.Error(),
+		}
+	}
+	log.L(m.ctx).Debugf("MQTT<- reply to event %s rejected=%t", eventID, response.Rejected)
+	response.ID = pending.event.ID
+	response.Subscription = pending.event.Subscription
+	m.respond(pending.connID, pending.namespace, &response)
+}
+
+func (m *MQTT) DeliveryRequest(ctx context.Context, connID string, sub *core.Subscription, event *core.EventDelivery, data core.DataArray) error {
+	msg, err := m.buildMessage(ctx, sub, event, data)
+	if err != nil {
+		return err
+	}
+	awaitReply := msg.Properties.ResponseTopic != ""
+	if awaitReply {
+		m.addPendingReply(connID, sub, event)
+	}
+
+	publishCtx, cancel := context.WithTimeout(ctx, m.publishTimeout)
+	defer cancel()
+	log.L(m.ctx).Debugf("MQTT-> topic='%s' event %s on subscription %s", msg.Topic, event.ID, sub.ID)
+	resp, err := m.client.Publish(publishCtx, msg)
+	if resp != nil && resp.ReasonCode >= pubackErrorThreshold {
+		// The broker has told us it will not deliver the message, so it is rejected and redelivered
+		log.L(m.ctx).Errorf("MQTT<- topic='%s' event %s on subscription %s rejected: 0x%x", msg.Topic, event.ID, sub.ID, resp.ReasonCode)
+		if awaitReply && m.takePendingReply(event.ID.String()) == nil {
+			return nil
+		}
+		m.respond(connID, sub.Namespace, &core.EventDeliveryResponse{
+			ID:           event.ID,
+			Rejected:     true,
+			Info:         i18n.NewError(ctx, coremsgs.MsgMQTTNackReceived, resp.ReasonCode).Error(),
+			Subscription: event.Subscription,
+		})
+		return nil
+	}
+	if err != nil {
+		log.L(m.ctx).Errorf("MQTT<- topic='%s' event %s on subscription %s failed: %s", msg.Topic, event.ID, sub.ID, err)
+		if awaitReply {
+			m.takePendingReply(event.ID.String())
+		}
+		return i18n.WrapError(ctx, err, coremsgs.MsgMQTTPublishFailed, msg.Topic)
+	}
+	log.L(m.ctx).Debugf("MQTT<- topic='%s' event %s on subscription %s acknowledged", msg.Topic, event.ID, sub.ID)
+
+	// The PUBACK means the broker has taken responsibility for the message, so unless we need
+	// to wait for a reply the subscription offset can now move forwards
+	if !awaitReply {
+		m.respond(connID, sub.Namespace, &core.EventDeliveryResponse{
+			ID:           event.ID,
+			Rejected:     false,
+			Subscription: event.Subscription,
+		})
+	}
+	return nil
+}
+
+func (m *MQTT) BatchDeliveryRequest(ctx context.Context, connID string, sub *core.Subscription, events []*core.CombinedEventDataDelivery) error {
+	// Batch delivery is not a capability of this transport, but each event can be published in turn
+	for _, combinedEvent := range events {
+		if err := m.DeliveryRequest(ctx, connID, sub, combinedEvent.Event, combinedEvent.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NamespaceRestarted forgets the events of the namespace that are awaiting a reply, as its dispatchers
+// start afresh and deliver them again, and a reply is only matched to the latest delivery of an event
+func (m *MQTT) NamespaceRestarted(ns string, startTime time.Time) {
+	m.replyMux.Lock()
+	defer m.replyMux.Unlock()
+	for eventID, pending := range m.awaitingReply {
+		if pending.namespace == ns {
+			pending.timer.Stop()
+			delete(m.awaitingReply, eventID)
+		}
+	}
+}
@@ -0,0 +1,440 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/eventsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testClient struct {
+	published  []*paho.Publish
+	reasonCode byte
+	publishErr error
+	onPublish  func(p *paho.Publish)
+}
+
+func (c *testClient) Publish(ctx context.Context, p *paho.Publish) (*paho.PublishResponse, error) {
+	c.published = append(c.published, p)
+	if c.onPublish != nil {
+		c.onPublish(p)
+	}
+	if c.reasonCode != 0 {
+		return &paho.PublishResponse{ReasonCode: c.reasonCode}, fmt.Errorf("nack")
+	}
+	if c.publishErr != nil {
+		return nil, c.publishErr
+	}
+	return &paho.PublishResponse{}, nil
+}
+
+func newTestMQTT(t *testing.T, replyTopic string) (m *MQTT, c *testClient, cbs *eventsmocks.Callbacks) {
+	coreconfig.Reset()
+
+	c = &testClient{}
+	origDial := dialClient
+	dialClient = func(ctx context.Context, conf *clientConfig, onReply func(*paho.Publish)) (client, error) {
+		assert.Equal(t, "mqtt://localhost:1883", conf.url.String())
+		assert.Regexp(t, "^firefly-", conf.clientID)
+		assert.Equal(t, strings.ReplaceAll(replyTopic, "{clientID}", conf.clientID), conf.replyTopic)
+		return c, nil
+	}
+	t.Cleanup(func() { dialClient = origDial })
+
+	cbs = &eventsmocks.Callbacks{}
+	rc := cbs.On("RegisterConnection", mock.Anything, mock.Anything).Return(nil)
+	rc.RunFn = func(a mock.Arguments) {
+		assert.True(t, a[1].(events.SubscriptionMatcher)(core.SubscriptionRef{}))
+	}
+	m = &MQTT{}
+	conf := config.RootSection("ut.mqtt")
+	m.InitConfig(conf)
+	conf.Set(MQTTConfURL, "mqtt://localhost:1883")
+	conf.Set(MQTTConfReplyTopic, replyTopic)
+	err := m.Init(context.Background(), conf)
+	assert.NoError(t, err)
+	err = m.SetHandler("ns1", cbs)
+	assert.NoError(t, err)
+	assert.Equal(t, "mqtt", m.Name())
+	assert.False(t, m.Capabilities().BatchDelivery)
+	return m, c, cbs
+}
+
+func newTestEvent(sub *core.Subscription) *core.EventDelivery {
+	return &core.EventDelivery{
+		EnrichedEvent: core.EnrichedEvent{
+			Event: core.Event{
+				ID:        fftypes.NewUUID(),
+				Namespace: "ns1",
+				Type:      core.EventTypeMessageConfirmed,
+				Topic:     "fftopic1",
+			},
+		},
+		Subscription: sub.SubscriptionRef,
+	}
+}
+
+func newTestSubscription(options fftypes.JSONObject) *core.Subscription {
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID:        fftypes.NewUUID(),
+			Namespace: "ns1",
+			Name:      "sub1",
+		},
+		Transport: "mqtt",
+	}
+	for k, v := range options {
+		sub.Options.TransportOptions()[k] = v
+	}
+	return sub
+}
+
+func userProperty(p *paho.Publish, key string) string {
+	return p.Properties.User.Get(key)
+}
+
+func TestInitMissingURL(t *testing.T) {
+	coreconfig.Reset()
+
+	m := &MQTT{}
+	conf := config.RootSection("ut.mqtt")
+	m.InitConfig(conf)
+	err := m.Init(context.Background(), conf)
+	assert.Regexp(t, "FF10138.*url", err)
+}
+
+func TestInitBadURL(t *testing.T) {
+	coreconfig.Reset()
+
+	m := &MQTT{}
+	conf := config.RootSection("ut.mqtt")
+	m.InitConfig(conf)
+	conf.Set(MQTTConfURL, ":::not a url")
+	err := m.Init(context.Background(), conf)
+	assert.Regexp(t, "FF10515", err)
+}
+
+func TestInitBadTopicTemplate(t *testing.T) {
+	coreconfig.Reset()
+
+	m := &MQTT{}
+	conf := config.RootSection("ut.mqtt")
+	m.InitConfig(conf)
+	conf.Set(MQTTConfURL, "mqtt://localhost:1883")
+	conf.Set(MQTTConfTopicTemplate, "firefly/#")
+	err := m.Init(context.Background(), conf)
+	assert.Regexp(t, "FF10518", err)
+}
+
+func TestInitBadReplyTopic(t *testing.T) {
+	for _, replyTopic := range []string{"firefly/replies", "$share/group/{clientID}", "firefly/{clientID}/#"} {
+		coreconfig.Reset()
+
+		m := &MQTT{}
+		conf := config.RootSection("ut.mqtt")
+		m.InitConfig(conf)
+		conf.Set(MQTTConfURL, "mqtt://localhost:1883")
+		conf.Set(MQTTConfReplyTopic, replyTopic)
+		err := m.Init(context.Background(), conf)
+		assert.Regexp(t, "FF10550", err)
+	}
+}
+
+func TestInitBadTLS(t *testing.T) {
+	coreconfig.Reset()
+
+	m := &MQTT{}
+	conf := config.RootSection("ut.mqtt")
+	m.InitConfig(conf)
+	conf.Set(MQTTConfURL, "mqtts://localhost:8883")
+	tlsConfig := conf.SubSection(MQTTConfTLS)
+	tlsConfig.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConfig.Set(fftls.HTTPConfTLSCAFile, "BADCA")
+	err := m.Init(context.Background(), conf)
+	assert.Regexp(t, "FF00153", err)
+}
+
+func TestInitConnectFail(t *testing.T) {
+	coreconfig.Reset()
+
+	m := &MQTT{}
+	conf := config.RootSection("ut.mqtt")
+	m.InitConfig(conf)
+	conf.Set(MQTTConfURL, "mqtt://localhost:1")
+	conf.Set(MQTTConfReplyTopic, "firefly/{clientID}/replies")
+	conf.Set(MQTTConfConnectTimeout, "100ms")
+	err := m.Init(context.Background(), conf)
+	assert.Regexp(t, "FF10515", err)
+}
+
+func TestSetHandlerRemove(t *testing.T) {
+	m, _, _ := newTestMQTT(t, "")
+
+	err := m.SetHandler("ns1", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, m.callbacks.handlers)
+}
+
+func TestValidateOptions(t *testing.T) {
+	m, _, _ := newTestMQTT(t, "")
+
+	opts := &core.SubscriptionOptions{}
+	assert.NoError(t, m.ValidateOptions(context.Background(), opts))
+	opts.TransportOptions()["topicTemplate"] = "app/{topic}"
+	assert.NoError(t, m.ValidateOptions(context.Background(), opts))
+	opts.TransportOptions()["topicTemplate"] = "app/+"
+	assert.Regexp(t, "FF10518", m.ValidateOptions(context.Background(), opts))
+	opts.TransportOptions()["topicTemplate"] = ""
+	opts.TransportOptions()["reply"] = true
+	assert.Regexp(t, "FF10519", m.ValidateOptions(context.Background(), opts))
+
+	m.replyTopic = "firefly/replies"
+	assert.NoError(t, m.ValidateOptions(context.Background(), opts))
+}
+
+func TestDeliveryRequestAcked(t *testing.T) {
+	m, c, cbs := newTestMQTT(t, "")
+
+	sub := newTestSubscription(nil)
+	event := newTestEvent(sub)
+	data := core.DataArray{{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`{"some":"data"}`)}}
+	cbs.On("DeliveryResponse", m.connID, mock.MatchedBy(func(response *core.EventDeliveryResponse) bool {
+		return response.ID.Equals(event.ID) && !response.Rejected
+	})).Return(nil)
+
+	err := m.DeliveryRequest(context.Background(), m.connID, sub, event, data)
+	assert.NoError(t, err)
+
+	assert.Len(t, c.published, 1)
+	p := c.published[0]
+	assert.Equal(t, "firefly/ns1/message_confirmed/fftopic1", p.Topic)
+	assert.Equal(t, byte(1), p.QoS)
+	assert.Equal(t, "application/json", p.Properties.ContentType)
+	assert.Empty(t, p.Properties.ResponseTopic)
+	assert.Equal(t, "ns1", userProperty(p, "ff-namespace"))
+	assert.Equal(t, event.ID.String(), userProperty(p, "ff-event-id"))
+	assert.Equal(t, "message_confirmed", userProperty(p, "ff-event-type"))
+	assert.Equal(t, "sub1", userProperty(p, "ff-subscription"))
+	var body fftypes.JSONObject
+	err = json.Unmarshal(p.Payload, &body)
+	assert.NoError(t, err)
+	assert.Equal(t, event.ID.String(), body.GetString("id"))
+	assert.Equal(t, "data", body.GetObjectArray("data")[0].GetObject("value").GetString("some"))
+
+	cbs.AssertExpectations(t)
+}
+
+func TestDeliveryRequestTopicTemplateOption(t *testing.T) {
+	m, c, cbs := newTestMQTT(t, "")
+
+	sub := newTestSubscription(fftypes.JSONObject{"topicTemplate": "app/{namespace}/{topic}"})
+	event := newTestEvent(sub)
+	event.Topic = "a+b#c"
+	cbs.On("DeliveryResponse", m.connID, mock.Anything).Return(nil)
+
+	err := m.DeliveryRequest(context.Background(), m.connID, sub, event, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "app/ns1/a_b_c", c.published[0].Topic)
+}
+
+func TestDeliveryRequestBadData(t *testing.T) {
+	m, _, _ := newTestMQTT(t, "")
+
+	sub := newTestSubscription(nil)
+	data := core.DataArray{{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`!json`)}}
+	err := m.DeliveryRequest(context.Background(), m.connID, sub, newTestEvent(sub), data)
+	assert.Regexp(t, "FF10137", err)
+}
+
+func TestDeliveryRequestPublishFail(t *testing.T) {
+	m, c, _ := newTestMQTT(t, "firefly/{clientID}/replies")
+	c.publishErr = fmt.Errorf("pop")
+
+	sub := newTestSubscription(fftypes.JSONObject{"reply": true})
+	err := m.DeliveryRequest(context.Background(), m.connID, sub, newTestEvent(sub), nil)
+	assert.Regexp(t, "FF10516.*pop", err)
+	assert.Empty(t, m.awaitingReply)
+}
+
+func TestDeliveryRequestNacked(t *testing.T) {
+	m, c, cbs := newTestMQTT(t, "firefly/{clientID}/replies")
+	c.reasonCode = 0x87
+
+	sub := newTestSubscription(fftypes.JSONObject{"reply": true})
+	event := newTestEvent(sub)
+	cbs.On("DeliveryResponse", m.connID, mock.MatchedBy(func(response *core.EventDeliveryResponse) bool {
+		return response.ID.Equals(event.ID) && response.Rejected
+	})).Return(nil)
+
+	err := m.DeliveryRequest(context.Background(), m.connID, sub, event, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, m.awaitingReply)
+
+	cbs.AssertExpectations(t)
+}
+
+func TestDeliveryRequestNackedAfterReplyTimeout(t *testing.T) {
+	m, c, _ := newTestMQTT(t, "firefly/{clientID}/replies")
+	c.reasonCode = 0x87
+	c.onPublish = func(p *paho.Publish) {
+		// Simulate the reply timeout firing, and rejecting the event, while the publish was in flight
+		m.takePendingReply(string(p.Properties.CorrelationData))
+	}
+
+	sub := newTestSubscription(fftypes.JSONObject{"reply": true})
+	err := m.DeliveryRequest(context.Background(), m.connID, sub, newTestEvent(sub), nil)
+	assert.NoError(t, err)
+}
+
+func TestDeliveryRequestReply(t *testing.T) {
+	m, c, cbs := newTestMQTT(t, "firefly/{clientID}/replies")
+
+	sub := newTestSubscription(fftypes.JSONObject{"reply": true})
+	event := newTestEvent(sub)
+	cbs.On("DeliveryResponse", m.connID, mock.MatchedBy(func(response *core.EventDeliveryResponse) bool {
+		return response.ID.Equals(event.ID) && !response.Rejected &&
+			response.Subscription.ID.Equals(sub.ID) && response.Reply.Message.Header.Tag == "reply1"
+	})).Return(nil)
+
+	err := m.DeliveryRequest(context.Background(), m.connID, sub, event, nil)
+	assert.NoError(t, err)
+	p := c.published[0]
+	assert.Regexp(t, "^firefly/firefly-.*/replies$", p.Properties.ResponseTopic)
+	assert.Equal(t, m.replyTopic, p.Properties.ResponseTopic)
+	assert.Equal(t, event.ID.String(), string(p.Properties.CorrelationData))
+	assert.Len(t, m.awaitingReply, 1)
+
+	m.handleReply(&paho.Publish{
+		Topic: m.replyTopic,
+		Properties: &paho.PublishProperties{
+			CorrelationData: p.Properties.CorrelationData,
+		},
+		Payload: []byte(`{"reply":{"header":{"tag":"reply1"}}}`),
+	})
+	assert.Empty(t, m.awaitingReply)
+
+	cbs.AssertExpectations(t)
+}
+
+func TestDeliveryRequestReplyBadJSON(t *testing.T) {
+	m, c, cbs := newTestMQTT(t, "firefly/{clientID}/replies")
+
+	sub := newTestSubscription(fftypes.JSONObject{"reply": true})
+	event := newTestEvent(sub)
+	cbs.On("DeliveryResponse", m.connID, mock.MatchedBy(func(response *core.EventDeliveryResponse) bool {
+		return response.ID.Equals(event.ID) && response.Rejected
+	})).Return(nil)
+
+	err := m.DeliveryRequest(context.Background(), m.connID, sub, event, nil)
+	assert.NoError(t, err)
+
+	m.handleReply(&paho.Publish{
+		Properties: &paho.PublishProperties{
+			CorrelationData: c.published[0].Properties.CorrelationData,
+		},
+		Payload: []byte(`!json`),
+	})
+
+	cbs.AssertExpectations(t)
+}
+
+func TestDeliveryRequestReplyTimeout(t *testing.T) {
+	m, _, cbs := newTestMQTT(t, "firefly/{clientID}/replies")
+	m.replyTimeout = 1 * time.Millisecond
+
+	sub := newTestSubscription(fftypes.JSONObject{"reply": true})
+	event := newTestEvent(sub)
+	responded := make(chan *core.EventDeliveryResponse)
+	cbs.On("DeliveryResponse", m.connID, mock.Anything).Return(nil).Run(func(a mock.Arguments) {
+		responded <- a[1].(*core.EventDeliveryResponse)
+	})
+
+	err := m.DeliveryRequest(context.Background(), m.connID, sub, event, nil)
+	assert.NoError(t, err)
+
+	response := <-responded
+	assert.True(t, response.Rejected)
+	assert.Regexp(t, "FF10520", response.Info)
+}
+
+func TestHandleReplyIgnored(t *testing.T) {
+	m, _, _ := newTestMQTT(t, "firefly/{clientID}/replies")
+
+	m.handleReply(&paho.Publish{Topic: m.replyTopic})
+	m.handleReply(&paho.Publish{
+		Topic:      m.replyTopic,
+		Properties: &paho.PublishProperties{CorrelationData: []byte("unknown")},
+	})
+}
+
+func TestBatchDeliveryRequestOk(t *testing.T) {
+	m, c, cbs := newTestMQTT(t, "")
+
+	sub := newTestSubscription(nil)
+	cbs.On("DeliveryResponse", m.connID, mock.Anything).Return(nil)
+
+	err := m.BatchDeliveryRequest(context.Background(), m.connID, sub, []*core.CombinedEventDataDelivery{
+		{Event: newTestEvent(sub)},
+		{Event: newTestEvent(sub)},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, c.published, 2)
+}
+
+func TestBatchDeliveryRequestFail(t *testing.T) {
+	m, c, _ := newTestMQTT(t, "")
+	c.publishErr = fmt.Errorf("pop")
+
+	sub := newTestSubscription(nil)
+	err := m.BatchDeliveryRequest(context.Background(), m.connID, sub, []*core.CombinedEventDataDelivery{
+		{Event: newTestEvent(sub)},
+	})
+	assert.Regexp(t, "FF10516", err)
+}
+
+func TestNamespaceRestarted(t *testing.T) {
+	m, _, _ := newTestMQTT(t, "firefly/{clientID}/replies")
+
+	sub1 := newTestSubscription(fftypes.JSONObject{"reply": true})
+	sub2 := newTestSubscription(fftypes.JSONObject{"reply": true})
+	sub2.Namespace = "ns2"
+	err := m.DeliveryRequest(context.Background(), m.connID, sub1, newTestEvent(sub1), nil)
+	assert.NoError(t, err)
+	event2 := newTestEvent(sub2)
+	err = m.DeliveryRequest(context.Background(), m.connID, sub2, event2, nil)
+	assert.NoError(t, err)
+	assert.Len(t, m.awaitingReply, 2)
+
+	m.NamespaceRestarted("ns1", time.Now())
+	assert.Len(t, m.awaitingReply, 1)
+	assert.NotNil(t, m.takePendingReply(event2.ID.String()))
+}
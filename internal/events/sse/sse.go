@@ -0,0 +1,303 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/events"
+)
+
+type SSENamespaced interface {
+	ServeHTTPNamespaced(namespace string, res http.ResponseWriter, req *http.Request)
+	AckNamespaced(ctx context.Context, namespace, connID string, header http.Header, ack *core.WSAck) error
+}
+
+// SSE streams events to clients over a long-lived HTTP GET using the text/event-stream format,
+// with acknowledgements posted back on a separate request
+type SSE struct {
+	ctx          context.Context
+	capabilities *events.Capabilities
+	callbacks    callbacks
+	connections  map[string]*sseConnection
+	connMux      sync.Mutex
+	pingInterval time.Duration
+	auth         core.Authorizer
+}
+
+type callbacks struct {
+	writeLock sync.Mutex
+	handlers  map[string]events.Callbacks
+}
+
+func (s *SSE) Name() string { return "sse" }
+
+func (s *SSE) Init(ctx context.Context, config config.Section) error {
+	*s = SSE{
+		ctx:         ctx,
+		connections: make(map[string]*sseConnection),
+		capabilities: &events.Capabilities{
+			BatchDelivery: true,
+		},
+		callbacks: callbacks{
+			handlers: make(map[string]events.Callbacks),
+		},
+		pingInterval: config.GetDuration(PingInterval),
+	}
+	return nil
+}
+
+func (s *SSE) SetAuthorizer(auth core.Authorizer) {
+	s.auth = auth
+}
+
+func (s *SSE) getHandler(namespace string) (events.Callbacks, bool) {
+	s.callbacks.writeLock.Lock()
+	defer s.callbacks.writeLock.Unlock()
+	cb, ok := s.callbacks.handlers[namespace]
+	return cb, ok
+}
+
+func (s *SSE) SetHandler(namespace string, handler events.Callbacks) error {
+	s.callbacks.writeLock.Lock()
+	defer s.callbacks.writeLock.Unlock()
+	if handler == nil {
+		delete(s.callbacks.handlers, namespace)
+		return nil
+	}
+	s.callbacks.handlers[namespace] = handler
+	return nil
+}
+
+func (s *SSE) Capabilities() *events.Capabilities {
+	return s.capabilities
+}
+
+func (s *SSE) ValidateOptions(ctx context.Context, options *core.SubscriptionOptions) error {
+	// As with websockets, we don't support streaming the full data
+	if options.WithData != nil && *options.WithData {
+		return i18n.NewError(ctx, coremsgs.MsgWebsocketsNoData)
+	}
+	forceFalse := false
+	options.WithData = &forceFalse
+	return nil
+}
+
+func (s *SSE) getConnection(ctx context.Context, connID string) (*sseConnection, error) {
+	s.connMux.Lock()
+	sc, ok := s.connections[connID]
+	s.connMux.Unlock()
+	if !ok {
+		return nil, i18n.NewError(ctx, coremsgs.MsgSSEConnectionNotActive, connID)
+	}
+	return sc, nil
+}
+
+func (s *SSE) DeliveryRequest(ctx context.Context, connID string, sub *core.Subscription, event *core.EventDelivery, data core.DataArray) error {
+	sc, err := s.getConnection(ctx, connID)
+	if err != nil {
+		return err
+	}
+	return sc.dispatch(event)
+}
+
+func (s *SSE) BatchDeliveryRequest(ctx context.Context, connID string, sub *core.Subscription, events []*core.CombinedEventDataDelivery) error {
+	sc, err := s.getConnection(ctx, connID)
+	if err != nil {
+		return err
+	}
+	return sc.dispatchBatch(sub, events)
+}
+
+func (s *SSE) authorize(ctx context.Context, namespace string, header http.Header) error {
+	if s.auth != nil {
+		return s.auth.Authorize(ctx, &fftypes.AuthReq{
+			Namespace: namespace,
+			Header:    header,
+		})
+	}
+	return nil
+}
+
+func writeError(res http.ResponseWriter, status int, err error) {
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(status)
+	_ = json.NewEncoder(res).Encode(fftypes.JSONObject{"error": err.Error()})
+}
+
+// ServeHTTPNamespaced holds open the request, streaming events on the subscription described by the query
+// parameters until the client disconnects. Delivery resumes after the sequence in the Last-Event-ID header
+// for ephemeral subscriptions. Durable subscriptions resume from their stored offset, and any events up to
+// the Last-Event-ID are not sent again, but must still be acknowledged by the client.
+func (s *SSE) ServeHTTPNamespaced(namespace string, res http.ResponseWriter, req *http.Request) {
+	flusher, ok := res.(http.Flusher)
+	if !ok {
+		writeError(res, http.StatusInternalServerError, i18n.NewError(s.ctx, coremsgs.MsgSSEStreamingNotSupported))
+		return
+	}
+	if _, ok = s.getHandler(namespace); !ok {
+		writeError(res, http.StatusNotFound, i18n.NewError(s.ctx, coremsgs.MsgNamespaceDoesNotExist))
+		return
+	}
+	if err := s.authorize(req.Context(), namespace, req.Header); err != nil {
+		writeError(res, http.StatusForbidden, err)
+		return
+	}
+	start, lastEventSequence, err := s.parseStart(req, namespace)
+	if err != nil {
+		writeError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	s.connMux.Lock()
+	sc := newConnection(s.ctx, s, req, res, flusher, start)
+	sc.lastEventSequence = lastEventSequence
+	s.connections[sc.connID] = sc
+	s.connMux.Unlock()
+
+	if err := sc.handshake(); err != nil {
+		sc.close()
+		return
+	}
+	if err := s.start(sc, start); err != nil {
+		log.L(sc.ctx).Errorf("Failed to start subscription: %s", err)
+		sc.protocolError(err)
+		sc.close()
+		return
+	}
+	sc.sendLoop(req.Context())
+}
+
+// parseStart returns the subscription to start, and the sequence of the last event the client received or -1
+func (s *SSE) parseStart(req *http.Request, namespace string) (*core.WSStart, int64, error) {
+	query := req.URL.Query()
+	isEphemeral := isBoolQuerySet(query, "ephemeral")
+	name := query.Get("name")
+	if !isEphemeral && name == "" {
+		return nil, -1, i18n.NewError(s.ctx, coremsgs.MsgSSEInvalidStart)
+	}
+	isAutoack := isBoolQuerySet(query, "autoack")
+	isBatch := isBoolQuerySet(query, "batch")
+	start := &core.WSStart{
+		AutoAck:   &isAutoack,
+		Ephemeral: isEphemeral,
+		Namespace: namespace,
+		Name:      name,
+		Filter:    core.NewSubscriptionFilterFromQuery(query),
+		Options: core.SubscriptionOptions{
+			SubscriptionCoreOptions: core.SubscriptionCoreOptions{
+				Batch:        &isBatch,
+				BatchTimeout: getBatchTimeout(query),
+				ReadAhead:    getReadAhead(query),
+			},
+		},
+	}
+
+	// Browsers send Last-Event-ID automatically when an EventSource reconnects, but we also
+	// accept it as a query parameter for clients that cannot set headers
+	lastEventID := req.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = query.Get("lastEventId")
+	}
+	if lastEventID == "" {
+		return start, -1, nil
+	}
+	lastEventSequence, err := parseLastEventID(s.ctx, lastEventID)
+	if err != nil {
+		return nil, -1, err
+	}
+	// An ephemeral subscription has no stored offset, so the poller starts after the last event received
+	if isEphemeral {
+		firstEvent := core.SubOptsFirstEvent(strconv.FormatInt(lastEventSequence, 10))
+		start.Options.FirstEvent = &firstEvent
+	}
+	return start, lastEventSequence, nil
+}
+
+func (s *SSE) start(sc *sseConnection, start *core.WSStart) error {
+	if cb, ok := s.getHandler(start.Namespace); ok {
+		if start.Ephemeral {
+			return cb.EphemeralSubscription(sc.connID, start.Namespace, &start.Filter, &start.Options)
+		}
+		return cb.RegisterConnection(sc.connID, func(sr core.SubscriptionRef) bool {
+			return sr.Namespace == start.Namespace && sr.Name == start.Name
+		})
+	}
+	return i18n.NewError(s.ctx, coremsgs.MsgNamespaceDoesNotExist)
+}
+
+// AckNamespaced handles an acknowledgement posted by the client for an event or batch
+// delivered on the stream identified by connID
+func (s *SSE) AckNamespaced(ctx context.Context, namespace, connID string, header http.Header, ack *core.WSAck) error {
+	if err := s.authorize(ctx, namespace, header); err != nil {
+		return err
+	}
+	sc, err := s.getConnection(ctx, connID)
+	if err != nil {
+		return err
+	}
+	if sc.start.Namespace != namespace {
+		return i18n.NewError(ctx, coremsgs.MsgSSEConnectionNotActive, connID)
+	}
+	return sc.handleAck(ack)
+}
+
+func (s *SSE) ack(connID string, inflight *core.EventDeliveryResponse) {
+	if cb, ok := s.getHandler(inflight.Subscription.Namespace); ok {
+		cb.DeliveryResponse(connID, inflight)
+	}
+}
+
+func (s *SSE) connClosed(connID string) {
+	s.connMux.Lock()
+	delete(s.connections, connID)
+	s.connMux.Unlock()
+	s.callbacks.writeLock.Lock()
+	handlers := make([]events.Callbacks, 0, len(s.callbacks.handlers))
+	for _, cb := range s.callbacks.handlers {
+		handlers = append(handlers, cb)
+	}
+	s.callbacks.writeLock.Unlock()
+	// Drop locks before calling back
+	for _, cb := range handlers {
+		cb.ConnectionClosed(connID)
+	}
+}
+
+func (s *SSE) NamespaceRestarted(ns string, startTime time.Time) {
+	s.connMux.Lock()
+	connections := make([]*sseConnection, 0, len(s.connections))
+	for _, sc := range s.connections {
+		connections = append(connections, sc)
+	}
+	s.connMux.Unlock()
+
+	for _, sc := range connections {
+		sc.restartForNamespace(ns, startTime)
+	}
+}
@@ -0,0 +1,414 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// sseConnected is sent as a named "connected" event when the stream opens, so the client
+// knows the connection ID to use when posting acks
+type sseConnected struct {
+	Connection string `json:"connection"`
+}
+
+type sseMessage struct {
+	id      string
+	payload interface{}
+}
+
+type sseConnection struct {
+	ctx             context.Context
+	s               *SSE
+	res             http.ResponseWriter
+	flusher         http.Flusher
+	cancelCtx       func()
+	connID          string
+	sendMessages    chan *sseMessage
+	autoAck         bool
+	start           *core.WSStart
+	startTime       *fftypes.FFTime
+	inflight        []*core.EventDeliveryResponse
+	inflightBatches []*core.WSEventBatch
+	mux             sync.Mutex
+	closed          bool
+	// lastEventSequence is the Last-Event-ID the client reconnected with, or -1
+	lastEventSequence int64
+}
+
+func newConnection(pCtx context.Context, s *SSE, req *http.Request, res http.ResponseWriter, flusher http.Flusher, start *core.WSStart) *sseConnection {
+	connID := fftypes.NewUUID().String()
+	ctx := log.WithLogField(pCtx, "sse", connID)
+	ctx, cancelCtx := context.WithCancel(ctx)
+	log.L(ctx).Infof("SSE connection from %s (%s)", req.RemoteAddr, req.UserAgent())
+	return &sseConnection{
+		ctx:          ctx,
+		s:            s,
+		res:          res,
+		flusher:      flusher,
+		cancelCtx:    cancelCtx,
+		connID:       connID,
+		sendMessages: make(chan *sseMessage),
+		autoAck:      start.AutoAck != nil && *start.AutoAck,
+		start:        start,
+		startTime:    fftypes.Now(),
+
+		lastEventSequence: -1,
+	}
+}
+
+func isBoolQuerySet(query url.Values, boolOption string) bool {
+	optionValues, hasOptionValues := query[boolOption]
+	return hasOptionValues && (len(optionValues) == 0 || optionValues[0] != "false")
+}
+
+func getReadAhead(query url.Values) *uint {
+	readaheadStr := query.Get("readahead")
+	if readaheadStr != "" {
+		readAheadInt, err := strconv.ParseUint(readaheadStr, 10, 16)
+		if err == nil {
+			readahead := uint(readAheadInt)
+			return &readahead
+		}
+	}
+	return nil
+}
+
+func getBatchTimeout(query url.Values) *string {
+	batchTimeout := query.Get("batchtimeout")
+	if batchTimeout != "" {
+		return &batchTimeout
+	}
+	return nil
+}
+
+// parseLastEventID returns the sequence of the last event the client received, which is its ID
+func parseLastEventID(ctx context.Context, lastEventID string) (int64, error) {
+	sequence, err := strconv.ParseInt(lastEventID, 10, 64)
+	if err != nil || sequence < 0 {
+		return -1, i18n.NewError(ctx, coremsgs.MsgSSEInvalidLastEventID, lastEventID)
+	}
+	return sequence, nil
+}
+
+// alreadyReceived checks if an event is at or before the Last-Event-ID of the client. A durable subscription
+// redelivers the events that were not acknowledged before the client reconnected, so these are not sent
+// again. They are still only acknowledged when the client acknowledges them, as the client might not have
+// processed them before it reconnected.
+func (sc *sseConnection) alreadyReceived(event *core.EventDelivery) bool {
+	return !sc.start.Ephemeral && event.Sequence <= sc.lastEventSequence
+}
+
+// handshake writes the response headers, and the connected event
+func (sc *sseConnection) handshake() error {
+	header := sc.res.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// Stop reverse proxies such as nginx from buffering the stream
+	header.Set("X-Accel-Buffering", "no")
+	sc.res.WriteHeader(http.StatusOK)
+	return sc.writeEvent("connected", "", &sseConnected{Connection: sc.connID})
+}
+
+// writeEvent must only be called from the goroutine serving the request
+func (sc *sseConnection) writeEvent(eventName, id string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	var frame bytes.Buffer
+	if eventName != "" {
+		fmt.Fprintf(&frame, "event: %s\n", eventName)
+	}
+	if id != "" {
+		fmt.Fprintf(&frame, "id: %s\n", id)
+	}
+	fmt.Fprintf(&frame, "data: %s\n\n", data)
+	log.L(sc.ctx).Tracef("Sending: %s", frame.Bytes())
+	if _, err := sc.res.Write(frame.Bytes()); err != nil {
+		return err
+	}
+	sc.flusher.Flush()
+	return nil
+}
+
+func (sc *sseConnection) writePing() error {
+	if _, err := sc.res.Write([]byte(": ping\n\n")); err != nil {
+		return err
+	}
+	sc.flusher.Flush()
+	return nil
+}
+
+// sendLoop runs on the goroutine serving the request, until the client goes away or the connection is closed
+func (sc *sseConnection) sendLoop(reqCtx context.Context) {
+	l := log.L(sc.ctx)
+	defer sc.close()
+	var ping <-chan time.Time
+	if sc.s.pingInterval > 0 {
+		ticker := time.NewTicker(sc.s.pingInterval)
+		defer ticker.Stop()
+		ping = ticker.C
+	}
+	for {
+		var err error
+		select {
+		case msg := <-sc.sendMessages:
+			err = sc.writeEvent("", msg.id, msg.payload)
+		case <-ping:
+			err = sc.writePing()
+		case <-reqCtx.Done():
+			l.Debugf("Sender closing - client disconnected")
+			return
+		case <-sc.ctx.Done():
+			l.Debugf("Sender closing - context cancelled")
+			return
+		}
+		if err != nil {
+			l.Errorf("Write failed on stream: %s", err)
+			return
+		}
+	}
+}
+
+func (sc *sseConnection) send(id string, payload interface{}) error {
+	sc.mux.Lock()
+	closed := sc.closed
+	sc.mux.Unlock()
+	if closed {
+		return i18n.NewError(sc.ctx, coremsgs.MsgSSEClosed)
+	}
+	select {
+	case sc.sendMessages <- &sseMessage{id: id, payload: payload}:
+		return nil
+	case <-sc.ctx.Done():
+		return i18n.NewError(sc.ctx, coremsgs.MsgSSEClosed)
+	}
+}
+
+// protocolError must only be called from the goroutine serving the request
+func (sc *sseConnection) protocolError(err error) {
+	log.L(sc.ctx).Errorf("Sending protocol error to client: %s", err)
+	sendErr := sc.writeEvent("", "", &core.WSError{
+		Type:  core.WSProtocolErrorEventType,
+		Error: err.Error(),
+	})
+	if sendErr != nil {
+		log.L(sc.ctx).Errorf("Failed to send protocol error: %s", sendErr)
+	}
+}
+
+func (sc *sseConnection) dispatch(event *core.EventDelivery) error {
+	inflight := &core.EventDeliveryResponse{
+		ID:           event.ID,
+		Subscription: event.Subscription,
+	}
+	sc.mux.Lock()
+	if !sc.autoAck {
+		sc.inflight = append(sc.inflight, inflight)
+	}
+	sc.mux.Unlock()
+
+	if sc.alreadyReceived(event) {
+		log.L(sc.ctx).Debugf("Not resending event %d already received by client", event.Sequence)
+	} else {
+		// The event sequence is the SSE event ID, so a reconnecting client can resume with Last-Event-ID
+		err := sc.send(strconv.FormatInt(event.Sequence, 10), event)
+		if err != nil {
+			return err
+		}
+	}
+
+	if sc.autoAck {
+		sc.s.ack(sc.connID, inflight)
+	}
+	return nil
+}
+
+func (sc *sseConnection) dispatchBatch(sub *core.Subscription, events []*core.CombinedEventDataDelivery) error {
+	toSend := make([]*core.CombinedEventDataDelivery, 0, len(events))
+	for _, e := range events {
+		if sc.alreadyReceived(e.Event) {
+			// The client acknowledges these individually by event ID, as they are not part of a batch it has received
+			log.L(sc.ctx).Debugf("Not resending event %d already received by client", e.Event.Sequence)
+			inflight := &core.EventDeliveryResponse{
+				ID:           e.Event.ID,
+				Subscription: e.Event.Subscription,
+			}
+			if sc.autoAck {
+				sc.s.ack(sc.connID, inflight)
+			} else {
+				sc.mux.Lock()
+				sc.inflight = append(sc.inflight, inflight)
+				sc.mux.Unlock()
+			}
+		} else {
+			toSend = append(toSend, e)
+		}
+	}
+	if len(toSend) == 0 {
+		return nil
+	}
+	events = toSend
+
+	inflightBatch := &core.WSEventBatch{
+		Type:   core.WSEventBatchType,
+		ID:     fftypes.NewUUID(),
+		Events: make([]*core.EventDelivery, len(events)),
+	}
+	if sub != nil {
+		inflightBatch.Subscription = sub.SubscriptionRef
+	}
+	var lastSequence int64
+	for i, e := range events {
+		// For ephemeral there's no sub, so we pick up from first event
+		if inflightBatch.Subscription.Namespace == "" {
+			inflightBatch.Subscription = e.Event.Subscription
+		}
+		inflightBatch.Events[i] = e.Event
+		lastSequence = e.Event.Sequence
+	}
+
+	sc.mux.Lock()
+	if !sc.autoAck {
+		sc.inflightBatches = append(sc.inflightBatches, inflightBatch)
+	}
+	sc.mux.Unlock()
+
+	err := sc.send(strconv.FormatInt(lastSequence, 10), inflightBatch)
+	if err != nil {
+		return err
+	}
+
+	if sc.autoAck {
+		sc.ackBatch(inflightBatch)
+	}
+	return nil
+}
+
+func (sc *sseConnection) ackBatch(batch *core.WSEventBatch) {
+	for _, e := range batch.Events {
+		sc.s.ack(sc.connID, &core.EventDeliveryResponse{
+			ID:           e.ID,
+			Subscription: batch.Subscription,
+		})
+	}
+}
+
+func (sc *sseConnection) checkBatchAck(ack *core.WSAck) *core.WSEventBatch {
+	sc.mux.Lock()
+	defer sc.mux.Unlock()
+	for i, batch := range sc.inflightBatches {
+		if batch.ID.Equals(ack.ID) { // nil safe check
+			sc.inflightBatches = append(sc.inflightBatches[0:i], sc.inflightBatches[i+1:]...)
+			return batch
+		}
+	}
+	return nil
+}
+
+func (sc *sseConnection) checkAck(ack *core.WSAck) (*core.EventDeliveryResponse, error) {
+	sc.mux.Lock()
+	defer sc.mux.Unlock()
+
+	if sc.autoAck {
+		return nil, i18n.NewError(sc.ctx, coremsgs.MsgWSAutoAckEnabled)
+	}
+
+	if ack.ID == nil {
+		// Just ack the front of the queue
+		if len(sc.inflight) > 0 {
+			inflight := sc.inflight[0]
+			sc.inflight = sc.inflight[1:]
+			return inflight, nil
+		}
+	} else {
+		for i, candidate := range sc.inflight {
+			if candidate.ID.Equals(ack.ID) &&
+				(ack.Subscription == nil ||
+					(ack.Subscription.ID != nil && ack.Subscription.ID.Equals(candidate.Subscription.ID)) ||
+					(ack.Subscription.Name == candidate.Subscription.Name && ack.Subscription.Namespace == candidate.Subscription.Namespace)) {
+				sc.inflight = append(sc.inflight[0:i], sc.inflight[i+1:]...)
+				return candidate, nil
+			}
+		}
+	}
+	return nil, i18n.NewError(sc.ctx, coremsgs.MsgWSMsgSubNotMatched)
+}
+
+func (sc *sseConnection) handleAck(ack *core.WSAck) error {
+	if batch := sc.checkBatchAck(ack); batch != nil {
+		sc.ackBatch(batch)
+		return nil
+	}
+
+	// Perform a locked set of check
+	inflight, err := sc.checkAck(ack)
+	if err != nil {
+		return err
+	}
+
+	// Deliver the ack to the core, now we're unlocked
+	sc.s.ack(sc.connID, inflight)
+	return nil
+}
+
+func (sc *sseConnection) restartForNamespace(ns string, startTime time.Time) {
+	sc.mux.Lock()
+	restart := sc.start.Namespace == ns && sc.startTime.Time().Before(startTime)
+	if restart {
+		log.L(sc.ctx).Infof("Restarting subscription '%s:%s' (ephemeral=%t)", sc.start.Namespace, sc.start.Name, sc.start.Ephemeral)
+		sc.startTime = fftypes.Now()
+	}
+	sc.mux.Unlock()
+	if restart {
+		if err := sc.s.start(sc, sc.start); err != nil {
+			log.L(sc.ctx).Errorf("Failed restart subscription '%s:%s' (closing): %s", sc.start.Namespace, sc.start.Name, err)
+			sc.close()
+		}
+	}
+}
+
+func (sc *sseConnection) close() {
+	var didClosed bool
+	sc.mux.Lock()
+	if !sc.closed {
+		didClosed = true
+		sc.closed = true
+		sc.cancelCtx()
+	}
+	sc.mux.Unlock()
+	// Drop lock before callback
+	if didClosed {
+		sc.s.connClosed(sc.connID)
+	}
+}
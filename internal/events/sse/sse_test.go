@@ -0,0 +1,757 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sse
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/eventsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testAuthorizer struct{}
+
+func (t *testAuthorizer) Authorize(ctx context.Context, authReq *fftypes.AuthReq) error {
+	if authReq.Header.Get("Authorization") == "" {
+		return i18n.NewError(ctx, i18n.MsgUnauthorized)
+	}
+	return nil
+}
+
+type testNamespacedHandler struct {
+	s         *SSE
+	namespace string
+}
+
+func (h *testNamespacedHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	h.s.ServeHTTPNamespaced(h.namespace, res, req)
+}
+
+type testStream struct {
+	res    *http.Response
+	reader *bufio.Reader
+	cancel func()
+}
+
+func newTestSSE(t *testing.T, cbs *eventsmocks.Callbacks) (s *SSE, svr *httptest.Server, cancel func()) {
+	coreconfig.Reset()
+
+	s = &SSE{}
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	svrConfig := config.RootSection("ut.sse")
+	s.InitConfig(svrConfig)
+	err := s.Init(ctx, svrConfig)
+	assert.NoError(t, err)
+	err = s.SetHandler("ns1", cbs)
+	assert.NoError(t, err)
+	s.SetAuthorizer(&testAuthorizer{})
+	assert.Equal(t, "sse", s.Name())
+	assert.True(t, s.Capabilities().BatchDelivery)
+	svr = httptest.NewServer(&testNamespacedHandler{s: s, namespace: "ns1"})
+	return s, svr, func() {
+		cancelCtx()
+		svr.Close()
+	}
+}
+
+func openStream(t *testing.T, svr *httptest.Server, query string, header http.Header) *testStream {
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s?%s", svr.URL, query), nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer token")
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	res, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	return &testStream{
+		res:    res,
+		reader: bufio.NewReader(res.Body),
+		cancel: func() {
+			cancel()
+			res.Body.Close()
+		},
+	}
+}
+
+// readFrame reads the fields of the next frame from the stream, up to the blank line that terminates it
+func (ts *testStream) readFrame(t *testing.T) map[string]string {
+	frame := make(map[string]string)
+	for {
+		line, err := ts.reader.ReadString('\n')
+		assert.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return frame
+		}
+		field, value, _ := strings.Cut(line, ":")
+		frame[field] = strings.TrimPrefix(value, " ")
+	}
+}
+
+func (ts *testStream) readConnected(t *testing.T) string {
+	frame := ts.readFrame(t)
+	assert.Equal(t, "connected", frame["event"])
+	var connected sseConnected
+	err := json.Unmarshal([]byte(frame["data"]), &connected)
+	assert.NoError(t, err)
+	return connected.Connection
+}
+
+func newTestEvent(sequence int64) *core.EventDelivery {
+	return &core.EventDelivery{
+		EnrichedEvent: core.EnrichedEvent{
+			Event: core.Event{
+				ID:        fftypes.NewUUID(),
+				Sequence:  sequence,
+				Namespace: "ns1",
+				Type:      core.EventTypeMessageConfirmed,
+			},
+		},
+		Subscription: core.SubscriptionRef{
+			ID:        fftypes.NewUUID(),
+			Namespace: "ns1",
+			Name:      "sub1",
+		},
+	}
+}
+
+func TestEphemeralResumeAndAck(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, svr, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	cbs.On("EphemeralSubscription", mock.Anything, "ns1", mock.Anything, mock.MatchedBy(func(o *core.SubscriptionOptions) bool {
+		return o.FirstEvent != nil && *o.FirstEvent == "12"
+	})).Return(nil)
+	closed := make(chan struct{})
+	cbs.On("ConnectionClosed", mock.Anything).Run(func(args mock.Arguments) {
+		close(closed)
+	}).Return(nil)
+
+	stream := openStream(t, svr, "ephemeral", http.Header{"Last-Event-Id": []string{"12"}})
+	assert.Equal(t, 200, stream.res.StatusCode)
+	assert.Equal(t, "text/event-stream", stream.res.Header.Get("Content-Type"))
+	connID := stream.readConnected(t)
+
+	event := newTestEvent(13)
+	go func() {
+		err := s.DeliveryRequest(context.Background(), connID, nil, event, nil)
+		assert.NoError(t, err)
+	}()
+	frame := stream.readFrame(t)
+	assert.Equal(t, "13", frame["id"])
+	var received core.EventDelivery
+	err := json.Unmarshal([]byte(frame["data"]), &received)
+	assert.NoError(t, err)
+	assert.Equal(t, event.ID, received.ID)
+
+	acked := make(chan struct{})
+	cbs.On("DeliveryResponse", connID, mock.MatchedBy(func(resp *core.EventDeliveryResponse) bool {
+		return resp.ID.Equals(event.ID)
+	})).Run(func(args mock.Arguments) {
+		close(acked)
+	}).Return()
+	err = s.AckNamespaced(context.Background(), "ns1", connID, http.Header{"Authorization": []string{"Bearer token"}}, &core.WSAck{
+		ID: event.ID,
+	})
+	assert.NoError(t, err)
+	<-acked
+
+	stream.cancel()
+	<-closed
+	cbs.AssertExpectations(t)
+}
+
+func TestLastEventIDDurableSkipsReceived(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, svr, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	cbs.On("RegisterConnection", mock.Anything, mock.Anything).Return(nil)
+	cbs.On("ConnectionClosed", mock.Anything).Return(nil).Maybe()
+
+	stream := openStream(t, svr, "name=sub1&lastEventId=5", nil)
+	defer stream.cancel()
+	assert.Equal(t, 200, stream.res.StatusCode)
+	connID := stream.readConnected(t)
+
+	// The client already has event 5, so it is not sent again, but is not acknowledged until the client acknowledges it
+	event5 := newTestEvent(5)
+	err := s.DeliveryRequest(context.Background(), connID, nil, event5, nil)
+	assert.NoError(t, err)
+	cbs.AssertNotCalled(t, "DeliveryResponse", mock.Anything, mock.Anything)
+
+	event6 := newTestEvent(6)
+	go func() {
+		err := s.DeliveryRequest(context.Background(), connID, nil, event6, nil)
+		assert.NoError(t, err)
+	}()
+	frame := stream.readFrame(t)
+	assert.Equal(t, "6", frame["id"])
+
+	cbs.On("DeliveryResponse", connID, mock.MatchedBy(func(resp *core.EventDeliveryResponse) bool {
+		return resp.ID.Equals(event5.ID)
+	})).Return().Once()
+	err = s.AckNamespaced(context.Background(), "ns1", connID, http.Header{"Authorization": []string{"Bearer token"}}, &core.WSAck{ID: event5.ID})
+	assert.NoError(t, err)
+	cbs.AssertExpectations(t)
+}
+
+func TestLastEventIDDurableAutoAckSkipsReceived(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, svr, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	cbs.On("RegisterConnection", mock.Anything, mock.Anything).Return(nil)
+	cbs.On("ConnectionClosed", mock.Anything).Return(nil).Maybe()
+
+	stream := openStream(t, svr, "name=sub1&batch&autoack&lastEventId=5", nil)
+	defer stream.cancel()
+	connID := stream.readConnected(t)
+
+	// With autoack the events are acknowledged as they are dispatched, whether or not they are sent
+	event5 := newTestEvent(5)
+	cbs.On("DeliveryResponse", connID, mock.MatchedBy(func(resp *core.EventDeliveryResponse) bool {
+		return resp.ID.Equals(event5.ID)
+	})).Return().Once()
+	err := s.BatchDeliveryRequest(context.Background(), connID, nil, []*core.CombinedEventDataDelivery{
+		{Event: event5},
+	})
+	assert.NoError(t, err)
+	cbs.AssertExpectations(t)
+}
+
+func TestLastEventIDDurableBatchSkipsReceived(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, svr, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	cbs.On("RegisterConnection", mock.Anything, mock.Anything).Return(nil)
+	cbs.On("ConnectionClosed", mock.Anything).Return(nil).Maybe()
+
+	stream := openStream(t, svr, "name=sub1&batch", http.Header{"Last-Event-Id": []string{"5"}})
+	defer stream.cancel()
+	connID := stream.readConnected(t)
+
+	event4 := newTestEvent(4)
+	event5 := newTestEvent(5)
+	event6 := newTestEvent(6)
+	sub := &core.Subscription{SubscriptionRef: event4.Subscription}
+
+	// A batch of events the client already has is not sent at all
+	err := s.BatchDeliveryRequest(context.Background(), connID, sub, []*core.CombinedEventDataDelivery{
+		{Event: event4},
+	})
+	assert.NoError(t, err)
+
+	go func() {
+		err := s.BatchDeliveryRequest(context.Background(), connID, sub, []*core.CombinedEventDataDelivery{
+			{Event: event5},
+			{Event: event6},
+		})
+		assert.NoError(t, err)
+	}()
+	frame := stream.readFrame(t)
+	assert.Equal(t, "6", frame["id"])
+	var batch core.WSEventBatch
+	err = json.Unmarshal([]byte(frame["data"]), &batch)
+	assert.NoError(t, err)
+	assert.Len(t, batch.Events, 1)
+	assert.True(t, batch.Events[0].ID.Equals(event6.ID))
+	cbs.AssertNotCalled(t, "DeliveryResponse", mock.Anything, mock.Anything)
+
+	// The events that were not sent again are acknowledged individually by the client
+	cbs.On("DeliveryResponse", connID, mock.MatchedBy(func(resp *core.EventDeliveryResponse) bool {
+		return resp.ID.Equals(event4.ID) || resp.ID.Equals(event5.ID)
+	})).Return().Twice()
+	header := http.Header{"Authorization": []string{"Bearer token"}}
+	err = s.AckNamespaced(context.Background(), "ns1", connID, header, &core.WSAck{ID: event4.ID})
+	assert.NoError(t, err)
+	err = s.AckNamespaced(context.Background(), "ns1", connID, header, &core.WSAck{ID: event5.ID})
+	assert.NoError(t, err)
+	cbs.AssertExpectations(t)
+}
+
+func TestDurableAutoAckBatch(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, svr, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	cbs.On("RegisterConnection", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		matcher := args[1].(events.SubscriptionMatcher)
+		assert.True(t, matcher(core.SubscriptionRef{Namespace: "ns1", Name: "sub1"}))
+		assert.False(t, matcher(core.SubscriptionRef{Namespace: "ns1", Name: "sub2"}))
+	})
+	cbs.On("ConnectionClosed", mock.Anything).Return(nil).Maybe()
+
+	stream := openStream(t, svr, "name=sub1&autoack&batch&readahead=10&batchtimeout=1s", nil)
+	defer stream.cancel()
+	connID := stream.readConnected(t)
+
+	event1 := newTestEvent(1)
+	event2 := newTestEvent(2)
+	acked := make(chan struct{}, 2)
+	cbs.On("DeliveryResponse", connID, mock.Anything).Run(func(args mock.Arguments) {
+		acked <- struct{}{}
+	}).Return()
+	sub := &core.Subscription{SubscriptionRef: event1.Subscription}
+	go func() {
+		err := s.BatchDeliveryRequest(context.Background(), connID, sub, []*core.CombinedEventDataDelivery{
+			{Event: event1},
+			{Event: event2},
+		})
+		assert.NoError(t, err)
+	}()
+	frame := stream.readFrame(t)
+	assert.Equal(t, "2", frame["id"])
+	var batch core.WSEventBatch
+	err := json.Unmarshal([]byte(frame["data"]), &batch)
+	assert.NoError(t, err)
+	assert.Len(t, batch.Events, 2)
+	<-acked
+	<-acked
+
+	err = s.AckNamespaced(context.Background(), "ns1", connID, http.Header{"Authorization": []string{"Bearer token"}}, &core.WSAck{})
+	assert.Regexp(t, "FF10180", err)
+}
+
+func TestBatchAckEphemeral(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, svr, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	cbs.On("EphemeralSubscription", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil)
+	cbs.On("ConnectionClosed", mock.Anything).Return(nil).Maybe()
+
+	stream := openStream(t, svr, "ephemeral&batch", nil)
+	defer stream.cancel()
+	connID := stream.readConnected(t)
+
+	event := newTestEvent(1)
+	go func() {
+		err := s.BatchDeliveryRequest(context.Background(), connID, nil, []*core.CombinedEventDataDelivery{
+			{Event: event},
+		})
+		assert.NoError(t, err)
+	}()
+	frame := stream.readFrame(t)
+	var batch core.WSEventBatch
+	err := json.Unmarshal([]byte(frame["data"]), &batch)
+	assert.NoError(t, err)
+	assert.Equal(t, "sub1", batch.Subscription.Name)
+
+	cbs.On("DeliveryResponse", connID, mock.MatchedBy(func(resp *core.EventDeliveryResponse) bool {
+		return resp.ID.Equals(event.ID)
+	})).Return()
+	err = s.AckNamespaced(context.Background(), "ns1", connID, http.Header{"Authorization": []string{"Bearer token"}}, &core.WSAck{
+		ID: batch.ID,
+	})
+	assert.NoError(t, err)
+	cbs.AssertExpectations(t)
+}
+
+func TestAckMatching(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, svr, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	cbs.On("EphemeralSubscription", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil)
+	cbs.On("ConnectionClosed", mock.Anything).Return(nil).Maybe()
+	cbs.On("DeliveryResponse", mock.Anything, mock.Anything).Return()
+
+	stream := openStream(t, svr, "ephemeral", nil)
+	defer stream.cancel()
+	connID := stream.readConnected(t)
+	header := http.Header{"Authorization": []string{"Bearer token"}}
+
+	event1 := newTestEvent(1)
+	event2 := newTestEvent(2)
+	event3 := newTestEvent(3)
+	go func() {
+		for _, e := range []*core.EventDelivery{event1, event2, event3} {
+			err := s.DeliveryRequest(context.Background(), connID, nil, e, nil)
+			assert.NoError(t, err)
+		}
+	}()
+	stream.readFrame(t)
+	stream.readFrame(t)
+	stream.readFrame(t)
+
+	// Nothing matches an unknown ID
+	err := s.AckNamespaced(context.Background(), "ns1", connID, header, &core.WSAck{ID: fftypes.NewUUID()})
+	assert.Regexp(t, "FF10175", err)
+
+	// The subscription must match if specified
+	err = s.AckNamespaced(context.Background(), "ns1", connID, header, &core.WSAck{
+		ID:           event2.ID,
+		Subscription: &core.SubscriptionRef{Namespace: "ns1", Name: "other"},
+	})
+	assert.Regexp(t, "FF10175", err)
+	err = s.AckNamespaced(context.Background(), "ns1", connID, header, &core.WSAck{
+		ID:           event2.ID,
+		Subscription: &core.SubscriptionRef{ID: event2.Subscription.ID},
+	})
+	assert.NoError(t, err)
+	err = s.AckNamespaced(context.Background(), "ns1", connID, header, &core.WSAck{
+		ID:           event3.ID,
+		Subscription: &core.SubscriptionRef{Namespace: "ns1", Name: "sub1"},
+	})
+	assert.NoError(t, err)
+
+	// Front of the queue, then nothing left
+	err = s.AckNamespaced(context.Background(), "ns1", connID, header, &core.WSAck{})
+	assert.NoError(t, err)
+	err = s.AckNamespaced(context.Background(), "ns1", connID, header, &core.WSAck{})
+	assert.Regexp(t, "FF10175", err)
+
+	// Wrong namespace, or unauthorized
+	err = s.AckNamespaced(context.Background(), "ns2", connID, header, &core.WSAck{})
+	assert.Regexp(t, "FF10496", err)
+	err = s.AckNamespaced(context.Background(), "ns1", connID, http.Header{}, &core.WSAck{})
+	assert.Regexp(t, "FF00169", err)
+}
+
+func TestAckUnknownConnection(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, _, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	err := s.AckNamespaced(context.Background(), "ns1", "unknown", http.Header{"Authorization": []string{"Bearer token"}}, &core.WSAck{})
+	assert.Regexp(t, "FF10496", err)
+}
+
+func TestDeliveryRequestUnknownConnection(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, _, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	err := s.DeliveryRequest(context.Background(), "unknown", nil, newTestEvent(1), nil)
+	assert.Regexp(t, "FF10496", err)
+	err = s.BatchDeliveryRequest(context.Background(), "unknown", nil, nil)
+	assert.Regexp(t, "FF10496", err)
+}
+
+func TestDeliveryRequestClosed(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, _, cancel := newTestSSE(t, cbs)
+	defer cancel()
+	cbs.On("ConnectionClosed", mock.Anything).Return(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/?ephemeral", nil)
+	start := &core.WSStart{Namespace: "ns1", Ephemeral: true}
+	sc := newConnection(s.ctx, s, req, httptest.NewRecorder(), nil, start)
+	s.connections[sc.connID] = sc
+
+	sc.close()
+	err := sc.dispatch(newTestEvent(1))
+	assert.Regexp(t, "FF10498", err)
+	err = sc.dispatchBatch(nil, []*core.CombinedEventDataDelivery{{Event: newTestEvent(1)}})
+	assert.Regexp(t, "FF10498", err)
+}
+
+func TestDeliveryRequestContextCancelled(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, _, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	req := httptest.NewRequest(http.MethodGet, "/?ephemeral", nil)
+	start := &core.WSStart{Namespace: "ns1", Ephemeral: true}
+	sc := newConnection(s.ctx, s, req, httptest.NewRecorder(), nil, start)
+	sc.cancelCtx()
+
+	err := sc.dispatch(newTestEvent(1))
+	assert.Regexp(t, "FF10498", err)
+}
+
+func TestServeUnknownNamespace(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, _, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	res := httptest.NewRecorder()
+	s.ServeHTTPNamespaced("ns2", res, httptest.NewRequest(http.MethodGet, "/?ephemeral", nil))
+	assert.Equal(t, 404, res.Code)
+	assert.Regexp(t, "FF10187", res.Body.String())
+}
+
+func TestServeUnauthorized(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, _, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	res := httptest.NewRecorder()
+	s.ServeHTTPNamespaced("ns1", res, httptest.NewRequest(http.MethodGet, "/?ephemeral", nil))
+	assert.Equal(t, 403, res.Code)
+}
+
+func TestServeBadStart(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, _, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	res := httptest.NewRecorder()
+	s.ServeHTTPNamespaced("ns1", res, req)
+	assert.Equal(t, 400, res.Code)
+	assert.Regexp(t, "FF10495", res.Body.String())
+}
+
+func TestServeBadLastEventID(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, _, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	req := httptest.NewRequest(http.MethodGet, "/?ephemeral", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("Last-Event-ID", "-1")
+	res := httptest.NewRecorder()
+	s.ServeHTTPNamespaced("ns1", res, req)
+	assert.Equal(t, 400, res.Code)
+	assert.Regexp(t, "FF10497", res.Body.String())
+}
+
+type testNoFlushWriter struct {
+	http.ResponseWriter
+}
+
+func TestServeNoFlusher(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, _, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	res := httptest.NewRecorder()
+	s.ServeHTTPNamespaced("ns1", &testNoFlushWriter{ResponseWriter: res}, httptest.NewRequest(http.MethodGet, "/?ephemeral", nil))
+	assert.Equal(t, 500, res.Code)
+	assert.Regexp(t, "FF10494", res.Body.String())
+}
+
+type testFailWriter struct {
+	header http.Header
+}
+
+func (w *testFailWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *testFailWriter) Write([]byte) (int, error) {
+	return 0, fmt.Errorf("pop")
+}
+
+func (w *testFailWriter) WriteHeader(int) {}
+
+func (w *testFailWriter) Flush() {}
+
+func TestServeHandshakeFail(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, _, cancel := newTestSSE(t, cbs)
+	defer cancel()
+	s.auth = nil
+
+	cbs.On("ConnectionClosed", mock.Anything).Return(nil)
+
+	s.ServeHTTPNamespaced("ns1", &testFailWriter{header: http.Header{}}, httptest.NewRequest(http.MethodGet, "/?ephemeral", nil))
+	assert.Empty(t, s.connections)
+
+	cbs.AssertExpectations(t)
+}
+
+func TestConnectionWriteFail(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, _, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	cbs.On("ConnectionClosed", mock.Anything).Return(nil)
+
+	w := &testFailWriter{header: http.Header{}}
+	sc := newConnection(s.ctx, s, httptest.NewRequest(http.MethodGet, "/", nil), w, w, &core.WSStart{})
+	err := sc.writeEvent("", "", map[bool]bool{true: false})
+	assert.Error(t, err)
+	err = sc.writeEvent("", "1", newTestEvent(1))
+	assert.EqualError(t, err, "pop")
+	err = sc.writePing()
+	assert.EqualError(t, err, "pop")
+	sc.protocolError(fmt.Errorf("bad"))
+
+	done := make(chan struct{})
+	go func() {
+		sc.sendLoop(context.Background())
+		close(done)
+	}()
+	err = sc.send("1", newTestEvent(1))
+	assert.NoError(t, err)
+	<-done
+
+	err = sc.dispatch(newTestEvent(2))
+	assert.Regexp(t, "FF10498", err)
+
+	err = s.start(sc, &core.WSStart{Namespace: "ns2"})
+	assert.Regexp(t, "FF10187", err)
+
+	cbs.AssertExpectations(t)
+}
+
+func TestDispatchAutoAck(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, _, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	autoAck := true
+	res := httptest.NewRecorder()
+	sc := newConnection(s.ctx, s, httptest.NewRequest(http.MethodGet, "/", nil), res, res, &core.WSStart{AutoAck: &autoAck})
+	defer sc.cancelCtx()
+	go sc.sendLoop(context.Background())
+
+	event := newTestEvent(1)
+	cbs.On("DeliveryResponse", sc.connID, mock.MatchedBy(func(inflight *core.EventDeliveryResponse) bool {
+		return inflight.ID.Equals(event.ID)
+	})).Return()
+	cbs.On("ConnectionClosed", sc.connID).Return(nil).Maybe()
+
+	err := sc.dispatch(event)
+	assert.NoError(t, err)
+	assert.Empty(t, sc.inflight)
+
+	cbs.AssertExpectations(t)
+}
+
+func TestServeStartFail(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	_, svr, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	cbs.On("EphemeralSubscription", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	cbs.On("ConnectionClosed", mock.Anything).Return(nil)
+
+	stream := openStream(t, svr, "ephemeral", nil)
+	defer stream.cancel()
+	stream.readConnected(t)
+	frame := stream.readFrame(t)
+	var wsErr core.WSError
+	err := json.Unmarshal([]byte(frame["data"]), &wsErr)
+	assert.NoError(t, err)
+	assert.Equal(t, core.WSProtocolErrorEventType, wsErr.Type)
+	assert.Equal(t, "pop", wsErr.Error)
+}
+
+func TestPing(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, svr, cancel := newTestSSE(t, cbs)
+	defer cancel()
+	s.pingInterval = 1 * time.Millisecond
+
+	cbs.On("EphemeralSubscription", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil)
+	cbs.On("ConnectionClosed", mock.Anything).Return(nil).Maybe()
+
+	stream := openStream(t, svr, "ephemeral", nil)
+	defer stream.cancel()
+	stream.readConnected(t)
+	frame := stream.readFrame(t)
+	assert.Equal(t, "ping", frame[""])
+}
+
+func TestNamespaceRestarted(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, svr, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	cbs.On("EphemeralSubscription", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil).Once()
+	cbs.On("EphemeralSubscription", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(fmt.Errorf("pop")).Once()
+	closed := make(chan struct{})
+	cbs.On("ConnectionClosed", mock.Anything).Run(func(args mock.Arguments) {
+		close(closed)
+	}).Return(nil)
+
+	stream := openStream(t, svr, "ephemeral", nil)
+	defer stream.cancel()
+	stream.readConnected(t)
+
+	// Not restarted for another namespace, or an earlier start time
+	s.NamespaceRestarted("ns2", time.Now())
+	s.NamespaceRestarted("ns1", time.Now().Add(-1*time.Hour))
+	// Restart fails, which closes the connection
+	s.NamespaceRestarted("ns1", time.Now().Add(1*time.Hour))
+	<-closed
+
+	cbs.AssertExpectations(t)
+}
+
+func TestSetHandlerRemove(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, _, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	err := s.SetHandler("ns1", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, s.callbacks.handlers)
+}
+
+func TestSetHandlerConcurrentWithAck(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, _, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	cbs2 := &eventsmocks.Callbacks{}
+	cbs2.On("ConnectionClosed", mock.Anything).Return(nil)
+	cbs.On("ConnectionClosed", mock.Anything).Return(nil)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = s.SetHandler("ns2", cbs2)
+			_ = s.SetHandler("ns2", nil)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		s.ack("conn1", &core.EventDeliveryResponse{Subscription: core.SubscriptionRef{Namespace: "ns3"}})
+		s.connClosed("conn1")
+	}
+	<-done
+}
+
+func TestValidateOptions(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	s, _, cancel := newTestSSE(t, cbs)
+	defer cancel()
+
+	options := &core.SubscriptionOptions{}
+	err := s.ValidateOptions(context.Background(), options)
+	assert.NoError(t, err)
+	assert.False(t, *options.WithData)
+
+	withData := true
+	err = s.ValidateOptions(context.Background(), &core.SubscriptionOptions{
+		SubscriptionCoreOptions: core.SubscriptionCoreOptions{WithData: &withData},
+	})
+	assert.Regexp(t, "FF10244", err)
+}
@@ -22,6 +22,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/PaesslerAG/gval"
+	"github.com/PaesslerAG/jsonpath"
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
@@ -31,6 +33,7 @@ import (
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/data"
+	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/privatemessaging"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/pkg/core"
@@ -47,6 +50,16 @@ type subscription struct {
 	blockchainFilter   *blockchainFilter
 	transactionFilter  *transactionFilter
 	topicFilter        *regexp.Regexp
+	expressionFilter   gval.Evaluable
+}
+
+// expressionLanguage is the full gval language (arithmetic, logic, comparison and strings) extended with JSONPath
+var expressionLanguage = gval.Full(jsonpath.Language())
+
+// expressionInput is the JSON document an expression filter is evaluated against
+type expressionInput struct {
+	*core.EnrichedEvent
+	Data core.DataArray `json:"data,omitempty"`
 }
 
 type messageFilter struct {
@@ -79,11 +92,13 @@ type subscriptionManager struct {
 	database                  database.Plugin
 	data                      data.Manager
 	txHelper                  txcommon.Helper
+	metrics                   metrics.Manager
 	eventNotifier             *eventNotifier
 	broadcast                 broadcast.Manager
 	messaging                 privatemessaging.Manager
 	transports                map[string]events.Plugin
 	connections               map[string]*connection
+	replays                   map[string]chan *core.EventDeliveryResponse // responses to dead letter replays, by connection ID
 	mux                       sync.Mutex
 	maxSubs                   uint64
 	durableSubs               map[fftypes.UUID]*subscription
@@ -96,7 +111,7 @@ type subscriptionManager struct {
 	defaultBatchTimeout time.Duration
 }
 
-func newSubscriptionManager(ctx context.Context, ns *core.Namespace, enricher *eventEnricher, di database.Plugin, dm data.Manager, en *eventNotifier, bm broadcast.Manager, pm privatemessaging.Manager, txHelper txcommon.Helper, transports map[string]events.Plugin) (*subscriptionManager, error) {
+func newSubscriptionManager(ctx context.Context, ns *core.Namespace, enricher *eventEnricher, di database.Plugin, dm data.Manager, en *eventNotifier, bm broadcast.Manager, pm privatemessaging.Manager, txHelper txcommon.Helper, mm metrics.Manager, transports map[string]events.Plugin) (*subscriptionManager, error) {
 	ctx, cancelCtx := context.WithCancel(ctx)
 	sm := &subscriptionManager{
 		ctx:                       ctx,
@@ -106,6 +121,7 @@ func newSubscriptionManager(ctx context.Context, ns *core.Namespace, enricher *e
 		data:                      dm,
 		transports:                transports,
 		connections:               make(map[string]*connection),
+		replays:                   make(map[string]chan *core.EventDeliveryResponse),
 		durableSubs:               make(map[fftypes.UUID]*subscription),
 		newOrUpdatedSubscriptions: make(chan *fftypes.UUID),
 		deletedSubscriptions:      make(chan *fftypes.UUID),
@@ -115,6 +131,7 @@ func newSubscriptionManager(ctx context.Context, ns *core.Namespace, enricher *e
 		broadcast:                 bm, // optional
 		messaging:                 pm, // optional
 		txHelper:                  txHelper,
+		metrics:                   mm,
 		retry: retry.Retry{
 			InitialDelay: config.GetDuration(coreconfig.SubscriptionsRetryInitialDelay),
 			MaximumDelay: config.GetDuration(coreconfig.SubscriptionsRetryMaxDelay),
@@ -239,6 +256,7 @@ func (sm *subscriptionManager) closeDurableSubscriptionLocked(id *fftypes.UUID)
 
 func (sm *subscriptionManager) deletedDurableSubscription(id *fftypes.UUID) {
 	sm.mux.Lock()
+	sub := sm.durableSubs[*id]
 	loaded, dispatchers := sm.closeDurableSubscriptionLocked(id)
 	sm.mux.Unlock()
 
@@ -248,6 +266,10 @@ func (sm *subscriptionManager) deletedDurableSubscription(id *fftypes.UUID) {
 	for _, dispatcher := range dispatchers {
 		dispatcher.close()
 	}
+	// Only once the dispatchers are closed, so they cannot publish them again, remove the metrics
+	if loaded && sm.metrics != nil && sm.metrics.IsMetricsEnabled() {
+		sm.metrics.SubscriptionDeleted(sm.namespace.Name, sub.definition.Name)
+	}
 	// Delete the offsets, as the durable subscriptions are gone
 	err := sm.database.DeleteOffset(sm.ctx, core.OffsetTypeSubscription, id.String())
 	if err != nil {
@@ -255,6 +277,50 @@ func (sm *subscriptionManager) deletedDurableSubscription(id *fftypes.UUID) {
 	}
 }
 
+// rewindDurableSubscription closes any dispatchers on this node before writing the new offset, then touches
+// the subscription so every node reloads it, and starts dispatching from the new offset.
+// Only the dispatchers on this node are closed before the offset is written. A dispatcher on another node
+// runs until that node processes the update, and can commit its own offset over the new one in that time,
+// so the rewind is only reliable on a single node, or for a subscription that has been paused.
+func (sm *subscriptionManager) rewindDurableSubscription(ctx context.Context, subDef *core.Subscription, offset int64) (err error) {
+	sm.mux.Lock()
+	loaded, dispatchers := sm.closeDurableSubscriptionLocked(subDef.ID)
+	sm.mux.Unlock()
+
+	log.L(ctx).Infof("Rewinding subscription %s:%s [%s] to offset %d loaded=%t dispatchers=%d", subDef.Namespace, subDef.Name, subDef.ID, offset, loaded, len(dispatchers))
+	for _, dispatcher := range dispatchers {
+		dispatcher.close()
+	}
+
+	defer func() {
+		if err != nil {
+			// Make sure we carry on dispatching from wherever the offset was left
+			go sm.newOrUpdatedDurableSubscription(subDef.ID)
+		}
+	}()
+
+	existing, err := sm.database.GetOffset(ctx, core.OffsetTypeSubscription, subDef.ID.String())
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		err = sm.database.UpsertOffset(ctx, &core.Offset{
+			Type:    core.OffsetTypeSubscription,
+			Name:    subDef.ID.String(),
+			Current: offset,
+		}, false)
+	} else {
+		err = sm.database.UpdateOffset(ctx, existing.RowID, database.OffsetQueryFactory.NewUpdate(ctx).Set("current", offset))
+	}
+	if err != nil {
+		return err
+	}
+
+	// Touching the subscription emits the update event, which reloads it from the new offset on every node
+	update := database.SubscriptionQueryFactory.NewUpdate(ctx).Set("updated", fftypes.Now())
+	return sm.database.UpdateSubscription(ctx, subDef.Namespace, subDef.Name, update)
+}
+
 func (sm *subscriptionManager) getTransport(ctx context.Context, transportName string) (events.Plugin, error) {
 	transport, ok := sm.transports[transportName]
 	if !ok {
@@ -276,6 +342,14 @@ func (sm *subscriptionManager) parseSubscriptionDef(ctx context.Context, subDef
 		subDef.Options.TLSConfig = sm.namespace.TLSConfigs[subDef.Options.TLSConfigName]
 	}
 
+	// A subscription that should be signed is never delivered unsigned, if its secret is removed from the config
+	if subDef.Options.SigningSecretName != "" {
+		if sm.namespace.SigningSecrets[subDef.Options.SigningSecretName] == nil {
+			return nil, i18n.NewError(ctx, coremsgs.MsgNotFoundSigningSecret, subDef.Options.SigningSecretName, subDef.Namespace)
+		}
+		subDef.Options.SigningSecret = sm.namespace.SigningSecrets[subDef.Options.SigningSecretName]
+	}
+
 	// Defaults that only apply in batch mode
 	if subDef.Options.Batch != nil && *subDef.Options.Batch {
 		if subDef.Options.ReadAhead == nil || *subDef.Options.ReadAhead == 0 {
@@ -418,6 +492,13 @@ func (sm *subscriptionManager) parseSubscriptionDef(ctx context.Context, subDef
 		sub.transactionFilter = tf
 	}
 
+	if filter.Expression != "" {
+		sub.expressionFilter, err = expressionLanguage.NewEvaluable(filter.Expression)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, coremsgs.MsgExpressionCompileFailed, "filter.expression", filter.Expression)
+		}
+	}
+
 	return sub, err
 }
 
@@ -481,9 +562,13 @@ func (sm *subscriptionManager) matchSubToConnLocked(conn *connection, sub *subsc
 		log.L(sm.ctx).Warnf("Invalid connection/subscription registered: conn=%+v sub=%+v", conn, sub)
 		return
 	}
+	if sub.definition.Paused {
+		log.L(sm.ctx).Debugf("Subscription %s:%s [%s] is paused", sub.definition.Namespace, sub.definition.Name, sub.definition.ID)
+		return
+	}
 	if conn.transport == sub.definition.Transport && conn.matcher(sub.definition.SubscriptionRef) {
 		if _, ok := conn.dispatchers[*sub.definition.ID]; !ok {
-			dispatcher := newEventDispatcher(sm.ctx, sm.enricher, conn.ei, sm.database, sm.data, sm.broadcast, sm.messaging, conn.id, sub, sm.eventNotifier, sm.txHelper)
+			dispatcher := newEventDispatcher(sm.ctx, sm.enricher, conn.ei, sm.database, sm.data, sm.broadcast, sm.messaging, conn.id, sub, sm.eventNotifier, sm.txHelper, sm.metrics)
 			conn.dispatchers[*sub.definition.ID] = dispatcher
 			dispatcher.start()
 		}
@@ -520,7 +605,7 @@ func (sm *subscriptionManager) ephemeralSubscription(ei events.Plugin, connID, n
 	}
 
 	// Create the dispatcher, and start immediately
-	dispatcher := newEventDispatcher(sm.ctx, sm.enricher, ei, sm.database, sm.data, sm.broadcast, sm.messaging, connID, newSub, sm.eventNotifier, sm.txHelper)
+	dispatcher := newEventDispatcher(sm.ctx, sm.enricher, ei, sm.database, sm.data, sm.broadcast, sm.messaging, connID, newSub, sm.eventNotifier, sm.txHelper, sm.metrics)
 	dispatcher.start()
 
 	conn.dispatchers[*subID] = dispatcher
@@ -553,6 +638,14 @@ func (sm *subscriptionManager) connectionClosed(ei events.Plugin, connID string)
 
 func (sm *subscriptionManager) deliveryResponse(ei events.Plugin, connID string, inflight *core.EventDeliveryResponse) {
 	sm.mux.Lock()
+	if replay, ok := sm.replays[connID]; ok {
+		sm.mux.Unlock()
+		select {
+		case replay <- inflight:
+		default:
+		}
+		return
+	}
 	var dispatcher *eventDispatcher
 	conn, ok := sm.connections[connID]
 	if ok && inflight.Subscription.ID != nil {
@@ -574,6 +667,28 @@ func (sm *subscriptionManager) deliveryResponse(ei events.Plugin, connID string,
 	dispatcher.deliveryResponse(inflight)
 }
 
+// getDeliveryStatus returns the delivery counts of the dispatchers running on this node for a subscription,
+// or nil if the subscription is not currently being dispatched
+func (sm *subscriptionManager) getDeliveryStatus(id *fftypes.UUID) *core.SubscriptionStatus {
+	sm.mux.Lock()
+	var dispatchers []*eventDispatcher
+	for _, conn := range sm.connections {
+		if dispatcher, ok := conn.dispatchers[*id]; ok {
+			dispatchers = append(dispatchers, dispatcher)
+		}
+	}
+	sm.mux.Unlock()
+
+	if len(dispatchers) == 0 {
+		return nil
+	}
+	status := &core.SubscriptionStatus{}
+	for _, dispatcher := range dispatchers {
+		dispatcher.addDeliveryStatus(status)
+	}
+	return status
+}
+
 func (sub *subscription) MatchesEvent(event *core.EnrichedEvent) bool {
 	if sub.eventMatcher != nil && !sub.eventMatcher.MatchString(string(event.Type)) {
 		return false
@@ -645,3 +760,24 @@ func (sub *subscription) MatchesEvent(event *core.EnrichedEvent) bool {
 	}
 	return true
 }
+
+// HasExpression returns true if the subscription has an expression filter, which must be checked with MatchesExpression
+func (sub *subscription) HasExpression() bool {
+	return sub.expressionFilter != nil
+}
+
+// MatchesExpression evaluates the expression filter against the document built for the event by the
+// enricher. An expression that fails to evaluate, for example because it references a field that is
+// not set on the event, does not match.
+func (sub *subscription) MatchesExpression(ctx context.Context, eventID *fftypes.UUID, doc interface{}) bool {
+	if sub.expressionFilter == nil {
+		return true
+	}
+
+	matches, err := sub.expressionFilter.EvalBool(ctx, doc)
+	if err == nil {
+		return matches
+	}
+	log.L(ctx).Debugf("Expression filter did not match event %s: %s", eventID, err)
+	return false
+}
@@ -34,9 +34,11 @@ import (
 	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/mocks/datamocks"
 	"github.com/hyperledger/firefly/mocks/eventsmocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
 	"github.com/hyperledger/firefly/mocks/operationmocks"
 	"github.com/hyperledger/firefly/mocks/privatemessagingmocks"
 	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/hyperledger/firefly/pkg/events"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -47,6 +49,7 @@ func newTestSubManager(t *testing.T, mei *eventsmocks.Plugin) (*subscriptionMana
 	config.Set(coreconfig.EventTransportsEnabled, []string{})
 
 	mdi := &databasemocks.Plugin{}
+	mdi.On("Capabilities").Return(&database.Capabilities{}).Maybe()
 	mdm := &datamocks.Manager{}
 	mbm := &broadcastmocks.Manager{}
 	mpm := &privatemessagingmocks.Manager{}
@@ -55,7 +58,7 @@ func newTestSubManager(t *testing.T, mei *eventsmocks.Plugin) (*subscriptionMana
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
-	enricher := newEventEnricher("ns1", mdi, mdm, mom, txHelper)
+	enricher := newEventEnricher("ns1", mdi, mdm, mom, txHelper, cache.NewUmanagedCache(ctx, 100, 5*time.Minute))
 
 	ctx, cancel := context.WithCancel(context.Background())
 	mei.On("Name").Return("ut")
@@ -64,7 +67,9 @@ func newTestSubManager(t *testing.T, mei *eventsmocks.Plugin) (*subscriptionMana
 	mei.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	mdi.On("GetEvents", mock.Anything, mock.Anything, mock.Anything).Return([]*core.Event{}, nil, nil).Maybe()
 	mdi.On("GetOffset", mock.Anything, mock.Anything, mock.Anything).Return(&core.Offset{RowID: 3333333, Current: 0}, nil).Maybe()
-	sm, err := newSubscriptionManager(ctx, &core.Namespace{Name: "ns1"}, enricher, mdi, mdm, newEventNotifier(ctx, "ut"), mbm, mpm, txHelper, nil)
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(false).Maybe()
+	sm, err := newSubscriptionManager(ctx, &core.Namespace{Name: "ns1"}, enricher, mdi, mdm, newEventNotifier(ctx, "ut"), mbm, mpm, txHelper, mmi, nil)
 	assert.NoError(t, err)
 	sm.transports = map[string]events.Plugin{
 		"ut": mei,
@@ -325,6 +330,69 @@ func TestCreateSubscriptionBadTopicFilter(t *testing.T) {
 	assert.Regexp(t, "FF10171.*topic", err)
 }
 
+func TestCreateSubscriptionBadExpressionFilter(t *testing.T) {
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	defer cancel()
+	mei.On("ValidateOptions", mock.Anything, mock.Anything).Return(nil)
+	_, err := sm.parseSubscriptionDef(sm.ctx, &core.Subscription{
+		Filter: core.SubscriptionFilter{
+			Expression: "$.topic ==",
+		},
+		Transport: "ut",
+	})
+	assert.Regexp(t, "FF10511.*expression", err)
+}
+
+func TestCreateSubscriptionExpressionFilter(t *testing.T) {
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	defer cancel()
+	mei.On("ValidateOptions", mock.Anything, mock.Anything).Return(nil)
+	sub, err := sm.parseSubscriptionDef(sm.ctx, &core.Subscription{
+		Filter: core.SubscriptionFilter{
+			Expression: `$.topic == "topic1"`,
+		},
+		Transport: "ut",
+	})
+	assert.NoError(t, err)
+	assert.True(t, sub.HasExpression())
+	assert.True(t, sub.MatchesExpression(sm.ctx, nil, testExpressionDocument(t, &core.EnrichedEvent{Event: core.Event{Topic: "topic1"}}, nil)))
+	assert.False(t, sub.MatchesExpression(sm.ctx, nil, testExpressionDocument(t, &core.EnrichedEvent{Event: core.Event{Topic: "topic2"}}, nil)))
+}
+
+func testExpressionDocument(t *testing.T, event *core.EnrichedEvent, data core.DataArray) interface{} {
+	doc, err := newTestEventEnricher().expressionDocument(event, data)
+	assert.NoError(t, err)
+	return doc
+}
+
+func TestMatchesExpressionNoExpression(t *testing.T) {
+	sub := &subscription{}
+	assert.False(t, sub.HasExpression())
+	assert.True(t, sub.MatchesExpression(context.Background(), nil, nil))
+}
+
+func TestMatchesExpressionEvalFail(t *testing.T) {
+	sub := &subscription{}
+	sub.expressionFilter, _ = expressionLanguage.NewEvaluable(`$.message.header.tag == "order"`)
+	// No message on the event, so the path cannot be resolved
+	assert.False(t, sub.MatchesExpression(context.Background(), nil, testExpressionDocument(t, &core.EnrichedEvent{}, nil)))
+}
+
+func TestMatchesExpressionNotBool(t *testing.T) {
+	sub := &subscription{}
+	sub.expressionFilter, _ = expressionLanguage.NewEvaluable(`$.topic`)
+	assert.False(t, sub.MatchesExpression(context.Background(), nil, testExpressionDocument(t, &core.EnrichedEvent{Event: core.Event{Topic: "topic1"}}, nil)))
+}
+
+func TestMatchesExpressionData(t *testing.T) {
+	sub := &subscription{}
+	sub.expressionFilter, _ = expressionLanguage.NewEvaluable(`$.data[0].value.amount > 100`)
+	data := core.DataArray{{Value: fftypes.JSONAnyPtr(`{"amount": 150}`)}}
+	assert.True(t, sub.MatchesExpression(context.Background(), nil, testExpressionDocument(t, &core.EnrichedEvent{}, data)))
+}
+
 func TestCreateSubscriptionBadGroupFilter(t *testing.T) {
 	mei := &eventsmocks.Plugin{}
 	sm, cancel := newTestSubManager(t, mei)
@@ -551,6 +619,49 @@ func TestCreateSubscriptionSuccessTLSConfig(t *testing.T) {
 	assert.NotNil(t, sub.definition.Options.TLSConfig)
 }
 
+func TestCreateSubscriptionSuccessSigningSecret(t *testing.T) {
+	coreconfig.Reset()
+
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	defer cancel()
+
+	sm.namespace.SigningSecrets = map[string][]byte{
+		"mysecret": []byte("shhh"),
+	}
+
+	mei.On("ValidateOptions", mock.Anything, mock.Anything).Return(nil)
+	sub, err := sm.parseSubscriptionDef(sm.ctx, &core.Subscription{
+		Options: core.SubscriptionOptions{
+			WebhookSubOptions: core.WebhookSubOptions{
+				SigningSecretName: "mysecret",
+			},
+		},
+		Transport: "ut",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []byte("shhh"), sub.definition.Options.SigningSecret)
+}
+
+func TestCreateSubscriptionSigningSecretNotFound(t *testing.T) {
+	coreconfig.Reset()
+
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	defer cancel()
+
+	_, err := sm.parseSubscriptionDef(sm.ctx, &core.Subscription{
+		Options: core.SubscriptionOptions{
+			WebhookSubOptions: core.WebhookSubOptions{
+				SigningSecretName: "mysecret",
+			},
+		},
+		Transport: "ut",
+	})
+	assert.Regexp(t, "FF10501", err)
+}
+
 func TestCreateSubscriptionSuccessBatch(t *testing.T) {
 	coreconfig.Reset()
 
@@ -907,3 +1018,136 @@ func TestDeleteDurableSubscriptionOk(t *testing.T) {
 	assert.Empty(t, sm.durableSubs)
 	<-ed.closed
 }
+
+func TestDeleteDurableSubscriptionMetrics(t *testing.T) {
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	defer cancel()
+	mdi := sm.database.(*databasemocks.Plugin)
+	mmi := &metricsmocks.Manager{}
+	sm.metrics = mmi
+
+	subID := fftypes.NewUUID()
+	sm.durableSubs[*subID] = &subscription{
+		definition: &core.Subscription{
+			SubscriptionRef: core.SubscriptionRef{ID: subID, Namespace: "ns1", Name: "sub1"},
+		},
+	}
+
+	mmi.On("IsMetricsEnabled").Return(true)
+	mmi.On("SubscriptionDeleted", "ns1", "sub1").Return()
+	mdi.On("DeleteOffset", mock.Anything, fftypes.FFEnum("subscription"), subID.String()).Return(nil)
+	sm.deletedDurableSubscription(subID)
+
+	mmi.AssertExpectations(t)
+}
+
+func TestMatchSubToConnPaused(t *testing.T) {
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	defer cancel()
+
+	conn := &connection{
+		ei:          mei,
+		id:          "conn1",
+		transport:   "ut",
+		matcher:     func(sr core.SubscriptionRef) bool { return true },
+		dispatchers: map[fftypes.UUID]*eventDispatcher{},
+	}
+	sm.matchSubToConnLocked(conn, &subscription{
+		definition: &core.Subscription{
+			SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Namespace: "ns1", Name: "sub1"},
+			Transport:       "ut",
+			Paused:          true,
+		},
+	})
+	assert.Empty(t, conn.dispatchers)
+}
+
+func newTestRewindSubManager(t *testing.T) (*subscriptionManager, *core.Subscription, *databasemocks.Plugin, func()) {
+	subDef := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID:        fftypes.NewUUID(),
+			Namespace: "ns1",
+			Name:      "sub1",
+		},
+		Transport: "ut",
+	}
+	sub := &subscription{
+		definition: subDef,
+	}
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	mdi := &databasemocks.Plugin{}
+	sm.database = mdi
+	ed, edCancel := newTestEventDispatcher(sub)
+	ed.database = mdi
+	sm.durableSubs[*subDef.ID] = sub
+	sm.connections["conn1"] = &connection{
+		ei:          mei,
+		id:          "conn1",
+		transport:   "ut",
+		matcher:     func(sr core.SubscriptionRef) bool { return true },
+		dispatchers: map[fftypes.UUID]*eventDispatcher{*subDef.ID: ed},
+	}
+	close(ed.closed)
+	return sm, subDef, mdi, func() {
+		edCancel()
+		cancel()
+	}
+}
+
+func TestRewindDurableSubscriptionExistingOffset(t *testing.T) {
+	sm, subDef, mdi, cancel := newTestRewindSubManager(t)
+	defer cancel()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, subDef.ID.String()).Return(&core.Offset{RowID: 12345, Current: 100}, nil)
+	mdi.On("UpdateOffset", mock.Anything, int64(12345), mock.Anything).Return(nil)
+	mdi.On("UpdateSubscription", mock.Anything, "ns1", "sub1", mock.Anything).Return(nil)
+
+	err := sm.rewindDurableSubscription(context.Background(), subDef, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, sm.connections["conn1"].dispatchers)
+	assert.Empty(t, sm.durableSubs)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestRewindDurableSubscriptionNewOffset(t *testing.T) {
+	sm, subDef, mdi, cancel := newTestRewindSubManager(t)
+	defer cancel()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, subDef.ID.String()).Return(nil, nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.MatchedBy(func(o *core.Offset) bool {
+		return o.Current == -1 && o.Name == subDef.ID.String()
+	}), false).Return(nil)
+	mdi.On("UpdateSubscription", mock.Anything, "ns1", "sub1", mock.Anything).Return(nil)
+
+	err := sm.rewindDurableSubscription(context.Background(), subDef, -1)
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestRewindDurableSubscriptionGetOffsetFail(t *testing.T) {
+	sm, subDef, mdi, cancel := newTestRewindSubManager(t)
+	defer cancel()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, subDef.ID.String()).Return(nil, fmt.Errorf("pop"))
+	mdi.On("GetSubscriptionByID", mock.Anything, "ns1", subDef.ID).Return(nil, nil).Maybe()
+
+	err := sm.rewindDurableSubscription(context.Background(), subDef, 10)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRewindDurableSubscriptionUpdateOffsetFail(t *testing.T) {
+	sm, subDef, mdi, cancel := newTestRewindSubManager(t)
+	defer cancel()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, subDef.ID.String()).Return(&core.Offset{RowID: 12345, Current: 100}, nil)
+	mdi.On("UpdateOffset", mock.Anything, int64(12345), mock.Anything).Return(fmt.Errorf("pop"))
+	mdi.On("GetSubscriptionByID", mock.Anything, "ns1", subDef.ID).Return(nil, nil).Maybe()
+
+	err := sm.rewindDurableSubscription(context.Background(), subDef, 10)
+	assert.EqualError(t, err, "pop")
+}
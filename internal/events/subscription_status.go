@@ -0,0 +1,99 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// Metrics are only published for durable subscriptions, as ephemeral subscriptions
+// would create a new set of labels for every connection
+func (ed *eventDispatcher) metricsEnabled() bool {
+	return ed.metrics != nil && !ed.subscription.definition.Ephemeral && ed.metrics.IsMetricsEnabled()
+}
+
+func (ed *eventDispatcher) recordDelivered(count int) {
+	ed.mux.Lock()
+	ed.stats.delivered += int64(count)
+	ed.stats.lastDelivery = fftypes.Now()
+	ed.mux.Unlock()
+
+	if ed.metricsEnabled() {
+		ed.metrics.SubscriptionDelivered(ed.namespace, ed.subscription.definition.Name, count)
+	}
+}
+
+func (ed *eventDispatcher) recordResponse(isNack bool) {
+	ed.mux.Lock()
+	if isNack {
+		ed.stats.rejected++
+	} else {
+		ed.stats.acked++
+	}
+	ed.mux.Unlock()
+
+	if ed.metricsEnabled() {
+		if isNack {
+			ed.metrics.SubscriptionRejected(ed.namespace, ed.subscription.definition.Name)
+		} else {
+			ed.metrics.SubscriptionAcked(ed.namespace, ed.subscription.definition.Name)
+		}
+	}
+}
+
+// getLag returns how many events the subscription is behind the latest event known to this node,
+// which is the latest event we have been notified of, or read from the database.
+// It is only approximate, as the event sequence is shared by all namespaces, and the difference
+// also counts the events the subscription does not match.
+func (ed *eventDispatcher) getLag() int64 {
+	head := ed.eventPoller.eventNotifier.getLatestSequence()
+	ed.mux.Lock()
+	if ed.stats.highestSequence > head {
+		head = ed.stats.highestSequence
+	}
+	ed.mux.Unlock()
+	lag := head - ed.eventPoller.getPollingOffset()
+	if lag < 0 {
+		return 0
+	}
+	return lag
+}
+
+// publishStatus updates the gauges for the subscription, as events are dispatched and acknowledged
+func (ed *eventDispatcher) publishStatus() {
+	if ed.metricsEnabled() {
+		ed.mux.Lock()
+		inflight := len(ed.inflight)
+		ed.mux.Unlock()
+		ed.metrics.SubscriptionInflight(ed.namespace, ed.subscription.definition.Name, inflight)
+		ed.metrics.SubscriptionLag(ed.namespace, ed.subscription.definition.Name, ed.getLag())
+	}
+}
+
+// addDeliveryStatus adds the counts of this dispatcher to the status of the subscription
+func (ed *eventDispatcher) addDeliveryStatus(status *core.SubscriptionStatus) {
+	ed.mux.Lock()
+	defer ed.mux.Unlock()
+	status.Delivered += ed.stats.delivered
+	status.Acked += ed.stats.acked
+	status.Rejected += ed.stats.rejected
+	status.Inflight += int64(len(ed.inflight))
+	if ed.stats.lastDelivery != nil && (status.LastDelivery == nil || ed.stats.lastDelivery.Time().After(*status.LastDelivery.Time())) {
+		status.LastDelivery = ed.stats.lastDelivery
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/eventsmocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestDurableSubscription() *subscription {
+	return &subscription{
+		definition: &core.Subscription{
+			SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Namespace: "ns1", Name: "sub1"},
+		},
+	}
+}
+
+func TestDeliveryStatsMetricsEnabled(t *testing.T) {
+	ed, cancel := newTestEventDispatcher(newTestDurableSubscription())
+	defer cancel()
+
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(true)
+	mmi.On("SubscriptionDelivered", "ns1", "sub1", 2).Return()
+	mmi.On("SubscriptionAcked", "ns1", "sub1").Return()
+	mmi.On("SubscriptionRejected", "ns1", "sub1").Return()
+	mmi.On("SubscriptionInflight", "ns1", "sub1", 1).Return()
+	mmi.On("SubscriptionLag", "ns1", "sub1", int64(5)).Return()
+	ed.metrics = mmi
+
+	ed.stats.highestSequence = 15
+	ed.eventPoller.pollingOffset = 10
+	ed.inflight[*fftypes.NewUUID()] = &core.Event{}
+
+	ed.recordDelivered(2)
+	ed.recordResponse(false)
+	ed.recordResponse(true)
+	ed.publishStatus()
+
+	status := &core.SubscriptionStatus{}
+	ed.addDeliveryStatus(status)
+	assert.Equal(t, int64(2), status.Delivered)
+	assert.Equal(t, int64(1), status.Acked)
+	assert.Equal(t, int64(1), status.Rejected)
+	assert.Equal(t, int64(1), status.Inflight)
+	assert.NotNil(t, status.LastDelivery)
+
+	mmi.AssertExpectations(t)
+}
+
+func TestDeliveryStatsEphemeralNoMetrics(t *testing.T) {
+	sub := newTestDurableSubscription()
+	sub.definition.Ephemeral = true
+	ed, cancel := newTestEventDispatcher(sub)
+	defer cancel()
+
+	mmi := &metricsmocks.Manager{}
+	ed.metrics = mmi
+
+	ed.recordDelivered(1)
+	ed.recordResponse(false)
+	ed.publishStatus()
+
+	assert.Equal(t, int64(1), ed.stats.delivered)
+	assert.Equal(t, int64(1), ed.stats.acked)
+	mmi.AssertExpectations(t)
+}
+
+func TestDeliveryStatsLagNotNegative(t *testing.T) {
+	ed, cancel := newTestEventDispatcher(newTestDurableSubscription())
+	defer cancel()
+
+	ed.eventPoller.pollingOffset = 10
+	assert.Equal(t, int64(0), ed.getLag())
+}
+
+func TestAddDeliveryStatusLatestDelivery(t *testing.T) {
+	ed, cancel := newTestEventDispatcher(newTestDurableSubscription())
+	defer cancel()
+
+	earlier := fftypes.FFTime(fftypes.Now().Time().Add(-time.Second))
+	status := &core.SubscriptionStatus{LastDelivery: &earlier, Delivered: 5}
+	ed.recordDelivered(1)
+	ed.addDeliveryStatus(status)
+	assert.Equal(t, int64(6), status.Delivered)
+	assert.Equal(t, ed.stats.lastDelivery, status.LastDelivery)
+
+	later := fftypes.FFTime(ed.stats.lastDelivery.Time().Add(time.Second))
+	status.LastDelivery = &later
+	ed.addDeliveryStatus(status)
+	assert.Equal(t, &later, status.LastDelivery)
+}
+
+func TestGetDeliveryStatus(t *testing.T) {
+	sm, cancel := newTestSubManager(t, &eventsmocks.Plugin{})
+	defer cancel()
+
+	sub := newTestDurableSubscription()
+	assert.Nil(t, sm.getDeliveryStatus(sub.definition.ID))
+
+	ed, edCancel := newTestEventDispatcher(sub)
+	defer edCancel()
+	ed.recordDelivered(3)
+	sm.connections["conn1"] = &connection{
+		id:          "conn1",
+		dispatchers: map[fftypes.UUID]*eventDispatcher{*sub.definition.ID: ed},
+	}
+	sm.connections["conn2"] = &connection{
+		id:          "conn2",
+		dispatchers: map[fftypes.UUID]*eventDispatcher{*sub.definition.ID: ed},
+	}
+
+	status := sm.getDeliveryStatus(sub.definition.ID)
+	assert.Equal(t, int64(6), status.Delivered)
+}
+
+func TestDeliveredRecordedBeforeSynchronousResponse(t *testing.T) {
+	ed, cancel := newTestEventDispatcher(newTestDurableSubscription())
+	defer cancel()
+	go ed.deliverEvents()
+
+	event := &core.EventDelivery{
+		EnrichedEvent: core.EnrichedEvent{Event: core.Event{ID: fftypes.NewUUID(), Sequence: 10}},
+	}
+	ed.inflight[*event.ID] = &event.Event
+	ed.acksNacks = make(chan ackNack, 1)
+
+	// Transports such as Kafka and AMQP respond before DeliveryRequest returns
+	mei := ed.transport.(*eventsmocks.Plugin)
+	mei.On("DeliveryRequest", mock.Anything, mock.Anything, mock.Anything, event, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		ed.mux.Lock()
+		assert.Equal(t, int64(1), ed.stats.delivered)
+		ed.mux.Unlock()
+		ed.deliveryResponse(&core.EventDeliveryResponse{ID: event.ID})
+	})
+
+	ed.eventDelivery <- []*core.EventDelivery{event}
+	an := <-ed.acksNacks
+	assert.False(t, an.isNack)
+
+	status := &core.SubscriptionStatus{}
+	ed.addDeliveryStatus(status)
+	assert.Equal(t, int64(1), status.Delivered)
+	assert.Equal(t, int64(1), status.Acked)
+}
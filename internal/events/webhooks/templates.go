@@ -0,0 +1,130 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+const (
+	templateBody         = "body"
+	templatePath         = "path"
+	templateHeaderPrefix = "headers."
+)
+
+var templateFuncs = template.FuncMap{
+	// json renders any value as JSON, so templates can safely build a JSON body
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// templateInput is the JSON form of the event the templates are executed against, with the message data available as "data"
+type templateInput struct {
+	*core.EventDelivery
+	Data core.DataArray `json:"data,omitempty"`
+}
+
+// compileTemplates parses the body, path and header templates into a single set of named templates.
+// A batch is sent as a single request, so only the body can be templated for each of its events.
+func compileTemplates(ctx context.Context, options *core.WebhookTemplateOptions, batch bool) (*template.Template, error) {
+	if options.Body == "" && options.Path == "" && len(options.Headers) == 0 {
+		return nil, nil
+	}
+	if batch && (options.Path != "" || len(options.Headers) > 0) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgWebhookTemplateBatchNotSupported)
+	}
+	// A reference to a field that is not set on the event fails, rather than rendering "<no value>"
+	tmpl := template.New("webhook").Funcs(templateFuncs).Option("missingkey=error")
+	parse := func(name, text string) error {
+		if text == "" {
+			return nil
+		}
+		if _, err := tmpl.New(name).Parse(text); err != nil {
+			return i18n.NewError(ctx, coremsgs.MsgWebhookTemplateInvalid, name, err)
+		}
+		return nil
+	}
+	if err := parse(templateBody, options.Body); err != nil {
+		return nil, err
+	}
+	if err := parse(templatePath, options.Path); err != nil {
+		return nil, err
+	}
+	for h, text := range options.Headers {
+		if err := parse(templateHeaderPrefix+h, text); err != nil {
+			return nil, err
+		}
+	}
+	return tmpl, nil
+}
+
+func executeTemplate(ctx context.Context, tmpl *template.Template, name string, doc interface{}) (string, bool, error) {
+	t := tmpl.Lookup(name)
+	if t == nil {
+		return "", false, nil
+	}
+	buf := &strings.Builder{}
+	if err := t.Execute(buf, doc); err != nil {
+		return "", false, i18n.NewError(ctx, coremsgs.MsgWebhookTemplateFailed, name, err)
+	}
+	return buf.String(), true, nil
+}
+
+// executeTemplates renders the templates of the subscription for an event, and stores the output in the payload
+func (p *whPayload) executeTemplates(ctx context.Context, options *core.WebhookSubOptions, event *core.CombinedEventDataDelivery) error {
+	b, err := json.Marshal(&templateInput{
+		EventDelivery: event.Event,
+		Data:          event.Data,
+	})
+	if err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgSerializationFailed)
+	}
+	var doc interface{}
+	_ = json.Unmarshal(b, &doc) // we know we can parse what we just serialized
+
+	tmpl := options.CompiledTemplate
+	body, ok, err := executeTemplate(ctx, tmpl, templateBody, doc)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if !json.Valid([]byte(body)) {
+			return i18n.NewError(ctx, coremsgs.MsgWebhookTemplateBodyNotJSON)
+		}
+		p.body = body
+		p.templatedBody = true
+	}
+	if p.templatePath, _, err = executeTemplate(ctx, tmpl, templatePath, doc); err != nil {
+		return err
+	}
+	p.templateHeaders = make(map[string]string, len(options.Template.Headers))
+	for h := range options.Template.Headers {
+		if p.templateHeaders[h], _, err = executeTemplate(ctx, tmpl, templateHeaderPrefix+h, doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
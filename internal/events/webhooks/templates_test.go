@@ -0,0 +1,371 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/eventsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestTemplateEvent(sub *core.Subscription, tag, orderID string) *core.CombinedEventDataDelivery {
+	return &core.CombinedEventDataDelivery{
+		Event: &core.EventDelivery{
+			EnrichedEvent: core.EnrichedEvent{
+				Event: core.Event{
+					ID: fftypes.NewUUID(),
+				},
+				Message: &core.Message{
+					Header: core.MessageHeader{
+						ID:  fftypes.NewUUID(),
+						Tag: tag,
+					},
+				},
+			},
+			Subscription: core.SubscriptionRef{
+				ID:        sub.ID,
+				Namespace: "ns1",
+			},
+		},
+		Data: core.DataArray{
+			{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(fmt.Sprintf(`{"id": "%s"}`, orderID))},
+		},
+	}
+}
+
+func TestValidateOptionsNoTemplates(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	opts := &core.SubscriptionOptions{}
+	opts.TransportOptions()["url"] = "/somepath"
+	err := wh.ValidateOptions(wh.ctx, opts)
+	assert.NoError(t, err)
+	assert.Nil(t, opts.CompiledTemplate)
+}
+
+func TestValidateOptionsTemplates(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	opts := &core.SubscriptionOptions{}
+	opts.TransportOptions()["url"] = "/somepath"
+	opts.Template = core.WebhookTemplateOptions{
+		Body:    `{"tag": {{ json .message.header.tag }}}`,
+		Headers: map[string]string{"X-Tag": "{{ .message.header.tag }}"},
+		Path:    "{{ .topic }}",
+	}
+	err := wh.ValidateOptions(wh.ctx, opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, opts.CompiledTemplate.Lookup(templateBody))
+	assert.NotNil(t, opts.CompiledTemplate.Lookup(templatePath))
+	assert.NotNil(t, opts.CompiledTemplate.Lookup(templateHeaderPrefix+"X-Tag"))
+}
+
+func TestValidateOptionsBadBodyTemplate(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	opts := &core.SubscriptionOptions{}
+	opts.TransportOptions()["url"] = "/somepath"
+	opts.Template.Body = "{{ .message"
+	err := wh.ValidateOptions(wh.ctx, opts)
+	assert.Regexp(t, "FF10512.*body", err)
+}
+
+func TestValidateOptionsBadPathTemplate(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	opts := &core.SubscriptionOptions{}
+	opts.TransportOptions()["url"] = "/somepath"
+	opts.Template.Path = "{{ .topic"
+	err := wh.ValidateOptions(wh.ctx, opts)
+	assert.Regexp(t, "FF10512.*path", err)
+}
+
+func TestValidateOptionsBadHeaderTemplate(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	opts := &core.SubscriptionOptions{}
+	opts.TransportOptions()["url"] = "/somepath"
+	opts.Template.Headers = map[string]string{"X-Tag": "{{ .tag"}
+	err := wh.ValidateOptions(wh.ctx, opts)
+	assert.Regexp(t, "FF10512.*headers.X-Tag", err)
+}
+
+func TestValidateOptionsBatchHeaderTemplate(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	batch := true
+	opts := &core.SubscriptionOptions{}
+	opts.Batch = &batch
+	opts.TransportOptions()["url"] = "/somepath"
+	opts.Template.Headers = map[string]string{"X-Tag": "{{ .message.header.tag }}"}
+	err := wh.ValidateOptions(wh.ctx, opts)
+	assert.Regexp(t, "FF10548", err)
+}
+
+func TestValidateOptionsBatchPathTemplate(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	batch := true
+	opts := &core.SubscriptionOptions{}
+	opts.Batch = &batch
+	opts.TransportOptions()["url"] = "/somepath"
+	opts.Template.Path = "{{ .topic }}"
+	err := wh.ValidateOptions(wh.ctx, opts)
+	assert.Regexp(t, "FF10548", err)
+}
+
+func TestRequestWithTemplates(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	called := false
+	r := mux.NewRouter()
+	r.HandleFunc("/myapi/orders/{orderId}", func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "order1", mux.Vars(req)["orderId"])
+		assert.Equal(t, "neworder", req.Header.Get("X-Order-Tag"))
+		var body fftypes.JSONObject
+		err := json.NewDecoder(req.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, "order1", body.GetString("orderId"))
+		assert.Equal(t, "neworder", body.GetString("kind"))
+		res.WriteHeader(200)
+		called = true
+	}).Methods(http.MethodPost)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			Namespace: "ns1",
+		},
+	}
+	sub.Options.TransportOptions()["url"] = fmt.Sprintf("http://%s/myapi", server.Listener.Addr())
+	sub.Options.Template = core.WebhookTemplateOptions{
+		Body:    `{"orderId": {{ json (index .data 0).value.id }}, "kind": {{ json .message.header.tag }}}`,
+		Headers: map[string]string{"X-Order-Tag": "{{ .message.header.tag }}"},
+		Path:    "orders/{{ (index .data 0).value.id }}",
+	}
+	err := wh.ValidateOptions(wh.ctx, &sub.Options)
+	assert.NoError(t, err)
+	event := newTestTemplateEvent(sub, "neworder", "order1")
+
+	mcb := wh.callbacks.handlers["ns1"].(*eventsmocks.Callbacks)
+	mcb.On("DeliveryResponse", mock.Anything, mock.MatchedBy(func(response *core.EventDeliveryResponse) bool {
+		return !response.Rejected && !response.DeadLetter
+	})).Return(nil)
+
+	err = wh.DeliveryRequest(wh.ctx, mock.Anything, sub, event.Event, event.Data)
+	assert.NoError(t, err)
+	assert.True(t, called)
+
+	mcb.AssertExpectations(t)
+}
+
+func TestRequestWithTemplatesBatch(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	called := false
+	r := mux.NewRouter()
+	r.HandleFunc("/myapi", func(res http.ResponseWriter, req *http.Request) {
+		b, err := io.ReadAll(req.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{"orderId": "order1"}, {"orderId": "order2"}]`, string(b))
+		res.WriteHeader(200)
+		called = true
+	}).Methods(http.MethodPost)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			Namespace: "ns1",
+		},
+	}
+	sub.Options.TransportOptions()["url"] = fmt.Sprintf("http://%s/myapi", server.Listener.Addr())
+	batch := true
+	sub.Options.Batch = &batch
+	sub.Options.Template = core.WebhookTemplateOptions{
+		Body: `{"orderId": {{ json (index .data 0).value.id }}}`,
+	}
+	err := wh.ValidateOptions(wh.ctx, &sub.Options)
+	assert.NoError(t, err)
+
+	mcb := wh.callbacks.handlers["ns1"].(*eventsmocks.Callbacks)
+	mcb.On("DeliveryResponse", mock.Anything, mock.MatchedBy(func(response *core.EventDeliveryResponse) bool {
+		return !response.Rejected && !response.DeadLetter
+	})).Return(nil).Twice()
+
+	err = wh.BatchDeliveryRequest(wh.ctx, mock.Anything, sub, []*core.CombinedEventDataDelivery{
+		newTestTemplateEvent(sub, "neworder", "order1"),
+		newTestTemplateEvent(sub, "neworder", "order2"),
+	})
+	assert.NoError(t, err)
+	assert.True(t, called)
+
+	mcb.AssertExpectations(t)
+}
+
+func TestRequestWithTemplatesBatchFail(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			Namespace: "ns1",
+		},
+	}
+	sub.Options.TransportOptions()["url"] = "http://localhost:12345/myapi"
+	sub.Options.DeadLetterPolicy = core.WebhookDeadLetterPolicyDeadLetter
+	sub.Options.Template.Body = "{{ index .data 5 }}"
+	err := wh.ValidateOptions(wh.ctx, &sub.Options)
+	assert.NoError(t, err)
+
+	mcb := wh.callbacks.handlers["ns1"].(*eventsmocks.Callbacks)
+	mcb.On("DeliveryResponse", mock.Anything, mock.MatchedBy(func(response *core.EventDeliveryResponse) bool {
+		return response.DeadLetter
+	})).Return(nil)
+
+	err = wh.BatchDeliveryRequest(wh.ctx, mock.Anything, sub, []*core.CombinedEventDataDelivery{
+		newTestTemplateEvent(sub, "neworder", "order1"),
+	})
+	assert.NoError(t, err)
+
+	mcb.AssertExpectations(t)
+}
+
+func TestRequestTemplateBodyFail(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			Namespace: "ns1",
+		},
+	}
+	sub.Options.TransportOptions()["url"] = "http://localhost:12345/myapi"
+	sub.Options.DeadLetterPolicy = core.WebhookDeadLetterPolicyDeadLetter
+	sub.Options.Template.Body = "{{ index .data 5 }}"
+	err := wh.ValidateOptions(wh.ctx, &sub.Options)
+	assert.NoError(t, err)
+	event := newTestTemplateEvent(sub, "neworder", "order1")
+
+	mcb := wh.callbacks.handlers["ns1"].(*eventsmocks.Callbacks)
+	mcb.On("DeliveryResponse", mock.Anything, mock.MatchedBy(func(response *core.EventDeliveryResponse) bool {
+		return response.DeadLetter && assert.Regexp(t, "FF10513.*body", response.Info)
+	})).Return(nil)
+
+	err = wh.DeliveryRequest(wh.ctx, mock.Anything, sub, event.Event, event.Data)
+	assert.NoError(t, err)
+
+	mcb.AssertExpectations(t)
+}
+
+func TestExecuteTemplatesPathFail(t *testing.T) {
+	options := &core.WebhookSubOptions{
+		Template: core.WebhookTemplateOptions{
+			Path: "{{ index .data 5 }}",
+		},
+	}
+	var err error
+	options.CompiledTemplate, err = compileTemplates(context.Background(), &options.Template, false)
+	assert.NoError(t, err)
+
+	p := &whPayload{}
+	err = p.executeTemplates(context.Background(), options, newTestTemplateEvent(&core.Subscription{}, "tag1", "order1"))
+	assert.Regexp(t, "FF10513.*path", err)
+}
+
+func TestExecuteTemplatesHeaderFail(t *testing.T) {
+	options := &core.WebhookSubOptions{
+		Template: core.WebhookTemplateOptions{
+			Headers: map[string]string{"X-Order": "{{ index .data 5 }}"},
+		},
+	}
+	var err error
+	options.CompiledTemplate, err = compileTemplates(context.Background(), &options.Template, false)
+	assert.NoError(t, err)
+
+	p := &whPayload{}
+	err = p.executeTemplates(context.Background(), options, newTestTemplateEvent(&core.Subscription{}, "tag1", "order1"))
+	assert.Regexp(t, "FF10513.*headers.X-Order", err)
+}
+
+func TestExecuteTemplatesBadData(t *testing.T) {
+	options := &core.WebhookSubOptions{
+		Template: core.WebhookTemplateOptions{
+			Body: "{{ .topic }}",
+		},
+	}
+	var err error
+	options.CompiledTemplate, err = compileTemplates(context.Background(), &options.Template, false)
+	assert.NoError(t, err)
+
+	event := newTestTemplateEvent(&core.Subscription{}, "tag1", "order1")
+	event.Data[0].Value = fftypes.JSONAnyPtr("!json")
+	p := &whPayload{}
+	err = p.executeTemplates(context.Background(), options, event)
+	assert.Regexp(t, "FF10137", err)
+}
+
+func TestExecuteTemplatesMissingKey(t *testing.T) {
+	options := &core.WebhookSubOptions{
+		Template: core.WebhookTemplateOptions{
+			Body: `{"ref": {{ json .message.header.cid }}}`,
+		},
+	}
+	var err error
+	options.CompiledTemplate, err = compileTemplates(context.Background(), &options.Template, false)
+	assert.NoError(t, err)
+
+	p := &whPayload{}
+	err = p.executeTemplates(context.Background(), options, newTestTemplateEvent(&core.Subscription{}, "tag1", "order1"))
+	assert.Regexp(t, "FF10513.*body.*cid", err)
+}
+
+func TestExecuteTemplatesBodyNotJSON(t *testing.T) {
+	options := &core.WebhookSubOptions{
+		Template: core.WebhookTemplateOptions{
+			Body: `{"tag": {{ .message.header.tag }}}`,
+		},
+	}
+	var err error
+	options.CompiledTemplate, err = compileTemplates(context.Background(), &options.Template, false)
+	assert.NoError(t, err)
+
+	p := &whPayload{}
+	err = p.executeTemplates(context.Background(), options, newTestTemplateEvent(&core.Subscription{}, "tag1", "order1"))
+	assert.Regexp(t, "FF10547", err)
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/events"
+	"github.com/hyperledger/firefly/pkg/webhooksig"
 )
 
 type WebHooks struct {
@@ -62,10 +63,31 @@ type whRequest struct {
 }
 
 type whPayload struct {
-	input       fftypes.JSONObject
-	parsedData0 fftypes.JSONObject
-	data0       *fftypes.JSONAny
-	body        interface{}
+	input           fftypes.JSONObject
+	parsedData0     fftypes.JSONObject
+	data0           *fftypes.JSONAny
+	body            interface{}
+	templatedBody   bool
+	templatePath    string
+	templateHeaders map[string]string
+}
+
+// requestSigning is set in the context of a request that must be signed. The signature includes the time,
+// so it is calculated in a hook that runs before each attempt, rather than once for all the retries.
+// The delivery ID is the same for every attempt, so the receiver can recognize a retry.
+type requestSigning struct {
+	secret     []byte
+	deliveryID string
+	body       []byte
+}
+
+type requestSigningKey struct{}
+
+func signRequest(_ *resty.Client, r *resty.Request) error {
+	if signing, ok := r.Context().Value(requestSigningKey{}).(*requestSigning); ok {
+		webhooksig.SetHeaders(r.Header, signing.secret, time.Now(), signing.deliveryID, signing.body)
+	}
+	return nil
 }
 
 type whResponse struct {
@@ -85,6 +107,7 @@ func (wh *WebHooks) Init(ctx context.Context, config config.Section) (err error)
 	}
 
 	client := ffresty.NewWithConfig(ctx, *ffrestyConfig)
+	client.OnBeforeRequest(signRequest)
 
 	*wh = WebHooks{
 		ctx: log.WithLogField(ctx, "webhook", wh.connID),
@@ -138,7 +161,7 @@ func (p *whPayload) firstData() fftypes.JSONObject {
 	return p.parsedData0
 }
 
-func (wh *WebHooks) buildPayload(ctx context.Context, sub *core.Subscription, event *core.CombinedEventDataDelivery) *whPayload {
+func (wh *WebHooks) buildPayload(ctx context.Context, sub *core.Subscription, event *core.CombinedEventDataDelivery) (*whPayload, error) {
 	log.L(wh.ctx).Debugf("Webhook-> %s event %s on subscription %s", sub.Options.URL, event.Event.ID, sub.ID)
 	withData := sub.Options.WithData != nil && *sub.Options.WithData
 	options := sub.Options.TransportOptions()
@@ -180,7 +203,26 @@ func (wh *WebHooks) buildPayload(ctx context.Context, sub *core.Subscription, ev
 		// Just send the event itself
 		p.body = event.Event
 	}
-	return p
+
+	// Templates can build the body, headers and path from the whole event
+	if sub.Options.CompiledTemplate != nil {
+		if err := p.executeTemplates(ctx, &sub.Options.WebhookSubOptions, event); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// appendPath adds each segment of a dynamic path to the URL, with escaping
+func appendPath(baseURL, extraPath string) string {
+	extraPath = strings.TrimPrefix(extraPath, "/")
+	if len(extraPath) > 0 {
+		pathSegments := strings.Split(extraPath, "/")
+		for _, ps := range pathSegments {
+			baseURL = strings.TrimSuffix(baseURL, "/") + "/" + url.PathEscape(ps)
+		}
+	}
+	return baseURL
 }
 
 func (wh *WebHooks) buildRequest(ctx context.Context, restyClient *resty.Client, options fftypes.JSONObject, p *whPayload) (req *whRequest, err error) {
@@ -240,14 +282,13 @@ func (wh *WebHooks) buildRequest(ctx context.Context, restyClient *resty.Client,
 		// Choose to add an additional dynamic path
 		inputPath := p.input.GetString("path")
 		if inputPath != "" {
-			extraPath := strings.TrimPrefix(p.firstData().GetString(inputPath), "/")
-			if len(extraPath) > 0 {
-				pathSegments := strings.Split(extraPath, "/")
-				for _, ps := range pathSegments {
-					req.url = strings.TrimSuffix(req.url, "/") + "/" + url.PathEscape(ps)
-				}
-			}
+			req.url = appendPath(req.url, p.firstData().GetString(inputPath))
+		}
+		// Headers and an additional dynamic path built from templates
+		for h, v := range p.templateHeaders {
+			_ = req.r.SetHeader(h, v)
 		}
+		req.url = appendPath(req.url, p.templatePath)
 		// Choose to add an additional dynamic path
 		inputTxtype := p.input.GetString("replytx")
 		if inputTxtype != "" {
@@ -269,6 +310,22 @@ func (wh *WebHooks) ValidateOptions(ctx context.Context, options *core.Subscript
 		options.WithData = &defaultTrue
 	}
 
+	switch options.DeadLetterPolicy {
+	case "", core.WebhookDeadLetterPolicyNone:
+	case core.WebhookDeadLetterPolicyDeadLetter:
+		if options.Fastack {
+			return i18n.NewError(ctx, coremsgs.MsgDeadLetterFastAckNotSupported, options.DeadLetterPolicy)
+		}
+	default:
+		return i18n.NewError(ctx, coremsgs.MsgInvalidDeadLetterPolicy, options.DeadLetterPolicy)
+	}
+
+	compiledTemplate, err := compileTemplates(ctx, &options.Template, options.Batch != nil && *options.Batch)
+	if err != nil {
+		return err
+	}
+	options.CompiledTemplate = compiledTemplate
+
 	newFFRestyConfig := ffresty.Config{}
 	if wh.ffrestyConfig != nil {
 		// Take a copy of the webhooks global resty config
@@ -353,25 +410,51 @@ func (wh *WebHooks) ValidateOptions(ctx context.Context, options *core.Subscript
 	// API call or anything else and we want to use this client later on!!
 	// So these clients should live as long as the plugin exists
 	options.RestyClient = ffresty.NewWithConfig(wh.ctx, newFFRestyConfig)
+	options.RestyClient.OnBeforeRequest(signRequest)
 
-	_, err := wh.buildRequest(ctx, options.RestyClient, options.TransportOptions(), nil)
+	_, err = wh.buildRequest(ctx, options.RestyClient, options.TransportOptions(), nil)
 	return err
 }
 
+// serializeBody matches how resty serializes a request body, where strings are sent as-is and nil sends nothing
+func serializeBody(ctx context.Context, requestBody interface{}) ([]byte, error) {
+	switch b := requestBody.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(b), nil
+	}
+	b, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgSerializationFailed)
+	}
+	return b, nil
+}
+
 func (wh *WebHooks) attemptRequest(ctx context.Context, sub *core.Subscription, events []*core.CombinedEventDataDelivery, batch bool) (req *whRequest, res *whResponse, err error) {
 
 	var payloadForBuildingRequest *whPayload // only set for a single event delivery
 	var requestBody interface{}
 	if len(events) == 1 && !batch {
-		payloadForBuildingRequest = wh.buildPayload(ctx, sub, events[0])
+		if payloadForBuildingRequest, err = wh.buildPayload(ctx, sub, events[0]); err != nil {
+			return nil, nil, err
+		}
 		// Payload for POST/PATCH/PUT is what is calculated for a single event in buildPayload
 		requestBody = payloadForBuildingRequest.body
 	} else {
 		batchBody := make([]interface{}, len(events))
 		for i, event := range events {
 			// We only use the body itself from the whPayload - then discard it.
-			p := wh.buildPayload(ctx, sub, event)
-			batchBody[i] = p.body
+			p, err := wh.buildPayload(ctx, sub, event)
+			if err != nil {
+				return nil, nil, err
+			}
+			if p.templatedBody {
+				// A templated body is already JSON, so it is included in the array as-is
+				batchBody[i] = json.RawMessage(p.body.(string))
+			} else {
+				batchBody[i] = p.body
+			}
 		}
 		// Payload for POST/PATCH/PUT is the array of outputs calculated for a each event in buildPayload
 		requestBody = batchBody
@@ -387,8 +470,24 @@ func (wh *WebHooks) attemptRequest(ctx context.Context, sub *core.Subscription,
 		return nil, nil, err
 	}
 
+	var signedBody []byte
 	if req.method == http.MethodPost || req.method == http.MethodPatch || req.method == http.MethodPut {
-		req.r.SetBody(requestBody)
+		if len(sub.Options.SigningSecret) > 0 {
+			// The signature must be over the exact bytes we send, so we serialize the body ourselves
+			if signedBody, err = serializeBody(ctx, requestBody); err != nil {
+				return nil, nil, err
+			}
+			req.r.SetBody(signedBody)
+		} else {
+			req.r.SetBody(requestBody)
+		}
+	}
+	if len(sub.Options.SigningSecret) > 0 {
+		req.r.SetContext(context.WithValue(req.r.Context(), requestSigningKey{}, &requestSigning{
+			secret:     sub.Options.SigningSecret,
+			deliveryID: fftypes.NewUUID().String(),
+			body:       signedBody,
+		}))
 	}
 
 	resp, err := req.r.Execute(req.method, req.url)
@@ -455,11 +554,33 @@ func (wh *WebHooks) doDelivery(ctx context.Context, connID string, reply bool, s
 	b, _ := json.Marshal(&res)
 	log.L(wh.ctx).Tracef("Webhook response: %s", string(b))
 
+	// With the dead letter policy, a call that failed after all retries is recorded against the
+	// subscription, rather than being acknowledged (or replied to) as if it had been delivered
+	deadLetterReason := ""
+	if !fastAck && sub.Options.DeadLetterPolicy == core.WebhookDeadLetterPolicyDeadLetter {
+		if gwErr != nil {
+			deadLetterReason = gwErr.Error()
+		} else if res.Status < 200 || res.Status >= 300 {
+			deadLetterReason = i18n.NewError(ctx, coremsgs.MsgWebhookFailedStatus, res.Status).Error()
+		}
+	}
+
 	// For each event emit a response
 	for _, combinedEvent := range events {
 		event := combinedEvent.Event
 		// Emit the response
-		if reply && event.Message != nil {
+		if deadLetterReason != "" {
+			if cb, ok := wh.callbacks.handlers[sub.Namespace]; ok {
+				log.L(wh.ctx).Warnf("Dead lettering event %s on subscription %s: %s", event.ID, sub.ID, deadLetterReason)
+				cb.DeliveryResponse(connID, &core.EventDeliveryResponse{
+					ID:           event.ID,
+					Rejected:     false,
+					DeadLetter:   true,
+					Info:         deadLetterReason,
+					Subscription: event.Subscription,
+				})
+			}
+		} else if reply && event.Message != nil {
 			txType := fftypes.FFEnum(strings.ToLower(sub.Options.TransportOptions().GetString("replytx")))
 			if req != nil && req.replyTx != "" {
 				txType = fftypes.FFEnum(strings.ToLower(req.replyTx))
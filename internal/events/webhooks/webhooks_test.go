@@ -44,6 +44,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/eventsmocks"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/events"
+	"github.com/hyperledger/firefly/pkg/webhooksig"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -111,6 +112,40 @@ func TestValidateOptionsWithDataDefaulTrue(t *testing.T) {
 	assert.Empty(t, wh.callbacks.handlers)
 }
 
+func TestValidateOptionsDeadLetterPolicy(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	opts := &core.SubscriptionOptions{}
+	opts.DeadLetterPolicy = core.WebhookDeadLetterPolicyDeadLetter
+	opts.TransportOptions()["url"] = "/anything"
+	err := wh.ValidateOptions(wh.ctx, opts)
+	assert.NoError(t, err)
+}
+
+func TestValidateOptionsBadDeadLetterPolicy(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	opts := &core.SubscriptionOptions{}
+	opts.DeadLetterPolicy = "wrong"
+	opts.TransportOptions()["url"] = "/anything"
+	err := wh.ValidateOptions(wh.ctx, opts)
+	assert.Regexp(t, "FF10506", err)
+}
+
+func TestValidateOptionsDeadLetterPolicyFastAck(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	opts := &core.SubscriptionOptions{}
+	opts.DeadLetterPolicy = core.WebhookDeadLetterPolicyDeadLetter
+	opts.Fastack = true
+	opts.TransportOptions()["url"] = "/anything"
+	err := wh.ValidateOptions(wh.ctx, opts)
+	assert.Regexp(t, "FF10507", err)
+}
+
 func TestValidateOptionsBadURL(t *testing.T) {
 	wh, cancel := newTestWebHooks(t)
 	defer cancel()
@@ -784,6 +819,233 @@ func TestRequestNoBodyNoReply(t *testing.T) {
 	mcb.AssertExpectations(t)
 }
 
+func TestRequestSignedWithSigningSecret(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	secret := []byte("mysecret")
+	msgID := fftypes.NewUUID()
+
+	called := false
+	r := mux.NewRouter()
+	r.HandleFunc("/myapi", func(res http.ResponseWriter, req *http.Request) {
+		body, err := webhooksig.VerifyRequest(context.Background(), secret, req, 5*time.Minute)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, req.Header.Get(webhooksig.HeaderDeliveryID))
+		var jsonBody fftypes.JSONObject
+		err = json.Unmarshal(body, &jsonBody)
+		assert.NoError(t, err)
+		assert.Equal(t, msgID.String(), jsonBody.GetObject("message").GetObject("header").GetString("id"))
+		res.WriteHeader(200)
+		called = true
+	}).Methods(http.MethodPost)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			Namespace: "ns1",
+		},
+	}
+	sub.Options.SigningSecretName = "secret1"
+	sub.Options.SigningSecret = secret
+	to := sub.Options.TransportOptions()
+	to["url"] = fmt.Sprintf("http://%s/myapi", server.Listener.Addr())
+	event := &core.EventDelivery{
+		EnrichedEvent: core.EnrichedEvent{
+			Event: core.Event{
+				ID: fftypes.NewUUID(),
+			},
+			Message: &core.Message{
+				Header: core.MessageHeader{
+					ID:   msgID,
+					Type: core.MessageTypeBroadcast,
+				},
+			},
+		},
+		Subscription: core.SubscriptionRef{
+			ID:        sub.ID,
+			Namespace: "ns1",
+		},
+	}
+
+	mcb := wh.callbacks.handlers["ns1"].(*eventsmocks.Callbacks)
+	mcb.On("DeliveryResponse", mock.Anything, mock.MatchedBy(func(response *core.EventDeliveryResponse) bool {
+		return !response.Rejected
+	})).Return(nil)
+
+	err := wh.DeliveryRequest(wh.ctx, mock.Anything, sub, event, core.DataArray{})
+	assert.NoError(t, err)
+	assert.True(t, called)
+
+	mcb.AssertExpectations(t)
+}
+
+func TestRequestSignedOnEachRetry(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	secret := []byte("mysecret")
+
+	deliveryIDs := []string{}
+	r := mux.NewRouter()
+	r.HandleFunc("/myapi", func(res http.ResponseWriter, req *http.Request) {
+		_, err := webhooksig.VerifyRequest(context.Background(), secret, req, 5*time.Minute)
+		assert.NoError(t, err)
+		deliveryIDs = append(deliveryIDs, req.Header.Get(webhooksig.HeaderDeliveryID))
+		if len(deliveryIDs) == 1 {
+			res.WriteHeader(500)
+			return
+		}
+		res.WriteHeader(200)
+	}).Methods(http.MethodPost)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			Namespace: "ns1",
+		},
+	}
+	sub.Options.SigningSecretName = "secret1"
+	sub.Options.SigningSecret = secret
+	sub.Options.Retry = core.WebhookRetryOptions{
+		Enabled:      true,
+		Count:        1,
+		InitialDelay: "1ms",
+	}
+	to := sub.Options.TransportOptions()
+	to["url"] = fmt.Sprintf("http://%s/myapi", server.Listener.Addr())
+	err := wh.ValidateOptions(wh.ctx, &sub.Options)
+	assert.NoError(t, err)
+	event := &core.EventDelivery{
+		EnrichedEvent: core.EnrichedEvent{
+			Event: core.Event{
+				ID: fftypes.NewUUID(),
+			},
+			Message: &core.Message{
+				Header: core.MessageHeader{
+					ID:   fftypes.NewUUID(),
+					Type: core.MessageTypeBroadcast,
+				},
+			},
+		},
+		Subscription: core.SubscriptionRef{
+			ID:        sub.ID,
+			Namespace: "ns1",
+		},
+	}
+
+	mcb := wh.callbacks.handlers["ns1"].(*eventsmocks.Callbacks)
+	mcb.On("DeliveryResponse", mock.Anything, mock.MatchedBy(func(response *core.EventDeliveryResponse) bool {
+		return !response.Rejected
+	})).Return(nil)
+
+	err = wh.DeliveryRequest(wh.ctx, mock.Anything, sub, event, core.DataArray{})
+	assert.NoError(t, err)
+	assert.Len(t, deliveryIDs, 2)
+	// Each attempt is signed again, but the retry is still the same delivery
+	assert.NotEmpty(t, deliveryIDs[0])
+	assert.Equal(t, deliveryIDs[0], deliveryIDs[1])
+
+	mcb.AssertExpectations(t)
+}
+
+func TestRequestSignedGETNoBody(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	secret := []byte("mysecret")
+
+	called := false
+	r := mux.NewRouter()
+	r.HandleFunc("/myapi", func(res http.ResponseWriter, req *http.Request) {
+		body, err := webhooksig.VerifyRequest(context.Background(), secret, req, 5*time.Minute)
+		assert.NoError(t, err)
+		assert.Empty(t, body)
+		res.WriteHeader(200)
+		called = true
+	}).Methods(http.MethodGet)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			Namespace: "ns1",
+		},
+	}
+	sub.Options.SigningSecret = secret
+	to := sub.Options.TransportOptions()
+	to["url"] = fmt.Sprintf("http://%s/myapi", server.Listener.Addr())
+	to["method"] = http.MethodGet
+	event := &core.EventDelivery{
+		EnrichedEvent: core.EnrichedEvent{
+			Event: core.Event{
+				ID: fftypes.NewUUID(),
+			},
+		},
+		Subscription: core.SubscriptionRef{
+			ID:        sub.ID,
+			Namespace: "ns1",
+		},
+	}
+
+	mcb := wh.callbacks.handlers["ns1"].(*eventsmocks.Callbacks)
+	mcb.On("DeliveryResponse", mock.Anything, mock.Anything).Return(nil)
+
+	err := wh.DeliveryRequest(wh.ctx, mock.Anything, sub, event, core.DataArray{})
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
+
+func TestSerializeBody(t *testing.T) {
+	b, err := serializeBody(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Nil(t, b)
+
+	b, err = serializeBody(context.Background(), "raw")
+	assert.NoError(t, err)
+	assert.Equal(t, "raw", string(b))
+}
+
+func TestSerializeBodyFail(t *testing.T) {
+	_, err := serializeBody(context.Background(), map[bool]bool{false: true})
+	assert.Regexp(t, "FF10137", err)
+}
+
+func TestRequestSignedBadBody(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			Namespace: "ns1",
+		},
+	}
+	sub.Options.SigningSecret = []byte("mysecret")
+	sub.Options.TransportOptions()["url"] = "http://localhost:12345/myapi"
+	err := wh.ValidateOptions(wh.ctx, &sub.Options)
+	assert.NoError(t, err)
+	// Data that is not valid JSON fails serialization of the body to sign
+	event := &core.CombinedEventDataDelivery{
+		Event: &core.EventDelivery{
+			EnrichedEvent: core.EnrichedEvent{
+				Event: core.Event{
+					ID: fftypes.NewUUID(),
+				},
+				Message: &core.Message{},
+			},
+		},
+		Data: core.DataArray{
+			{Value: fftypes.JSONAnyPtr("!json")},
+			{Value: fftypes.JSONAnyPtr("!json")},
+		},
+	}
+
+	_, _, err = wh.attemptRequest(wh.ctx, sub, []*core.CombinedEventDataDelivery{event}, true)
+	assert.Regexp(t, "FF10137", err)
+}
+
 func TestRequestReplyEmptyData(t *testing.T) {
 	wh, cancel := newTestWebHooks(t)
 	defer cancel()
@@ -1092,6 +1354,100 @@ func TestRequestReplyDataArrayError(t *testing.T) {
 	mcb.AssertExpectations(t)
 }
 
+func TestRequestDeadLetterBadStatus(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	r := mux.NewRouter()
+	r.HandleFunc("/myapi", func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(500)
+	}).Methods(http.MethodPost)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			Namespace: "ns1",
+		},
+	}
+	sub.Options.DeadLetterPolicy = core.WebhookDeadLetterPolicyDeadLetter
+	to := sub.Options.TransportOptions()
+	to["url"] = fmt.Sprintf("http://%s/myapi", server.Listener.Addr())
+	to["reply"] = true
+	event := &core.EventDelivery{
+		EnrichedEvent: core.EnrichedEvent{
+			Event: core.Event{
+				ID: fftypes.NewUUID(),
+			},
+			Message: &core.Message{
+				Header: core.MessageHeader{
+					ID:   fftypes.NewUUID(),
+					Type: core.MessageTypeBroadcast,
+				},
+			},
+		},
+		Subscription: core.SubscriptionRef{
+			ID:        sub.ID,
+			Namespace: "ns1",
+		},
+	}
+
+	mcb := wh.callbacks.handlers["ns1"].(*eventsmocks.Callbacks)
+	mcb.On("DeliveryResponse", mock.Anything, mock.MatchedBy(func(response *core.EventDeliveryResponse) bool {
+		return !response.Rejected && response.DeadLetter && response.Reply == nil &&
+			assert.Regexp(t, "FF10505.*500", response.Info)
+	})).Return(nil)
+
+	err := wh.DeliveryRequest(wh.ctx, mock.Anything, sub, event, core.DataArray{})
+	assert.NoError(t, err)
+
+	mcb.AssertExpectations(t)
+}
+
+func TestRequestDeadLetterBatchConnectFail(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	r := mux.NewRouter()
+	server := httptest.NewServer(r)
+	server.Close()
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			Namespace: "ns1",
+		},
+	}
+	sub.Options.DeadLetterPolicy = core.WebhookDeadLetterPolicyDeadLetter
+	to := sub.Options.TransportOptions()
+	to["url"] = fmt.Sprintf("http://%s/myapi", server.Listener.Addr())
+	events := make([]*core.CombinedEventDataDelivery, 2)
+	for i := range events {
+		events[i] = &core.CombinedEventDataDelivery{
+			Event: &core.EventDelivery{
+				EnrichedEvent: core.EnrichedEvent{
+					Event: core.Event{
+						ID: fftypes.NewUUID(),
+					},
+				},
+				Subscription: core.SubscriptionRef{
+					ID:        sub.ID,
+					Namespace: "ns1",
+				},
+			},
+		}
+	}
+
+	mcb := wh.callbacks.handlers["ns1"].(*eventsmocks.Callbacks)
+	mcb.On("DeliveryResponse", mock.Anything, mock.MatchedBy(func(response *core.EventDeliveryResponse) bool {
+		return response.DeadLetter && response.Info != ""
+	})).Return(nil).Twice()
+
+	err := wh.BatchDeliveryRequest(wh.ctx, mock.Anything, sub, events)
+	assert.NoError(t, err)
+
+	mcb.AssertExpectations(t)
+}
+
 func TestWebhookFailFastAck(t *testing.T) {
 	wh, cancel := newTestWebHooks(t)
 	defer cancel()
@@ -43,6 +43,12 @@ type Manager interface {
 	BlockchainEvent(location, signature string)
 	NodeIdentityDXCertMismatch(namespace string, mismatch NodeIdentityDXCertMismatchStatus)
 	NodeIdentityDXCertExpiry(namespace string, expiry time.Time)
+	SubscriptionDelivered(namespace, subscription string, count int)
+	SubscriptionAcked(namespace, subscription string)
+	SubscriptionRejected(namespace, subscription string)
+	SubscriptionInflight(namespace, subscription string, inflight int)
+	SubscriptionLag(namespace, subscription string, lag int64)
+	SubscriptionDeleted(namespace, subscription string)
 	AddTime(id string)
 	GetTime(id string) time.Time
 	DeleteTime(id string)
@@ -89,6 +89,7 @@ func initMetricsCollectors() {
 	InitBatchPinMetrics()
 	InitBlockchainMetrics()
 	InitIdentityMetrics()
+	InitSubscriptionMetrics()
 }
 
 func registerMetricsCollectors() {
@@ -103,4 +104,5 @@ func registerMetricsCollectors() {
 	RegisterTokenBurnMetrics()
 	RegisterBlockchainMetrics()
 	RegisterIdentityMetrics()
+	RegisterSubscriptionMetrics()
 }
@@ -0,0 +1,114 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var SubscriptionDeliveredCounter *prometheus.CounterVec
+var SubscriptionAckedCounter *prometheus.CounterVec
+var SubscriptionRejectedCounter *prometheus.CounterVec
+var SubscriptionInflightGauge *prometheus.GaugeVec
+var SubscriptionLastDeliveryGauge *prometheus.GaugeVec
+var SubscriptionLagGauge *prometheus.GaugeVec
+
+const (
+	// MetricsSubscriptionDelivered is the prometheus metric for the number of events delivered to a subscription
+	MetricsSubscriptionDelivered = "ff_subscription_delivered_total"
+	// MetricsSubscriptionAcked is the prometheus metric for the number of events acknowledged on a subscription
+	MetricsSubscriptionAcked = "ff_subscription_acked_total"
+	// MetricsSubscriptionRejected is the prometheus metric for the number of events rejected on a subscription
+	MetricsSubscriptionRejected = "ff_subscription_rejected_total"
+	// MetricsSubscriptionInflight is the prometheus metric for the number of events in-flight on a subscription
+	MetricsSubscriptionInflight = "ff_subscription_inflight"
+	// MetricsSubscriptionLastDelivery is the prometheus metric for the time of the last delivery on a subscription
+	MetricsSubscriptionLastDelivery = "ff_subscription_last_delivery_epoch"
+	// MetricsSubscriptionLag is the prometheus metric for the number of events a subscription is behind the latest event
+	MetricsSubscriptionLag = "ff_subscription_lag"
+)
+
+var subscriptionLabels = []string{"ns", "subscription"}
+
+func InitSubscriptionMetrics() {
+	SubscriptionDeliveredCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: MetricsSubscriptionDelivered,
+		Help: "Number of events delivered to a subscription",
+	}, subscriptionLabels)
+	SubscriptionAckedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: MetricsSubscriptionAcked,
+		Help: "Number of events acknowledged on a subscription",
+	}, subscriptionLabels)
+	SubscriptionRejectedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: MetricsSubscriptionRejected,
+		Help: "Number of events rejected on a subscription, which are then redelivered",
+	}, subscriptionLabels)
+	SubscriptionInflightGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricsSubscriptionInflight,
+		Help: "Number of events delivered to a subscription and waiting for a response",
+	}, subscriptionLabels)
+	SubscriptionLastDeliveryGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricsSubscriptionLastDelivery,
+		Help: "Timestamp, in Unix epoch format, of the last delivery to a subscription",
+	}, subscriptionLabels)
+	SubscriptionLagGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricsSubscriptionLag,
+		Help: "Approximate number of events a subscription is behind the latest event known to this node, as the difference in event sequence, which also counts the events of other namespaces and events the subscription does not match",
+	}, subscriptionLabels)
+}
+
+func RegisterSubscriptionMetrics() {
+	registry.MustRegister(SubscriptionDeliveredCounter)
+	registry.MustRegister(SubscriptionAckedCounter)
+	registry.MustRegister(SubscriptionRejectedCounter)
+	registry.MustRegister(SubscriptionInflightGauge)
+	registry.MustRegister(SubscriptionLastDeliveryGauge)
+	registry.MustRegister(SubscriptionLagGauge)
+}
+
+func (mm *metricsManager) SubscriptionDelivered(namespace, subscription string, count int) {
+	SubscriptionDeliveredCounter.WithLabelValues(namespace, subscription).Add(float64(count))
+	SubscriptionLastDeliveryGauge.WithLabelValues(namespace, subscription).Set(float64(time.Now().UTC().Unix()))
+}
+
+func (mm *metricsManager) SubscriptionAcked(namespace, subscription string) {
+	SubscriptionAckedCounter.WithLabelValues(namespace, subscription).Inc()
+}
+
+func (mm *metricsManager) SubscriptionRejected(namespace, subscription string) {
+	SubscriptionRejectedCounter.WithLabelValues(namespace, subscription).Inc()
+}
+
+func (mm *metricsManager) SubscriptionInflight(namespace, subscription string, inflight int) {
+	SubscriptionInflightGauge.WithLabelValues(namespace, subscription).Set(float64(inflight))
+}
+
+func (mm *metricsManager) SubscriptionLag(namespace, subscription string, lag int64) {
+	SubscriptionLagGauge.WithLabelValues(namespace, subscription).Set(float64(lag))
+}
+
+// SubscriptionDeleted removes the series of a deleted subscription, so they are not reported forever
+func (mm *metricsManager) SubscriptionDeleted(namespace, subscription string) {
+	SubscriptionDeliveredCounter.DeleteLabelValues(namespace, subscription)
+	SubscriptionAckedCounter.DeleteLabelValues(namespace, subscription)
+	SubscriptionRejectedCounter.DeleteLabelValues(namespace, subscription)
+	SubscriptionInflightGauge.DeleteLabelValues(namespace, subscription)
+	SubscriptionLastDeliveryGauge.DeleteLabelValues(namespace, subscription)
+	SubscriptionLagGauge.DeleteLabelValues(namespace, subscription)
+}
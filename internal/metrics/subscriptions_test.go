@@ -0,0 +1,69 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscriptionDelivered(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.SubscriptionDelivered("ns1", "sub1", 3)
+	assert.Equal(t, float64(3), testutil.ToFloat64(SubscriptionDeliveredCounter.WithLabelValues("ns1", "sub1")))
+	assert.Greater(t, testutil.ToFloat64(SubscriptionLastDeliveryGauge.WithLabelValues("ns1", "sub1")), float64(0))
+}
+
+func TestSubscriptionAckedRejected(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.SubscriptionAcked("ns1", "sub1")
+	mm.SubscriptionAcked("ns1", "sub1")
+	mm.SubscriptionRejected("ns1", "sub1")
+	assert.Equal(t, float64(2), testutil.ToFloat64(SubscriptionAckedCounter.WithLabelValues("ns1", "sub1")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(SubscriptionRejectedCounter.WithLabelValues("ns1", "sub1")))
+}
+
+func TestSubscriptionInflightAndLag(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.SubscriptionInflight("ns1", "sub1", 5)
+	mm.SubscriptionLag("ns1", "sub1", 42)
+	assert.Equal(t, float64(5), testutil.ToFloat64(SubscriptionInflightGauge.WithLabelValues("ns1", "sub1")))
+	assert.Equal(t, float64(42), testutil.ToFloat64(SubscriptionLagGauge.WithLabelValues("ns1", "sub1")))
+}
+
+func TestSubscriptionDeleted(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.SubscriptionDelivered("ns1", "sub1", 1)
+	mm.SubscriptionAcked("ns1", "sub1")
+	mm.SubscriptionRejected("ns1", "sub1")
+	mm.SubscriptionInflight("ns1", "sub1", 1)
+	mm.SubscriptionLag("ns1", "sub1", 1)
+	mm.SubscriptionDelivered("ns1", "sub2", 1)
+	mm.SubscriptionDeleted("ns1", "sub1")
+	assert.Equal(t, 1, testutil.CollectAndCount(SubscriptionDeliveredCounter))
+	assert.Equal(t, 1, testutil.CollectAndCount(SubscriptionLastDeliveryGauge))
+	assert.Equal(t, 0, testutil.CollectAndCount(SubscriptionAckedCounter))
+	assert.Equal(t, 0, testutil.CollectAndCount(SubscriptionRejectedCounter))
+	assert.Equal(t, 0, testutil.CollectAndCount(SubscriptionInflightGauge))
+	assert.Equal(t, 0, testutil.CollectAndCount(SubscriptionLagGauge))
+}
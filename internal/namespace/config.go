@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/auth/authfactory"
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly/internal/blobstorage/bsfactory"
 	"github.com/hyperledger/firefly/internal/blockchain/bifactory"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/database/difactory"
@@ -45,6 +46,7 @@ var (
 	tokensConfig        = config.RootArray("plugins.tokens")
 	databaseConfig      = config.RootArray("plugins.database")
 	sharedstorageConfig = config.RootArray("plugins.sharedstorage")
+	blobstorageConfig   = config.RootArray("plugins.blobstorage")
 	dataexchangeConfig  = config.RootArray("plugins.dataexchange")
 	identityConfig      = config.RootArray("plugins.identity")
 	authConfig          = config.RootArray("plugins.auth")
@@ -77,9 +79,22 @@ func InitConfig() {
 	tlsConf := tlsConfigs.SubSection(coreconfig.NamespaceTLSConfigTLSSection)
 	fftls.InitTLSConfig(tlsConf)
 
+	signingSecrets := namespacePredefined.SubArray(coreconfig.NamespaceSigningSecrets)
+	signingSecrets.AddKnownKey(coreconfig.NamespaceSigningSecretName)
+	signingSecrets.AddKnownKey(coreconfig.NamespaceSigningSecretValue)
+
+	retentionConf := namespacePredefined.SubSection(coreconfig.NamespaceRetention)
+	retentionConf.AddKnownKey(coreconfig.NamespaceRetentionMaxAge, "0")
+	retentionConf.AddKnownKey(coreconfig.NamespaceRetentionMaxEvents, 0)
+	retentionConf.AddKnownKey(coreconfig.NamespaceRetentionInterval, "1h")
+	retentionConf.AddKnownKey(coreconfig.NamespaceRetentionBatchSize, 1000)
+	retentionConf.AddKnownKey(coreconfig.NamespaceRetentionCollections)
+	retentionConf.AddKnownKey(coreconfig.NamespaceRetentionArchivePath)
+
 	bifactory.InitConfig(blockchainConfig)
 	difactory.InitConfig(databaseConfig)
 	ssfactory.InitConfig(sharedstorageConfig)
+	bsfactory.InitConfig(blobstorageConfig)
 	dxfactory.InitConfig(dataexchangeConfig)
 	iifactory.InitConfig(identityConfig)
 	tifactory.InitConfig(tokensConfig)
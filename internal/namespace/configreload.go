@@ -220,5 +220,6 @@ func (nm *namespaceManager) stopDefunctPlugins(ctx context.Context, pluginsToSto
 	for pluginName, plugin := range pluginsToStop {
 		log.L(ctx).Debugf("Stopping plugin '%s' after config reload. Loaded at %s", pluginName, plugin.loadTime)
 		plugin.cancelCtx()
+		waitStopPlugin(plugin)
 	}
 }
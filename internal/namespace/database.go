@@ -0,0 +1,69 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/database/difactory"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// OpenDatabase initializes the database plugin of a namespace, as defined in the configuration,
+// without starting the namespace. This is used by commands that work directly on the database.
+func OpenDatabase(ctx context.Context, name string) (database.Plugin, error) {
+	var pluginNames []string
+	found := false
+	// The size of each array must be read before any entry is, as reading an entry sets its defaults
+	nsArraySize := namespacePredefined.ArraySize()
+	dbArraySize := databaseConfig.ArraySize()
+	for i := 0; i < nsArraySize; i++ {
+		nsConfig := namespacePredefined.ArrayEntry(i)
+		if nsConfig.GetString(coreconfig.NamespaceName) == name {
+			found = true
+			// If no plugins are listed under the namespace, all defined plugins are used
+			if nsConfig.Get(coreconfig.NamespacePlugins) != nil {
+				pluginNames = nsConfig.GetStringSlice(coreconfig.NamespacePlugins)
+			}
+			break
+		}
+	}
+	if !found {
+		return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceNotDefined, name)
+	}
+
+	for i := 0; i < dbArraySize; i++ {
+		config := databaseConfig.ArrayEntry(i)
+		if pluginNames != nil && !slices.Contains(pluginNames, config.GetString(coreconfig.PluginConfigName)) {
+			continue
+		}
+		pluginType := config.GetString(coreconfig.PluginConfigType)
+		plugin, err := difactory.GetPlugin(ctx, pluginType)
+		if err != nil {
+			return nil, err
+		}
+		if err := plugin.Init(ctx, config.SubSection(pluginType)); err != nil {
+			return nil, err
+		}
+		return plugin, nil
+	}
+	return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceNoDatabasePlugin, name)
+}
@@ -0,0 +1,127 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func readTestConfig(t *testing.T, yaml string) {
+	coreconfig.Reset()
+	InitConfig()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(yaml))
+	assert.NoError(t, err)
+}
+
+func TestOpenDatabase(t *testing.T) {
+	readTestConfig(t, `
+  plugins:
+    database:
+    - name: db1
+      type: sqlite3
+      sqlite3:
+        url: "file::memory:"
+    - name: db2
+      type: sqlite3
+      sqlite3:
+        url: "file::memory:"
+  namespaces:
+    predefined:
+    - name: ns1
+      plugins: [db2]
+  `)
+	di, err := OpenDatabase(context.Background(), "ns1")
+	assert.NoError(t, err)
+	assert.Equal(t, "sqlite3", di.Name())
+}
+
+func TestOpenDatabaseAllPlugins(t *testing.T) {
+	readTestConfig(t, `
+  plugins:
+    database:
+    - name: db1
+      type: sqlite3
+      sqlite3:
+        url: "file::memory:"
+  namespaces:
+    predefined:
+    - name: ns1
+  `)
+	di, err := OpenDatabase(context.Background(), "ns1")
+	assert.NoError(t, err)
+	assert.NotNil(t, di)
+}
+
+func TestOpenDatabaseNamespaceNotDefined(t *testing.T) {
+	readTestConfig(t, `
+  namespaces:
+    predefined:
+    - name: ns1
+  `)
+	_, err := OpenDatabase(context.Background(), "ns2")
+	assert.Regexp(t, "FF10536", err)
+}
+
+func TestOpenDatabaseNoPlugin(t *testing.T) {
+	readTestConfig(t, `
+  plugins:
+    database:
+    - name: db1
+      type: sqlite3
+  namespaces:
+    predefined:
+    - name: ns1
+      plugins: [bc1]
+  `)
+	_, err := OpenDatabase(context.Background(), "ns1")
+	assert.Regexp(t, "FF10537", err)
+}
+
+func TestOpenDatabaseBadType(t *testing.T) {
+	readTestConfig(t, `
+  plugins:
+    database:
+    - name: db1
+      type: wrong
+  namespaces:
+    predefined:
+    - name: ns1
+  `)
+	_, err := OpenDatabase(context.Background(), "ns1")
+	assert.Regexp(t, "FF10122", err)
+}
+
+func TestOpenDatabaseInitFail(t *testing.T) {
+	readTestConfig(t, `
+  plugins:
+    database:
+    - name: db1
+      type: sqlite3
+  namespaces:
+    predefined:
+    - name: ns1
+  `)
+	_, err := OpenDatabase(context.Background(), "ns1")
+	assert.Error(t, err)
+}
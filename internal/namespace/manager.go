@@ -32,6 +32,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/internal/blobstorage/bsfactory"
 	"github.com/hyperledger/firefly/internal/blockchain/bifactory"
 	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
@@ -43,9 +44,11 @@ import (
 	"github.com/hyperledger/firefly/internal/identity/iifactory"
 	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/orchestrator"
+	"github.com/hyperledger/firefly/internal/retention"
 	"github.com/hyperledger/firefly/internal/sharedstorage/ssfactory"
 	"github.com/hyperledger/firefly/internal/spievents"
 	"github.com/hyperledger/firefly/internal/tokens/tifactory"
+	"github.com/hyperledger/firefly/pkg/blobstorage"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
@@ -107,6 +110,7 @@ type namespaceManager struct {
 	databaseFactory      func(ctx context.Context, pluginType string) (database.Plugin, error)
 	dataexchangeFactory  func(ctx context.Context, pluginType string) (dataexchange.Plugin, error)
 	sharedstorageFactory func(ctx context.Context, pluginType string) (sharedstorage.Plugin, error)
+	blobstorageFactory   func(ctx context.Context, pluginType string) (blobstorage.Plugin, error)
 	tokensFactory        func(ctx context.Context, pluginType string) (tokens.Plugin, error)
 	identityFactory      func(ctx context.Context, pluginType string) (identity.Plugin, error)
 	eventsFactory        func(ctx context.Context, pluginType string) (events.Plugin, error)
@@ -120,6 +124,7 @@ const (
 	pluginCategoryDatabase      pluginCategory = "database"
 	pluginCategoryDataexchange  pluginCategory = "dataexchange"
 	pluginCategorySharedstorage pluginCategory = "sharedstorage"
+	pluginCategoryBlobstorage   pluginCategory = "blobstorage"
 	pluginCategoryTokens        pluginCategory = "tokens"
 	pluginCategoryIdentity      pluginCategory = "identity"
 	pluginCategoryEvents        pluginCategory = "events"
@@ -140,6 +145,7 @@ type plugin struct {
 	database      database.Plugin
 	dataexchange  dataexchange.Plugin
 	sharedstorage sharedstorage.Plugin
+	blobstorage   blobstorage.Plugin
 	tokens        tokens.Plugin
 	identity      identity.Plugin
 	events        events.Plugin
@@ -170,6 +176,7 @@ func NewNamespaceManager() Manager {
 		databaseFactory:      difactory.GetPlugin,
 		dataexchangeFactory:  dxfactory.GetPlugin,
 		sharedstorageFactory: ssfactory.GetPlugin,
+		blobstorageFactory:   bsfactory.GetPlugin,
 		tokensFactory:        tifactory.GetPlugin,
 		identityFactory:      iifactory.GetPlugin,
 		eventsFactory:        eifactory.GetPlugin,
@@ -424,14 +431,33 @@ func (nm *namespaceManager) WaitStop() {
 	for k, v := range nm.namespaces {
 		namespaces[k] = v
 	}
+	plugins := make(map[string]*plugin, len(nm.plugins))
+	for k, v := range nm.plugins {
+		plugins[k] = v
+	}
 	nm.nsMux.Unlock()
 
 	for _, ns := range namespaces {
 		nm.stopNamespace(nm.ctx, ns)
 	}
+	for _, p := range plugins {
+		waitStopPlugin(p)
+	}
 	nm.adminEvents.WaitStop()
 }
 
+// waitStopper is implemented by plugins that run routines in the background, which exit once
+// the context of the plugin is cancelled
+type waitStopper interface {
+	WaitStop()
+}
+
+func waitStopPlugin(p *plugin) {
+	if ws, ok := p.database.(waitStopper); ok {
+		ws.WaitStop()
+	}
+}
+
 func (nm *namespaceManager) Reset(ctx context.Context) error {
 	if config.GetBool(coreconfig.ConfigAutoReload) {
 		// We do not allow these settings to be combined, because viper does not provide a way to
@@ -485,6 +511,10 @@ func (nm *namespaceManager) loadPlugins(ctx context.Context, rawConfig fftypes.J
 		return nil, err
 	}
 
+	if err := nm.getBlobStoragePlugins(ctx, newPlugins, rawConfig); err != nil {
+		return nil, err
+	}
+
 	if err := nm.getDataExchangePlugins(ctx, newPlugins, rawConfig); err != nil {
 		return nil, err
 	}
@@ -686,6 +716,27 @@ func (nm *namespaceManager) getSharedStoragePlugins(ctx context.Context, plugins
 	return nil
 }
 
+func (nm *namespaceManager) getBlobStoragePlugins(ctx context.Context, plugins map[string]*plugin, rawConfig fftypes.JSONObject) (err error) {
+	configSize := blobstorageConfig.ArraySize()
+	rawPluginBlobStorageConfig := rawConfig.GetObject("plugins").GetObjectArray("blobstorage")
+	if len(rawPluginBlobStorageConfig) != configSize {
+		log.L(ctx).Errorf("Expected len(%d) for plugins.blobstorage: %s", configSize, rawPluginBlobStorageConfig)
+		return i18n.NewError(ctx, coremsgs.MsgConfigArrayVsRawConfigMismatch)
+	}
+	for i := 0; i < configSize; i++ {
+		config := blobstorageConfig.ArrayEntry(i)
+		pc, err := nm.validatePluginConfig(ctx, plugins, pluginCategoryBlobstorage, config, rawPluginBlobStorageConfig[i])
+		if err == nil {
+			pc.blobstorage, err = nm.blobstorageFactory(ctx, pc.pluginType)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (nm *namespaceManager) initPlugins(pluginsToStart map[string]*plugin) (err error) {
 	for name, p := range nm.plugins {
 		if pluginsToStart[name] == nil {
@@ -709,6 +760,10 @@ func (nm *namespaceManager) initPlugins(pluginsToStart map[string]*plugin) (err
 			if err = p.sharedstorage.Init(p.ctx, p.config); err != nil {
 				return err
 			}
+		case pluginCategoryBlobstorage:
+			if err = p.blobstorage.Init(p.ctx, p.config); err != nil {
+				return err
+			}
 		case pluginCategoryTokens:
 			if err = p.tokens.Init(p.ctx, nm.cancelCtx /* allow plugin to stop whole process */, name, p.config); err != nil {
 				return err
@@ -789,6 +844,28 @@ func (nm *namespaceManager) loadTLSConfig(ctx context.Context, tlsConfigs map[st
 	return nil
 }
 
+func (nm *namespaceManager) loadSigningSecrets(ctx context.Context, signingSecrets map[string][]byte, conf config.ArraySection) error {
+	signingSecretArraySize := conf.ArraySize()
+
+	for i := 0; i < signingSecretArraySize; i++ {
+		entry := conf.ArrayEntry(i)
+		name := entry.GetString(coreconfig.NamespaceSigningSecretName)
+		secret := entry.GetString(coreconfig.NamespaceSigningSecretValue)
+
+		if secret == "" {
+			return i18n.NewError(ctx, coremsgs.MsgMissingSigningSecret, name)
+		}
+
+		if signingSecrets[name] != nil {
+			return i18n.NewError(ctx, coremsgs.MsgDuplicateSigningSecret, name)
+		}
+
+		signingSecrets[name] = []byte(secret)
+	}
+
+	return nil
+}
+
 // nolint: gocyclo
 func (nm *namespaceManager) loadNamespace(ctx context.Context, name string, index int, conf config.Section, rawNSConfig fftypes.JSONObject, availablePlugins map[string]*plugin) (ns *namespace, err error) {
 	if err := fftypes.ValidateFFNameField(ctx, name, fmt.Sprintf("namespaces.predefined[%d].name", index)); err != nil {
@@ -865,6 +942,7 @@ func (nm *namespaceManager) loadNamespace(ctx context.Context, name string, inde
 				pluginCategoryDataexchange,
 				pluginCategoryIdentity,
 				pluginCategorySharedstorage,
+				pluginCategoryBlobstorage,
 				pluginCategoryTokens,
 				pluginCategoryAuth:
 				pluginNames = append(pluginNames, pluginName)
@@ -881,12 +959,28 @@ func (nm *namespaceManager) loadNamespace(ctx context.Context, name string, inde
 		return nil, err
 	}
 
+	// Handle webhook signing secrets
+	signingSecrets := make(map[string][]byte)
+	err = nm.loadSigningSecrets(ctx, signingSecrets, conf.SubArray(coreconfig.NamespaceSigningSecrets))
+	if err != nil {
+		return nil, err
+	}
+
 	config := orchestrator.Config{
 		DefaultKey:                  conf.GetString(coreconfig.NamespaceDefaultKey),
 		TokenBroadcastNames:         nm.tokenBroadcastNames,
 		KeyNormalization:            keyNormalization,
 		MaxHistoricalEventScanLimit: config.GetInt(coreconfig.SubscriptionMaxHistoricalEventScanLength),
 	}
+	retentionConf := conf.SubSection(coreconfig.NamespaceRetention)
+	config.Retention = retention.Config{
+		MaxAge:      retentionConf.GetDuration(coreconfig.NamespaceRetentionMaxAge),
+		MaxEvents:   retentionConf.GetInt64(coreconfig.NamespaceRetentionMaxEvents),
+		Interval:    retentionConf.GetDuration(coreconfig.NamespaceRetentionInterval),
+		BatchSize:   retentionConf.GetInt(coreconfig.NamespaceRetentionBatchSize),
+		Collections: retentionConf.GetStringSlice(coreconfig.NamespaceRetentionCollections),
+		ArchivePath: retentionConf.GetString(coreconfig.NamespaceRetentionArchivePath),
+	}
 	if multipartyEnabled.(bool) {
 		contractsConf := multipartyConf.SubArray(coreconfig.NamespaceMultipartyContract)
 		contractConfArraySize := contractsConf.ArraySize()
@@ -915,10 +1009,11 @@ func (nm *namespaceManager) loadNamespace(ctx context.Context, name string, inde
 
 	ns = &namespace{
 		Namespace: core.Namespace{
-			Name:        name,
-			NetworkName: networkName,
-			Description: conf.GetString(coreconfig.NamespaceDescription),
-			TLSConfigs:  tlsConfigs,
+			Name:           name,
+			NetworkName:    networkName,
+			Description:    conf.GetString(coreconfig.NamespaceDescription),
+			TLSConfigs:     tlsConfigs,
+			SigningSecrets: signingSecrets,
 		},
 		loadTime:    fftypes.Now(),
 		config:      config,
@@ -982,6 +1077,14 @@ func (nm *namespaceManager) validateNSPlugins(ctx context.Context, ns *namespace
 				Name:   pluginName,
 				Plugin: p.sharedstorage,
 			}
+		case pluginCategoryBlobstorage:
+			if result.BlobStorage.Plugin != nil {
+				return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceMultiplePluginType, ns.Name, "blobstorage")
+			}
+			result.BlobStorage = orchestrator.BlobStoragePlugin{
+				Name:   pluginName,
+				Plugin: p.blobstorage,
+			}
 		case pluginCategoryDatabase:
 			if result.Database.Plugin != nil {
 				return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceMultiplePluginType, ns.Name, "database")
@@ -39,6 +39,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/internal/blobstorage/bsfactory"
 	"github.com/hyperledger/firefly/internal/blockchain/bifactory"
 	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
@@ -50,6 +51,7 @@ import (
 	"github.com/hyperledger/firefly/internal/orchestrator"
 	"github.com/hyperledger/firefly/internal/sharedstorage/ssfactory"
 	"github.com/hyperledger/firefly/internal/tokens/tifactory"
+	"github.com/hyperledger/firefly/mocks/blobstoragemocks"
 	"github.com/hyperledger/firefly/mocks/blockchainmocks"
 	"github.com/hyperledger/firefly/mocks/cachemocks"
 	"github.com/hyperledger/firefly/mocks/databasemocks"
@@ -62,6 +64,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/sharedstoragemocks"
 	"github.com/hyperledger/firefly/mocks/spieventsmocks"
 	"github.com/hyperledger/firefly/mocks/tokenmocks"
+	"github.com/hyperledger/firefly/pkg/blobstorage"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
@@ -114,6 +117,9 @@ plugins:
     - ipfs:
       name: ipfs
       type: ipfs
+  blobstorage:
+    - name: filesystem
+      type: filesystem
   tokens:
     - name: erc721
       type: type1
@@ -136,6 +142,7 @@ type nmMocks struct {
 	mdi *databasemocks.Plugin
 	mdx *dataexchangemocks.Plugin
 	mps *sharedstoragemocks.Plugin
+	mbs *blobstoragemocks.Plugin
 	mti []*tokenmocks.Plugin
 	mei []*eventsmocks.Plugin
 	mai *authmocks.Plugin
@@ -151,6 +158,7 @@ func (nmm *nmMocks) cleanup(t *testing.T) {
 	nmm.mdi.AssertExpectations(t)
 	nmm.mdx.AssertExpectations(t)
 	nmm.mps.AssertExpectations(t)
+	nmm.mbs.AssertExpectations(t)
 	nmm.mti[0].AssertExpectations(t)
 	nmm.mti[1].AssertExpectations(t)
 	nmm.mai.AssertExpectations(t)
@@ -176,6 +184,7 @@ func mockPluginFactories(inm Manager) (nmm *nmMocks) {
 		mdi: &databasemocks.Plugin{},
 		mdx: &dataexchangemocks.Plugin{},
 		mps: &sharedstoragemocks.Plugin{},
+		mbs: &blobstoragemocks.Plugin{},
 		mti: []*tokenmocks.Plugin{{}, {}},
 		mei: []*eventsmocks.Plugin{{}, {}, {}},
 		mai: &authmocks.Plugin{},
@@ -186,6 +195,7 @@ func mockPluginFactories(inm Manager) (nmm *nmMocks) {
 	factoryMocks(&nmm.mdi.Mock, "postgres")
 	factoryMocks(&nmm.mdx.Mock, "ffdx")
 	factoryMocks(&nmm.mps.Mock, "ipfs")
+	factoryMocks(&nmm.mbs.Mock, "filesystem")
 	factoryMocks(&nmm.mti[0].Mock, "erc721")
 	factoryMocks(&nmm.mti[1].Mock, "erc1155")
 	factoryMocks(&nmm.mei[0].Mock, "system")
@@ -208,6 +218,9 @@ func mockPluginFactories(inm Manager) (nmm *nmMocks) {
 	nm.sharedstorageFactory = func(ctx context.Context, pluginType string) (sharedstorage.Plugin, error) {
 		return nmm.mps, nil
 	}
+	nm.blobstorageFactory = func(ctx context.Context, pluginType string) (blobstorage.Plugin, error) {
+		return nmm.mbs, nil
+	}
 	nm.tokensFactory = func(ctx context.Context, pluginType string) (tokens.Plugin, error) {
 		if pluginType == "type1" {
 			return nmm.mti[0], nil
@@ -275,6 +288,7 @@ func newTestNamespaceManager(t *testing.T, initConfig bool) (*namespaceManager,
 		nmm.mbi.On("Init", mock.Anything, mock.Anything, mock.Anything, nmm.mmi, mock.Anything).Return(nil).Once()
 		nmm.mdx.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 		nmm.mps.On("Init", mock.Anything, mock.Anything).Return(nil).Once()
+		nmm.mbs.On("Init", mock.Anything, mock.Anything).Return(nil).Once()
 		nmm.mti[0].On("Init", mock.Anything, mock.Anything, "erc721", mock.Anything).Return(nil).Once()
 		nmm.mti[1].On("Init", mock.Anything, mock.Anything, "erc1155", mock.Anything).Return(nil).Once()
 		nmm.mei[0].On("Init", mock.Anything, mock.Anything).Return(nil)
@@ -383,6 +397,18 @@ func TestInitSharedStorageFail(t *testing.T) {
 	assert.EqualError(t, err, "pop")
 }
 
+func TestInitBlobStorageFail(t *testing.T) {
+	nm, nmm, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	nmm.mbs.On("Init", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := nm.initPlugins(map[string]*plugin{
+		"filesystem": nm.plugins["filesystem"],
+	})
+	assert.EqualError(t, err, "pop")
+}
+
 func TestInitTokensFail(t *testing.T) {
 	nm, nmm, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
@@ -893,6 +919,34 @@ func TestSharedStoragePluginBadType(t *testing.T) {
 	assert.Regexp(t, "pop", err)
 }
 
+func TestBlobStoragePlugin(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	bsfactory.InitConfig(blobstorageConfig)
+	config.Set("plugins.blobstorage", []fftypes.JSONObject{{}})
+	blobstorageConfig.AddKnownKey(coreconfig.PluginConfigName, "flapflip")
+	blobstorageConfig.AddKnownKey(coreconfig.PluginConfigType, "filesystem")
+	plugins := make(map[string]*plugin)
+	err := nm.getBlobStoragePlugins(context.Background(), plugins, nm.dumpRootConfig())
+	assert.Equal(t, 1, len(plugins))
+	assert.NoError(t, err)
+}
+
+func TestBlobStoragePluginBadType(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	bsfactory.InitConfig(blobstorageConfig)
+	config.Set("plugins.blobstorage", []fftypes.JSONObject{{}})
+	blobstorageConfig.AddKnownKey(coreconfig.PluginConfigName, "flapflip")
+	blobstorageConfig.AddKnownKey(coreconfig.PluginConfigType, "wrong")
+
+	nm.blobstorageFactory = func(ctx context.Context, pluginType string) (blobstorage.Plugin, error) {
+		return nil, fmt.Errorf("pop")
+	}
+	_, err := nm.loadPlugins(context.Background(), nm.dumpRootConfig())
+	assert.Regexp(t, "pop", err)
+}
+
 func TestDataExchangePlugin(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, false)
 	defer cleanup()
@@ -1128,6 +1182,8 @@ func TestRawConfigCorrelation(t *testing.T) {
 	assert.Regexp(t, "FF10439", err)
 	err = nm.getSharedStoragePlugins(nm.ctx, nm.plugins, fftypes.JSONObject{})
 	assert.Regexp(t, "FF10439", err)
+	err = nm.getBlobStoragePlugins(nm.ctx, nm.plugins, fftypes.JSONObject{})
+	assert.Regexp(t, "FF10439", err)
 	err = nm.getTokensPlugins(nm.ctx, nm.plugins, fftypes.JSONObject{})
 	assert.Regexp(t, "FF10439", err)
 	err = nm.getIdentityPlugins(nm.ctx, nm.plugins, fftypes.JSONObject{})
@@ -1315,6 +1371,47 @@ func TestLoadNamespacesUseDefaults(t *testing.T) {
 	assert.Equal(t, "oldest", newNS["ns1"].config.Multiparty.Contracts[0].FirstEvent)
 }
 
+func TestLoadNamespacesRetention(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      multiparty:
+        enabled: false
+      retention:
+        maxAge: 720h
+        maxEvents: 1000000
+        collections:
+        - events
+        - operations
+        archive:
+          path: /data/archive
+    - name: ns2
+      multiparty:
+        enabled: false
+  `))
+	assert.NoError(t, err)
+
+	newNS, err := nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.NoError(t, err)
+	assert.Len(t, newNS, 2)
+	rc := newNS["ns1"].config.Retention
+	assert.True(t, rc.Enabled())
+	assert.Equal(t, 720*time.Hour, rc.MaxAge)
+	assert.Equal(t, int64(1000000), rc.MaxEvents)
+	assert.Equal(t, time.Hour, rc.Interval)
+	assert.Equal(t, 1000, rc.BatchSize)
+	assert.Equal(t, []string{"events", "operations"}, rc.Collections)
+	assert.Equal(t, "/data/archive", rc.ArchivePath)
+	assert.False(t, newNS["ns2"].config.Retention.Enabled())
+}
+
 func TestLoadTLSConfigsBadTLS(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
@@ -1517,6 +1614,79 @@ namespaces:
 	assert.Regexp(t, "FF00153", err)
 }
 
+func TestLoadSigningSecrets(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+namespaces:
+  default: ns1
+  predefined:
+  - name: ns1
+    signingSecrets:
+    - name: secret1
+      secret: shhh
+    - name: secret2
+      secret: quiet
+  `))
+	assert.NoError(t, err)
+
+	signingSecretArray := namespacePredefined.ArrayEntry(0).SubArray(coreconfig.NamespaceSigningSecrets)
+	signingSecrets := make(map[string][]byte)
+	err = nm.loadSigningSecrets(nm.ctx, signingSecrets, signingSecretArray)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("shhh"), signingSecrets["secret1"])
+	assert.Equal(t, []byte("quiet"), signingSecrets["secret2"])
+}
+
+func TestLoadSigningSecretsDuplicate(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+namespaces:
+  default: ns1
+  predefined:
+  - name: ns1
+    signingSecrets:
+    - name: secret1
+      secret: shhh
+    - name: secret1
+      secret: quiet
+  `))
+	assert.NoError(t, err)
+
+	signingSecretArray := namespacePredefined.ArrayEntry(0).SubArray(coreconfig.NamespaceSigningSecrets)
+	signingSecrets := make(map[string][]byte)
+	err = nm.loadSigningSecrets(nm.ctx, signingSecrets, signingSecretArray)
+	assert.Regexp(t, "FF10499", err)
+}
+
+func TestLoadNamespacesWithEmptySigningSecret(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+namespaces:
+  default: ns1
+  predefined:
+  - name: ns1
+    signingSecrets:
+    - name: secret1
+  `))
+	assert.NoError(t, err)
+
+	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+
+	assert.Regexp(t, "FF10500", err)
+}
+
 func TestLoadNamespacesNonMultipartyNoDatabase(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
@@ -1621,6 +1791,25 @@ func TestLoadNamespacesMultipartyMultipleSS(t *testing.T) {
 	assert.Regexp(t, "FF10394.*sharedstorage", err)
 }
 
+func TestLoadNamespacesMultipleBlobStorage(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [postgres, filesystem, filesystem]
+  `))
+	assert.NoError(t, err)
+
+	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF10394.*blobstorage", err)
+}
+
 func TestLoadNamespacesMultipartyMultipleDB(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
@@ -1930,6 +2119,22 @@ func TestWaitStop(t *testing.T) {
 	nmm.mae.AssertExpectations(t)
 }
 
+type testWaitStopDatabase struct {
+	databasemocks.Plugin
+	stopped bool
+}
+
+func (db *testWaitStopDatabase) WaitStop() {
+	db.stopped = true
+}
+
+func TestWaitStopPlugin(t *testing.T) {
+	db := &testWaitStopDatabase{}
+	waitStopPlugin(&plugin{database: db})
+	assert.True(t, db.stopped)
+	waitStopPlugin(&plugin{database: &databasemocks.Plugin{}})
+}
+
 func TestReset(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
@@ -79,6 +79,8 @@ func (nm *networkMap) generateDIDAuthentication(ctx context.Context, identity *c
 		return nm.generateEthAddressVerifier(identity, verifier)
 	case core.VerifierTypeTezosAddress:
 		return nm.generateTezosAddressVerifier(identity, verifier)
+	case core.VerifierTypeSolanaAddress:
+		return nm.generateSolanaAddressVerifier(identity, verifier)
 	case core.VerifierTypeMSPIdentity:
 		return nm.generateMSPVerifier(identity, verifier)
 	case core.VerifierTypeFFDXPeerID:
@@ -107,6 +109,15 @@ func (nm *networkMap) generateTezosAddressVerifier(identity *core.Identity, veri
 	}
 }
 
+func (nm *networkMap) generateSolanaAddressVerifier(identity *core.Identity, verifier *core.Verifier) *VerificationMethod {
+	return &VerificationMethod{
+		ID:                  verifier.Hash.String(),
+		Type:                "Ed25519VerificationKey2020",
+		Controller:          identity.DID,
+		BlockchainAccountID: verifier.Value,
+	}
+}
+
 func (nm *networkMap) generateMSPVerifier(identity *core.Identity, verifier *core.Verifier) *VerificationMethod {
 	return &VerificationMethod{
 		ID:                verifier.Hash.String(),
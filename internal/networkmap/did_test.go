@@ -52,6 +52,15 @@ func TestDIDGenerationOK(t *testing.T) {
 		},
 		Created: fftypes.Now(),
 	}).Seal()
+	verifierSolana := (&core.Verifier{
+		Identity:  org1.ID,
+		Namespace: org1.Namespace,
+		VerifierRef: core.VerifierRef{
+			Type:  core.VerifierTypeSolanaAddress,
+			Value: "4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T",
+		},
+		Created: fftypes.Now(),
+	}).Seal()
 	verifierMSP := (&core.Verifier{
 		Identity:  org1.ID,
 		Namespace: org1.Namespace,
@@ -85,6 +94,7 @@ func TestDIDGenerationOK(t *testing.T) {
 	mdi.On("GetVerifiers", nm.ctx, "ns1", mock.Anything).Return([]*core.Verifier{
 		verifierEth,
 		verifierTezos,
+		verifierSolana,
 		verifierMSP,
 		verifierDX,
 		verifierUnknown,
@@ -111,6 +121,12 @@ func TestDIDGenerationOK(t *testing.T) {
 				Controller:          org1.DID,
 				BlockchainAccountID: verifierTezos.Value,
 			},
+			{
+				ID:                  verifierSolana.Hash.String(),
+				Type:                "Ed25519VerificationKey2020",
+				Controller:          org1.DID,
+				BlockchainAccountID: verifierSolana.Value,
+			},
 			{
 				ID:                verifierMSP.Hash.String(),
 				Type:              "HyperledgerFabricMSPIdentity",
@@ -127,6 +143,7 @@ func TestDIDGenerationOK(t *testing.T) {
 		Authentication: []string{
 			fmt.Sprintf("#%s", verifierEth.Hash.String()),
 			fmt.Sprintf("#%s", verifierTezos.Hash.String()),
+			fmt.Sprintf("#%s", verifierSolana.Hash.String()),
 			fmt.Sprintf("#%s", verifierMSP.Hash.String()),
 			fmt.Sprintf("#%s", verifierDX.Hash.String()),
 		},
@@ -0,0 +1,385 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsarchive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"hash"
+	"io"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// FormatVersion is the version of the archive format written by Export.
+// Import rejects archives with a different version.
+const FormatVersion = 1
+
+const (
+	defaultPageSize = 500
+	maxLineSize     = 64 * 1024 * 1024
+)
+
+type entryType string
+
+const (
+	entryTypeHeader  entryType = "header"
+	entryTypeRecord  entryType = "record"
+	entryTypeTrailer entryType = "trailer"
+)
+
+// entry is a single line of the NDJSON archive. The first line is a header, the
+// last line is a trailer, and every line in between is a record.
+type entry struct {
+	Type        entryType        `json:"type"`
+	Version     int              `json:"version,omitempty"`
+	Namespace   string           `json:"namespace,omitempty"`
+	Created     *fftypes.FFTime  `json:"created,omitempty"`
+	Collections []string         `json:"collections,omitempty"`
+	Collection  string           `json:"collection,omitempty"`
+	Hash        *fftypes.Bytes32 `json:"hash,omitempty"`
+	Value       json.RawMessage  `json:"value,omitempty"`
+	Counts      map[string]int64 `json:"counts,omitempty"`
+}
+
+// Summary describes the contents of an archive that was written, verified or imported
+type Summary struct {
+	Namespace string           `json:"namespace"`
+	Version   int              `json:"version"`
+	Created   *fftypes.FFTime  `json:"created"`
+	Counts    map[string]int64 `json:"counts"`
+	Hash      *fftypes.Bytes32 `json:"hash"`
+}
+
+// Archiver exports the contents of one namespace to a portable archive, and restores
+// such an archive into another database.
+//
+// The archive is a gzip compressed file of newline delimited JSON. Each record carries
+// a SHA-256 hash of its value, and the trailer carries the count of records in each
+// collection along with a SHA-256 hash over every line that precedes it.
+type Archiver interface {
+	// Export writes every record in the namespace to the supplied writer
+	Export(ctx context.Context, w io.Writer) (*Summary, error)
+
+	// Verify reads a complete archive and checks its structure and hashes, without writing to the database
+	Verify(ctx context.Context, r io.Reader) (*Summary, error)
+
+	// Import writes the records of an archive into the namespace, which must be empty.
+	// The records are written in a single database transaction, which is rolled back if any
+	// check fails, including the check of the trailer at the end of the archive.
+	Import(ctx context.Context, r io.Reader) (*Summary, error)
+}
+
+type archiver struct {
+	namespace   string
+	database    database.Plugin
+	pageSize    int
+	collections []*collection
+}
+
+func NewArchiver(ctx context.Context, ns string, di database.Plugin) (Archiver, error) {
+	if di == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "Archiver")
+	}
+	a := &archiver{
+		namespace: ns,
+		database:  di,
+		pageSize:  defaultPageSize,
+	}
+	a.collections = a.allCollections()
+	return a, nil
+}
+
+func (a *archiver) collectionNames() []string {
+	names := make([]string, len(a.collections))
+	for i, c := range a.collections {
+		names[i] = c.name
+	}
+	return names
+}
+
+func (a *archiver) getCollection(name string) *collection {
+	for _, c := range a.collections {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// archiveWriter writes lines to the archive, hashing everything it writes. The first error is
+// kept, and every write after it is skipped, so the caller only needs to check err after a batch of writes.
+type archiveWriter struct {
+	gz     *gzip.Writer
+	digest hash.Hash
+	err    error
+}
+
+func (aw *archiveWriter) write(e *entry) {
+	if aw.err != nil {
+		return
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		aw.err = err
+		return
+	}
+	b = append(b, '\n')
+	aw.digest.Write(b)
+	_, aw.err = aw.gz.Write(b)
+}
+
+// close writes the trailer and closes the compressor, returning the first error of any write
+func (aw *archiveWriter) close(trailer *entry) error {
+	aw.write(trailer)
+	if aw.err == nil {
+		aw.err = aw.gz.Close()
+	}
+	return aw.err
+}
+
+func (a *archiver) Export(ctx context.Context, w io.Writer) (*Summary, error) {
+	aw := &archiveWriter{
+		gz:     gzip.NewWriter(w),
+		digest: sha256.New(),
+	}
+	summary := &Summary{
+		Namespace: a.namespace,
+		Version:   FormatVersion,
+		Created:   fftypes.Now(),
+		Counts:    make(map[string]int64),
+	}
+	aw.write(&entry{
+		Type:        entryTypeHeader,
+		Version:     FormatVersion,
+		Namespace:   a.namespace,
+		Created:     summary.Created,
+		Collections: a.collectionNames(),
+	})
+
+	for _, c := range a.collections {
+		summary.Counts[c.name] = 0
+		for skip := 0; ; skip += a.pageSize {
+			records, err := c.list(ctx, a.namespace, skip, a.pageSize)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range records {
+				b, err := json.Marshal(r)
+				if err != nil {
+					return nil, err
+				}
+				var h fftypes.Bytes32 = sha256.Sum256(b)
+				aw.write(&entry{
+					Type:       entryTypeRecord,
+					Collection: c.name,
+					Hash:       &h,
+					Value:      b,
+				})
+			}
+			if aw.err != nil {
+				return nil, aw.err
+			}
+			summary.Counts[c.name] += int64(len(records))
+			if len(records) < a.pageSize {
+				break
+			}
+		}
+		log.L(ctx).Infof("Exported %d %s records from namespace '%s'", summary.Counts[c.name], c.name, a.namespace)
+	}
+
+	var archiveHash fftypes.Bytes32
+	copy(archiveHash[:], aw.digest.Sum(nil))
+	summary.Hash = &archiveHash
+	if err := aw.close(&entry{
+		Type:   entryTypeTrailer,
+		Hash:   summary.Hash,
+		Counts: summary.Counts,
+	}); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// archiveReader reads the archive a line at a time, checking the structure and hashes,
+// and calling back for each record
+type archiveReader struct {
+	a       *archiver
+	summary *Summary
+	line    int
+}
+
+func (ar *archiveReader) invalid(ctx context.Context, reason string) error {
+	return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalid, ar.line, reason)
+}
+
+func (ar *archiveReader) read(ctx context.Context, r io.Reader, onRecord func(c *collection, value []byte) error) (*Summary, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgNamespaceArchiveInvalid, 0, "not a gzip file")
+	}
+	defer gz.Close()
+
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	digest := sha256.New()
+	counts := make(map[string]int64)
+	for _, c := range ar.a.collections {
+		counts[c.name] = 0
+	}
+	var trailer *entry
+	for scanner.Scan() {
+		ar.line++
+		b := scanner.Bytes()
+		if trailer != nil {
+			return nil, ar.invalid(ctx, "data after trailer")
+		}
+		var e entry
+		if err := json.Unmarshal(b, &e); err != nil {
+			return nil, ar.invalid(ctx, err.Error())
+		}
+		switch {
+		case ar.line == 1:
+			if err := ar.checkHeader(ctx, &e); err != nil {
+				return nil, err
+			}
+		case e.Type == entryTypeRecord:
+			c := ar.a.getCollection(e.Collection)
+			if c == nil {
+				return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveUnknownCollection, e.Collection)
+			}
+			if e.Value == nil || e.Hash == nil {
+				return nil, ar.invalid(ctx, "record missing value or hash")
+			}
+			value := []byte(e.Value)
+			var h fftypes.Bytes32 = sha256.Sum256(value)
+			if !h.Equals(e.Hash) {
+				return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveHashMismatch, e.Collection, ar.line)
+			}
+			if err := onRecord(c, value); err != nil {
+				return nil, err
+			}
+			counts[c.name]++
+		case e.Type == entryTypeTrailer:
+			trailer = &e
+			continue
+		default:
+			return nil, ar.invalid(ctx, "unexpected entry type '"+string(e.Type)+"'")
+		}
+		digest.Write(b)
+		digest.Write([]byte{'\n'})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, ar.invalid(ctx, err.Error())
+	}
+	if ar.line == 0 {
+		return nil, ar.invalid(ctx, "empty archive")
+	}
+	if trailer == nil || trailer.Hash == nil {
+		return nil, ar.invalid(ctx, "missing trailer")
+	}
+
+	var archiveHash fftypes.Bytes32
+	copy(archiveHash[:], digest.Sum(nil))
+	if !archiveHash.Equals(trailer.Hash) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveChecksumMismatch)
+	}
+	for _, c := range ar.a.collections {
+		if counts[c.name] != trailer.Counts[c.name] {
+			return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveCountMismatch, trailer.Counts[c.name], c.name, counts[c.name])
+		}
+	}
+	ar.summary.Counts = counts
+	ar.summary.Hash = &archiveHash
+	return ar.summary, nil
+}
+
+func (ar *archiveReader) checkHeader(ctx context.Context, e *entry) error {
+	if e.Type != entryTypeHeader {
+		return ar.invalid(ctx, "missing header")
+	}
+	if e.Version != FormatVersion {
+		return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveVersion, e.Version, FormatVersion)
+	}
+	if e.Namespace != ar.a.namespace {
+		return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveWrongNamespace, e.Namespace, ar.a.namespace)
+	}
+	ar.summary = &Summary{
+		Namespace: e.Namespace,
+		Version:   e.Version,
+		Created:   e.Created,
+	}
+	return nil
+}
+
+func (a *archiver) Verify(ctx context.Context, r io.Reader) (*Summary, error) {
+	ar := &archiveReader{a: a}
+	return ar.read(ctx, r, func(c *collection, value []byte) error {
+		return c.verify(ctx, value)
+	})
+}
+
+func (a *archiver) Import(ctx context.Context, r io.Reader) (*Summary, error) {
+	for _, c := range a.collections {
+		if c.allowExisting {
+			continue
+		}
+		records, err := c.list(ctx, a.namespace, 0, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(records) > 0 {
+			return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveTargetNotEmpty, a.namespace, c.name)
+		}
+	}
+
+	is := newImportState()
+	ar := &archiveReader{a: a}
+	var summary *Summary
+	err := a.database.RunAsGroup(ctx, func(ctx context.Context) (err error) {
+		summary, err = ar.read(ctx, r, func(c *collection, value []byte) error {
+			if err := c.verify(ctx, value); err != nil {
+				return err
+			}
+			return c.restore(ctx, is, value)
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Events are only allocated their sequence in the target database on commit, so the
+	// subscription offsets that refer to them are written afterwards
+	for _, offset := range is.offsets {
+		offset.Current = is.eventSequence(offset.Current)
+		if err := a.database.UpsertOffset(ctx, offset, false); err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range a.collections {
+		log.L(ctx).Infof("Imported %d %s records into namespace '%s'", summary.Counts[c.name], c.name, a.namespace)
+	}
+	return summary, nil
+}
@@ -0,0 +1,554 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsarchive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/dbsql"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/database/sqlcommon"
+	"github.com/hyperledger/firefly/internal/database/sqlite3"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestSQLite(t *testing.T, name string) (*sqlite3.SQLite3, func()) {
+	coreconfig.Reset()
+	db := &sqlite3.SQLite3{}
+	conf := config.RootSection("unittest." + name)
+	db.InitConfig(conf)
+	conf.Set(sqlcommon.SQLConfDatasourceURL, "file::memory:")
+	conf.Set(sqlcommon.SQLConfMigrationsAuto, true)
+	conf.Set(sqlcommon.SQLConfMigrationsDirectory, "../../db/migrations/sqlite")
+	conf.Set(dbsql.SQLConfMaxConnections, 1)
+	err := db.Init(context.Background(), conf)
+	assert.NoError(t, err)
+	return db, db.Close
+}
+
+func newTestArchiver(t *testing.T) (*archiver, *databasemocks.Plugin) {
+	mdi := &databasemocks.Plugin{}
+	a, err := NewArchiver(context.Background(), "ns1", mdi)
+	assert.NoError(t, err)
+	return a.(*archiver), mdi
+}
+
+// buildArchive writes the supplied entries to an archive, filling in the hash of any
+// record or trailer that does not have one
+func buildArchive(t *testing.T, entries ...*entry) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	aw := &archiveWriter{gz: gzip.NewWriter(buf), digest: sha256.New()}
+	for _, e := range entries {
+		if e.Hash == nil {
+			switch e.Type {
+			case entryTypeRecord:
+				var h fftypes.Bytes32 = sha256.Sum256(e.Value)
+				e.Hash = &h
+			case entryTypeTrailer:
+				var h fftypes.Bytes32
+				copy(h[:], aw.digest.Sum(nil))
+				e.Hash = &h
+			}
+		}
+		aw.write(e)
+	}
+	err := aw.gz.Close()
+	assert.NoError(t, aw.err)
+	assert.NoError(t, err)
+	return buf
+}
+
+func testHeader() *entry {
+	return &entry{Type: entryTypeHeader, Version: FormatVersion, Namespace: "ns1"}
+}
+
+func testRecord(collection, value string) *entry {
+	return &entry{Type: entryTypeRecord, Collection: collection, Value: json.RawMessage(value)}
+}
+
+func seedNamespace(t *testing.T, ctx context.Context, db database.Plugin) (sub *core.Subscription, events []*core.Event) {
+	err := db.UpsertNamespace(ctx, &core.Namespace{
+		Name:        "ns1",
+		NetworkName: "ns1",
+		Created:     fftypes.Now(),
+		Contracts: &core.MultipartyContracts{
+			Active: &core.MultipartyContract{Index: 1, Location: fftypes.JSONAnyPtr(`{"address":"0x123"}`)},
+		},
+	}, false)
+	assert.NoError(t, err)
+
+	err = db.UpsertDatatype(ctx, &core.Datatype{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Name:      "widget",
+		Version:   "1.0",
+		Message:   fftypes.NewUUID(),
+		Hash:      fftypes.NewRandB32(),
+		Created:   fftypes.Now(),
+		Value:     fftypes.JSONAnyPtr(`{}`),
+	}, false)
+	assert.NoError(t, err)
+
+	ffiID := fftypes.NewUUID()
+	_, err = db.InsertOrGetFFI(ctx, &fftypes.FFI{ID: ffiID, Namespace: "ns1", Name: "counter", Version: "1.0"})
+	assert.NoError(t, err)
+	err = db.UpsertFFIMethod(ctx, &fftypes.FFIMethod{ID: fftypes.NewUUID(), Interface: ffiID, Namespace: "ns1", Name: "inc", Pathname: "inc"})
+	assert.NoError(t, err)
+	err = db.UpsertFFIEvent(ctx, &fftypes.FFIEvent{ID: fftypes.NewUUID(), Interface: ffiID, Namespace: "ns1", Pathname: "changed", FFIEventDefinition: fftypes.FFIEventDefinition{Name: "changed"}})
+	assert.NoError(t, err)
+	err = db.UpsertFFIError(ctx, &fftypes.FFIError{ID: fftypes.NewUUID(), Interface: ffiID, Namespace: "ns1", Pathname: "overflow", FFIErrorDefinition: fftypes.FFIErrorDefinition{Name: "overflow"}})
+	assert.NoError(t, err)
+	_, err = db.InsertOrGetContractAPI(ctx, &core.ContractAPI{ID: fftypes.NewUUID(), Namespace: "ns1", Name: "counter", Interface: &fftypes.FFIReference{ID: ffiID}})
+	assert.NoError(t, err)
+	err = db.InsertContractListener(ctx, &core.ContractListener{ID: fftypes.NewUUID(), Namespace: "ns1", Name: "listener1", BackendID: "sb-1", Interface: &fftypes.FFIReference{ID: ffiID},
+		Event: &core.FFISerializedEvent{FFIEventDefinition: fftypes.FFIEventDefinition{Name: "changed"}},
+	})
+	assert.NoError(t, err)
+
+	org := &core.Identity{
+		IdentityBase: core.IdentityBase{ID: fftypes.NewUUID(), DID: "did:firefly:org/org1", Namespace: "ns1", Name: "org1", Type: core.IdentityTypeOrg},
+	}
+	err = db.UpsertIdentity(ctx, org, database.UpsertOptimizationNew)
+	assert.NoError(t, err)
+	err = db.UpsertVerifier(ctx, (&core.Verifier{Identity: org.ID, Namespace: "ns1", VerifierRef: core.VerifierRef{Type: core.VerifierTypeEthAddress, Value: "0x12345"}}).Seal(), database.UpsertOptimizationNew)
+	assert.NoError(t, err)
+	group := &core.Group{
+		GroupIdentity:  core.GroupIdentity{Namespace: "ns1", Name: "group1", Members: core.Members{{Identity: org.DID, Node: fftypes.NewUUID()}}},
+		LocalNamespace: "ns1",
+		Created:        fftypes.Now(),
+	}
+	group.Seal()
+	err = db.UpsertGroup(ctx, group, database.UpsertOptimizationNew)
+	assert.NoError(t, err)
+
+	txID := fftypes.NewUUID()
+	err = db.InsertTransaction(ctx, &core.Transaction{ID: txID, Namespace: "ns1", Type: core.TransactionTypeBatchPin})
+	assert.NoError(t, err)
+
+	blob := &core.Blob{Namespace: "ns1", Hash: fftypes.NewRandB32(), PayloadRef: "ns1/blob1", Size: 12, Created: fftypes.Now()}
+	data := &core.Data{ID: fftypes.NewUUID(), Namespace: "ns1", Value: fftypes.JSONAnyPtr(`{"some":"data"}`), Blob: &core.BlobRef{Hash: blob.Hash}}
+	err = data.Seal(ctx, blob)
+	assert.NoError(t, err)
+	blob.DataID = data.ID
+	err = db.InsertBlob(ctx, blob)
+	assert.NoError(t, err)
+	err = db.UpsertData(ctx, data, database.UpsertOptimizationNew)
+	assert.NoError(t, err)
+
+	msg := &core.Message{
+		Header:         core.MessageHeader{Namespace: "ns1", Type: core.MessageTypeBroadcast, TxType: core.TransactionTypeBatchPin, Topics: fftypes.FFStringArray{"topic1"}},
+		LocalNamespace: "ns1",
+		Data:           core.DataRefs{{ID: data.ID, Hash: data.Hash}},
+		State:          core.MessageStateConfirmed,
+	}
+	err = msg.Seal(ctx)
+	assert.NoError(t, err)
+	batchID := fftypes.NewUUID()
+	msg.BatchID = batchID
+	msg.TransactionID = txID
+	_, err = db.InsertOrGetBatch(ctx, &core.BatchPersisted{
+		BatchHeader: core.BatchHeader{ID: batchID, Type: core.BatchTypeBroadcast, Namespace: "ns1", Created: fftypes.Now()},
+		Hash:        fftypes.NewRandB32(),
+		TX:          core.TransactionRef{ID: txID, Type: core.TransactionTypeBatchPin},
+		Manifest:    fftypes.JSONAnyPtr(`{}`),
+	})
+	assert.NoError(t, err)
+	err = db.UpsertMessage(ctx, msg, database.UpsertOptimizationNew)
+	assert.NoError(t, err)
+
+	err = db.InsertPins(ctx, []*core.Pin{{Namespace: "ns1", Hash: fftypes.NewRandB32(), Batch: batchID, BatchHash: fftypes.NewRandB32(), Signer: "0x12345", Dispatched: true, Created: fftypes.Now()}})
+	assert.NoError(t, err)
+	err = db.InsertNextPin(ctx, &core.NextPin{Namespace: "ns1", Context: fftypes.NewRandB32(), Identity: org.DID, Hash: fftypes.NewRandB32(), Nonce: 1})
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		event := core.NewEvent(core.EventTypeMessageConfirmed, "ns1", msg.Header.ID, txID, "topic1")
+		err = db.InsertEvent(ctx, event)
+		assert.NoError(t, err)
+		events = append(events, event)
+	}
+
+	sub = &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Namespace: "ns1", Name: "sub1"},
+		Transport:       "websockets",
+		Created:         fftypes.Now(),
+	}
+	err = db.UpsertSubscription(ctx, sub, false)
+	assert.NoError(t, err)
+	err = db.UpsertOffset(ctx, &core.Offset{Type: core.OffsetTypeSubscription, Name: sub.ID.String(), Current: events[1].Sequence}, false)
+	assert.NoError(t, err)
+	return sub, events
+}
+
+func TestNewArchiverMissingDeps(t *testing.T) {
+	_, err := NewArchiver(context.Background(), "ns1", nil)
+	assert.Regexp(t, "FF10128", err)
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source, closeSource := newTestSQLite(t, "source")
+	defer closeSource()
+	sub, events := seedNamespace(t, ctx, source)
+
+	a, err := NewArchiver(ctx, "ns1", source)
+	assert.NoError(t, err)
+	a.(*archiver).pageSize = 2
+	buf := &bytes.Buffer{}
+	exported, err := a.Export(ctx, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), exported.Counts["namespace"])
+	assert.Equal(t, int64(1), exported.Counts["ffis"])
+	assert.Equal(t, int64(1), exported.Counts["messages"])
+	assert.Equal(t, int64(3), exported.Counts["events"])
+	assert.Equal(t, int64(1), exported.Counts["offsets"])
+	archive := buf.Bytes()
+
+	target, closeTarget := newTestSQLite(t, "target")
+	defer closeTarget()
+	// Events from another namespace move the sequences in the target database
+	for i := 0; i < 5; i++ {
+		err = target.InsertEvent(ctx, core.NewEvent(core.EventTypeMessageConfirmed, "ns2", fftypes.NewUUID(), nil, "topic1"))
+		assert.NoError(t, err)
+	}
+
+	a, err = NewArchiver(ctx, "ns1", target)
+	assert.NoError(t, err)
+	verified, err := a.Verify(ctx, bytes.NewReader(archive))
+	assert.NoError(t, err)
+	assert.Equal(t, exported.Counts, verified.Counts)
+	assert.Equal(t, exported.Hash, verified.Hash)
+
+	imported, err := a.Import(ctx, bytes.NewReader(archive))
+	assert.NoError(t, err)
+	assert.Equal(t, exported.Counts, imported.Counts)
+
+	ns, err := target.GetNamespace(ctx, "ns1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ns.Contracts.Active.Index)
+
+	ffi, err := target.GetFFI(ctx, "ns1", "counter", "1.0")
+	assert.NoError(t, err)
+	methods, _, err := target.GetFFIMethods(ctx, "ns1", database.FFIMethodQueryFactory.NewFilter(ctx).Eq("interface", ffi.ID))
+	assert.NoError(t, err)
+	assert.Len(t, methods, 1)
+
+	msgs, _, err := target.GetMessages(ctx, "ns1", database.MessageQueryFactory.NewFilter(ctx).And())
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 1)
+	assert.NoError(t, msgs[0].Verify(ctx))
+
+	importedEvents, _, err := target.GetEvents(ctx, "ns1", database.EventQueryFactory.NewFilter(ctx).And().Sort("sequence"))
+	assert.NoError(t, err)
+	assert.Len(t, importedEvents, 3)
+	assert.Equal(t, events[1].ID, importedEvents[1].ID)
+	assert.NotEqual(t, events[1].Sequence, importedEvents[1].Sequence)
+
+	offset, err := target.GetOffset(ctx, core.OffsetTypeSubscription, sub.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, importedEvents[1].Sequence, offset.Current)
+
+	// A second import is rejected, as the namespace is no longer empty
+	_, err = a.Import(ctx, bytes.NewReader(archive))
+	assert.Regexp(t, "FF10533.*datatypes", err)
+}
+
+func TestImportRollbackOnBadTrailer(t *testing.T) {
+	ctx := context.Background()
+	target, closeTarget := newTestSQLite(t, "rollback")
+	defer closeTarget()
+
+	a, err := NewArchiver(ctx, "ns1", target)
+	assert.NoError(t, err)
+	dt := `{"id":"` + fftypes.NewUUID().String() + `","message":"` + fftypes.NewUUID().String() + `","namespace":"ns1","name":"widget","version":"1.0","hash":"` + fftypes.NewRandB32().String() + `","created":"2026-01-01T00:00:00Z","value":{}}`
+	archive := buildArchive(t,
+		testHeader(),
+		testRecord("datatypes", dt),
+		&entry{Type: entryTypeTrailer, Counts: map[string]int64{"datatypes": 2}},
+	)
+	_, err = a.Import(ctx, archive)
+	assert.Regexp(t, "FF10532", err)
+
+	datatypes, _, err := target.GetDatatypes(ctx, "ns1", database.DatatypeQueryFactory.NewFilter(ctx).And())
+	assert.NoError(t, err)
+	assert.Empty(t, datatypes)
+}
+
+func TestImportEmptyCheckFail(t *testing.T) {
+	a, mdi := newTestArchiver(t)
+	mdi.On("GetDatatypes", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	_, err := a.Import(context.Background(), &bytes.Buffer{})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestImportOffsetFail(t *testing.T) {
+	a, mdi := newTestArchiver(t)
+	a.collections = []*collection{a.getCollection("offsets")}
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return([]*core.Subscription{}, nil, nil)
+	mdi.On("RunAsGroup", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	mdi.On("UpsertOffset", mock.Anything, mock.Anything, false).Return(fmt.Errorf("pop"))
+	archive := buildArchive(t,
+		testHeader(),
+		testRecord("offsets", `{"type":"subscription","name":"sub1","current":10}`),
+		&entry{Type: entryTypeTrailer, Counts: map[string]int64{"offsets": 1}},
+	)
+	_, err := a.Import(context.Background(), archive)
+	assert.EqualError(t, err, "pop")
+	mdi.AssertExpectations(t)
+}
+
+func TestExportListFail(t *testing.T) {
+	a, mdi := newTestArchiver(t)
+	mdi.On("GetNamespace", mock.Anything, "ns1").Return(nil, fmt.Errorf("pop"))
+	_, err := a.Export(context.Background(), &bytes.Buffer{})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestExportMarshalFail(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	a.collections = []*collection{{
+		name: "bad",
+		list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+			return []interface{}{map[bool]bool{false: true}}, nil
+		},
+	}}
+	_, err := a.Export(context.Background(), &bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+type errorWriter struct{}
+
+func (ew *errorWriter) Write(p []byte) (int, error) {
+	return 0, fmt.Errorf("pop")
+}
+
+// headerOnlyWriter accepts the gzip header, which is written on the first write, and fails every write after it
+type headerOnlyWriter struct {
+	writes int
+}
+
+func (hw *headerOnlyWriter) Write(p []byte) (int, error) {
+	hw.writes++
+	if hw.writes > 1 {
+		return 0, fmt.Errorf("pop")
+	}
+	return len(p), nil
+}
+
+func TestExportWriteFail(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	a.collections = []*collection{{
+		name: "big",
+		list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+			// Enough data that does not compress to force the compressor to flush to the writer
+			values := make([]interface{}, 10000)
+			for i := range values {
+				values[i] = fftypes.NewRandB32()
+			}
+			return values, nil
+		},
+	}}
+	_, err := a.Export(context.Background(), &headerOnlyWriter{})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestArchiveWriterMarshalFail(t *testing.T) {
+	aw := &archiveWriter{gz: gzip.NewWriter(&bytes.Buffer{}), digest: sha256.New()}
+	aw.write(&entry{Type: entryTypeRecord, Value: json.RawMessage(`!json`)})
+	assert.Error(t, aw.err)
+	aw.write(testHeader())
+	assert.Regexp(t, "invalid character", aw.close(&entry{Type: entryTypeTrailer}))
+}
+
+func TestExportCloseFail(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	a.collections = []*collection{}
+	_, err := a.Export(context.Background(), &errorWriter{})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyNotGzip(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	_, err := a.Verify(context.Background(), bytes.NewReader([]byte("not gzip")))
+	assert.Regexp(t, "FF10526.*line 0", err)
+}
+
+func TestVerifyEmpty(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	_, err := a.Verify(context.Background(), buildArchive(t))
+	assert.Regexp(t, "FF10526.*empty archive", err)
+}
+
+func TestVerifyBadJSON(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	_, _ = gz.Write([]byte("!json\n"))
+	_ = gz.Close()
+	_, err := a.Verify(context.Background(), buf)
+	assert.Regexp(t, "FF10526.*line 1", err)
+}
+
+func TestVerifyLineTooLong(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	_, _ = gz.Write(make([]byte, maxLineSize+1))
+	_ = gz.Close()
+	_, err := a.Verify(context.Background(), buf)
+	assert.Regexp(t, "FF10526.*too long", err)
+}
+
+func TestVerifyMissingHeader(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	_, err := a.Verify(context.Background(), buildArchive(t, testRecord("datatypes", `{}`)))
+	assert.Regexp(t, "FF10526.*missing header", err)
+}
+
+func TestVerifyBadVersion(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	_, err := a.Verify(context.Background(), buildArchive(t, &entry{Type: entryTypeHeader, Version: 99, Namespace: "ns1"}))
+	assert.Regexp(t, "FF10527", err)
+}
+
+func TestVerifyWrongNamespace(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	_, err := a.Verify(context.Background(), buildArchive(t, &entry{Type: entryTypeHeader, Version: FormatVersion, Namespace: "ns2"}))
+	assert.Regexp(t, "FF10528", err)
+}
+
+func TestVerifyUnknownCollection(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	_, err := a.Verify(context.Background(), buildArchive(t, testHeader(), testRecord("unknown", `{}`)))
+	assert.Regexp(t, "FF10529", err)
+}
+
+func TestVerifyMissingValue(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	_, err := a.Verify(context.Background(), buildArchive(t, testHeader(), &entry{Type: entryTypeRecord, Collection: "datatypes"}))
+	assert.Regexp(t, "FF10526.*missing value", err)
+}
+
+func TestVerifyRecordHashMismatch(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	record := testRecord("datatypes", `{}`)
+	record.Hash = fftypes.NewRandB32()
+	_, err := a.Verify(context.Background(), buildArchive(t, testHeader(), record))
+	assert.Regexp(t, "FF10530", err)
+}
+
+func TestVerifyBadEntryType(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	_, err := a.Verify(context.Background(), buildArchive(t, testHeader(), &entry{Type: "wrong"}))
+	assert.Regexp(t, "FF10526.*wrong", err)
+}
+
+func TestVerifyMissingTrailer(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	_, err := a.Verify(context.Background(), buildArchive(t, testHeader(), testRecord("datatypes", `{}`)))
+	assert.Regexp(t, "FF10526.*missing trailer", err)
+}
+
+func TestVerifyDataAfterTrailer(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	_, err := a.Verify(context.Background(), buildArchive(t, testHeader(), &entry{Type: entryTypeTrailer}, testRecord("datatypes", `{}`)))
+	assert.Regexp(t, "FF10526.*after trailer", err)
+}
+
+func TestVerifyChecksumMismatch(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	_, err := a.Verify(context.Background(), buildArchive(t, testHeader(), &entry{Type: entryTypeTrailer, Hash: fftypes.NewRandB32()}))
+	assert.Regexp(t, "FF10531", err)
+}
+
+func TestVerifyCountMismatch(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	_, err := a.Verify(context.Background(), buildArchive(t,
+		testHeader(),
+		testRecord("datatypes", `{}`),
+		&entry{Type: entryTypeTrailer, Counts: map[string]int64{"datatypes": 2}},
+	))
+	assert.Regexp(t, "FF10532.*2 datatypes.*found 1", err)
+}
+
+func TestImportTruncated(t *testing.T) {
+	ctx := context.Background()
+	source, closeSource := newTestSQLite(t, "truncatedsource")
+	defer closeSource()
+	seedNamespace(t, ctx, source)
+	a, err := NewArchiver(ctx, "ns1", source)
+	assert.NoError(t, err)
+	buf := &bytes.Buffer{}
+	_, err = a.Export(ctx, buf)
+	assert.NoError(t, err)
+
+	target, closeTarget := newTestSQLite(t, "truncatedtarget")
+	defer closeTarget()
+	a, err = NewArchiver(ctx, "ns1", target)
+	assert.NoError(t, err)
+	_, err = a.Import(ctx, bytes.NewReader(buf.Bytes()[0:buf.Len()/2]))
+	assert.Error(t, err)
+
+	// Nothing from the truncated archive was imported
+	datatypes, _, err := target.GetDatatypes(ctx, "ns1", database.DatatypeQueryFactory.NewFilter(ctx).And())
+	assert.NoError(t, err)
+	assert.Empty(t, datatypes)
+}
+
+func TestImportNonEmptyNamespace(t *testing.T) {
+	ctx := context.Background()
+	target, closeTarget := newTestSQLite(t, "nonempty")
+	defer closeTarget()
+	seedNamespace(t, ctx, target)
+
+	a, err := NewArchiver(ctx, "ns1", target)
+	assert.NoError(t, err)
+	_, err = a.Import(ctx, buildArchive(t, testHeader(), &entry{Type: entryTypeTrailer}))
+	assert.Regexp(t, "FF10533.*ns1.*datatypes", err)
+}
+
+func TestImportCheckFail(t *testing.T) {
+	a, mdi := newTestArchiver(t)
+	a.collections = []*collection{a.getCollection("messages")}
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{}, nil, nil)
+	mdi.On("RunAsGroup", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	_, err := a.Import(context.Background(), buildArchive(t, testHeader(), testRecord("messages", `{"hash":"`+fftypes.NewRandB32().String()+`"}`)))
+	assert.Regexp(t, "FF10535", err)
+	mdi.AssertExpectations(t)
+}
+
+func TestVerifyCheckFail(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	_, err := a.Verify(context.Background(), buildArchive(t, testHeader(), testRecord("messages", `{"hash":"`+fftypes.NewRandB32().String()+`"}`)))
+	assert.Regexp(t, "FF10535", err)
+}
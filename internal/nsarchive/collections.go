@@ -0,0 +1,536 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsarchive
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// collection describes how to list the records of one collection for export, and how to
+// check and restore each record on import
+type collection struct {
+	name          string
+	allowExisting bool // the target is not required to be empty
+	list          func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error)
+	check         func(ctx context.Context, value []byte) error // optional
+	restore       func(ctx context.Context, is *importState, value []byte) error
+}
+
+func (c *collection) verify(ctx context.Context, value []byte) error {
+	if c.check == nil {
+		return nil
+	}
+	return c.check(ctx, value)
+}
+
+// namespaceRecord carries the multiparty contract state of the namespace, which is
+// not part of the JSON serialization of core.Namespace
+type namespaceRecord struct {
+	Namespace *core.Namespace           `json:"namespace"`
+	Contracts *core.MultipartyContracts `json:"contracts,omitempty"`
+}
+
+// sequenceMapping records the sequence an event had in the source database, and the
+// event as inserted into the target database
+type sequenceMapping struct {
+	source int64
+	event  *core.Event
+}
+
+// importState holds the information carried between collections during an import
+type importState struct {
+	events  []*sequenceMapping
+	offsets []*core.Offset
+}
+
+func newImportState() *importState {
+	return &importState{}
+}
+
+// eventSequence maps a subscription offset from the source database to the target database.
+// Events are restored in sequence order, so the new offset is the target sequence of the last
+// event at or before the source offset.
+func (is *importState) eventSequence(source int64) int64 {
+	i := sort.Search(len(is.events), func(i int) bool {
+		return is.events[i].source > source
+	})
+	if i == 0 {
+		return -1
+	}
+	return is.events[i-1].event.Sequence
+}
+
+// pageFilter returns a filter for one page of a collection, in database sequence order
+func pageFilter(ctx context.Context, factory ffapi.QueryFactory, skip, limit int) ffapi.AndFilter {
+	filter := factory.NewFilter(ctx).And()
+	filter.Sort("sequence").Skip(uint64(skip)).Limit(uint64(limit))
+	return filter
+}
+
+func hashMismatch(ctx context.Context, collection string, id interface{}) error {
+	return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveRecordHashInvalid, collection, id)
+}
+
+// nolint: gocyclo
+func (a *archiver) allCollections() []*collection {
+	return []*collection{
+		{
+			name:          "namespace",
+			allowExisting: true,
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				if skip > 0 {
+					return nil, nil
+				}
+				namespace, err := a.database.GetNamespace(ctx, ns)
+				if err != nil || namespace == nil {
+					return nil, err
+				}
+				return []interface{}{&namespaceRecord{Namespace: namespace, Contracts: namespace.Contracts}}, nil
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var nr namespaceRecord
+				if err := json.Unmarshal(value, &nr); err != nil || nr.Namespace == nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "namespace")
+				}
+				nr.Namespace.Contracts = nr.Contracts
+				return a.database.UpsertNamespace(ctx, nr.Namespace, true)
+			},
+		},
+		{
+			name: "datatypes",
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				datatypes, _, err := a.database.GetDatatypes(ctx, ns, pageFilter(ctx, database.DatatypeQueryFactory, skip, limit))
+				records := make([]interface{}, len(datatypes))
+				for i, dt := range datatypes {
+					records[i] = dt
+				}
+				return records, err
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var dt core.Datatype
+				if err := json.Unmarshal(value, &dt); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "datatypes")
+				}
+				return a.database.UpsertDatatype(ctx, &dt, false)
+			},
+		},
+		{
+			name: "ffis",
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				ffis, _, err := a.database.GetFFIs(ctx, ns, pageFilter(ctx, database.FFIQueryFactory, skip, limit))
+				if err != nil {
+					return nil, err
+				}
+				records := make([]interface{}, len(ffis))
+				for i, ffi := range ffis {
+					if ffi.Methods, _, err = a.database.GetFFIMethods(ctx, ns, database.FFIMethodQueryFactory.NewFilter(ctx).Eq("interface", ffi.ID)); err != nil {
+						return nil, err
+					}
+					if ffi.Events, _, err = a.database.GetFFIEvents(ctx, ns, database.FFIEventQueryFactory.NewFilter(ctx).Eq("interface", ffi.ID)); err != nil {
+						return nil, err
+					}
+					if ffi.Errors, _, err = a.database.GetFFIErrors(ctx, ns, database.FFIErrorQueryFactory.NewFilter(ctx).Eq("interface", ffi.ID)); err != nil {
+						return nil, err
+					}
+					records[i] = ffi
+				}
+				return records, nil
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var ffi fftypes.FFI
+				if err := json.Unmarshal(value, &ffi); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "ffis")
+				}
+				existing, err := a.database.InsertOrGetFFI(ctx, &ffi)
+				if err != nil {
+					return err
+				}
+				if existing != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveTargetNotEmpty, a.namespace, "ffis")
+				}
+				for _, method := range ffi.Methods {
+					if err := a.database.UpsertFFIMethod(ctx, method); err != nil {
+						return err
+					}
+				}
+				for _, event := range ffi.Events {
+					if err := a.database.UpsertFFIEvent(ctx, event); err != nil {
+						return err
+					}
+				}
+				for _, errorDef := range ffi.Errors {
+					if err := a.database.UpsertFFIError(ctx, errorDef); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			name: "contractapis",
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				apis, _, err := a.database.GetContractAPIs(ctx, ns, pageFilter(ctx, database.ContractAPIQueryFactory, skip, limit))
+				records := make([]interface{}, len(apis))
+				for i, api := range apis {
+					records[i] = api
+				}
+				return records, err
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var api core.ContractAPI
+				if err := json.Unmarshal(value, &api); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "contractapis")
+				}
+				existing, err := a.database.InsertOrGetContractAPI(ctx, &api)
+				if err == nil && existing != nil {
+					err = i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveTargetNotEmpty, a.namespace, "contractapis")
+				}
+				return err
+			},
+		},
+		{
+			name: "contractlisteners",
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				listeners, _, err := a.database.GetContractListeners(ctx, ns, pageFilter(ctx, database.ContractListenerQueryFactory, skip, limit))
+				records := make([]interface{}, len(listeners))
+				for i, l := range listeners {
+					records[i] = l
+				}
+				return records, err
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var listener core.ContractListener
+				if err := json.Unmarshal(value, &listener); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "contractlisteners")
+				}
+				return a.database.InsertContractListener(ctx, &listener)
+			},
+		},
+		{
+			name: "identities",
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				identities, _, err := a.database.GetIdentities(ctx, ns, pageFilter(ctx, database.IdentityQueryFactory, skip, limit))
+				records := make([]interface{}, len(identities))
+				for i, identity := range identities {
+					records[i] = identity
+				}
+				return records, err
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var identity core.Identity
+				if err := json.Unmarshal(value, &identity); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "identities")
+				}
+				return a.database.UpsertIdentity(ctx, &identity, database.UpsertOptimizationNew)
+			},
+		},
+		{
+			name: "verifiers",
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				verifiers, _, err := a.database.GetVerifiers(ctx, ns, pageFilter(ctx, database.VerifierQueryFactory, skip, limit))
+				records := make([]interface{}, len(verifiers))
+				for i, verifier := range verifiers {
+					records[i] = verifier
+				}
+				return records, err
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var verifier core.Verifier
+				if err := json.Unmarshal(value, &verifier); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "verifiers")
+				}
+				return a.database.UpsertVerifier(ctx, &verifier, database.UpsertOptimizationNew)
+			},
+		},
+		{
+			name: "groups",
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				groups, _, err := a.database.GetGroups(ctx, ns, pageFilter(ctx, database.GroupQueryFactory, skip, limit))
+				records := make([]interface{}, len(groups))
+				for i, group := range groups {
+					records[i] = group
+				}
+				return records, err
+			},
+			check: func(ctx context.Context, value []byte) error {
+				var group core.Group
+				if err := json.Unmarshal(value, &group); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "groups")
+				}
+				if !group.Hash.Equals(group.GroupIdentity.Hash()) {
+					return hashMismatch(ctx, "groups", group.Hash)
+				}
+				return nil
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var group core.Group
+				if err := json.Unmarshal(value, &group); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "groups")
+				}
+				return a.database.UpsertGroup(ctx, &group, database.UpsertOptimizationNew)
+			},
+		},
+		{
+			name: "transactions",
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				txns, _, err := a.database.GetTransactions(ctx, ns, pageFilter(ctx, database.TransactionQueryFactory, skip, limit))
+				records := make([]interface{}, len(txns))
+				for i, tx := range txns {
+					records[i] = tx
+				}
+				return records, err
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var tx core.Transaction
+				if err := json.Unmarshal(value, &tx); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "transactions")
+				}
+				return a.database.InsertTransaction(ctx, &tx)
+			},
+		},
+		{
+			name: "blobs",
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				blobs, _, err := a.database.GetBlobs(ctx, ns, pageFilter(ctx, database.BlobQueryFactory, skip, limit))
+				records := make([]interface{}, len(blobs))
+				for i, blob := range blobs {
+					records[i] = blob
+				}
+				return records, err
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var blob core.Blob
+				if err := json.Unmarshal(value, &blob); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "blobs")
+				}
+				return a.database.InsertBlob(ctx, &blob)
+			},
+		},
+		{
+			name: "data",
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				data, _, err := a.database.GetData(ctx, ns, pageFilter(ctx, database.DataQueryFactory, skip, limit))
+				records := make([]interface{}, len(data))
+				for i, d := range data {
+					records[i] = d
+				}
+				return records, err
+			},
+			check: func(ctx context.Context, value []byte) error {
+				var d core.Data
+				if err := json.Unmarshal(value, &d); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "data")
+				}
+				if d.Hash == nil {
+					return nil
+				}
+				hash, err := d.CalcHash(ctx)
+				if err != nil || !hash.Equals(d.Hash) {
+					return hashMismatch(ctx, "data", d.ID)
+				}
+				return nil
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var d core.Data
+				if err := json.Unmarshal(value, &d); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "data")
+				}
+				return a.database.UpsertData(ctx, &d, database.UpsertOptimizationNew)
+			},
+		},
+		{
+			name: "batches",
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				batches, _, err := a.database.GetBatches(ctx, ns, pageFilter(ctx, database.BatchQueryFactory, skip, limit))
+				records := make([]interface{}, len(batches))
+				for i, batch := range batches {
+					records[i] = batch
+				}
+				return records, err
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var batch core.BatchPersisted
+				if err := json.Unmarshal(value, &batch); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "batches")
+				}
+				existing, err := a.database.InsertOrGetBatch(ctx, &batch)
+				if err == nil && existing != nil {
+					err = i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveTargetNotEmpty, a.namespace, "batches")
+				}
+				return err
+			},
+		},
+		{
+			name: "messages",
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				msgs, _, err := a.database.GetMessages(ctx, ns, pageFilter(ctx, database.MessageQueryFactory, skip, limit))
+				records := make([]interface{}, len(msgs))
+				for i, msg := range msgs {
+					records[i] = msg
+				}
+				return records, err
+			},
+			check: func(ctx context.Context, value []byte) error {
+				var msg core.Message
+				if err := json.Unmarshal(value, &msg); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "messages")
+				}
+				// Messages that have not been sealed yet do not have hashes
+				if msg.Hash != nil && !msg.Hash.Equals(msg.Header.Hash()) {
+					return hashMismatch(ctx, "messages", msg.Header.ID)
+				}
+				if msg.Header.DataHash != nil && !msg.Header.DataHash.Equals(msg.Data.Hash()) {
+					return hashMismatch(ctx, "messages", msg.Header.ID)
+				}
+				return nil
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var msg core.Message
+				if err := json.Unmarshal(value, &msg); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "messages")
+				}
+				return a.database.UpsertMessage(ctx, &msg, database.UpsertOptimizationNew)
+			},
+		},
+		{
+			name: "pins",
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				pins, _, err := a.database.GetPins(ctx, ns, pageFilter(ctx, database.PinQueryFactory, skip, limit))
+				records := make([]interface{}, len(pins))
+				for i, pin := range pins {
+					records[i] = pin
+				}
+				return records, err
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var pin core.Pin
+				if err := json.Unmarshal(value, &pin); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "pins")
+				}
+				return a.database.InsertPins(ctx, []*core.Pin{&pin})
+			},
+		},
+		{
+			name: "nextpins",
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				nextpins, _, err := a.database.GetNextPins(ctx, ns, pageFilter(ctx, database.NextPinQueryFactory, skip, limit))
+				records := make([]interface{}, len(nextpins))
+				for i, nextpin := range nextpins {
+					records[i] = nextpin
+				}
+				return records, err
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var nextpin core.NextPin
+				if err := json.Unmarshal(value, &nextpin); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "nextpins")
+				}
+				return a.database.InsertNextPin(ctx, &nextpin)
+			},
+		},
+		{
+			name: "events",
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				events, _, err := a.database.GetEvents(ctx, ns, pageFilter(ctx, database.EventQueryFactory, skip, limit))
+				records := make([]interface{}, len(events))
+				for i, event := range events {
+					records[i] = event
+				}
+				return records, err
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var event core.Event
+				if err := json.Unmarshal(value, &event); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "events")
+				}
+				mapping := &sequenceMapping{source: event.Sequence, event: &event}
+				if err := a.database.InsertEvent(ctx, &event); err != nil {
+					return err
+				}
+				is.events = append(is.events, mapping)
+				return nil
+			},
+		},
+		{
+			name: "subscriptions",
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				subs, _, err := a.database.GetSubscriptions(ctx, ns, pageFilter(ctx, database.SubscriptionQueryFactory, skip, limit))
+				records := make([]interface{}, len(subs))
+				for i, sub := range subs {
+					records[i] = sub
+				}
+				return records, err
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var sub core.Subscription
+				if err := json.Unmarshal(value, &sub); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "subscriptions")
+				}
+				return a.database.UpsertSubscription(ctx, &sub, false)
+			},
+		},
+		{
+			// Offsets are not namespaced, so only the offsets of the subscriptions in the namespace
+			// are exported. The offsets of the aggregator and batch manager are shared by all
+			// namespaces, and are rebuilt by the target node from the dispatched state of each pin.
+			name: "offsets",
+			list: func(ctx context.Context, ns string, skip, limit int) ([]interface{}, error) {
+				// There is at most one offset per subscription, so all offsets are returned in the first page
+				if skip > 0 {
+					return nil, nil
+				}
+				var records []interface{}
+				for subSkip := 0; ; subSkip += a.pageSize {
+					subs, _, err := a.database.GetSubscriptions(ctx, ns, pageFilter(ctx, database.SubscriptionQueryFactory, subSkip, a.pageSize))
+					if err != nil {
+						return nil, err
+					}
+					for _, sub := range subs {
+						offset, err := a.database.GetOffset(ctx, core.OffsetTypeSubscription, sub.ID.String())
+						if err != nil {
+							return nil, err
+						}
+						if offset != nil {
+							records = append(records, offset)
+						}
+					}
+					if len(subs) < a.pageSize {
+						return records, nil
+					}
+				}
+			},
+			restore: func(ctx context.Context, is *importState, value []byte) error {
+				var offset core.Offset
+				if err := json.Unmarshal(value, &offset); err != nil {
+					return i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveInvalidRecord, "offsets")
+				}
+				// Offsets are written once the events have been committed, and have their new sequences
+				is.offsets = append(is.offsets, &offset)
+				return nil
+			},
+		},
+	}
+}
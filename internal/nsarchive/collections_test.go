@@ -0,0 +1,234 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsarchive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCollectionsListFail(t *testing.T) {
+	a, mdi := newTestArchiver(t)
+	mdi.On("GetNamespace", mock.Anything, "ns1").Return(nil, fmt.Errorf("pop"))
+	mdi.On("GetDatatypes", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetFFIs", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetContractAPIs", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetContractListeners", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetVerifiers", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetGroups", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetTransactions", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetBlobs", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetNextPins", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	for _, c := range a.collections {
+		_, err := c.list(context.Background(), "ns1", 0, 10)
+		assert.EqualError(t, err, "pop", c.name)
+	}
+}
+
+func TestCollectionsListFFIDetailsFail(t *testing.T) {
+	a, mdi := newTestArchiver(t)
+	c := a.getCollection("ffis")
+	ffis := []*fftypes.FFI{{ID: fftypes.NewUUID()}}
+	mdi.On("GetFFIs", mock.Anything, "ns1", mock.Anything).Return(ffis, nil, nil)
+	mdi.On("GetFFIMethods", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+	_, err := c.list(context.Background(), "ns1", 0, 10)
+	assert.EqualError(t, err, "pop")
+
+	mdi.On("GetFFIMethods", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFIMethod{}, nil, nil)
+	mdi.On("GetFFIEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+	_, err = c.list(context.Background(), "ns1", 0, 10)
+	assert.EqualError(t, err, "pop")
+
+	mdi.On("GetFFIEvents", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFIEvent{}, nil, nil)
+	mdi.On("GetFFIErrors", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	_, err = c.list(context.Background(), "ns1", 0, 10)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCollectionsListOffsets(t *testing.T) {
+	a, mdi := newTestArchiver(t)
+	a.pageSize = 1
+	c := a.getCollection("offsets")
+	sub1 := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID()}}
+	sub2 := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID()}}
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return([]*core.Subscription{sub1}, nil, nil).Once()
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return([]*core.Subscription{sub2}, nil, nil).Once()
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return([]*core.Subscription{}, nil, nil).Once()
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, sub1.ID.String()).Return(&core.Offset{Name: sub1.ID.String()}, nil)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, sub2.ID.String()).Return(nil, nil)
+
+	records, err := c.list(context.Background(), "ns1", 0, 1)
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+
+	records, err = c.list(context.Background(), "ns1", 1, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+	mdi.AssertExpectations(t)
+}
+
+func TestCollectionsListOffsetFail(t *testing.T) {
+	a, mdi := newTestArchiver(t)
+	c := a.getCollection("offsets")
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return([]*core.Subscription{{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID()}}}, nil, nil)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, mock.Anything).Return(nil, fmt.Errorf("pop"))
+	_, err := c.list(context.Background(), "ns1", 0, 10)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCollectionsNamespaceNotStored(t *testing.T) {
+	a, mdi := newTestArchiver(t)
+	c := a.getCollection("namespace")
+	mdi.On("GetNamespace", mock.Anything, "ns1").Return(nil, nil)
+	records, err := c.list(context.Background(), "ns1", 0, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestCollectionsNamespaceSecondPage(t *testing.T) {
+	a, mdi := newTestArchiver(t)
+	c := a.getCollection("namespace")
+	records, err := c.list(context.Background(), "ns1", 1, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+	mdi.AssertExpectations(t)
+}
+
+func TestCollectionsRestoreBadRecord(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	for _, c := range a.collections {
+		err := c.restore(context.Background(), newImportState(), []byte("!json"))
+		assert.Regexp(t, "FF10534", err, c.name)
+		if c.check != nil {
+			err := c.check(context.Background(), []byte("!json"))
+			assert.Regexp(t, "FF10534", err, c.name)
+		}
+	}
+}
+
+func TestCollectionsRestoreFail(t *testing.T) {
+	a, mdi := newTestArchiver(t)
+	mdi.On("UpsertNamespace", mock.Anything, mock.Anything, true).Return(fmt.Errorf("pop"))
+	mdi.On("UpsertDatatype", mock.Anything, mock.Anything, false).Return(fmt.Errorf("pop"))
+	mdi.On("InsertOrGetFFI", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+	mdi.On("InsertOrGetContractAPI", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+	mdi.On("InsertContractListener", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	mdi.On("UpsertIdentity", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	mdi.On("UpsertVerifier", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	mdi.On("UpsertGroup", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	mdi.On("InsertTransaction", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	mdi.On("InsertBlob", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	mdi.On("UpsertData", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+	mdi.On("UpsertMessage", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	mdi.On("InsertPins", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	mdi.On("InsertNextPin", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	mdi.On("UpsertSubscription", mock.Anything, mock.Anything, false).Return(fmt.Errorf("pop"))
+
+	for _, c := range a.collections {
+		if c.name == "offsets" {
+			continue
+		}
+		value := `{}`
+		if c.name == "namespace" {
+			value = `{"namespace":{"name":"ns1"}}`
+		}
+		err := c.restore(context.Background(), newImportState(), []byte(value))
+		assert.EqualError(t, err, "pop", c.name)
+	}
+}
+
+func TestCollectionsRestoreExisting(t *testing.T) {
+	a, mdi := newTestArchiver(t)
+	mdi.On("InsertOrGetFFI", mock.Anything, mock.Anything).Return(&fftypes.FFI{}, nil)
+	mdi.On("InsertOrGetContractAPI", mock.Anything, mock.Anything).Return(&core.ContractAPI{}, nil)
+	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(&core.BatchPersisted{}, nil)
+
+	for _, name := range []string{"ffis", "contractapis", "batches"} {
+		err := a.getCollection(name).restore(context.Background(), newImportState(), []byte(`{}`))
+		assert.Regexp(t, "FF10533.*"+name, err)
+	}
+}
+
+func TestCollectionsRestoreFFIDetailsFail(t *testing.T) {
+	a, mdi := newTestArchiver(t)
+	c := a.getCollection("ffis")
+	mdi.On("InsertOrGetFFI", mock.Anything, mock.Anything).Return(nil, nil)
+	mdi.On("UpsertFFIMethod", mock.Anything, mock.Anything).Return(fmt.Errorf("pop")).Once()
+	err := c.restore(context.Background(), newImportState(), []byte(`{"methods":[{}]}`))
+	assert.EqualError(t, err, "pop")
+
+	mdi.On("UpsertFFIEvent", mock.Anything, mock.Anything).Return(fmt.Errorf("pop")).Once()
+	err = c.restore(context.Background(), newImportState(), []byte(`{"events":[{}]}`))
+	assert.EqualError(t, err, "pop")
+
+	mdi.On("UpsertFFIError", mock.Anything, mock.Anything).Return(fmt.Errorf("pop")).Once()
+	err = c.restore(context.Background(), newImportState(), []byte(`{"errors":[{}]}`))
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCollectionsCheckHashes(t *testing.T) {
+	a, _ := newTestArchiver(t)
+	ctx := context.Background()
+
+	err := a.getCollection("data").check(ctx, []byte(`{"id":"`+fftypes.NewUUID().String()+`"}`))
+	assert.NoError(t, err)
+	err = a.getCollection("data").check(ctx, []byte(`{"hash":"`+fftypes.NewRandB32().String()+`"}`))
+	assert.Regexp(t, "FF10535.*data", err)
+	err = a.getCollection("data").check(ctx, []byte(`{"hash":"`+fftypes.NewRandB32().String()+`","value":"test"}`))
+	assert.Regexp(t, "FF10535.*data", err)
+
+	err = a.getCollection("groups").check(ctx, []byte(`{"hash":"`+fftypes.NewRandB32().String()+`"}`))
+	assert.Regexp(t, "FF10535.*groups", err)
+
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}}
+	msg.Hash = msg.Header.Hash()
+	msg.Header.DataHash = fftypes.NewRandB32()
+	msg.Hash = msg.Header.Hash()
+	b, _ := json.Marshal(msg)
+	err = a.getCollection("messages").check(ctx, b)
+	assert.Regexp(t, "FF10535.*messages", err)
+}
+
+func TestEventSequence(t *testing.T) {
+	is := newImportState()
+	is.events = []*sequenceMapping{
+		{source: 10, event: &core.Event{Sequence: 100}},
+		{source: 20, event: &core.Event{Sequence: 101}},
+	}
+	assert.Equal(t, int64(-1), is.eventSequence(-1))
+	assert.Equal(t, int64(-1), is.eventSequence(9))
+	assert.Equal(t, int64(100), is.eventSequence(10))
+	assert.Equal(t, int64(100), is.eventSequence(19))
+	assert.Equal(t, int64(101), is.eventSequence(25))
+}
@@ -40,10 +40,12 @@ import (
 	"github.com/hyperledger/firefly/internal/networkmap"
 	"github.com/hyperledger/firefly/internal/operations"
 	"github.com/hyperledger/firefly/internal/privatemessaging"
+	"github.com/hyperledger/firefly/internal/retention"
 	"github.com/hyperledger/firefly/internal/shareddownload"
 	"github.com/hyperledger/firefly/internal/syncasync"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/internal/txwriter"
+	"github.com/hyperledger/firefly/pkg/blobstorage"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
@@ -80,12 +82,19 @@ type Orchestrator interface {
 
 	// Subscription management
 	GetSubscriptions(ctx context.Context, filter ffapi.AndFilter) ([]*core.Subscription, *ffapi.FilterResult, error)
+	GetSubscriptionsWithStatus(ctx context.Context, filter ffapi.AndFilter) ([]*core.SubscriptionWithStatus, *ffapi.FilterResult, error)
 	GetSubscriptionByID(ctx context.Context, id string) (*core.Subscription, error)
 	GetSubscriptionByIDWithStatus(ctx context.Context, id string) (*core.SubscriptionWithStatus, error)
 	GetSubscriptionEventsHistorical(ctx context.Context, subscription *core.Subscription, filter ffapi.AndFilter, startSequence int, endSequence int) ([]*core.EnrichedEvent, *ffapi.FilterResult, error)
 	CreateSubscription(ctx context.Context, subDef *core.Subscription) (*core.Subscription, error)
 	CreateUpdateSubscription(ctx context.Context, subDef *core.Subscription) (*core.Subscription, error)
 	DeleteSubscription(ctx context.Context, id string) error
+	PauseSubscription(ctx context.Context, id string) (*core.Subscription, error)
+	ResumeSubscription(ctx context.Context, id string) (*core.Subscription, error)
+	RewindSubscription(ctx context.Context, id string, rewind *core.SubscriptionRewind) (*core.Subscription, error)
+	GetSubscriptionDeadLetters(ctx context.Context, subID string, filter ffapi.AndFilter) ([]*core.DeadLetter, *ffapi.FilterResult, error)
+	ReplaySubscriptionDeadLetter(ctx context.Context, subID, deadLetterID string) error
+	DeleteSubscriptionDeadLetter(ctx context.Context, subID, deadLetterID string) error
 
 	// Data Query
 	GetNamespace(ctx context.Context) *core.Namespace
@@ -126,6 +135,9 @@ type Orchestrator interface {
 	// Charts
 	GetChartHistogram(ctx context.Context, startTime int64, endTime int64, buckets int64, tableName database.CollectionName) ([]*core.ChartHistogram, error)
 
+	// Search
+	Search(ctx context.Context, text string, limit int) ([]*core.SearchResult, error)
+
 	// Message Routing
 	RequestReply(ctx context.Context, msg *core.MessageInOut) (reply *core.MessageInOut, err error)
 
@@ -156,6 +168,11 @@ type SharedStoragePlugin struct {
 	Plugin sharedstorage.Plugin
 }
 
+type BlobStoragePlugin struct {
+	Name   string
+	Plugin blobstorage.Plugin
+}
+
 type TokensPlugin struct {
 	Name   string
 	Plugin tokens.Plugin
@@ -175,6 +192,7 @@ type Plugins struct {
 	Blockchain    BlockchainPlugin
 	Identity      IdentityPlugin
 	SharedStorage SharedStoragePlugin
+	BlobStorage   BlobStoragePlugin
 	DataExchange  DataExchangePlugin
 	Database      DatabasePlugin
 	Tokens        []TokensPlugin
@@ -188,6 +206,7 @@ type Config struct {
 	Multiparty                  multiparty.Config
 	TokenBroadcastNames         map[string]string
 	MaxHistoricalEventScanLimit int
+	Retention                   retention.Config
 }
 
 type orchestrator struct {
@@ -219,6 +238,7 @@ type orchestrator struct {
 	operations              operations.Manager
 	txHelper                txcommon.Helper
 	txWriter                txwriter.Writer
+	retention               retention.Manager // only if a retention policy is configured
 }
 
 func NewOrchestrator(ns *core.Namespace, config Config, plugins *Plugins, metrics metrics.Manager, cacheManager cache.Manager) Orchestrator {
@@ -271,6 +291,10 @@ func (or *orchestrator) sharedstorage() sharedstorage.Plugin {
 	return or.plugins.SharedStorage.Plugin
 }
 
+func (or *orchestrator) blobstorage() blobstorage.Plugin {
+	return or.plugins.BlobStorage.Plugin
+}
+
 func (or *orchestrator) tokens() map[string]tokens.Plugin {
 	result := make(map[string]tokens.Plugin, len(or.plugins.Tokens))
 	for _, plugin := range or.plugins.Tokens {
@@ -302,6 +326,9 @@ func (or *orchestrator) Start() (err error) {
 	if err == nil {
 		err = or.assets.Start()
 	}
+	if err == nil && or.retention != nil {
+		or.retention.Start()
+	}
 
 	or.started = true
 
@@ -355,6 +382,10 @@ func (or *orchestrator) WaitStop() {
 	if or.txWriter != nil {
 		or.txWriter.Close()
 	}
+	if or.retention != nil {
+		or.retention.WaitStop()
+		or.retention = nil
+	}
 	or.startedLock.Lock()
 	defer or.startedLock.Unlock()
 	or.started = false
@@ -572,6 +603,12 @@ func (or *orchestrator) initManagers(ctx context.Context) (err error) {
 		}
 	}
 
+	if or.retention == nil && or.config.Retention.Enabled() {
+		if or.retention, err = retention.NewRetentionManager(ctx, or.namespace.Name, or.database(), or.config.Retention); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -589,7 +626,7 @@ func (or *orchestrator) initComponents(ctx context.Context) (err error) {
 	}
 
 	if or.data == nil {
-		or.data, err = data.NewDataManager(ctx, or.namespace, or.database(), or.dataexchange(), or.cacheManager)
+		or.data, err = data.NewDataManager(ctx, or.namespace, or.database(), or.dataexchange(), or.blobstorage(), or.cacheManager)
 		if err != nil {
 			return err
 		}
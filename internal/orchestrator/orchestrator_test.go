@@ -46,6 +46,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/networkmapmocks"
 	"github.com/hyperledger/firefly/mocks/operationmocks"
 	"github.com/hyperledger/firefly/mocks/privatemessagingmocks"
+	"github.com/hyperledger/firefly/mocks/retentionmocks"
 	"github.com/hyperledger/firefly/mocks/shareddownloadmocks"
 	"github.com/hyperledger/firefly/mocks/sharedstoragemocks"
 	"github.com/hyperledger/firefly/mocks/spieventsmocks"
@@ -474,6 +475,60 @@ func TestInitTXWriter(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestInitRetention(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Multiparty.Enabled = false
+	or.config.Retention.MaxAge = 24 * time.Hour
+	or.config.Retention.Interval = time.Hour
+	or.config.Retention.BatchSize = 100
+	err := or.initManagers(context.Background())
+	assert.NoError(t, err)
+	assert.NotNil(t, or.retention)
+}
+
+func TestInitRetentionFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Multiparty.Enabled = false
+	or.config.Retention.MaxAge = 24 * time.Hour
+	or.config.Retention.Interval = time.Hour
+	or.config.Retention.BatchSize = 100
+	or.config.Retention.Collections = []string{"wrong"}
+	err := or.initManagers(context.Background())
+	assert.Regexp(t, "FF10521", err)
+}
+
+func TestStartStopRetention(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	mrm := &retentionmocks.Manager{}
+	or.retention = mrm
+	or.config.Multiparty.Enabled = false
+	or.mdm.On("Start").Return(nil)
+	or.mem.On("Start").Return(nil)
+	or.mom.On("Start").Return(nil)
+	or.mtw.On("Start").Return()
+	or.mam.On("Start").Return(nil)
+	mrm.On("Start").Return()
+	or.mba.On("WaitStop").Return(nil)
+	or.mbm.On("WaitStop").Return(nil)
+	or.mdm.On("WaitStop").Return(nil)
+	or.msd.On("WaitStop").Return(nil)
+	or.mom.On("WaitStop").Return(nil)
+	or.mem.On("WaitStop").Return(nil)
+	or.mtw.On("Close").Return(nil)
+	mrm.On("WaitStop").Return()
+	or.mbi.On("StopNamespace", mock.Anything, "ns").Return(nil)
+	or.mti.On("StopNamespace", mock.Anything, "ns").Return(nil)
+	err := or.Start()
+	assert.NoError(t, err)
+	or.WaitStop()
+	assert.Nil(t, or.retention)
+	mrm.AssertExpectations(t)
+}
+
 func TestStartStopOk(t *testing.T) {
 	coreconfig.Reset()
 	or := newTestOrchestrator()
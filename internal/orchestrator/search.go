@@ -0,0 +1,41 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+func (or *orchestrator) Search(ctx context.Context, text string, limit int) ([]*core.SearchResult, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgSearchTextMissing)
+	}
+	if limit == 0 {
+		limit = core.SearchDefaultLimit
+	}
+	if limit < 0 || limit > core.SearchMaxLimit {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInvalidSearchLimit, strconv.Itoa(limit), core.SearchMaxLimit)
+	}
+	return or.database().Search(ctx, or.namespace.Name, text, limit)
+}
@@ -0,0 +1,57 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearch(t *testing.T) {
+	or := newTestOrchestrator()
+	results := []*core.SearchResult{}
+	or.mdi.On("Search", mock.Anything, "ns", "PO-1234", core.SearchDefaultLimit).Return(results, nil)
+	res, err := or.Search(context.Background(), " PO-1234 ", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, results, res)
+}
+
+func TestSearchLimit(t *testing.T) {
+	or := newTestOrchestrator()
+	or.mdi.On("Search", mock.Anything, "ns", "PO-1234", 10).Return(nil, fmt.Errorf("pop"))
+	_, err := or.Search(context.Background(), "PO-1234", 10)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestSearchMissingText(t *testing.T) {
+	or := newTestOrchestrator()
+	_, err := or.Search(context.Background(), "  ", 10)
+	assert.Regexp(t, "FF10524", err)
+}
+
+func TestSearchBadLimit(t *testing.T) {
+	or := newTestOrchestrator()
+	_, err := or.Search(context.Background(), "PO-1234", core.SearchMaxLimit+1)
+	assert.Regexp(t, "FF10525", err)
+	_, err = or.Search(context.Background(), "PO-1234", -1)
+	assert.Regexp(t, "FF10525", err)
+}
@@ -18,6 +18,7 @@ package orchestrator
 
 import (
 	"context"
+	"database/sql/driver"
 	"math"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/events/system"
 	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
 )
 
 func (or *orchestrator) CreateSubscription(ctx context.Context, subDef *core.Subscription) (*core.Subscription, error) {
@@ -99,6 +101,84 @@ func (or *orchestrator) DeleteSubscription(ctx context.Context, id string) error
 	return or.events.DeleteDurableSubscription(ctx, sub)
 }
 
+func (or *orchestrator) getSubscriptionForUpdate(ctx context.Context, id string) (*core.Subscription, error) {
+	sub, err := or.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	return sub, nil
+}
+
+// setSubscriptionPaused updates the stored subscription, and the change event reloads it on every node
+func (or *orchestrator) setSubscriptionPaused(ctx context.Context, id string, paused bool) (*core.Subscription, error) {
+	sub, err := or.getSubscriptionForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	sub.Paused = paused
+	sub.Updated = fftypes.Now()
+	update := database.SubscriptionQueryFactory.NewUpdate(ctx).
+		Set("paused", sub.Paused).
+		Set("updated", sub.Updated)
+	if err := or.database().UpdateSubscription(ctx, or.namespace.Name, sub.Name, update); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (or *orchestrator) PauseSubscription(ctx context.Context, id string) (*core.Subscription, error) {
+	return or.setSubscriptionPaused(ctx, id, true)
+}
+
+func (or *orchestrator) ResumeSubscription(ctx context.Context, id string) (*core.Subscription, error) {
+	return or.setSubscriptionPaused(ctx, id, false)
+}
+
+// getRewindOffset returns the offset for the rewind, which is the sequence before the first event to redeliver
+func (or *orchestrator) getRewindOffset(ctx context.Context, rewind *core.SubscriptionRewind) (int64, error) {
+	switch {
+	case rewind.Sequence != nil && rewind.Timestamp == nil && !rewind.Oldest:
+		if *rewind.Sequence < 0 {
+			return -1, i18n.NewError(ctx, coremsgs.MsgSubscriptionRewindInvalid)
+		}
+		return *rewind.Sequence - 1, nil
+	case rewind.Timestamp != nil && rewind.Sequence == nil && !rewind.Oldest:
+		fb := database.EventQueryFactory.NewFilter(ctx)
+		filter := fb.And(fb.Gte("created", rewind.Timestamp.String())).Sort("sequence").Limit(1)
+		events, _, err := or.database().GetEvents(ctx, or.namespace.Name, filter)
+		if err != nil {
+			return -1, err
+		}
+		if len(events) > 0 {
+			return events[0].Sequence - 1, nil
+		}
+		// Nothing has happened since the requested time, so we move to the newest event
+		return or.getEventsHead(ctx)
+	case rewind.Oldest && rewind.Sequence == nil && rewind.Timestamp == nil:
+		return -1, nil
+	default:
+		return -1, i18n.NewError(ctx, coremsgs.MsgSubscriptionRewindInvalid)
+	}
+}
+
+func (or *orchestrator) RewindSubscription(ctx context.Context, id string, rewind *core.SubscriptionRewind) (*core.Subscription, error) {
+	sub, err := or.getSubscriptionForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := or.getRewindOffset(ctx, rewind)
+	if err != nil {
+		return nil, err
+	}
+	if err := or.events.RewindDurableSubscription(ctx, sub, offset); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
 func (or *orchestrator) GetSubscriptions(ctx context.Context, filter ffapi.AndFilter) ([]*core.Subscription, *ffapi.FilterResult, error) {
 	return or.database().GetSubscriptions(ctx, or.namespace.Name, filter)
 }
@@ -111,6 +191,50 @@ func (or *orchestrator) GetSubscriptionByID(ctx context.Context, id string) (*co
 	return or.database().GetSubscriptionByID(ctx, or.namespace.Name, u)
 }
 
+func (or *orchestrator) GetSubscriptionsWithStatus(ctx context.Context, filter ffapi.AndFilter) ([]*core.SubscriptionWithStatus, *ffapi.FilterResult, error) {
+	subs, fr, err := or.GetSubscriptions(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	head, err := or.getEventsHead(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	offsets, err := or.getSubscriptionOffsets(ctx, subs)
+	if err != nil {
+		return nil, nil, err
+	}
+	subsWithStatus := make([]*core.SubscriptionWithStatus, len(subs))
+	for i, sub := range subs {
+		subsWithStatus[i] = or.getSubscriptionWithStatus(sub, offsets[sub.ID.String()], head)
+	}
+	return subsWithStatus, fr, nil
+}
+
+// getSubscriptionOffsets loads the offsets of a page of subscriptions in one query, keyed by subscription ID
+func (or *orchestrator) getSubscriptionOffsets(ctx context.Context, subs []*core.Subscription) (map[string]*core.Offset, error) {
+	offsets := make(map[string]*core.Offset, len(subs))
+	if len(subs) == 0 {
+		return offsets, nil
+	}
+	names := make([]driver.Value, len(subs))
+	for i, sub := range subs {
+		names[i] = sub.ID.String()
+	}
+	fb := database.OffsetQueryFactory.NewFilter(ctx)
+	results, _, err := or.database().GetOffsets(ctx, fb.And(
+		fb.Eq("type", core.OffsetTypeSubscription),
+		fb.In("name", names),
+	))
+	if err != nil {
+		return nil, err
+	}
+	for _, offset := range results {
+		offsets[offset.Name] = offset
+	}
+	return offsets, nil
+}
+
 func (or *orchestrator) GetSubscriptionByIDWithStatus(ctx context.Context, id string) (*core.SubscriptionWithStatus, error) {
 	sub, err := or.GetSubscriptionByID(ctx, id)
 	if err != nil {
@@ -119,23 +243,98 @@ func (or *orchestrator) GetSubscriptionByIDWithStatus(ctx context.Context, id st
 	if sub == nil {
 		return nil, nil
 	}
-
+	head, err := or.getEventsHead(ctx)
+	if err != nil {
+		return nil, err
+	}
 	offset, err := or.database().GetOffset(ctx, core.OffsetTypeSubscription, sub.ID.String())
 	if err != nil {
 		return nil, err
 	}
+	return or.getSubscriptionWithStatus(sub, offset, head), nil
+}
 
+// getEventsHead returns the sequence of the newest event in the namespace, or -1 if there are no events
+func (or *orchestrator) getEventsHead(ctx context.Context) (int64, error) {
+	fb := database.EventQueryFactory.NewFilter(ctx)
+	events, _, err := or.database().GetEvents(ctx, or.namespace.Name, fb.And().Sort("sequence").Descending().Limit(1))
+	if err != nil {
+		return -1, err
+	}
+	if len(events) == 0 {
+		return -1, nil
+	}
+	return events[0].Sequence, nil
+}
+
+func (or *orchestrator) getSubscriptionWithStatus(sub *core.Subscription, offset *core.Offset, head int64) *core.SubscriptionWithStatus {
 	subWithStatus := &core.SubscriptionWithStatus{
 		Subscription: *sub,
 	}
 
+	// The delivery counts are only available from this node, while it is dispatching the subscription
+	if deliveryStatus := or.events.GetSubscriptionDeliveryStatus(sub.ID); deliveryStatus != nil {
+		subWithStatus.Status = *deliveryStatus
+	}
+
 	if offset != nil {
-		subWithStatus.Status = core.SubscriptionStatus{
-			CurrentOffset: offset.Current,
+		subWithStatus.Status.CurrentOffset = offset.Current
+		if head > offset.Current {
+			subWithStatus.Status.Lag = head - offset.Current
 		}
 	}
 
-	return subWithStatus, nil
+	return subWithStatus
+}
+
+func (or *orchestrator) getSubscriptionDeadLetter(ctx context.Context, subID, deadLetterID string) (*core.DeadLetter, error) {
+	sub, err := or.GetSubscriptionByID(ctx, subID)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	u, err := fftypes.ParseUUID(ctx, deadLetterID)
+	if err != nil {
+		return nil, err
+	}
+	deadLetter, err := or.database().GetDeadLetterByID(ctx, or.namespace.Name, u)
+	if err != nil {
+		return nil, err
+	}
+	if deadLetter == nil || !deadLetter.Subscription.Equals(sub.ID) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgDeadLetterNotFound, u)
+	}
+	return deadLetter, nil
+}
+
+func (or *orchestrator) GetSubscriptionDeadLetters(ctx context.Context, subID string, filter ffapi.AndFilter) ([]*core.DeadLetter, *ffapi.FilterResult, error) {
+	sub, err := or.GetSubscriptionByID(ctx, subID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sub == nil {
+		return nil, nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	filter = filter.Condition(filter.Builder().Eq("subscription", sub.ID))
+	return or.database().GetDeadLetters(ctx, or.namespace.Name, filter)
+}
+
+func (or *orchestrator) ReplaySubscriptionDeadLetter(ctx context.Context, subID, deadLetterID string) error {
+	deadLetter, err := or.getSubscriptionDeadLetter(ctx, subID, deadLetterID)
+	if err != nil {
+		return err
+	}
+	return or.events.ReplayDeadLetter(ctx, deadLetter)
+}
+
+func (or *orchestrator) DeleteSubscriptionDeadLetter(ctx context.Context, subID, deadLetterID string) error {
+	deadLetter, err := or.getSubscriptionDeadLetter(ctx, subID, deadLetterID)
+	if err != nil {
+		return err
+	}
+	return or.database().DeleteDeadLetterByID(ctx, or.namespace.Name, deadLetter.ID)
 }
 
 func (or *orchestrator) GetSubscriptionEventsHistorical(ctx context.Context, subscription *core.Subscription, filter ffapi.AndFilter, startSequence int, endSequence int) ([]*core.EnrichedEvent, *ffapi.FilterResult, error) {
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/internal/events/system"
 	"github.com/hyperledger/firefly/internal/events/webhooks"
@@ -312,10 +313,144 @@ func TestGetSGetSubscriptionsByIDWithStatus(t *testing.T) {
 		},
 	}
 	or.mdi.On("GetSubscriptionByID", context.Background(), "ns", u).Return(sub, nil)
+	or.mdi.On("GetEvents", context.Background(), "ns", mock.Anything).Return([]*core.Event{{Sequence: 150}}, nil, nil)
 	or.mdi.On("GetOffset", context.Background(), core.OffsetTypeSubscription, u.String()).Return(&core.Offset{Current: 100}, nil)
+	or.mem.On("GetSubscriptionDeliveryStatus", u).Return(&core.SubscriptionStatus{Delivered: 10, Acked: 9, Inflight: 1})
 	subWithStatus, err := or.GetSubscriptionByIDWithStatus(context.Background(), u.String())
 	assert.NoError(t, err)
 	assert.NotNil(t, subWithStatus)
+	assert.Equal(t, int64(100), subWithStatus.Status.CurrentOffset)
+	assert.Equal(t, int64(50), subWithStatus.Status.Lag)
+	assert.Equal(t, int64(10), subWithStatus.Status.Delivered)
+	assert.Equal(t, int64(9), subWithStatus.Status.Acked)
+	assert.Equal(t, int64(1), subWithStatus.Status.Inflight)
+}
+
+func TestGetSGetSubscriptionsByIDWithStatusNotDispatching(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	u := fftypes.NewUUID()
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID:        u,
+			Name:      "sub1",
+			Namespace: "ns1",
+		},
+	}
+	or.mdi.On("GetSubscriptionByID", context.Background(), "ns", u).Return(sub, nil)
+	or.mdi.On("GetEvents", context.Background(), "ns", mock.Anything).Return([]*core.Event{}, nil, nil)
+	or.mdi.On("GetOffset", context.Background(), core.OffsetTypeSubscription, u.String()).Return(nil, nil)
+	or.mem.On("GetSubscriptionDeliveryStatus", u).Return(nil)
+	subWithStatus, err := or.GetSubscriptionByIDWithStatus(context.Background(), u.String())
+	assert.NoError(t, err)
+	assert.Equal(t, core.SubscriptionStatus{}, subWithStatus.Status)
+}
+
+func TestGetSGetSubscriptionsByIDWithStatusEventsQueryError(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	u := fftypes.NewUUID()
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID:        u,
+			Name:      "sub1",
+			Namespace: "ns1",
+		},
+	}
+	or.mdi.On("GetSubscriptionByID", context.Background(), "ns", u).Return(sub, nil)
+	or.mdi.On("GetEvents", context.Background(), "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	subWithStatus, err := or.GetSubscriptionByIDWithStatus(context.Background(), u.String())
+	assert.EqualError(t, err, "pop")
+	assert.Nil(t, subWithStatus)
+}
+
+func TestGetSubscriptionsWithStatus(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	u := fftypes.NewUUID()
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID:        u,
+			Name:      "sub1",
+			Namespace: "ns1",
+		},
+	}
+	u2 := fftypes.NewUUID()
+	sub2 := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID:        u2,
+			Name:      "sub2",
+			Namespace: "ns1",
+		},
+	}
+	or.mdi.On("GetSubscriptions", mock.Anything, "ns", mock.Anything).Return([]*core.Subscription{sub, sub2}, nil, nil)
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{{Sequence: 150}}, nil, nil)
+	or.mdi.On("GetOffsets", mock.Anything, mock.MatchedBy(func(filter ffapi.Filter) bool {
+		f, _ := filter.Finalize()
+		return f.String() == fmt.Sprintf("( type == 'subscription' ) && ( name IN ['%s','%s'] )", u, u2)
+	})).Return([]*core.Offset{{Name: u.String(), Current: 140}}, nil, nil).Once()
+	or.mem.On("GetSubscriptionDeliveryStatus", mock.Anything).Return(nil)
+	fb := database.SubscriptionQueryFactory.NewFilter(context.Background())
+	subs, _, err := or.GetSubscriptionsWithStatus(context.Background(), fb.And())
+	assert.NoError(t, err)
+	assert.Len(t, subs, 2)
+	assert.Equal(t, int64(140), subs[0].Status.CurrentOffset)
+	assert.Equal(t, int64(10), subs[0].Status.Lag)
+	// The second subscription has no offset yet
+	assert.Equal(t, core.SubscriptionStatus{}, subs[1].Status)
+	or.mdi.AssertExpectations(t)
+}
+
+func TestGetSubscriptionsWithStatusEmpty(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetSubscriptions", mock.Anything, "ns", mock.Anything).Return([]*core.Subscription{}, nil, nil)
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{{Sequence: 150}}, nil, nil)
+	fb := database.SubscriptionQueryFactory.NewFilter(context.Background())
+	subs, _, err := or.GetSubscriptionsWithStatus(context.Background(), fb.And())
+	assert.NoError(t, err)
+	assert.Empty(t, subs)
+	or.mdi.AssertNotCalled(t, "GetOffsets", mock.Anything, mock.Anything)
+}
+
+func TestGetSubscriptionsWithStatusQueryFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetSubscriptions", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	fb := database.SubscriptionQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetSubscriptionsWithStatus(context.Background(), fb.And())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetSubscriptionsWithStatusEventsQueryFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetSubscriptions", mock.Anything, "ns", mock.Anything).Return([]*core.Subscription{}, nil, nil)
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	fb := database.SubscriptionQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetSubscriptionsWithStatus(context.Background(), fb.And())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetSubscriptionsWithStatusOffsetQueryFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	u := fftypes.NewUUID()
+	or.mdi.On("GetSubscriptions", mock.Anything, "ns", mock.Anything).Return([]*core.Subscription{
+		{SubscriptionRef: core.SubscriptionRef{ID: u}},
+	}, nil, nil)
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{}, nil, nil)
+	or.mdi.On("GetOffsets", mock.Anything, mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	fb := database.SubscriptionQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetSubscriptionsWithStatus(context.Background(), fb.And())
+	assert.EqualError(t, err, "pop")
 }
 
 func TestGetSGetSubscriptionsByIDWithStatusQuerySubFail(t *testing.T) {
@@ -342,6 +477,7 @@ func TestGetSGetSubscriptionsByIDWithStatusOffsetQueryError(t *testing.T) {
 		},
 	}
 	or.mdi.On("GetSubscriptionByID", context.Background(), "ns", u).Return(sub, nil)
+	or.mdi.On("GetEvents", context.Background(), "ns", mock.Anything).Return([]*core.Event{}, nil, nil)
 	or.mdi.On("GetOffset", context.Background(), core.OffsetTypeSubscription, u.String()).Return(nil, fmt.Errorf("pop"))
 	subWithStatus, err := or.GetSubscriptionByIDWithStatus(context.Background(), u.String())
 	assert.EqualError(t, err, "pop")
@@ -613,3 +749,326 @@ func TestGetHistoricalEventsForSubscriptionNoStartOrEndSequenceFails(t *testing.
 	_, _, err := or.GetSubscriptionEventsHistorical(context.Background(), &core.Subscription{}, filter, -1, -1)
 	assert.NotNil(t, err)
 }
+
+func TestGetSubscriptionDeadLetters(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID: fftypes.NewUUID(),
+		},
+	}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mdi.On("GetDeadLetters", mock.Anything, "ns", mock.Anything).Return([]*core.DeadLetter{}, nil, nil)
+	fb := database.DeadLetterQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetSubscriptionDeadLetters(context.Background(), sub.ID.String(), fb.And())
+	assert.NoError(t, err)
+}
+
+func TestGetSubscriptionDeadLettersNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", mock.Anything).Return(nil, nil)
+	fb := database.DeadLetterQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetSubscriptionDeadLetters(context.Background(), fftypes.NewUUID().String(), fb.And())
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestGetSubscriptionDeadLettersBadUUID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	fb := database.DeadLetterQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetSubscriptionDeadLetters(context.Background(), "! a UUID", fb.And())
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestReplaySubscriptionDeadLetter(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID: fftypes.NewUUID(),
+		},
+	}
+	deadLetter := &core.DeadLetter{
+		ID:           fftypes.NewUUID(),
+		Subscription: sub.ID,
+	}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mdi.On("GetDeadLetterByID", mock.Anything, "ns", deadLetter.ID).Return(deadLetter, nil)
+	or.mem.On("ReplayDeadLetter", mock.Anything, deadLetter).Return(nil)
+	err := or.ReplaySubscriptionDeadLetter(context.Background(), sub.ID.String(), deadLetter.ID.String())
+	assert.NoError(t, err)
+}
+
+func TestReplaySubscriptionDeadLetterOtherSubscription(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID: fftypes.NewUUID(),
+		},
+	}
+	deadLetter := &core.DeadLetter{
+		ID:           fftypes.NewUUID(),
+		Subscription: fftypes.NewUUID(),
+	}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mdi.On("GetDeadLetterByID", mock.Anything, "ns", deadLetter.ID).Return(deadLetter, nil)
+	err := or.ReplaySubscriptionDeadLetter(context.Background(), sub.ID.String(), deadLetter.ID.String())
+	assert.Regexp(t, "FF10508", err)
+}
+
+func TestReplaySubscriptionDeadLetterLookupFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID: fftypes.NewUUID(),
+		},
+	}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mdi.On("GetDeadLetterByID", mock.Anything, "ns", mock.Anything).Return(nil, fmt.Errorf("pop"))
+	err := or.ReplaySubscriptionDeadLetter(context.Background(), sub.ID.String(), fftypes.NewUUID().String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReplaySubscriptionDeadLetterBadID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID: fftypes.NewUUID(),
+		},
+	}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	err := or.ReplaySubscriptionDeadLetter(context.Background(), sub.ID.String(), "! a UUID")
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestReplaySubscriptionDeadLetterSubscriptionNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", mock.Anything).Return(nil, nil)
+	err := or.ReplaySubscriptionDeadLetter(context.Background(), fftypes.NewUUID().String(), fftypes.NewUUID().String())
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestReplaySubscriptionDeadLetterSubscriptionLookupFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", mock.Anything).Return(nil, fmt.Errorf("pop"))
+	err := or.ReplaySubscriptionDeadLetter(context.Background(), fftypes.NewUUID().String(), fftypes.NewUUID().String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestDeleteSubscriptionDeadLetter(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID: fftypes.NewUUID(),
+		},
+	}
+	deadLetter := &core.DeadLetter{
+		ID:           fftypes.NewUUID(),
+		Subscription: sub.ID,
+	}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mdi.On("GetDeadLetterByID", mock.Anything, "ns", deadLetter.ID).Return(deadLetter, nil)
+	or.mdi.On("DeleteDeadLetterByID", mock.Anything, "ns", deadLetter.ID).Return(nil)
+	err := or.DeleteSubscriptionDeadLetter(context.Background(), sub.ID.String(), deadLetter.ID.String())
+	assert.NoError(t, err)
+}
+
+func TestDeleteSubscriptionDeadLetterNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID: fftypes.NewUUID(),
+		},
+	}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mdi.On("GetDeadLetterByID", mock.Anything, "ns", mock.Anything).Return(nil, nil)
+	err := or.DeleteSubscriptionDeadLetter(context.Background(), sub.ID.String(), fftypes.NewUUID().String())
+	assert.Regexp(t, "FF10508", err)
+}
+
+func TestPauseSubscription(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	u := fftypes.NewUUID()
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{ID: u, Name: "sub1", Namespace: "ns"},
+	}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", u).Return(sub, nil)
+	or.mdi.On("UpdateSubscription", mock.Anything, "ns", "sub1", mock.Anything).Return(nil)
+	res, err := or.PauseSubscription(context.Background(), u.String())
+	assert.NoError(t, err)
+	assert.True(t, res.Paused)
+	assert.NotNil(t, res.Updated)
+}
+
+func TestResumeSubscription(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	u := fftypes.NewUUID()
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{ID: u, Name: "sub1", Namespace: "ns"},
+		Paused:          true,
+	}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", u).Return(sub, nil)
+	or.mdi.On("UpdateSubscription", mock.Anything, "ns", "sub1", mock.Anything).Return(nil)
+	res, err := or.ResumeSubscription(context.Background(), u.String())
+	assert.NoError(t, err)
+	assert.False(t, res.Paused)
+}
+
+func TestPauseSubscriptionUpdateFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	u := fftypes.NewUUID()
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{ID: u, Name: "sub1", Namespace: "ns"},
+	}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", u).Return(sub, nil)
+	or.mdi.On("UpdateSubscription", mock.Anything, "ns", "sub1", mock.Anything).Return(fmt.Errorf("pop"))
+	_, err := or.PauseSubscription(context.Background(), u.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestPauseSubscriptionNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	u := fftypes.NewUUID()
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", u).Return(nil, nil)
+	_, err := or.PauseSubscription(context.Background(), u.String())
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestPauseSubscriptionBadID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.PauseSubscription(context.Background(), "bad")
+	assert.Regexp(t, "FF00138", err)
+}
+
+func newTestRewindSubscription(or *testOrchestrator) *core.Subscription {
+	u := fftypes.NewUUID()
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{ID: u, Name: "sub1", Namespace: "ns"},
+	}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", u).Return(sub, nil)
+	return sub
+}
+
+func TestRewindSubscriptionSequence(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := newTestRewindSubscription(or)
+	or.mem.On("RewindDurableSubscription", mock.Anything, sub, int64(99)).Return(nil)
+	seq := int64(100)
+	_, err := or.RewindSubscription(context.Background(), sub.ID.String(), &core.SubscriptionRewind{Sequence: &seq})
+	assert.NoError(t, err)
+}
+
+func TestRewindSubscriptionBadSequence(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := newTestRewindSubscription(or)
+	seq := int64(-5)
+	_, err := or.RewindSubscription(context.Background(), sub.ID.String(), &core.SubscriptionRewind{Sequence: &seq})
+	assert.Regexp(t, "FF10514", err)
+}
+
+func TestRewindSubscriptionOldest(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := newTestRewindSubscription(or)
+	or.mem.On("RewindDurableSubscription", mock.Anything, sub, int64(-1)).Return(nil)
+	_, err := or.RewindSubscription(context.Background(), sub.ID.String(), &core.SubscriptionRewind{Oldest: true})
+	assert.NoError(t, err)
+}
+
+func TestRewindSubscriptionTimestamp(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := newTestRewindSubscription(or)
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{{Sequence: 50}}, nil, nil)
+	or.mem.On("RewindDurableSubscription", mock.Anything, sub, int64(49)).Return(nil)
+	_, err := or.RewindSubscription(context.Background(), sub.ID.String(), &core.SubscriptionRewind{Timestamp: fftypes.Now()})
+	assert.NoError(t, err)
+}
+
+func TestRewindSubscriptionTimestampNoEvents(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := newTestRewindSubscription(or)
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{}, nil, nil).Once()
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{{Sequence: 75}}, nil, nil).Once()
+	or.mem.On("RewindDurableSubscription", mock.Anything, sub, int64(75)).Return(nil)
+	_, err := or.RewindSubscription(context.Background(), sub.ID.String(), &core.SubscriptionRewind{Timestamp: fftypes.Now()})
+	assert.NoError(t, err)
+}
+
+func TestRewindSubscriptionTimestampQueryFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := newTestRewindSubscription(or)
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	_, err := or.RewindSubscription(context.Background(), sub.ID.String(), &core.SubscriptionRewind{Timestamp: fftypes.Now()})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRewindSubscriptionMultiplePositions(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := newTestRewindSubscription(or)
+	seq := int64(100)
+	_, err := or.RewindSubscription(context.Background(), sub.ID.String(), &core.SubscriptionRewind{Sequence: &seq, Oldest: true})
+	assert.Regexp(t, "FF10514", err)
+}
+
+func TestRewindSubscriptionFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := newTestRewindSubscription(or)
+	or.mem.On("RewindDurableSubscription", mock.Anything, sub, int64(-1)).Return(fmt.Errorf("pop"))
+	_, err := or.RewindSubscription(context.Background(), sub.ID.String(), &core.SubscriptionRewind{Oldest: true})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRewindSubscriptionNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	u := fftypes.NewUUID()
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", u).Return(nil, nil)
+	_, err := or.RewindSubscription(context.Background(), u.String(), &core.SubscriptionRewind{Oldest: true})
+	assert.Regexp(t, "FF10109", err)
+}
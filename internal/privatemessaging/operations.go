@@ -145,7 +145,11 @@ func (pm *privateMessaging) RunOperation(ctx context.Context, op *core.PreparedO
 		if err != nil {
 			return nil, core.OpPhaseInitializing, err
 		}
-		return nil, core.OpPhaseInitializing, pm.exchange.TransferBlob(ctx, op.NamespacedIDString(), data.Node.Profile, localNode.Profile, data.Blob.PayloadRef)
+		payloadRef, err := pm.data.ExchangeBlobRef(ctx, data.Blob)
+		if err != nil {
+			return nil, core.OpPhaseInitializing, err
+		}
+		return nil, core.OpPhaseInitializing, pm.exchange.TransferBlob(ctx, op.NamespacedIDString(), data.Node.Profile, localNode.Profile, payloadRef)
 
 	case batchSendData:
 		localNode, err := pm.identity.GetLocalNode(ctx)
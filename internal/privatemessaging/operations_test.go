@@ -74,6 +74,8 @@ func TestPrepareAndRunTransferBlob(t *testing.T) {
 	mim.On("CachedIdentityLookupByID", context.Background(), mock.Anything).Return(node, nil)
 	mdi.On("GetBlobs", context.Background(), "ns1", mock.Anything).Return([]*core.Blob{blob}, nil, nil)
 	mim.On("GetLocalNode", context.Background()).Return(localNode, nil)
+	mdm := pm.data.(*datamocks.Manager)
+	mdm.On("ExchangeBlobRef", context.Background(), blob).Return("payload", nil)
 	mdx.On("TransferBlob", context.Background(), "ns1:"+op.ID.String(), node.Profile, localNode.Profile, "payload").Return(nil)
 
 	po, err := pm.PrepareOperation(context.Background(), op)
@@ -89,6 +91,36 @@ func TestPrepareAndRunTransferBlob(t *testing.T) {
 	mdi.AssertExpectations(t)
 	mdx.AssertExpectations(t)
 	mim.AssertExpectations(t)
+	mdm.AssertExpectations(t)
+}
+
+func TestRunTransferBlobExchangeRefFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	op := &core.Operation{
+		Type:      core.OpTypeDataExchangeSendBlob,
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+	}
+	node := &core.Identity{}
+	blob := &core.Blob{
+		Hash:       fftypes.NewRandB32(),
+		PayloadRef: "filesystem://ns1/blob1",
+	}
+
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", context.Background()).Return(&core.Identity{}, nil)
+	mdm := pm.data.(*datamocks.Manager)
+	mdm.On("ExchangeBlobRef", context.Background(), blob).Return("", fmt.Errorf("pop"))
+
+	_, phase, err := pm.RunOperation(context.Background(), opSendBlob(op, node, blob))
+
+	assert.Equal(t, core.OpPhaseInitializing, phase)
+	assert.Regexp(t, "pop", err)
+
+	mim.AssertExpectations(t)
+	mdm.AssertExpectations(t)
 }
 
 func TestPrepareAndRunBatchSend(t *testing.T) {
@@ -0,0 +1,196 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retention
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// collection describes how to select, list and remove the expired records of one collection
+type collection struct {
+	name      string
+	factory   ffapi.QueryFactory
+	idField   string
+	sortField string
+	filter    func(fb ffapi.FilterBuilder, rs *runState) ffapi.Filter // returns nil if nothing has expired
+	list      func(ctx context.Context, ns string, filter ffapi.Filter) (records []interface{}, ids []driver.Value, err error)
+	purge     func(ctx context.Context, ns string, filter ffapi.Filter) (int64, error)
+}
+
+func olderThan(fb ffapi.FilterBuilder, field string, rs *runState) ffapi.Filter {
+	if rs.cutoff == nil {
+		return nil
+	}
+	return fb.And(fb.Lt(field, rs.cutoff))
+}
+
+func (rm *retentionManager) allCollections() []*collection {
+	return []*collection{
+		{
+			name:      "messages",
+			factory:   database.MessageQueryFactory,
+			idField:   "id",
+			sortField: "sequence",
+			filter: func(fb ffapi.FilterBuilder, rs *runState) ffapi.Filter {
+				// Only messages that have reached a final state are removed
+				if rs.cutoff == nil {
+					return nil
+				}
+				return fb.And(
+					fb.In("state", []driver.Value{core.MessageStateConfirmed, core.MessageStateRejected}),
+					fb.Lt("confirmed", rs.cutoff),
+				)
+			},
+			list: func(ctx context.Context, ns string, filter ffapi.Filter) ([]interface{}, []driver.Value, error) {
+				msgs, _, err := rm.database.GetMessages(ctx, ns, filter)
+				records := make([]interface{}, len(msgs))
+				ids := make([]driver.Value, len(msgs))
+				for i, msg := range msgs {
+					records[i] = msg
+					ids[i] = msg.Header.ID.String()
+				}
+				return records, ids, err
+			},
+			purge: rm.database.PurgeMessages,
+		},
+		{
+			name:      "data",
+			factory:   database.DataQueryFactory,
+			idField:   "id",
+			sortField: "created",
+			filter: func(fb ffapi.FilterBuilder, rs *runState) ffapi.Filter {
+				return olderThan(fb, "created", rs)
+			},
+			list: func(ctx context.Context, ns string, filter ffapi.Filter) ([]interface{}, []driver.Value, error) {
+				data, _, err := rm.database.GetData(ctx, ns, filter)
+				records := make([]interface{}, len(data))
+				ids := make([]driver.Value, len(data))
+				for i, d := range data {
+					records[i] = d
+					ids[i] = d.ID.String()
+				}
+				return records, ids, err
+			},
+			purge: rm.database.PurgeData,
+		},
+		{
+			name:      "events",
+			factory:   database.EventQueryFactory,
+			idField:   "id",
+			sortField: "sequence",
+			filter: func(fb ffapi.FilterBuilder, rs *runState) ffapi.Filter {
+				var conditions []ffapi.Filter
+				if rs.cutoff != nil {
+					conditions = append(conditions, fb.Lt("created", rs.cutoff))
+				}
+				if rs.maxEventSequence >= 0 {
+					conditions = append(conditions, fb.Lte("sequence", rs.maxEventSequence))
+				}
+				if len(conditions) == 0 {
+					return nil
+				}
+				if rs.subscriptionSeq != nil {
+					return fb.And(fb.Or(conditions...), fb.Lte("sequence", *rs.subscriptionSeq))
+				}
+				return fb.And(fb.Or(conditions...))
+			},
+			list: func(ctx context.Context, ns string, filter ffapi.Filter) ([]interface{}, []driver.Value, error) {
+				events, _, err := rm.database.GetEvents(ctx, ns, filter)
+				records := make([]interface{}, len(events))
+				ids := make([]driver.Value, len(events))
+				for i, ev := range events {
+					records[i] = ev
+					ids[i] = ev.ID.String()
+				}
+				return records, ids, err
+			},
+			purge: rm.database.PurgeEvents,
+		},
+		{
+			name:      "operations",
+			factory:   database.OperationQueryFactory,
+			idField:   "id",
+			sortField: "created",
+			filter: func(fb ffapi.FilterBuilder, rs *runState) ffapi.Filter {
+				// Only operations that have reached a final state are removed
+				if rs.cutoff == nil {
+					return nil
+				}
+				return fb.And(
+					fb.In("status", []driver.Value{core.OpStatusSucceeded, core.OpStatusFailed}),
+					fb.Lt("updated", rs.cutoff),
+				)
+			},
+			list: func(ctx context.Context, ns string, filter ffapi.Filter) ([]interface{}, []driver.Value, error) {
+				ops, _, err := rm.database.GetOperations(ctx, ns, filter)
+				records := make([]interface{}, len(ops))
+				ids := make([]driver.Value, len(ops))
+				for i, op := range ops {
+					records[i] = op
+					ids[i] = op.ID.String()
+				}
+				return records, ids, err
+			},
+			purge: rm.database.PurgeOperations,
+		},
+		{
+			name:      "blockchainevents",
+			factory:   database.BlockchainEventQueryFactory,
+			idField:   "id",
+			sortField: "timestamp",
+			filter: func(fb ffapi.FilterBuilder, rs *runState) ffapi.Filter {
+				return olderThan(fb, "timestamp", rs)
+			},
+			list: func(ctx context.Context, ns string, filter ffapi.Filter) ([]interface{}, []driver.Value, error) {
+				events, _, err := rm.database.GetBlockchainEvents(ctx, ns, filter)
+				records := make([]interface{}, len(events))
+				ids := make([]driver.Value, len(events))
+				for i, ev := range events {
+					records[i] = ev
+					ids[i] = ev.ID.String()
+				}
+				return records, ids, err
+			},
+			purge: rm.database.PurgeBlockchainEvents,
+		},
+		{
+			name:      "tokentransfers",
+			factory:   database.TokenTransferQueryFactory,
+			idField:   "localid",
+			sortField: "created",
+			filter: func(fb ffapi.FilterBuilder, rs *runState) ffapi.Filter {
+				return olderThan(fb, "created", rs)
+			},
+			list: func(ctx context.Context, ns string, filter ffapi.Filter) ([]interface{}, []driver.Value, error) {
+				transfers, _, err := rm.database.GetTokenTransfers(ctx, ns, filter)
+				records := make([]interface{}, len(transfers))
+				ids := make([]driver.Value, len(transfers))
+				for i, t := range transfers {
+					records[i] = t
+					ids[i] = t.LocalID.String()
+				}
+				return records, ids, err
+			},
+			purge: rm.database.PurgeTokenTransfers,
+		},
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCollectionsList(t *testing.T) {
+	rm, cleanup := newTestRetentionManager(t, Config{MaxAge: time.Hour})
+	defer cleanup()
+
+	msgID := fftypes.NewUUID()
+	dataID := fftypes.NewUUID()
+	eventID := fftypes.NewUUID()
+	opID := fftypes.NewUUID()
+	blockchainEventID := fftypes.NewUUID()
+	transferID := fftypes.NewUUID()
+
+	mdi := rm.database.(*databasemocks.Plugin)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{{Header: core.MessageHeader{ID: msgID}}}, nil, nil)
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(core.DataArray{{ID: dataID}}, nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{{ID: eventID}}, nil, nil)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{{ID: opID}}, nil, nil)
+	mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.BlockchainEvent{{ID: blockchainEventID}}, nil, nil)
+	mdi.On("GetTokenTransfers", mock.Anything, "ns1", mock.Anything).Return([]*core.TokenTransfer{{LocalID: transferID}}, nil, nil)
+
+	expectedIDs := []*fftypes.UUID{msgID, dataID, eventID, opID, blockchainEventID, transferID}
+	ctx := context.Background()
+	rs := &runState{cutoff: fftypes.Now(), maxEventSequence: 10}
+	for i, c := range rm.collections {
+		filter := c.filter(c.factory.NewFilter(ctx), rs)
+		assert.NotNil(t, filter)
+		_, err := filter.Finalize()
+		assert.NoError(t, err)
+
+		records, ids, err := c.list(ctx, "ns1", filter)
+		assert.NoError(t, err)
+		assert.Len(t, records, 1)
+		assert.Equal(t, expectedIDs[i].String(), ids[0])
+	}
+}
+
+func TestCollectionsNothingExpired(t *testing.T) {
+	rm, cleanup := newTestRetentionManager(t, Config{MaxEvents: 10})
+	defer cleanup()
+
+	ctx := context.Background()
+	rs := &runState{maxEventSequence: -1}
+	for _, c := range rm.collections {
+		assert.Nil(t, c.filter(c.factory.NewFilter(ctx), rs))
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retention
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// Manager runs the data retention policy of a namespace in the background
+type Manager interface {
+	Start()
+	WaitStop()
+}
+
+// Config is the data retention policy of a namespace
+type Config struct {
+	MaxAge      time.Duration
+	MaxEvents   int64
+	Interval    time.Duration
+	BatchSize   int
+	Collections []string
+	ArchivePath string
+}
+
+// Enabled returns true if the policy would remove any records
+func (c *Config) Enabled() bool {
+	return c.MaxAge > 0 || c.MaxEvents > 0
+}
+
+// runState holds the limits calculated at the start of each retention run
+type runState struct {
+	runID            string
+	cutoff           *fftypes.FFTime // nil if age based retention is disabled
+	maxEventSequence int64           // -1 if count based retention is disabled
+	subscriptionSeq  *int64          // nil if there are no durable subscriptions to keep events for
+}
+
+type retentionManager struct {
+	ctx         context.Context
+	cancelCtx   context.CancelFunc
+	namespace   string
+	database    database.Plugin
+	conf        Config
+	collections []*collection
+	loopDone    chan struct{}
+}
+
+func NewRetentionManager(ctx context.Context, ns string, di database.Plugin, conf Config) (Manager, error) {
+	if di == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "RetentionManager")
+	}
+	if conf.Interval <= 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgRetentionInvalidConfig, "interval", ns)
+	}
+	if conf.BatchSize <= 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgRetentionInvalidConfig, "batchSize", ns)
+	}
+	rm := &retentionManager{
+		namespace: ns,
+		database:  di,
+		conf:      conf,
+	}
+	all := rm.allCollections()
+	if len(conf.Collections) == 0 {
+		rm.collections = all
+	} else {
+		known := make(map[string]bool, len(all))
+		for _, c := range all {
+			known[c.name] = true
+		}
+		enabled := make(map[string]bool)
+		for _, name := range conf.Collections {
+			if !known[name] {
+				return nil, i18n.NewError(ctx, coremsgs.MsgRetentionUnknownCollection, name, ns)
+			}
+			enabled[name] = true
+		}
+		// Collections are always processed in the fixed order, so messages are removed before the data they reference
+		for _, c := range all {
+			if enabled[c.name] {
+				rm.collections = append(rm.collections, c)
+			}
+		}
+	}
+	rm.ctx, rm.cancelCtx = context.WithCancel(log.WithLogField(ctx, "role", "retention"))
+	return rm, nil
+}
+
+func (rm *retentionManager) Start() {
+	rm.loopDone = make(chan struct{})
+	go rm.retentionLoop()
+}
+
+func (rm *retentionManager) WaitStop() {
+	rm.cancelCtx()
+	if rm.loopDone != nil {
+		<-rm.loopDone
+	}
+}
+
+func (rm *retentionManager) retentionLoop() {
+	defer close(rm.loopDone)
+	ticker := time.NewTicker(rm.conf.Interval)
+	defer ticker.Stop()
+	for {
+		if err := rm.runRetention(rm.ctx); err != nil {
+			log.L(rm.ctx).Errorf("Retention run failed (will retry in %s): %s", rm.conf.Interval, err)
+		}
+		select {
+		case <-ticker.C:
+		case <-rm.ctx.Done():
+			log.L(rm.ctx).Debugf("Retention loop exiting")
+			return
+		}
+	}
+}
+
+func (rm *retentionManager) runRetention(ctx context.Context) error {
+	now := time.Now()
+	rs := &runState{
+		runID:            now.UTC().Format("20060102T150405.000Z"),
+		maxEventSequence: -1,
+	}
+	if rm.conf.MaxAge > 0 {
+		cutoff := fftypes.FFTime(now.Add(-rm.conf.MaxAge))
+		rs.cutoff = &cutoff
+	}
+	if rm.conf.MaxEvents > 0 {
+		// The sequence is shared by all namespaces, so find the newest event of this namespace beyond the limit
+		fb := database.EventQueryFactory.NewFilter(ctx)
+		events, _, err := rm.database.GetEvents(ctx, rm.namespace, fb.And().Sort("sequence").Descending().Skip(uint64(rm.conf.MaxEvents)).Limit(1))
+		if err != nil {
+			return err
+		}
+		if len(events) > 0 {
+			rs.maxEventSequence = events[0].Sequence
+		}
+	}
+	for _, c := range rm.collections {
+		if c.filter(c.factory.NewFilter(ctx), rs) == nil {
+			continue
+		}
+		if c.name == "events" {
+			seq, err := rm.subscriptionSequence(ctx)
+			if err != nil {
+				return err
+			}
+			rs.subscriptionSeq = seq
+		}
+		if err := rm.pruneCollection(ctx, c, rs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// subscriptionSequence returns the lowest offset of the durable subscriptions in the namespace,
+// as the events after it have not yet been delivered to every subscription, so must be kept
+func (rm *retentionManager) subscriptionSequence(ctx context.Context) (*int64, error) {
+	subs, _, err := rm.database.GetSubscriptions(ctx, rm.namespace, database.SubscriptionQueryFactory.NewFilter(ctx).And())
+	if err != nil || len(subs) == 0 {
+		return nil, err
+	}
+	names := make([]driver.Value, len(subs))
+	for i, sub := range subs {
+		names[i] = sub.ID.String()
+	}
+	fb := database.OffsetQueryFactory.NewFilter(ctx)
+	offsets, _, err := rm.database.GetOffsets(ctx, fb.And(
+		fb.Eq("type", core.OffsetTypeSubscription),
+		fb.In("name", names),
+	))
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]int64, len(offsets))
+	for _, offset := range offsets {
+		current[offset.Name] = offset.Current
+	}
+
+	var lowest *int64
+	for _, sub := range subs {
+		seq, ok := current[sub.ID.String()]
+		if !ok {
+			// A subscription that has never been dispatched starts after the first event locked in when it was created
+			seq = -1
+			if sub.Options.FirstEvent != nil {
+				if firstEvent, err := strconv.ParseInt(string(*sub.Options.FirstEvent), 10, 64); err == nil {
+					seq = firstEvent
+				}
+			}
+		}
+		if lowest == nil || seq < *lowest {
+			lowest = &seq
+		}
+	}
+	return lowest, nil
+}
+
+func (rm *retentionManager) pruneCollection(ctx context.Context, c *collection, rs *runState) error {
+	if rm.conf.ArchivePath != "" {
+		return rm.archiveCollection(ctx, c, rs)
+	}
+	count, err := c.purge(ctx, rm.namespace, c.filter(c.factory.NewFilter(ctx), rs))
+	if err != nil {
+		return err
+	}
+	log.L(ctx).Infof("Removed %d expired %s", count, c.name)
+	return nil
+}
+
+// archiveCollection pages through the expired records, and for each page removes them from the database and
+// appends them to the archive file in a single database transaction. The archive is synced before the transaction
+// commits, so a failure can result in records being archived twice, but never in records being lost.
+func (rm *retentionManager) archiveCollection(ctx context.Context, c *collection, rs *runState) error {
+	var archive *os.File
+	archiveName := filepath.Join(rm.conf.ArchivePath, rm.namespace, fmt.Sprintf("%s-%s.ndjson", c.name, rs.runID))
+	defer func() {
+		if archive != nil {
+			_ = archive.Close()
+		}
+	}()
+
+	var total int64
+	retained := 0
+	for {
+		pageLen := 0
+		err := rm.database.RunAsGroup(ctx, func(ctx context.Context) error {
+			filter := c.filter(c.factory.NewFilter(ctx), rs).
+				Sort(c.sortField).Ascending().
+				Skip(uint64(retained)).
+				Limit(uint64(rm.conf.BatchSize))
+			records, ids, err := c.list(ctx, rm.namespace, filter)
+			if err != nil || len(records) == 0 {
+				return err
+			}
+			pageLen = len(records)
+			count, err := c.purge(ctx, rm.namespace, c.factory.NewFilter(ctx).In(c.idField, ids))
+			if err != nil {
+				return err
+			}
+			if int(count) < len(records) {
+				// Some records were retained by the database (such as data still referenced by a message),
+				// so must not be archived - and must be skipped in the following pages
+				if records, err = rm.excludeRetained(ctx, c, records, ids); err != nil {
+					return err
+				}
+			}
+			if archive == nil {
+				if archive, err = openArchive(ctx, archiveName); err != nil {
+					return err
+				}
+			}
+			if err := writeArchive(ctx, archive, records); err != nil {
+				return err
+			}
+			retained += pageLen - len(records)
+			total += count
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if pageLen < rm.conf.BatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		log.L(ctx).Infof("Archived and removed %d expired %s to %s", total, c.name, archiveName)
+	}
+	return nil
+}
+
+func (rm *retentionManager) excludeRetained(ctx context.Context, c *collection, records []interface{}, ids []driver.Value) ([]interface{}, error) {
+	_, remainingIDs, err := c.list(ctx, rm.namespace, c.factory.NewFilter(ctx).In(c.idField, ids))
+	if err != nil {
+		return nil, err
+	}
+	remaining := make(map[driver.Value]bool, len(remainingIDs))
+	for _, id := range remainingIDs {
+		remaining[id] = true
+	}
+	removed := make([]interface{}, 0, len(records))
+	for i, r := range records {
+		if !remaining[ids[i]] {
+			removed = append(removed, r)
+		}
+	}
+	return removed, nil
+}
+
+func openArchive(ctx context.Context, archiveName string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(archiveName), 0755); err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgRetentionArchiveFailed, archiveName)
+	}
+	archive, err := os.OpenFile(archiveName, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgRetentionArchiveFailed, archiveName)
+	}
+	return archive, nil
+}
+
+func writeArchive(ctx context.Context, archive *os.File, records []interface{}) error {
+	for _, r := range records {
+		b, _ := json.Marshal(r)
+		if _, err := archive.Write(append(b, '\n')); err != nil {
+			return i18n.WrapError(ctx, err, coremsgs.MsgRetentionArchiveFailed, archive.Name())
+		}
+	}
+	if err := archive.Sync(); err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgRetentionArchiveFailed, archive.Name())
+	}
+	return nil
+}
@@ -0,0 +1,453 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retention
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestRetentionManager(t *testing.T, conf Config) (*retentionManager, func()) {
+	mdi := &databasemocks.Plugin{}
+	if conf.Interval == 0 {
+		conf.Interval = time.Hour
+	}
+	if conf.BatchSize == 0 {
+		conf.BatchSize = 2
+	}
+	rm, err := NewRetentionManager(context.Background(), "ns1", mdi, conf)
+	assert.NoError(t, err)
+	return rm.(*retentionManager), func() {
+		rm.WaitStop()
+		mdi.AssertExpectations(t)
+	}
+}
+
+func mockRunAsGroup(mdi *databasemocks.Plugin) {
+	rag := mdi.On("RunAsGroup", mock.Anything, mock.Anything)
+	rag.RunFn = func(a mock.Arguments) {
+		rag.ReturnArguments = mock.Arguments{a[1].(func(context.Context) error)(a[0].(context.Context))}
+	}
+}
+
+func filterContains(s string) interface{} {
+	return mock.MatchedBy(func(f ffapi.Filter) bool {
+		fi, err := f.Finalize()
+		return err == nil && strings.Contains(fi.String(), s)
+	})
+}
+
+func TestConfigEnabled(t *testing.T) {
+	assert.False(t, (&Config{}).Enabled())
+	assert.True(t, (&Config{MaxAge: time.Hour}).Enabled())
+	assert.True(t, (&Config{MaxEvents: 10}).Enabled())
+}
+
+func TestNewRetentionManagerMissingDeps(t *testing.T) {
+	_, err := NewRetentionManager(context.Background(), "ns1", nil, Config{})
+	assert.Regexp(t, "FF10128", err)
+}
+
+func TestNewRetentionManagerUnknownCollection(t *testing.T) {
+	_, err := NewRetentionManager(context.Background(), "ns1", &databasemocks.Plugin{}, Config{
+		Interval:    time.Hour,
+		BatchSize:   10,
+		Collections: []string{"events", "wrong"},
+	})
+	assert.Regexp(t, "FF10521.*wrong", err)
+}
+
+func TestNewRetentionManagerBadInterval(t *testing.T) {
+	_, err := NewRetentionManager(context.Background(), "ns1", &databasemocks.Plugin{}, Config{
+		BatchSize: 10,
+	})
+	assert.Regexp(t, "FF10549.*interval", err)
+}
+
+func TestNewRetentionManagerBadBatchSize(t *testing.T) {
+	_, err := NewRetentionManager(context.Background(), "ns1", &databasemocks.Plugin{}, Config{
+		Interval:  time.Hour,
+		BatchSize: -1,
+	})
+	assert.Regexp(t, "FF10549.*batchSize", err)
+}
+
+func TestNewRetentionManagerCollectionOrder(t *testing.T) {
+	rm, cleanup := newTestRetentionManager(t, Config{
+		Collections: []string{"data", "events", "messages"},
+	})
+	defer cleanup()
+	assert.Len(t, rm.collections, 3)
+	assert.Equal(t, "messages", rm.collections[0].name)
+	assert.Equal(t, "data", rm.collections[1].name)
+	assert.Equal(t, "events", rm.collections[2].name)
+}
+
+func TestRetentionLoopPurge(t *testing.T) {
+	rm, cleanup := newTestRetentionManager(t, Config{MaxAge: time.Hour})
+	defer cleanup()
+
+	done := make(chan struct{})
+	mdi := rm.database.(*databasemocks.Plugin)
+	mdi.On("PurgeMessages", mock.Anything, "ns1", filterContains("state IN")).Return(int64(1), nil).Once()
+	mdi.On("PurgeData", mock.Anything, "ns1", filterContains("created <")).Return(int64(2), nil).Once()
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return([]*core.Subscription{}, nil, nil).Once()
+	mdi.On("PurgeEvents", mock.Anything, "ns1", filterContains("created <")).Return(int64(3), nil).Once()
+	mdi.On("PurgeOperations", mock.Anything, "ns1", filterContains("status IN")).Return(int64(4), nil).Once()
+	mdi.On("PurgeBlockchainEvents", mock.Anything, "ns1", filterContains("timestamp <")).Return(int64(5), nil).Once()
+	mdi.On("PurgeTokenTransfers", mock.Anything, "ns1", filterContains("created <")).Return(int64(6), nil).Once().
+		Run(func(args mock.Arguments) { close(done) })
+
+	rm.Start()
+	<-done
+}
+
+func TestRetentionLoopError(t *testing.T) {
+	rm, cleanup := newTestRetentionManager(t, Config{MaxEvents: 10})
+	defer cleanup()
+
+	done := make(chan struct{})
+	mdi := rm.database.(*databasemocks.Plugin)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once().
+		Run(func(args mock.Arguments) { close(done) })
+
+	rm.Start()
+	<-done
+}
+
+func TestWaitStopNotStarted(t *testing.T) {
+	rm, cleanup := newTestRetentionManager(t, Config{})
+	defer cleanup()
+	rm.WaitStop()
+}
+
+func TestRunRetentionMaxEvents(t *testing.T) {
+	rm, cleanup := newTestRetentionManager(t, Config{MaxEvents: 10})
+	defer cleanup()
+
+	mdi := rm.database.(*databasemocks.Plugin)
+	mdi.On("GetEvents", mock.Anything, "ns1", filterContains("skip=10")).Return([]*core.Event{{Sequence: 90}}, nil, nil).Once()
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return([]*core.Subscription{}, nil, nil).Once()
+	mdi.On("PurgeEvents", mock.Anything, "ns1", filterContains("sequence <= 90")).Return(int64(90), nil).Once()
+
+	err := rm.runRetention(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRunRetentionSubscriptionOffsets(t *testing.T) {
+	rm, cleanup := newTestRetentionManager(t, Config{MaxEvents: 10})
+	defer cleanup()
+
+	sub1 := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID()}}
+	sub2 := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID()}}
+	firstEvent := core.SubOptsFirstEvent("75")
+	sub2.Options.FirstEvent = &firstEvent
+	sub3 := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID()}}
+	mdi := rm.database.(*databasemocks.Plugin)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{{Sequence: 90}}, nil, nil).Once()
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return([]*core.Subscription{sub1, sub2, sub3}, nil, nil).Once()
+	mdi.On("GetOffsets", mock.Anything, filterContains("name IN")).Return([]*core.Offset{
+		{Type: core.OffsetTypeSubscription, Name: sub1.ID.String(), Current: 80},
+		{Type: core.OffsetTypeSubscription, Name: sub3.ID.String(), Current: 85},
+	}, nil, nil).Once()
+	mdi.On("PurgeEvents", mock.Anything, "ns1", filterContains("sequence <= 75")).Return(int64(75), nil).Once()
+
+	err := rm.runRetention(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRunRetentionSubscriptionNeverDispatched(t *testing.T) {
+	rm, cleanup := newTestRetentionManager(t, Config{MaxEvents: 10})
+	defer cleanup()
+
+	sub1 := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID()}}
+	mdi := rm.database.(*databasemocks.Plugin)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{{Sequence: 90}}, nil, nil).Once()
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return([]*core.Subscription{sub1}, nil, nil).Once()
+	mdi.On("GetOffsets", mock.Anything, mock.Anything).Return([]*core.Offset{}, nil, nil).Once()
+	mdi.On("PurgeEvents", mock.Anything, "ns1", filterContains("sequence <= -1")).Return(int64(0), nil).Once()
+
+	err := rm.runRetention(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRunRetentionGetSubscriptionsFail(t *testing.T) {
+	rm, cleanup := newTestRetentionManager(t, Config{MaxEvents: 10})
+	defer cleanup()
+
+	mdi := rm.database.(*databasemocks.Plugin)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{{Sequence: 90}}, nil, nil).Once()
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+
+	err := rm.runRetention(context.Background())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestRunRetentionGetOffsetsFail(t *testing.T) {
+	rm, cleanup := newTestRetentionManager(t, Config{MaxEvents: 10})
+	defer cleanup()
+
+	mdi := rm.database.(*databasemocks.Plugin)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{{Sequence: 90}}, nil, nil).Once()
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return([]*core.Subscription{
+		{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID()}},
+	}, nil, nil).Once()
+	mdi.On("GetOffsets", mock.Anything, mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+
+	err := rm.runRetention(context.Background())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestRunRetentionMaxEventsNoEvents(t *testing.T) {
+	rm, cleanup := newTestRetentionManager(t, Config{MaxEvents: 10})
+	defer cleanup()
+
+	mdi := rm.database.(*databasemocks.Plugin)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil).Once()
+
+	err := rm.runRetention(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRunRetentionPurgeFail(t *testing.T) {
+	rm, cleanup := newTestRetentionManager(t, Config{MaxAge: time.Hour})
+	defer cleanup()
+
+	mdi := rm.database.(*databasemocks.Plugin)
+	mdi.On("PurgeMessages", mock.Anything, "ns1", mock.Anything).Return(int64(-1), fmt.Errorf("pop")).Once()
+
+	err := rm.runRetention(context.Background())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestRunRetentionArchive(t *testing.T) {
+	dir := t.TempDir()
+	rm, cleanup := newTestRetentionManager(t, Config{
+		MaxAge:      time.Hour,
+		ArchivePath: dir,
+		Collections: []string{"events"},
+	})
+	defer cleanup()
+
+	ev1 := &core.Event{ID: fftypes.NewUUID(), Sequence: 1}
+	ev2 := &core.Event{ID: fftypes.NewUUID(), Sequence: 2}
+	ev3 := &core.Event{ID: fftypes.NewUUID(), Sequence: 3}
+	mdi := rm.database.(*databasemocks.Plugin)
+	mockRunAsGroup(mdi)
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return([]*core.Subscription{}, nil, nil).Once()
+	mdi.On("GetEvents", mock.Anything, "ns1", filterContains("limit=2")).Return([]*core.Event{ev1, ev2}, nil, nil).Once()
+	mdi.On("PurgeEvents", mock.Anything, "ns1", filterContains("id IN")).Return(int64(2), nil).Once()
+	mdi.On("GetEvents", mock.Anything, "ns1", filterContains("limit=2")).Return([]*core.Event{ev3}, nil, nil).Once()
+	mdi.On("PurgeEvents", mock.Anything, "ns1", filterContains("id IN")).Return(int64(1), nil).Once()
+
+	err := rm.runRetention(context.Background())
+	assert.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "ns1", "events-*.ndjson"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	b, err := os.ReadFile(files[0])
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	assert.Len(t, lines, 3)
+	assert.Contains(t, lines[0], ev1.ID.String())
+	assert.Contains(t, lines[2], ev3.ID.String())
+}
+
+func TestRunRetentionArchiveRetained(t *testing.T) {
+	dir := t.TempDir()
+	rm, cleanup := newTestRetentionManager(t, Config{
+		MaxAge:      time.Hour,
+		ArchivePath: dir,
+		Collections: []string{"data"},
+	})
+	defer cleanup()
+
+	d1 := &core.Data{ID: fftypes.NewUUID()}
+	d2 := &core.Data{ID: fftypes.NewUUID()}
+	mdi := rm.database.(*databasemocks.Plugin)
+	mockRunAsGroup(mdi)
+	mdi.On("GetData", mock.Anything, "ns1", filterContains("limit=2")).Return(core.DataArray{d1, d2}, nil, nil).Once()
+	mdi.On("PurgeData", mock.Anything, "ns1", filterContains("id IN")).Return(int64(1), nil).Once()
+	mdi.On("GetData", mock.Anything, "ns1", filterContains("id IN")).Return(core.DataArray{d2}, nil, nil).Once()
+	mdi.On("GetData", mock.Anything, "ns1", filterContains("skip=1")).Return(core.DataArray{}, nil, nil).Once()
+
+	err := rm.runRetention(context.Background())
+	assert.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "ns1", "data-*.ndjson"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	b, err := os.ReadFile(files[0])
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), d1.ID.String())
+	assert.NotContains(t, string(b), d2.ID.String())
+}
+
+func TestRunRetentionArchiveNothingExpired(t *testing.T) {
+	dir := t.TempDir()
+	rm, cleanup := newTestRetentionManager(t, Config{
+		MaxAge:      time.Hour,
+		ArchivePath: dir,
+		Collections: []string{"operations"},
+	})
+	defer cleanup()
+
+	mdi := rm.database.(*databasemocks.Plugin)
+	mockRunAsGroup(mdi)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil).Once()
+
+	err := rm.runRetention(context.Background())
+	assert.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "ns1", "*"))
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestRunRetentionArchiveListFail(t *testing.T) {
+	rm, cleanup := newTestRetentionManager(t, Config{
+		MaxAge:      time.Hour,
+		ArchivePath: t.TempDir(),
+		Collections: []string{"blockchainevents"},
+	})
+	defer cleanup()
+
+	mdi := rm.database.(*databasemocks.Plugin)
+	mockRunAsGroup(mdi)
+	mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+
+	err := rm.runRetention(context.Background())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestRunRetentionArchivePurgeFail(t *testing.T) {
+	rm, cleanup := newTestRetentionManager(t, Config{
+		MaxAge:      time.Hour,
+		ArchivePath: t.TempDir(),
+		Collections: []string{"tokentransfers"},
+	})
+	defer cleanup()
+
+	mdi := rm.database.(*databasemocks.Plugin)
+	mockRunAsGroup(mdi)
+	mdi.On("GetTokenTransfers", mock.Anything, "ns1", mock.Anything).Return([]*core.TokenTransfer{{LocalID: fftypes.NewUUID()}}, nil, nil).Once()
+	mdi.On("PurgeTokenTransfers", mock.Anything, "ns1", filterContains("localid IN")).Return(int64(-1), fmt.Errorf("pop")).Once()
+
+	err := rm.runRetention(context.Background())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestRunRetentionArchiveRetainedListFail(t *testing.T) {
+	rm, cleanup := newTestRetentionManager(t, Config{
+		MaxAge:      time.Hour,
+		ArchivePath: t.TempDir(),
+		Collections: []string{"data"},
+	})
+	defer cleanup()
+
+	mdi := rm.database.(*databasemocks.Plugin)
+	mockRunAsGroup(mdi)
+	mdi.On("GetData", mock.Anything, "ns1", filterContains("limit=2")).Return(core.DataArray{{ID: fftypes.NewUUID()}}, nil, nil).Once()
+	mdi.On("PurgeData", mock.Anything, "ns1", mock.Anything).Return(int64(0), nil).Once()
+	mdi.On("GetData", mock.Anything, "ns1", filterContains("id IN")).Return(nil, nil, fmt.Errorf("pop")).Once()
+
+	err := rm.runRetention(context.Background())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestRunRetentionArchiveOpenFail(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "ns1"), []byte{}, 0644)
+	assert.NoError(t, err)
+	rm, cleanup := newTestRetentionManager(t, Config{
+		MaxAge:      time.Hour,
+		ArchivePath: dir,
+		Collections: []string{"messages"},
+	})
+	defer cleanup()
+
+	mdi := rm.database.(*databasemocks.Plugin)
+	mockRunAsGroup(mdi)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{{Header: core.MessageHeader{ID: fftypes.NewUUID()}}}, nil, nil).Once()
+	mdi.On("PurgeMessages", mock.Anything, "ns1", mock.Anything).Return(int64(1), nil).Once()
+
+	err = rm.runRetention(context.Background())
+	assert.Regexp(t, "FF10522", err)
+}
+
+func TestOpenArchiveFail(t *testing.T) {
+	dir := t.TempDir()
+	err := os.Mkdir(filepath.Join(dir, "events.ndjson"), 0755)
+	assert.NoError(t, err)
+	_, err = openArchive(context.Background(), filepath.Join(dir, "events.ndjson"))
+	assert.Regexp(t, "FF10522", err)
+}
+
+func TestWriteArchiveFail(t *testing.T) {
+	archive, err := openArchive(context.Background(), filepath.Join(t.TempDir(), "events.ndjson"))
+	assert.NoError(t, err)
+	archive.Close()
+	err = writeArchive(context.Background(), archive, []interface{}{&core.Event{}})
+	assert.Regexp(t, "FF10522", err)
+}
+
+func TestWriteArchiveSyncFail(t *testing.T) {
+	archive, err := openArchive(context.Background(), filepath.Join(t.TempDir(), "events.ndjson"))
+	assert.NoError(t, err)
+	archive.Close()
+	err = writeArchive(context.Background(), archive, []interface{}{})
+	assert.Regexp(t, "FF10522", err)
+}
+
+func TestArchiveCollectionWriteFail(t *testing.T) {
+	if _, err := os.Stat("/dev/full"); err != nil {
+		t.Skip("requires /dev/full")
+	}
+	dir := t.TempDir()
+	rm, cleanup := newTestRetentionManager(t, Config{
+		MaxAge:      time.Hour,
+		ArchivePath: dir,
+		Collections: []string{"events"},
+	})
+	defer cleanup()
+	err := os.Mkdir(filepath.Join(dir, "ns1"), 0755)
+	assert.NoError(t, err)
+	err = os.Symlink("/dev/full", filepath.Join(dir, "ns1", "events-test.ndjson"))
+	assert.NoError(t, err)
+
+	mdi := rm.database.(*databasemocks.Plugin)
+	mockRunAsGroup(mdi)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{{ID: fftypes.NewUUID()}}, nil, nil).Once()
+	mdi.On("PurgeEvents", mock.Anything, "ns1", mock.Anything).Return(int64(1), nil).Once()
+
+	cutoff := fftypes.Now()
+	err = rm.archiveCollection(context.Background(), rm.collections[0], &runState{runID: "test", cutoff: cutoff, maxEventSequence: -1})
+	assert.Regexp(t, "FF10522", err)
+}
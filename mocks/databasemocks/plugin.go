@@ -115,6 +115,42 @@ func (_m *Plugin) DeleteData(ctx context.Context, namespace string, id *fftypes.
 	return r0
 }
 
+// DeleteDeadLetterByID provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) DeleteDeadLetterByID(ctx context.Context, namespace string, id *fftypes.UUID) error {
+	ret := _m.Called(ctx, namespace, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDeadLetterByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r0 = rf(ctx, namespace, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteDeadLetters provides a mock function with given fields: ctx, namespace, subscriptionID
+func (_m *Plugin) DeleteDeadLetters(ctx context.Context, namespace string, subscriptionID *fftypes.UUID) error {
+	ret := _m.Called(ctx, namespace, subscriptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDeadLetters")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r0 = rf(ctx, namespace, subscriptionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteFFI provides a mock function with given fields: ctx, namespace, id
 func (_m *Plugin) DeleteFFI(ctx context.Context, namespace string, id *fftypes.UUID) error {
 	ret := _m.Called(ctx, namespace, id)
@@ -1051,6 +1087,75 @@ func (_m *Plugin) GetDatatypes(ctx context.Context, namespace string, filter ffa
 	return r0, r1, r2
 }
 
+// GetDeadLetterByID provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) GetDeadLetterByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.DeadLetter, error) {
+	ret := _m.Called(ctx, namespace, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeadLetterByID")
+	}
+
+	var r0 *core.DeadLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.DeadLetter, error)); ok {
+		return rf(ctx, namespace, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.DeadLetter); ok {
+		r0 = rf(ctx, namespace, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.DeadLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r1 = rf(ctx, namespace, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeadLetters provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetDeadLetters(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.DeadLetter, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeadLetters")
+	}
+
+	var r0 []*core.DeadLetter
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.DeadLetter, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.DeadLetter); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.DeadLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetEventByID provides a mock function with given fields: ctx, namespace, id
 func (_m *Plugin) GetEventByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.Event, error) {
 	ret := _m.Called(ctx, namespace, id)
@@ -3007,6 +3112,24 @@ func (_m *Plugin) InsertDataArray(ctx context.Context, data core.DataArray) erro
 	return r0
 }
 
+// InsertDeadLetter provides a mock function with given fields: ctx, deadLetter
+func (_m *Plugin) InsertDeadLetter(ctx context.Context, deadLetter *core.DeadLetter) error {
+	ret := _m.Called(ctx, deadLetter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertDeadLetter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.DeadLetter) error); ok {
+		r0 = rf(ctx, deadLetter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertEvent provides a mock function with given fields: ctx, data
 func (_m *Plugin) InsertEvent(ctx context.Context, data *core.Event) error {
 	ret := _m.Called(ctx, data)
@@ -3388,6 +3511,174 @@ func (_m *Plugin) Name() string {
 	return r0
 }
 
+// PurgeBlockchainEvents provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) PurgeBlockchainEvents(ctx context.Context, namespace string, filter ffapi.Filter) (int64, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeBlockchainEvents")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) (int64, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) int64); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) error); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeData provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) PurgeData(ctx context.Context, namespace string, filter ffapi.Filter) (int64, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeData")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) (int64, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) int64); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) error); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeEvents provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) PurgeEvents(ctx context.Context, namespace string, filter ffapi.Filter) (int64, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeEvents")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) (int64, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) int64); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) error); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeMessages provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) PurgeMessages(ctx context.Context, namespace string, filter ffapi.Filter) (int64, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeMessages")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) (int64, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) int64); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) error); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeOperations provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) PurgeOperations(ctx context.Context, namespace string, filter ffapi.Filter) (int64, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeOperations")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) (int64, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) int64); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) error); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeTokenTransfers provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) PurgeTokenTransfers(ctx context.Context, namespace string, filter ffapi.Filter) (int64, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTokenTransfers")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) (int64, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) int64); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) error); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ReplaceMessage provides a mock function with given fields: ctx, message
 func (_m *Plugin) ReplaceMessage(ctx context.Context, message *core.Message) error {
 	ret := _m.Called(ctx, message)
@@ -3424,6 +3715,36 @@ func (_m *Plugin) RunAsGroup(ctx context.Context, fn func(context.Context) error
 	return r0
 }
 
+// Search provides a mock function with given fields: ctx, namespace, text, limit
+func (_m *Plugin) Search(ctx context.Context, namespace string, text string, limit int) ([]*core.SearchResult, error) {
+	ret := _m.Called(ctx, namespace, text, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []*core.SearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) ([]*core.SearchResult, error)); ok {
+		return rf(ctx, namespace, text, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) []*core.SearchResult); ok {
+		r0 = rf(ctx, namespace, text, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.SearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, namespace, text, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetHandler provides a mock function with given fields: namespace, handler
 func (_m *Plugin) SetHandler(namespace string, handler database.Callbacks) {
 	_m.Called(namespace, handler)
@@ -3447,6 +3768,24 @@ func (_m *Plugin) UpdateBatch(ctx context.Context, namespace string, id *fftypes
 	return r0
 }
 
+// UpdateBlob provides a mock function with given fields: ctx, sequence, update
+func (_m *Plugin) UpdateBlob(ctx context.Context, sequence int64, update ffapi.Update) error {
+	ret := _m.Called(ctx, sequence, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBlob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, ffapi.Update) error); ok {
+		r0 = rf(ctx, sequence, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateContractListener provides a mock function with given fields: ctx, namespace, id, update
 func (_m *Plugin) UpdateContractListener(ctx context.Context, namespace string, id *fftypes.UUID, update ffapi.Update) error {
 	ret := _m.Called(ctx, namespace, id, update)
@@ -115,6 +115,34 @@ func (_m *Manager) DownloadBlob(ctx context.Context, dataID string) (*core.Blob,
 	return r0, r1, r2
 }
 
+// ExchangeBlobRef provides a mock function with given fields: ctx, blob
+func (_m *Manager) ExchangeBlobRef(ctx context.Context, blob *core.Blob) (string, error) {
+	ret := _m.Called(ctx, blob)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExchangeBlobRef")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Blob) (string, error)); ok {
+		return rf(ctx, blob)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Blob) string); ok {
+		r0 = rf(ctx, blob)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.Blob) error); ok {
+		r1 = rf(ctx, blob)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMessageDataCached provides a mock function with given fields: ctx, msg, options
 func (_m *Manager) GetMessageDataCached(ctx context.Context, msg *core.Message, options ...data.CacheReadOption) (core.DataArray, bool, error) {
 	_va := make([]interface{}, len(options))
@@ -242,6 +270,36 @@ func (_m *Manager) HydrateBatch(ctx context.Context, persistedBatch *core.BatchP
 	return r0, r1
 }
 
+// OpenBlob provides a mock function with given fields: ctx, payloadRef
+func (_m *Manager) OpenBlob(ctx context.Context, payloadRef string) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, payloadRef)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenBlob")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (io.ReadCloser, error)); ok {
+		return rf(ctx, payloadRef)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) io.ReadCloser); ok {
+		r0 = rf(ctx, payloadRef)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, payloadRef)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PeekMessageCache provides a mock function with given fields: ctx, id, options
 func (_m *Manager) PeekMessageCache(ctx context.Context, id *fftypes.UUID, options ...data.CacheReadOption) (*core.Message, core.DataArray) {
 	_va := make([]interface{}, len(options))
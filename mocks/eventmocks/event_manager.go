@@ -249,6 +249,26 @@ func (_m *EventManager) GetPlugins() []*core.NamespaceStatusPlugin {
 	return r0
 }
 
+// GetSubscriptionDeliveryStatus provides a mock function with given fields: id
+func (_m *EventManager) GetSubscriptionDeliveryStatus(id *fftypes.UUID) *core.SubscriptionStatus {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionDeliveryStatus")
+	}
+
+	var r0 *core.SubscriptionStatus
+	if rf, ok := ret.Get(0).(func(*fftypes.UUID) *core.SubscriptionStatus); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.SubscriptionStatus)
+		}
+	}
+
+	return r0
+}
+
 // NewEvents provides a mock function with given fields:
 func (_m *EventManager) NewEvents() chan<- int64 {
 	ret := _m.Called()
@@ -314,6 +334,24 @@ func (_m *EventManager) QueueBatchRewind(batchID *fftypes.UUID) {
 	_m.Called(batchID)
 }
 
+// ReplayDeadLetter provides a mock function with given fields: ctx, deadLetter
+func (_m *EventManager) ReplayDeadLetter(ctx context.Context, deadLetter *core.DeadLetter) error {
+	ret := _m.Called(ctx, deadLetter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayDeadLetter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.DeadLetter) error); ok {
+		r0 = rf(ctx, deadLetter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResolveTransportAndCapabilities provides a mock function with given fields: ctx, transportName
 func (_m *EventManager) ResolveTransportAndCapabilities(ctx context.Context, transportName string) (string, *pkgevents.Capabilities, error) {
 	ret := _m.Called(ctx, transportName)
@@ -351,6 +389,24 @@ func (_m *EventManager) ResolveTransportAndCapabilities(ctx context.Context, tra
 	return r0, r1, r2
 }
 
+// RewindDurableSubscription provides a mock function with given fields: ctx, subDef, offset
+func (_m *EventManager) RewindDurableSubscription(ctx context.Context, subDef *core.Subscription, offset int64) error {
+	ret := _m.Called(ctx, subDef, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RewindDurableSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Subscription, int64) error); ok {
+		r0 = rf(ctx, subDef, offset)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SharedStorageBatchDownloaded provides a mock function with given fields: ss, payloadRef, data
 func (_m *EventManager) SharedStorageBatchDownloaded(ss sharedstorage.Plugin, payloadRef string, data []byte) (*fftypes.UUID, error) {
 	ret := _m.Called(ss, payloadRef, data)
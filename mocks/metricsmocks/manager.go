@@ -109,6 +109,36 @@ func (_m *Manager) NodeIdentityDXCertMismatch(namespace string, mismatch metrics
 	_m.Called(namespace, mismatch)
 }
 
+// SubscriptionAcked provides a mock function with given fields: namespace, subscription
+func (_m *Manager) SubscriptionAcked(namespace string, subscription string) {
+	_m.Called(namespace, subscription)
+}
+
+// SubscriptionDelivered provides a mock function with given fields: namespace, subscription, count
+func (_m *Manager) SubscriptionDelivered(namespace string, subscription string, count int) {
+	_m.Called(namespace, subscription, count)
+}
+
+// SubscriptionInflight provides a mock function with given fields: namespace, subscription, inflight
+func (_m *Manager) SubscriptionInflight(namespace string, subscription string, inflight int) {
+	_m.Called(namespace, subscription, inflight)
+}
+
+// SubscriptionDeleted provides a mock function with given fields: namespace, subscription
+func (_m *Manager) SubscriptionDeleted(namespace string, subscription string) {
+	_m.Called(namespace, subscription)
+}
+
+// SubscriptionLag provides a mock function with given fields: namespace, subscription, lag
+func (_m *Manager) SubscriptionLag(namespace string, subscription string, lag int64) {
+	_m.Called(namespace, subscription, lag)
+}
+
+// SubscriptionRejected provides a mock function with given fields: namespace, subscription
+func (_m *Manager) SubscriptionRejected(namespace string, subscription string) {
+	_m.Called(namespace, subscription)
+}
+
 // TransferConfirmed provides a mock function with given fields: transfer
 func (_m *Manager) TransferConfirmed(transfer *core.TokenTransfer) {
 	_m.Called(transfer)
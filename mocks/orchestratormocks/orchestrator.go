@@ -260,6 +260,24 @@ func (_m *Orchestrator) DeleteSubscription(ctx context.Context, id string) error
 	return r0
 }
 
+// DeleteSubscriptionDeadLetter provides a mock function with given fields: ctx, subID, deadLetterID
+func (_m *Orchestrator) DeleteSubscriptionDeadLetter(ctx context.Context, subID string, deadLetterID string) error {
+	ret := _m.Called(ctx, subID, deadLetterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSubscriptionDeadLetter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, subID, deadLetterID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Events provides a mock function with given fields:
 func (_m *Orchestrator) Events() events.EventManager {
 	ret := _m.Called()
@@ -1377,6 +1395,45 @@ func (_m *Orchestrator) GetSubscriptionByIDWithStatus(ctx context.Context, id st
 	return r0, r1
 }
 
+// GetSubscriptionDeadLetters provides a mock function with given fields: ctx, subID, filter
+func (_m *Orchestrator) GetSubscriptionDeadLetters(ctx context.Context, subID string, filter ffapi.AndFilter) ([]*core.DeadLetter, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, subID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionDeadLetters")
+	}
+
+	var r0 []*core.DeadLetter
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) ([]*core.DeadLetter, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, subID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) []*core.DeadLetter); ok {
+		r0 = rf(ctx, subID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.DeadLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, subID, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, subID, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetSubscriptionEventsHistorical provides a mock function with given fields: ctx, subscription, filter, startSequence, endSequence
 func (_m *Orchestrator) GetSubscriptionEventsHistorical(ctx context.Context, subscription *core.Subscription, filter ffapi.AndFilter, startSequence int, endSequence int) ([]*core.EnrichedEvent, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, subscription, filter, startSequence, endSequence)
@@ -1455,6 +1512,45 @@ func (_m *Orchestrator) GetSubscriptions(ctx context.Context, filter ffapi.AndFi
 	return r0, r1, r2
 }
 
+// GetSubscriptionsWithStatus provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetSubscriptionsWithStatus(ctx context.Context, filter ffapi.AndFilter) ([]*core.SubscriptionWithStatus, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionsWithStatus")
+	}
+
+	var r0 []*core.SubscriptionWithStatus
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.SubscriptionWithStatus, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.SubscriptionWithStatus); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.SubscriptionWithStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetTransactionBlockchainEvents provides a mock function with given fields: ctx, id
 func (_m *Orchestrator) GetTransactionBlockchainEvents(ctx context.Context, id string) ([]*core.BlockchainEvent, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, id)
@@ -1730,6 +1826,36 @@ func (_m *Orchestrator) Operations() operations.Manager {
 	return r0
 }
 
+// PauseSubscription provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) PauseSubscription(ctx context.Context, id string) (*core.Subscription, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseSubscription")
+	}
+
+	var r0 *core.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Subscription, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Subscription); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PreInit provides a mock function with given fields: ctx, cancelCtx
 func (_m *Orchestrator) PreInit(ctx context.Context, cancelCtx context.CancelFunc) {
 	_m.Called(ctx, cancelCtx)
@@ -1755,6 +1881,24 @@ func (_m *Orchestrator) PrivateMessaging() privatemessaging.Manager {
 	return r0
 }
 
+// ReplaySubscriptionDeadLetter provides a mock function with given fields: ctx, subID, deadLetterID
+func (_m *Orchestrator) ReplaySubscriptionDeadLetter(ctx context.Context, subID string, deadLetterID string) error {
+	ret := _m.Called(ctx, subID, deadLetterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaySubscriptionDeadLetter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, subID, deadLetterID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RequestReply provides a mock function with given fields: ctx, msg
 func (_m *Orchestrator) RequestReply(ctx context.Context, msg *core.MessageInOut) (*core.MessageInOut, error) {
 	ret := _m.Called(ctx, msg)
@@ -1785,6 +1929,36 @@ func (_m *Orchestrator) RequestReply(ctx context.Context, msg *core.MessageInOut
 	return r0, r1
 }
 
+// ResumeSubscription provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) ResumeSubscription(ctx context.Context, id string) (*core.Subscription, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeSubscription")
+	}
+
+	var r0 *core.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Subscription, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Subscription); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RewindPins provides a mock function with given fields: ctx, rewind
 func (_m *Orchestrator) RewindPins(ctx context.Context, rewind *core.PinRewind) (*core.PinRewind, error) {
 	ret := _m.Called(ctx, rewind)
@@ -1815,6 +1989,66 @@ func (_m *Orchestrator) RewindPins(ctx context.Context, rewind *core.PinRewind)
 	return r0, r1
 }
 
+// RewindSubscription provides a mock function with given fields: ctx, id, rewind
+func (_m *Orchestrator) RewindSubscription(ctx context.Context, id string, rewind *core.SubscriptionRewind) (*core.Subscription, error) {
+	ret := _m.Called(ctx, id, rewind)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RewindSubscription")
+	}
+
+	var r0 *core.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.SubscriptionRewind) (*core.Subscription, error)); ok {
+		return rf(ctx, id, rewind)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.SubscriptionRewind) *core.Subscription); ok {
+		r0 = rf(ctx, id, rewind)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *core.SubscriptionRewind) error); ok {
+		r1 = rf(ctx, id, rewind)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: ctx, text, limit
+func (_m *Orchestrator) Search(ctx context.Context, text string, limit int) ([]*core.SearchResult, error) {
+	ret := _m.Called(ctx, text, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []*core.SearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]*core.SearchResult, error)); ok {
+		return rf(ctx, text, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*core.SearchResult); ok {
+		r0 = rf(ctx, text, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.SearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, text, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Start provides a mock function with given fields:
 func (_m *Orchestrator) Start() error {
 	ret := _m.Called()
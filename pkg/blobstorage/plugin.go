@@ -0,0 +1,62 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstorage
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// Plugin is the interface implemented by each Blob Storage plugin.
+//
+// Blob storage holds the blobs uploaded to the local node, separately from the data exchange.
+// The payload references returned by a plugin are in the format "<plugin name>://<location>",
+// so that they can be told apart from those returned by a data exchange.
+type Plugin interface {
+	core.Named
+
+	// InitConfig initializes the set of configuration options that are valid, with defaults. Called on all plugins.
+	InitConfig(config config.Section)
+
+	// Init initializes the plugin, with configuration
+	Init(ctx context.Context, config config.Section) error
+
+	// Capabilities returns capabilities - not called until after Init
+	Capabilities() *Capabilities
+
+	// UploadBlob streams a blob to storage, and returns the hash to confirm the hash calculated in Core matches the hash calculated in the plugin
+	UploadBlob(ctx context.Context, ns string, id fftypes.UUID, content io.Reader) (payloadRef string, hash *fftypes.Bytes32, size int64, err error)
+
+	// DownloadBlob streams a blob out of storage
+	DownloadBlob(ctx context.Context, payloadRef string) (content io.ReadCloser, err error)
+
+	// DeleteBlob deletes a blob from storage. Deleting a blob that does not exist is not an error
+	DeleteBlob(ctx context.Context, payloadRef string) (err error)
+}
+
+type Capabilities struct {
+}
+
+// IsPayloadRef returns true if the payload reference was returned by the given plugin
+func IsPayloadRef(plugin Plugin, payloadRef string) bool {
+	return strings.HasPrefix(payloadRef, plugin.Name()+"://")
+}
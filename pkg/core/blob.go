@@ -19,12 +19,13 @@ package core
 import "github.com/hyperledger/firefly-common/pkg/fftypes"
 
 type Blob struct {
-	Sequence   int64            `json:"-"`
-	Namespace  string           `json:"namespace"`
-	Hash       *fftypes.Bytes32 `json:"hash"`
-	PayloadRef string           `json:"payloadRef,omitempty"`
-	Created    *fftypes.FFTime  `json:"created,omitempty"`
-	Peer       string           `json:"peer,omitempty"`
-	Size       int64            `json:"size"`
-	DataID     *fftypes.UUID    `json:"data_id"`
+	Sequence    int64            `json:"-"`
+	Namespace   string           `json:"namespace"`
+	Hash        *fftypes.Bytes32 `json:"hash"`
+	PayloadRef  string           `json:"payloadRef,omitempty"`
+	Created     *fftypes.FFTime  `json:"created,omitempty"`
+	Peer        string           `json:"peer,omitempty"`
+	Size        int64            `json:"size"`
+	DataID      *fftypes.UUID    `json:"data_id"`
+	ExchangeRef string           `json:"exchangeRef,omitempty"`
 }
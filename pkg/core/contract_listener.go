@@ -45,7 +45,8 @@ type ContractListenerWithStatus struct {
 	Status interface{} `ffstruct:"ContractListenerWithStatus" json:"status,omitempty" ffexcludeinput:"true"`
 }
 type ContractListenerOptions struct {
-	FirstEvent string `ffstruct:"ContractListenerOptions" json:"firstEvent,omitempty"`
+	FirstEvent     string `ffstruct:"ContractListenerOptions" json:"firstEvent,omitempty"`
+	PrivacyGroupID string `ffstruct:"ContractListenerOptions" json:"privacyGroupId,omitempty"`
 }
 
 type ListenerStatusError struct {
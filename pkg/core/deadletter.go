@@ -0,0 +1,30 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// DeadLetter records an event that could not be delivered on a subscription, once the transport gave up retrying.
+// The offset of the subscription moves on past the event, and it can be replayed or discarded later.
+type DeadLetter struct {
+	ID           *fftypes.UUID   `ffstruct:"DeadLetter" json:"id"`
+	Namespace    string          `ffstruct:"DeadLetter" json:"namespace"`
+	Subscription *fftypes.UUID   `ffstruct:"DeadLetter" json:"subscription"`
+	Event        *fftypes.UUID   `ffstruct:"DeadLetter" json:"event"`
+	Reason       string          `ffstruct:"DeadLetter" json:"reason"`
+	Created      *fftypes.FFTime `ffstruct:"DeadLetter" json:"created"`
+}
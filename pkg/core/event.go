@@ -112,6 +112,7 @@ type EventDeliveryResponse struct {
 	Info         string          `json:"info,omitempty"`
 	Subscription SubscriptionRef `json:"subscription"`
 	Reply        *MessageInOut   `json:"reply,omitempty"`
+	DeadLetter   bool            `json:"-"` // set by transports that gave up delivering the event, to record it as a dead letter and move on
 }
 
 func NewEvent(t EventType, ns string, ref *fftypes.UUID, tx *fftypes.UUID, topic string) *Event {
@@ -0,0 +1,43 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+const (
+	// SearchDefaultLimit is the number of results returned when no limit is requested
+	SearchDefaultLimit = 25
+	// SearchMaxLimit is the maximum number of results that can be requested
+	SearchMaxLimit = 250
+)
+
+// SearchResultType is the type of resource referenced by a search result
+type SearchResultType = fftypes.FFEnum
+
+var (
+	// SearchResultTypeMessage matched on the tag, topics or author of a message
+	SearchResultTypeMessage = fftypes.FFEnumValue("searchresulttype", "message")
+	// SearchResultTypeData matched on the JSON value of a data item
+	SearchResultTypeData = fftypes.FFEnumValue("searchresulttype", "data")
+)
+
+// SearchResult is a reference to a message or data item that matched a full-text search
+type SearchResult struct {
+	Type SearchResultType `ffstruct:"SearchResult" json:"type" ffenum:"searchresulttype"`
+	ID   *fftypes.UUID    `ffstruct:"SearchResult" json:"id"`
+	Rank float64          `ffstruct:"SearchResult" json:"rank"`
+}
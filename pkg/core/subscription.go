@@ -32,6 +32,7 @@ type SubscriptionFilter struct {
 	Message          MessageFilter         `ffstruct:"SubscriptionFilter" json:"message,omitempty"`
 	Transaction      TransactionFilter     `ffstruct:"SubscriptionFilter" json:"transaction,omitempty"`
 	BlockchainEvent  BlockchainEventFilter `ffstruct:"SubscriptionFilter" json:"blockchainevent,omitempty"`
+	Expression       string                `ffstruct:"SubscriptionFilter" json:"expression,omitempty"`
 	Topic            string                `ffstruct:"SubscriptionFilter" json:"topic,omitempty"`
 	DeprecatedTopics string                `ffstruct:"SubscriptionFilter" json:"topics,omitempty"`
 	DeprecatedTag    string                `ffstruct:"SubscriptionFilter" json:"tag,omitempty"`
@@ -54,6 +55,7 @@ func NewSubscriptionFilterFromQuery(query url.Values) SubscriptionFilter {
 		Transaction: TransactionFilter{
 			Type: query.Get("filter.transaction.type"),
 		},
+		Expression:       query.Get("filter.expression"),
 		Topic:            query.Get("filter.topic"),
 		DeprecatedTag:    query.Get("filter.tag"),
 		DeprecatedTopics: query.Get("filter.topics"),
@@ -121,17 +123,31 @@ type Subscription struct {
 	Filter    SubscriptionFilter  `ffstruct:"Subscription" json:"filter"`
 	Options   SubscriptionOptions `ffstruct:"Subscription" json:"options"`
 	Ephemeral bool                `ffstruct:"Subscription" json:"ephemeral,omitempty" ffexcludeinput:"true"`
+	Paused    bool                `ffstruct:"Subscription" json:"paused,omitempty" ffexcludeinput:"true"`
 	Created   *fftypes.FFTime     `ffstruct:"Subscription" json:"created" ffexcludeinput:"true"`
 	Updated   *fftypes.FFTime     `ffstruct:"Subscription" json:"updated" ffexcludeinput:"true"`
 }
 
+// SubscriptionRewind is the position a durable subscription is rewound to, from which it redelivers events
+type SubscriptionRewind struct {
+	Sequence  *int64          `ffstruct:"SubscriptionRewind" json:"sequence,omitempty"`
+	Timestamp *fftypes.FFTime `ffstruct:"SubscriptionRewind" json:"timestamp,omitempty"`
+	Oldest    bool            `ffstruct:"SubscriptionRewind" json:"oldest,omitempty"`
+}
+
 type SubscriptionWithStatus struct {
 	Subscription
 	Status SubscriptionStatus `ffstruct:"SubscriptionWithStatus" json:"status,omitempty" ffexcludeinput:"true"`
 }
 
 type SubscriptionStatus struct {
-	CurrentOffset int64 `ffstruct:"SubscriptionStatus" json:"currentOffset,omitempty" ffexcludeinout:"true"`
+	CurrentOffset int64           `ffstruct:"SubscriptionStatus" json:"currentOffset,omitempty" ffexcludeinout:"true"`
+	Lag           int64           `ffstruct:"SubscriptionStatus" json:"lag,omitempty" ffexcludeinout:"true"`
+	Delivered     int64           `ffstruct:"SubscriptionStatus" json:"delivered,omitempty" ffexcludeinout:"true"`
+	Acked         int64           `ffstruct:"SubscriptionStatus" json:"acked,omitempty" ffexcludeinout:"true"`
+	Rejected      int64           `ffstruct:"SubscriptionStatus" json:"rejected,omitempty" ffexcludeinout:"true"`
+	Inflight      int64           `ffstruct:"SubscriptionStatus" json:"inflight,omitempty" ffexcludeinout:"true"`
+	LastDelivery  *fftypes.FFTime `ffstruct:"SubscriptionStatus" json:"lastDelivery,omitempty" ffexcludeinout:"true"`
 }
 
 func (so *SubscriptionOptions) UnmarshalJSON(b []byte) error {
@@ -168,6 +184,9 @@ func (so SubscriptionOptions) MarshalJSON() ([]byte, error) {
 	if so.TLSConfigName != "" {
 		so.additionalOptions["tlsConfigName"] = so.TLSConfigName
 	}
+	if so.SigningSecretName != "" {
+		so.additionalOptions["signingSecretName"] = so.SigningSecretName
+	}
 	if so.Batch != nil {
 		so.additionalOptions["batch"] = so.Batch
 	}
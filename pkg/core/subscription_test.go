@@ -39,7 +39,8 @@ func TestSubscriptionOptionsDatabaseSerialization(t *testing.T) {
 				BatchTimeout: &oneSec,
 			},
 			WebhookSubOptions: WebhookSubOptions{
-				TLSConfigName: "myconfig",
+				TLSConfigName:     "myconfig",
+				SigningSecretName: "mysecret",
 			},
 		},
 		Filter: SubscriptionFilter{},
@@ -60,6 +61,7 @@ func TestSubscriptionOptionsDatabaseSerialization(t *testing.T) {
 		},
 		"readAhead":50,
 		"tlsConfigName":"myconfig",
+		"signingSecretName":"mysecret",
 		"withData":true,
 		"batch":true,
 		"batchTimeout":"1s"
@@ -126,7 +128,7 @@ func TestSubscriptionUnMarshalFail(t *testing.T) {
 }
 
 func TestNewSubscriptionFilterFromQuery(t *testing.T) {
-	query, _ := url.ParseQuery("filter.events=message_confirmed&filter.topic=topic1&filter.message.author=did:firefly:org/author1&filter.blockchain.name=flapflip&filter.transaction.type=test&filter.group=deprecated")
+	query, _ := url.ParseQuery("filter.events=message_confirmed&filter.topic=topic1&filter.message.author=did:firefly:org/author1&filter.blockchain.name=flapflip&filter.transaction.type=test&filter.group=deprecated&filter.expression=$.topic==\"topic1\"")
 	expectedFilter := SubscriptionFilter{
 		Events: "message_confirmed",
 		Topic:  "topic1",
@@ -139,6 +141,7 @@ func TestNewSubscriptionFilterFromQuery(t *testing.T) {
 		Transaction: TransactionFilter{
 			Type: "test",
 		},
+		Expression:      "$.topic==\"topic1\"",
 		DeprecatedGroup: "deprecated",
 	}
 	filter := NewSubscriptionFilterFromQuery(query)
@@ -30,6 +30,8 @@ var (
 	VerifierTypeEthAddress = fftypes.FFEnumValue("verifiertype", "ethereum_address")
 	// VerifierTypeTezosAddress is a Tezos (ed25519) address string
 	VerifierTypeTezosAddress = fftypes.FFEnumValue("verifiertype", "tezos_address")
+	// VerifierTypeSolanaAddress is a Solana (ed25519) address string
+	VerifierTypeSolanaAddress = fftypes.FFEnumValue("verifiertype", "solana_address")
 	// VerifierTypeMSPIdentity is the MSP id (X509 distinguished name) of an issued signing certificate / keypair
 	VerifierTypeMSPIdentity = fftypes.FFEnumValue("verifiertype", "fabric_msp_id")
 	// VerifierTypeFFDXPeerID is the peer identifier that FireFly Data Exchange verifies (using plugin specific tech) when receiving data
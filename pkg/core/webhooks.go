@@ -18,26 +18,43 @@ package core
 
 import (
 	"crypto/tls"
+	"text/template"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// WebhookDeadLetterPolicy is what happens to an event when a webhook delivery fails, after any retries
+type WebhookDeadLetterPolicy = fftypes.FFEnum
+
+var (
+	// WebhookDeadLetterPolicyNone acknowledges the event and moves on, passing the error back as the reply if enabled. This is the default
+	WebhookDeadLetterPolicyNone = fftypes.FFEnumValue("webhookdeadletterpolicy", "none")
+	// WebhookDeadLetterPolicyDeadLetter records the event as a dead letter on the subscription, so it can be replayed or discarded later, and moves on
+	WebhookDeadLetterPolicyDeadLetter = fftypes.FFEnumValue("webhookdeadletterpolicy", "deadletter")
 )
 
 type WebhookSubOptions struct {
-	Fastack       bool                `ffstruct:"WebhookSubOptions" json:"fastack,omitempty"`
-	URL           string              `ffstruct:"WebhookSubOptions" json:"url,omitempty"`
-	Method        string              `ffstruct:"WebhookSubOptions" json:"method,omitempty"`
-	JSON          bool                `ffstruct:"WebhookSubOptions" json:"json,omitempty"`
-	Reply         bool                `ffstruct:"WebhookSubOptions" json:"reply,omitempty"`
-	ReplyTag      string              `ffstruct:"WebhookSubOptions" json:"replytag,omitempty"`
-	ReplyTX       string              `ffstruct:"WebhookSubOptions" json:"replytx,omitempty"`
-	Headers       map[string]string   `ffstruct:"WebhookSubOptions" json:"headers,omitempty"`
-	Query         map[string]string   `ffstruct:"WebhookSubOptions" json:"query,omitempty"`
-	TLSConfigName string              `ffstruct:"WebhookSubOptions" json:"tlsConfigName,omitempty"`
-	TLSConfig     *tls.Config         `ffstruct:"WebhookSubOptions" json:"-" ffexcludeinput:"true"`
-	Input         WebhookInputOptions `ffstruct:"WebhookSubOptions" json:"input,omitempty"`
-	Retry         WebhookRetryOptions `ffstruct:"WebhookSubOptions" json:"retry,omitempty"`
-	HTTPOptions   WebhookHTTPOptions  `ffstruct:"WebhookSubOptions" json:"httpOptions,omitempty"`
-	RestyClient   *resty.Client       `ffstruct:"WebhookSubOptions" json:"-" ffexcludeinput:"true"`
+	Fastack           bool                    `ffstruct:"WebhookSubOptions" json:"fastack,omitempty"`
+	URL               string                  `ffstruct:"WebhookSubOptions" json:"url,omitempty"`
+	Method            string                  `ffstruct:"WebhookSubOptions" json:"method,omitempty"`
+	JSON              bool                    `ffstruct:"WebhookSubOptions" json:"json,omitempty"`
+	Reply             bool                    `ffstruct:"WebhookSubOptions" json:"reply,omitempty"`
+	ReplyTag          string                  `ffstruct:"WebhookSubOptions" json:"replytag,omitempty"`
+	ReplyTX           string                  `ffstruct:"WebhookSubOptions" json:"replytx,omitempty"`
+	Headers           map[string]string       `ffstruct:"WebhookSubOptions" json:"headers,omitempty"`
+	Query             map[string]string       `ffstruct:"WebhookSubOptions" json:"query,omitempty"`
+	TLSConfigName     string                  `ffstruct:"WebhookSubOptions" json:"tlsConfigName,omitempty"`
+	TLSConfig         *tls.Config             `ffstruct:"WebhookSubOptions" json:"-" ffexcludeinput:"true"`
+	SigningSecretName string                  `ffstruct:"WebhookSubOptions" json:"signingSecretName,omitempty"`
+	SigningSecret     []byte                  `ffstruct:"WebhookSubOptions" json:"-" ffexcludeinput:"true"`
+	Input             WebhookInputOptions     `ffstruct:"WebhookSubOptions" json:"input,omitempty"`
+	Retry             WebhookRetryOptions     `ffstruct:"WebhookSubOptions" json:"retry,omitempty"`
+	HTTPOptions       WebhookHTTPOptions      `ffstruct:"WebhookSubOptions" json:"httpOptions,omitempty"`
+	DeadLetterPolicy  WebhookDeadLetterPolicy `ffstruct:"WebhookSubOptions" json:"deadLetterPolicy,omitempty" ffenum:"webhookdeadletterpolicy"`
+	Template          WebhookTemplateOptions  `ffstruct:"WebhookSubOptions" json:"template,omitempty"`
+	RestyClient       *resty.Client           `ffstruct:"WebhookSubOptions" json:"-" ffexcludeinput:"true"`
+	CompiledTemplate  *template.Template      `ffstruct:"WebhookSubOptions" json:"-" ffexcludeinput:"true"`
 }
 
 type WebhookRetryOptions struct {
@@ -64,3 +81,9 @@ type WebhookInputOptions struct {
 	Path    string `ffstruct:"WebhookInputOptions" json:"path,omitempty"`
 	ReplyTX string `ffstruct:"WebhookInputOptions" json:"replytx,omitempty"`
 }
+
+type WebhookTemplateOptions struct {
+	Body    string            `ffstruct:"WebhookTemplateOptions" json:"body,omitempty"`
+	Headers map[string]string `ffstruct:"WebhookTemplateOptions" json:"headers,omitempty"`
+	Path    string            `ffstruct:"WebhookTemplateOptions" json:"path,omitempty"`
+}
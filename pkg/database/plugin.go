@@ -111,6 +111,9 @@ type iMessageCollection interface {
 
 	// GetBatchIDsForDataAttachments - an optimized query to retrieve any non-null batch IDs for a list of data IDs that might be attached to messages in batches
 	GetBatchIDsForDataAttachments(ctx context.Context, namespace string, dataIDs []*fftypes.UUID) (batchIDs []*fftypes.UUID, err error)
+
+	// PurgeMessages - Bulk delete the messages matching the filter, along with their data references
+	PurgeMessages(ctx context.Context, namespace string, filter ffapi.Filter) (count int64, err error)
 }
 
 type iDataCollection interface {
@@ -140,6 +143,9 @@ type iDataCollection interface {
 
 	// DeleteData - Deletes a data record by ID
 	DeleteData(ctx context.Context, namespace string, id *fftypes.UUID) (err error)
+
+	// PurgeData - Bulk delete the data records matching the filter, that are no longer referenced by any message
+	PurgeData(ctx context.Context, namespace string, filter ffapi.Filter) (count int64, err error)
 }
 
 type iBatchCollection interface {
@@ -233,6 +239,9 @@ type iOperationCollection interface {
 
 	// GetOperations - Get operation
 	GetOperations(ctx context.Context, namespace string, filter ffapi.Filter) (operation []*core.Operation, res *ffapi.FilterResult, err error)
+
+	// PurgeOperations - Bulk delete the operations matching the filter
+	PurgeOperations(ctx context.Context, namespace string, filter ffapi.Filter) (count int64, err error)
 }
 
 type iSubscriptionCollection interface {
@@ -256,6 +265,23 @@ type iSubscriptionCollection interface {
 	DeleteSubscriptionByID(ctx context.Context, namespace string, id *fftypes.UUID) (err error)
 }
 
+type iDeadLetterCollection interface {
+	// InsertDeadLetter - insert an event that could not be delivered on a subscription
+	InsertDeadLetter(ctx context.Context, deadLetter *core.DeadLetter) (err error)
+
+	// GetDeadLetterByID - get a dead letter by ID
+	GetDeadLetterByID(ctx context.Context, namespace string, id *fftypes.UUID) (deadLetter *core.DeadLetter, err error)
+
+	// GetDeadLetters - get dead letters
+	GetDeadLetters(ctx context.Context, namespace string, filter ffapi.Filter) (deadLetters []*core.DeadLetter, res *ffapi.FilterResult, err error)
+
+	// DeleteDeadLetterByID - delete a dead letter
+	DeleteDeadLetterByID(ctx context.Context, namespace string, id *fftypes.UUID) (err error)
+
+	// DeleteDeadLetters - delete all the dead letters of a subscription
+	DeleteDeadLetters(ctx context.Context, namespace string, subscriptionID *fftypes.UUID) (err error)
+}
+
 type iEventCollection interface {
 	// InsertEvent - Insert an event. The order of the sequences added to the database, must match the order that
 	//               the rows/objects appear available to the event dispatcher. For a concurrency enabled database
@@ -271,6 +297,9 @@ type iEventCollection interface {
 
 	// GetEventsInSequenceRange - Get a range of events between 2 sequence values
 	GetEventsInSequenceRange(ctx context.Context, namespace string, filter ffapi.Filter, startSequence int, endSequence int) (message []*core.Event, res *ffapi.FilterResult, err error)
+
+	// PurgeEvents - Bulk delete the events matching the filter
+	PurgeEvents(ctx context.Context, namespace string, filter ffapi.Filter) (count int64, err error)
 }
 
 type iIdentitiesCollection interface {
@@ -356,6 +385,9 @@ type iBlobCollection interface {
 	// GetBlobs - get blobs
 	GetBlobs(ctx context.Context, namespace string, filter ffapi.Filter) (message []*core.Blob, res *ffapi.FilterResult, err error)
 
+	// UpdateBlob - update a blob, using its local database ID
+	UpdateBlob(ctx context.Context, sequence int64, update ffapi.Update) (err error)
+
 	// DeleteBlob - delete a blob, using its local database ID
 	DeleteBlob(ctx context.Context, sequence int64) (err error)
 }
@@ -420,6 +452,9 @@ type iTokenTransferCollection interface {
 
 	// DeleteTokenTransfers - Delete token transfers from a particular pool
 	DeleteTokenTransfers(ctx context.Context, namespace string, poolID *fftypes.UUID) error
+
+	// PurgeTokenTransfers - Bulk delete the token transfers matching the filter
+	PurgeTokenTransfers(ctx context.Context, namespace string, filter ffapi.Filter) (count int64, err error)
 }
 
 type iTokenApprovalCollection interface {
@@ -563,6 +598,9 @@ type iBlockchainEventCollection interface {
 
 	// GetBlockchainEvents - get blockchain events
 	GetBlockchainEvents(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.BlockchainEvent, *ffapi.FilterResult, error)
+
+	// PurgeBlockchainEvents - bulk delete the blockchain events matching the filter
+	PurgeBlockchainEvents(ctx context.Context, namespace string, filter ffapi.Filter) (count int64, err error)
 }
 
 // PersistenceInterface are the operations that must be implemented by a database interface plugin.
@@ -571,6 +609,11 @@ type iChartCollection interface {
 	GetChartHistogram(ctx context.Context, namespace string, intervals []core.ChartHistogramInterval, collection CollectionName) ([]*core.ChartHistogram, error)
 }
 
+type iSearchCollection interface {
+	// Search - full-text search over the headers of messages and the values of data, with the best matches first
+	Search(ctx context.Context, namespace string, text string, limit int) ([]*core.SearchResult, error)
+}
+
 // PeristenceInterface are the operations that must be implemented by a database interface plugin.
 // The database mechanism of Firefly is designed to provide the balance between being able
 // to query the data a member of the network has transferred/received via Firefly efficiently,
@@ -615,6 +658,7 @@ type PersistenceInterface interface {
 	iPinCollection
 	iOperationCollection
 	iSubscriptionCollection
+	iDeadLetterCollection
 	iEventCollection
 	iIdentitiesCollection
 	iVerifiersCollection
@@ -634,6 +678,7 @@ type PersistenceInterface interface {
 	iContractListenerCollection
 	iBlockchainEventCollection
 	iChartCollection
+	iSearchCollection
 }
 
 // CollectionName represents all collections
@@ -669,6 +714,7 @@ const (
 	CollectionDataTypes         UUIDCollectionNS = "datatypes"
 	CollectionOperations        UUIDCollectionNS = "operations"
 	CollectionSubscriptions     UUIDCollectionNS = "subscriptions"
+	CollectionDeadLetters       UUIDCollectionNS = "deadletters"
 	CollectionTransactions      UUIDCollectionNS = "transactions"
 	CollectionTokenPools        UUIDCollectionNS = "tokenpools"
 	CollectionTokenTransfers    UUIDCollectionNS = "tokentransfers"
@@ -739,6 +785,54 @@ type Callbacks interface {
 // Capabilities defines the capabilities a plugin can report as implementing or not
 type Capabilities struct {
 	Concurrency bool
+	// ChangeFeed is true when inserts of messages and events made by other processes sharing the database
+	// are delivered to the Callbacks, so pollers are woken without waiting for their next timed poll
+	ChangeFeed bool
+	// Search is true when the plugin maintains a full-text index of messages and data, for the Search operation
+	Search bool
+}
+
+type readReplicaContextKey struct{}
+
+// WithReadReplica marks a context as serving a read only API query, which the plugin can route to a read
+// replica of the database when one is configured. Queries made within a transaction always use the primary.
+func WithReadReplica(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readReplicaContextKey{}, true)
+}
+
+// IsReadReplica returns true if the context has been marked with WithReadReplica
+func IsReadReplica(ctx context.Context) bool {
+	readReplica, _ := ctx.Value(readReplicaContextKey{}).(bool)
+	return readReplica
+}
+
+// CursorPosition is the position in a query sorted by sequence, that the next page of results continues from
+type CursorPosition struct {
+	Sequence   int64 `json:"seq"`
+	Descending bool  `json:"desc,omitempty"`
+}
+
+// QueryCursor carries cursor based paging between an API query and the plugin. The API sets After to
+// continue from the end of a previous page, and the plugin sets Next to the position of the last record
+// it returns. Cursors are only supported when the results are sorted by sequence alone, and only the
+// first query made with the context uses the cursor.
+type QueryCursor struct {
+	After *CursorPosition
+	Next  *CursorPosition
+	Used  bool
+}
+
+type queryCursorContextKey struct{}
+
+// WithQueryCursor attaches a query cursor to a context
+func WithQueryCursor(ctx context.Context, qc *QueryCursor) context.Context {
+	return context.WithValue(ctx, queryCursorContextKey{}, qc)
+}
+
+// GetQueryCursor returns the query cursor attached to a context, or nil
+func GetQueryCursor(ctx context.Context) *QueryCursor {
+	qc, _ := ctx.Value(queryCursorContextKey{}).(*QueryCursor)
+	return qc
 }
 
 // MessageQueryFactory filter fields for messages
@@ -849,7 +943,18 @@ var SubscriptionQueryFactory = &ffapi.QueryFields{
 	"events":    &ffapi.StringField{},
 	"filters":   &ffapi.JSONField{},
 	"options":   &ffapi.StringField{},
+	"paused":    &ffapi.BoolField{},
 	"created":   &ffapi.TimeField{},
+	"updated":   &ffapi.TimeField{},
+}
+
+// DeadLetterQueryFactory filter fields for dead letters
+var DeadLetterQueryFactory = &ffapi.QueryFields{
+	"id":           &ffapi.UUIDField{},
+	"subscription": &ffapi.UUIDField{},
+	"event":        &ffapi.UUIDField{},
+	"reason":       &ffapi.StringField{},
+	"created":      &ffapi.TimeField{},
 }
 
 // EventQueryFactory filter fields for data events
@@ -925,11 +1030,12 @@ var NextPinQueryFactory = &ffapi.QueryFields{
 
 // BlobQueryFactory filter fields for config records
 var BlobQueryFactory = &ffapi.QueryFields{
-	"hash":       &ffapi.Bytes32Field{},
-	"size":       &ffapi.Int64Field{},
-	"payloadref": &ffapi.StringField{},
-	"created":    &ffapi.TimeField{},
-	"data_id":    &ffapi.UUIDField{},
+	"hash":        &ffapi.Bytes32Field{},
+	"size":        &ffapi.Int64Field{},
+	"payloadref":  &ffapi.StringField{},
+	"created":     &ffapi.TimeField{},
+	"data_id":     &ffapi.UUIDField{},
+	"exchangeref": &ffapi.StringField{},
 }
 
 // TokenPoolQueryFactory filter fields for token pools
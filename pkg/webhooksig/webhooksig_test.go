@@ -0,0 +1,152 @@
+// Copyright © 2026 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooksig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type errorReader struct{}
+
+func (r *errorReader) Read(p []byte) (int, error) {
+	return 0, fmt.Errorf("pop")
+}
+
+func TestSignVerifyOk(t *testing.T) {
+	header := http.Header{}
+	body := []byte(`{"some":"data"}`)
+	SetHeaders(header, []byte("shhh"), time.Now(), "delivery1", body)
+	assert.Regexp(t, "^v1=[0-9a-f]{64}$", header.Get(HeaderSignature))
+	assert.Equal(t, "delivery1", header.Get(HeaderDeliveryID))
+
+	err := Verify(context.Background(), []byte("shhh"), header, body, 5*time.Minute)
+	assert.NoError(t, err)
+}
+
+func TestSignKnownValue(t *testing.T) {
+	// Receivers in other languages can check their implementation against this
+	assert.Equal(t,
+		"v1=d676d68cf2301d7ef63fff495b6bea8bca75e03a0d850ea1847dbac0c8b2212d",
+		Sign([]byte("shhh"), 1700000000, "delivery1", []byte(`{}`)),
+	)
+}
+
+func TestVerifyMultipleSignatures(t *testing.T) {
+	header := http.Header{}
+	body := []byte(`{}`)
+	SetHeaders(header, []byte("shhh"), time.Now(), "delivery1", body)
+	header.Set(HeaderSignature, "v1=0000, "+header.Get(HeaderSignature))
+
+	err := Verify(context.Background(), []byte("shhh"), header, body, 5*time.Minute)
+	assert.NoError(t, err)
+}
+
+func TestVerifyWrongSecret(t *testing.T) {
+	header := http.Header{}
+	body := []byte(`{}`)
+	SetHeaders(header, []byte("shhh"), time.Now(), "delivery1", body)
+
+	err := Verify(context.Background(), []byte("wrong"), header, body, 5*time.Minute)
+	assert.Regexp(t, "FF10503", err)
+}
+
+func TestVerifyTamperedBody(t *testing.T) {
+	header := http.Header{}
+	SetHeaders(header, []byte("shhh"), time.Now(), "delivery1", []byte(`{"amount":1}`))
+
+	err := Verify(context.Background(), []byte("shhh"), header, []byte(`{"amount":100}`), 5*time.Minute)
+	assert.Regexp(t, "FF10503", err)
+}
+
+func TestVerifyTamperedDeliveryID(t *testing.T) {
+	header := http.Header{}
+	body := []byte(`{}`)
+	SetHeaders(header, []byte("shhh"), time.Now(), "delivery1", body)
+	header.Set(HeaderDeliveryID, "delivery2")
+
+	err := Verify(context.Background(), []byte("shhh"), header, body, 5*time.Minute)
+	assert.Regexp(t, "FF10503", err)
+}
+
+func TestVerifyMissingHeader(t *testing.T) {
+	header := http.Header{}
+	body := []byte(`{}`)
+	SetHeaders(header, []byte("shhh"), time.Now(), "delivery1", body)
+	header.Del(HeaderDeliveryID)
+
+	err := Verify(context.Background(), []byte("shhh"), header, body, 5*time.Minute)
+	assert.Regexp(t, "FF10502.*X-FireFly-Delivery-Id", err)
+}
+
+func TestVerifyBadTimestamp(t *testing.T) {
+	header := http.Header{}
+	body := []byte(`{}`)
+	SetHeaders(header, []byte("shhh"), time.Now(), "delivery1", body)
+	header.Set(HeaderTimestamp, "not a number")
+
+	err := Verify(context.Background(), []byte("shhh"), header, body, 5*time.Minute)
+	assert.Regexp(t, "FF10504", err)
+}
+
+func TestVerifyReplayedOutsideTolerance(t *testing.T) {
+	header := http.Header{}
+	body := []byte(`{}`)
+	SetHeaders(header, []byte("shhh"), time.Now().Add(-10*time.Minute), "delivery1", body)
+
+	err := Verify(context.Background(), []byte("shhh"), header, body, 5*time.Minute)
+	assert.Regexp(t, "FF10504", err)
+
+	SetHeaders(header, []byte("shhh"), time.Now().Add(10*time.Minute), "delivery1", body)
+	err = Verify(context.Background(), []byte("shhh"), header, body, 5*time.Minute)
+	assert.Regexp(t, "FF10504", err)
+}
+
+func TestVerifyRequest(t *testing.T) {
+	body := []byte(`{"some":"data"}`)
+	timestamp := time.Now().Unix()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderDeliveryID, "delivery1")
+	req.Header.Set(HeaderSignature, Sign([]byte("shhh"), timestamp, "delivery1", body))
+
+	received, err := VerifyRequest(context.Background(), []byte("shhh"), req, 5*time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, body, received)
+}
+
+func TestVerifyRequestFail(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{}`)))
+
+	_, err := VerifyRequest(context.Background(), []byte("shhh"), req, 5*time.Minute)
+	assert.Regexp(t, "FF10502", err)
+}
+
+func TestVerifyRequestReadFail(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", &errorReader{})
+
+	_, err := VerifyRequest(context.Background(), []byte("shhh"), req, 5*time.Minute)
+	assert.Regexp(t, "pop", err)
+}